@@ -11,12 +11,16 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/config"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 )
 
 func main() {
 	// Парсим флаги
 	up := flag.Bool("up", false, "Apply all migrations")
 	down := flag.Bool("down", false, "Rollback all migrations")
+	configFile := flag.String("config", os.Getenv(config.FileEnv), "YAML config file; environment variables take precedence")
 	flag.Parse()
 
 	if !*up && !*down {
@@ -24,23 +28,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Получаем параметры подключения из переменных окружения
-	var connStr string
-	if value := os.Getenv("DB_URL"); value != "" {
-		connStr = value
-	} else {
-		dbHost := getEnvOrDefault("DB_HOST", "localhost")
-		dbPort := getEnvOrDefault("DB_PORT", "5432")
-		dbUser := getEnvOrDefault("DB_USER", "postgres")
-		dbPassword := getEnvOrDefault("DB_PASSWORD", "postgres")
-		dbName := getEnvOrDefault("DB_NAME", "yourdb")
-
-		connStr = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-			dbUser, dbPassword, dbHost, dbPort, dbName)
+	// Получаем параметры подключения из переменных окружения и файла конфигурации
+	dbConfig, err := config.LoadDatabase(*configFile)
+	if err != nil {
+		log.Fatalf("Invalid database configuration:\n%v", err)
+	}
+	if database.Dialect(dbConfig.Driver) != database.Postgres {
+		log.Fatalf("Migrations support only PostgreSQL, DB_DRIVER is %q", dbConfig.Driver)
 	}
 
 	// Подключаемся к базе данных
-	db, err := sql.Open("postgres", connStr)
+	db, err := sql.Open("postgres", dbConfig.DSN())
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -73,10 +71,3 @@ func main() {
 		log.Println("Migrations rolled back successfully")
 	}
 }
-
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
@@ -2,47 +2,101 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Часовые пояса тихих часов уведомлений, даже если в образе нет zoneinfo
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"google.golang.org/api/option"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/broker"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/config"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	adminhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/admin"
+	announcementhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/announcement"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/auth"
+	bothandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/bot"
+	campaignhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/campaign"
+	cataloghandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/catalog"
+	classhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/class"
+	consenthandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/consent"
+	exporthandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/export"
+	feedhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/feed"
+	keyshandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/keys"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/media"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/messaging"
+	onboardinghandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/onboarding"
+	partnerhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/partner"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/profile"
+	referralhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/referral"
+	reminderhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/reminder"
+	reporthandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/report"
+	suspensionhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/suspension"
+	teamhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/team"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/health"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/linkpreview"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/logging"
+	adminrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/admin"
+	announcementrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/announcement"
+	botrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/bot"
+	campaignrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/campaign"
+	catalogrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/catalog"
+	classrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/class"
+	consentrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/consent"
+	exportrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/export"
+	feedrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/feed"
+	keysrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/keys"
 	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
 	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	onboardingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/onboarding"
+	partnerrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/partner"
 	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+	referralrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/referral"
+	reminderrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/reminder"
+	reportrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/report"
+	searchindexrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/searchindex"
+	suspensionrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/suspension"
+	teamrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/team"
 	userrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/user"
 
+	adminservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/admin"
+	announcementservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/announcement"
 	authservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	botservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/bot"
+	campaignservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/campaign"
+	catalogservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/catalog"
+	classservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/class"
+	consentservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/consent"
+	exportservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/export"
+	feedservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+	keysservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/keys"
 	mediaservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/media"
 	messagingservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+	onboardingservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/onboarding"
+	partnerservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/partner"
 	profileservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+	referralservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/referral"
+	reminderservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/reminder"
+	reportservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/report"
+	searchindexservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/searchindex"
+	suspensionservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
+	teamservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
 
 	mediastorage "github.com/bulatminnakhmetov/brigadka-backend/internal/storage/media"
 
 	pushhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/push"
 	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
 	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
-
-	firebase "firebase.google.com/go/v4"
 )
 
 // @title           Brigadka API
@@ -71,6 +125,44 @@ type HealthResponse struct {
 	Timestamp string `json:"timestamp"`
 }
 
+// HealthDetailsResponse представляет ответ от расширенного health endpoint
+type HealthDetailsResponse struct {
+	Status      string                 `json:"status"`
+	Version     string                 `json:"version"`
+	Timestamp   string                 `json:"timestamp"`
+	Environment string                 `json:"environment"`
+	Uptime      string                 `json:"uptime"`
+	Build       health.BuildInfo       `json:"build"`
+	Database    DatabaseHealth         `json:"database"`
+	Features    map[string]bool        `json:"features"`
+	WebSocket   WebSocketHealth        `json:"websocket"`
+	Workers     []health.WorkerStatus  `json:"workers"`
+	PushQueue   pushservice.QueueStats `json:"push_queue"` // Счетчики очереди push-уведомлений с момента запуска
+}
+
+// DatabaseHealth описывает состояние базы данных и версию схемы
+type DatabaseHealth struct {
+	Status    string                  `json:"status"`
+	Driver    string                  `json:"driver"`
+	Host      string                  `json:"host,omitempty"`
+	Name      string                  `json:"name"`
+	Migration *health.MigrationStatus `json:"migration,omitempty"` // Отсутствует, если миграции не применялись
+}
+
+// WebSocketHealth описывает WS-подключения: активные и счетчики с момента запуска
+type WebSocketHealth struct {
+	ActiveConnections  int   `json:"active_connections"`
+	OpenedConnections  int64 `json:"opened_connections"`
+	ClosedConnections  int64 `json:"closed_connections"`
+	EvictedConnections int64 `json:"evicted_connections"` // Закрыты, потому что клиент перестал отвечать на ping
+}
+
+// TimeResponse представляет ответ от endpoint серверного времени
+type TimeResponse struct {
+	ServerTime time.Time `json:"server_time"` // RFC 3339 с наносекундами, UTC
+	UnixMilli  int64     `json:"unix_ms"`
+}
+
 // Объявление startTime в глобальной области видимости
 var startTime time.Time
 
@@ -110,103 +202,493 @@ func healthHandler(w http.ResponseWriter, r *http.Request, db *sql.DB, appVersio
 	json.NewEncoder(w).Encode(response)
 }
 
+// webSocketHealth переводит счетчики WS-подключений в формат ответа /health/details
+func webSocketHealth(stats messaging.ConnectionStats) WebSocketHealth {
+	return WebSocketHealth{
+		ActiveConnections:  stats.Active,
+		OpenedConnections:  stats.Opened,
+		ClosedConnections:  stats.Closed,
+		EvictedConnections: stats.Evicted,
+	}
+}
+
+// @Summary      Подробное состояние сервиса
+// @Description  Возвращает сведения о сборке, версию схемы БД, включенные функции, число WS-подключений, состояние фоновых задач и счетчики очереди push-уведомлений
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  HealthDetailsResponse
+// @Failure      503  {object}  HealthDetailsResponse
+// @Router       /health/details [get]
+func healthDetailsHandler(w http.ResponseWriter, r *http.Request, db *sql.DB, cfg *config.Config,
+	features map[string]bool, messagingHandler *messaging.Handler, workers *health.Workers, pushQueue *pushservice.Queue) {
+	now := time.Now()
+	details := HealthDetailsResponse{
+		Status:      "healthy",
+		Version:     cfg.Version,
+		Timestamp:   now.Format(time.RFC3339),
+		Environment: cfg.Environment,
+		Uptime:      time.Since(startTime).String(),
+		Build:       health.Build(),
+		Database: DatabaseHealth{
+			Status: "connected",
+			Driver: cfg.Database.Driver,
+			Host:   cfg.Database.Host,
+			Name:   cfg.Database.DBName,
+		},
+		Features:  features,
+		WebSocket: webSocketHealth(messagingHandler.ConnectionStats()),
+		Workers:   workers.Statuses(now),
+		PushQueue: pushQueue.Stats(),
+	}
+
+	status := http.StatusOK
+
+	// Проверка соединения с базой данных
+	if err := db.PingContext(r.Context()); err != nil {
+		details.Status = "error"
+		details.Database.Status = "error"
+		status = http.StatusServiceUnavailable
+	} else if migration, err := health.GetMigrationStatus(r.Context(), db); err != nil {
+		log.Printf("Failed to read migration version: %v", err)
+	} else {
+		details.Database.Migration = migration
+		if migration != nil && migration.Dirty {
+			details.Status = "degraded"
+		}
+	}
+
+	// Зависшая фоновая задача не делает сервис недоступным, но требует внимания
+	for _, worker := range details.Workers {
+		if !worker.Healthy && details.Status == "healthy" {
+			details.Status = "degraded"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(details)
+}
+
+// @Summary      Серверное время
+// @Description  Возвращает текущее время сервера с высокой точностью, чтобы клиенты могли учесть расхождение часов
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  TimeResponse
+// @Router       /api/time [get]
+func timeHandler(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(TimeResponse{
+		ServerTime: now,
+		UnixMilli:  now.UnixMilli(),
+	})
+}
+
 func main() {
 	_ = godotenv.Load()
-	// Загрузка конфигурации из переменных окружения
-	dbConfig := &database.Config{
-		Host:     getEnv("DB_HOST", nil),
-		Port:     getEnvAsInt("DB_PORT", 5432),
-		User:     getEnv("DB_USER", nil),
-		Password: getEnv("DB_PASSWORD", nil),
-		DBName:   getEnv("DB_NAME", nil),
-		SSLMode:  getEnv("DB_SSL_MODE", ptr("disable")),
+	// Загрузка конфигурации из переменных окружения и файла CONFIG_FILE; все ошибки выводятся сразу
+	cfg, err := config.Load(os.Getenv(config.FileEnv))
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
 	}
-
-	jwtSecret := getEnv("JWT_SECRET", nil)
-	serverPort := getEnv("SERVER_PORT", ptr("8080"))
-	appVersion := getEnv("APP_VERSION", ptr("dev"))
+	log.Printf("Configuration:\n%s", cfg.Summary())
 
 	// Подключение к базе данных
-	db, err := database.NewConnection(dbConfig)
+	db, err := database.NewConnection(&cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	// Инициализация S3-совместимого хранилища для Backblaze B2
-	s3Storage, err := mediastorage.NewS3StorageProvider(
-		getEnv("B2_ACCESS_KEY_ID", nil),
-		getEnv("B2_SECRET_ACCESS_KEY", nil),
-		getEnv("B2_ENDPOINT", nil), // Выберите нужный регион
-		getEnv("B2_BUCKET_NAME", nil),
-		getEnv("CLOUDFLARE_CDN_DOMAIN", nil),
-		"media", // Путь для загрузки в бакете
-		getEnv("B2_PUBLIC_ENDPOINT", ptr("")),
-	)
+	// Хранилище медиа выбирает STORAGE_PROVIDER: s3 (Backblaze B2, MinIO и другие S3-совместимые),
+	// gcs или local — каталог на диске для разработки и тестов, файлы раздаются по /files
+	var mediaStorage mediaservice.StorageProvider
+	var localStorage *mediastorage.LocalStorageProvider
+	switch cfg.Storage.Provider {
+	case "s3", "b2", "minio":
+		mediaStorage, err = mediastorage.NewS3StorageProvider(
+			cfg.Storage.S3AccessKeyID,
+			cfg.Storage.S3SecretAccessKey,
+			cfg.Storage.S3Endpoint,
+			cfg.Storage.S3Bucket,
+			cfg.Storage.CDNDomain,
+			"media", // Путь для загрузки в бакете
+			cfg.Storage.S3PublicEndpoint,
+		)
+	case "gcs":
+		mediaStorage, err = mediastorage.NewGCSStorageProvider(
+			cfg.Storage.GCSAccessID,
+			cfg.Storage.GCSSecret,
+			cfg.Storage.GCSBucket,
+			cfg.Storage.CDNDomain,
+			"media",
+		)
+	case "local":
+		localStorage, err = mediastorage.NewLocalStorageProvider(cfg.Storage.LocalDir, cfg.Storage.LocalBaseURL, "media")
+		mediaStorage = localStorage
+	}
 	if err != nil {
-		log.Fatalf("Failed to initialize S3 storage: %v", err)
+		log.Fatalf("Failed to initialize %s storage: %v", cfg.Storage.Provider, err)
 	}
 
 	mediaRepo := mediarepo.NewRepository(db)
 
+	// Подписки и лента активности (события пишут сервисы медиа, профилей и команд)
+	feedRepo := feedrepo.NewPostgresRepository(db)
+	feedService := feedservice.NewFeedService(feedRepo)
+	feedHandler := feedhandler.NewHandler(feedService)
+
 	// Инициализация сервиса медиа
-	mediaService := mediaservice.NewMediaService(mediaRepo, s3Storage)
+	mediaService := mediaservice.NewMediaService(mediaRepo, mediaStorage)
+	mediaService.SetActivityRecorder(feedService)
+	mediaService.SetLimits(cfg.Media.Limits)
+
+	// Включенные функции, которые показывает /health/details
+	features := map[string]bool{}
+
+	// Длительность видео и аудио измеряет ffprobe; без него читается заголовок MP4
+	features["ffprobe"] = false
+	if ffprobe, err := mediaservice.NewFFProbe(cfg.Media.FFprobePath); err != nil {
+		log.Printf("ffprobe not found, media durations are read from MP4 headers: %v", err)
+	} else {
+		features["ffprobe"] = true
+		mediaService.SetDurationProber(ffprobe)
+	}
+
+	// Автоматическая модерация изображений: загрузки выше порога ждут ручной проверки
+	features["nsfw_moderation"] = false
+	if cfg.Media.NSFWProvider != "" {
+		features["nsfw_moderation"] = true
+		classifier := mediaservice.NewHTTPClassifier(cfg.Media.NSFWProvider, cfg.Media.NSFWEndpoint, cfg.Media.NSFWAPIKey)
+		mediaService.SetNSFWClassifier(classifier, cfg.Media.NSFWThreshold)
+	}
 
 	// Инициализация репозитория и хендлера авторизации
 	userRepo := userrepo.NewPostgresUserRepository(db)
-	authService := authservice.NewAuthService(userRepo, jwtSecret)
+	authService := authservice.NewAuthService(userRepo, cfg.JWTSecret)
+
+	passwordPolicy := authservice.DefaultPasswordPolicy()
+	passwordPolicy.MinLength = cfg.Password.MinLength
+	passwordPolicy.RequireSymbol = cfg.Password.RequireSymbol
+	features["password_breach_check"] = cfg.Password.BreachCheck
+	if features["password_breach_check"] {
+		passwordPolicy.BreachChecker = authservice.NewPwnedPasswordsChecker(cfg.Password.BreachAPIURL)
+	}
+	authService.SetPasswordPolicy(passwordPolicy)
+
 	authHandler := auth.NewAuthHandler(authService)
 
+	// Версии пользовательского соглашения и политики конфиденциальности (пустая версия не требует согласия)
+	consentRepo := consentrepo.NewPostgresRepository(db)
+	consentService := consentservice.NewConsentService(consentRepo, consentservice.Versions{
+		TermsOfService: cfg.Consent.TermsOfServiceVersion,
+		PrivacyPolicy:  cfg.Consent.PrivacyPolicyVersion,
+	})
+	consentHandler := consenthandler.NewHandler(consentService)
+
+	// Реферальные коды: регистрация по коду и статистика приглашенных
+	referralRepo := referralrepo.NewPostgresRepository(db)
+	referralService := referralservice.NewReferralService(referralRepo)
+	referralHandler := referralhandler.NewHandler(referralService)
+	authHandler.SetReferralTracker(referralService)
+
+	// Инициализация сервиса и хендлера асинхронных выгрузок
+	exportRepo := exportrepo.NewPostgresRepository(db)
+	exportService := exportservice.NewExportService(exportRepo)
+	exportHandler := exporthandler.NewHandler(exportService)
+
 	// Инициализация сервиса и хендлера профилей
 	profileRepo := profilerepo.NewPostgresRepository(db)
 	profileService := profileservice.NewProfileService(profileRepo, mediaRepo)
-	profileHandler := profile.NewProfileHandler(profileService)
+	profileService.SetActivityRecorder(feedService)
+	profileHandler := profile.NewProfileHandler(profileService, exportService)
+	exportService.RegisterGenerator(profileservice.SearchExportKind, profileService.GenerateSearchExport)
+
+	// Инициализация сборки справочников для офлайн-режима приложения
+	catalogRepo := catalogrepo.NewPostgresRepository(db)
+	catalogService := catalogservice.NewCatalogService(profileService, catalogRepo)
+	catalogService.SetMediaLimits(cfg.Media.Limits)
+	catalogHandler := cataloghandler.NewHandler(catalogService)
+
+	// Инициализация сервиса и хендлера анкеты онбординга
+	onboardingRepo := onboardingrepo.NewPostgresRepository(db)
+	onboardingService := onboardingservice.NewOnboardingService(onboardingRepo, profileRepo)
+	onboardingHandler := onboardinghandler.NewHandler(onboardingService)
+	profileService.SetSearchRecorder(onboardingService)
 
 	// Инициализация хендлера медиа
 	mediaHandler := media.NewMediaHandler(mediaService)
 
-	// Load APNS private key
-	apnsPrivateKey := []byte{}
-	apnsPrivateKeySource := getEnv("APNS_PRIVATE_KEY", ptr(""))
-	if apnsPrivateKeySource != "" {
-		var err error
-		apnsPrivateKey, err = LoadAPNSPrivateKey(apnsPrivateKeySource)
-		if err != nil {
-			log.Printf("Warning: Failed to load APNS private key: %v", err)
+	pushRepo := pushrepo.NewPostgresRepository(db)
+
+	// PUSH_PROVIDER=log только пишет уведомления в лог: для локальной разработки и интеграционных
+	// тестов без ключей Firebase и APNS. По умолчанию уведомления отправляются через FCM и APNS.
+	var pushProviders map[string]pushservice.Provider
+	switch cfg.Push.Provider {
+	case "log":
+		log.Println("Push notifications are logged instead of sent")
+		pushProviders = map[string]pushservice.Provider{
+			pushservice.PlatformAndroid: pushservice.NewLogProvider(pushservice.PlatformAndroid),
+			pushservice.PlatformIOS:     pushservice.NewLogProvider(pushservice.PlatformIOS),
+		}
+	case "fcm_apns":
+		// Каждый провайдер включается своим флагом и подключается при первой отправке.
+		// Без ключей платформа отключается с предупреждением; уведомления на ее устройства пропускаются.
+		pushProviders = map[string]pushservice.Provider{}
+		if cfg.Push.FCMEnabled {
+			if cfg.Push.FCMCredentials != "" {
+				pushProviders[pushservice.PlatformAndroid] = pushservice.NewFCMProvider(cfg.Push.FCMCredentials)
+			} else {
+				log.Println("Warning: GOOGLE_APPLICATION_CREDENTIALS is not set, push notifications to android are disabled")
+			}
+		}
+		if cfg.Push.APNSEnabled {
+			apnsConfig := pushservice.APNSConfig{
+				KeyID:       cfg.Push.APNSKeyID,
+				TeamID:      cfg.Push.APNSTeamID,
+				PrivateKey:  cfg.Push.APNSPrivateKey,
+				BundleID:    cfg.Push.APNSBundleID,
+				Development: cfg.Environment != "production",
+			}
+			if apnsConfig.Complete() {
+				pushProviders[pushservice.PlatformIOS] = pushservice.NewAPNSProvider(apnsConfig)
+			} else {
+				log.Println("Warning: APNS configuration is incomplete, push notifications to ios are disabled")
+			}
 		}
 	}
 
-	pushRepo := pushrepo.NewPostgresRepository(db)
-	pushConfig := pushservice.Config{
-		APNSKeyID:  getEnv("APNS_KEY_ID", ptr("")),
-		APNSTeamID: getEnv("APNS_TEAM_ID", ptr("")),
-		// In a real implementation, load private key from file or environment
-		APNSPrivateKey:  apnsPrivateKey,
-		APNSBundleID:    getEnv("APNS_BUNDLE_ID", ptr("")),
-		APNSDevelopment: getEnv("APP_ENV", ptr("development")) != "production",
+	features["push_android"] = pushProviders[pushservice.PlatformAndroid] != nil
+	features["push_ios"] = pushProviders[pushservice.PlatformIOS] != nil
+	pushService := pushservice.NewPushService(pushRepo, pushProviders)
+	pushHandler := pushhandler.NewHandler(pushService)
+
+	// Уведомления отправляются из очереди в БД, чтобы медленный APNS/FCM не задерживал запросы;
+	// неудачные попытки повторяются до PUSH_QUEUE_MAX_ATTEMPTS раз
+	pushQueue := pushservice.NewQueue(pushRepo, pushService, cfg.Push.QueueMaxAttempts)
+	pushHandler.SetQueue(pushQueue)
+
+	// Инициализация сервиса и хендлера сообщений
+	messagingRepo := messagingrepo.NewRepository(db)
+	messagingService := messagingservice.NewService(messagingRepo, profileRepo, mediaRepo)
+
+	// Подписанные ссылки на вложения сообщений, если хранилище медиа закрыто для публичного доступа
+	features["signed_media_urls"] = cfg.Media.URLSigningSecret != ""
+	if features["signed_media_urls"] {
+		messagingService.SetURLSigner(mediaservice.NewURLSigner(cfg.Media.URLSigningSecret, cfg.Media.URLTTL))
+	}
+	messagingHandler := messaging.NewHandler(messagingService, profileService, pushQueue)
+	// Системные сообщения и ответы бота доставляются через WebSocket
+	messagingService.SetMessageListener(messagingHandler)
+
+	// Лимиты размера групповых чатов; для чатов верифицированных организаторов лимит больше (0 — без лимита)
+	messagingService.SetGroupLimits(cfg.Messaging.GroupLimits)
+	catalogService.SetGroupLimits(cfg.Messaging.GroupLimits)
+
+	// Лимит частоты сообщений пользователя в один чат для защиты от спама (0 — без лимита)
+	messagingService.SetMessageRateLimit(cfg.Messaging.RateLimit)
+
+	// Инициализация сервиса и хендлера команд
+	teamRepo := teamrepo.NewPostgresRepository(db)
+	teamService := teamservice.NewTeamService(teamRepo, mediaRepo, messagingService, pushQueue)
+	teamService.SetActivityRecorder(feedService)
+	teamHandler := teamhandler.NewHandler(teamService)
+
+	// Инициализация сервиса и хендлера мастер-классов
+	classRepo := classrepo.NewPostgresRepository(db)
+	classService := classservice.NewClassService(classRepo, messagingService)
+	classHandler := classhandler.NewHandler(classService)
+
+	// Боты сообщества: API по ключу и вебхуки о новых сообщениях в групповых чатах
+	botRepo := botrepo.NewPostgresRepository(db)
+	botService := botservice.NewBotService(botRepo, messagingService)
+	botService.SetMessageListener(messagingHandler)
+	messagingService.SetMessageObserver(botService)
+	botHandler := bothandler.NewHandler(botService)
+
+	// Фоновые задачи, состояние которых показывает /health/details
+	workers := health.NewWorkers()
+
+	// Напоминания в чатах: планировщик раз в REMINDER_POLL_INTERVAL секунд публикует наступившие
+	reminderRepo := reminderrepo.NewPostgresRepository(db)
+	reminderService := reminderservice.NewReminderService(reminderRepo, messagingService, pushQueue)
+	reminderService.SetMessageListener(messagingHandler)
+	reminderHandler := reminderhandler.NewHandler(reminderService)
+	reminderInterval := cfg.Intervals.Reminders
+	reminderService.SetRunObserver(workers.Register("reminders", reminderInterval))
+	go reminderService.Run(context.Background(), reminderInterval)
+
+	// Очередь поиска партнера для практики: подобранной паре создается личный чат
+	partnerRepo := partnerrepo.NewPostgresRepository(db)
+	partnerService := partnerservice.NewPartnerService(partnerRepo, messagingService, pushQueue)
+	partnerHandler := partnerhandler.NewHandler(partnerService)
+	partnerInterval := cfg.Intervals.PartnerMatch
+	partnerService.SetRunObserver(workers.Register("partner_matcher", partnerInterval))
+	go partnerService.Run(context.Background(), partnerInterval)
+
+	// Объявления администрации: доставляются всем подключенным по WebSocket и пушем
+	announcementRepo := announcementrepo.NewPostgresRepository(db)
+	announcementService := announcementservice.NewAnnouncementService(announcementRepo, pushQueue)
+	announcementService.SetListener(messagingHandler)
+	announcementHandler := announcementhandler.NewHandler(announcementService)
+
+	// Запланированные push-рассылки администрации по сегментам пользователей
+	campaignRepo := campaignrepo.NewPostgresRepository(db)
+	campaignService := campaignservice.NewCampaignService(campaignRepo, pushQueue)
+	campaignHandler := campaignhandler.NewHandler(campaignService)
+	campaignInterval := cfg.Intervals.PushCampaigns
+	campaignService.SetRunObserver(workers.Register("push_campaigns", campaignInterval))
+	go campaignService.Run(context.Background(), campaignInterval)
+
+	// Публичные ключи устройств для сквозного шифрования личных чатов
+	keysRepo := keysrepo.NewPostgresRepository(db)
+	keysService := keysservice.NewKeyService(keysRepo)
+	keysHandler := keyshandler.NewHandler(keysService)
+
+	// Блокировки аккаунтов: проверяются в AuthMiddleware, истекшие снимает планировщик
+	suspensionRepo := suspensionrepo.NewPostgresRepository(db)
+	suspensionService := suspensionservice.NewSuspensionService(suspensionRepo, userRepo, pushQueue)
+	suspensionHandler := suspensionhandler.NewHandler(suspensionService)
+	authHandler.SetSuspensionChecker(suspensionService)
+	suspensionInterval := cfg.Intervals.Suspensions
+	suspensionService.SetRunObserver(workers.Register("suspensions", suspensionInterval))
+	go suspensionService.Run(context.Background(), suspensionInterval)
+
+	// Push-токены, которые приложение не обновляло PUSH_TOKEN_MAX_AGE_DAYS дней, удаляются раз в сутки
+	tokenCleaner := pushservice.NewTokenCleaner(pushRepo, cfg.Push.TokenMaxAge)
+	tokenCleanupInterval := 24 * time.Hour
+	tokenCleaner.SetRunObserver(workers.Register("push_token_cleanup", tokenCleanupInterval))
+	go tokenCleaner.Run(context.Background(), tokenCleanupInterval)
+
+	// Очередь push-уведомлений опрашивается раз в PUSH_QUEUE_POLL_INTERVAL секунд и сразу после постановки
+	pushQueueInterval := cfg.Intervals.PushQueue
+	pushQueue.SetRunObserver(workers.Register("push_queue", pushQueueInterval))
+	// Окно агрегации категории задается PUSH_DIGEST_WINDOW_<КАТЕГОРИЯ> в секундах; 0 — отправлять сразу
+	for category, window := range cfg.Push.DigestWindows {
+		pushQueue.SetDigestWindow(category, window)
 	}
+	go pushQueue.Run(context.Background(), pushQueueInterval)
+
+	// Превью для загрузок без thumbnail генерирует фоновый воркер: изображения уменьшаются,
+	// кадр видео берет ffmpeg. Без ffmpeg для видео thumbnail по-прежнему обязателен
+	var frameGrabber mediaservice.FrameGrabber
+	features["video_thumbnails"] = false
+	if ffmpeg, err := mediaservice.NewFFmpeg(cfg.Media.FFmpegPath); err != nil {
+		log.Printf("ffmpeg not found, video uploads need a thumbnail: %v", err)
+	} else {
+		features["video_thumbnails"] = true
+		frameGrabber = ffmpeg
+	}
+	thumbnailWorker := mediaService.EnableThumbnailGeneration(frameGrabber, cfg.Media.ThumbnailMaxAttempts)
+	thumbnailInterval := cfg.Intervals.MediaThumbnails
+	thumbnailWorker.SetRunObserver(workers.Register("media_thumbnails", thumbnailInterval))
+	go thumbnailWorker.Run(context.Background(), thumbnailInterval)
+
+	// Выгрузки генерирует фоновый воркер, не больше нескольких одновременно. Выгрузки, созданные
+	// другими репликами или брошенные при перезапуске, он берет раз в EXPORT_POLL_INTERVAL секунд
+	exportInterval := cfg.Intervals.Exports
+	exportService.SetRunObserver(workers.Register("exports", exportInterval))
+	go exportService.Run(context.Background(), exportInterval)
+
+	// Поиск профилей: по умолчанию в PostgreSQL, с SEARCH_PROVIDER=opensearch — во внешнем индексе.
+	// Индексатор раз в SEARCH_INDEX_POLL_INTERVAL секунд переносит в индекс изменения из очереди
+	features["opensearch"] = cfg.Search.Provider == "opensearch"
+	if features["opensearch"] {
+		searchClient := searchindexservice.NewClient(
+			cfg.Search.OpenSearchURL,
+			cfg.Search.OpenSearchIndex,
+			cfg.Search.OpenSearchUsername,
+			cfg.Search.OpenSearchPassword,
+		)
+		indexer := searchindexservice.NewIndexer(searchindexrepo.NewPostgresRepository(db), searchClient, cfg.Search.BatchSize)
+		if err := indexer.EnsureIndex(context.Background()); err != nil {
+			log.Printf("Failed to create search index: %v", err)
+		}
+		searchIndexInterval := cfg.Intervals.SearchIndex
+		indexer.SetRunObserver(workers.Register("search_index", searchIndexInterval))
+		go indexer.Run(context.Background(), searchIndexInterval)
 
-	// Initialize Firebase app
+		profileService.SetSearchProvider(searchindexservice.NewOpenSearchProvider(
+			searchClient, profileRepo, profileservice.NewPostgresSearchProvider(profileRepo)))
+	}
 
-	ctx := context.Background()
-	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(getEnv("GOOGLE_APPLICATION_CREDENTIALS", nil)))
-	if err != nil {
-		log.Fatalf("error initializing app: %v", err)
+	// Жалобы на профили, сообщения и медиа
+	reportRepo := reportrepo.NewPostgresRepository(db)
+	reportService := reportservice.NewReportService(reportRepo)
+	reportHandler := reporthandler.NewHandler(reportService)
+
+	// Инструменты поддержки: справочник пользователей, управление профилями и просмотр от имени пользователя
+	adminRepo := adminrepo.NewPostgresRepository(db)
+	adminService := adminservice.NewAdminService(adminRepo, profileService, userRepo, authService)
+	adminHandler := adminhandler.NewHandler(adminService)
+	authHandler.SetImpersonationGuard(adminService)
+
+	// Бот «Бригадка»: приветствие новых пользователей и ответы на частые вопросы
+	features["welcome_bot"] = false
+	if cfg.WelcomeBot.Enabled {
+		botUser, err := userRepo.GetUserByEmail(cfg.WelcomeBot.Email)
+		if err != nil {
+			log.Printf("Warning: welcome bot disabled, bot user not found: %v", err)
+		} else {
+			features["welcome_bot"] = true
+			messagingService.SetBot(messagingservice.NewWelcomeBot(botUser.ID))
+			authHandler.SetWelcomer(messagingService)
+		}
 	}
 
-	// Get Messaging client
-	firebaseClient, err := app.Messaging(ctx)
-	if err != nil {
-		log.Fatalf("error getting Messaging client: %v", err)
+	// Журнал последних WS-событий пользователя для диагностики (0 — отключен)
+	features["ws_event_log"] = cfg.WebSocket.EventLogSize > 0
+	if features["ws_event_log"] {
+		messagingHandler.EnableEventLog(cfg.WebSocket.EventLogSize)
 	}
 
-	pushService := pushservice.NewPushService(pushRepo, pushConfig, firebaseClient)
-	pushHandler := pushhandler.NewHandler(pushService)
+	// Сжатие WS-сообщений (permessage-deflate) для клиентов, которые его поддерживают
+	features["ws_compression"] = cfg.WebSocket.CompressionEnabled
+	if features["ws_compression"] {
+		messagingHandler.EnableCompression(messaging.CompressionConfig{
+			Level:          cfg.WebSocket.CompressionLevel,
+			Threshold:      cfg.WebSocket.CompressionThreshold,
+			MaxMessageSize: cfg.WebSocket.MaxMessageSize,
+		})
+	}
 
-	// Инициализация сервиса и хендлера сообщений
-	messagingRepo := messagingrepo.NewRepository(db)
-	messagingService := messagingservice.NewService(messagingRepo, profileRepo)
-	messagingHandler := messaging.NewHandler(messagingService, profileService, pushService)
+	// Ping/pong для WS: соединения, не ответившие за WS_PONG_WAIT секунд, закрываются (0 в WS_PING_INTERVAL — отключено)
+	features["ws_heartbeat"] = cfg.WebSocket.PingInterval > 0
+	if features["ws_heartbeat"] {
+		messagingHandler.EnableHeartbeat(messaging.HeartbeatConfig{
+			PingInterval: cfg.WebSocket.PingInterval,
+			PongWait:     cfg.WebSocket.PongWait,
+			WriteWait:    cfg.WebSocket.WriteWait,
+		})
+	}
+
+	// Доставка WS-сообщений через Redis pub/sub, чтобы клиенты, подключенные к разным репликам, получали все события
+	ctx := context.Background()
+	features["ws_redis_broker"] = cfg.WebSocket.RedisAddr != ""
+	if features["ws_redis_broker"] {
+		redisConfig := broker.RedisConfig{
+			Addr:     cfg.WebSocket.RedisAddr,
+			Password: cfg.WebSocket.RedisPassword,
+		}
+		if cfg.WebSocket.RedisTLS {
+			redisConfig.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		wsBroker := broker.NewRedis(redisConfig)
+		if err := messagingHandler.EnableBroker(ctx, wsBroker); err != nil {
+			log.Fatalf("Failed to subscribe to Redis: %v", err)
+		}
+	}
+
+	// Превью ссылок в сообщениях: воркеры загружают OpenGraph-метаданные первой ссылки и рассылают message_preview_ready
+	features["link_previews"] = cfg.Messaging.LinkPreviewsEnabled
+	if features["link_previews"] {
+		messagingService.EnableLinkPreviews(ctx,
+			linkpreview.NewFetcher(cfg.Messaging.LinkPreviewTimeout),
+			messagingHandler,
+			cfg.Messaging.LinkPreviewWorkers,
+		)
+	}
 
 	// Создание роутера
 	r := chi.NewRouter()
@@ -225,38 +707,21 @@ func main() {
 
 	// Health endpoint для проверки работоспособности сервиса
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		healthHandler(w, r, db, appVersion)
+		healthHandler(w, r, db, cfg.Version)
 	})
 
-	// Расширенный health check с дополнительной информацией
+	// Расширенный health check: сборка, схема БД, функции и фоновые задачи для разбора инцидентов
 	r.Get("/health/details", func(w http.ResponseWriter, r *http.Request) {
-		details := map[string]interface{}{
-			"status":      "healthy",
-			"version":     appVersion,
-			"timestamp":   time.Now().Format(time.RFC3339),
-			"environment": getEnv("APP_ENV", ptr("development")),
-			"services": map[string]interface{}{
-				"database": map[string]interface{}{
-					"status": "connected",
-					"host":   dbConfig.Host,
-					"name":   dbConfig.DBName,
-				},
-			},
-			"uptime": time.Since(startTime).String(),
-		}
+		healthDetailsHandler(w, r, db, cfg, features, messagingHandler, workers, pushQueue)
+	})
 
-		// Проверка соединения с базой данных
-		if err := db.Ping(); err != nil {
-			details["status"] = "error"
-			details["services"].(map[string]interface{})["database"].(map[string]interface{})["status"] = "error"
-			w.WriteHeader(http.StatusServiceUnavailable)
-		} else {
-			w.WriteHeader(http.StatusOK)
-		}
+	// Файлы локального хранилища (STORAGE_PROVIDER=local)
+	if localStorage != nil {
+		r.Handle("/files/*", http.StripPrefix("/files", localStorage.Handler()))
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(details)
-	})
+	// Серверное время для синхронизации часов клиентов (без аутентификации)
+	r.Get("/api/time", timeHandler)
 
 	// Публичные маршруты аутентификации
 	r.Route("/api/auth", func(r chi.Router) {
@@ -264,6 +729,23 @@ func main() {
 		r.Post("/register", authHandler.Register)
 		r.Get("/verify", authHandler.Verify)
 		r.Post("/refresh", authHandler.RefreshToken)
+		r.Post("/guest", authHandler.GuestToken)
+
+		// Согласие с документами (требует аутентификации)
+		r.With(authHandler.AuthMiddleware, authHandler.RequireUser).Post("/consent", consentHandler.Accept)
+		r.With(authHandler.AuthMiddleware, authHandler.RequireUser).Get("/consent", consentHandler.GetStatus)
+
+		// Статус блокировки и обжалование (доступны заблокированным пользователям)
+		r.With(authHandler.AuthMiddlewareAllowSuspended, authHandler.RequireUser).Get("/suspension", suspensionHandler.GetStatus)
+		r.With(authHandler.AuthMiddlewareAllowSuspended, authHandler.RequireUser).Post("/suspension/appeal", suspensionHandler.SubmitAppeal)
+	})
+
+	// API для ботов (аутентификация по API-ключу бота, а не по JWT пользователя)
+	r.Route("/api/bot", func(r chi.Router) {
+		r.Use(botHandler.RequireBot)
+
+		r.Get("/chats", botHandler.GetChats)
+		r.Post("/chats/{chatID}/messages", botHandler.PostMessage)
 	})
 
 	// Защищенные маршруты (требуют аутентификации)
@@ -271,18 +753,30 @@ func main() {
 		r.Use(authHandler.AuthMiddleware)
 
 		r.Route("/api", func(r chi.Router) {
-			r.Get("/protected", func(w http.ResponseWriter, r *http.Request) {
-				userID := r.Context().Value("user_id").(int)
-				email := r.Context().Value("email").(string)
-				w.Write([]byte(fmt.Sprintf("Protected resource. User ID: %d, Email: %s", userID, email)))
-			})
+			// Все справочники одним ответом для кэширования в приложении (доступно гостям)
+			r.Get("/catalog/bundle", catalogHandler.GetBundle)
 
-			// Маршруты для работы с профилями (требуют аутентификации)
+			// Маршруты для работы с профилями (справочники, просмотр и поиск доступны гостям)
 			r.Route("/profiles", func(r chi.Router) {
 
-				r.Post("/", profileHandler.CreateProfile)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Post("/", profileHandler.CreateProfile)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Get("/me/onboarding", onboardingHandler.GetStatus)
 				r.Get("/{userID}", profileHandler.GetProfile)
-				r.Patch("/{userID}", profileHandler.UpdateProfile)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Patch("/{userID}", profileHandler.UpdateProfile)
+
+				// Избранные профили
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Get("/favorites", profileHandler.GetFavorites)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Post("/{userID}/favorite", profileHandler.AddFavorite)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Delete("/{userID}/favorite", profileHandler.RemoveFavorite)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Post("/{userID}/endorsements", profileHandler.Endorse)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Delete("/{userID}/endorsements/{style}", profileHandler.RemoveEndorsement)
+
+				// Рекомендации: общие стили, город, цели и недавняя активность
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Get("/recommended", profileHandler.GetRecommendations)
+
+				// Подписки на пользователей
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Post("/{userID}/follow", feedHandler.FollowUser)
+				r.With(authHandler.RequireUser, consentHandler.RequireConsent).Delete("/{userID}/follow", feedHandler.UnfollowUser)
 
 				// Регистрация обработчиков для справочников
 				r.Route("/catalog", func(r chi.Router) {
@@ -290,45 +784,237 @@ func main() {
 					r.Get("/improv-goals", profileHandler.GetImprovGoals)
 					r.Get("/genders", profileHandler.GetGenders)
 					r.Get("/cities", profileHandler.GetCities)
+					r.Get("/tags", profileHandler.GetTags)
 				})
 
 				r.Post("/search", profileHandler.SearchProfiles)
+				r.With(
+					authHandler.RequireUser,
+					consentHandler.RequireConsent,
+					authHandler.RequireRole(authservice.RoleOrganizer, authservice.RoleAdmin),
+				).Post("/search/export", profileHandler.ExportSearch)
+			})
+
+			// Маршруты для работы с командами (просмотр и поиск доступны гостям)
+			r.Route("/teams", func(r chi.Router) {
+				r.Post("/search", teamHandler.SearchTeams)
+				r.Get("/{teamID}", teamHandler.GetTeam)
+				r.Get("/{teamID}/members", teamHandler.GetMembers)
+
+				r.Group(func(r chi.Router) {
+					r.Use(authHandler.RequireUser)
+					r.Use(consentHandler.RequireConsent)
+
+					r.Post("/", teamHandler.CreateTeam)
+					r.Patch("/{teamID}", teamHandler.UpdateTeam)
+					r.Delete("/{teamID}", teamHandler.DeleteTeam)
+					r.Post("/{teamID}/members", teamHandler.AddMember)
+					r.Patch("/{teamID}/members/{userID}", teamHandler.UpdateMember)
+					r.Delete("/{teamID}/members/{userID}", teamHandler.RemoveMember)
+
+					// Заявки на вступление в команду
+					r.Post("/{teamID}/applications", teamHandler.Apply)
+					r.Get("/{teamID}/applications", teamHandler.GetApplications)
+					r.Post("/{teamID}/applications/{applicationID}/accept", teamHandler.AcceptApplication)
+					r.Post("/{teamID}/applications/{applicationID}/reject", teamHandler.RejectApplication)
+
+					// Подписки на команды
+					r.Post("/{teamID}/follow", feedHandler.FollowTeam)
+					r.Delete("/{teamID}/follow", feedHandler.UnfollowTeam)
+				})
 			})
 
-			// Маршруты для работы с медиа (требуют аутентификации)
-			r.Route("/media", func(r chi.Router) {
-				r.Post("/", mediaHandler.UploadMedia)
+			// Маршруты для мастер-классов (просмотр и поиск доступны гостям)
+			r.Route("/classes", func(r chi.Router) {
+				r.Post("/search", classHandler.SearchClasses)
+				r.Get("/{classID}", classHandler.GetClass)
+
+				r.Group(func(r chi.Router) {
+					r.Use(authHandler.RequireUser)
+					r.Use(consentHandler.RequireConsent)
+
+					r.Post("/", classHandler.CreateClass)
+					r.Delete("/{classID}", classHandler.DeleteClass)
+					r.Get("/{classID}/enrollments", classHandler.GetEnrollments)
+					r.Post("/{classID}/enrollment", classHandler.Enroll)
+					r.Delete("/{classID}/enrollment", classHandler.Unenroll)
+				})
 			})
 
-			// Маршруты для работы с сообщениями (требуют аутентификации)
-			r.Post("/chats", messagingHandler.CreateChat)
-			r.Get("/chats", messagingHandler.GetUserChats)
-			r.Post("/chats/direct", messagingHandler.GetOrCreateDirectChat)
-			r.Get("/chats/{chatID}", messagingHandler.GetChat)
-			r.Get("/chats/{chatID}/messages", messagingHandler.GetChatMessages)
-			r.Post("/chats/{chatID}/messages", messagingHandler.SendMessage)
-			r.Post("/chats/{chatID}/participants", messagingHandler.AddParticipant)
-			r.Delete("/chats/{chatID}/participants/{userID}", messagingHandler.RemoveParticipant)
-			r.Post("/messages/{messageID}/reactions", messagingHandler.AddReaction)
-			r.Delete("/messages/{messageID}/reactions/{reactionCode}", messagingHandler.RemoveReaction)
-			r.HandleFunc("/ws/chat", messagingHandler.HandleWebSocket)
-
-			r.Post("/push/register", pushHandler.RegisterToken)
-			r.Delete("/push/unregister", pushHandler.UnregisterToken)
+			// Остальные маршруты недоступны гостевым токенам
+			r.Group(func(r chi.Router) {
+				r.Use(authHandler.RequireUser)
+				r.Use(consentHandler.RequireConsent)
+
+				r.Get("/protected", func(w http.ResponseWriter, r *http.Request) {
+					userID := r.Context().Value("user_id").(int)
+					email := r.Context().Value("email").(string)
+					w.Write([]byte(fmt.Sprintf("Protected resource. User ID: %d, Email: %s", userID, email)))
+				})
+
+				// Маршруты для работы с медиа (требуют аутентификации)
+				r.Route("/media", func(r chi.Router) {
+					r.Get("/", mediaHandler.GetMediaLibrary)
+					r.Get("/quota", mediaHandler.GetQuota)
+					r.Post("/", mediaHandler.UploadMedia)
+					r.Delete("/{mediaID}", mediaHandler.DeleteMedia)
+					r.Post("/{mediaID}/crop", mediaHandler.CropMedia)
+				})
+
+				// Анкета онбординга (не входит в публичный профиль)
+				r.Get("/onboarding/quiz", onboardingHandler.GetQuiz)
+				r.Put("/onboarding/quiz", onboardingHandler.SaveQuiz)
+
+				// Лента активности подписок
+				r.Get("/feed", feedHandler.GetFeed)
+
+				// Статистика реферальной программы
+				r.Get("/referrals/stats", referralHandler.GetStats)
+
+				// Объявления администрации (только чтение)
+				r.Get("/announcements", announcementHandler.GetAnnouncements)
+				r.Post("/announcements/read", announcementHandler.MarkRead)
+
+				// Маршруты для работы с сообщениями (требуют аутентификации)
+				r.Post("/chats", messagingHandler.CreateChat)
+				r.Get("/chats", messagingHandler.GetUserChats)
+				r.Post("/chats/direct", messagingHandler.GetOrCreateDirectChat)
+				r.Get("/chats/requests", messagingHandler.GetChatRequests)
+				r.Get("/chats/privacy", messagingHandler.GetDirectMessageSettings)
+				r.Put("/chats/privacy", messagingHandler.UpdateDirectMessageSettings)
+				r.Get("/chats/{chatID}", messagingHandler.GetChat)
+				r.Patch("/chats/{chatID}", messagingHandler.UpdateChat)
+				r.Post("/chats/read-all", messagingHandler.MarkAllRead)
+				r.Get("/chats/{chatID}/messages", messagingHandler.GetChatMessages)
+				r.Get("/chats/{chatID}/export", messagingHandler.ExportChat)
+				r.Post("/chats/{chatID}/read-all", messagingHandler.MarkChatRead)
+				r.Post("/chats/{chatID}/read", messagingHandler.MarkRead)
+				r.Post("/chats/{chatID}/mute", messagingHandler.MuteChat)
+				r.Delete("/chats/{chatID}/mute", messagingHandler.UnmuteChat)
+				r.Post("/chats/{chatID}/archive", messagingHandler.ArchiveChat)
+				r.Delete("/chats/{chatID}/archive", messagingHandler.UnarchiveChat)
+				r.Post("/chats/{chatID}/messages", messagingHandler.SendMessage)
+				r.Post("/chats/{chatID}/participants", messagingHandler.AddParticipant)
+				r.Delete("/chats/{chatID}/participants/{userID}", messagingHandler.RemoveParticipant)
+				r.Post("/chats/{chatID}/participants/{userID}/admin", messagingHandler.PromoteToAdmin)
+				r.Post("/chats/{chatID}/leave", messagingHandler.LeaveChat)
+				r.Post("/chats/{chatID}/accept", messagingHandler.AcceptChatRequest)
+				r.Post("/chats/{chatID}/reminders", reminderHandler.CreateReminder)
+				r.Get("/chats/{chatID}/reminders", reminderHandler.GetReminders)
+				r.Delete("/chats/{chatID}/reminders/{reminderID}", reminderHandler.CancelReminder)
+
+				// Поиск партнера для практики
+				r.Post("/partners/queue", partnerHandler.JoinQueue)
+				r.Get("/partners/queue", partnerHandler.GetQueueEntry)
+				r.Delete("/partners/queue", partnerHandler.LeaveQueue)
+				r.Get("/messages/{messageID}/reactions", messagingHandler.GetMessageReactions)
+				r.Post("/messages/{messageID}/reactions", messagingHandler.AddReaction)
+				r.Delete("/messages/{messageID}/reactions/{reactionCode}", messagingHandler.RemoveReaction)
+				r.HandleFunc("/ws/chat", messagingHandler.HandleWebSocket)
+
+				// Ключи устройств для сквозного шифрования
+				r.Put("/keys/devices/{deviceID}", keysHandler.RegisterDevice)
+				r.Post("/keys/devices/{deviceID}/prekeys", keysHandler.UploadPrekeys)
+				r.Delete("/keys/devices/{deviceID}", keysHandler.RemoveDevice)
+				r.Get("/users/{userID}/keys", keysHandler.GetKeyBundles)
+
+				// Управление ботами (боты добавляются в групповые чаты как участники)
+				r.Post("/bots", botHandler.CreateBot)
+				r.Get("/bots", botHandler.GetBots)
+				r.Patch("/bots/{botID}", botHandler.UpdateBot)
+				r.Delete("/bots/{botID}", botHandler.DeleteBot)
+
+				// Жалобы на контент
+				r.Get("/reports/reasons", reportHandler.GetReasons)
+				r.Post("/reports", reportHandler.CreateReport)
+
+				// Асинхронные выгрузки
+				r.Get("/exports/{exportID}", exportHandler.GetExport)
+				r.Get("/exports/{exportID}/download", exportHandler.Download)
+
+				// Согласие на просмотр аккаунта поддержкой
+				r.Get("/support-access", adminHandler.GetSupportAccess)
+				r.Put("/support-access", adminHandler.GrantSupportAccess)
+				r.Delete("/support-access", adminHandler.RevokeSupportAccess)
+
+				r.Post("/push/register", pushHandler.RegisterToken)
+				r.Delete("/push/unregister", pushHandler.UnregisterToken)
+				r.Post("/push/test", pushHandler.SendTestNotification)
+				r.Get("/push/tokens", pushHandler.GetTokens)
+				r.Get("/notifications/preferences", pushHandler.GetPreferences)
+				r.Put("/notifications/preferences", pushHandler.UpdatePreferences)
+
+				// Административные маршруты
+				r.Route("/admin", func(r chi.Router) {
+					r.Use(authHandler.RequireRole(authservice.RoleAdmin))
+
+					r.Get("/ws-events/{userID}", messagingHandler.GetUserWSEvents)
+
+					// Публикация объявлений
+					r.Post("/announcements", announcementHandler.PostAnnouncement)
+
+					// Счетчики доставки push-уведомлений по платформам и категориям
+					r.Get("/push/stats", pushHandler.GetStats)
+
+					// Push-рассылки по сегментам: предпросмотр с размером аудитории, планирование, отмена
+					r.Post("/push/campaigns/preview", campaignHandler.PreviewCampaign)
+					r.Post("/push/campaigns", campaignHandler.ScheduleCampaign)
+					r.Get("/push/campaigns", campaignHandler.GetCampaigns)
+					r.Get("/push/campaigns/{campaignID}", campaignHandler.GetCampaign)
+					r.Post("/push/campaigns/{campaignID}/cancel", campaignHandler.CancelCampaign)
+
+					// Справочник пользователей для поддержки
+					r.Get("/users", adminHandler.SearchUsers)
+					r.Post("/users/{userID}/impersonate", adminHandler.StartImpersonation)
+
+					// Управление профилями; действия пишутся в журнал аудита
+					r.Get("/profiles", adminHandler.SearchProfiles)
+					r.Patch("/profiles/{userID}", adminHandler.UpdateProfile)
+					r.Post("/profiles/{userID}/ban", adminHandler.BanProfile)
+					r.Delete("/profiles/{userID}/ban", adminHandler.UnbanProfile)
+					r.Post("/profiles/{userID}/verify", adminHandler.VerifyProfile)
+					r.Delete("/profiles/{userID}/verify", adminHandler.UnverifyProfile)
+					r.Post("/profiles/{userID}/shadowban", adminHandler.ShadowbanProfile)
+					r.Delete("/profiles/{userID}/shadowban", adminHandler.UnshadowbanProfile)
+					r.Get("/audit", adminHandler.GetAuditLog)
+
+					// Сводка модерации и удаление контента
+					r.Get("/dashboard", adminHandler.GetDashboard)
+					r.Delete("/messages/{messageID}", adminHandler.RemoveMessage)
+					r.Delete("/media/{mediaID}", adminHandler.RemoveMedia)
+
+					// Модерация медиа
+					r.Get("/moderation/media", mediaHandler.GetModerationQueue)
+					r.Post("/moderation/media/{mediaID}/approve", mediaHandler.ApproveMedia)
+					r.Post("/moderation/media/{mediaID}/reject", mediaHandler.RejectMedia)
+					r.Get("/moderation/metrics", mediaHandler.GetModerationMetrics)
+
+					// Блокировки пользователей и обжалования
+					r.Post("/users/{userID}/suspension", suspensionHandler.SuspendUser)
+					r.Delete("/users/{userID}/suspension", suspensionHandler.LiftSuspension)
+					r.Get("/moderation/appeals", suspensionHandler.GetAppeals)
+					r.Post("/moderation/appeals/{appealID}/accept", suspensionHandler.AcceptAppeal)
+					r.Post("/moderation/appeals/{appealID}/reject", suspensionHandler.RejectAppeal)
+
+					// Жалобы пользователей
+					r.Get("/moderation/reports", reportHandler.GetReports)
+					r.Post("/moderation/reports/{reportID}/resolve", reportHandler.ResolveReport)
+				})
+			})
 		})
 	})
 
 	// Запуск сервера с корректной обработкой graceful shutdown
 	server := &http.Server{
-		Addr:    ":" + serverPort,
+		Addr:    ":" + cfg.ServerPort,
 		Handler: r,
 	}
 
 	// Запуск сервера в горутине
 	go func() {
-		log.Printf("Server is starting on port %s", serverPort)
+		log.Printf("Server is starting on port %s", cfg.ServerPort)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Could not listen on port %s: %v\n", serverPort, err)
+			log.Fatalf("Could not listen on port %s: %v\n", cfg.ServerPort, err)
 		}
 	}()
 
@@ -350,51 +1036,3 @@ func main() {
 
 	log.Println("Server gracefully stopped")
 }
-
-// Вспомогательные функции для работы с переменными окружения
-func getEnv(key string, fallback *string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	if fallback == nil {
-		panic(fmt.Sprintf("Environment variable %s is not set and no fallback provided", key))
-	}
-	return *fallback
-}
-
-func getEnvAsInt(key string, fallback int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		var intVal int
-		if _, err := fmt.Sscanf(value, "%d", &intVal); err == nil {
-			return intVal
-		}
-	}
-	return fallback
-}
-
-// LoadAPNSPrivateKey loads an APNS private key from a file path or from base64-encoded environment variable
-func LoadAPNSPrivateKey(source string) ([]byte, error) {
-	// Check if the source is a file path
-	if strings.HasPrefix(source, "file://") {
-		filePath := strings.TrimPrefix(source, "file://")
-		return os.ReadFile(filePath)
-	}
-
-	// Check if the source is a base64-encoded string
-	if strings.HasPrefix(source, "base64://") {
-		encodedKey := strings.TrimPrefix(source, "base64://")
-		return base64.StdEncoding.DecodeString(encodedKey)
-	}
-
-	// If the source is empty, return empty key
-	if source == "" {
-		return []byte{}, nil
-	}
-
-	// Otherwise assume it's the actual key content
-	return []byte(source), nil
-}
-
-func ptr[T any](val T) *T {
-	return &val
-}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Check is an invariant the schema cannot enforce by itself.
+// Query selects the rows that violate it.
+type Check struct {
+	Name        string
+	Description string
+	Query       string
+}
+
+var checks = []Check{
+	{
+		Name:        "profile_media_owner",
+		Description: "Profile media must be owned by the profile's user",
+		Query: `
+            SELECT pm.user_id, pm.media_id, pm.role, m.owner_id
+            FROM profile_media pm
+            JOIN media m ON m.id = pm.media_id
+            WHERE m.owner_id IS NULL OR m.owner_id <> pm.user_id`,
+	},
+	{
+		Name:        "chat_participant_user",
+		Description: "Chat participants must reference existing users",
+		Query: `
+            SELECT cp.chat_id, cp.user_id
+            FROM chat_participants cp
+            LEFT JOIN users u ON u.id = cp.user_id
+            WHERE u.id IS NULL`,
+	},
+	{
+		Name:        "read_receipt_seq",
+		Description: "Read receipts must not point past the last message of the chat",
+		Query: `
+            SELECT r.user_id, r.chat_id, r.last_read_seq, COALESCE(MAX(m.seq), 0) AS max_seq
+            FROM message_read_receipts r
+            LEFT JOIN messages m ON m.chat_id = r.chat_id
+            GROUP BY r.user_id, r.chat_id, r.last_read_seq
+            HAVING r.last_read_seq > COALESCE(MAX(m.seq), 0)`,
+	},
+	{
+		Name:        "read_receipt_participant",
+		Description: "Read receipts must belong to chat participants",
+		Query: `
+            SELECT r.user_id, r.chat_id
+            FROM message_read_receipts r
+            LEFT JOIN chat_participants cp ON cp.chat_id = r.chat_id AND cp.user_id = r.user_id
+            WHERE cp.user_id IS NULL`,
+	},
+	{
+		Name:        "delivery_receipt_seq",
+		Description: "Delivery receipts must not point past the last message of the chat",
+		Query: `
+            SELECT d.user_id, d.chat_id, d.last_delivered_seq, COALESCE(MAX(m.seq), 0) AS max_seq
+            FROM message_delivery_receipts d
+            LEFT JOIN messages m ON m.chat_id = d.chat_id
+            GROUP BY d.user_id, d.chat_id, d.last_delivered_seq
+            HAVING d.last_delivered_seq > COALESCE(MAX(m.seq), 0)`,
+	},
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Violations  int    `json:"violations"`
+	// Samples are the first violating rows keyed by column name
+	Samples []map[string]interface{} `json:"samples"`
+	// Error is set when the check could not run, e.g. the table is missing
+	Error string `json:"error,omitempty"`
+}
+
+// Run counts the violations of the check and collects up to sampleSize of them
+func (c Check) Run(ctx context.Context, db *sql.DB, sampleSize int) Result {
+	result := Result{
+		Name:        c.Name,
+		Description: c.Description,
+		Samples:     []map[string]interface{}{},
+	}
+
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+c.Query+") violations").Scan(&result.Violations); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if result.Violations == 0 || sampleSize <= 0 {
+		return result
+	}
+
+	samples, err := querySamples(ctx, db, fmt.Sprintf("%s LIMIT %d", c.Query, sampleSize))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Samples = samples
+	return result
+}
+
+// querySamples scans rows of any shape into maps keyed by column name
+func querySamples(ctx context.Context, db *sql.DB, query string) ([]map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		sample := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			// Text and UUID columns come back as bytes
+			if b, ok := values[i].([]byte); ok {
+				sample[column] = string(b)
+			} else {
+				sample[column] = values[i]
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
@@ -0,0 +1,132 @@
+// dbcheck проверяет инварианты данных, которые не выражаются внешними ключами,
+// и выводит JSON-отчет для мониторинга.
+//
+// Коды выхода: 0 — нарушений нет, 1 — найдены нарушения, 2 — проверка не выполнена.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/config"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Report is the machine-readable output of dbcheck
+type Report struct {
+	CheckedAt time.Time `json:"checked_at"`
+	OK        bool      `json:"ok"`
+	Checks    []Result  `json:"checks"`
+}
+
+func main() {
+	// Парсим флаги
+	samples := flag.Int("samples", 10, "Number of violating rows to include per check")
+	only := flag.String("checks", "", "Comma-separated check names to run (default: all)")
+	output := flag.String("output", "", "Write the report to this file instead of stdout")
+	timeout := flag.Duration("timeout", 5*time.Minute, "Timeout for all checks")
+	configFile := flag.String("config", os.Getenv(config.FileEnv), "YAML config file; environment variables take precedence")
+	flag.Parse()
+
+	selected, err := selectChecks(*only)
+	if err != nil {
+		log.Println(err)
+		os.Exit(2)
+	}
+
+	// Получаем параметры подключения из переменных окружения и файла конфигурации
+	dbConfig, err := config.LoadDatabase(*configFile)
+	if err != nil {
+		log.Printf("Invalid database configuration:\n%v", err)
+		os.Exit(2)
+	}
+	if database.Dialect(dbConfig.Driver) != database.Postgres {
+		log.Printf("dbcheck supports only PostgreSQL, DB_DRIVER is %q", dbConfig.Driver)
+		os.Exit(2)
+	}
+
+	// Подключаемся к базе данных
+	db, err := sql.Open("postgres", dbConfig.DSN())
+	if err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		os.Exit(2)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		os.Exit(2)
+	}
+
+	report := Report{CheckedAt: time.Now().UTC(), OK: true}
+	failed := false
+	for _, check := range selected {
+		result := check.Run(ctx, db, *samples)
+		if result.Error != "" {
+			failed = true
+		}
+		if result.Violations > 0 || result.Error != "" {
+			report.OK = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Printf("Failed to create report file: %v", err)
+			os.Exit(2)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Printf("Failed to write report: %v", err)
+		os.Exit(2)
+	}
+
+	switch {
+	case failed:
+		os.Exit(2)
+	case !report.OK:
+		os.Exit(1)
+	}
+}
+
+// selectChecks returns the checks named in the comma-separated list, or all checks for an empty list
+func selectChecks(names string) ([]Check, error) {
+	if strings.TrimSpace(names) == "" {
+		return checks, nil
+	}
+
+	byName := make(map[string]Check, len(checks))
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+
+	var selected []Check
+	for _, name := range strings.Split(names, ",") {
+		check, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+		selected = append(selected, check)
+	}
+	return selected, nil
+}
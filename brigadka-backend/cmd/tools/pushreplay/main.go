@@ -0,0 +1,101 @@
+// pushreplay возвращает в очередь push-уведомления, упавшие во время сбоя APNS/FCM,
+// и выводит JSON-отчет: какие доставки повторены и какие не доставить повтором.
+//
+// Коды выхода: 0 — все найденные доставки повторены, 1 — есть доставки, которые
+// не доставить повтором, 2 — повтор не выполнен.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/config"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
+)
+
+func main() {
+	// Парсим флаги
+	now := time.Now().UTC()
+	from := flag.String("from", now.Add(-24*time.Hour).Format(time.RFC3339), "Replay deliveries that failed at or after this time (RFC 3339)")
+	to := flag.String("to", now.Format(time.RFC3339), "Replay deliveries that failed before this time (RFC 3339)")
+	errorLike := flag.String("error", "", "Replay only deliveries whose last error contains this text, case-insensitive")
+	limit := flag.Int("limit", 1000, "Maximum number of deliveries to replay in one run")
+	rate := flag.Float64("rate", 20, "Deliveries returned to the queue per second")
+	maxReplays := flag.Int("max-replays", 3, "Report deliveries replayed this many times as permanently failed instead of replaying them")
+	dryRun := flag.Bool("dry-run", false, "Only list the deliveries that would be replayed")
+	output := flag.String("output", "", "Write the report to this file instead of stdout")
+	timeout := flag.Duration("timeout", 30*time.Minute, "Timeout for the whole run")
+	configFile := flag.String("config", os.Getenv(config.FileEnv), "YAML config file; environment variables take precedence")
+	flag.Parse()
+
+	filter := pushrepo.FailedDeliveryFilter{Error: *errorLike, Limit: *limit}
+	var err error
+	if filter.From, err = time.Parse(time.RFC3339, *from); err != nil {
+		log.Printf("Invalid -from: %v", err)
+		os.Exit(2)
+	}
+	if filter.To, err = time.Parse(time.RFC3339, *to); err != nil {
+		log.Printf("Invalid -to: %v", err)
+		os.Exit(2)
+	}
+	if !filter.From.Before(filter.To) || *limit <= 0 || *rate <= 0 || *maxReplays <= 0 {
+		log.Println("-from must precede -to; -limit, -rate and -max-replays must be positive")
+		os.Exit(2)
+	}
+
+	// Получаем параметры подключения из переменных окружения и файла конфигурации
+	dbConfig, err := config.LoadDatabase(*configFile)
+	if err != nil {
+		log.Printf("Invalid database configuration:\n%v", err)
+		os.Exit(2)
+	}
+
+	// Подключаемся к базе данных
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		os.Exit(2)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	replayer := &Replayer{
+		repository: pushrepo.NewPostgresRepository(db),
+		interval:   time.Duration(float64(time.Second) / *rate),
+		maxReplays: *maxReplays,
+		dryRun:     *dryRun,
+	}
+	report := replayer.Run(ctx, filter)
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Printf("Failed to create report file: %v", err)
+			os.Exit(2)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Printf("Failed to write report: %v", err)
+		os.Exit(2)
+	}
+
+	switch {
+	case report.Error != "":
+		os.Exit(2)
+	case len(report.Permanent) > 0:
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// Permanent is a dead letter that replaying would not deliver
+type Permanent struct {
+	pushrepo.FailedDelivery
+	Reason string `json:"reason"`
+}
+
+// Report is the machine-readable output of pushreplay
+type Report struct {
+	StartedAt time.Time `json:"started_at"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	ErrorLike string    `json:"error_like,omitempty"`
+	DryRun    bool      `json:"dry_run"`
+	Matched   int       `json:"matched"`
+	// Truncated is set when more dead letters match than were listed; run again to get the rest
+	Truncated bool                      `json:"truncated"`
+	Replayed  []pushrepo.FailedDelivery `json:"replayed"`
+	Permanent []Permanent               `json:"permanent"`
+	// Gone counts dead letters replayed or removed by someone else during the run
+	Gone  int    `json:"gone"`
+	Error string `json:"error,omitempty"`
+}
+
+// Replayer returns dead letters to the push queue at a limited rate
+type Replayer struct {
+	repository pushrepo.Repository
+	interval   time.Duration // Pause between two replays
+	maxReplays int           // Dead letters replayed this many times are reported instead
+	dryRun     bool
+}
+
+// Run replays the dead letters matching the filter. Deliveries with a payload the queue
+// cannot send, or that failed again after maxReplays replays, are reported as permanent.
+func (r *Replayer) Run(ctx context.Context, filter pushrepo.FailedDeliveryFilter) Report {
+	report := Report{
+		StartedAt: time.Now().UTC(),
+		From:      filter.From,
+		To:        filter.To,
+		ErrorLike: filter.Error,
+		DryRun:    r.dryRun,
+		Replayed:  []pushrepo.FailedDelivery{},
+		Permanent: []Permanent{},
+	}
+
+	deliveries, err := r.repository.ListFailedDeliveries(ctx, filter)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Matched = len(deliveries)
+	report.Truncated = len(deliveries) == filter.Limit
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	first := true
+	for _, delivery := range deliveries {
+		if reason := permanentReason(delivery, r.maxReplays); reason != "" {
+			report.Permanent = append(report.Permanent, Permanent{FailedDelivery: delivery, Reason: reason})
+			continue
+		}
+		if r.dryRun {
+			report.Replayed = append(report.Replayed, delivery)
+			continue
+		}
+
+		if !first {
+			select {
+			case <-ctx.Done():
+				report.Error = ctx.Err().Error()
+				return report
+			case <-ticker.C:
+			}
+		}
+		first = false
+
+		replayed, err := r.repository.ReplayDelivery(ctx, delivery.ID, time.Now())
+		if err != nil {
+			report.Error = fmt.Sprintf("replaying delivery %s: %v", delivery.ID, err)
+			return report
+		}
+		if !replayed {
+			report.Gone++
+			continue
+		}
+		report.Replayed = append(report.Replayed, delivery)
+	}
+	return report
+}
+
+// permanentReason explains why a dead letter should not be replayed, or returns ""
+func permanentReason(delivery pushrepo.FailedDelivery, maxReplays int) string {
+	var payload push.NotificationPayload
+	if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+		return fmt.Sprintf("invalid payload: %v", err)
+	}
+	if delivery.Replays >= maxReplays {
+		return fmt.Sprintf("failed again after %d replays", delivery.Replays)
+	}
+	return ""
+}
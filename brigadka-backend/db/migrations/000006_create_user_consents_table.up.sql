@@ -0,0 +1,8 @@
+-- Accepted versions of legal documents (terms of service, privacy policy)
+CREATE TABLE user_consents (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document VARCHAR(50) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    accepted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, document, version)
+);
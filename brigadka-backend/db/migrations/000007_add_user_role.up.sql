@@ -0,0 +1,2 @@
+-- Роль пользователя (user, admin)
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
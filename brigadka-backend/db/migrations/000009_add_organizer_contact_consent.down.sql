@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS profile_consent_audit;
+ALTER TABLE profiles DROP COLUMN IF EXISTS allow_organizer_contact;
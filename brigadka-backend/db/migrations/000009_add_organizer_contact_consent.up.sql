@@ -0,0 +1,13 @@
+-- Согласие на связь от организаторов (по умолчанию выключено)
+ALTER TABLE profiles ADD COLUMN allow_organizer_contact BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Журнал изменений согласий профиля
+CREATE TABLE profile_consent_audit (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    setting VARCHAR(50) NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    changed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_profile_consent_audit_user_id ON profile_consent_audit(user_id);
@@ -0,0 +1,9 @@
+-- Избранные профили пользователя
+CREATE TABLE profile_favorites (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    profile_user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, profile_user_id)
+);
+
+CREATE INDEX idx_profile_favorites_user_created ON profile_favorites(user_id, created_at DESC);
@@ -0,0 +1,29 @@
+-- Команды
+CREATE TABLE teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    city_id INT REFERENCES cities(city_id),
+    bio TEXT,
+    avatar_media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    open_slots INT NOT NULL DEFAULT 0 CHECK (open_slots >= 0),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Стили импровизации команды
+CREATE TABLE team_styles (
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    style VARCHAR(50) REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, style)
+);
+
+-- Участники команды
+CREATE TABLE team_members (
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user ON team_members(user_id);
+CREATE INDEX idx_teams_city ON teams(city_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_applications;
+ALTER TABLE teams DROP COLUMN IF EXISTS chat_id;
@@ -0,0 +1,18 @@
+-- Групповой чат команды, создается при первом вступлении участника
+ALTER TABLE teams ADD COLUMN chat_id UUID REFERENCES chats(id) ON DELETE SET NULL;
+
+-- Заявки на вступление в команду
+CREATE TABLE team_applications (
+    id SERIAL PRIMARY KEY,
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMPTZ,
+    decided_by INT REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Не более одной рассматриваемой заявки от пользователя в команду
+CREATE UNIQUE INDEX idx_team_applications_pending ON team_applications(team_id, user_id) WHERE status = 'pending';
+CREATE INDEX idx_team_applications_team_created ON team_applications(team_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS onboarding_formats;
+DROP TABLE IF EXISTS onboarding_answers;
@@ -0,0 +1,15 @@
+-- Ответы на анкету онбординга. Не входят в публичный профиль,
+-- используются для рекомендаций новым пользователям
+CREATE TABLE onboarding_answers (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    schedule VARCHAR(20) CHECK (schedule IN ('weekdays', 'weekends', 'evenings', 'flexible')),
+    experience VARCHAR(20) CHECK (experience IN ('none', 'beginner', 'intermediate', 'advanced')),
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Предпочитаемые форматы (стили импровизации) из анкеты
+CREATE TABLE onboarding_formats (
+    user_id INT NOT NULL REFERENCES onboarding_answers(user_id) ON DELETE CASCADE,
+    style VARCHAR(50) NOT NULL REFERENCES improv_style_catalog(style_code),
+    PRIMARY KEY (user_id, style)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS activities;
+DROP TABLE IF EXISTS team_follows;
+DROP TABLE IF EXISTS user_follows;
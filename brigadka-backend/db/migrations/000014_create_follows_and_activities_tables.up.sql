@@ -0,0 +1,34 @@
+-- Подписки на пользователей
+CREATE TABLE user_follows (
+    follower_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, user_id),
+    CHECK (follower_id <> user_id)
+);
+
+CREATE INDEX idx_user_follows_user ON user_follows(user_id);
+
+-- Подписки на команды
+CREATE TABLE team_follows (
+    follower_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, team_id)
+);
+
+CREATE INDEX idx_team_follows_team ON team_follows(team_id);
+
+-- Журнал активности пользователей и команд для ленты подписок
+CREATE TABLE activities (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    payload JSONB,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id IS NOT NULL OR team_id IS NOT NULL)
+);
+
+CREATE INDEX idx_activities_user ON activities(user_id, id DESC);
+CREATE INDEX idx_activities_team ON activities(team_id, id DESC);
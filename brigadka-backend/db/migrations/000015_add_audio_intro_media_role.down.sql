@@ -0,0 +1,4 @@
+DELETE FROM profile_media WHERE role = 'audio_intro';
+DELETE FROM media_role_catalog WHERE role = 'audio_intro';
+DELETE FROM media WHERE type = 'audio';
+DELETE FROM media_type_catalog WHERE id = 'audio';
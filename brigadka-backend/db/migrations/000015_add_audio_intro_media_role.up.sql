@@ -0,0 +1,5 @@
+-- Аудио как тип медиа
+INSERT INTO media_type_catalog (id) VALUES ('audio');
+
+-- Голосовое представление профиля
+INSERT INTO media_role_catalog (role) VALUES ('audio_intro');
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bot_conversations;
+DELETE FROM users WHERE email = 'bot@brigadka.app';
@@ -0,0 +1,10 @@
+-- Системный пользователь бота «Бригадка» (вход по паролю невозможен)
+INSERT INTO users (email, password_hash, role) VALUES ('bot@brigadka.app', '!', 'bot');
+
+-- Личные чаты с ботом и язык, на котором бот отвечает
+CREATE TABLE bot_conversations (
+    chat_id UUID PRIMARY KEY REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DELETE FROM users WHERE id IN (SELECT user_id FROM bot_accounts);
+DROP TABLE IF EXISTS bot_accounts;
@@ -0,0 +1,12 @@
+-- Боты сообщества: пишут в групповые чаты по API-ключу и получают вебхуки о новых сообщениях
+CREATE TABLE bot_accounts (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL CHECK (LENGTH(TRIM(name)) > 0),
+    api_key_hash CHAR(64) NOT NULL UNIQUE,
+    webhook_url TEXT,
+    webhook_secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_bot_accounts_owner_id ON bot_accounts(owner_id);
@@ -0,0 +1,7 @@
+-- Ссылки на соцсети и сайт профиля
+CREATE TABLE profile_links (
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('instagram', 'telegram', 'youtube', 'website')),
+    url TEXT NOT NULL,
+    PRIMARY KEY (user_id, kind)
+);
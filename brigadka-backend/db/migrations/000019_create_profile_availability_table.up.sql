@@ -0,0 +1,11 @@
+-- Еженедельная доступность исполнителя (день недели ISO 1-7, время в минутах от полуночи)
+CREATE TABLE profile_availability (
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 1 AND 7),
+    start_minute SMALLINT NOT NULL CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute SMALLINT NOT NULL CHECK (end_minute BETWEEN 1 AND 1440),
+    PRIMARY KEY (user_id, day_of_week, start_minute),
+    CHECK (start_minute < end_minute)
+);
+
+CREATE INDEX idx_profile_availability_day ON profile_availability(day_of_week, start_minute, end_minute);
@@ -0,0 +1,14 @@
+-- Напоминания, запланированные участниками чата
+CREATE TABLE chat_reminders (
+    id CHAR(26) PRIMARY KEY,
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text TEXT NOT NULL CHECK (LENGTH(TRIM(text)) BETWEEN 1 AND 500),
+    remind_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'cancelled')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_chat_reminders_chat ON chat_reminders(chat_id, remind_at);
+CREATE INDEX idx_chat_reminders_due ON chat_reminders(remind_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_media_moderation_pending;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderation_provider,
+    DROP COLUMN IF EXISTS nsfw_score,
+    DROP COLUMN IF EXISTS moderation_status;
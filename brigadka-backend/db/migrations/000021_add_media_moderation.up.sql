@@ -0,0 +1,10 @@
+-- Автоматическая модерация медиа: подозрительные файлы ждут ручной проверки
+ALTER TABLE media
+    ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+        CHECK (moderation_status IN ('approved', 'pending', 'rejected')),
+    ADD COLUMN nsfw_score REAL,
+    ADD COLUMN moderation_provider VARCHAR(50),
+    ADD COLUMN moderated_by INT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at TIMESTAMP;
+
+CREATE INDEX idx_media_moderation_pending ON media(uploaded_at) WHERE moderation_status = 'pending';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS suspension_appeals;
+DROP TABLE IF EXISTS user_suspensions;
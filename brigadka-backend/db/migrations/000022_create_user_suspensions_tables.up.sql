@@ -0,0 +1,29 @@
+-- Блокировки аккаунтов, выданные администраторами
+CREATE TABLE user_suspensions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL CHECK (LENGTH(TRIM(reason)) BETWEEN 1 AND 500),
+    suspended_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMPTZ,
+    lifted_at TIMESTAMPTZ,
+    lifted_by INT REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- У пользователя не больше одной действующей блокировки
+CREATE UNIQUE INDEX idx_user_suspensions_active ON user_suspensions(user_id) WHERE lifted_at IS NULL;
+CREATE INDEX idx_user_suspensions_expiring ON user_suspensions(ends_at) WHERE lifted_at IS NULL AND ends_at IS NOT NULL;
+
+-- Обжалования блокировок, одно на блокировку
+CREATE TABLE suspension_appeals (
+    id SERIAL PRIMARY KEY,
+    suspension_id INT NOT NULL UNIQUE REFERENCES user_suspensions(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL CHECK (LENGTH(TRIM(message)) BETWEEN 1 AND 2000),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_suspension_appeals_status ON suspension_appeals(status, created_at);
@@ -0,0 +1,6 @@
+ALTER TABLE messages DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE profiles DROP COLUMN IF EXISTS hidden_at;
+
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS report_reason_translation;
+DROP TABLE IF EXISTS report_reason_catalog;
@@ -0,0 +1,54 @@
+-- Справочник причин жалоб
+CREATE TABLE report_reason_catalog (
+    reason_code VARCHAR(50) PRIMARY KEY
+);
+
+-- Переводы причин жалоб
+CREATE TABLE report_reason_translation (
+    reason_code VARCHAR(50) REFERENCES report_reason_catalog(reason_code) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    label TEXT NOT NULL,
+    PRIMARY KEY (reason_code, lang)
+);
+
+INSERT INTO report_reason_catalog (reason_code) VALUES
+    ('spam'),
+    ('harassment'),
+    ('inappropriate'),
+    ('fake_profile'),
+    ('other');
+
+INSERT INTO report_reason_translation (reason_code, lang, label) VALUES
+    ('spam', 'ru', 'Спам'),
+    ('spam', 'en', 'Spam'),
+    ('harassment', 'ru', 'Оскорбления или травля'),
+    ('harassment', 'en', 'Harassment'),
+    ('inappropriate', 'ru', 'Неприемлемый контент'),
+    ('inappropriate', 'en', 'Inappropriate content'),
+    ('fake_profile', 'ru', 'Фейковый профиль'),
+    ('fake_profile', 'en', 'Fake profile'),
+    ('other', 'ru', 'Другое'),
+    ('other', 'en', 'Other');
+
+-- Жалобы на профили, сообщения и медиа
+CREATE TABLE reports (
+    id SERIAL PRIMARY KEY,
+    reporter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('profile', 'message', 'media')),
+    target_id VARCHAR(64) NOT NULL,
+    reason_code VARCHAR(50) NOT NULL REFERENCES report_reason_catalog(reason_code),
+    comment TEXT NOT NULL DEFAULT '' CHECK (LENGTH(comment) <= 1000),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_by INT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- Пользователь может держать открытой только одну жалобу на объект
+CREATE UNIQUE INDEX idx_reports_open_per_reporter ON reports(reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX idx_reports_target ON reports(target_type, target_id);
+CREATE INDEX idx_reports_status ON reports(status, created_at);
+
+-- Скрытие контента модераторами
+ALTER TABLE profiles ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE messages ADD COLUMN hidden_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS admin_audit_log;
+ALTER TABLE profiles DROP COLUMN IF EXISTS verified_at;
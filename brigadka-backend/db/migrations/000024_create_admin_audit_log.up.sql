@@ -0,0 +1,16 @@
+-- Подтверждение профилей администраторами
+ALTER TABLE profiles ADD COLUMN verified_at TIMESTAMPTZ;
+
+-- Журнал действий администраторов
+CREATE TABLE admin_audit_log (
+    id SERIAL PRIMARY KEY,
+    admin_id INT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(64) NOT NULL,
+    details JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target_type, target_id, created_at);
+CREATE INDEX idx_admin_audit_log_admin ON admin_audit_log(admin_id, created_at);
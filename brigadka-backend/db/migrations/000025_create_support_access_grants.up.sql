@@ -0,0 +1,6 @@
+-- Согласие пользователя на просмотр аккаунта поддержкой от его имени
+CREATE TABLE support_access_grants (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS improv_profile_goals;
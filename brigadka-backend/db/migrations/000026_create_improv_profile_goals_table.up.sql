@@ -0,0 +1,12 @@
+-- Таблица соответствий профилей и целей импровизации.
+-- profiles.goal остаётся основной целью для старых клиентов и входит в этот список.
+CREATE TABLE improv_profile_goals (
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    goal VARCHAR(50) REFERENCES improv_goals_catalog(goal_id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, goal)
+);
+
+CREATE INDEX idx_improv_profile_goals_goal ON improv_profile_goals(goal);
+
+INSERT INTO improv_profile_goals (user_id, goal)
+SELECT user_id, goal FROM profiles WHERE goal IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS profile_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,18 @@
+-- Произвольные теги профилей («музимпров», «плейбек»).
+-- Теги хранятся в нормализованном виде (нижний регистр, без «#»), один раз на всех.
+CREATE TABLE tags (
+    tag_id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Индекс для автодополнения по префиксу
+CREATE INDEX idx_tags_name_prefix ON tags(name text_pattern_ops);
+
+CREATE TABLE profile_tags (
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    tag_id INT REFERENCES tags(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX idx_profile_tags_tag_id ON profile_tags(tag_id);
@@ -0,0 +1,6 @@
+-- Прогресс онбординга, который нельзя вычислить из других таблиц.
+-- Время первого поиска фиксируется один раз и больше не меняется.
+CREATE TABLE onboarding_progress (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    first_search_at TIMESTAMPTZ NOT NULL
+);
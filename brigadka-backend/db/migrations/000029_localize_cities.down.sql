@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS city_translation;
+
+DELETE FROM cities
+WHERE name IN ('Новосибирск', 'Екатеринбург', 'Казань', 'Нижний Новгород', 'Краснодар',
+               'Самара', 'Минск', 'Алматы', 'Тбилиси', 'Ереван')
+  AND city_id NOT IN (SELECT city_id FROM profiles WHERE city_id IS NOT NULL
+                      UNION SELECT city_id FROM teams WHERE city_id IS NOT NULL);
+
+DROP INDEX IF EXISTS idx_cities_country_code;
+
+ALTER TABLE cities
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS country_code;
@@ -0,0 +1,57 @@
+-- Страна, регион и часовой пояс городов.
+-- cities.name и cities.region остаются значениями по умолчанию (на русском),
+-- переводы хранятся в city_translation.
+ALTER TABLE cities
+    ADD COLUMN country_code CHAR(2) NOT NULL DEFAULT 'RU', -- ISO 3166-1 alpha-2
+    ADD COLUMN region VARCHAR(255),
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow'; -- Идентификатор IANA
+
+ALTER TABLE cities ALTER COLUMN country_code DROP DEFAULT;
+ALTER TABLE cities ALTER COLUMN timezone DROP DEFAULT;
+
+CREATE INDEX idx_cities_country_code ON cities(country_code);
+
+-- Переводы названий городов и регионов
+CREATE TABLE city_translation (
+    city_id INT REFERENCES cities(city_id) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    region VARCHAR(255),
+    PRIMARY KEY (city_id, lang)
+);
+
+UPDATE cities SET region = 'Москва' WHERE name = 'Москва';
+UPDATE cities SET region = 'Санкт-Петербург' WHERE name = 'Санкт-Петербург';
+
+INSERT INTO cities (name, country_code, region, timezone) VALUES
+    ('Новосибирск', 'RU', 'Новосибирская область', 'Asia/Novosibirsk'),
+    ('Екатеринбург', 'RU', 'Свердловская область', 'Asia/Yekaterinburg'),
+    ('Казань', 'RU', 'Республика Татарстан', 'Europe/Moscow'),
+    ('Нижний Новгород', 'RU', 'Нижегородская область', 'Europe/Moscow'),
+    ('Краснодар', 'RU', 'Краснодарский край', 'Europe/Moscow'),
+    ('Самара', 'RU', 'Самарская область', 'Europe/Samara'),
+    ('Минск', 'BY', NULL, 'Europe/Minsk'),
+    ('Алматы', 'KZ', NULL, 'Asia/Almaty'),
+    ('Тбилиси', 'GE', NULL, 'Asia/Tbilisi'),
+    ('Ереван', 'AM', NULL, 'Asia/Yerevan');
+
+INSERT INTO city_translation (city_id, lang, name, region)
+SELECT city_id, 'ru', name, region FROM cities;
+
+INSERT INTO city_translation (city_id, lang, name, region)
+SELECT c.city_id, 'en', t.name, t.region
+FROM cities c
+JOIN (VALUES
+    ('Москва', 'Moscow', 'Moscow'),
+    ('Санкт-Петербург', 'Saint Petersburg', 'Saint Petersburg'),
+    ('Новосибирск', 'Novosibirsk', 'Novosibirsk Oblast'),
+    ('Екатеринбург', 'Yekaterinburg', 'Sverdlovsk Oblast'),
+    ('Казань', 'Kazan', 'Republic of Tatarstan'),
+    ('Нижний Новгород', 'Nizhny Novgorod', 'Nizhny Novgorod Oblast'),
+    ('Краснодар', 'Krasnodar', 'Krasnodar Krai'),
+    ('Самара', 'Samara', 'Samara Oblast'),
+    ('Минск', 'Minsk', NULL),
+    ('Алматы', 'Almaty', NULL),
+    ('Тбилиси', 'Tbilisi', NULL),
+    ('Ереван', 'Yerevan', NULL)
+) AS t(source_name, name, region) ON t.source_name = c.name;
@@ -0,0 +1,3 @@
+-- Создатель группового чата. От его верификации зависит лимит участников.
+-- Для чатов, созданных до миграции, создатель неизвестен и действует обычный лимит.
+ALTER TABLE chats ADD COLUMN created_by INT REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_cities_location;
+
+ALTER TABLE cities
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
+
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
@@ -0,0 +1,29 @@
+-- Координаты городов для поиска профилей рядом с точкой.
+-- Расстояния считаются расширением earthdistance (поверх cube).
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+ALTER TABLE cities
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION;
+
+UPDATE cities c SET latitude = t.latitude, longitude = t.longitude
+FROM (VALUES
+    ('Москва', 55.7558, 37.6173),
+    ('Санкт-Петербург', 59.9311, 30.3609),
+    ('Новосибирск', 55.0084, 82.9357),
+    ('Екатеринбург', 56.8389, 60.6057),
+    ('Казань', 55.7961, 49.1064),
+    ('Нижний Новгород', 56.2965, 43.9361),
+    ('Краснодар', 45.0355, 38.9753),
+    ('Самара', 53.1959, 50.1002),
+    ('Минск', 53.9006, 27.5590),
+    ('Алматы', 43.2220, 76.8512),
+    ('Тбилиси', 41.7151, 44.8271),
+    ('Ереван', 40.1872, 44.5152)
+) AS t(name, latitude, longitude)
+WHERE c.name = t.name;
+
+-- Индекс для отбора городов в радиусе через earth_box
+CREATE INDEX idx_cities_location ON cities USING gist (ll_to_earth(latitude, longitude))
+    WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_profiles_bio_trgm;
+DROP INDEX IF EXISTS idx_profiles_full_name_trgm;
+DROP INDEX IF EXISTS idx_profiles_search_vector;
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,13 @@
+-- Полнотекстовый поиск по имени и описанию профиля.
+-- Имя индексируется без стемминга (конфигурация simple), описание — с русским стеммингом.
+-- pg_trgm дает устойчивость к опечаткам.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE profiles ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(full_name, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(bio, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_profiles_search_vector ON profiles USING gin (search_vector);
+CREATE INDEX idx_profiles_full_name_trgm ON profiles USING gin (full_name gin_trgm_ops);
+CREATE INDEX idx_profiles_bio_trgm ON profiles USING gin (bio gin_trgm_ops);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS media_search_sync ON media;
+DROP TRIGGER IF EXISTS profile_media_search_sync ON profile_media;
+DROP TRIGGER IF EXISTS profile_tags_search_sync ON profile_tags;
+DROP TRIGGER IF EXISTS improv_profile_goals_search_sync ON improv_profile_goals;
+DROP TRIGGER IF EXISTS improv_profile_styles_search_sync ON improv_profile_styles;
+DROP TRIGGER IF EXISTS profiles_search_sync ON profiles;
+
+DROP FUNCTION IF EXISTS enqueue_media_search_sync();
+DROP FUNCTION IF EXISTS enqueue_profile_search_sync();
+
+DROP TABLE IF EXISTS profile_search_outbox;
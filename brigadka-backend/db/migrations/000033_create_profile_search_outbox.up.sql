@@ -0,0 +1,61 @@
+-- Очередь профилей для синхронизации с внешним поисковым индексом (OpenSearch).
+-- Триггеры ставят профиль в очередь при любом изменении данных, по которым ищут;
+-- индексатор читает очередь и удаляет обработанные записи.
+-- На профиль приходится не больше одной записи: повторное изменение обновляет enqueued_at.
+CREATE TABLE profile_search_outbox (
+    user_id INT PRIMARY KEY,
+    enqueued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_search_outbox_enqueued_at ON profile_search_outbox (enqueued_at);
+
+CREATE FUNCTION enqueue_profile_search_sync() RETURNS trigger AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    INSERT INTO profile_search_outbox (user_id, enqueued_at)
+    VALUES (changed.user_id, clock_timestamp())
+    ON CONFLICT (user_id) DO UPDATE SET enqueued_at = EXCLUDED.enqueued_at;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER profiles_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON profiles
+    FOR EACH ROW EXECUTE FUNCTION enqueue_profile_search_sync();
+
+CREATE TRIGGER improv_profile_styles_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON improv_profile_styles
+    FOR EACH ROW EXECUTE FUNCTION enqueue_profile_search_sync();
+
+CREATE TRIGGER improv_profile_goals_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON improv_profile_goals
+    FOR EACH ROW EXECUTE FUNCTION enqueue_profile_search_sync();
+
+CREATE TRIGGER profile_tags_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON profile_tags
+    FOR EACH ROW EXECUTE FUNCTION enqueue_profile_search_sync();
+
+CREATE TRIGGER profile_media_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON profile_media
+    FOR EACH ROW EXECUTE FUNCTION enqueue_profile_search_sync();
+
+-- Результат модерации меняет наличие аватара и видео у профилей, использующих медиа
+CREATE FUNCTION enqueue_media_search_sync() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO profile_search_outbox (user_id, enqueued_at)
+    SELECT DISTINCT user_id, clock_timestamp() FROM profile_media WHERE media_id = NEW.id
+    ON CONFLICT (user_id) DO UPDATE SET enqueued_at = EXCLUDED.enqueued_at;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER media_search_sync
+    AFTER UPDATE OF moderation_status ON media
+    FOR EACH ROW EXECUTE FUNCTION enqueue_media_search_sync();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS message_attachments;
+
+-- Сообщения без текста были отправлены только с вложениями
+DELETE FROM messages WHERE LENGTH(TRIM(content)) = 0;
+ALTER TABLE messages ADD CONSTRAINT messages_content_check CHECK (LENGTH(TRIM(content)) > 0);
@@ -0,0 +1,13 @@
+-- Вложения сообщений: фото и видео, загруженные отправителем.
+-- position сохраняет порядок вложений в сообщении.
+CREATE TABLE message_attachments (
+    message_id UUID REFERENCES messages(id) ON DELETE CASCADE,
+    media_id INT REFERENCES media(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (message_id, media_id)
+);
+
+CREATE INDEX idx_message_attachments_media_id ON message_attachments(media_id);
+
+-- Сообщение с вложениями может быть без текста; пустые сообщения без вложений отклоняет сервис
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_check;
@@ -0,0 +1,2 @@
+-- Курсорная пагинация сообщений чата по seq (before_seq / after_seq)
+CREATE INDEX idx_messages_chat_id_seq ON messages(chat_id, seq);
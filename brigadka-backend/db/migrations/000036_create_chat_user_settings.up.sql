@@ -0,0 +1,10 @@
+-- Настройки чата для отдельного участника: отключенные уведомления и архив.
+-- Уведомления отключены, если muted_at задан и muted_until пуст (бессрочно) или еще не наступил.
+CREATE TABLE chat_user_settings (
+    chat_id UUID REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    muted_at TIMESTAMPTZ,
+    muted_until TIMESTAMPTZ,
+    archived_at TIMESTAMPTZ,
+    PRIMARY KEY (chat_id, user_id)
+);
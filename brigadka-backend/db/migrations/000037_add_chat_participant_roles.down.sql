@@ -0,0 +1 @@
+ALTER TABLE chat_participants DROP COLUMN IF EXISTS role;
@@ -0,0 +1,21 @@
+-- Роли участников чата: администратор группы может удалять участников,
+-- переименовывать чат и назначать других администраторов.
+ALTER TABLE chat_participants
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member'));
+
+-- Создатели существующих групповых чатов становятся администраторами
+UPDATE chat_participants cp SET role = 'admin'
+FROM chats c
+WHERE c.id = cp.chat_id AND c.is_group AND c.created_by = cp.user_id;
+
+-- В группах без создателя администратором становится самый давний участник
+UPDATE chat_participants cp SET role = 'admin'
+FROM (
+    SELECT DISTINCT ON (p.chat_id) p.chat_id, p.user_id
+    FROM chat_participants p
+    JOIN chats c ON c.id = p.chat_id
+    WHERE c.is_group
+      AND NOT EXISTS (SELECT 1 FROM chat_participants a WHERE a.chat_id = p.chat_id AND a.role = 'admin')
+    ORDER BY p.chat_id, p.joined_at, p.user_id
+) first_participants
+WHERE cp.chat_id = first_participants.chat_id AND cp.user_id = first_participants.user_id;
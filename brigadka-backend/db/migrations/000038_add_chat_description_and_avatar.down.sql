@@ -0,0 +1,3 @@
+ALTER TABLE chats
+    DROP COLUMN IF EXISTS avatar_media_id,
+    DROP COLUMN IF EXISTS description;
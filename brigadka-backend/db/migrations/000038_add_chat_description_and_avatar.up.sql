@@ -0,0 +1,4 @@
+-- Описание и аватар группового чата; меняют администраторы чата
+ALTER TABLE chats
+    ADD COLUMN description TEXT CHECK (description IS NULL OR LENGTH(description) <= 1000),
+    ADD COLUMN avatar_media_id INT REFERENCES media(id) ON DELETE SET NULL;
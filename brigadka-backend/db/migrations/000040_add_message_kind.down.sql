@@ -0,0 +1,5 @@
+DELETE FROM messages WHERE kind = 'system';
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS target_user_id,
+    DROP COLUMN IF EXISTS system_event,
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,6 @@
+-- Системные сообщения («Анна добавила Бориса», «чат переименован»): sender_id — автор изменения,
+-- system_event — вид изменения, target_user_id — участник, которого оно касается
+ALTER TABLE messages
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'user' CHECK (kind IN ('user', 'system')),
+    ADD COLUMN system_event VARCHAR(32),
+    ADD COLUMN target_user_id INT REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+-- Доставка сообщений в личных чатах: до какого сообщения устройство получателя подтвердило получение.
+-- Как и позиция прочтения, позиция доставки только растет.
+CREATE TABLE message_delivery_receipts (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    chat_id UUID REFERENCES chats(id) ON DELETE CASCADE,
+    last_delivered_seq BIGINT NOT NULL,
+    delivered_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, chat_id)
+);
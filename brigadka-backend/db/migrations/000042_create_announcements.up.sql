@@ -0,0 +1,16 @@
+-- Объявления администрации: общий канал, на который подписаны все пользователи.
+-- Публикуют только администраторы; id — ULID, поэтому объявления упорядочены по id.
+CREATE TABLE announcements (
+    id CHAR(26) PRIMARY KEY,
+    author_id INT REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL CHECK (LENGTH(TRIM(title)) > 0),
+    body TEXT NOT NULL CHECK (LENGTH(TRIM(body)) BETWEEN 1 AND 4000),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Позиция прочтения объявлений: прочитаны все объявления с id не больше last_read_id
+CREATE TABLE announcement_reads (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_read_id CHAR(26) NOT NULL,
+    read_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE chat_user_settings DROP COLUMN IF EXISTS requested_at;
+DROP TABLE IF EXISTS direct_message_settings;
@@ -0,0 +1,14 @@
+-- Кто может начинать личные чаты с пользователем.
+-- everyone: все; filtered: те, на кого пользователь подписан, а также участники его команд
+-- и верифицированные пользователи, если пользователь их разрешил.
+CREATE TABLE direct_message_settings (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    policy VARCHAR(20) NOT NULL DEFAULT 'everyone' CHECK (policy IN ('everyone', 'filtered')),
+    allow_teammates BOOLEAN NOT NULL DEFAULT TRUE,
+    allow_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Личный чат от незнакомого пользователя, который участник еще не принял.
+-- Такой чат не попадает в список чатов и не присылает push-уведомления.
+ALTER TABLE chat_user_settings ADD COLUMN requested_at TIMESTAMPTZ;
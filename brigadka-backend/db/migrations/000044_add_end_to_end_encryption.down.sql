@@ -0,0 +1,3 @@
+ALTER TABLE messages DROP COLUMN IF EXISTS ciphertext;
+DROP TABLE IF EXISTS device_one_time_prekeys;
+DROP TABLE IF EXISTS device_keys;
@@ -0,0 +1,26 @@
+-- Открытые ключи устройств для сквозного шифрования личных чатов.
+-- Сервер хранит только открытые ключи; закрытые не покидают устройство.
+CREATE TABLE device_keys (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(64),
+    identity_key TEXT NOT NULL,
+    signed_prekey_id INT NOT NULL,
+    signed_prekey TEXT NOT NULL,
+    signed_prekey_signature TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, device_id)
+);
+
+-- Одноразовые ключи устройства: каждый выдается собеседнику один раз и удаляется
+CREATE TABLE device_one_time_prekeys (
+    user_id INT,
+    device_id VARCHAR(64),
+    key_id INT,
+    public_key TEXT NOT NULL,
+    PRIMARY KEY (user_id, device_id, key_id),
+    FOREIGN KEY (user_id, device_id) REFERENCES device_keys(user_id, device_id) ON DELETE CASCADE
+);
+
+-- Зашифрованные сообщения: сервер хранит и пересылает шифротекст, не зная содержимого; content пуст
+ALTER TABLE messages ADD COLUMN ciphertext TEXT;
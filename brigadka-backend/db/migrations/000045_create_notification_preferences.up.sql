@@ -0,0 +1,15 @@
+-- Настройки push-уведомлений пользователя; без строки действуют значения по умолчанию.
+-- Тихие часы задаются минутами от начала суток в часовом поясе пользователя,
+-- по умолчанию — в часовом поясе его города.
+CREATE TABLE notification_preferences (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_message BOOLEAN NOT NULL DEFAULT TRUE,
+    new_match BOOLEAN NOT NULL DEFAULT TRUE,
+    team_application BOOLEAN NOT NULL DEFAULT TRUE,
+    announcements BOOLEAN NOT NULL DEFAULT TRUE,
+    quiet_hours_start INT CHECK (quiet_hours_start BETWEEN 0 AND 1439),
+    quiet_hours_end INT CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    timezone VARCHAR(64),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
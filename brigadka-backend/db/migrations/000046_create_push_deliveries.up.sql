@@ -0,0 +1,15 @@
+-- Очередь доставки push-уведомлений. Воркер забирает записи, у которых наступило
+-- next_attempt_at, и отодвигает его на время попытки, чтобы упавшую попытку повторил другой.
+-- Доставленные записи удаляются; исчерпавшие попытки остаются с failed_at для разбора.
+CREATE TABLE push_deliveries (
+    id TEXT PRIMARY KEY, -- ULID, задается сервером
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL, -- NotificationPayload в JSON
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    failed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_push_deliveries_next_attempt_at ON push_deliveries (next_attempt_at, id) WHERE failed_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE push_tokens DROP COLUMN IF EXISTS last_success_at;
@@ -0,0 +1,3 @@
+-- Время последней доставки на устройство: провайдер принял уведомление для этого токена.
+-- NULL, пока ни одно уведомление не было доставлено.
+ALTER TABLE push_tokens ADD COLUMN last_success_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_push_deliveries_user_category;
+ALTER TABLE push_deliveries DROP COLUMN IF EXISTS category;
@@ -0,0 +1,5 @@
+-- Категория уведомления в очереди. Уведомления категории с окном агрегации ждут конца окна
+-- и отправляются одним сводным уведомлением; пока окно открыто, у записей attempts = 0.
+ALTER TABLE push_deliveries ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_push_deliveries_user_category ON push_deliveries (user_id, category) WHERE failed_at IS NULL AND attempts = 0;
@@ -0,0 +1,22 @@
+-- Рассылки push-уведомлений администраторов сегменту пользователей в заданное время.
+-- Сегмент — пересечение заданных фильтров; без фильтров рассылка идет всем с зарегистрированным устройством.
+-- Воркер переводит наступившие рассылки в sending, ставит уведомления в очередь и сохраняет счетчики.
+CREATE TABLE push_campaigns (
+    id CHAR(26) PRIMARY KEY,
+    author_id INT REFERENCES users(id) ON DELETE SET NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    segment_city_id INT REFERENCES cities(city_id),
+    segment_looking_for_team BOOLEAN,
+    segment_inactive_days INT CHECK (segment_inactive_days > 0), -- Без сообщений и входов в приложение столько дней
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'sending', 'sent', 'cancelled')),
+    recipients INT,                -- Размер сегмента на момент отправки
+    queued INT NOT NULL DEFAULT 0, -- Уведомления, поставленные в очередь доставки
+    failed INT NOT NULL DEFAULT 0, -- Уведомления, которые не удалось поставить в очередь
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_push_campaigns_due ON push_campaigns(scheduled_at) WHERE status = 'scheduled';
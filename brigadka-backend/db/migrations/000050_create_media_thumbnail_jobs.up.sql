@@ -0,0 +1,11 @@
+-- Превью для загрузок без thumbnail генерирует фоновый воркер: изображения уменьшаются,
+-- из видео берется кадр через ffmpeg. До готовности превью thumbnail_url медиа пустой.
+CREATE TABLE media_thumbnail_jobs (
+    media_id INT PRIMARY KEY REFERENCES media(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    failed_at TIMESTAMPTZ -- Попытки закончились; превью не будет
+);
+
+CREATE INDEX idx_media_thumbnail_jobs_due ON media_thumbnail_jobs(next_attempt_at) WHERE failed_at IS NULL;
@@ -0,0 +1,8 @@
+-- Уменьшенные копии изображений для аватаров и списков: {"128": url, "512": url}.
+-- Оригинал остается в url. Копии генерирует воркер превью, поэтому для уже загруженных
+-- изображений ставятся задачи, и они получают копии в фоне.
+ALTER TABLE media ADD COLUMN variants JSONB NOT NULL DEFAULT '{}';
+
+INSERT INTO media_thumbnail_jobs (media_id)
+SELECT id FROM media WHERE type = 'image'
+ON CONFLICT (media_id) DO NOTHING;
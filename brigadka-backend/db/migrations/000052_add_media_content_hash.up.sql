@@ -0,0 +1,6 @@
+-- SHA-256 содержимого загруженного файла. Повторная загрузка того же файла тем же
+-- пользователем возвращает уже сохраненное медиа вместо новой копии в хранилище.
+-- У медиа, загруженных раньше, хеша нет.
+ALTER TABLE media ADD COLUMN content_hash CHAR(64);
+
+CREATE INDEX idx_media_owner_content_hash ON media(owner_id, content_hash) WHERE content_hash IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Размер загруженного файла вместе с thumbnail — из него складывается занятое пользователем
+-- место, которое ограничивает квота. Медиа, загруженные раньше, в квоту не засчитываются.
+ALTER TABLE media ADD COLUMN size_bytes BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Рекомендации участников по стилям импровизации; оставляются только теми, с кем человек в одной команде
+CREATE TABLE profile_endorsements (
+    endorser_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    profile_user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    style VARCHAR(50) NOT NULL REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    -- Команда, в которой участники пересеклись
+    team_id INT REFERENCES teams(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (endorser_id, profile_user_id, style),
+    CHECK (endorser_id <> profile_user_id)
+);
+
+CREATE INDEX idx_profile_endorsements_profile ON profile_endorsements(profile_user_id, style);
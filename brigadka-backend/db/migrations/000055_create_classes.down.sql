@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS class_enrollments;
+DROP TABLE IF EXISTS classes;
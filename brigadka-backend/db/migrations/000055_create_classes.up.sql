@@ -0,0 +1,33 @@
+-- Мастер-классы и курсы, которые ведут преподаватели
+CREATE TABLE classes (
+    id SERIAL PRIMARY KEY,
+    teacher_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    city_id INT REFERENCES cities(city_id),
+    level VARCHAR(20) NOT NULL CHECK (level IN ('beginner', 'intermediate', 'advanced', 'all')),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    -- Расписание занятий в свободной форме, например «по вторникам в 19:00»
+    schedule TEXT NOT NULL DEFAULT '',
+    capacity INT NOT NULL CHECK (capacity > 0),
+    -- Цена в минимальных единицах валюты; 0 — бесплатно
+    price_cents INT NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+    currency CHAR(3),
+    -- Групповой чат участников, создается при первой записи
+    chat_id UUID REFERENCES chats(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+-- Записи учеников на занятия
+CREATE TABLE class_enrollments (
+    class_id INT REFERENCES classes(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    enrolled_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (class_id, user_id)
+);
+
+CREATE INDEX idx_classes_ends_at ON classes(ends_at);
+CREATE INDEX idx_classes_teacher ON classes(teacher_id);
+CREATE INDEX idx_class_enrollments_user ON class_enrollments(user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS partner_requests;
+DROP TABLE IF EXISTS partner_matches;
@@ -0,0 +1,28 @@
+-- Подобранные пары партнеров для практики
+CREATE TABLE partner_matches (
+    id SERIAL PRIMARY KEY,
+    user_id1 INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id2 INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- Личный чат пары, NULL если его не удалось создать
+    chat_id UUID REFERENCES chats(id) ON DELETE SET NULL,
+    matched_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id1 <> user_id2)
+);
+
+-- Очередь поиска партнера на вечер: город, уровень и время, когда пользователь свободен
+CREATE TABLE partner_requests (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    city_id INT NOT NULL REFERENCES cities(city_id),
+    level VARCHAR(20) NOT NULL CHECK (level IN ('beginner', 'intermediate', 'advanced')),
+    available_from TIMESTAMPTZ NOT NULL,
+    available_until TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'matched', 'cancelled', 'expired')),
+    match_id INT REFERENCES partner_matches(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (available_until > available_from)
+);
+
+-- В очереди может быть только одна заявка пользователя
+CREATE UNIQUE INDEX idx_partner_requests_waiting_user ON partner_requests(user_id) WHERE status = 'waiting';
+CREATE INDEX idx_partner_requests_waiting ON partner_requests(city_id, available_until) WHERE status = 'waiting';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_messages_sent_at;
+DROP INDEX IF EXISTS idx_users_created_at;
+ALTER TABLE profiles DROP COLUMN IF EXISTS shadowbanned_at;
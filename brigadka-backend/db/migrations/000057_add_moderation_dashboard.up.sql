@@ -0,0 +1,7 @@
+-- Теневой бан: профиль пропадает из поиска, рекомендаций и подсказок тегов,
+-- но пользователь об этом не узнает, в отличие от скрытия профиля (hidden_at)
+ALTER TABLE profiles ADD COLUMN shadowbanned_at TIMESTAMPTZ;
+
+-- Статистика регистраций и сообщений для панели модерации
+CREATE INDEX idx_users_created_at ON users(created_at);
+CREATE INDEX idx_messages_sent_at ON messages(sent_at);
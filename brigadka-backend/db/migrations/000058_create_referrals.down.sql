@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_messages_sender_id;
+DROP TABLE IF EXISTS referrals;
+DROP TABLE IF EXISTS referral_codes;
@@ -0,0 +1,19 @@
+-- Реферальный код пользователя, создается при первом запросе статистики
+CREATE TABLE referral_codes (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Регистрации по реферальным кодам. Приглашенный считается активным,
+-- когда создал профиль и отправил первое сообщение
+CREATE TABLE referrals (
+    referred_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    referrer_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (referred_id <> referrer_id)
+);
+
+CREATE INDEX idx_referrals_referrer_id ON referrals(referrer_id);
+CREATE INDEX idx_messages_sender_id ON messages(sender_id);
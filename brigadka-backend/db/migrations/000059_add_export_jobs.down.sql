@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_exports_pending;
+ALTER TABLE exports DROP COLUMN IF EXISTS lease_until;
+ALTER TABLE exports DROP COLUMN IF EXISTS attempts;
+ALTER TABLE exports DROP COLUMN IF EXISTS params;
@@ -0,0 +1,11 @@
+-- Выгрузки выполняет фоновый воркер: параметры хранятся с выгрузкой, чтобы после
+-- перезапуска ее можно было сгенерировать заново, а аренда не дает двум репликам взять одну
+ALTER TABLE exports ADD COLUMN params JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE exports ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE exports ADD COLUMN lease_until TIMESTAMPTZ;
+
+-- Незавершенные выгрузки без параметров уже не сгенерировать
+UPDATE exports SET status = 'failed', error = 'export was interrupted', completed_at = CURRENT_TIMESTAMP
+WHERE status = 'pending';
+
+CREATE INDEX idx_exports_pending ON exports(lease_until) WHERE status = 'pending';
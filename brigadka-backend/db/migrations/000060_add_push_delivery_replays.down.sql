@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_push_deliveries_failed_at;
+ALTER TABLE push_deliveries DROP COLUMN IF EXISTS replays;
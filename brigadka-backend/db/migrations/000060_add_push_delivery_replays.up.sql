@@ -0,0 +1,5 @@
+-- pushreplay возвращает упавшие доставки в очередь; счетчик повторов отличает
+-- доставки, которые падают и после повтора, от задетых временным сбоем APNS/FCM
+ALTER TABLE push_deliveries ADD COLUMN replays INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_push_deliveries_failed_at ON push_deliveries (failed_at, id) WHERE failed_at IS NOT NULL;
@@ -105,6 +105,35 @@ func (s *AuthIntegrationTestSuite) TestRegisterDuplicate() {
 	assert.Equal(t, http.StatusConflict, duplicateResp.StatusCode, "Should return status 409 Conflict")
 }
 
+// TestRegisterWeakPassword tests that registration rejects passwords violating the policy
+func (s *AuthIntegrationTestSuite) TestRegisterWeakPassword() {
+	t := s.T()
+
+	registerData := auth.RegisterRequest{
+		Email:    generateTestEmail(),
+		Password: "short",
+	}
+
+	registerJSON, _ := json.Marshal(registerData)
+	req, _ := http.NewRequest("POST", s.appUrl+"/api/auth/register", bytes.NewBuffer(registerJSON))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should return status 400 Bad Request")
+
+	// Check field-level errors
+	var validationResponse auth.ValidationErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&validationResponse)
+	assert.NoError(t, err)
+	assert.Contains(t, validationResponse.Fields["password"], "too_short")
+	assert.Contains(t, validationResponse.Fields["password"], "missing_uppercase")
+	assert.Contains(t, validationResponse.Fields["password"], "missing_digit")
+}
+
 // TestLogin tests the login endpoint
 func (s *AuthIntegrationTestSuite) TestLogin() {
 	t := s.T()
@@ -390,6 +419,54 @@ func (s *AuthIntegrationTestSuite) TestProtectedEndpointUnauthorized() {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Should return status 401 Unauthorized")
 }
 
+// TestGuestToken tests that guest tokens can browse catalogs but cannot use messaging
+func (s *AuthIntegrationTestSuite) TestGuestToken() {
+	t := s.T()
+
+	req, _ := http.NewRequest("POST", s.appUrl+"/api/auth/guest", nil)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Should return status 200 OK")
+
+	var guestResponse auth.GuestTokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&guestResponse)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, guestResponse.Token, "Token should not be empty")
+	assert.Equal(t, "guest", guestResponse.Scope)
+
+	// Catalogs are readable with a guest token
+	catalogReq, _ := http.NewRequest("GET", s.appUrl+"/api/profiles/catalog/cities", nil)
+	catalogReq.Header.Set("Authorization", "Bearer "+guestResponse.Token)
+
+	catalogResp, err := client.Do(catalogReq)
+	assert.NoError(t, err)
+	defer catalogResp.Body.Close()
+	assert.Equal(t, http.StatusOK, catalogResp.StatusCode, "Guest should be able to read catalogs")
+
+	// Messaging is not available to guests
+	chatsReq, _ := http.NewRequest("GET", s.appUrl+"/api/chats", nil)
+	chatsReq.Header.Set("Authorization", "Bearer "+guestResponse.Token)
+
+	chatsResp, err := client.Do(chatsReq)
+	assert.NoError(t, err)
+	defer chatsResp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, chatsResp.StatusCode, "Guest should not be able to access chats")
+
+	// Profile creation is not available to guests
+	createReq, _ := http.NewRequest("POST", s.appUrl+"/api/profiles", bytes.NewBufferString("{}"))
+	createReq.Header.Set("Authorization", "Bearer "+guestResponse.Token)
+	createReq.Header.Set("Content-Type", "application/json")
+
+	createResp, err := client.Do(createReq)
+	assert.NoError(t, err)
+	defer createResp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, createResp.StatusCode, "Guest should not be able to create profiles")
+}
+
 // TestAuthIntegration runs the auth integration test suite
 func TestAuthIntegration(t *testing.T) {
 	// Skip tests if SKIP_INTEGRATION_TESTS environment variable is set
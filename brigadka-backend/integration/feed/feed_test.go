@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/auth"
+	teamhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/team"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// FeedIntegrationTestSuite defines a set of integration tests for follows and the activity feed
+type FeedIntegrationTestSuite struct {
+	suite.Suite
+	appUrl string
+}
+
+// SetupSuite prepares the test environment before running all tests
+func (s *FeedIntegrationTestSuite) SetupSuite() {
+	s.appUrl = os.Getenv("APP_URL")
+	if s.appUrl == "" {
+		s.appUrl = "http://localhost:8080" // Default for local testing
+	}
+}
+
+// Helper function to register a test user and return the auth token and user ID
+func (s *FeedIntegrationTestSuite) registerTestUser() (string, int) {
+	registerData := auth.RegisterRequest{
+		Email:    fmt.Sprintf("test_feed_%d_%d@example.com", os.Getpid(), time.Now().UnixNano()),
+		Password: "TestPassword123!",
+	}
+
+	registerJSON, _ := json.Marshal(registerData)
+	resp, err := http.Post(s.appUrl+"/api/auth/register", "application/json", bytes.NewBuffer(registerJSON))
+	if err != nil {
+		s.T().Fatalf("Failed to register test user: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		s.T().Fatalf("Failed to register test user. Status: %d", resp.StatusCode)
+	}
+
+	var authResponse auth.AuthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&authResponse); err != nil {
+		s.T().Fatalf("Failed to decode auth response: %v", err)
+	}
+
+	return authResponse.Token, authResponse.UserID
+}
+
+// Helper function to send an authenticated JSON request
+func (s *FeedIntegrationTestSuite) do(method, path, token string, body interface{}) *http.Response {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+
+	req, _ := http.NewRequest(method, s.appUrl+path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.T().Fatalf("Request %s %s failed: %v", method, path, err)
+	}
+	return resp
+}
+
+// TestTeamActivityFeed tests that followers see team updates in their feed
+func (s *FeedIntegrationTestSuite) TestTeamActivityFeed() {
+	ownerToken, _ := s.registerTestUser()
+	followerToken, followerID := s.registerTestUser()
+
+	resp := s.do("POST", "/api/teams", ownerToken, teamhandler.CreateTeamRequest{Name: "Feed team", CityID: 1, OpenSlots: 1})
+	if resp.StatusCode != http.StatusCreated {
+		s.T().Fatalf("Failed to create team. Status: %d", resp.StatusCode)
+	}
+	var created team.Team
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+
+	// Users cannot follow themselves
+	resp = s.do("POST", fmt.Sprintf("/api/profiles/%d/follow", followerID), followerToken, nil)
+	assert.Equal(s.T(), http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", fmt.Sprintf("/api/teams/%d/follow", created.ID), followerToken, nil)
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	bio := "Updated bio"
+	resp = s.do("PATCH", fmt.Sprintf("/api/teams/%d", created.ID), ownerToken, teamhandler.UpdateTeamRequest{Bio: &bio})
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("GET", "/api/feed", followerToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var result feed.Feed
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+
+	if assert.NotEmpty(s.T(), result.Activities) {
+		latest := result.Activities[0]
+		assert.Equal(s.T(), feed.ActivityTeamUpdated, latest.Type)
+		if assert.NotNil(s.T(), latest.TeamID) {
+			assert.Equal(s.T(), created.ID, *latest.TeamID)
+		}
+	}
+
+	// After unfollowing, the team's activity no longer shows up
+	resp = s.do("DELETE", fmt.Sprintf("/api/teams/%d/follow", created.ID), followerToken, nil)
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("GET", "/api/feed", followerToken, nil)
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Empty(s.T(), result.Activities)
+}
+
+// TestFeedIntegration runs the feed integration test suite
+func TestFeedIntegration(t *testing.T) {
+	// Skip tests if SKIP_INTEGRATION_TESTS environment variable is set
+	if os.Getenv("SKIP_INTEGRATION_TESTS") != "" {
+		t.Skip("Skipping integration tests")
+	}
+
+	suite.Run(t, new(FeedIntegrationTestSuite))
+}
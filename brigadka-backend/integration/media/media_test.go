@@ -133,6 +133,13 @@ func (s *MediaIntegrationTestSuite) createTestVideo() {
 		'm', 'p', '4', '2', 0x00, 0x00, 0x00, 0x00,
 		'm', 'p', '4', '2', 'i', 's', 'o', 'm',
 		0x00, 0x00, 0x00, 0x08, 'f', 'r', 'e', 'e',
+		// Movie header with a 5 second duration, checked by the upload limits
+		0x00, 0x00, 0x00, 0x24, 'm', 'o', 'o', 'v',
+		0x00, 0x00, 0x00, 0x1C, 'm', 'v', 'h', 'd',
+		0x00, 0x00, 0x00, 0x00, // version and flags
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // creation and modification time
+		0x00, 0x00, 0x03, 0xE8, // timescale: 1000
+		0x00, 0x00, 0x13, 0x88, // duration: 5000
 	}
 	_ = os.WriteFile(s.testVideoPath, data, 0644)
 }
@@ -322,8 +329,15 @@ func (s *MediaIntegrationTestSuite) TestUploadInvalidFile() {
 	assert.NoError(t, err)
 	defer resp.Body.Close()
 
-	// Should return bad request for invalid file type
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should return status 400 Bad Request")
+	// Should reject the unsupported file type with a structured error
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return status 422 Unprocessable Entity")
+	var validationErr struct {
+		Error string `json:"error"`
+		Field string `json:"field"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&validationErr))
+	assert.Equal(t, "unsupported_media_type", validationErr.Error)
+	assert.Equal(t, "file", validationErr.Field)
 }
 
 // TestUploadNoFile tests submitting a request without a file
@@ -347,15 +361,119 @@ func (s *MediaIntegrationTestSuite) TestUploadNoFile() {
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should return status 400 Bad Request")
 }
 
-// TestUploadNoThumbnail tests uploading media without a thumbnail
+// TestUploadNoThumbnail tests uploading an image without a thumbnail, which the server generates
 func (s *MediaIntegrationTestSuite) TestUploadNoThumbnail() {
 	t := s.T()
 
-	// Create a request with only the main file but no thumbnail
+	// Create a request with only the main file but no thumbnail. A new user, since the
+	// image uploaded again by the suite user would return the earlier media with its thumbnail
 	files := map[string]string{
 		"file": s.testImagePath,
 	}
 
+	req, err := createMultipartRequestWithFiles(s.appUrl+"/api/media", files, s.registerTestUser())
+	assert.NoError(t, err)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Should accept the upload and generate the thumbnail in the background
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Should return status 200 OK")
+
+	var mediaResponse media.MediaResponse
+	err = json.NewDecoder(resp.Body).Decode(&mediaResponse)
+	assert.NoError(t, err)
+	assert.Greater(t, mediaResponse.ID, 0, "Media ID should be positive")
+	assert.True(t, mediaResponse.ThumbnailPending, "Thumbnail should be generated by the server")
+	assert.Empty(t, mediaResponse.ThumbnailURL, "ThumbnailURL should be empty until generated")
+}
+
+// TestUploadDuplicate tests that uploading the same file again returns the earlier media
+func (s *MediaIntegrationTestSuite) TestUploadDuplicate() {
+	t := s.T()
+	authToken := s.registerTestUser()
+
+	upload := func() media.MediaResponse {
+		files := map[string]string{
+			"file":      s.testImagePath,
+			"thumbnail": s.testThumbnailPath,
+		}
+		req, err := createMultipartRequestWithFiles(s.appUrl+"/api/media", files, authToken)
+		assert.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var mediaResponse media.MediaResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mediaResponse))
+		return mediaResponse
+	}
+
+	first := upload()
+	second := upload()
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.URL, second.URL)
+}
+
+// TestMediaLibrary tests listing uploads with their size and the storage used
+func (s *MediaIntegrationTestSuite) TestMediaLibrary() {
+	t := s.T()
+	authToken := s.registerTestUser()
+
+	files := map[string]string{
+		"file":      s.testImagePath,
+		"thumbnail": s.testThumbnailPath,
+	}
+	req, err := createMultipartRequestWithFiles(s.appUrl+"/api/media", files, authToken)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	var mediaResponse media.MediaResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mediaResponse))
+	resp.Body.Close()
+
+	req, err = http.NewRequest(http.MethodGet, s.appUrl+"/api/media", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+authToken)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var library struct {
+		Items []struct {
+			ID        int      `json:"id"`
+			SizeBytes int64    `json:"size_bytes"`
+			Usages    []string `json:"usages"`
+		} `json:"items"`
+		TotalCount int `json:"total_count"`
+		Quota      struct {
+			UsedBytes  int64 `json:"used_bytes"`
+			LimitBytes int64 `json:"limit_bytes"`
+		} `json:"quota"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&library))
+	assert.Equal(t, 1, library.TotalCount)
+	if assert.Len(t, library.Items, 1) {
+		assert.Equal(t, mediaResponse.ID, library.Items[0].ID)
+		assert.Positive(t, library.Items[0].SizeBytes)
+		assert.Empty(t, library.Items[0].Usages)
+		assert.Equal(t, library.Items[0].SizeBytes, library.Quota.UsedBytes)
+	}
+}
+
+// TestDeleteMedia tests deleting an uploaded file
+func (s *MediaIntegrationTestSuite) TestDeleteMedia() {
+	t := s.T()
+
+	files := map[string]string{
+		"file":      s.testImagePath,
+		"thumbnail": s.testThumbnailPath,
+	}
 	req, err := createMultipartRequestWithFiles(s.appUrl+"/api/media", files, s.authToken)
 	assert.NoError(t, err)
 
@@ -363,9 +481,25 @@ func (s *MediaIntegrationTestSuite) TestUploadNoThumbnail() {
 	resp, err := client.Do(req)
 	assert.NoError(t, err)
 	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-	// Should return bad request since thumbnail is required
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should return status 400 Bad Request")
+	var mediaResponse media.MediaResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mediaResponse))
+
+	deleteMedia := func(token string) int {
+		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/media/%d", s.appUrl, mediaResponse.ID), nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Only the owner can delete the media
+	assert.Equal(t, http.StatusForbidden, deleteMedia(s.registerTestUser()))
+	assert.Equal(t, http.StatusNoContent, deleteMedia(s.authToken))
+	assert.Equal(t, http.StatusNotFound, deleteMedia(s.authToken))
 }
 
 // TestMediaIntegration runs the media integration test suite
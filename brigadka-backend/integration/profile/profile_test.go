@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/faker"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/auth"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/media"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/profile"
@@ -85,16 +86,15 @@ func (s *ProfileIntegrationTestSuite) uploadTestMedia(t *testing.T, authToken st
 	testImagePath := filepath.Join(s.testDirPath, fmt.Sprintf("avatar_%d.jpg", time.Now().UnixNano()))
 	createTestJPEGFile(testImagePath)
 
-	videoPath := filepath.Join(s.testDirPath, fmt.Sprintf("video_%d.mp4", time.Now().UnixNano()))
-	createTestMP4File(videoPath)
-
 	// Upload avatar
 	avatarID, err := s.uploadFile(testImagePath, authToken)
 	assert.NoError(t, err)
 
-	// Upload two video files
+	// Upload two different video files; uploading the same file twice returns the same media
 	var mediaIDs []int
 	for i := 0; i < 2; i++ {
+		videoPath := filepath.Join(s.testDirPath, fmt.Sprintf("video_%d_%d.mp4", time.Now().UnixNano(), i))
+		createTestMP4File(videoPath, byte(i))
 		mediaID, err := s.uploadFile(videoPath, authToken)
 		assert.NoError(t, err)
 		mediaIDs = append(mediaIDs, mediaID)
@@ -219,14 +219,22 @@ func createTestJPEGFile(path string) {
 	_ = os.WriteFile(path, data, 0644)
 }
 
-// Helper function to create a test MP4 file
-func createTestMP4File(path string) {
+// Helper function to create a test MP4 file; files with different variants differ in content
+func createTestMP4File(path string, variant byte) {
 	// Create a very simple MP4 file header
 	data := []byte{
 		0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p',
 		'm', 'p', '4', '2', 0x00, 0x00, 0x00, 0x00,
 		'm', 'p', '4', '2', 'i', 's', 'o', 'm',
 		0x00, 0x00, 0x00, 0x08, 'f', 'r', 'e', 'e',
+		// Movie header with a 5 second duration, checked by the upload limits
+		0x00, 0x00, 0x00, 0x24, 'm', 'o', 'o', 'v',
+		0x00, 0x00, 0x00, 0x1C, 'm', 'v', 'h', 'd',
+		0x00, 0x00, 0x00, 0x00, // version and flags
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // creation and modification time
+		0x00, 0x00, 0x03, 0xE8, // timescale: 1000
+		0x00, 0x00, 0x13, 0x88, // duration: 5000
+		0x00, 0x00, 0x00, 0x09, 'f', 'r', 'e', 'e', variant,
 	}
 	_ = os.WriteFile(path, data, 0644)
 }
@@ -285,6 +293,46 @@ func (s *ProfileIntegrationTestSuite) TestCreateProfile() {
 	assert.Equal(t, len(mediaIDs), len(profileResp.Videos))
 }
 
+// TestCreateFakeProfile tests that generated fake profiles are accepted by the API
+func (s *ProfileIntegrationTestSuite) TestCreateFakeProfile() {
+	t := s.T()
+
+	authToken, userID := s.registerTestUser(t)
+
+	fake := faker.New(time.Now().UnixNano()).Profile()
+	createReqMap := map[string]interface{}{
+		"user_id":          userID,
+		"full_name":        fake.FullName,
+		"birthday":         fake.Birthday.Format("2006-01-02"),
+		"gender":           fake.Gender,
+		"city_id":          fake.CityID,
+		"bio":              fake.Bio,
+		"goal":             fake.Goal,
+		"improv_styles":    fake.ImprovStyles,
+		"looking_for_team": fake.LookingForTeam,
+	}
+
+	reqBody, _ := json.Marshal(createReqMap)
+	req, _ := http.NewRequest("POST", s.appUrl+"/api/profiles", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var profileResp profile.ProfileResponse
+	err = json.NewDecoder(resp.Body).Decode(&profileResp)
+	assert.NoError(t, err)
+
+	assert.Equal(t, fake.FullName, profileResp.FullName)
+	assert.Equal(t, fake.CityID, profileResp.CityID)
+	assert.ElementsMatch(t, fake.ImprovStyles, profileResp.ImprovStyles)
+}
+
 // TestUpdateProfile tests updating a profile
 func (s *ProfileIntegrationTestSuite) TestUpdateProfile() {
 	t := s.T()
@@ -361,6 +409,53 @@ func (s *ProfileIntegrationTestSuite) TestUpdateProfile() {
 	assert.Equal(t, len(mediaIDs), len(profileResp.Videos))
 }
 
+// TestOrganizerContactConsent tests that organizer contact is opt-in and can be enabled
+func (s *ProfileIntegrationTestSuite) TestOrganizerContactConsent() {
+	t := s.T()
+
+	authToken, userID := s.registerTestUser(t)
+
+	createReqMap := map[string]interface{}{
+		"user_id":       userID,
+		"full_name":     "Consent User",
+		"birthday":      "1992-03-03",
+		"gender":        "female",
+		"city_id":       1,
+		"bio":           "Bio",
+		"goal":          "career",
+		"improv_styles": []string{"longform"},
+	}
+
+	reqBody, _ := json.Marshal(createReqMap)
+	req, _ := http.NewRequest("POST", s.appUrl+"/api/profiles", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created profile.ProfileResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.False(t, created.AllowOrganizerContact)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{"allow_organizer_contact": true})
+	updateReq, _ := http.NewRequest("PATCH", fmt.Sprintf("%s/api/profiles/%d", s.appUrl, userID), bytes.NewBuffer(updateBody))
+	updateReq.Header.Set("Content-Type", "application/json")
+	updateReq.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err = client.Do(updateReq)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated profile.ProfileResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	assert.True(t, updated.AllowOrganizerContact)
+}
+
 // TestGetProfile tests retrieving a profile
 func (s *ProfileIntegrationTestSuite) TestGetProfile() {
 	t := s.T()
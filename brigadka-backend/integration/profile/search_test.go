@@ -369,6 +369,13 @@ func createTestVideo(path string) {
 		'm', 'p', '4', '2', 0x00, 0x00, 0x00, 0x00,
 		'm', 'p', '4', '2', 'i', 's', 'o', 'm',
 		0x00, 0x00, 0x00, 0x08, 'f', 'r', 'e', 'e',
+		// Movie header with a 5 second duration, checked by the upload limits
+		0x00, 0x00, 0x00, 0x24, 'm', 'o', 'o', 'v',
+		0x00, 0x00, 0x00, 0x1C, 'm', 'v', 'h', 'd',
+		0x00, 0x00, 0x00, 0x00, // version and flags
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // creation and modification time
+		0x00, 0x00, 0x03, 0xE8, // timescale: 1000
+		0x00, 0x00, 0x13, 0x88, // duration: 5000
 	}
 	os.WriteFile(path, data, 0644)
 }
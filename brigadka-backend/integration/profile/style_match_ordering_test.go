@@ -351,6 +351,66 @@ func (s *StyleMatchOrderingTestSuite) TestCreationDateSecondaryOrdering() {
 	assert.Less(t, pos2, pos1, "Middle profile should come before oldest profile")
 }
 
+// TestOnboardingQuizOrdering tests that a new user without improv styles gets results ordered by the formats from the onboarding quiz
+func (s *StyleMatchOrderingTestSuite) TestOnboardingQuizOrdering() {
+	t := s.T()
+
+	// The new user has no profile yet, only quiz answers
+	newUserToken, _ := s.registerTestUser(t)
+
+	quizBody, _ := json.Marshal(map[string]interface{}{
+		"preferred_formats": []string{"battles", "rap"},
+		"experience":        "beginner",
+	})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("%s/api/onboarding/quiz", s.appUrl), bytes.NewBuffer(quizBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+newUserToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	noMatchesID := s.createProfileWithStyles(t, "Quiz No Matches", []string{"absurd", "realistic"}, "male")
+	twoMatchesID := s.createProfileWithStyles(t, "Quiz Two Matches", []string{"battles", "rap"}, "male")
+	oneMatchID := s.createProfileWithStyles(t, "Quiz One Match", []string{"battles", "absurd"}, "male")
+
+	searchBody, _ := json.Marshal(map[string]interface{}{
+		"created_after": s.createdAt,
+		"page":          1,
+		"page_size":     100,
+	})
+	req, _ = http.NewRequest("POST", fmt.Sprintf("%s/api/profiles/search", s.appUrl), bytes.NewBuffer(searchBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+newUserToken)
+
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result profile.SearchResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	orderedUserIDs := make([]int, 0, len(result.Profiles))
+	for _, p := range result.Profiles {
+		orderedUserIDs = append(orderedUserIDs, p.UserID)
+	}
+
+	twoMatchesPos := indexOf(twoMatchesID, orderedUserIDs)
+	oneMatchPos := indexOf(oneMatchID, orderedUserIDs)
+	noMatchesPos := indexOf(noMatchesID, orderedUserIDs)
+
+	assert.NotEqual(t, -1, twoMatchesPos, "Two matches profile should be in results")
+	assert.NotEqual(t, -1, oneMatchPos, "One match profile should be in results")
+	assert.NotEqual(t, -1, noMatchesPos, "No matches profile should be in results")
+
+	// Quiz formats are used for ordering although the user has no improv styles
+	assert.Less(t, twoMatchesPos, oneMatchPos)
+	assert.Less(t, oneMatchPos, noMatchesPos)
+}
+
 // Helper function to update a profile's gender
 func (s *StyleMatchOrderingTestSuite) updateProfileGender(t *testing.T, userID int, gender string) {
 	// Register a user for this profile
@@ -0,0 +1,262 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/auth"
+	teamhandler "github.com/bulatminnakhmetov/brigadka-backend/internal/handler/team"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// TeamIntegrationTestSuite defines a set of integration tests for team operations
+type TeamIntegrationTestSuite struct {
+	suite.Suite
+	appUrl string
+}
+
+// SetupSuite prepares the test environment before running all tests
+func (s *TeamIntegrationTestSuite) SetupSuite() {
+	s.appUrl = os.Getenv("APP_URL")
+	if s.appUrl == "" {
+		s.appUrl = "http://localhost:8080" // Default for local testing
+	}
+}
+
+// Helper function to register a test user and return the auth token and user ID
+func (s *TeamIntegrationTestSuite) registerTestUser() (string, int) {
+	registerData := auth.RegisterRequest{
+		Email:    fmt.Sprintf("test_team_%d_%d@example.com", os.Getpid(), time.Now().UnixNano()),
+		Password: "TestPassword123!",
+	}
+
+	registerJSON, _ := json.Marshal(registerData)
+	resp, err := http.Post(s.appUrl+"/api/auth/register", "application/json", bytes.NewBuffer(registerJSON))
+	if err != nil {
+		s.T().Fatalf("Failed to register test user: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		s.T().Fatalf("Failed to register test user. Status: %d", resp.StatusCode)
+	}
+
+	var authResponse auth.AuthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&authResponse); err != nil {
+		s.T().Fatalf("Failed to decode auth response: %v", err)
+	}
+
+	return authResponse.Token, authResponse.UserID
+}
+
+// Helper function to send an authenticated JSON request
+func (s *TeamIntegrationTestSuite) do(method, path, token string, body interface{}) *http.Response {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+
+	req, _ := http.NewRequest(method, s.appUrl+path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.T().Fatalf("Request %s %s failed: %v", method, path, err)
+	}
+	return resp
+}
+
+// Helper function to create a team owned by the given user
+func (s *TeamIntegrationTestSuite) createTeam(token string, name string) team.Team {
+	resp := s.do("POST", "/api/teams", token, teamhandler.CreateTeamRequest{
+		Name:         name,
+		CityID:       1,
+		Bio:          "Integration test team",
+		ImprovStyles: []string{"shortform"},
+		OpenSlots:    2,
+	})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		s.T().Fatalf("Failed to create team. Status: %d", resp.StatusCode)
+	}
+
+	var created team.Team
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		s.T().Fatalf("Failed to decode team: %v", err)
+	}
+	return created
+}
+
+// TestTeamLifecycle tests creating, updating, searching and deleting a team
+func (s *TeamIntegrationTestSuite) TestTeamLifecycle() {
+	token, _ := s.registerTestUser()
+	name := fmt.Sprintf("Team %d", time.Now().UnixNano())
+
+	created := s.createTeam(token, name)
+	assert.Equal(s.T(), name, created.Name)
+	assert.Equal(s.T(), 1, created.MemberCount)
+	assert.Equal(s.T(), []string{"shortform"}, created.ImprovStyles)
+
+	openSlots := 0
+	resp := s.do("PATCH", fmt.Sprintf("/api/teams/%d", created.ID), token, teamhandler.UpdateTeamRequest{OpenSlots: &openSlots})
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Team without open slots is excluded when searching for open slots
+	hasOpenSlots := true
+	resp = s.do("POST", "/api/teams/search", token, teamhandler.SearchRequest{Name: &name, HasOpenSlots: &hasOpenSlots})
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var result team.SearchResult
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(s.T(), 0, result.TotalCount)
+
+	resp = s.do("POST", "/api/teams/search", token, teamhandler.SearchRequest{Name: &name})
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(s.T(), 1, result.TotalCount)
+
+	resp = s.do("DELETE", fmt.Sprintf("/api/teams/%d", created.ID), token, nil)
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("GET", fmt.Sprintf("/api/teams/%d", created.ID), token, nil)
+	assert.Equal(s.T(), http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestTeamMembership tests adding members, ownership checks and leaving a team
+func (s *TeamIntegrationTestSuite) TestTeamMembership() {
+	ownerToken, ownerID := s.registerTestUser()
+	memberToken, memberID := s.registerTestUser()
+
+	created := s.createTeam(ownerToken, fmt.Sprintf("Team %d", time.Now().UnixNano()))
+	membersPath := fmt.Sprintf("/api/teams/%d/members", created.ID)
+
+	// Members cannot be added by non-owners
+	resp := s.do("POST", membersPath, memberToken, teamhandler.AddMemberRequest{UserID: memberID})
+	assert.Equal(s.T(), http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", membersPath, ownerToken, teamhandler.AddMemberRequest{UserID: memberID})
+	assert.Equal(s.T(), http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", membersPath, ownerToken, teamhandler.AddMemberRequest{UserID: memberID})
+	assert.Equal(s.T(), http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	// The only owner cannot leave
+	resp = s.do("DELETE", fmt.Sprintf("%s/%d", membersPath, ownerID), ownerToken, nil)
+	assert.Equal(s.T(), http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("GET", membersPath, memberToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var members []team.Member
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&members))
+	resp.Body.Close()
+	if assert.Len(s.T(), members, 2) {
+		assert.Equal(s.T(), ownerID, members[0].UserID)
+		assert.Equal(s.T(), team.RoleOwner, members[0].Role)
+	}
+
+	// Members can leave on their own
+	resp = s.do("DELETE", fmt.Sprintf("%s/%d", membersPath, memberID), memberToken, nil)
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestTeamApplications tests applying to a team and the owner accepting or rejecting applications
+func (s *TeamIntegrationTestSuite) TestTeamApplications() {
+	ownerToken, _ := s.registerTestUser()
+	applicantToken, applicantID := s.registerTestUser()
+	otherToken, _ := s.registerTestUser()
+
+	created := s.createTeam(ownerToken, fmt.Sprintf("Team %d", time.Now().UnixNano()))
+	applicationsPath := fmt.Sprintf("/api/teams/%d/applications", created.ID)
+
+	resp := s.do("POST", applicationsPath, applicantToken, teamhandler.ApplyRequest{Message: "Let me in"})
+	assert.Equal(s.T(), http.StatusCreated, resp.StatusCode)
+	var app team.Application
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&app))
+	resp.Body.Close()
+	assert.Equal(s.T(), team.ApplicationPending, app.Status)
+	assert.Equal(s.T(), applicantID, app.UserID)
+
+	// Only one pending application per user
+	resp = s.do("POST", applicationsPath, applicantToken, teamhandler.ApplyRequest{})
+	assert.Equal(s.T(), http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", applicationsPath, otherToken, nil)
+	assert.Equal(s.T(), http.StatusCreated, resp.StatusCode)
+	var otherApp team.Application
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&otherApp))
+	resp.Body.Close()
+
+	// Applicants cannot review applications
+	resp = s.do("GET", applicationsPath, applicantToken, nil)
+	assert.Equal(s.T(), http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("GET", applicationsPath+"?status=pending", ownerToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var pending []team.Application
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&pending))
+	resp.Body.Close()
+	assert.Len(s.T(), pending, 2)
+
+	resp = s.do("POST", fmt.Sprintf("%s/%d/accept", applicationsPath, app.ID), ownerToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", fmt.Sprintf("%s/%d/reject", applicationsPath, app.ID), ownerToken, nil)
+	assert.Equal(s.T(), http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.do("POST", fmt.Sprintf("%s/%d/reject", applicationsPath, otherApp.ID), ownerToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// The accepted applicant took one of the open slots
+	resp = s.do("GET", fmt.Sprintf("/api/teams/%d", created.ID), applicantToken, nil)
+	var updated team.Team
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&updated))
+	resp.Body.Close()
+	assert.Equal(s.T(), 2, updated.MemberCount)
+	assert.Equal(s.T(), created.OpenSlots-1, updated.OpenSlots)
+
+	// The team chat is shared by the owner and the new member
+	resp = s.do("GET", "/api/chats", applicantToken, nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var chats []map[string]interface{}
+	assert.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&chats))
+	resp.Body.Close()
+	found := false
+	for _, chat := range chats {
+		if chat["chat_name"] == created.Name {
+			found = true
+		}
+	}
+	assert.True(s.T(), found, "applicant should be in the team chat")
+}
+
+// TestTeamIntegration runs the team integration test suite
+func TestTeamIntegration(t *testing.T) {
+	// Skip tests if SKIP_INTEGRATION_TESTS environment variable is set
+	if os.Getenv("SKIP_INTEGRATION_TESTS") != "" {
+		t.Skip("Skipping integration tests")
+	}
+
+	suite.Run(t, new(TeamIntegrationTestSuite))
+}
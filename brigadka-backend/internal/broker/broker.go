@@ -0,0 +1,65 @@
+// Package broker passes messages between replicas of the service, so that
+// events raised on one replica reach WebSocket clients connected to another.
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker publishes payloads to named channels and delivers them to every
+// subscriber of the channel, including subscribers in the publishing process
+type Broker interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe calls handle with every payload published to the channel until ctx
+	// is done. It returns once the subscription is active. Payloads of one publisher
+	// are delivered in order; handle must not block for long.
+	Subscribe(ctx context.Context, channel string, handle func(payload []byte)) error
+}
+
+// Memory is a Broker for a single process
+type Memory struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[string]map[int]func(payload []byte)
+}
+
+// NewMemory creates an in-process broker
+func NewMemory() *Memory {
+	return &Memory{subscribers: make(map[string]map[int]func(payload []byte))}
+}
+
+// Publish delivers the payload to the subscribers of the channel before returning
+func (m *Memory) Publish(ctx context.Context, channel string, payload []byte) error {
+	m.mu.RLock()
+	handlers := make([]func(payload []byte), 0, len(m.subscribers[channel]))
+	for _, handle := range m.subscribers[channel] {
+		handlers = append(handlers, handle)
+	}
+	m.mu.RUnlock()
+
+	for _, handle := range handlers {
+		handle(payload)
+	}
+	return nil
+}
+
+// Subscribe registers handle until ctx is done
+func (m *Memory) Subscribe(ctx context.Context, channel string, handle func(payload []byte)) error {
+	m.mu.Lock()
+	id := m.nextID
+	m.nextID++
+	if m.subscribers[channel] == nil {
+		m.subscribers[channel] = make(map[int]func(payload []byte))
+	}
+	m.subscribers[channel][id] = handle
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		delete(m.subscribers[channel], id)
+		m.mu.Unlock()
+	}()
+	return nil
+}
@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryDeliversToSubscribersOfChannel(t *testing.T) {
+	b := NewMemory()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var got, other [][]byte
+	assert.NoError(t, b.Subscribe(ctx, "chat", func(payload []byte) { got = append(got, payload) }))
+	assert.NoError(t, b.Subscribe(context.Background(), "other", func(payload []byte) { other = append(other, payload) }))
+
+	assert.NoError(t, b.Publish(context.Background(), "chat", []byte("hello")))
+	assert.Equal(t, [][]byte{[]byte("hello")}, got)
+	assert.Empty(t, other)
+
+	// Cancelling the context ends the subscription
+	cancel()
+	assert.Eventually(t, func() bool {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.subscribers["chat"]) == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, b.Publish(context.Background(), "chat", []byte("again")))
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,81 @@
+package broker
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig configures the connection to Redis
+type RedisConfig struct {
+	Addr        string      // host:port
+	Password    string      // Empty when Redis requires no authentication
+	TLS         *tls.Config // Nil for a plain TCP connection
+	DialTimeout time.Duration
+}
+
+// Redis is a Broker over Redis pub/sub, for running several replicas.
+// Publishes share a connection pool and do not wait for one another.
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a broker connecting to Redis on first use
+func NewRedis(config RedisConfig) *Redis {
+	if config.DialTimeout == 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+	options := &redis.Options{
+		Addr:        config.Addr,
+		Password:    config.Password,
+		DialTimeout: config.DialTimeout,
+		// A command that failed may still have reached Redis; resending a PUBLISH
+		// would deliver the payload twice, so failures are returned instead
+		MaxRetries: -1,
+		TLSConfig:  config.TLS,
+	}
+	return &Redis{client: redis.NewClient(options)}
+}
+
+// Publish sends the payload with PUBLISH. It is sent at most once: when the
+// connection fails mid-command the error is returned and the payload may be lost.
+func (r *Redis) Publish(ctx context.Context, channel string, payload []byte) error {
+	return r.client.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe listens on a dedicated connection, which the client re-establishes and
+// resubscribes after connection losses. Payloads published while it is down are lost.
+func (r *Redis) Subscribe(ctx context.Context, channel string, handle func(payload []byte)) error {
+	pubsub := r.client.Subscribe(ctx, channel)
+	// The first reply confirms the subscription
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					log.Printf("Redis subscription to %s closed", channel)
+					return
+				}
+				handle([]byte(msg.Payload))
+			}
+		}
+	}()
+	return nil
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
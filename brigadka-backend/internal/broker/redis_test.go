@@ -0,0 +1,181 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertReceived(t *testing.T, received chan string, want ...string) {
+	for _, payload := range want {
+		select {
+		case got := <-received:
+			assert.Equal(t, payload, got)
+		case <-time.After(time.Second):
+			t.Fatalf("payload %q not received", payload)
+		}
+	}
+}
+
+func TestRedisPublishReachesSubscribers(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan string, 10)
+	subscriber := NewRedis(RedisConfig{Addr: server.Addr(), Password: "secret"})
+	defer subscriber.Close()
+	assert.NoError(t, subscriber.Subscribe(ctx, "ws", func(payload []byte) { received <- string(payload) }))
+	assert.Equal(t, 1, server.PubSubNumSub("ws")["ws"])
+
+	publisher := NewRedis(RedisConfig{Addr: server.Addr(), Password: "secret"})
+	defer publisher.Close()
+	assert.NoError(t, publisher.Publish(ctx, "ws", []byte(`{"kind":"message"}`)))
+	assert.NoError(t, publisher.Publish(ctx, "ws", []byte("second\r\nline")))
+
+	assertReceived(t, received, `{"kind":"message"}`, "second\r\nline")
+}
+
+func TestRedisErrorReply(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	b := NewRedis(RedisConfig{Addr: server.Addr(), Password: "wrong"})
+	defer b.Close()
+	err := b.Publish(context.Background(), "ws", []byte("x"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "WRONGPASS")
+	}
+}
+
+func TestRedisTLS(t *testing.T) {
+	cert, roots := selfSignedCertificate(t)
+	server, err := miniredis.RunTLS(&tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := NewRedis(RedisConfig{Addr: server.Addr(), TLS: &tls.Config{RootCAs: roots}})
+	defer b.Close()
+	received := make(chan string, 1)
+	require.NoError(t, b.Subscribe(ctx, "ws", func(payload []byte) { received <- string(payload) }))
+	assert.NoError(t, b.Publish(ctx, "ws", []byte("over tls")))
+	assertReceived(t, received, "over tls")
+
+	// Without the certificate authority the server is not trusted
+	untrusted := NewRedis(RedisConfig{Addr: server.Addr(), TLS: &tls.Config{}})
+	defer untrusted.Close()
+	assert.Error(t, untrusted.Publish(ctx, "ws", []byte("x")))
+}
+
+func TestRedisPublishIsNotResent(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// The server drops the connection on PUBLISH, as if it failed after Redis got the command
+	var publishes atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					args, err := readCommand(reader)
+					if err != nil {
+						return
+					}
+					if strings.EqualFold(args[0], "PUBLISH") {
+						publishes.Add(1)
+						return
+					}
+					fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+				}
+			}(conn)
+		}
+	}()
+
+	b := NewRedis(RedisConfig{Addr: listener.Addr().String()})
+	defer b.Close()
+	assert.Error(t, b.Publish(context.Background(), "ws", []byte("x")))
+	assert.Equal(t, int32(1), publishes.Load())
+}
+
+// readCommand reads a command sent as an array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:length])
+	}
+	return args, nil
+}
+
+// selfSignedCertificate returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "redis"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, roots
+}
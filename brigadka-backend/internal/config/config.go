@@ -0,0 +1,623 @@
+// Package config loads the settings of the service binaries from environment
+// variables and an optional YAML file, validates them up front and prints
+// them with secrets redacted.
+//
+// The YAML file is a flat map using the environment variable names:
+//
+//	DB_HOST: db.internal
+//	PUSH_PROVIDER: log
+//
+// Environment variables take precedence over the file.
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	authservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	mediaservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/media"
+	messagingservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+	searchindexservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/searchindex"
+)
+
+// FileEnv names the environment variable with the path of the YAML config file
+const FileEnv = "CONFIG_FILE"
+
+// Config is the configuration of the API server
+type Config struct {
+	Environment string // APNS uses the sandbox unless this is production
+	Version     string
+	ServerPort  string
+	JWTSecret   string
+
+	Database   database.Config
+	Storage    Storage
+	Media      Media
+	Password   Password
+	Consent    Consent
+	Push       Push
+	Messaging  Messaging
+	WebSocket  WebSocket
+	Search     Search
+	WelcomeBot WelcomeBot
+	Intervals  Intervals
+
+	entries []entry
+}
+
+// Storage selects where uploads are kept
+type Storage struct {
+	Provider  string // s3 (also b2 or minio), gcs or local
+	CDNDomain string
+
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3Endpoint        string
+	S3Bucket          string
+	S3PublicEndpoint  string
+
+	GCSAccessID string
+	GCSSecret   string
+	GCSBucket   string
+
+	LocalDir     string
+	LocalBaseURL string
+}
+
+// Media holds upload limits, media tools and moderation
+type Media struct {
+	Limits               mediaservice.Limits
+	FFprobePath          string
+	FFmpegPath           string
+	ThumbnailMaxAttempts int
+	URLSigningSecret     string // Signing is off when empty
+	URLTTL               time.Duration
+
+	NSFWProvider  string // Moderation is off when empty
+	NSFWEndpoint  string
+	NSFWAPIKey    string
+	NSFWThreshold float64
+}
+
+// Password is the password policy applied on registration
+type Password struct {
+	MinLength     int
+	RequireSymbol bool
+	BreachCheck   bool
+	BreachAPIURL  string
+}
+
+// Consent holds the document versions users must accept; empty versions require nothing
+type Consent struct {
+	TermsOfServiceVersion string
+	PrivacyPolicyVersion  string
+}
+
+// Push configures push providers and the delivery queue
+type Push struct {
+	Provider string // fcm_apns or log
+
+	FCMEnabled     bool
+	FCMCredentials string
+
+	APNSEnabled    bool
+	APNSKeyID      string
+	APNSTeamID     string
+	APNSBundleID   string
+	APNSPrivateKey []byte
+
+	QueueMaxAttempts int
+	DigestWindows    map[string]time.Duration // By notification category
+	TokenMaxAge      time.Duration
+}
+
+// Messaging holds chat limits and link previews
+type Messaging struct {
+	GroupLimits         messagingservice.GroupLimits
+	RateLimit           messagingservice.MessageRateLimit
+	LinkPreviewsEnabled bool
+	LinkPreviewTimeout  time.Duration
+	LinkPreviewWorkers  int
+}
+
+// WebSocket configures chat connections and the Redis broker between replicas
+type WebSocket struct {
+	EventLogSize int // 0 disables the event log
+
+	CompressionEnabled   bool
+	CompressionLevel     int
+	CompressionThreshold int
+	MaxMessageSize       int64
+
+	PingInterval time.Duration // 0 disables the heartbeat
+	PongWait     time.Duration
+	WriteWait    time.Duration
+
+	RedisAddr     string // Deliveries stay in the process when empty
+	RedisPassword string
+	RedisTLS      bool
+}
+
+// Search selects the profile search backend
+type Search struct {
+	Provider           string // postgres or opensearch
+	OpenSearchURL      string
+	OpenSearchIndex    string
+	OpenSearchUsername string
+	OpenSearchPassword string
+	BatchSize          int
+}
+
+// WelcomeBot configures the bot that greets new users
+type WelcomeBot struct {
+	Enabled bool
+	Email   string
+}
+
+// Intervals are the periods of the background workers
+type Intervals struct {
+	Reminders       time.Duration
+	PartnerMatch    time.Duration
+	PushCampaigns   time.Duration
+	Suspensions     time.Duration
+	PushQueue       time.Duration
+	MediaThumbnails time.Duration
+	SearchIndex     time.Duration
+	Exports         time.Duration
+}
+
+// Load reads the server configuration from the environment and the YAML file at
+// path, which may be empty, and validates it. All problems are reported together.
+func Load(path string) (*Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+// LoadDatabase reads and validates only the database settings, for tools that
+// need nothing else
+func LoadDatabase(path string) (*database.Config, error) {
+	s, err := newSource(path, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	db := loadDatabase(s)
+	if err := errors.Join(append(s.errs, validateDatabase(&db)...)...); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+func load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	s, err := newSource(path, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Environment: s.str("APP_ENV", "development"),
+		Version:     s.str("APP_VERSION", "dev"),
+		ServerPort:  s.str("SERVER_PORT", "8080"),
+		JWTSecret:   s.str("JWT_SECRET", ""),
+		Database:    loadDatabase(s),
+	}
+	cfg.Storage = loadStorage(s, cfg.ServerPort)
+	cfg.Media = loadMedia(s)
+	cfg.Password = loadPassword(s)
+	cfg.Consent = Consent{
+		TermsOfServiceVersion: s.str("TOS_VERSION", ""),
+		PrivacyPolicyVersion:  s.str("PRIVACY_POLICY_VERSION", ""),
+	}
+	cfg.Push = loadPush(s)
+	cfg.Messaging = loadMessaging(s)
+	cfg.WebSocket = loadWebSocket(s)
+	cfg.Search = loadSearch(s)
+	cfg.WelcomeBot = WelcomeBot{Enabled: s.bool("WELCOME_BOT_ENABLED", false)}
+	if cfg.WelcomeBot.Enabled {
+		cfg.WelcomeBot.Email = s.str("WELCOME_BOT_EMAIL", "bot@brigadka.app")
+	}
+	cfg.Intervals = Intervals{
+		Reminders:       s.seconds("REMINDER_POLL_INTERVAL", 30*time.Second),
+		PartnerMatch:    s.seconds("PARTNER_MATCH_INTERVAL", 60*time.Second),
+		PushCampaigns:   s.seconds("PUSH_CAMPAIGN_POLL_INTERVAL", 30*time.Second),
+		Suspensions:     s.seconds("SUSPENSION_POLL_INTERVAL", 60*time.Second),
+		PushQueue:       s.seconds("PUSH_QUEUE_POLL_INTERVAL", 5*time.Second),
+		MediaThumbnails: s.seconds("MEDIA_THUMBNAIL_POLL_INTERVAL", 10*time.Second),
+		SearchIndex:     s.seconds("SEARCH_INDEX_POLL_INTERVAL", 5*time.Second),
+		Exports:         s.seconds("EXPORT_POLL_INTERVAL", 10*time.Second),
+	}
+
+	if err := errors.Join(s.errs...); err != nil {
+		return nil, errors.Join(err, cfg.Validate())
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.entries = s.entries
+	return cfg, nil
+}
+
+func loadDatabase(s *source) database.Config {
+	db := database.Config{Driver: s.str("DB_DRIVER", string(database.Postgres))}
+	if database.Dialect(db.Driver) == database.SQLite {
+		// Демо-режим: один бинарник без Docker и PostgreSQL
+		db.Path = s.str("DB_PATH", "brigadka.db")
+		db.DBName = db.Path
+		return db
+	}
+
+	db.URL = s.str("DB_URL", "")
+	if db.URL != "" {
+		return db
+	}
+	db.Host = s.str("DB_HOST", "")
+	db.Port = s.int("DB_PORT", 5432)
+	db.User = s.str("DB_USER", "")
+	db.Password = s.str("DB_PASSWORD", "")
+	db.DBName = s.str("DB_NAME", "")
+	db.SSLMode = s.str("DB_SSL_MODE", "disable")
+	return db
+}
+
+func loadStorage(s *source, serverPort string) Storage {
+	storage := Storage{Provider: s.str("STORAGE_PROVIDER", "s3")}
+	switch storage.Provider {
+	case "s3", "b2", "minio":
+		storage.S3AccessKeyID = s.str("B2_ACCESS_KEY_ID", "")
+		storage.S3SecretAccessKey = s.str("B2_SECRET_ACCESS_KEY", "")
+		storage.S3Endpoint = s.str("B2_ENDPOINT", "")
+		storage.S3Bucket = s.str("B2_BUCKET_NAME", "")
+		storage.S3PublicEndpoint = s.str("B2_PUBLIC_ENDPOINT", "")
+		storage.CDNDomain = s.str("CLOUDFLARE_CDN_DOMAIN", "")
+	case "gcs":
+		storage.GCSAccessID = s.str("GCS_HMAC_ACCESS_ID", "")
+		storage.GCSSecret = s.str("GCS_HMAC_SECRET", "")
+		storage.GCSBucket = s.str("GCS_BUCKET_NAME", "")
+		storage.CDNDomain = s.str("CLOUDFLARE_CDN_DOMAIN", "")
+	case "local":
+		storage.LocalDir = s.str("STORAGE_LOCAL_DIR", "data/storage")
+		storage.LocalBaseURL = s.str("STORAGE_LOCAL_BASE_URL", "http://localhost:"+serverPort+"/files")
+	}
+	return storage
+}
+
+func loadMedia(s *source) Media {
+	media := Media{
+		Limits: mediaservice.Limits{
+			MaxFileSize:       int64(s.int("MEDIA_MAX_FILE_SIZE_MB", mediaservice.MaxFileSize>>20)) << 20,
+			MaxImageDimension: s.int("MEDIA_MAX_IMAGE_DIMENSION", mediaservice.MaxImageDimension),
+			MaxVideoDuration:  s.seconds("MEDIA_MAX_VIDEO_DURATION", mediaservice.MaxVideoDuration),
+			MaxAudioDuration:  s.seconds("MEDIA_MAX_AUDIO_DURATION", mediaservice.MaxAudioDuration),
+			StorageQuota:      int64(s.int("MEDIA_STORAGE_QUOTA_MB", mediaservice.StorageQuota>>20)) << 20,
+		},
+		FFprobePath:          s.str("FFPROBE_PATH", "ffprobe"),
+		FFmpegPath:           s.str("FFMPEG_PATH", "ffmpeg"),
+		ThumbnailMaxAttempts: s.int("MEDIA_THUMBNAIL_MAX_ATTEMPTS", mediaservice.DefaultThumbnailMaxAttempts),
+		URLSigningSecret:     s.str("MEDIA_URL_SIGNING_SECRET", ""),
+	}
+	if media.URLSigningSecret != "" {
+		media.URLTTL = s.seconds("MEDIA_URL_TTL", time.Hour)
+	}
+
+	// Classifier settings are named after the provider: NSFW_<PROVIDER>_ENDPOINT and so on
+	media.NSFWProvider = s.str("NSFW_PROVIDER", "")
+	if media.NSFWProvider != "" {
+		prefix := "NSFW_" + strings.ToUpper(media.NSFWProvider) + "_"
+		media.NSFWEndpoint = s.str(prefix+"ENDPOINT", "")
+		media.NSFWAPIKey = s.str(prefix+"API_KEY", "")
+		media.NSFWThreshold = s.float(prefix+"THRESHOLD", mediaservice.DefaultNSFWThreshold)
+	}
+	return media
+}
+
+func loadPassword(s *source) Password {
+	policy := authservice.DefaultPasswordPolicy()
+	password := Password{
+		MinLength:     s.int("PASSWORD_MIN_LENGTH", policy.MinLength),
+		RequireSymbol: s.bool("PASSWORD_REQUIRE_SYMBOL", policy.RequireSymbol),
+		BreachCheck:   s.bool("PASSWORD_BREACH_CHECK", false),
+	}
+	if password.BreachCheck {
+		password.BreachAPIURL = s.str("PASSWORD_BREACH_API_URL", "")
+	}
+	return password
+}
+
+func loadPush(s *source) Push {
+	push := Push{
+		Provider:         s.str("PUSH_PROVIDER", "fcm_apns"),
+		QueueMaxAttempts: s.int("PUSH_QUEUE_MAX_ATTEMPTS", pushservice.DefaultQueueMaxAttempts),
+		DigestWindows:    map[string]time.Duration{},
+		TokenMaxAge:      time.Duration(s.int("PUSH_TOKEN_MAX_AGE_DAYS", pushservice.DefaultTokenMaxAgeDays)) * 24 * time.Hour,
+	}
+	for _, category := range []string{pushservice.CategoryNewMessage, pushservice.CategoryNewMatch,
+		pushservice.CategoryTeamApplication, pushservice.CategoryAnnouncement} {
+		push.DigestWindows[category] = s.seconds("PUSH_DIGEST_WINDOW_"+strings.ToUpper(category), pushservice.DefaultDigestWindows[category])
+	}
+
+	if push.Provider != "fcm_apns" {
+		return push
+	}
+	push.FCMEnabled = s.bool("PUSH_FCM_ENABLED", true)
+	if push.FCMEnabled {
+		push.FCMCredentials = s.str("GOOGLE_APPLICATION_CREDENTIALS", "")
+	}
+	push.APNSEnabled = s.bool("PUSH_APNS_ENABLED", true)
+	if push.APNSEnabled {
+		push.APNSKeyID = s.str("APNS_KEY_ID", "")
+		push.APNSTeamID = s.str("APNS_TEAM_ID", "")
+		push.APNSBundleID = s.str("APNS_BUNDLE_ID", "")
+		key, err := loadAPNSPrivateKey(s.str("APNS_PRIVATE_KEY", ""))
+		if err != nil {
+			s.errs = append(s.errs, fmt.Errorf("APNS_PRIVATE_KEY: %w", err))
+		}
+		push.APNSPrivateKey = key
+	}
+	return push
+}
+
+func loadMessaging(s *source) Messaging {
+	messaging := Messaging{
+		GroupLimits: messagingservice.GroupLimits{
+			MaxParticipants:         s.int("GROUP_CHAT_MAX_PARTICIPANTS", messagingservice.DefaultMaxGroupParticipants),
+			MaxParticipantsVerified: s.int("GROUP_CHAT_MAX_PARTICIPANTS_VERIFIED", messagingservice.DefaultMaxGroupParticipantsVerified),
+		},
+		RateLimit: messagingservice.MessageRateLimit{
+			Messages: s.int("MESSAGE_RATE_LIMIT", messagingservice.DefaultMessageRateLimit),
+			Window:   s.seconds("MESSAGE_RATE_WINDOW", messagingservice.DefaultMessageRateWindow),
+		},
+		LinkPreviewsEnabled: s.bool("LINK_PREVIEWS_ENABLED", true),
+	}
+	if messaging.LinkPreviewsEnabled {
+		messaging.LinkPreviewTimeout = s.seconds("LINK_PREVIEW_TIMEOUT", 5*time.Second)
+		messaging.LinkPreviewWorkers = s.int("LINK_PREVIEW_WORKERS", 2)
+	}
+	return messaging
+}
+
+func loadWebSocket(s *source) WebSocket {
+	ws := WebSocket{
+		EventLogSize:       s.int("WS_EVENT_LOG_SIZE", 0),
+		CompressionEnabled: s.bool("WS_COMPRESSION_ENABLED", true),
+		PingInterval:       s.seconds("WS_PING_INTERVAL", 30*time.Second),
+		RedisAddr:          s.str("REDIS_ADDR", ""),
+	}
+	if ws.CompressionEnabled {
+		ws.CompressionLevel = s.int("WS_COMPRESSION_LEVEL", 1)
+		ws.CompressionThreshold = s.int("WS_COMPRESSION_THRESHOLD", 256)
+		ws.MaxMessageSize = int64(s.int("WS_MAX_MESSAGE_SIZE", 1<<20))
+	}
+	if ws.PingInterval > 0 {
+		ws.PongWait = s.seconds("WS_PONG_WAIT", 60*time.Second)
+		ws.WriteWait = s.seconds("WS_WRITE_WAIT", 10*time.Second)
+	}
+	if ws.RedisAddr != "" {
+		ws.RedisPassword = s.str("REDIS_PASSWORD", "")
+		ws.RedisTLS = s.bool("REDIS_TLS", false)
+	}
+	return ws
+}
+
+func loadSearch(s *source) Search {
+	search := Search{Provider: s.str("SEARCH_PROVIDER", "postgres")}
+	if search.Provider == "opensearch" {
+		search.OpenSearchURL = s.str("OPENSEARCH_URL", "")
+		search.OpenSearchIndex = s.str("OPENSEARCH_INDEX", "profiles")
+		search.OpenSearchUsername = s.str("OPENSEARCH_USERNAME", "")
+		search.OpenSearchPassword = s.str("OPENSEARCH_PASSWORD", "")
+		search.BatchSize = s.int("SEARCH_INDEX_BATCH_SIZE", searchindexservice.DefaultBatchSize)
+	}
+	return search
+}
+
+// loadAPNSPrivateKey loads an APNS private key given as file://<path>, base64://<key> or the key itself
+func loadAPNSPrivateKey(source string) ([]byte, error) {
+	switch {
+	case source == "":
+		return []byte{}, nil
+	case strings.HasPrefix(source, "file://"):
+		return os.ReadFile(strings.TrimPrefix(source, "file://"))
+	case strings.HasPrefix(source, "base64://"):
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(source, "base64://"))
+	default:
+		return []byte(source), nil
+	}
+}
+
+// Validate checks that required settings are present and values are in range
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	required := func(value, key string) {
+		if value == "" {
+			add("%s is required", key)
+		}
+	}
+	positive := func(value time.Duration, key string) {
+		if value <= 0 {
+			add("%s must be positive", key)
+		}
+	}
+	notNegative := func(value int, key string) {
+		if value < 0 {
+			add("%s must not be negative", key)
+		}
+	}
+
+	if port, err := strconv.Atoi(c.ServerPort); err != nil || port < 1 || port > 65535 {
+		add("SERVER_PORT must be a port number, got %q", c.ServerPort)
+	}
+	required(c.JWTSecret, "JWT_SECRET")
+	errs = append(errs, validateDatabase(&c.Database)...)
+
+	switch c.Storage.Provider {
+	case "s3", "b2", "minio":
+		required(c.Storage.S3AccessKeyID, "B2_ACCESS_KEY_ID")
+		required(c.Storage.S3SecretAccessKey, "B2_SECRET_ACCESS_KEY")
+		required(c.Storage.S3Endpoint, "B2_ENDPOINT")
+		required(c.Storage.S3Bucket, "B2_BUCKET_NAME")
+	case "gcs":
+		required(c.Storage.GCSAccessID, "GCS_HMAC_ACCESS_ID")
+		required(c.Storage.GCSSecret, "GCS_HMAC_SECRET")
+		required(c.Storage.GCSBucket, "GCS_BUCKET_NAME")
+	case "local":
+		required(c.Storage.LocalDir, "STORAGE_LOCAL_DIR")
+	default:
+		add("STORAGE_PROVIDER must be s3, gcs or local, got %q", c.Storage.Provider)
+	}
+
+	limits := c.Media.Limits
+	if limits.MaxFileSize <= 0 {
+		add("MEDIA_MAX_FILE_SIZE_MB must be positive")
+	}
+	if limits.MaxImageDimension <= 0 {
+		add("MEDIA_MAX_IMAGE_DIMENSION must be positive")
+	}
+	positive(limits.MaxVideoDuration, "MEDIA_MAX_VIDEO_DURATION")
+	positive(limits.MaxAudioDuration, "MEDIA_MAX_AUDIO_DURATION")
+	if limits.StorageQuota < 0 {
+		add("MEDIA_STORAGE_QUOTA_MB must not be negative")
+	}
+	if c.Media.ThumbnailMaxAttempts < 1 {
+		add("MEDIA_THUMBNAIL_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Media.URLSigningSecret != "" {
+		positive(c.Media.URLTTL, "MEDIA_URL_TTL")
+	}
+	if c.Media.NSFWProvider != "" {
+		prefix := "NSFW_" + strings.ToUpper(c.Media.NSFWProvider) + "_"
+		required(c.Media.NSFWEndpoint, prefix+"ENDPOINT")
+		if c.Media.NSFWThreshold <= 0 || c.Media.NSFWThreshold > 1 {
+			add("%sTHRESHOLD must be in (0, 1]", prefix)
+		}
+	}
+
+	if c.Password.MinLength < 1 {
+		add("PASSWORD_MIN_LENGTH must be at least 1")
+	}
+
+	switch c.Push.Provider {
+	case "fcm_apns", "log":
+	default:
+		add("PUSH_PROVIDER must be fcm_apns or log, got %q", c.Push.Provider)
+	}
+	if c.Push.QueueMaxAttempts < 1 {
+		add("PUSH_QUEUE_MAX_ATTEMPTS must be at least 1")
+	}
+	for category, window := range c.Push.DigestWindows {
+		if window < 0 {
+			add("PUSH_DIGEST_WINDOW_%s must not be negative", strings.ToUpper(category))
+		}
+	}
+	positive(c.Push.TokenMaxAge, "PUSH_TOKEN_MAX_AGE_DAYS")
+
+	notNegative(c.Messaging.GroupLimits.MaxParticipants, "GROUP_CHAT_MAX_PARTICIPANTS")
+	notNegative(c.Messaging.GroupLimits.MaxParticipantsVerified, "GROUP_CHAT_MAX_PARTICIPANTS_VERIFIED")
+	notNegative(c.Messaging.RateLimit.Messages, "MESSAGE_RATE_LIMIT")
+	if c.Messaging.RateLimit.Messages > 0 {
+		positive(c.Messaging.RateLimit.Window, "MESSAGE_RATE_WINDOW")
+	}
+	if c.Messaging.LinkPreviewsEnabled {
+		positive(c.Messaging.LinkPreviewTimeout, "LINK_PREVIEW_TIMEOUT")
+		if c.Messaging.LinkPreviewWorkers < 1 {
+			add("LINK_PREVIEW_WORKERS must be at least 1")
+		}
+	}
+
+	notNegative(c.WebSocket.EventLogSize, "WS_EVENT_LOG_SIZE")
+	if c.WebSocket.CompressionEnabled {
+		if c.WebSocket.CompressionLevel < 1 || c.WebSocket.CompressionLevel > 9 {
+			add("WS_COMPRESSION_LEVEL must be between 1 and 9")
+		}
+		notNegative(c.WebSocket.CompressionThreshold, "WS_COMPRESSION_THRESHOLD")
+		if c.WebSocket.MaxMessageSize <= 0 {
+			add("WS_MAX_MESSAGE_SIZE must be positive")
+		}
+	}
+	if c.WebSocket.PingInterval < 0 {
+		add("WS_PING_INTERVAL must not be negative")
+	}
+	if c.WebSocket.PingInterval > 0 {
+		if c.WebSocket.PongWait <= c.WebSocket.PingInterval {
+			add("WS_PONG_WAIT must be longer than WS_PING_INTERVAL")
+		}
+		positive(c.WebSocket.WriteWait, "WS_WRITE_WAIT")
+	}
+
+	switch c.Search.Provider {
+	case "postgres":
+	case "opensearch":
+		required(c.Search.OpenSearchURL, "OPENSEARCH_URL")
+		required(c.Search.OpenSearchIndex, "OPENSEARCH_INDEX")
+		if c.Search.BatchSize < 1 {
+			add("SEARCH_INDEX_BATCH_SIZE must be at least 1")
+		}
+	default:
+		add("SEARCH_PROVIDER must be postgres or opensearch, got %q", c.Search.Provider)
+	}
+
+	if c.WelcomeBot.Enabled {
+		required(c.WelcomeBot.Email, "WELCOME_BOT_EMAIL")
+	}
+
+	positive(c.Intervals.Reminders, "REMINDER_POLL_INTERVAL")
+	positive(c.Intervals.PartnerMatch, "PARTNER_MATCH_INTERVAL")
+	positive(c.Intervals.PushCampaigns, "PUSH_CAMPAIGN_POLL_INTERVAL")
+	positive(c.Intervals.Suspensions, "SUSPENSION_POLL_INTERVAL")
+	positive(c.Intervals.PushQueue, "PUSH_QUEUE_POLL_INTERVAL")
+	positive(c.Intervals.MediaThumbnails, "MEDIA_THUMBNAIL_POLL_INTERVAL")
+	positive(c.Intervals.Exports, "EXPORT_POLL_INTERVAL")
+	if c.Search.Provider == "opensearch" {
+		positive(c.Intervals.SearchIndex, "SEARCH_INDEX_POLL_INTERVAL")
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateDatabase(db *database.Config) []error {
+	var errs []error
+	switch database.Dialect(db.Driver) {
+	case database.SQLite:
+		if db.Path == "" {
+			errs = append(errs, errors.New("DB_PATH is required"))
+		}
+	case database.Postgres:
+		if db.URL != "" {
+			break
+		}
+		for _, setting := range []struct{ key, value string }{
+			{"DB_HOST", db.Host}, {"DB_USER", db.User}, {"DB_PASSWORD", db.Password}, {"DB_NAME", db.DBName},
+		} {
+			if setting.value == "" {
+				errs = append(errs, fmt.Errorf("%s is required, or DB_URL", setting.key))
+			}
+		}
+		if db.Port < 1 || db.Port > 65535 {
+			errs = append(errs, fmt.Errorf("DB_PORT must be a port number, got %d", db.Port))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("DB_DRIVER must be postgres or sqlite, got %q", db.Driver))
+	}
+	return errs
+}
+
+// Summary lists the settings in effect, one per line, with secrets redacted and
+// the origin of values that did not come from the environment
+func (c *Config) Summary() string {
+	var b strings.Builder
+	for _, e := range c.entries {
+		fmt.Fprintf(&b, "%s=%s", e.key, e.value)
+		if e.origin != fromEnv {
+			fmt.Fprintf(&b, " (%s)", e.origin)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// env is a fake environment; tests never read the real one
+type env map[string]string
+
+func (e env) lookup(key string) (string, bool) {
+	value, ok := e[key]
+	return value, ok
+}
+
+// minimal is the smallest environment a server starts with
+func minimal() env {
+	return env{
+		"JWT_SECRET":       "jwt-secret",
+		"DB_HOST":          "localhost",
+		"DB_USER":          "brigadka",
+		"DB_PASSWORD":      "db-password",
+		"DB_NAME":          "brigadka",
+		"STORAGE_PROVIDER": "local",
+		"PUSH_PROVIDER":    "log",
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load("", minimal().lookup)
+	require.NoError(t, err)
+
+	assert.Equal(t, "development", cfg.Environment)
+	assert.Equal(t, "8080", cfg.ServerPort)
+	assert.Equal(t, "jwt-secret", cfg.JWTSecret)
+	assert.Equal(t, database.Config{
+		Driver: "postgres", Host: "localhost", Port: 5432, User: "brigadka",
+		Password: "db-password", DBName: "brigadka", SSLMode: "disable",
+	}, cfg.Database)
+	assert.Equal(t, "data/storage", cfg.Storage.LocalDir)
+	assert.Equal(t, "http://localhost:8080/files", cfg.Storage.LocalBaseURL)
+	assert.Equal(t, 30*time.Second, cfg.WebSocket.PingInterval)
+	assert.Equal(t, 60*time.Second, cfg.WebSocket.PongWait)
+	assert.Equal(t, pushservice.DefaultDigestWindows[pushservice.CategoryNewMessage],
+		cfg.Push.DigestWindows[pushservice.CategoryNewMessage])
+	assert.Equal(t, 5*time.Second, cfg.Intervals.PushQueue)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeFile(t, "SERVER_PORT: 9090\nws_ping_interval: 0\nDB_HOST: file-host\n")
+	environment := minimal()
+	environment["DB_HOST"] = "env-host"
+
+	cfg, err := load(path, environment.lookup)
+	require.NoError(t, err)
+
+	assert.Equal(t, "9090", cfg.ServerPort)
+	assert.Equal(t, time.Duration(0), cfg.WebSocket.PingInterval)
+	assert.Equal(t, "env-host", cfg.Database.Host, "environment overrides the file")
+	assert.Equal(t, "http://localhost:9090/files", cfg.Storage.LocalBaseURL)
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	_, err := load(filepath.Join(t.TempDir(), "missing.yaml"), minimal().lookup)
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	_, err = load(writeFile(t, "- not\n- a map\n"), minimal().lookup)
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	environment := minimal()
+	delete(environment, "JWT_SECRET")
+	delete(environment, "DB_PASSWORD")
+	environment["DB_PORT"] = "five"
+	environment["STORAGE_PROVIDER"] = "ftp"
+	environment["WS_COMPRESSION_LEVEL"] = "12"
+	environment["WS_PONG_WAIT"] = "10"
+
+	_, err := load("", environment.lookup)
+	require.Error(t, err)
+
+	for _, message := range []string{
+		"DB_PORT must be an integer",
+		"JWT_SECRET is required",
+		"DB_PASSWORD is required, or DB_URL",
+		"STORAGE_PROVIDER must be s3, gcs or local",
+		"WS_COMPRESSION_LEVEL must be between 1 and 9",
+		"WS_PONG_WAIT must be longer than WS_PING_INTERVAL",
+	} {
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestLoadProviderSettings(t *testing.T) {
+	environment := minimal()
+	environment["STORAGE_PROVIDER"] = "s3"
+	environment["SEARCH_PROVIDER"] = "opensearch"
+	environment["NSFW_PROVIDER"] = "sightengine"
+	environment["NSFW_SIGHTENGINE_THRESHOLD"] = "1.5"
+
+	_, err := load("", environment.lookup)
+	require.Error(t, err)
+	for _, message := range []string{
+		"B2_ACCESS_KEY_ID is required",
+		"B2_BUCKET_NAME is required",
+		"OPENSEARCH_URL is required",
+		"NSFW_SIGHTENGINE_ENDPOINT is required",
+		"NSFW_SIGHTENGINE_THRESHOLD must be in (0, 1]",
+	} {
+		assert.ErrorContains(t, err, message)
+	}
+	assert.NotContains(t, err.Error(), "GCS_")
+}
+
+func TestLoadAPNSPrivateKey(t *testing.T) {
+	environment := minimal()
+	environment["PUSH_PROVIDER"] = "fcm_apns"
+	environment["APNS_PRIVATE_KEY"] = "base64://a2V5"
+
+	cfg, err := load("", environment.lookup)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), cfg.Push.APNSPrivateKey)
+
+	environment["APNS_PRIVATE_KEY"] = "file://" + filepath.Join(t.TempDir(), "missing.p8")
+	_, err = load("", environment.lookup)
+	assert.ErrorContains(t, err, "APNS_PRIVATE_KEY")
+}
+
+func TestSummaryRedactsSecrets(t *testing.T) {
+	environment := minimal()
+	environment["REDIS_ADDR"] = "redis:6379"
+	environment["REDIS_PASSWORD"] = "redis-password"
+	environment["PASSWORD_MIN_LENGTH"] = "12"
+	environment["PASSWORD_REQUIRE_SYMBOL"] = "true"
+	environment["PASSWORD_BREACH_CHECK"] = "true"
+	environment["PASSWORD_BREACH_API_URL"] = "https://breach.example.com"
+
+	cfg, err := load(writeFile(t, "APP_VERSION: 1.2.3\n"), environment.lookup)
+	require.NoError(t, err)
+
+	summary := cfg.Summary()
+	assert.Contains(t, summary, "DB_HOST=localhost\n")
+	assert.Contains(t, summary, "APP_VERSION=1.2.3 (file)\n")
+	assert.Contains(t, summary, "SERVER_PORT=8080 (default)\n")
+	assert.Contains(t, summary, "JWT_SECRET=******\n")
+	assert.Contains(t, summary, "REDIS_PASSWORD=******\n")
+	assert.NotContains(t, summary, "jwt-secret")
+	assert.NotContains(t, summary, "db-password")
+	assert.NotContains(t, summary, "redis-password")
+	// Settings about passwords are not secrets themselves
+	assert.Contains(t, summary, "PASSWORD_MIN_LENGTH=12\n")
+	assert.Contains(t, summary, "PASSWORD_REQUIRE_SYMBOL=true\n")
+	assert.Contains(t, summary, "PASSWORD_BREACH_CHECK=true\n")
+	assert.Contains(t, summary, "PASSWORD_BREACH_API_URL=https://breach.example.com\n")
+}
+
+func TestIsSecret(t *testing.T) {
+	for _, key := range []string{"DB_URL", "DB_PASSWORD", "REDIS_PASSWORD", "JWT_SECRET", "GCS_HMAC_SECRET", "B2_SECRET_ACCESS_KEY", "APNS_PRIVATE_KEY", "OPENAI_API_KEY"} {
+		assert.True(t, isSecret(key), key)
+	}
+	for _, key := range []string{"PASSWORD_MIN_LENGTH", "PASSWORD_REQUIRE_SYMBOL", "PASSWORD_BREACH_CHECK", "APNS_KEY_ID", "B2_ACCESS_KEY_ID", "MEDIA_URL_TTL"} {
+		assert.False(t, isSecret(key), key)
+	}
+}
+
+func TestLoadDatabaseURL(t *testing.T) {
+	s, err := newSource("", env{"DB_URL": "postgres://u:p@db/brigadka"}.lookup)
+	require.NoError(t, err)
+
+	db := loadDatabase(s)
+	assert.Empty(t, validateDatabase(&db))
+	assert.Equal(t, "postgres://u:p@db/brigadka", db.DSN())
+
+	s, err = newSource("", env{"DB_DRIVER": "sqlite"}.lookup)
+	require.NoError(t, err)
+	db = loadDatabase(s)
+	assert.Empty(t, validateDatabase(&db))
+	assert.Equal(t, "brigadka.db", db.Path)
+}
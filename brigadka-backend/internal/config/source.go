@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Where a setting came from
+const (
+	fromEnv     = "env"
+	fromFile    = "file"
+	fromDefault = "default"
+)
+
+// entry is a setting as it was resolved, for the startup summary
+type entry struct {
+	key    string
+	value  string
+	origin string
+}
+
+// source resolves settings by name from the environment, then the config file,
+// then the default. Values that fail to parse are collected as errors and the
+// default is used, so every problem is reported at once.
+type source struct {
+	lookupEnv func(string) (string, bool)
+	file      map[string]string
+	entries   []entry
+	errs      []error
+}
+
+// newSource reads the YAML config file at path, if any. The file is a flat map
+// of the same names as the environment variables, e.g. `DB_HOST: localhost`.
+func newSource(path string, lookupEnv func(string) (string, bool)) (*source, error) {
+	s := &source{lookupEnv: lookupEnv, file: map[string]string{}}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	values := map[string]string{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	for key, value := range values {
+		s.file[strings.ToUpper(key)] = value
+	}
+	return s, nil
+}
+
+// lookup returns the raw value of a setting and where it came from
+func (s *source) lookup(key string) (string, string, bool) {
+	if value, ok := s.lookupEnv(key); ok {
+		return value, fromEnv, true
+	}
+	if value, ok := s.file[key]; ok {
+		return value, fromFile, true
+	}
+	return "", fromDefault, false
+}
+
+// str reads a string setting. A value set to empty stays empty.
+func (s *source) str(key, fallback string) string {
+	value, origin, ok := s.lookup(key)
+	if !ok {
+		value = fallback
+	}
+	s.record(key, value, origin)
+	return value
+}
+
+func (s *source) int(key string, fallback int) int {
+	return parse(s, key, fallback, strconv.Itoa, func(value string) (int, error) {
+		return strconv.Atoi(value)
+	}, "an integer")
+}
+
+func (s *source) float(key string, fallback float64) float64 {
+	return parse(s, key, fallback, func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	}, "a number")
+}
+
+func (s *source) bool(key string, fallback bool) bool {
+	return parse(s, key, fallback, strconv.FormatBool, strconv.ParseBool, "true or false")
+}
+
+// seconds reads a duration given as a whole number of seconds
+func (s *source) seconds(key string, fallback time.Duration) time.Duration {
+	return time.Duration(s.int(key, int(fallback/time.Second))) * time.Second
+}
+
+// parse reads a typed setting; a value set to empty counts as unset
+func parse[T any](s *source, key string, fallback T, format func(T) string, parseValue func(string) (T, error), expected string) T {
+	raw, origin, ok := s.lookup(key)
+	raw = strings.TrimSpace(raw)
+	if !ok || raw == "" {
+		s.record(key, format(fallback), fromDefault)
+		return fallback
+	}
+
+	value, err := parseValue(raw)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s must be %s, got %q", key, expected, raw))
+		s.record(key, format(fallback), fromDefault)
+		return fallback
+	}
+	s.record(key, format(value), origin)
+	return value
+}
+
+func (s *source) record(key, value, origin string) {
+	if isSecret(key) && value != "" {
+		value = "******"
+	}
+	s.entries = append(s.entries, entry{key: key, value: value, origin: origin})
+}
+
+// secretSuffixes end the names of settings whose values must not be printed. Names are
+// matched by suffix so that settings about secrets, like PASSWORD_MIN_LENGTH, stay visible.
+var secretSuffixes = []string{"_PASSWORD", "_SECRET", "_SECRET_ACCESS_KEY", "_API_KEY", "_PRIVATE_KEY"}
+
+// isSecret reports whether the value of a setting must not be printed
+func isSecret(key string) bool {
+	if key == "DB_URL" {
+		return true
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
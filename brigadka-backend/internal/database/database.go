@@ -10,6 +10,13 @@ import (
 
 // Config содержит настройки подключения к базе данных
 type Config struct {
+	// Driver — "postgres" (по умолчанию) или "sqlite"
+	Driver string
+	// Path — путь к файлу базы данных для SQLite
+	Path string
+	// URL — строка подключения к PostgreSQL; если задана, остальные параметры не используются
+	URL string
+
 	Host     string
 	Port     int
 	User     string
@@ -20,12 +27,11 @@ type Config struct {
 
 // NewConnection устанавливает соединение с базой данных
 func NewConnection(config *Config) (*sql.DB, error) {
-	connStr := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode,
-	)
+	if Dialect(config.Driver) == SQLite {
+		return newSQLiteConnection(config)
+	}
 
-	db, err := sql.Open("postgres", connStr)
+	db, err := sql.Open("postgres", config.DSN())
 	if err != nil {
 		return nil, err
 	}
@@ -38,3 +44,14 @@ func NewConnection(config *Config) (*sql.DB, error) {
 	log.Println("Успешное подключение к базе данных")
 	return db, nil
 }
+
+// DSN возвращает строку подключения к PostgreSQL
+func (c *Config) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
+	)
+}
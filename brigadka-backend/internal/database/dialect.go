@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Dialect описывает различия SQL между поддерживаемыми базами данных
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// DialectFor определяет диалект по драйверу соединения.
+// Всё, что не является SQLite (включая sqlmock в тестах), считается PostgreSQL.
+func DialectFor(db *sql.DB) Dialect {
+	if db == nil {
+		return Postgres
+	}
+	if strings.Contains(strings.ToLower(fmt.Sprintf("%T", db.Driver())), "sqlite") {
+		return SQLite
+	}
+	return Postgres
+}
+
+// Now returns the expression for the current timestamp
+func (d Dialect) Now() string {
+	if d == SQLite {
+		return "CURRENT_TIMESTAMP"
+	}
+	return "NOW()"
+}
+
+// ILike returns the case-insensitive LIKE operator.
+// SQLite LIKE is case-insensitive for ASCII by default.
+func (d Dialect) ILike() string {
+	if d == SQLite {
+		return "LIKE"
+	}
+	return "ILIKE"
+}
+
+// OnConflictUpdate returns an upsert clause for the given conflict target and SET list.
+// Both PostgreSQL and SQLite (3.24+) accept the same syntax.
+func (d Dialect) OnConflictUpdate(conflictColumns string, set string) string {
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", conflictColumns, set)
+}
+
+// SkipLocked returns the row locking clause that lets concurrent workers claim
+// different rows. SQLite has a single writer and no row locks.
+func (d Dialect) SkipLocked() string {
+	if d == SQLite {
+		return ""
+	}
+	return "FOR UPDATE SKIP LOCKED"
+}
+
+// ForUpdate returns the clause that locks the selected rows until the end of the
+// transaction. SQLite locks the whole database for writes instead.
+func (d Dialect) ForUpdate() string {
+	if d == SQLite {
+		return ""
+	}
+	return "FOR UPDATE"
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fake drivers named like the real ones; DialectFor only looks at the driver type
+type postgresDriver struct{}
+type sqliteDriver struct{}
+
+func (postgresDriver) Open(string) (driver.Conn, error) { return nil, errors.New("not connected") }
+func (sqliteDriver) Open(string) (driver.Conn, error)   { return nil, errors.New("not connected") }
+
+func init() {
+	sql.Register("fake-postgres", postgresDriver{})
+	sql.Register("fake-sqlite", sqliteDriver{})
+}
+
+func TestDialectFor(t *testing.T) {
+	assert.Equal(t, Postgres, DialectFor(nil))
+
+	for name, want := range map[string]Dialect{"fake-postgres": Postgres, "fake-sqlite": SQLite} {
+		db, err := sql.Open(name, "")
+		require.NoError(t, err)
+		assert.Equal(t, want, DialectFor(db), name)
+		db.Close()
+	}
+}
+
+func TestDialectClauses(t *testing.T) {
+	assert.Equal(t, "NOW()", Postgres.Now())
+	assert.Equal(t, "CURRENT_TIMESTAMP", SQLite.Now())
+
+	assert.Equal(t, "ILIKE", Postgres.ILike())
+	assert.Equal(t, "LIKE", SQLite.ILike())
+
+	for _, d := range []Dialect{Postgres, SQLite} {
+		assert.Equal(t, "ON CONFLICT (user_id, chat_id) DO UPDATE SET read_at = excluded.read_at",
+			d.OnConflictUpdate("user_id, chat_id", "read_at = excluded.read_at"))
+	}
+
+	assert.Equal(t, "FOR UPDATE SKIP LOCKED", Postgres.SkipLocked())
+	assert.Equal(t, "", SQLite.SkipLocked())
+
+	assert.Equal(t, "FOR UPDATE", Postgres.ForUpdate())
+	assert.Equal(t, "", SQLite.ForUpdate())
+}
+
+func TestNewConnectionSQLiteNeedsBuildTag(t *testing.T) {
+	if sqliteCompiledIn() {
+		t.Skip("built with -tags sqlite")
+	}
+	_, err := NewConnection(&Config{Driver: "sqlite", Path: t.TempDir() + "/test.db"})
+	assert.ErrorContains(t, err, "rebuild with -tags sqlite")
+}
+
+func sqliteCompiledIn() bool {
+	for _, driver := range sql.Drivers() {
+		if driver == "sqlite" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Коды ошибок PostgreSQL (SQLSTATE), названия совпадают с github.com/jackc/pgerrcode
+const (
+	UniqueViolation     = "23505"
+	ForeignKeyViolation = "23503"
+)
+
+// Расширенные коды ограничений SQLite
+const (
+	sqliteConstraintPrimaryKey = 1555
+	sqliteConstraintUnique     = 2067
+)
+
+// sqliteError соответствует ошибкам драйвера modernc.org/sqlite,
+// чтобы не подключать драйвер в сборку по умолчанию
+type sqliteError interface {
+	error
+	Code() int
+}
+
+// IsUniqueViolation сообщает, нарушила ли операция первичный ключ или уникальное ограничение
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == UniqueViolation
+	}
+
+	var liteErr sqliteError
+	if errors.As(err, &liteErr) {
+		code := liteErr.Code()
+		return code == sqliteConstraintPrimaryKey || code == sqliteConstraintUnique
+	}
+
+	return false
+}
+
+// IsForeignKeyViolation сообщает, сослалась ли операция на несуществующую запись
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == ForeignKeyViolation
+}
+
+// RequireRow возвращает notFound, если запрос не затронул ни одной строки
+func RequireRow(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
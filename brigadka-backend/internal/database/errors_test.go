@@ -0,0 +1,42 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSQLiteError int
+
+func (e fakeSQLiteError) Error() string { return "constraint failed" }
+func (e fakeSQLiteError) Code() int     { return int(e) }
+
+func TestIsUniqueViolation(t *testing.T) {
+	assert.True(t, IsUniqueViolation(&pq.Error{Code: UniqueViolation}))
+	assert.True(t, IsUniqueViolation(fmt.Errorf("insert message: %w", &pq.Error{Code: UniqueViolation})))
+	assert.True(t, IsUniqueViolation(fakeSQLiteError(sqliteConstraintPrimaryKey)))
+	assert.True(t, IsUniqueViolation(fakeSQLiteError(sqliteConstraintUnique)))
+
+	assert.False(t, IsUniqueViolation(nil))
+	assert.False(t, IsUniqueViolation(&pq.Error{Code: ForeignKeyViolation}))
+	assert.False(t, IsUniqueViolation(fakeSQLiteError(787)))
+	// Matching on the message text is no longer supported
+	assert.False(t, IsUniqueViolation(errors.New("pq: duplicate key value violates unique constraint")))
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	assert.True(t, IsForeignKeyViolation(&pq.Error{Code: ForeignKeyViolation}))
+	assert.False(t, IsForeignKeyViolation(&pq.Error{Code: UniqueViolation}))
+	assert.False(t, IsForeignKeyViolation(errors.New("foreign key")))
+}
+
+func TestRequireRow(t *testing.T) {
+	notFound := errors.New("not found")
+	assert.NoError(t, RequireRow(driver.RowsAffected(1), notFound))
+	assert.Equal(t, notFound, RequireRow(driver.RowsAffected(0), notFound))
+	assert.Error(t, RequireRow(driver.ResultNoRows, notFound))
+}
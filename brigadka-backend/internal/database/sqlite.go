@@ -0,0 +1,52 @@
+//go:build sqlite
+
+package database
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"log"
+
+	// Pure-Go SQLite driver, registered as "sqlite". Only linked into demo builds
+	// so the default binary does not carry it.
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema — итоговая схема миграций в диалекте SQLite
+//
+//go:embed sqlite_schema.sql
+var sqliteSchema string
+
+// newSQLiteConnection открывает файл SQLite для демо-режима и локальной разработки
+// и создает в нем недостающие таблицы
+func newSQLiteConnection(config *Config) (*sql.DB, error) {
+	path := config.Path
+	if path == "" {
+		path = "brigadka.db"
+	}
+
+	// _time_format=sqlite сохраняет время в формате, который драйвер разбирает обратно в time.Time
+	// и который сравнивается со значениями CURRENT_TIMESTAMP
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite допускает только одного писателя одновременно
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to apply sqlite schema: %w", err)
+	}
+
+	log.Printf("Успешное подключение к SQLite: %s", path)
+	return db, nil
+}
@@ -0,0 +1,13 @@
+//go:build !sqlite
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// newSQLiteConnection недоступен в сборке без тега sqlite
+func newSQLiteConnection(config *Config) (*sql.DB, error) {
+	return nil, fmt.Errorf("sqlite driver is not compiled in, rebuild with -tags sqlite")
+}
@@ -0,0 +1,853 @@
+-- Схема SQLite для демо-режима: итоговое состояние миграций из db/migrations.
+-- Применяется при каждом подключении, поэтому все объекты создаются с IF NOT EXISTS,
+-- а справочники заполняются через INSERT OR IGNORE.
+-- При добавлении миграции, меняющей схему, нужно обновить и этот файл.
+--
+-- Отличия от PostgreSQL:
+--   SERIAL               -> INTEGER PRIMARY KEY AUTOINCREMENT
+--   TIMESTAMPTZ          -> TIMESTAMP (драйвер разбирает в time.Time только DATE, DATETIME и TIMESTAMP)
+--   UUID, JSONB          -> TEXT
+--   BYTEA                -> BLOB
+--   messages.seq         -> первичный ключ таблицы, id — уникальный ключ
+-- Нет полнотекстового поиска (search_vector), поиска рядом с точкой через earthdistance
+-- и триггеров очереди синхронизации с OpenSearch: profile_search_outbox остается пустой.
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    role VARCHAR(20) NOT NULL DEFAULT 'user'
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
+
+-- Системный пользователь бота «Бригадка» (вход по паролю невозможен)
+INSERT OR IGNORE INTO users (email, password_hash, role) VALUES ('bot@brigadka.app', '!', 'bot');
+
+-- Справочники
+
+CREATE TABLE IF NOT EXISTS media_type_catalog (
+    id VARCHAR(50) PRIMARY KEY
+);
+
+INSERT OR IGNORE INTO media_type_catalog (id) VALUES ('image'), ('video'), ('audio');
+
+CREATE TABLE IF NOT EXISTS media_role_catalog (
+    role VARCHAR(50) PRIMARY KEY
+);
+
+INSERT OR IGNORE INTO media_role_catalog (role) VALUES ('avatar'), ('video'), ('audio_intro');
+
+CREATE TABLE IF NOT EXISTS improv_style_catalog (
+    style_code VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS improv_style_translation (
+    style_code VARCHAR(50) REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    label TEXT NOT NULL,
+    PRIMARY KEY (style_code, lang)
+);
+
+INSERT OR IGNORE INTO improv_style_catalog (style_code) VALUES
+    ('shortform'),
+    ('longform'),
+    ('battles'),
+    ('musical'),
+    ('rap'),
+    ('playback'),
+    ('absurd'),
+    ('realistic');
+
+INSERT OR IGNORE INTO improv_style_translation (style_code, lang, label) VALUES
+    ('shortform', 'ru', 'Короткая форма'),
+    ('longform', 'ru', 'Длинная форма'),
+    ('battles', 'ru', 'Баттлы'),
+    ('musical', 'ru', 'Мюзикл'),
+    ('rap', 'ru', 'Фристайл-рэп'),
+    ('playback', 'ru', 'Плейбэк-театр'),
+    ('absurd', 'ru', 'Абсурд'),
+    ('realistic', 'ru', 'Реализм');
+
+CREATE TABLE IF NOT EXISTS improv_goals_catalog (
+    goal_id VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS improv_goals_translation (
+    goal_id VARCHAR(50) REFERENCES improv_goals_catalog(goal_id) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    label TEXT NOT NULL,
+    PRIMARY KEY (goal_id, lang)
+);
+
+INSERT OR IGNORE INTO improv_goals_catalog (goal_id) VALUES ('hobby'), ('career');
+
+INSERT OR IGNORE INTO improv_goals_translation (goal_id, lang, label) VALUES
+    ('hobby', 'en', 'Hobby'),
+    ('hobby', 'ru', 'Хобби'),
+    ('career', 'en', 'Career'),
+    ('career', 'ru', 'Карьера');
+
+CREATE TABLE IF NOT EXISTS gender_catalog (
+    gender_code VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS gender_catalog_translation (
+    gender_code VARCHAR(50) REFERENCES gender_catalog(gender_code) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    label TEXT NOT NULL,
+    PRIMARY KEY (gender_code, lang)
+);
+
+INSERT OR IGNORE INTO gender_catalog (gender_code) VALUES ('male'), ('female');
+
+INSERT OR IGNORE INTO gender_catalog_translation (gender_code, lang, label) VALUES
+    ('male', 'ru', 'Мужчина'),
+    ('female', 'ru', 'Женщина'),
+    ('male', 'en', 'Male'),
+    ('female', 'en', 'Female');
+
+CREATE TABLE IF NOT EXISTS cities (
+    city_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    country_code CHAR(2) NOT NULL,
+    region VARCHAR(255),
+    timezone VARCHAR(64) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION
+);
+
+CREATE INDEX IF NOT EXISTS idx_cities_country_code ON cities(country_code);
+CREATE INDEX IF NOT EXISTS idx_cities_location ON cities(latitude, longitude);
+
+CREATE TABLE IF NOT EXISTS city_translation (
+    city_id INT REFERENCES cities(city_id) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    region VARCHAR(255),
+    PRIMARY KEY (city_id, lang)
+);
+
+INSERT OR IGNORE INTO cities (city_id, name, country_code, region, timezone, latitude, longitude) VALUES
+    (1, 'Москва', 'RU', 'Москва', 'Europe/Moscow', 55.7558, 37.6173),
+    (2, 'Санкт-Петербург', 'RU', 'Санкт-Петербург', 'Europe/Moscow', 59.9311, 30.3609),
+    (3, 'Новосибирск', 'RU', 'Новосибирская область', 'Asia/Novosibirsk', 55.0084, 82.9357),
+    (4, 'Екатеринбург', 'RU', 'Свердловская область', 'Asia/Yekaterinburg', 56.8389, 60.6057),
+    (5, 'Казань', 'RU', 'Республика Татарстан', 'Europe/Moscow', 55.7961, 49.1064),
+    (6, 'Нижний Новгород', 'RU', 'Нижегородская область', 'Europe/Moscow', 56.2965, 43.9361),
+    (7, 'Краснодар', 'RU', 'Краснодарский край', 'Europe/Moscow', 45.0355, 38.9753),
+    (8, 'Самара', 'RU', 'Самарская область', 'Europe/Samara', 53.1959, 50.1002),
+    (9, 'Минск', 'BY', NULL, 'Europe/Minsk', 53.9006, 27.5590),
+    (10, 'Алматы', 'KZ', NULL, 'Asia/Almaty', 43.2220, 76.8512),
+    (11, 'Тбилиси', 'GE', NULL, 'Asia/Tbilisi', 41.7151, 44.8271),
+    (12, 'Ереван', 'AM', NULL, 'Asia/Yerevan', 40.1872, 44.5152);
+
+INSERT OR IGNORE INTO city_translation (city_id, lang, name, region)
+SELECT city_id, 'ru', name, region FROM cities WHERE city_id <= 12;
+
+INSERT OR IGNORE INTO city_translation (city_id, lang, name, region) VALUES
+    (1, 'en', 'Moscow', 'Moscow'),
+    (2, 'en', 'Saint Petersburg', 'Saint Petersburg'),
+    (3, 'en', 'Novosibirsk', 'Novosibirsk Oblast'),
+    (4, 'en', 'Yekaterinburg', 'Sverdlovsk Oblast'),
+    (5, 'en', 'Kazan', 'Republic of Tatarstan'),
+    (6, 'en', 'Nizhny Novgorod', 'Nizhny Novgorod Oblast'),
+    (7, 'en', 'Krasnodar', 'Krasnodar Krai'),
+    (8, 'en', 'Samara', 'Samara Oblast'),
+    (9, 'en', 'Minsk', NULL),
+    (10, 'en', 'Almaty', NULL),
+    (11, 'en', 'Tbilisi', NULL),
+    (12, 'en', 'Yerevan', NULL);
+
+CREATE TABLE IF NOT EXISTS reaction_catalog (
+    reaction_code VARCHAR(50) PRIMARY KEY,
+    emoji TEXT NOT NULL CHECK (LENGTH(TRIM(emoji)) > 0)
+);
+
+INSERT OR IGNORE INTO reaction_catalog (reaction_code, emoji) VALUES
+    ('like', '👍'),
+    ('laugh', '😂'),
+    ('clap', '👏'),
+    ('heart', '❤️'),
+    ('wow', '😮');
+
+CREATE TABLE IF NOT EXISTS report_reason_catalog (
+    reason_code VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS report_reason_translation (
+    reason_code VARCHAR(50) REFERENCES report_reason_catalog(reason_code) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    label TEXT NOT NULL,
+    PRIMARY KEY (reason_code, lang)
+);
+
+INSERT OR IGNORE INTO report_reason_catalog (reason_code) VALUES
+    ('spam'),
+    ('harassment'),
+    ('inappropriate'),
+    ('fake_profile'),
+    ('other');
+
+INSERT OR IGNORE INTO report_reason_translation (reason_code, lang, label) VALUES
+    ('spam', 'ru', 'Спам'),
+    ('spam', 'en', 'Spam'),
+    ('harassment', 'ru', 'Оскорбления или травля'),
+    ('harassment', 'en', 'Harassment'),
+    ('inappropriate', 'ru', 'Неприемлемый контент'),
+    ('inappropriate', 'en', 'Inappropriate content'),
+    ('fake_profile', 'ru', 'Фейковый профиль'),
+    ('fake_profile', 'en', 'Fake profile'),
+    ('other', 'ru', 'Другое'),
+    ('other', 'en', 'Other');
+
+-- Медиа
+
+CREATE TABLE IF NOT EXISTS media (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INT REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) REFERENCES media_type_catalog(id),
+    url TEXT NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    uploaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+        CHECK (moderation_status IN ('approved', 'pending', 'rejected')),
+    nsfw_score REAL,
+    moderation_provider VARCHAR(50),
+    moderated_by INT REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP,
+    variants TEXT NOT NULL DEFAULT '{}',
+    content_hash CHAR(64),
+    size_bytes BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_moderation_pending ON media(uploaded_at) WHERE moderation_status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_media_owner_content_hash ON media(owner_id, content_hash) WHERE content_hash IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS media_thumbnail_jobs (
+    media_id INT PRIMARY KEY REFERENCES media(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    failed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_thumbnail_jobs_due ON media_thumbnail_jobs(next_attempt_at) WHERE failed_at IS NULL;
+
+-- Профили
+
+CREATE TABLE IF NOT EXISTS profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    full_name VARCHAR(255) NOT NULL,
+    birthday DATE NOT NULL,
+    gender VARCHAR(50) REFERENCES gender_catalog(gender_code),
+    city_id INT REFERENCES cities(city_id),
+    bio TEXT,
+    goal VARCHAR(50) REFERENCES improv_goals_catalog(goal_id),
+    looking_for_team BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    allow_organizer_contact BOOLEAN NOT NULL DEFAULT FALSE,
+    hidden_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    shadowbanned_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS improv_profile_styles (
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    style VARCHAR(50) REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, style)
+);
+
+CREATE TABLE IF NOT EXISTS improv_profile_goals (
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    goal VARCHAR(50) REFERENCES improv_goals_catalog(goal_id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, goal)
+);
+
+CREATE INDEX IF NOT EXISTS idx_improv_profile_goals_goal ON improv_profile_goals(goal);
+
+CREATE TABLE IF NOT EXISTS profile_media (
+    media_id INT REFERENCES media(id) ON DELETE CASCADE,
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    role VARCHAR(50) REFERENCES media_role_catalog(role),
+    PRIMARY KEY (user_id, media_id)
+);
+
+CREATE TABLE IF NOT EXISTS profile_consent_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    setting VARCHAR(50) NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_consent_audit_user_id ON profile_consent_audit(user_id);
+
+CREATE TABLE IF NOT EXISTS profile_favorites (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    profile_user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, profile_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_favorites_user_created ON profile_favorites(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS profile_links (
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('instagram', 'telegram', 'youtube', 'website')),
+    url TEXT NOT NULL,
+    PRIMARY KEY (user_id, kind)
+);
+
+CREATE TABLE IF NOT EXISTS profile_availability (
+    user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 1 AND 7),
+    start_minute SMALLINT NOT NULL CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute SMALLINT NOT NULL CHECK (end_minute BETWEEN 1 AND 1440),
+    PRIMARY KEY (user_id, day_of_week, start_minute),
+    CHECK (start_minute < end_minute)
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_availability_day ON profile_availability(day_of_week, start_minute, end_minute);
+
+CREATE TABLE IF NOT EXISTS tags (
+    tag_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS profile_tags (
+    user_id INT REFERENCES profiles(user_id) ON DELETE CASCADE,
+    tag_id INT REFERENCES tags(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_tags_tag_id ON profile_tags(tag_id);
+
+CREATE TABLE IF NOT EXISTS profile_search_outbox (
+    user_id INT PRIMARY KEY,
+    enqueued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_search_outbox_enqueued_at ON profile_search_outbox (enqueued_at);
+
+CREATE TABLE IF NOT EXISTS profile_endorsements (
+    endorser_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    profile_user_id INT NOT NULL REFERENCES profiles(user_id) ON DELETE CASCADE,
+    style VARCHAR(50) NOT NULL REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    team_id INT REFERENCES teams(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (endorser_id, profile_user_id, style),
+    CHECK (endorser_id <> profile_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_endorsements_profile ON profile_endorsements(profile_user_id, style);
+
+-- Согласия и выгрузки
+
+CREATE TABLE IF NOT EXISTS user_consents (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document VARCHAR(50) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    accepted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, document, version)
+);
+
+CREATE TABLE IF NOT EXISTS exports (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    data BLOB,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    params TEXT NOT NULL DEFAULT '{}',
+    attempts INT NOT NULL DEFAULT 0,
+    lease_until TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exports_user_id ON exports(user_id);
+CREATE INDEX IF NOT EXISTS idx_exports_pending ON exports(lease_until) WHERE status = 'pending';
+
+-- Чаты
+
+CREATE TABLE IF NOT EXISTS chats (
+    id TEXT PRIMARY KEY,
+    chat_name VARCHAR(255) CHECK (chat_name IS NULL OR LENGTH(TRIM(chat_name)) > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    is_group BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    description TEXT CHECK (description IS NULL OR LENGTH(description) <= 1000),
+    avatar_media_id INT REFERENCES media(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS chat_participants (
+    chat_id TEXT REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member')),
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_participants_joined_at ON chat_participants(joined_at);
+
+-- seq — первичный ключ, чтобы SQLite выдавал его сам, как BIGSERIAL
+CREATE TABLE IF NOT EXISTS messages (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    id TEXT NOT NULL UNIQUE,
+    chat_id TEXT REFERENCES chats(id) ON DELETE CASCADE,
+    sender_id INT REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    hidden_at TIMESTAMP,
+    kind VARCHAR(16) NOT NULL DEFAULT 'user' CHECK (kind IN ('user', 'system')),
+    system_event VARCHAR(32),
+    target_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    ciphertext TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_chat_id_sent_at ON messages(chat_id, sent_at);
+CREATE INDEX IF NOT EXISTS idx_messages_chat_id_seq ON messages(chat_id, seq);
+CREATE INDEX IF NOT EXISTS idx_messages_sent_at ON messages(sent_at);
+CREATE INDEX IF NOT EXISTS idx_messages_sender_id ON messages(sender_id);
+
+CREATE TABLE IF NOT EXISTS message_reactions (
+    id TEXT PRIMARY KEY,
+    message_id TEXT REFERENCES messages(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    reaction_code VARCHAR(50) REFERENCES reaction_catalog(reaction_code) ON DELETE CASCADE,
+    reacted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (message_id, user_id, reaction_code)
+);
+
+CREATE TABLE IF NOT EXISTS message_read_receipts (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    chat_id TEXT REFERENCES chats(id) ON DELETE CASCADE,
+    last_read_seq BIGINT,
+    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, chat_id)
+);
+
+CREATE TABLE IF NOT EXISTS message_delivery_receipts (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    chat_id TEXT REFERENCES chats(id) ON DELETE CASCADE,
+    last_delivered_seq BIGINT NOT NULL,
+    delivered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, chat_id)
+);
+
+CREATE TABLE IF NOT EXISTS message_attachments (
+    message_id TEXT REFERENCES messages(id) ON DELETE CASCADE,
+    media_id INT REFERENCES media(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (message_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_media_id ON message_attachments(media_id);
+
+CREATE TABLE IF NOT EXISTS message_previews (
+    message_id TEXT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    site_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS chat_user_settings (
+    chat_id TEXT REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    muted_at TIMESTAMP,
+    muted_until TIMESTAMP,
+    archived_at TIMESTAMP,
+    requested_at TIMESTAMP,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS direct_message_settings (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    policy VARCHAR(20) NOT NULL DEFAULT 'everyone' CHECK (policy IN ('everyone', 'filtered')),
+    allow_teammates BOOLEAN NOT NULL DEFAULT TRUE,
+    allow_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS chat_reminders (
+    id CHAR(26) PRIMARY KEY,
+    chat_id TEXT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text TEXT NOT NULL CHECK (LENGTH(TRIM(text)) BETWEEN 1 AND 500),
+    remind_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_chat ON chat_reminders(chat_id, remind_at);
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_due ON chat_reminders(remind_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS device_keys (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(64),
+    identity_key TEXT NOT NULL,
+    signed_prekey_id INT NOT NULL,
+    signed_prekey TEXT NOT NULL,
+    signed_prekey_signature TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, device_id)
+);
+
+CREATE TABLE IF NOT EXISTS device_one_time_prekeys (
+    user_id INT,
+    device_id VARCHAR(64),
+    key_id INT,
+    public_key TEXT NOT NULL,
+    PRIMARY KEY (user_id, device_id, key_id),
+    FOREIGN KEY (user_id, device_id) REFERENCES device_keys(user_id, device_id) ON DELETE CASCADE
+);
+
+-- Боты
+
+CREATE TABLE IF NOT EXISTS bot_conversations (
+    chat_id TEXT PRIMARY KEY REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bot_accounts (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL CHECK (LENGTH(TRIM(name)) > 0),
+    api_key_hash CHAR(64) NOT NULL UNIQUE,
+    webhook_url TEXT,
+    webhook_secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_accounts_owner_id ON bot_accounts(owner_id);
+
+-- Команды
+
+CREATE TABLE IF NOT EXISTS teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    city_id INT REFERENCES cities(city_id),
+    bio TEXT,
+    avatar_media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    open_slots INT NOT NULL DEFAULT 0 CHECK (open_slots >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    chat_id TEXT REFERENCES chats(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_teams_city ON teams(city_id);
+
+CREATE TABLE IF NOT EXISTS team_styles (
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    style VARCHAR(50) REFERENCES improv_style_catalog(style_code) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, style)
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user ON team_members(user_id);
+
+CREATE TABLE IF NOT EXISTS team_applications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP,
+    decided_by INT REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_applications_pending ON team_applications(team_id, user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_team_applications_team_created ON team_applications(team_id, created_at DESC);
+
+-- Онбординг
+
+CREATE TABLE IF NOT EXISTS onboarding_answers (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    schedule VARCHAR(20) CHECK (schedule IN ('weekdays', 'weekends', 'evenings', 'flexible')),
+    experience VARCHAR(20) CHECK (experience IN ('none', 'beginner', 'intermediate', 'advanced')),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_formats (
+    user_id INT NOT NULL REFERENCES onboarding_answers(user_id) ON DELETE CASCADE,
+    style VARCHAR(50) NOT NULL REFERENCES improv_style_catalog(style_code),
+    PRIMARY KEY (user_id, style)
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_progress (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    first_search_at TIMESTAMP NOT NULL
+);
+
+-- Подписки и лента
+
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, user_id),
+    CHECK (follower_id <> user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_follows_user ON user_follows(user_id);
+
+CREATE TABLE IF NOT EXISTS team_follows (
+    follower_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_follows_team ON team_follows(team_id);
+
+CREATE TABLE IF NOT EXISTS activities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type VARCHAR(50) NOT NULL,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    payload TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id IS NOT NULL OR team_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activities_user ON activities(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_activities_team ON activities(team_id, id DESC);
+
+-- Модерация и администрирование
+
+CREATE TABLE IF NOT EXISTS user_suspensions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL CHECK (LENGTH(TRIM(reason)) BETWEEN 1 AND 500),
+    suspended_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMP,
+    lifted_at TIMESTAMP,
+    lifted_by INT REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_suspensions_active ON user_suspensions(user_id) WHERE lifted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_suspensions_expiring ON user_suspensions(ends_at) WHERE lifted_at IS NULL AND ends_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS suspension_appeals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    suspension_id INT NOT NULL UNIQUE REFERENCES user_suspensions(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL CHECK (LENGTH(TRIM(message)) BETWEEN 1 AND 2000),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_suspension_appeals_status ON suspension_appeals(status, created_at);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reporter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('profile', 'message', 'media')),
+    target_id VARCHAR(64) NOT NULL,
+    reason_code VARCHAR(50) NOT NULL REFERENCES report_reason_catalog(reason_code),
+    comment TEXT NOT NULL DEFAULT '' CHECK (LENGTH(comment) <= 1000),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_by INT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_per_reporter ON reports(reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports(target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports(status, created_at);
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    admin_id INT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(64) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target ON admin_audit_log(target_type, target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_admin ON admin_audit_log(admin_id, created_at);
+
+CREATE TABLE IF NOT EXISTS support_access_grants (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    granted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Объявления и уведомления
+
+CREATE TABLE IF NOT EXISTS announcements (
+    id CHAR(26) PRIMARY KEY,
+    author_id INT REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL CHECK (LENGTH(TRIM(title)) > 0),
+    body TEXT NOT NULL CHECK (LENGTH(TRIM(body)) BETWEEN 1 AND 4000),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS announcement_reads (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_read_id CHAR(26) NOT NULL,
+    read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS push_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    token TEXT NOT NULL UNIQUE,
+    platform VARCHAR(10) NOT NULL,
+    device_id TEXT,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_success_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS push_tokens_user_id_idx ON push_tokens(user_id);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_message BOOLEAN NOT NULL DEFAULT TRUE,
+    new_match BOOLEAN NOT NULL DEFAULT TRUE,
+    team_application BOOLEAN NOT NULL DEFAULT TRUE,
+    announcements BOOLEAN NOT NULL DEFAULT TRUE,
+    quiet_hours_start INT CHECK (quiet_hours_start BETWEEN 0 AND 1439),
+    quiet_hours_end INT CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    timezone VARCHAR(64),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS push_deliveries (
+    id TEXT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    failed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    category TEXT NOT NULL DEFAULT '',
+    replays INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_deliveries_next_attempt_at ON push_deliveries (next_attempt_at, id) WHERE failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_push_deliveries_user_category ON push_deliveries (user_id, category) WHERE failed_at IS NULL AND attempts = 0;
+CREATE INDEX IF NOT EXISTS idx_push_deliveries_failed_at ON push_deliveries (failed_at, id) WHERE failed_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS push_campaigns (
+    id CHAR(26) PRIMARY KEY,
+    author_id INT REFERENCES users(id) ON DELETE SET NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    segment_city_id INT REFERENCES cities(city_id),
+    segment_looking_for_team BOOLEAN,
+    segment_inactive_days INT CHECK (segment_inactive_days > 0),
+    scheduled_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'sending', 'sent', 'cancelled')),
+    recipients INT,
+    queued INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_campaigns_due ON push_campaigns(scheduled_at) WHERE status = 'scheduled';
+
+-- Занятия и партнеры
+
+CREATE TABLE IF NOT EXISTS classes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    teacher_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    city_id INT REFERENCES cities(city_id),
+    level VARCHAR(20) NOT NULL CHECK (level IN ('beginner', 'intermediate', 'advanced', 'all')),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    schedule TEXT NOT NULL DEFAULT '',
+    capacity INT NOT NULL CHECK (capacity > 0),
+    price_cents INT NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+    currency CHAR(3),
+    chat_id TEXT REFERENCES chats(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_classes_ends_at ON classes(ends_at);
+CREATE INDEX IF NOT EXISTS idx_classes_teacher ON classes(teacher_id);
+
+CREATE TABLE IF NOT EXISTS class_enrollments (
+    class_id INT REFERENCES classes(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    enrolled_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (class_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_class_enrollments_user ON class_enrollments(user_id);
+
+CREATE TABLE IF NOT EXISTS partner_matches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id1 INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id2 INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    chat_id TEXT REFERENCES chats(id) ON DELETE SET NULL,
+    matched_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id1 <> user_id2)
+);
+
+CREATE TABLE IF NOT EXISTS partner_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    city_id INT NOT NULL REFERENCES cities(city_id),
+    level VARCHAR(20) NOT NULL CHECK (level IN ('beginner', 'intermediate', 'advanced')),
+    available_from TIMESTAMP NOT NULL,
+    available_until TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'matched', 'cancelled', 'expired')),
+    match_id INT REFERENCES partner_matches(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (available_until > available_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_partner_requests_waiting_user ON partner_requests(user_id) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_partner_requests_waiting ON partner_requests(city_id, available_until) WHERE status = 'waiting';
+
+-- Рефералы
+
+CREATE TABLE IF NOT EXISTS referral_codes (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS referrals (
+    referred_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    referrer_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (referred_id <> referrer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_id ON referrals(referrer_id);
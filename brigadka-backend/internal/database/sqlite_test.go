@@ -0,0 +1,62 @@
+//go:build sqlite
+
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteSchema(t *testing.T) {
+	config := &Config{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "demo.db")}
+	db, err := NewConnection(config)
+	require.NoError(t, err)
+	assert.Equal(t, SQLite, DialectFor(db))
+	require.NoError(t, db.Close())
+
+	// The schema is applied on every start and must not duplicate the seed data
+	db, err = NewConnection(config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var cities, bots int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM cities").Scan(&cities))
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users WHERE role = 'bot'").Scan(&bots))
+	assert.Equal(t, 12, cities)
+	assert.Equal(t, 1, bots)
+
+	var userID int
+	require.NoError(t, db.QueryRow(
+		"INSERT INTO users (email, password_hash) VALUES ($1, $2) RETURNING id", "demo@example.com", "hash",
+	).Scan(&userID))
+	_, err = db.Exec("INSERT INTO chats (id, is_group) VALUES ($1, $2)", "chat-1", false)
+	require.NoError(t, err)
+
+	// messages.seq is assigned by the database, as BIGSERIAL is in PostgreSQL
+	var seqs []int64
+	for _, id := range []string{"message-1", "message-2"} {
+		var sentAt time.Time
+		var seq int64
+		require.NoError(t, db.QueryRow(
+			"INSERT INTO messages (id, chat_id, sender_id, content) VALUES ($1, $2, $3, $4) RETURNING sent_at, seq",
+			id, "chat-1", userID, "hello",
+		).Scan(&sentAt, &seq))
+		assert.WithinDuration(t, time.Now(), sentAt, time.Minute)
+		seqs = append(seqs, seq)
+	}
+	assert.Less(t, seqs[0], seqs[1])
+
+	// Times written by the application compare with CURRENT_TIMESTAMP
+	until := time.Now().UTC().Add(time.Hour)
+	_, err = db.Exec("INSERT INTO support_access_grants (user_id, expires_at) VALUES ($1, $2)", userID, until)
+	require.NoError(t, err)
+	var expiresAt time.Time
+	require.NoError(t, db.QueryRow(
+		"SELECT expires_at FROM support_access_grants WHERE user_id = $1 AND expires_at > "+SQLite.Now(), userID,
+	).Scan(&expiresAt))
+	assert.True(t, until.Equal(expiresAt))
+}
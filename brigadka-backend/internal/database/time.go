@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Форматы, в которых SQLite хранит время: CURRENT_TIMESTAMP и значения,
+// записанные драйвером с _time_format=sqlite
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02",
+}
+
+// Time считывает время из вычисляемого столбца (MAX, COALESCE и т.п.) в dest.
+// Драйвер SQLite разбирает время только для столбцов таблиц с типом TIMESTAMP,
+// а вычисляемые значения возвращает строкой.
+func Time(dest *time.Time) sql.Scanner {
+	return timeScanner{dest: dest}
+}
+
+// NullTime — то же, что Time, для столбцов, которые могут быть NULL
+func NullTime(dest *sql.NullTime) sql.Scanner {
+	return nullTimeScanner{dest: dest}
+}
+
+type timeScanner struct {
+	dest *time.Time
+}
+
+func (s timeScanner) Scan(value any) error {
+	var t sql.NullTime
+	if err := (nullTimeScanner{dest: &t}).Scan(value); err != nil {
+		return err
+	}
+	if !t.Valid {
+		return fmt.Errorf("converting NULL to time.Time is unsupported")
+	}
+	*s.dest = t.Time
+	return nil
+}
+
+type nullTimeScanner struct {
+	dest *sql.NullTime
+}
+
+func (s nullTimeScanner) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return s.parse(string(v))
+	case string:
+		return s.parse(v)
+	}
+	return s.dest.Scan(value)
+}
+
+func (s nullTimeScanner) parse(value string) error {
+	for _, layout := range sqliteTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			*s.dest = sql.NullTime{Time: t, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as time", value)
+}
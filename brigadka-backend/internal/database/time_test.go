@@ -0,0 +1,28 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeScanners(t *testing.T) {
+	want := time.Date(2026, 3, 1, 12, 30, 15, 500000000, time.UTC)
+
+	var got time.Time
+	for _, value := range []any{want, "2026-03-01 12:30:15.5+00:00", []byte("2026-03-01 12:30:15.5")} {
+		require.NoError(t, Time(&got).Scan(value))
+		assert.True(t, want.Equal(got), "%v", value)
+	}
+	assert.Error(t, Time(&got).Scan(nil))
+	assert.Error(t, Time(&got).Scan("yesterday"))
+
+	var nullable sql.NullTime
+	require.NoError(t, NullTime(&nullable).Scan("2026-03-01 12:30:15"))
+	assert.Equal(t, sql.NullTime{Time: want.Truncate(time.Second), Valid: true}, nullable)
+	require.NoError(t, NullTime(&nullable).Scan(nil))
+	assert.False(t, nullable.Valid)
+}
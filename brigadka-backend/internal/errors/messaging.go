@@ -3,10 +3,25 @@ package errors
 // Messaging service error constants
 const (
 	ErrorUserNotInChat               = "user not in chat"
+	ErrorChatNotFound                = "chat not found"
 	ErrorInvalidReactionCode         = "invalid reaction code"
 	ErrorNotAuthorizedToReact        = "user not authorized to react to this message"
 	ErrorCannotCreateChatWithSelf    = "cannot create direct chat with yourself"
 	ErrorChatAlreadyExistsWithThisID = "chat already exists with this ID"
 	ErrorMessageAlreadyExists        = "message with this ID already exists"
 	ErrorReactionAlreadyExists       = "reaction already exists with this ID"
+	ErrorOrganizerContactNotAllowed  = "user does not accept contact from organizers"
+	ErrorMessageNotFound             = "message not found"
+	ErrorEmptyMessage                = "message has neither content nor attachments"
+	ErrorInvalidAttachment           = "attachments must be up to 10 photos or videos uploaded by the sender"
+	ErrorInvalidMuteUntil            = "mute end must be in the future"
+	ErrorNotGroupChat                = "chat is not a group chat"
+	ErrorNotChatAdmin                = "only chat admins can do this"
+	ErrorParticipantNotFound         = "user is not a participant of the chat"
+	ErrorInvalidChatName             = "chat name must be 1 to 255 characters"
+	ErrorInvalidChatDescription      = "chat description must be up to 1000 characters"
+	ErrorInvalidChatAvatar           = "chat avatar must be an image uploaded by the admin"
+	ErrorInvalidDirectMessagePolicy  = "direct message policy must be everyone or filtered"
+	ErrorEncryptedGroupChat          = "only direct chats can be end-to-end encrypted"
+	ErrorInvalidCiphertext           = "ciphertext must be up to 65536 characters, without content or attachments"
 )
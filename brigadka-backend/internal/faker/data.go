@@ -0,0 +1,70 @@
+package faker
+
+// Cities seeded in migrations, weighted by population in thousands
+var cities = []weighted[int]{
+	{1, 13100}, // Москва
+	{2, 5600},  // Санкт-Петербург
+	{3, 1630},  // Новосибирск
+	{4, 1540},  // Екатеринбург
+	{5, 1320},  // Казань
+	{6, 1200},  // Нижний Новгород
+	{7, 1140},  // Краснодар
+	{8, 1160},  // Самара
+	{9, 2000},  // Минск
+	{10, 2230}, // Алматы
+	{11, 1280}, // Тбилиси
+	{12, 1090}, // Ереван
+}
+
+// Improv styles from improv_style_catalog, weighted by popularity
+var improvStyles = []weighted[string]{
+	{"shortform", 30},
+	{"longform", 20},
+	{"battles", 12},
+	{"musical", 8},
+	{"rap", 5},
+	{"playback", 7},
+	{"absurd", 10},
+	{"realistic", 8},
+}
+
+var russianMaleNames = []string{
+	"Александр", "Дмитрий", "Максим", "Сергей", "Андрей", "Алексей", "Артём", "Илья",
+	"Кирилл", "Михаил", "Никита", "Иван", "Егор", "Роман", "Павел", "Тимур",
+}
+
+var russianFemaleNames = []string{
+	"Анна", "Мария", "Елена", "Ольга", "Наталья", "Екатерина", "Анастасия", "Дарья",
+	"Полина", "Алиса", "Софья", "Виктория", "Ксения", "Юлия", "Алина", "Вера",
+}
+
+// Masculine forms; the feminine form adds "а"
+var russianSurnames = []string{
+	"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов",
+	"Новиков", "Фёдоров", "Морозов", "Волков", "Алексеев", "Лебедев", "Семёнов", "Егоров",
+}
+
+var englishMaleNames = []string{
+	"James", "John", "Robert", "Michael", "David", "William", "Thomas", "Daniel",
+}
+
+var englishFemaleNames = []string{
+	"Mary", "Emma", "Olivia", "Sophia", "Emily", "Grace", "Chloe", "Lucy",
+}
+
+var englishSurnames = []string{
+	"Smith", "Johnson", "Brown", "Taylor", "Miller", "Wilson", "Davis", "Clark",
+}
+
+// Bio fragments: opener, experience, wish
+var russianBioParts = [3][]string{
+	{"Занимаюсь импровом", "Играю импровизацию", "Выступаю на джемах", "Изучаю импровизацию"},
+	{"уже три года.", "с прошлой осени.", "после курсов в студии.", "и не могу остановиться."},
+	{"Ищу команду для регулярных шоу.", "Хочу больше джемов.", "Люблю длинную форму.", "Люблю эксперименты."},
+}
+
+var englishBioParts = [3][]string{
+	{"I have been doing improv", "Improv player", "Theatre kid turned improviser", "Stand-up comic exploring improv"},
+	{"for three years.", "since last fall.", "after a studio course.", "and loving every minute."},
+	{"Looking for a team to perform with.", "Always up for a jam.", "Big fan of longform.", "Open to experiments."},
+}
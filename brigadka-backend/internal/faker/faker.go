@@ -0,0 +1,170 @@
+// Package faker generates realistic fake users and profiles for tests,
+// local seeding and load testing. All output is derived from the seed,
+// so the same seed always produces the same sequence of values.
+package faker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Languages supported for names and bios
+const (
+	LangRU = "ru"
+	LangEN = "en"
+)
+
+// Profile is a generated profile matching the catalogs seeded by migrations
+type Profile struct {
+	FullName       string
+	Birthday       time.Time
+	Gender         string
+	CityID         int
+	Bio            string
+	Goal           string
+	ImprovStyles   []string
+	LookingForTeam bool
+}
+
+// Faker generates fake data from a deterministic source
+type Faker struct {
+	rnd     *rand.Rand
+	seed    int64
+	counter int
+}
+
+// New creates a faker with the given seed
+func New(seed int64) *Faker {
+	return &Faker{
+		rnd:  rand.New(rand.NewSource(seed)),
+		seed: seed,
+	}
+}
+
+// weighted is a value with a relative frequency
+type weighted[T any] struct {
+	value  T
+	weight int
+}
+
+func pick[T any](rnd *rand.Rand, items []weighted[T]) T {
+	total := 0
+	for _, item := range items {
+		total += item.weight
+	}
+	n := rnd.Intn(total)
+	for _, item := range items {
+		if n < item.weight {
+			return item.value
+		}
+		n -= item.weight
+	}
+	return items[len(items)-1].value
+}
+
+func oneOf(rnd *rand.Rand, items []string) string {
+	return items[rnd.Intn(len(items))]
+}
+
+// Email returns an email unique within this faker and seed
+func (f *Faker) Email() string {
+	f.counter++
+	return fmt.Sprintf("fake_%d_%d_%04d@example.com", f.seed, f.counter, f.rnd.Intn(10000))
+}
+
+// Password returns a password that satisfies the default password policy
+func (f *Faker) Password() string {
+	const letters = "abcdefghijkmnopqrstuvwxyz"
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = letters[f.rnd.Intn(len(letters))]
+	}
+	return fmt.Sprintf("Fk%s%d!", b, f.rnd.Intn(100))
+}
+
+// Lang returns a language, mostly Russian as in the real user base
+func (f *Faker) Lang() string {
+	return pick(f.rnd, []weighted[string]{{LangRU, 8}, {LangEN, 2}})
+}
+
+// Gender returns a gender code from gender_catalog
+func (f *Faker) Gender() string {
+	return pick(f.rnd, []weighted[string]{{"female", 55}, {"male", 45}})
+}
+
+// FullName returns a full name for the given gender and language
+func (f *Faker) FullName(gender, lang string) string {
+	if lang == LangEN {
+		first := englishMaleNames
+		if gender == "female" {
+			first = englishFemaleNames
+		}
+		return oneOf(f.rnd, first) + " " + oneOf(f.rnd, englishSurnames)
+	}
+
+	if gender == "female" {
+		// Russian surnames agree with gender: Иванов -> Иванова
+		return oneOf(f.rnd, russianFemaleNames) + " " + oneOf(f.rnd, russianSurnames) + "а"
+	}
+	return oneOf(f.rnd, russianMaleNames) + " " + oneOf(f.rnd, russianSurnames)
+}
+
+// Bio returns a short profile description in the given language
+func (f *Faker) Bio(lang string) string {
+	parts := russianBioParts
+	if lang == LangEN {
+		parts = englishBioParts
+	}
+	return oneOf(f.rnd, parts[0]) + " " + oneOf(f.rnd, parts[1]) + " " + oneOf(f.rnd, parts[2])
+}
+
+// CityID returns a city from the cities table, weighted by population
+func (f *Faker) CityID() int {
+	return pick(f.rnd, cities)
+}
+
+// Goal returns a goal from improv_goals_catalog
+func (f *Faker) Goal() string {
+	return pick(f.rnd, []weighted[string]{{"hobby", 7}, {"career", 3}})
+}
+
+// ImprovStyles returns one to three distinct styles following their popularity
+func (f *Faker) ImprovStyles() []string {
+	count := 1 + f.rnd.Intn(3)
+	seen := make(map[string]bool, count)
+	styles := make([]string, 0, count)
+	for len(styles) < count {
+		style := pick(f.rnd, improvStyles)
+		if !seen[style] {
+			seen[style] = true
+			styles = append(styles, style)
+		}
+	}
+	return styles
+}
+
+// Birthday returns a date of birth for an adult between 18 and 45 years old
+func (f *Faker) Birthday() time.Time {
+	age := 18 + f.rnd.Intn(28)
+	day := f.rnd.Intn(365)
+	// Anchored to a fixed date rather than time.Now to keep output deterministic
+	base := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return base.AddDate(-age, 0, -day)
+}
+
+// Profile returns a complete, internally consistent profile
+func (f *Faker) Profile() Profile {
+	lang := f.Lang()
+	gender := f.Gender()
+	return Profile{
+		FullName:       f.FullName(gender, lang),
+		Birthday:       f.Birthday(),
+		Gender:         gender,
+		CityID:         f.CityID(),
+		Bio:            f.Bio(lang),
+		Goal:           f.Goal(),
+		ImprovStyles:   f.ImprovStyles(),
+		LookingForTeam: f.rnd.Intn(3) == 0,
+	}
+}
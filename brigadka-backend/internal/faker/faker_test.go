@@ -0,0 +1,74 @@
+package faker
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameSeedProducesSameProfiles(t *testing.T) {
+	a := New(42)
+	b := New(42)
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, a.Profile(), b.Profile())
+		assert.Equal(t, a.Email(), b.Email())
+	}
+}
+
+func TestDifferentSeedsProduceDifferentEmails(t *testing.T) {
+	assert.NotEqual(t, New(1).Email(), New(2).Email())
+}
+
+func TestProfileUsesCatalogValues(t *testing.T) {
+	f := New(7)
+	styles := map[string]bool{}
+	for _, s := range improvStyles {
+		styles[s.value] = true
+	}
+	cityIDs := map[int]bool{}
+	for _, c := range cities {
+		cityIDs[c.value] = true
+	}
+
+	for i := 0; i < 100; i++ {
+		p := f.Profile()
+		assert.Contains(t, []string{"male", "female"}, p.Gender)
+		assert.Contains(t, []string{"hobby", "career"}, p.Goal)
+		assert.True(t, cityIDs[p.CityID], "unknown city %d", p.CityID)
+		assert.NotEmpty(t, p.FullName)
+		assert.NotEmpty(t, p.Bio)
+		assert.NotEmpty(t, p.ImprovStyles)
+		assert.LessOrEqual(t, len(p.ImprovStyles), 3)
+		for _, s := range p.ImprovStyles {
+			assert.True(t, styles[s], "unknown style %s", s)
+		}
+	}
+}
+
+func TestCitiesWeightedByPopulation(t *testing.T) {
+	f := New(3)
+	counts := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		counts[f.CityID()]++
+	}
+	assert.Greater(t, counts[1], counts[2])
+	assert.Greater(t, counts[2], counts[12])
+	assert.Len(t, counts, len(cities), "every seeded city is picked")
+}
+
+func TestPasswordSatisfiesDefaultPolicy(t *testing.T) {
+	f := New(5)
+	for i := 0; i < 20; i++ {
+		password := f.Password()
+		var upper, lower, digit bool
+		for _, r := range password {
+			upper = upper || unicode.IsUpper(r)
+			lower = lower || unicode.IsLower(r)
+			digit = digit || unicode.IsDigit(r)
+		}
+		assert.GreaterOrEqual(t, len(password), 8)
+		assert.True(t, upper && lower && digit, password)
+	}
+}
@@ -0,0 +1,643 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/admin"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+//go:generate moq -out mocks_test.go . AdminService
+
+// AdminService defines the admin operations used by the handler
+type AdminService interface {
+	SearchUsers(ctx context.Context, filter admin.UserFilter, page, pageSize int) (*admin.UserDirectory, error)
+	SearchProfiles(ctx context.Context, filter admin.ProfileFilter, page, pageSize int) (*admin.ProfileList, error)
+	UpdateProfile(ctx context.Context, adminID, userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error)
+	BanProfile(ctx context.Context, adminID, userID int, reason string) error
+	UnbanProfile(ctx context.Context, adminID, userID int) error
+	VerifyProfile(ctx context.Context, adminID, userID int) error
+	UnverifyProfile(ctx context.Context, adminID, userID int) error
+	GetAuditLog(ctx context.Context, filter admin.AuditFilter, page, pageSize int) (*admin.AuditLog, error)
+	StartImpersonation(ctx context.Context, adminID, userID int) (*admin.ImpersonationSession, error)
+
+	GetDashboard(ctx context.Context) (*admin.Dashboard, error)
+	ShadowbanProfile(ctx context.Context, adminID, userID int, reason string) error
+	UnshadowbanProfile(ctx context.Context, adminID, userID int) error
+	RemoveMessage(ctx context.Context, adminID int, messageID, reason string) error
+	RemoveMedia(ctx context.Context, adminID, mediaID int, reason string) error
+
+	GetSupportAccess(ctx context.Context, userID int) (*admin.SupportAccessStatus, error)
+	GrantSupportAccess(ctx context.Context, userID int) (*admin.SupportAccessStatus, error)
+	RevokeSupportAccess(ctx context.Context, userID int) error
+}
+
+// Handler handles admin tools. Routes must be mounted behind RequireRole(admin),
+// except the support access routes where users manage their consent to impersonation.
+type Handler struct {
+	service AdminService
+}
+
+// NewHandler creates a new admin handler
+func NewHandler(service AdminService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ProfileUpdateRequest represents an admin edit of a profile. Omitted fields are left unchanged.
+type ProfileUpdateRequest struct {
+	FullName       *string  `json:"full_name,omitempty"`
+	Bio            *string  `json:"bio,omitempty"`
+	CityID         *int     `json:"city_id,omitempty"`
+	Gender         *string  `json:"gender,omitempty"`
+	Goal           *string  `json:"goal,omitempty"`
+	Goals          []string `json:"goals,omitempty"`
+	ImprovStyles   []string `json:"improv_styles,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	LookingForTeam *bool    `json:"looking_for_team,omitempty"`
+}
+
+// BanProfileRequest represents a profile ban
+type BanProfileRequest struct {
+	Reason string `json:"reason,omitempty"` // Recorded in the audit log
+}
+
+// RemoveContentRequest represents the removal of a message or media
+type RemoveContentRequest struct {
+	Reason string `json:"reason,omitempty"` // Recorded in the audit log
+}
+
+// @Summary      User directory
+// @Description  Find accounts by email, name or ID with profile, suspension, report and activity details. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        query      query  string  false  "Email or name substring, or user ID"
+// @Param        status     query  string  false  "active, suspended or hidden"
+// @Param        city       query  int     false  "City ID"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Security     BearerAuth
+// @Success      200  {object}  admin.UserDirectory
+// @Failure      400  {string}  string  "Invalid filter"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/users [get]
+func (h *Handler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := admin.UserFilter{
+		Query:  query.Get("query"),
+		Status: query.Get("status"),
+	}
+	if city := query.Get("city"); city != "" {
+		cityID, err := strconv.Atoi(city)
+		if err != nil {
+			http.Error(w, "Invalid city", http.StatusBadRequest)
+			return
+		}
+		filter.CityID = &cityID
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	directory, err := h.service.SearchUsers(r.Context(), filter, page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(directory)
+}
+
+// @Summary      Profile list
+// @Description  Profiles with verification and ban state, newest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        query      query  string  false  "Name or email substring, or user ID"
+// @Param        city       query  int     false  "City ID"
+// @Param        verified   query  bool    false  "Only verified (true) or unverified (false) profiles"
+// @Param        banned     query  bool    false  "Only banned (true) or visible (false) profiles"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Security     BearerAuth
+// @Success      200  {object}  admin.ProfileList
+// @Failure      400  {string}  string  "Invalid filter"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles [get]
+func (h *Handler) SearchProfiles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := admin.ProfileFilter{Query: query.Get("query")}
+	if city := query.Get("city"); city != "" {
+		cityID, err := strconv.Atoi(city)
+		if err != nil {
+			http.Error(w, "Invalid city", http.StatusBadRequest)
+			return
+		}
+		filter.CityID = &cityID
+	}
+	var err error
+	if filter.Verified, err = parseBoolParam(query.Get("verified")); err != nil {
+		http.Error(w, "Invalid verified filter", http.StatusBadRequest)
+		return
+	}
+	if filter.Banned, err = parseBoolParam(query.Get("banned")); err != nil {
+		http.Error(w, "Invalid banned filter", http.StatusBadRequest)
+		return
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	list, err := h.service.SearchProfiles(r.Context(), filter, page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// @Summary      Edit profile
+// @Description  Edit a user's profile on their behalf. The change is recorded in the audit log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        userID   path  int                   true  "User ID"
+// @Param        request  body  ProfileUpdateRequest  true  "Changed fields"
+// @Security     BearerAuth
+// @Success      200  {object}  profile.Profile
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID} [patch]
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	adminID, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var req ProfileUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.UpdateProfile(r.Context(), adminID, userID, profile.ProfileUpdateRequest{
+		FullName:       req.FullName,
+		Bio:            req.Bio,
+		CityID:         req.CityID,
+		Gender:         req.Gender,
+		Goal:           req.Goal,
+		Goals:          req.Goals,
+		ImprovStyles:   req.ImprovStyles,
+		Tags:           req.Tags,
+		LookingForTeam: req.LookingForTeam,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// @Summary      Ban profile
+// @Description  Hide the profile from search and the community. Use suspensions to lock the account. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Param        userID   path  int                true   "User ID"
+// @Param        request  body  BanProfileRequest  false  "Ban reason"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/ban [post]
+func (h *Handler) BanProfile(w http.ResponseWriter, r *http.Request) {
+	adminID, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var req BanProfileRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	if err := h.service.BanProfile(r.Context(), adminID, userID, req.Reason); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Unban profile
+// @Description  Return the profile to search, including profiles hidden after a report. Admin only.
+// @Tags         admin
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/ban [delete]
+func (h *Handler) UnbanProfile(w http.ResponseWriter, r *http.Request) {
+	h.setProfileState(w, r, h.service.UnbanProfile)
+}
+
+// @Summary      Verify profile
+// @Description  Mark the profile as verified. Admin only.
+// @Tags         admin
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/verify [post]
+func (h *Handler) VerifyProfile(w http.ResponseWriter, r *http.Request) {
+	h.setProfileState(w, r, h.service.VerifyProfile)
+}
+
+// @Summary      Revoke verification
+// @Description  Remove the verified mark from the profile. Admin only.
+// @Tags         admin
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/verify [delete]
+func (h *Handler) UnverifyProfile(w http.ResponseWriter, r *http.Request) {
+	h.setProfileState(w, r, h.service.UnverifyProfile)
+}
+
+// @Summary      Shadowban profile
+// @Description  Keep the profile out of search, recommendations and tag suggestions without telling its owner. Chats and teams are unaffected. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Param        userID   path  int                true   "User ID"
+// @Param        request  body  BanProfileRequest  false  "Shadowban reason"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/shadowban [post]
+func (h *Handler) ShadowbanProfile(w http.ResponseWriter, r *http.Request) {
+	adminID, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var req BanProfileRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	if err := h.service.ShadowbanProfile(r.Context(), adminID, userID, req.Reason); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Lift shadowban
+// @Description  Return a shadowbanned profile to search and recommendations. Admin only.
+// @Tags         admin
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/profiles/{userID}/shadowban [delete]
+func (h *Handler) UnshadowbanProfile(w http.ResponseWriter, r *http.Request) {
+	h.setProfileState(w, r, h.service.UnshadowbanProfile)
+}
+
+// @Summary      Moderation dashboard
+// @Description  Open reports, recent sign-ups, flagged media and message volume. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  admin.Dashboard
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/dashboard [get]
+func (h *Handler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	dashboard, err := h.service.GetDashboard(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dashboard)
+}
+
+// @Summary      Remove message
+// @Description  Hide a message from chat history and resolve its open reports. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Param        messageID  path  string                true   "Message ID"
+// @Param        request    body  RemoveContentRequest  false  "Removal reason"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Content not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/messages/{messageID} [delete]
+func (h *Handler) RemoveMessage(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req RemoveContentRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	if err := h.service.RemoveMessage(r.Context(), adminID, chi.URLParam(r, "messageID"), req.Reason); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Remove media
+// @Description  Reject media so it is no longer shown and resolve its open reports. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Param        mediaID  path  int                   true   "Media ID"
+// @Param        request  body  RemoveContentRequest  false  "Removal reason"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Content not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/media/{mediaID} [delete]
+func (h *Handler) RemoveMedia(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "mediaID"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	var req RemoveContentRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	if err := h.service.RemoveMedia(r.Context(), adminID, mediaID, req.Reason); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Audit log
+// @Description  Actions taken by admins, newest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        admin_id     query  int     false  "Admin user ID"
+// @Param        action       query  string  false  "Action, e.g. profile.ban"
+// @Param        target_type  query  string  false  "Target type, e.g. profile"
+// @Param        target_id    query  string  false  "Target ID"
+// @Param        page         query  int     false  "Page number"
+// @Param        page_size    query  int     false  "Page size"
+// @Security     BearerAuth
+// @Success      200  {object}  admin.AuditLog
+// @Failure      400  {string}  string  "Invalid filter"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/audit [get]
+func (h *Handler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := admin.AuditFilter{
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+	}
+	if value := query.Get("admin_id"); value != "" {
+		adminID, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+			return
+		}
+		filter.AdminID = &adminID
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	auditLog, err := h.service.GetAuditLog(r.Context(), filter, page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(auditLog)
+}
+
+// @Summary      View as user
+// @Description  Issue a short-lived read-only token acting as the user. The user must have granted support access; every request made with the token is audited. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      200  {object}  admin.ImpersonationSession
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Support access not granted or user is an admin"
+// @Failure      404  {string}  string  "User not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/users/{userID}/impersonate [post]
+func (h *Handler) StartImpersonation(w http.ResponseWriter, r *http.Request) {
+	adminID, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.StartImpersonation(r.Context(), adminID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// @Summary      Support access status
+// @Description  Whether support may currently view the app as the current user
+// @Tags         support
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  admin.SupportAccessStatus
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /support-access [get]
+func (h *Handler) GetSupportAccess(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.service.GetSupportAccess(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// @Summary      Grant support access
+// @Description  Let support view the app as the current user for 72 hours, read-only. Granting again extends the grant.
+// @Tags         support
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  admin.SupportAccessStatus
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /support-access [put]
+func (h *Handler) GrantSupportAccess(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.service.GrantSupportAccess(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// @Summary      Revoke support access
+// @Description  Withdraw consent to impersonation. Active support sessions stop working immediately.
+// @Tags         support
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /support-access [delete]
+func (h *Handler) RevokeSupportAccess(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeSupportAccess(r.Context(), userID); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setProfileState runs a bodiless profile action and responds with 204
+func (h *Handler) setProfileState(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, adminID, userID int) error) {
+	adminID, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	if err := action(r.Context(), adminID, userID); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// adminTarget reads the acting admin and the target user ID, writing an error response on failure
+func adminTarget(w http.ResponseWriter, r *http.Request) (adminID, userID int, ok bool) {
+	adminID, ok = r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, 0, false
+	}
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return adminID, userID, true
+}
+
+// decodeOptionalBody decodes the request body when there is one, writing an error response on failure
+func decodeOptionalBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// parseBoolParam parses an optional boolean query parameter
+func parseBoolParam(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, admin.ErrProfileNotFound), errors.Is(err, profile.ErrProfileNotFound):
+		http.Error(w, "Profile not found", http.StatusNotFound)
+	case errors.Is(err, admin.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, admin.ErrContentNotFound):
+		http.Error(w, "Content not found", http.StatusNotFound)
+	case errors.Is(err, admin.ErrNoSupportAccess), errors.Is(err, admin.ErrImpersonateAdmin):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, admin.ErrInvalidStatus), errors.Is(err, admin.ErrInvalidBanReason),
+		errors.Is(err, profile.ErrInvalidCity), errors.Is(err, profile.ErrInvalidGender),
+		errors.Is(err, profile.ErrInvalidImprovGoal), errors.Is(err, profile.ErrInvalidImprovStyle),
+		errors.Is(err, profile.ErrInvalidTag):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Admin error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
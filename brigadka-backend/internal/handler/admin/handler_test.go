@@ -0,0 +1,389 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/admin"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+func TestSearchUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		serviceErr error
+		wantStatus int
+		wantFilter admin.UserFilter
+	}{
+		{"all users", "/api/admin/users", nil, http.StatusOK, admin.UserFilter{}},
+		{"filtered", "/api/admin/users?query=anna&status=suspended&city=2", nil, http.StatusOK, admin.UserFilter{Query: "anna", Status: "suspended", CityID: intPtr(2)}},
+		{"invalid city", "/api/admin/users?city=moscow", nil, http.StatusBadRequest, admin.UserFilter{}},
+		{"invalid status", "/api/admin/users?status=banned", admin.ErrInvalidStatus, http.StatusBadRequest, admin.UserFilter{Status: "banned"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				SearchUsersFunc: func(ctx context.Context, filter admin.UserFilter, page int, pageSize int) (*admin.UserDirectory, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &admin.UserDirectory{Users: []admin.UserSummary{{ID: 42, Email: "anna@example.com"}}, TotalCount: 1, Page: 1, PageSize: 20}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.SearchUsers(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if calls := service.SearchUsersCalls(); len(calls) > 0 {
+				assert.Equal(t, tt.wantFilter, calls[0].Filter)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp admin.UserDirectory
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 42, resp.Users[0].ID)
+			}
+		})
+	}
+}
+
+func TestSearchProfiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantFilter admin.ProfileFilter
+	}{
+		{"all profiles", "/api/admin/profiles", http.StatusOK, admin.ProfileFilter{}},
+		{"filtered", "/api/admin/profiles?query=anna&city=2&verified=true&banned=false", http.StatusOK,
+			admin.ProfileFilter{Query: "anna", CityID: intPtr(2), Verified: boolPtr(true), Banned: boolPtr(false)}},
+		{"invalid verified", "/api/admin/profiles?verified=yes", http.StatusBadRequest, admin.ProfileFilter{}},
+		{"invalid banned", "/api/admin/profiles?banned=maybe", http.StatusBadRequest, admin.ProfileFilter{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				SearchProfilesFunc: func(ctx context.Context, filter admin.ProfileFilter, page int, pageSize int) (*admin.ProfileList, error) {
+					return &admin.ProfileList{Profiles: []admin.ProfileSummary{{UserID: 7, FullName: "Anna"}}, TotalCount: 1, Page: 1, PageSize: 20}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.SearchProfiles(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			calls := service.SearchProfilesCalls()
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, calls)
+				return
+			}
+			assert.Equal(t, tt.wantFilter, calls[0].Filter)
+		})
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "7", nil, http.StatusOK},
+		{"invalid user id", "abc", nil, http.StatusBadRequest},
+		{"profile not found", "7", profile.ErrProfileNotFound, http.StatusNotFound},
+		{"invalid city", "7", profile.ErrInvalidCity, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				UpdateProfileFunc: func(ctx context.Context, adminID int, userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.Profile{UserID: userID, FullName: *req.FullName}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			name := "Anna K."
+			rec := httptest.NewRecorder()
+			params := map[string]string{"userID": tt.userID}
+			h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/admin/profiles/"+tt.userID, ProfileUpdateRequest{FullName: &name}, 1, params))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				call := service.UpdateProfileCalls()[0]
+				assert.Equal(t, 1, call.AdminID)
+				assert.Equal(t, 7, call.UserID)
+				assert.Equal(t, &name, call.Req.FullName)
+			}
+		})
+	}
+}
+
+func TestBanProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+	}{
+		{"with reason", BanProfileRequest{Reason: "Spam"}, nil, http.StatusNoContent},
+		{"without body", nil, nil, http.StatusNoContent},
+		{"profile not found", nil, admin.ErrProfileNotFound, http.StatusNotFound},
+		{"reason too long", BanProfileRequest{Reason: "..."}, admin.ErrInvalidBanReason, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				BanProfileFunc: func(ctx context.Context, adminID int, userID int, reason string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.BanProfile(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/profiles/7/ban", tt.body, 1, map[string]string{"userID": "7"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.BanProfileCalls()[0]
+			assert.Equal(t, 1, call.AdminID)
+			assert.Equal(t, 7, call.UserID)
+			if req, ok := tt.body.(BanProfileRequest); ok {
+				assert.Equal(t, req.Reason, call.Reason)
+			}
+		})
+	}
+}
+
+func TestVerifyProfile(t *testing.T) {
+	service := &AdminServiceMock{
+		VerifyProfileFunc: func(ctx context.Context, adminID int, userID int) error {
+			return nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.VerifyProfile(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/profiles/7/verify", nil, 1, map[string]string{"userID": "7"}))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 7, service.VerifyProfileCalls()[0].UserID)
+
+	rec = httptest.NewRecorder()
+	h.VerifyProfile(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/profiles/7/verify", nil, 0, map[string]string{"userID": "7"}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestShadowbanProfile(t *testing.T) {
+	service := &AdminServiceMock{
+		ShadowbanProfileFunc: func(ctx context.Context, adminID int, userID int, reason string) error {
+			return nil
+		},
+		UnshadowbanProfileFunc: func(ctx context.Context, adminID int, userID int) error {
+			return admin.ErrProfileNotFound
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.ShadowbanProfile(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/profiles/7/shadowban", BanProfileRequest{Reason: "Spam"}, 1, map[string]string{"userID": "7"}))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	call := service.ShadowbanProfileCalls()[0]
+	assert.Equal(t, 1, call.AdminID)
+	assert.Equal(t, 7, call.UserID)
+	assert.Equal(t, "Spam", call.Reason)
+
+	rec = httptest.NewRecorder()
+	h.UnshadowbanProfile(rec, handlertest.NewRequest(http.MethodDelete, "/api/admin/profiles/8/shadowban", nil, 1, map[string]string{"userID": "8"}))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, 8, service.UnshadowbanProfileCalls()[0].UserID)
+}
+
+func TestGetDashboard(t *testing.T) {
+	service := &AdminServiceMock{
+		GetDashboardFunc: func(ctx context.Context) (*admin.Dashboard, error) {
+			return &admin.Dashboard{
+				Reports:  admin.DashboardReports{Open: 4, Targets: []admin.ReportedTarget{{TargetType: "profile", TargetID: "7", OpenReports: 3}}},
+				Messages: admin.DashboardMessages{LastDay: 120},
+			}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetDashboard(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/dashboard", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp admin.Dashboard
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 4, resp.Reports.Open)
+	assert.Equal(t, "7", resp.Reports.Targets[0].TargetID)
+	assert.Equal(t, 120, resp.Messages.LastDay)
+}
+
+func TestRemoveMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+	}{
+		{"with reason", RemoveContentRequest{Reason: "Harassment"}, nil, http.StatusNoContent},
+		{"without body", nil, nil, http.StatusNoContent},
+		{"not found", nil, admin.ErrContentNotFound, http.StatusNotFound},
+		{"reason too long", RemoveContentRequest{Reason: "..."}, admin.ErrInvalidBanReason, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				RemoveMessageFunc: func(ctx context.Context, adminID int, messageID string, reason string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.RemoveMessage(rec, handlertest.NewRequest(http.MethodDelete, "/api/admin/messages/msg-1", tt.body, 1, map[string]string{"messageID": "msg-1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.RemoveMessageCalls()[0]
+			assert.Equal(t, 1, call.AdminID)
+			assert.Equal(t, "msg-1", call.MessageID)
+			if req, ok := tt.body.(RemoveContentRequest); ok {
+				assert.Equal(t, req.Reason, call.Reason)
+			}
+		})
+	}
+}
+
+func TestRemoveMedia(t *testing.T) {
+	service := &AdminServiceMock{
+		RemoveMediaFunc: func(ctx context.Context, adminID int, mediaID int, reason string) error {
+			return nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.RemoveMedia(rec, handlertest.NewRequest(http.MethodDelete, "/api/admin/media/5", RemoveContentRequest{Reason: "Nudity"}, 1, map[string]string{"mediaID": "5"}))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	call := service.RemoveMediaCalls()[0]
+	assert.Equal(t, 5, call.MediaID)
+	assert.Equal(t, "Nudity", call.Reason)
+
+	rec = httptest.NewRecorder()
+	h.RemoveMedia(rec, handlertest.NewRequest(http.MethodDelete, "/api/admin/media/abc", nil, 1, map[string]string{"mediaID": "abc"}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Len(t, service.RemoveMediaCalls(), 1)
+}
+
+func TestGetAuditLog(t *testing.T) {
+	service := &AdminServiceMock{
+		GetAuditLogFunc: func(ctx context.Context, filter admin.AuditFilter, page int, pageSize int) (*admin.AuditLog, error) {
+			return &admin.AuditLog{Entries: []admin.AuditEntry{{ID: 3, Action: "profile.ban"}}, TotalCount: 1, Page: page, PageSize: pageSize}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetAuditLog(rec, httptest.NewRequest(http.MethodGet, "/api/admin/audit?admin_id=1&target_type=profile&target_id=7&page=2", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.GetAuditLogCalls()[0]
+	assert.Equal(t, admin.AuditFilter{AdminID: intPtr(1), TargetType: "profile", TargetID: "7"}, call.Filter)
+	assert.Equal(t, 2, call.Page)
+
+	rec = httptest.NewRecorder()
+	h.GetAuditLog(rec, httptest.NewRequest(http.MethodGet, "/api/admin/audit?admin_id=me", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestStartImpersonation(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"no consent", admin.ErrNoSupportAccess, http.StatusForbidden},
+		{"admin target", admin.ErrImpersonateAdmin, http.StatusForbidden},
+		{"user not found", admin.ErrUserNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AdminServiceMock{
+				StartImpersonationFunc: func(ctx context.Context, adminID int, userID int) (*admin.ImpersonationSession, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &admin.ImpersonationSession{Token: "token", UserID: userID}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.StartImpersonation(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/users/7/impersonate", nil, 1, map[string]string{"userID": "7"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.StartImpersonationCalls()[0]
+			assert.Equal(t, 1, call.AdminID)
+			assert.Equal(t, 7, call.UserID)
+			if tt.wantStatus == http.StatusOK {
+				var resp admin.ImpersonationSession
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "token", resp.Token)
+			}
+		})
+	}
+}
+
+func TestGrantSupportAccess(t *testing.T) {
+	service := &AdminServiceMock{
+		GrantSupportAccessFunc: func(ctx context.Context, userID int) (*admin.SupportAccessStatus, error) {
+			return &admin.SupportAccessStatus{Granted: true}, nil
+		},
+		RevokeSupportAccessFunc: func(ctx context.Context, userID int) error {
+			return nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GrantSupportAccess(rec, handlertest.NewRequest(http.MethodPut, "/api/support-access", nil, 7, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 7, service.GrantSupportAccessCalls()[0].UserID)
+
+	rec = httptest.NewRecorder()
+	h.RevokeSupportAccess(rec, handlertest.NewRequest(http.MethodDelete, "/api/support-access", nil, 7, nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 7, service.RevokeSupportAccessCalls()[0].UserID)
+
+	rec = httptest.NewRecorder()
+	h.GrantSupportAccess(rec, handlertest.NewRequest(http.MethodPut, "/api/support-access", nil, 0, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,173 @@
+package announcement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/announcement"
+)
+
+//go:generate moq -out mocks_test.go . AnnouncementService
+
+// AnnouncementService defines the announcement operations used by the handler
+type AnnouncementService interface {
+	PostAnnouncement(ctx context.Context, adminID int, title, body string) (*announcement.Announcement, error)
+	GetAnnouncements(ctx context.Context, userID int, beforeID string, limit int) (*announcement.Page, error)
+	MarkRead(ctx context.Context, userID int, announcementID string) (int, error)
+}
+
+// Handler handles the announcements channel
+type Handler struct {
+	service AnnouncementService
+}
+
+// NewHandler creates a new announcement handler
+func NewHandler(service AnnouncementService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostAnnouncementRequest represents the request to publish an announcement
+type PostAnnouncementRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// MarkReadRequest is the newest announcement the user has read
+type MarkReadRequest struct {
+	AnnouncementID string `json:"announcement_id"`
+}
+
+// MarkReadResponse is the number of announcements still unread
+type MarkReadResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// @Summary      Post announcement
+// @Description  Publish an announcement to all users. Connected users get it over the WebSocket, users with a registered device get a push notification. Admin only.
+// @Tags         announcements
+// @Accept       json
+// @Produce      json
+// @Param        request  body  PostAnnouncementRequest  true  "Announcement"
+// @Security     BearerAuth
+// @Success      201  {object}  announcement.Announcement
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/announcements [post]
+func (h *Handler) PostAnnouncement(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req PostAnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	posted, err := h.service.PostAnnouncement(r.Context(), userID, req.Title, req.Body)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(posted)
+}
+
+// @Summary      List announcements
+// @Description  Announcements of the administration, newest first, with the read flag and the number of unread ones
+// @Tags         announcements
+// @Produce      json
+// @Param        before  query  string  false  "Return announcements older than this ID (next_before of the previous page)"
+// @Param        limit   query  int     false  "Page size, 20 by default, at most 100"
+// @Security     BearerAuth
+// @Success      200  {object}  announcement.Page
+// @Failure      400  {string}  string  "Invalid limit"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /announcements [get]
+func (h *Handler) GetAnnouncements(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.service.GetAnnouncements(r.Context(), userID, r.URL.Query().Get("before"), limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// @Summary      Mark announcements read
+// @Description  Mark the announcement and all older ones read. The read position never moves back.
+// @Tags         announcements
+// @Accept       json
+// @Produce      json
+// @Param        request  body  MarkReadRequest  true  "Newest read announcement"
+// @Security     BearerAuth
+// @Success      200  {object}  MarkReadResponse
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Announcement not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /announcements/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req MarkReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AnnouncementID == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unread, err := h.service.MarkRead(r.Context(), userID, req.AnnouncementID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MarkReadResponse{UnreadCount: unread})
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, announcement.ErrAnnouncementNotFound):
+		http.Error(w, "Announcement not found", http.StatusNotFound)
+	case errors.Is(err, announcement.ErrInvalidTitle), errors.Is(err, announcement.ErrInvalidBody):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Announcement error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
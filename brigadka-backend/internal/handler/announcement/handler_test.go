@@ -0,0 +1,144 @@
+package announcement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/announcement"
+)
+
+func TestPostAnnouncement(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"empty title", 1, announcement.ErrInvalidTitle, http.StatusBadRequest},
+		{"too long", 1, announcement.ErrInvalidBody, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AnnouncementServiceMock{
+				PostAnnouncementFunc: func(ctx context.Context, adminID int, title, body string) (*announcement.Announcement, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &announcement.Announcement{ID: "01HZX", AuthorID: &adminID, Title: title, Body: body}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := PostAnnouncementRequest{Title: "Festival", Body: "Applications are open"}
+			rec := httptest.NewRecorder()
+			h.PostAnnouncement(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/announcements", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.PostAnnouncementCalls()[0]
+				assert.Equal(t, 1, call.AdminID)
+				assert.Equal(t, "Festival", call.Title)
+				assert.Equal(t, "Applications are open", call.Body)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp announcement.Announcement
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "01HZX", resp.ID)
+			}
+		})
+	}
+}
+
+func TestGetAnnouncements(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBefore string
+		wantLimit  int
+	}{
+		{"first page", "/api/announcements", http.StatusOK, "", 0},
+		{"older page", "/api/announcements?before=01B&limit=10", http.StatusOK, "01B", 10},
+		{"invalid limit", "/api/announcements?limit=ten", http.StatusBadRequest, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := "01A"
+			service := &AnnouncementServiceMock{
+				GetAnnouncementsFunc: func(ctx context.Context, userID int, beforeID string, limit int) (*announcement.Page, error) {
+					return &announcement.Page{
+						Announcements: []announcement.Announcement{{ID: "01A", Title: "Festival", Read: true}},
+						NextBefore:    &next,
+						UnreadCount:   2,
+					}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetAnnouncements(rec, handlertest.NewRequest(http.MethodGet, tt.target, nil, 3, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, service.GetAnnouncementsCalls())
+				return
+			}
+			call := service.GetAnnouncementsCalls()[0]
+			assert.Equal(t, 3, call.UserID)
+			assert.Equal(t, tt.wantBefore, call.BeforeID)
+			assert.Equal(t, tt.wantLimit, call.Limit)
+
+			var resp announcement.Page
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, 2, resp.UnreadCount)
+			assert.Equal(t, "01A", *resp.NextBefore)
+			assert.True(t, resp.Announcements[0].Read)
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       MarkReadRequest
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", MarkReadRequest{AnnouncementID: "01B"}, nil, http.StatusOK},
+		{"missing ID", MarkReadRequest{}, nil, http.StatusBadRequest},
+		{"unknown announcement", MarkReadRequest{AnnouncementID: "01Z"}, announcement.ErrAnnouncementNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AnnouncementServiceMock{
+				MarkReadFunc: func(ctx context.Context, userID int, announcementID string) (int, error) {
+					return 1, tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.MarkRead(rec, handlertest.NewRequest(http.MethodPost, "/api/announcements/read", tt.body, 3, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp MarkReadResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, MarkReadResponse{UnreadCount: 1}, resp)
+			}
+		})
+	}
+}
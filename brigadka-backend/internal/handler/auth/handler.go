@@ -3,22 +3,84 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	authService "github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
 )
 
+//go:generate moq -out mocks_test.go . AuthService Welcomer ReferralTracker SuspensionChecker ImpersonationGuard
+
+// AuthService defines the auth operations used by the handler
+type AuthService interface {
+	Login(email, password string) (*authService.AuthResponse, error)
+	Register(email, password string) (*authService.AuthResponse, error)
+	RefreshToken(refreshToken string) (*authService.AuthResponse, error)
+	VerifyToken(tokenString string) error
+	ParseAccessToken(tokenString string) (*authService.TokenClaims, error)
+	IssueGuestToken() (string, time.Time, error)
+	GetUserRole(userID int) (string, error)
+}
+
+// Welcomer starts the welcome conversation of a newly registered user
+type Welcomer interface {
+	StartBotConversation(ctx context.Context, userID int, lang string) error
+}
+
+// ReferralTracker attributes a new user to the referral code they signed up with
+type ReferralTracker interface {
+	Attribute(ctx context.Context, userID int, code string) error
+}
+
+// SuspensionChecker looks up the suspension in effect for a user
+type SuspensionChecker interface {
+	GetActiveSuspension(ctx context.Context, userID int) (*suspension.Suspension, error)
+}
+
+// ImpersonationGuard checks that the user still allows support access and
+// audits each request made with an impersonation token
+type ImpersonationGuard interface {
+	AuthorizeImpersonation(ctx context.Context, adminID, userID int, method, path string) (bool, error)
+}
+
 type AuthHandler struct {
-	authService *authService.AuthService
+	authService   AuthService
+	welcomer      Welcomer           // Optional, nil when the welcome bot is disabled
+	referrals     ReferralTracker    // Optional, referral codes are ignored when nil
+	suspensions   SuspensionChecker  // Optional, nil when suspensions are not enforced
+	impersonation ImpersonationGuard // Optional, impersonation tokens are rejected when nil
 }
 
-func NewAuthHandler(authService *authService.AuthService) *AuthHandler {
+func NewAuthHandler(authService AuthService) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
 	}
 }
 
+// SetWelcomer enables the welcome conversation on registration
+func (h *AuthHandler) SetWelcomer(welcomer Welcomer) {
+	h.welcomer = welcomer
+}
+
+// SetReferralTracker enables referral attribution on registration
+func (h *AuthHandler) SetReferralTracker(tracker ReferralTracker) {
+	h.referrals = tracker
+}
+
+// SetSuspensionChecker makes AuthMiddleware reject suspended users
+func (h *AuthHandler) SetSuspensionChecker(checker SuspensionChecker) {
+	h.suspensions = checker
+}
+
+// SetImpersonationGuard lets AuthMiddleware accept impersonation tokens
+func (h *AuthHandler) SetImpersonationGuard(guard ImpersonationGuard) {
+	h.impersonation = guard
+}
+
 // @Summary      User login
 // @Description  Authenticate user by email and password
 // @Tags         auth
@@ -55,13 +117,13 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      User registration
-// @Description  Create a new user
+// @Description  Create a new user. When the welcome bot is enabled, a chat with it is opened in the language from the request or Accept-Language.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body  RegisterRequest  true  "Registration data"
 // @Success      201      {object}  AuthResponse
-// @Failure      400      {string}  string  "Invalid data"
+// @Failure      400      {object}  ValidationErrorResponse  "Password does not satisfy policy"
 // @Failure      409      {string}  string  "Email already registered"
 // @Failure      500      {string}  string  "Internal server error"
 // @Router       /auth/register [post]
@@ -74,6 +136,11 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	serviceResponse, err := h.authService.Register(req.Email, req.Password)
 	if err != nil {
+		var policyErr *authService.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			respondValidationError(w, "password", policyErr.Violations)
+			return
+		}
 		if err.Error() == "email already registered" {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
@@ -82,6 +149,21 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The account exists at this point, so an unknown code does not fail the registration
+	if h.referrals != nil && req.ReferralCode != "" {
+		if err := h.referrals.Attribute(r.Context(), serviceResponse.User.ID, req.ReferralCode); err != nil {
+			log.Printf("Failed to attribute referral code %q to user %d: %v", req.ReferralCode, serviceResponse.User.ID, err)
+		}
+	}
+
+	if h.welcomer != nil {
+		lang := req.Lang
+		if lang == "" {
+			lang = primaryLanguage(r.Header.Get("Accept-Language"))
+		}
+		go h.welcome(serviceResponse.User.ID, lang)
+	}
+
 	// Convert service response to API response
 	response := ToAuthResponse(serviceResponse)
 
@@ -90,6 +172,24 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// welcome starts the welcome conversation without delaying the registration response
+func (h *AuthHandler) welcome(userID int, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.welcomer.StartBotConversation(ctx, userID, lang); err != nil {
+		log.Printf("Failed to start welcome conversation for user %d: %v", userID, err)
+	}
+}
+
+// primaryLanguage returns the first language code of an Accept-Language header
+func primaryLanguage(acceptLanguage string) string {
+	lang := strings.Split(acceptLanguage, ",")[0]
+	lang = strings.Split(lang, ";")[0]
+	lang = strings.Split(lang, "-")[0]
+	return strings.ToLower(strings.TrimSpace(lang))
+}
+
 // @Summary      Token refresh
 // @Description  Get a new token using a refresh token
 // @Tags         auth
@@ -121,6 +221,28 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// @Summary      Guest token
+// @Description  Issue a limited-scope token for browsing catalogs and public profiles without an account
+// @Tags         auth
+// @Produce      json
+// @Success      200      {object}  GuestTokenResponse
+// @Failure      500      {string}  string  "Internal server error"
+// @Router       /auth/guest [post]
+func (h *AuthHandler) GuestToken(w http.ResponseWriter, r *http.Request) {
+	token, expiresAt, err := h.authService.IssueGuestToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GuestTokenResponse{
+		Token:     token,
+		Scope:     authService.ScopeGuest,
+		ExpiresAt: expiresAt,
+	})
+}
+
 // @Summary      Token verification
 // @Description  Verify JWT token validity
 // @Tags         auth
@@ -147,8 +269,18 @@ func (h *AuthHandler) Verify(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(`{"status":"valid"}`))
 }
 
-// Middleware for authentication
+// Middleware for authentication. Suspended users are rejected with 403 and SuspendedResponse.
 func (h *AuthHandler) AuthMiddleware(next http.Handler) http.Handler {
+	return h.authenticate(next, true)
+}
+
+// AuthMiddlewareAllowSuspended authenticates like AuthMiddleware but lets suspended users through,
+// so they can see why they are suspended and appeal.
+func (h *AuthHandler) AuthMiddlewareAllowSuspended(next http.Handler) http.Handler {
+	return h.authenticate(next, false)
+}
+
+func (h *AuthHandler) authenticate(next http.Handler, rejectSuspended bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tokenString := extractToken(r)
 		if tokenString == "" {
@@ -156,21 +288,121 @@ func (h *AuthHandler) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		userID, email, err := h.authService.GetUserInfoFromToken(tokenString)
+		claims, err := h.authService.ParseAccessToken(tokenString)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
+		if claims.Scope == authService.ScopeImpersonation && !h.authorizeImpersonation(w, r, claims) {
+			return
+		}
+
+		// Impersonating admins can look into suspended accounts
+		if rejectSuspended && h.suspensions != nil && claims.Scope == authService.ScopeUser {
+			active, err := h.suspensions.GetActiveSuspension(r.Context(), claims.UserID)
+			if err != nil {
+				log.Printf("Error checking suspension of user %d: %v", claims.UserID, err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			if active != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(SuspendedResponse{
+					Error:  "account suspended",
+					Reason: active.Reason,
+					EndsAt: active.EndsAt,
+				})
+				return
+			}
+		}
+
 		// Add user data to request context
 		ctx := r.Context()
-		ctx = context.WithValue(ctx, "user_id", userID)
-		ctx = context.WithValue(ctx, "email", email)
+		ctx = context.WithValue(ctx, "user_id", claims.UserID)
+		ctx = context.WithValue(ctx, "email", claims.Email)
+		ctx = context.WithValue(ctx, "scope", claims.Scope)
+		ctx = context.WithValue(ctx, "role", claims.Role)
+		if claims.ImpersonatorID != 0 {
+			ctx = context.WithValue(ctx, "impersonator_id", claims.ImpersonatorID)
+		}
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// authorizeImpersonation lets through read-only requests the user still consents to, writing an error response otherwise
+func (h *AuthHandler) authorizeImpersonation(w http.ResponseWriter, r *http.Request, claims *authService.TokenClaims) bool {
+	if h.impersonation == nil {
+		http.Error(w, "Impersonation is not enabled", http.StatusUnauthorized)
+		return false
+	}
+
+	// WebSocket upgrades are GET requests but open a channel for sending messages
+	readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+	if !readOnly || r.Header.Get("Upgrade") != "" {
+		http.Error(w, "Impersonation sessions are read-only", http.StatusForbidden)
+		return false
+	}
+
+	allowed, err := h.impersonation.AuthorizeImpersonation(r.Context(), claims.ImpersonatorID, claims.UserID, r.Method, r.URL.RequestURI())
+	if err != nil {
+		log.Printf("Error authorizing impersonation of user %d by admin %d: %v", claims.UserID, claims.ImpersonatorID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		http.Error(w, "Support access revoked", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// RequireUser rejects guest tokens. Must be used after AuthMiddleware.
+func (h *AuthHandler) RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if scope, _ := r.Context().Value("scope").(string); scope == authService.ScopeGuest {
+			http.Error(w, "Sign up required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole allows only users with one of the given roles. Must be used after AuthMiddleware.
+// The role is looked up in the database, so a revoked role takes effect before the token expires.
+func (h *AuthHandler) RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := r.Context().Value("user_id").(int)
+			userRole, err := h.authService.GetUserRole(userID)
+			if err != nil {
+				log.Printf("Error looking up role of user %d: %v", userID, err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			for _, role := range roles {
+				if userRole == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		})
+	}
+}
+
+// respondValidationError writes a 400 response with field-level error codes
+func respondValidationError(w http.ResponseWriter, field string, codes []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(ValidationErrorResponse{
+		Error:  "validation failed",
+		Fields: map[string][]string{field: codes},
+	})
+}
+
 // Helper function to extract token from request
 func extractToken(r *http.Request) string {
 	authHeader := r.Header.Get("Authorization")
@@ -0,0 +1,457 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	authService "github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
+)
+
+func newJSONRequest(method, target string, body interface{}) *http.Request {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(body)
+	return httptest.NewRequest(method, target, &buf)
+}
+
+func successResponse() *authService.AuthResponse {
+	return &authService.AuthResponse{
+		Token:        "access",
+		RefreshToken: "refresh",
+		User:         &authService.User{ID: 1, Email: "user@example.com"},
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid credentials", errors.New("invalid credentials"), http.StatusUnauthorized},
+		{"server error", errors.New("failed to get user"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				LoginFunc: func(email string, password string) (*authService.AuthResponse, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return successResponse(), nil
+				},
+			}
+			h := NewAuthHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Login(rec, newJSONRequest(http.MethodPost, "/api/auth/login", LoginRequest{Email: "user@example.com", Password: "pw"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp AuthResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 1, resp.UserID)
+				assert.Equal(t, "access", resp.Token)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"duplicate email", errors.New("email already registered"), http.StatusConflict},
+		{"weak password", &authService.PasswordPolicyError{Violations: []string{authService.PasswordTooShort}}, http.StatusBadRequest},
+		{"server error", errors.New("failed to create user"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				RegisterFunc: func(email string, password string) (*authService.AuthResponse, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return successResponse(), nil
+				},
+			}
+			h := NewAuthHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Register(rec, newJSONRequest(http.MethodPost, "/api/auth/register", RegisterRequest{Email: "user@example.com", Password: "pw"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestRegisterStartsWelcomeConversation(t *testing.T) {
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		wantLang       string
+	}{
+		{"language from request", "en", "ru-RU", "en"},
+		{"language from header", "", "en-US,en;q=0.9", "en"},
+		{"no language", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				RegisterFunc: func(email string, password string) (*authService.AuthResponse, error) {
+					return successResponse(), nil
+				},
+			}
+			started := make(chan string, 1)
+			welcomer := &WelcomerMock{
+				StartBotConversationFunc: func(ctx context.Context, userID int, lang string) error {
+					assert.Equal(t, 1, userID)
+					started <- lang
+					return nil
+				},
+			}
+			h := NewAuthHandler(service)
+			h.SetWelcomer(welcomer)
+
+			req := newJSONRequest(http.MethodPost, "/api/auth/register", RegisterRequest{Email: "user@example.com", Password: "pw", Lang: tt.lang})
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			h.Register(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			select {
+			case lang := <-started:
+				assert.Equal(t, tt.wantLang, lang)
+			case <-time.After(time.Second):
+				t.Fatal("welcome conversation was not started")
+			}
+		})
+	}
+}
+
+func TestRegisterAttributesReferral(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		trackerErr  error
+		wantTracked bool
+	}{
+		{"with code", "K7M2QX9A", nil, true},
+		{"unknown code", "UNKNOWN1", errors.New("invalid referral code"), true},
+		{"without code", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				RegisterFunc: func(email string, password string) (*authService.AuthResponse, error) {
+					return successResponse(), nil
+				},
+			}
+			tracker := &ReferralTrackerMock{
+				AttributeFunc: func(ctx context.Context, userID int, code string) error {
+					return tt.trackerErr
+				},
+			}
+			h := NewAuthHandler(service)
+			h.SetReferralTracker(tracker)
+
+			rec := httptest.NewRecorder()
+			h.Register(rec, newJSONRequest(http.MethodPost, "/api/auth/register", RegisterRequest{Email: "user@example.com", Password: "pw", ReferralCode: tt.code}))
+
+			// A bad code never fails the registration
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			if !tt.wantTracked {
+				assert.Empty(t, tracker.AttributeCalls())
+				return
+			}
+			call := tracker.AttributeCalls()[0]
+			assert.Equal(t, 1, call.UserID)
+			assert.Equal(t, tt.code, call.Code)
+		})
+	}
+}
+
+func TestRegisterWeakPasswordFields(t *testing.T) {
+	service := &AuthServiceMock{
+		RegisterFunc: func(email string, password string) (*authService.AuthResponse, error) {
+			return nil, &authService.PasswordPolicyError{Violations: []string{authService.PasswordTooShort, authService.PasswordNoDigit}}
+		},
+	}
+	h := NewAuthHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.Register(rec, newJSONRequest(http.MethodPost, "/api/auth/register", RegisterRequest{Email: "user@example.com", Password: "pw"}))
+
+	var resp ValidationErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, []string{"too_short", "missing_digit"}, resp.Fields["password"])
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		parseErr   error
+		wantStatus int
+	}{
+		{"valid token", "Bearer good", nil, http.StatusOK},
+		{"missing header", "", nil, http.StatusUnauthorized},
+		{"wrong scheme", "Basic abc", nil, http.StatusUnauthorized},
+		{"invalid token", "Bearer bad", errors.New("invalid token"), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				ParseAccessTokenFunc: func(tokenString string) (*authService.TokenClaims, error) {
+					if tt.parseErr != nil {
+						return nil, tt.parseErr
+					}
+					return &authService.TokenClaims{UserID: 5, Email: "user@example.com", Scope: authService.ScopeUser}, nil
+				},
+			}
+			h := NewAuthHandler(service)
+
+			var gotUserID int
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = r.Context().Value("user_id").(int)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/protected", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.AuthMiddleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, 5, gotUserID)
+				assert.Equal(t, "good", service.ParseAccessTokenCalls()[0].TokenString)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRejectsRefreshTokens(t *testing.T) {
+	const secret = "test-secret"
+	h := NewAuthHandler(authService.NewAuthService(nil, secret))
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		assert.NoError(t, err)
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name       string
+		claims     jwt.MapClaims
+		wantStatus int
+	}{
+		{"access token", jwt.MapClaims{"user_id": 5, "email": "user@example.com", "exp": exp, "type": "access", "scope": authService.ScopeUser}, http.StatusOK},
+		{"refresh token", jwt.MapClaims{"user_id": 5, "exp": exp, "type": "refresh"}, http.StatusUnauthorized},
+		{"token without type", jwt.MapClaims{"user_id": 5, "email": "user@example.com", "exp": exp}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+sign(tt.claims))
+			rec := httptest.NewRecorder()
+			h.AuthMiddleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, called)
+		})
+	}
+}
+
+func TestAuthMiddlewareRejectsSuspendedUsers(t *testing.T) {
+	service := &AuthServiceMock{
+		ParseAccessTokenFunc: func(tokenString string) (*authService.TokenClaims, error) {
+			return &authService.TokenClaims{UserID: 5, Scope: authService.ScopeUser}, nil
+		},
+	}
+	endsAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	checker := &SuspensionCheckerMock{
+		GetActiveSuspensionFunc: func(ctx context.Context, userID int) (*suspension.Suspension, error) {
+			return &suspension.Suspension{ID: 3, UserID: userID, Reason: "Spam", EndsAt: &endsAt}, nil
+		},
+	}
+	h := NewAuthHandler(service)
+	h.SetSuspensionChecker(checker)
+
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/protected", nil)
+	req.Header.Set("Authorization", "Bearer good")
+	rec := httptest.NewRecorder()
+	h.AuthMiddleware(next).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, called)
+	assert.Equal(t, 5, checker.GetActiveSuspensionCalls()[0].UserID)
+	var resp SuspendedResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "account suspended", resp.Error)
+	assert.Equal(t, "Spam", resp.Reason)
+	assert.Equal(t, endsAt, *resp.EndsAt)
+
+	// The appeal endpoints stay reachable
+	rec = httptest.NewRecorder()
+	h.AuthMiddlewareAllowSuspended(next).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+	assert.Len(t, checker.GetActiveSuspensionCalls(), 1)
+}
+
+func TestAuthMiddlewareImpersonation(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		upgrade    bool
+		noGuard    bool
+		allowed    bool
+		wantStatus int
+	}{
+		{"read request", http.MethodGet, false, false, true, http.StatusOK},
+		{"write request", http.MethodPost, false, false, true, http.StatusForbidden},
+		{"websocket upgrade", http.MethodGet, true, false, true, http.StatusForbidden},
+		{"access revoked", http.MethodGet, false, false, false, http.StatusForbidden},
+		{"impersonation disabled", http.MethodGet, false, true, true, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				ParseAccessTokenFunc: func(tokenString string) (*authService.TokenClaims, error) {
+					return &authService.TokenClaims{UserID: 5, Scope: authService.ScopeImpersonation, Role: authService.RoleUser, ImpersonatorID: 1}, nil
+				},
+			}
+			guard := &ImpersonationGuardMock{
+				AuthorizeImpersonationFunc: func(ctx context.Context, adminID int, userID int, method string, path string) (bool, error) {
+					return tt.allowed, nil
+				},
+			}
+			h := NewAuthHandler(service)
+			if !tt.noGuard {
+				h.SetImpersonationGuard(guard)
+			}
+
+			var gotImpersonator int
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotImpersonator, _ = r.Context().Value("impersonator_id").(int)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/profiles/search?city=1", nil)
+			req.Header.Set("Authorization", "Bearer impersonation")
+			if tt.upgrade {
+				req.Header.Set("Upgrade", "websocket")
+			}
+			rec := httptest.NewRecorder()
+			h.AuthMiddleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, 1, gotImpersonator)
+				call := guard.AuthorizeImpersonationCalls()[0]
+				assert.Equal(t, 1, call.AdminID)
+				assert.Equal(t, 5, call.UserID)
+				assert.Equal(t, "/api/profiles/search?city=1", call.Path)
+			}
+		})
+	}
+}
+
+func TestRequireUserRejectsGuests(t *testing.T) {
+	service := &AuthServiceMock{
+		ParseAccessTokenFunc: func(tokenString string) (*authService.TokenClaims, error) {
+			return &authService.TokenClaims{Scope: authService.ScopeGuest}, nil
+		},
+	}
+	h := NewAuthHandler(service)
+
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/chats", nil)
+	req.Header.Set("Authorization", "Bearer guest")
+	rec := httptest.NewRecorder()
+	h.AuthMiddleware(h.RequireUser(next)).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, called)
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenRole  string
+		role       string
+		roleErr    error
+		wantStatus int
+	}{
+		{"admin", authService.RoleAdmin, authService.RoleAdmin, nil, http.StatusOK},
+		{"regular user", authService.RoleUser, authService.RoleUser, nil, http.StatusForbidden},
+		{"role revoked after the token was issued", authService.RoleAdmin, authService.RoleUser, nil, http.StatusForbidden},
+		{"deleted user", authService.RoleAdmin, "", nil, http.StatusForbidden},
+		{"lookup error", authService.RoleAdmin, "", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &AuthServiceMock{
+				ParseAccessTokenFunc: func(tokenString string) (*authService.TokenClaims, error) {
+					return &authService.TokenClaims{UserID: 1, Scope: authService.ScopeUser, Role: tt.tokenRole}, nil
+				},
+				GetUserRoleFunc: func(userID int) (string, error) {
+					return tt.role, tt.roleErr
+				},
+			}
+			h := NewAuthHandler(service)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/ws-events/1", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			rec := httptest.NewRecorder()
+			h.AuthMiddleware(h.RequireRole(authService.RoleAdmin)(next)).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, 1, service.GetUserRoleCalls()[0].UserID)
+		})
+	}
+}
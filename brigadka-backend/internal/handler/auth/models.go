@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	serviceAuth "github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
 )
 
@@ -13,12 +15,29 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Lang     string `json:"lang,omitempty"` // Language of the welcome conversation
+	// ReferralCode of the user who invited this one, see /referrals/stats
+	ReferralCode string `json:"referral_code,omitempty"`
 }
 
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// ValidationErrorResponse describes request fields that failed validation
+type ValidationErrorResponse struct {
+	Error  string              `json:"error"`
+	Fields map[string][]string `json:"fields"`
+}
+
+// SuspendedResponse is returned with 403 when the account is suspended.
+// Suspended users can still appeal via /auth/suspension/appeal.
+type SuspendedResponse struct {
+	Error  string     `json:"error"`
+	Reason string     `json:"reason"`
+	EndsAt *time.Time `json:"ends_at,omitempty"` // Absent when the suspension lasts until lifted
+}
+
 type AuthResponse struct {
 	UserID       int    `json:"user_id"`
 	Token        string `json:"token"`
@@ -32,3 +51,9 @@ func ToAuthResponse(serviceResponse *serviceAuth.AuthResponse) AuthResponse {
 		RefreshToken: serviceResponse.RefreshToken,
 	}
 }
+
+type GuestTokenResponse struct {
+	Token     string    `json:"token"`
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
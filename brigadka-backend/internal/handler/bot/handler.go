@@ -0,0 +1,311 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/bot"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+//go:generate moq -out mocks_test.go . BotService
+
+// BotService defines the bot operations used by the handler
+type BotService interface {
+	CreateBot(ctx context.Context, ownerID int, name string, webhookURL *string) (*bot.Credentials, error)
+	GetBots(ctx context.Context, ownerID int) ([]bot.Bot, error)
+	UpdateWebhook(ctx context.Context, ownerID, botID int, webhookURL *string) (*bot.Bot, error)
+	DeleteBot(ctx context.Context, ownerID, botID int) error
+	Authenticate(ctx context.Context, apiKey string) (*bot.Bot, error)
+	GetChats(ctx context.Context, botID int) ([]messaging.Chat, error)
+	PostMessage(ctx context.Context, botID int, chatID, messageID, content string) (*messaging.ChatMessage, error)
+}
+
+// Handler handles bot management and the bot API
+type Handler struct {
+	service BotService
+}
+
+// NewHandler creates a new bot handler
+func NewHandler(service BotService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateBotRequest represents the request to create a bot
+type CreateBotRequest struct {
+	Name       string  `json:"name"`
+	WebhookURL *string `json:"webhook_url,omitempty"`
+}
+
+// UpdateBotRequest represents the request to change a bot; an empty webhook URL disables webhooks
+type UpdateBotRequest struct {
+	WebhookURL *string `json:"webhook_url"`
+}
+
+// PostMessageRequest represents a message sent by a bot
+type PostMessageRequest struct {
+	MessageID string `json:"message_id,omitempty"` // Generated when empty
+	Content   string `json:"content"`
+}
+
+// @Summary      Create bot
+// @Description  Create a bot account. The API key and webhook secret are returned only once. The webhook URL must be https on a public host.
+// @Tags         bots
+// @Accept       json
+// @Produce      json
+// @Param        request  body  CreateBotRequest  true  "Bot data"
+// @Security     BearerAuth
+// @Success      201  {object}  bot.Credentials
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bots [post]
+func (h *Handler) CreateBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	credentials, err := h.service.CreateBot(r.Context(), userID, req.Name, req.WebhookURL)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(credentials)
+}
+
+// @Summary      List bots
+// @Description  Bots created by the current user
+// @Tags         bots
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   bot.Bot
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bots [get]
+func (h *Handler) GetBots(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	bots, err := h.service.GetBots(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bots)
+}
+
+// @Summary      Update bot
+// @Description  Change the webhook URL of a bot; an empty URL disables webhooks. The URL must be https on a public host
+// @Tags         bots
+// @Accept       json
+// @Produce      json
+// @Param        botID    path  int               true  "Bot ID"
+// @Param        request  body  UpdateBotRequest  true  "Bot data"
+// @Security     BearerAuth
+// @Success      200  {object}  bot.Bot
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Bot not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bots/{botID} [patch]
+func (h *Handler) UpdateBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	botID, err := strconv.Atoi(chi.URLParam(r, "botID"))
+	if err != nil {
+		http.Error(w, "Invalid bot ID", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.UpdateWebhook(r.Context(), userID, botID, req.WebhookURL)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// @Summary      Delete bot
+// @Description  Delete a bot; it is removed from all chats
+// @Tags         bots
+// @Param        botID  path  int  true  "Bot ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid bot ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Bot not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bots/{botID} [delete]
+func (h *Handler) DeleteBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	botID, err := strconv.Atoi(chi.URLParam(r, "botID"))
+	if err != nil {
+		http.Error(w, "Invalid bot ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteBot(r.Context(), userID, botID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequireBot authenticates bot API requests by the "Authorization: Bot <api key>" header
+func (h *Handler) RequireBot(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bot ")
+		if !found || apiKey == "" {
+			http.Error(w, "Bot API key required", http.StatusUnauthorized)
+			return
+		}
+
+		authenticated, err := h.service.Authenticate(r.Context(), apiKey)
+		if err != nil {
+			if errors.Is(err, bot.ErrInvalidAPIKey) {
+				http.Error(w, "Invalid API key", http.StatusUnauthorized)
+				return
+			}
+			handleError(w, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "bot_id", authenticated.ID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// @Summary      Bot chats
+// @Description  Group chats the bot has been added to
+// @Tags         bot-api
+// @Produce      json
+// @Param        Authorization  header  string  true  "Bot <api key>"
+// @Success      200  {array}   messaging.Chat
+// @Failure      401  {string}  string  "Invalid API key"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bot/chats [get]
+func (h *Handler) GetChats(w http.ResponseWriter, r *http.Request) {
+	botID, ok := r.Context().Value("bot_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chats, err := h.service.GetChats(r.Context(), botID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(chats)
+}
+
+// @Summary      Post message as bot
+// @Description  Send a message to a group chat the bot has been added to
+// @Tags         bot-api
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header  string              true  "Bot <api key>"
+// @Param        chatID         path    string              true  "Chat ID"
+// @Param        request        body    PostMessageRequest  true  "Message"
+// @Success      201  {object}  messaging.ChatMessage
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Invalid API key"
+// @Failure      404  {string}  string  "Chat not found"
+// @Failure      409  {string}  string  "Message already exists"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /bot/chats/{chatID}/messages [post]
+func (h *Handler) PostMessage(w http.ResponseWriter, r *http.Request) {
+	botID, ok := r.Context().Value("bot_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req PostMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.MessageID == "" {
+		req.MessageID = uuid.New().String()
+	}
+
+	msg, err := h.service.PostMessage(r.Context(), botID, chi.URLParam(r, "chatID"), req.MessageID, req.Content)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(msg)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, bot.ErrBotNotFound):
+		http.Error(w, "Bot not found", http.StatusNotFound)
+	case errors.Is(err, bot.ErrInvalidName):
+		http.Error(w, "Invalid bot name", http.StatusBadRequest)
+	case errors.Is(err, bot.ErrInvalidWebhookURL):
+		http.Error(w, "Invalid webhook URL", http.StatusBadRequest)
+	case errors.Is(err, bot.ErrChatNotFound):
+		http.Error(w, "Chat not found", http.StatusNotFound)
+	case errors.Is(err, bot.ErrNotGroupChat):
+		http.Error(w, "Bots can only write to group chats", http.StatusForbidden)
+	case errors.Is(err, bot.ErrEmptyMessage):
+		http.Error(w, "Message content is empty", http.StatusBadRequest)
+	case database.IsUniqueViolation(err):
+		http.Error(w, "Message already exists", http.StatusConflict)
+	default:
+		log.Printf("Bot error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
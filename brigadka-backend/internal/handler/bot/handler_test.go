@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/bot"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+func TestCreateBot(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid name", 1, bot.ErrInvalidName, http.StatusBadRequest},
+		{"invalid webhook", 1, bot.ErrInvalidWebhookURL, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &BotServiceMock{
+				CreateBotFunc: func(ctx context.Context, ownerID int, name string, webhookURL *string) (*bot.Credentials, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &bot.Credentials{Bot: bot.Bot{ID: 42, Name: name, WebhookURL: webhookURL}, APIKey: "bk_key", WebhookSecret: "secret"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			webhookURL := "https://example.com/hook"
+			body := CreateBotRequest{Name: "Reminders", WebhookURL: &webhookURL}
+			rec := httptest.NewRecorder()
+			h.CreateBot(rec, handlertest.NewRequestWithKey(http.MethodPost, "/api/bots", body, "user_id", tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var resp bot.Credentials
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 42, resp.ID)
+				assert.Equal(t, "bk_key", resp.APIKey)
+				assert.Equal(t, "secret", resp.WebhookSecret)
+
+				call := service.CreateBotCalls()[0]
+				assert.Equal(t, 1, call.OwnerID)
+				assert.Equal(t, webhookURL, *call.WebhookURL)
+			}
+		})
+	}
+}
+
+func TestDeleteBotOfAnotherUser(t *testing.T) {
+	service := &BotServiceMock{
+		DeleteBotFunc: func(ctx context.Context, ownerID, botID int) error {
+			return bot.ErrBotNotFound
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.DeleteBot(rec, handlertest.NewRequestWithKey(http.MethodDelete, "/api/bots/42", nil, "user_id", 2, map[string]string{"botID": "42"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, 2, service.DeleteBotCalls()[0].OwnerID)
+}
+
+func TestRequireBot(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{"valid key", "Bot bk_valid", http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"user token", "Bearer jwt", http.StatusUnauthorized},
+		{"unknown key", "Bot bk_unknown", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &BotServiceMock{
+				AuthenticateFunc: func(ctx context.Context, apiKey string) (*bot.Bot, error) {
+					if apiKey != "bk_valid" {
+						return nil, bot.ErrInvalidAPIKey
+					}
+					return &bot.Bot{ID: 42}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			var gotBotID int
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBotID, _ = r.Context().Value("bot_id").(int)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/bot/chats", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.RequireBot(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, 42, gotBotID)
+			}
+		})
+	}
+}
+
+func TestPostMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		messageID  string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "m1", nil, http.StatusCreated},
+		{"generated message id", "", nil, http.StatusCreated},
+		{"not in chat", "m1", bot.ErrChatNotFound, http.StatusNotFound},
+		{"direct chat", "m1", bot.ErrNotGroupChat, http.StatusForbidden},
+		{"empty message", "m1", bot.ErrEmptyMessage, http.StatusBadRequest},
+		{"duplicate message", "m1", &pq.Error{Code: database.UniqueViolation}, http.StatusConflict},
+		{"server error", "m1", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &BotServiceMock{
+				PostMessageFunc: func(ctx context.Context, botID int, chatID, messageID, content string) (*messaging.ChatMessage, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &messaging.ChatMessage{MessageID: messageID, ChatID: chatID, SenderID: botID, Content: content, SentAt: time.Now()}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := PostMessageRequest{MessageID: tt.messageID, Content: "Rehearsal at 7pm"}
+			rec := httptest.NewRecorder()
+			h.PostMessage(rec, handlertest.NewRequestWithKey(http.MethodPost, "/api/bot/chats/c1/messages", body, "bot_id", 42, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.PostMessageCalls()[0]
+			assert.Equal(t, 42, call.BotID)
+			assert.Equal(t, "c1", call.ChatID)
+			assert.NotEmpty(t, call.MessageID)
+		})
+	}
+}
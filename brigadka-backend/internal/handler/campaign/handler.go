@@ -0,0 +1,217 @@
+package campaign
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/campaign"
+)
+
+//go:generate moq -out mocks_test.go . CampaignService
+
+// CampaignService defines the push campaign operations used by the handler
+type CampaignService interface {
+	Preview(ctx context.Context, title, body string, segment campaign.Segment) (*campaign.Preview, error)
+	ScheduleCampaign(ctx context.Context, authorID int, title, body string, segment campaign.Segment, scheduledAt time.Time) (*campaign.Campaign, error)
+	GetCampaigns(ctx context.Context, limit int) ([]campaign.Campaign, error)
+	GetCampaign(ctx context.Context, campaignID string) (*campaign.Campaign, error)
+	CancelCampaign(ctx context.Context, campaignID string) error
+}
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// Handler handles admin push campaigns
+type Handler struct {
+	service CampaignService
+}
+
+// NewHandler creates a new push campaign handler
+func NewHandler(service CampaignService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PreviewCampaignRequest represents the campaign to preview
+type PreviewCampaignRequest struct {
+	Title   string           `json:"title"`
+	Body    string           `json:"body"`
+	Segment campaign.Segment `json:"segment"`
+}
+
+// ScheduleCampaignRequest represents the request to schedule a campaign
+type ScheduleCampaignRequest struct {
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	Segment     campaign.Segment `json:"segment"`
+	ScheduledAt time.Time        `json:"scheduled_at"`
+}
+
+// @Summary      Preview push campaign
+// @Description  Validate a campaign and return the notification it would send with the number of users currently in the segment. Admin only.
+// @Tags         push
+// @Accept       json
+// @Produce      json
+// @Param        request  body  PreviewCampaignRequest  true  "Campaign"
+// @Security     BearerAuth
+// @Success      200  {object}  campaign.Preview
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/push/campaigns/preview [post]
+func (h *Handler) PreviewCampaign(w http.ResponseWriter, r *http.Request) {
+	var req PreviewCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.service.Preview(r.Context(), req.Title, req.Body, req.Segment)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// @Summary      Schedule push campaign
+// @Description  Schedule a push notification to the users of a segment (city, looking for a team, inactive for N days) at a future time. Admin only.
+// @Tags         push
+// @Accept       json
+// @Produce      json
+// @Param        request  body  ScheduleCampaignRequest  true  "Campaign"
+// @Security     BearerAuth
+// @Success      201  {object}  campaign.Campaign
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/push/campaigns [post]
+func (h *Handler) ScheduleCampaign(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ScheduleCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	scheduled, err := h.service.ScheduleCampaign(r.Context(), userID, req.Title, req.Body, req.Segment, req.ScheduledAt)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(scheduled)
+}
+
+// @Summary      List push campaigns
+// @Description  Push campaigns with their status and delivery stats, the latest scheduled first. Admin only.
+// @Tags         push
+// @Produce      json
+// @Param        limit  query  int  false  "Number of campaigns, 50 by default, at most 200"
+// @Security     BearerAuth
+// @Success      200  {array}   campaign.Campaign
+// @Failure      400  {string}  string  "Invalid limit"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/push/campaigns [get]
+func (h *Handler) GetCampaigns(w http.ResponseWriter, r *http.Request) {
+	limit := defaultListLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxListLimit)
+	}
+
+	campaigns, err := h.service.GetCampaigns(r.Context(), limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(campaigns)
+}
+
+// @Summary      Get push campaign
+// @Description  A push campaign with its status and delivery stats. Admin only.
+// @Tags         push
+// @Produce      json
+// @Param        campaignID  path  string  true  "Campaign ID"
+// @Security     BearerAuth
+// @Success      200  {object}  campaign.Campaign
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Campaign not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/push/campaigns/{campaignID} [get]
+func (h *Handler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	found, err := h.service.GetCampaign(r.Context(), chi.URLParam(r, "campaignID"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(found)
+}
+
+// @Summary      Cancel push campaign
+// @Description  Cancel a campaign that has not started sending. Admin only.
+// @Tags         push
+// @Param        campaignID  path  string  true  "Campaign ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Campaign not found"
+// @Failure      409  {string}  string  "Campaign already sent or cancelled"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/push/campaigns/{campaignID}/cancel [post]
+func (h *Handler) CancelCampaign(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.CancelCampaign(r.Context(), chi.URLParam(r, "campaignID")); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, campaign.ErrCampaignNotFound):
+		http.Error(w, "Campaign not found", http.StatusNotFound)
+	case errors.Is(err, campaign.ErrNotCancellable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, campaign.ErrInvalidTitle), errors.Is(err, campaign.ErrInvalidBody),
+		errors.Is(err, campaign.ErrInvalidTime), errors.Is(err, campaign.ErrInvalidSegment):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Push campaign error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
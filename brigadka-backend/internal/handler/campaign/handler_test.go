@@ -0,0 +1,176 @@
+package campaign
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/campaign"
+)
+
+func TestScheduleCampaign(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"in the past", 1, campaign.ErrInvalidTime, http.StatusBadRequest},
+		{"empty title", 1, campaign.ErrInvalidTitle, http.StatusBadRequest},
+		{"bad segment", 1, campaign.ErrInvalidSegment, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	cityID, inactiveDays := 5, 30
+	scheduledAt := time.Date(2030, 5, 14, 16, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &CampaignServiceMock{
+				ScheduleCampaignFunc: func(ctx context.Context, authorID int, title, body string, segment campaign.Segment, at time.Time) (*campaign.Campaign, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &campaign.Campaign{ID: "01HZX", AuthorID: &authorID, Title: title, Body: body, Segment: segment, ScheduledAt: at, Status: "scheduled"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := ScheduleCampaignRequest{
+				Title:       "We miss you",
+				Body:        "New jams in your city",
+				Segment:     campaign.Segment{CityID: &cityID, InactiveDays: &inactiveDays},
+				ScheduledAt: scheduledAt,
+			}
+			rec := httptest.NewRecorder()
+			h.ScheduleCampaign(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/push/campaigns", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.ScheduleCampaignCalls()[0]
+				assert.Equal(t, tt.userID, call.AuthorID)
+				assert.Equal(t, 5, *call.Segment.CityID)
+				assert.Equal(t, 30, *call.Segment.InactiveDays)
+				assert.Nil(t, call.Segment.LookingForTeam)
+				assert.True(t, scheduledAt.Equal(call.ScheduledAt))
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp campaign.Campaign
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "01HZX", resp.ID)
+			}
+		})
+	}
+}
+
+func TestPreviewCampaign(t *testing.T) {
+	lookingForTeam := true
+	service := &CampaignServiceMock{
+		PreviewFunc: func(ctx context.Context, title, body string, segment campaign.Segment) (*campaign.Preview, error) {
+			preview := &campaign.Preview{Audience: 42}
+			preview.Notification.Title = title
+			preview.Notification.Body = body
+			return preview, nil
+		},
+	}
+	h := NewHandler(service)
+
+	body := PreviewCampaignRequest{Title: "Teams are looking for you", Body: "Check the new teams", Segment: campaign.Segment{LookingForTeam: &lookingForTeam}}
+	rec := httptest.NewRecorder()
+	h.PreviewCampaign(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/push/campaigns/preview", body, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, *service.PreviewCalls()[0].Segment.LookingForTeam)
+	var resp campaign.Preview
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 42, resp.Audience)
+	assert.Equal(t, "Teams are looking for you", resp.Notification.Title)
+}
+
+func TestGetCampaigns(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantLimit  int
+	}{
+		{"default limit", "", http.StatusOK, 50},
+		{"custom limit", "?limit=10", http.StatusOK, 10},
+		{"limit capped", "?limit=1000", http.StatusOK, 200},
+		{"invalid limit", "?limit=abc", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &CampaignServiceMock{
+				GetCampaignsFunc: func(ctx context.Context, limit int) ([]campaign.Campaign, error) {
+					return []campaign.Campaign{{ID: "01HZX", Status: "sent", Queued: 120, Failed: 2}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetCampaigns(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/push/campaigns"+tt.query, nil, 1, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantLimit, service.GetCampaignsCalls()[0].Limit)
+			} else {
+				assert.Empty(t, service.GetCampaignsCalls())
+			}
+		})
+	}
+}
+
+func TestGetCampaignNotFound(t *testing.T) {
+	service := &CampaignServiceMock{
+		GetCampaignFunc: func(ctx context.Context, campaignID string) (*campaign.Campaign, error) {
+			return nil, campaign.ErrCampaignNotFound
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetCampaign(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/push/campaigns/missing", nil, 1, map[string]string{"campaignID": "missing"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "missing", service.GetCampaignCalls()[0].CampaignID)
+}
+
+func TestCancelCampaign(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not found", campaign.ErrCampaignNotFound, http.StatusNotFound},
+		{"already sent", campaign.ErrNotCancellable, http.StatusConflict},
+		{"server error", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &CampaignServiceMock{
+				CancelCampaignFunc: func(ctx context.Context, campaignID string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.CancelCampaign(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/push/campaigns/01HZX/cancel", nil, 1, map[string]string{"campaignID": "01HZX"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "01HZX", service.CancelCampaignCalls()[0].CampaignID)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/catalog"
+)
+
+//go:generate moq -out mocks_test.go . CatalogService
+
+// CatalogService defines the catalog operations used by the handler
+type CatalogService interface {
+	GetBundle(ctx context.Context, lang string) (*catalog.Bundle, error)
+}
+
+// Handler serves catalogs for offline use
+type Handler struct {
+	service CatalogService
+}
+
+// NewHandler creates a new catalog handler
+func NewHandler(service CatalogService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// @Summary      Catalog bundle
+// @Description  All catalogs and input limits in one payload for offline caching. The ETag is the bundle version; send it in If-None-Match to get 304 when nothing changed. Gzip-compressed when the client accepts it.
+// @Tags         catalog
+// @Produce      json
+// @Param        lang           query   string  false  "Language code (default: ru)"
+// @Param        If-None-Match  header  string  false  "Cached bundle version"
+// @Security     BearerAuth
+// @Success      200  {object}  catalog.Bundle
+// @Success      304  {string}  string  "Bundle not modified"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /catalog/bundle [get]
+func (h *Handler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.service.GetBundle(r.Context(), r.URL.Query().Get("lang"))
+	if err != nil {
+		log.Printf("Catalog bundle error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	etag := `"` + bundle.Version + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Add("Vary", "Accept-Encoding")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		json.NewEncoder(w).Encode(bundle)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	json.NewEncoder(gz).Encode(bundle)
+}
@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/catalog"
+)
+
+func newBundleService(err error) *CatalogServiceMock {
+	return &CatalogServiceMock{
+		GetBundleFunc: func(ctx context.Context, lang string) (*catalog.Bundle, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &catalog.Bundle{Version: "abc123", Reactions: []catalog.Reaction{{Code: "like", Emoji: "👍"}}}, nil
+		},
+	}
+}
+
+func TestGetBundle(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		serviceErr  error
+		wantStatus  int
+	}{
+		{"fresh", "", nil, http.StatusOK},
+		{"stale cache", `"old"`, nil, http.StatusOK},
+		{"not modified", `"abc123"`, nil, http.StatusNotModified},
+		{"server error", "", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newBundleService(tt.serviceErr)
+			h := NewHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/catalog/bundle?lang=en", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			h.GetBundle(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "en", service.GetBundleCalls()[0].Lang)
+			if tt.serviceErr == nil {
+				assert.Equal(t, `"abc123"`, rec.Header().Get("ETag"))
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp catalog.Bundle
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "abc123", resp.Version)
+			}
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestGetBundleGzip(t *testing.T) {
+	h := NewHandler(newBundleService(nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/catalog/bundle", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+	h.GetBundle(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	gz, err := gzip.NewReader(rec.Body)
+	if assert.NoError(t, err) {
+		var resp catalog.Bundle
+		assert.NoError(t, json.NewDecoder(gz).Decode(&resp))
+		assert.Equal(t, "like", resp.Reactions[0].Code)
+	}
+}
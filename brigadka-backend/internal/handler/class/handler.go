@@ -0,0 +1,346 @@
+package class
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/class"
+)
+
+//go:generate moq -out mocks_test.go . ClassService
+
+// ClassService defines the class operations used by the handler
+type ClassService interface {
+	CreateClass(ctx context.Context, teacherID int, req class.CreateRequest) (*class.Class, error)
+	GetClass(ctx context.Context, classID int) (*class.Class, error)
+	DeleteClass(ctx context.Context, userID, classID int) error
+	Search(ctx context.Context, filter class.SearchFilter) (*class.SearchResult, error)
+	Enroll(ctx context.Context, userID, classID int) (*class.Class, error)
+	Unenroll(ctx context.Context, userID, classID int) error
+	GetEnrollments(ctx context.Context, userID, classID int) ([]class.Enrollment, error)
+}
+
+// Handler handles class endpoints
+type Handler struct {
+	service ClassService
+}
+
+// NewHandler creates a new class handler
+func NewHandler(service ClassService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateClassRequest represents the request body for class creation
+type CreateClassRequest struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CityID      *int      `json:"city_id,omitempty"` // Omit for online classes
+	Level       string    `json:"level"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Schedule    string    `json:"schedule"`
+	Capacity    int       `json:"capacity"`
+	PriceCents  int       `json:"price_cents"`        // 0 for free classes
+	Currency    string    `json:"currency,omitempty"` // Required for paid classes
+}
+
+// SearchRequest represents the request body for class search
+type SearchRequest struct {
+	CityID    *int    `json:"city_id,omitempty"`
+	Level     *string `json:"level,omitempty"`
+	TeacherID *int    `json:"teacher_id,omitempty"`
+	Page      int     `json:"page"`
+	PageSize  int     `json:"page_size"`
+}
+
+// @Summary      Create Class
+// @Description  Lists a workshop or course taught by the current user
+// @Tags         classes
+// @Accept       json
+// @Produce      json
+// @Param        request  body  CreateClassRequest  true  "Class data"
+// @Security     BearerAuth
+// @Success      201  {object}  class.Class
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes [post]
+func (h *Handler) CreateClass(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateClassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.CreateClass(r.Context(), userID, class.CreateRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		CityID:      req.CityID,
+		Level:       req.Level,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Schedule:    req.Schedule,
+		Capacity:    req.Capacity,
+		PriceCents:  req.PriceCents,
+		Currency:    req.Currency,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// @Summary      Get Class
+// @Description  Get a class by ID
+// @Tags         classes
+// @Produce      json
+// @Param        classID  path  int  true  "Class ID"
+// @Security     BearerAuth
+// @Success      200  {object}  class.Class
+// @Failure      400  {string}  string  "Invalid class ID"
+// @Failure      404  {string}  string  "Class not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/{classID} [get]
+func (h *Handler) GetClass(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.Atoi(chi.URLParam(r, "classID"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetClass(r.Context(), classID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary      Delete Class
+// @Description  Cancels a class; only its teacher may do this
+// @Tags         classes
+// @Param        classID  path  int  true  "Class ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid class ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only the teacher of the class can do this"
+// @Failure      404  {string}  string  "Class not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/{classID} [delete]
+func (h *Handler) DeleteClass(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	classID, err := strconv.Atoi(chi.URLParam(r, "classID"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteClass(r.Context(), userID, classID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Search Classes
+// @Description  Search for classes that are not over yet, soonest first
+// @Tags         classes
+// @Accept       json
+// @Produce      json
+// @Param        request  body  SearchRequest  true  "Search filters"
+// @Security     BearerAuth
+// @Success      200  {object}  class.SearchResult
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/search [post]
+func (h *Handler) SearchClasses(w http.ResponseWriter, r *http.Request) {
+	var req SearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Search(r.Context(), class.SearchFilter{
+		CityID:    req.CityID,
+		Level:     req.Level,
+		TeacherID: req.TeacherID,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary      Enroll in Class
+// @Description  Takes a seat in a class and joins its group chat. Payment for paid classes is arranged with the teacher.
+// @Tags         classes
+// @Produce      json
+// @Param        classID  path  int  true  "Class ID"
+// @Security     BearerAuth
+// @Success      200  {object}  class.Class
+// @Failure      400  {string}  string  "Invalid class ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Teachers cannot enroll in their own class"
+// @Failure      404  {string}  string  "Class not found"
+// @Failure      409  {string}  string  "Already enrolled, class is full or class is over"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/{classID}/enrollment [post]
+func (h *Handler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	classID, err := strconv.Atoi(chi.URLParam(r, "classID"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Enroll(r.Context(), userID, classID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary      Leave Class
+// @Description  Frees the current user's seat in a class and leaves its group chat
+// @Tags         classes
+// @Param        classID  path  int  true  "Class ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid class ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Not enrolled"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/{classID}/enrollment [delete]
+func (h *Handler) Unenroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	classID, err := strconv.Atoi(chi.URLParam(r, "classID"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Unenroll(r.Context(), userID, classID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      List Class Enrollments
+// @Description  Returns the students of a class in the order they enrolled; only its teacher may do this
+// @Tags         classes
+// @Produce      json
+// @Param        classID  path  int  true  "Class ID"
+// @Security     BearerAuth
+// @Success      200  {array}   class.Enrollment
+// @Failure      400  {string}  string  "Invalid class ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only the teacher of the class can do this"
+// @Failure      404  {string}  string  "Class not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /classes/{classID}/enrollments [get]
+func (h *Handler) GetEnrollments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	classID, err := strconv.Atoi(chi.URLParam(r, "classID"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	enrollments, err := h.service.GetEnrollments(r.Context(), userID, classID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, enrollments)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, class.ErrClassNotFound):
+		http.Error(w, "Class not found", http.StatusNotFound)
+	case errors.Is(err, class.ErrNotEnrolled):
+		http.Error(w, "Not enrolled", http.StatusNotFound)
+	case errors.Is(err, class.ErrNotTeacher):
+		http.Error(w, "Only the teacher of the class can do this", http.StatusForbidden)
+	case errors.Is(err, class.ErrTeacherEnrolling):
+		http.Error(w, "Teachers cannot enroll in their own class", http.StatusForbidden)
+	case errors.Is(err, class.ErrAlreadyEnrolled):
+		http.Error(w, "Already enrolled", http.StatusConflict)
+	case errors.Is(err, class.ErrClassFull):
+		http.Error(w, "Class is full", http.StatusConflict)
+	case errors.Is(err, class.ErrClassOver):
+		http.Error(w, "Class is over", http.StatusConflict)
+	case errors.Is(err, class.ErrInvalidTitle):
+		http.Error(w, "Invalid class title", http.StatusBadRequest)
+	case errors.Is(err, class.ErrInvalidLevel):
+		http.Error(w, "Invalid class level", http.StatusBadRequest)
+	case errors.Is(err, class.ErrInvalidSchedule):
+		http.Error(w, "Class must end after it starts and not be over", http.StatusBadRequest)
+	case errors.Is(err, class.ErrInvalidCapacity):
+		http.Error(w, "Invalid class capacity", http.StatusBadRequest)
+	case errors.Is(err, class.ErrInvalidPrice):
+		http.Error(w, "Paid classes need a non-negative price and a currency code", http.StatusBadRequest)
+	case errors.Is(err, class.ErrInvalidCity):
+		http.Error(w, "Invalid city", http.StatusBadRequest)
+	default:
+		log.Printf("Class error: %v", err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+	}
+}
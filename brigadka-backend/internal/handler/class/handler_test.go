@@ -0,0 +1,264 @@
+package class
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/class"
+)
+
+func TestCreateClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid level", 1, class.ErrInvalidLevel, http.StatusBadRequest},
+		{"invalid price", 1, class.ErrInvalidPrice, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	startsAt := time.Date(2026, 11, 3, 19, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				CreateClassFunc: func(ctx context.Context, teacherID int, req class.CreateRequest) (*class.Class, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &class.Class{ID: 10, TeacherID: teacherID, Title: req.Title, Capacity: req.Capacity, SpotsLeft: req.Capacity}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := CreateClassRequest{
+				Title:      "Longform basics",
+				Level:      class.LevelBeginner,
+				StartsAt:   startsAt,
+				EndsAt:     startsAt.Add(2 * time.Hour),
+				Capacity:   12,
+				PriceCents: 300000,
+				Currency:   "rub",
+			}
+			rec := httptest.NewRecorder()
+			h.CreateClass(rec, handlertest.NewRequest(http.MethodPost, "/api/classes", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.CreateClassCalls()[0]
+				assert.Equal(t, tt.userID, call.TeacherID)
+				assert.Equal(t, "Longform basics", call.Req.Title)
+				assert.True(t, startsAt.Equal(call.Req.StartsAt))
+				assert.Equal(t, 300000, call.Req.PriceCents)
+				assert.Equal(t, "rub", call.Req.Currency)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp class.Class
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 10, resp.ID)
+				assert.Equal(t, 12, resp.SpotsLeft)
+			}
+		})
+	}
+}
+
+func TestGetClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		classID    string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "10", nil, http.StatusOK},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not found", "10", class.ErrClassNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				GetClassFunc: func(ctx context.Context, classID int) (*class.Class, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &class.Class{ID: classID, Title: "Jam"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetClass(rec, handlertest.NewRequest(http.MethodGet, "/api/classes/"+tt.classID, nil, 1, map[string]string{"classID": tt.classID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestDeleteClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not teacher", class.ErrNotTeacher, http.StatusForbidden},
+		{"not found", class.ErrClassNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				DeleteClassFunc: func(ctx context.Context, userID, classID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.DeleteClass(rec, handlertest.NewRequest(http.MethodDelete, "/api/classes/10", nil, 1, map[string]string{"classID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.DeleteClassCalls()[0]
+			assert.Equal(t, 1, call.UserID)
+			assert.Equal(t, 10, call.ClassID)
+		})
+	}
+}
+
+func TestSearchClasses(t *testing.T) {
+	service := &ClassServiceMock{
+		SearchFunc: func(ctx context.Context, filter class.SearchFilter) (*class.SearchResult, error) {
+			return &class.SearchResult{Classes: []class.Class{{ID: 10}}, TotalCount: 1, Page: 1, PageSize: 20}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	cityID := 1
+	level := class.LevelAdvanced
+	rec := httptest.NewRecorder()
+	h.SearchClasses(rec, handlertest.NewRequest(http.MethodPost, "/api/classes/search", SearchRequest{CityID: &cityID, Level: &level}, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	filter := service.SearchCalls()[0].Filter
+	assert.Equal(t, &cityID, filter.CityID)
+	assert.Equal(t, &level, filter.Level)
+	assert.Nil(t, filter.TeacherID)
+
+	var resp class.SearchResult
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.TotalCount)
+}
+
+func TestEnroll(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 7, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"full", 7, class.ErrClassFull, http.StatusConflict},
+		{"already enrolled", 7, class.ErrAlreadyEnrolled, http.StatusConflict},
+		{"over", 7, class.ErrClassOver, http.StatusConflict},
+		{"teacher", 5, class.ErrTeacherEnrolling, http.StatusForbidden},
+		{"not found", 7, class.ErrClassNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				EnrollFunc: func(ctx context.Context, userID, classID int) (*class.Class, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &class.Class{ID: classID, Capacity: 12, EnrolledCount: 4, SpotsLeft: 8}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Enroll(rec, handlertest.NewRequest(http.MethodPost, "/api/classes/10/enrollment", nil, tt.userID, map[string]string{"classID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp class.Class
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 8, resp.SpotsLeft)
+			}
+		})
+	}
+}
+
+func TestUnenroll(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not enrolled", class.ErrNotEnrolled, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				UnenrollFunc: func(ctx context.Context, userID, classID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Unenroll(rec, handlertest.NewRequest(http.MethodDelete, "/api/classes/10/enrollment", nil, 7, map[string]string{"classID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetEnrollments(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not teacher", class.ErrNotTeacher, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ClassServiceMock{
+				GetEnrollmentsFunc: func(ctx context.Context, userID, classID int) ([]class.Enrollment, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return []class.Enrollment{{UserID: 7, FullName: "Student"}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetEnrollments(rec, handlertest.NewRequest(http.MethodGet, "/api/classes/10/enrollments", nil, 5, map[string]string{"classID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp []class.Enrollment
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Len(t, resp, 1)
+			}
+		})
+	}
+}
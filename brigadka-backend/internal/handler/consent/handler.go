@@ -0,0 +1,152 @@
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/consent"
+)
+
+//go:generate moq -out mocks_test.go . ConsentService
+
+// ConsentService defines the consent operations used by the handler
+type ConsentService interface {
+	CurrentVersions() consent.Versions
+	Accept(ctx context.Context, userID int, accepted consent.Versions) error
+	MissingConsents(ctx context.Context, userID int) ([]string, error)
+	GetStatus(ctx context.Context, userID int) (*consent.Status, error)
+}
+
+// AcceptRequest represents a consent acceptance request
+type AcceptRequest struct {
+	TermsOfServiceVersion string `json:"tos_version"`
+	PrivacyPolicyVersion  string `json:"privacy_version"`
+}
+
+// ConsentRequiredResponse is returned with 451 when the user must accept new documents
+type ConsentRequiredResponse struct {
+	Error   string           `json:"error"`
+	Missing []string         `json:"missing"`
+	Current consent.Versions `json:"current"`
+}
+
+// Handler handles consent endpoints
+type Handler struct {
+	service ConsentService
+}
+
+// NewHandler creates a new consent handler
+func NewHandler(service ConsentService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// @Summary      Accept legal documents
+// @Description  Record acceptance of the current terms of service and privacy policy versions
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  AcceptRequest  true  "Accepted versions"
+// @Security     BearerAuth
+// @Success      200  {object}  consent.Status
+// @Failure      400  {string}  string  "Invalid request body"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      409  {string}  string  "Accepted version is outdated"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /auth/consent [post]
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req AcceptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.Accept(r.Context(), userID, consent.Versions{
+		TermsOfService: req.TermsOfServiceVersion,
+		PrivacyPolicy:  req.PrivacyPolicyVersion,
+	})
+	if err != nil {
+		if errors.Is(err, consent.ErrOutdatedVersion) {
+			http.Error(w, "Accepted version is outdated", http.StatusConflict)
+			return
+		}
+		log.Printf("Error saving consent: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondStatus(w, r, userID)
+}
+
+// @Summary      Consent status
+// @Description  Get current document versions and what the user has accepted
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  consent.Status
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /auth/consent [get]
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	h.respondStatus(w, r, userID)
+}
+
+func (h *Handler) respondStatus(w http.ResponseWriter, r *http.Request, userID int) {
+	status, err := h.service.GetStatus(r.Context(), userID)
+	if err != nil {
+		log.Printf("Error fetching consent status: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// RequireConsent responds with 451 until the user accepts the current document versions.
+// Must be used after AuthMiddleware; guest tokens are not checked.
+func (h *Handler) RequireConsent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value("user_id").(int)
+		if !ok || userID == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		missing, err := h.service.MissingConsents(r.Context(), userID)
+		if err != nil {
+			log.Printf("Error checking consent: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if len(missing) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnavailableForLegalReasons)
+			json.NewEncoder(w).Encode(ConsentRequiredResponse{
+				Error:   "consent required",
+				Missing: missing,
+				Current: h.service.CurrentVersions(),
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,97 @@
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/consent"
+)
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"outdated version", 1, consent.ErrOutdatedVersion, http.StatusConflict},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ConsentServiceMock{
+				AcceptFunc: func(ctx context.Context, userID int, accepted consent.Versions) error {
+					return tt.serviceErr
+				},
+				GetStatusFunc: func(ctx context.Context, userID int) (*consent.Status, error) {
+					return &consent.Status{Missing: []string{}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := AcceptRequest{TermsOfServiceVersion: "2025-01", PrivacyPolicyVersion: "2025-02"}
+			rec := httptest.NewRecorder()
+			h.Accept(rec, handlertest.NewRequest(http.MethodPost, "/api/auth/consent", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.AcceptCalls()[0]
+				assert.Equal(t, "2025-01", call.Accepted.TermsOfService)
+				assert.Equal(t, "2025-02", call.Accepted.PrivacyPolicy)
+			}
+		})
+	}
+}
+
+func TestRequireConsent(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		missing    []string
+		serviceErr error
+		wantStatus int
+	}{
+		{"accepted", 1, nil, nil, http.StatusOK},
+		{"missing consent", 1, []string{consent.DocumentTermsOfService}, nil, http.StatusUnavailableForLegalReasons},
+		{"guest", 0, []string{consent.DocumentTermsOfService}, nil, http.StatusOK},
+		{"server error", 1, nil, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ConsentServiceMock{
+				MissingConsentsFunc: func(ctx context.Context, userID int) ([]string, error) {
+					return tt.missing, tt.serviceErr
+				},
+				CurrentVersionsFunc: func() consent.Versions {
+					return consent.Versions{TermsOfService: "2025-01"}
+				},
+			}
+			h := NewHandler(service)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			h.RequireConsent(next).ServeHTTP(rec, handlertest.NewRequest(http.MethodGet, "/api/chats", nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnavailableForLegalReasons {
+				var resp ConsentRequiredResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, []string{consent.DocumentTermsOfService}, resp.Missing)
+				assert.Equal(t, "2025-01", resp.Current.TermsOfService)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/export"
+)
+
+//go:generate moq -out mocks_test.go . ExportService
+
+// ExportService defines the export operations used by the handler
+type ExportService interface {
+	GetExport(ctx context.Context, userID int, exportID string) (*export.Export, error)
+	Download(ctx context.Context, userID int, exportID string) (*export.Export, error)
+}
+
+// Handler handles export endpoints
+type Handler struct {
+	service ExportService
+}
+
+// NewHandler creates a new export handler
+func NewHandler(service ExportService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// @Summary      Export status
+// @Description  Get the status of an asynchronous export
+// @Tags         exports
+// @Produce      json
+// @Param        exportID  path  string  true  "Export ID"
+// @Security     BearerAuth
+// @Success      200  {object}  export.Export
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Export not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /exports/{exportID} [get]
+func (h *Handler) GetExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	result, err := h.service.GetExport(r.Context(), userID, chi.URLParam(r, "exportID"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// @Summary      Download export
+// @Description  Download the file produced by a finished export
+// @Tags         exports
+// @Produce      octet-stream
+// @Param        exportID  path  string  true  "Export ID"
+// @Security     BearerAuth
+// @Success      200  {file}    file
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Export not found"
+// @Failure      409  {string}  string  "Export is not ready"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /exports/{exportID}/download [get]
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	result, err := h.service.Download(r.Context(), userID, chi.URLParam(r, "exportID"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", result.FileName))
+	w.Write(result.Data)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, export.ErrExportNotFound):
+		http.Error(w, "Export not found", http.StatusNotFound)
+	case errors.Is(err, export.ErrExportNotReady):
+		http.Error(w, "Export is not ready", http.StatusConflict)
+	default:
+		log.Printf("Export error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/export"
+)
+
+func TestGetExport(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"not found", 1, export.ErrExportNotFound, http.StatusNotFound},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ExportServiceMock{
+				GetExportFunc: func(ctx context.Context, userID int, exportID string) (*export.Export, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &export.Export{ID: exportID, UserID: userID, Status: "pending"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetExport(rec, handlertest.NewRequest(http.MethodGet, "/api/exports/e1", nil, tt.userID, map[string]string{"exportID": "e1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				assert.Equal(t, "e1", service.GetExportCalls()[0].ExportID)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not ready", export.ErrExportNotReady, http.StatusConflict},
+		{"not found", export.ErrExportNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ExportServiceMock{
+				DownloadFunc: func(ctx context.Context, userID int, exportID string) (*export.Export, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &export.Export{ID: exportID, FileName: "profiles.csv", ContentType: "text/csv", Data: []byte("a,b\n")}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Download(rec, handlertest.NewRequest(http.MethodGet, "/api/exports/e1/download", nil, 1, map[string]string{"exportID": "e1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="profiles.csv"`, rec.Header().Get("Content-Disposition"))
+				assert.Equal(t, "a,b\n", rec.Body.String())
+			}
+		})
+	}
+}
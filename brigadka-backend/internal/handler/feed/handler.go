@@ -0,0 +1,180 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+)
+
+//go:generate moq -out mocks_test.go . FeedService
+
+// FeedService defines the follow and feed operations used by the handler
+type FeedService interface {
+	FollowUser(ctx context.Context, followerID, userID int) error
+	UnfollowUser(ctx context.Context, followerID, userID int) error
+	FollowTeam(ctx context.Context, followerID, teamID int) error
+	UnfollowTeam(ctx context.Context, followerID, teamID int) error
+	GetFeed(ctx context.Context, userID int, beforeID *int64, limit int) (*feed.Feed, error)
+}
+
+// Handler handles follow and feed endpoints
+type Handler struct {
+	service FeedService
+}
+
+// NewHandler creates a new feed handler
+func NewHandler(service FeedService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// @Summary      Get activity feed
+// @Description  Recent activity of followed users and teams, newest first
+// @Tags         feed
+// @Produce      json
+// @Param        before_id  query  int  false  "Return activities older than this ID (next_before_id of the previous page)"
+// @Param        limit      query  int  false  "Page size (default 20, max 100)"
+// @Security     BearerAuth
+// @Success      200  {object}  feed.Feed
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /feed [get]
+func (h *Handler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var beforeID *int64
+	if v := r.URL.Query().Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid before_id", http.StatusBadRequest)
+			return
+		}
+		beforeID = &id
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.service.GetFeed(r.Context(), userID, beforeID, limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// @Summary      Follow user
+// @Description  Subscribe to the activity of a user
+// @Tags         feed
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "User not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /profiles/{userID}/follow [post]
+func (h *Handler) FollowUser(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, "userID", h.service.FollowUser)
+}
+
+// @Summary      Unfollow user
+// @Description  Unsubscribe from the activity of a user
+// @Tags         feed
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Not following"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /profiles/{userID}/follow [delete]
+func (h *Handler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, "userID", h.service.UnfollowUser)
+}
+
+// @Summary      Follow team
+// @Description  Subscribe to the activity of a team
+// @Tags         feed
+// @Param        teamID  path  int  true  "Team ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /teams/{teamID}/follow [post]
+func (h *Handler) FollowTeam(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, "teamID", h.service.FollowTeam)
+}
+
+// @Summary      Unfollow team
+// @Description  Unsubscribe from the activity of a team
+// @Tags         feed
+// @Param        teamID  path  int  true  "Team ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Not following"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /teams/{teamID}/follow [delete]
+func (h *Handler) UnfollowTeam(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, "teamID", h.service.UnfollowTeam)
+}
+
+func (h *Handler) changeFollow(w http.ResponseWriter, r *http.Request, param string, change func(ctx context.Context, followerID, targetID int) error) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	targetID, err := strconv.Atoi(chi.URLParam(r, param))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := change(r.Context(), userID, targetID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, feed.ErrTargetNotFound):
+		http.Error(w, "Not found", http.StatusNotFound)
+	case errors.Is(err, feed.ErrNotFollowing):
+		http.Error(w, "Not following", http.StatusNotFound)
+	case errors.Is(err, feed.ErrCannotFollowSelf):
+		http.Error(w, "Users cannot follow themselves", http.StatusBadRequest)
+	default:
+		log.Printf("Feed error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
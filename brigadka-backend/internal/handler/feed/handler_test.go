@@ -0,0 +1,121 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+)
+
+func TestGetFeed(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		userID       int
+		wantStatus   int
+		wantBeforeID *int64
+		wantLimit    int
+	}{
+		{"first page", "/api/feed", 1, http.StatusOK, nil, 0},
+		{"next page", "/api/feed?before_id=42&limit=10", 1, http.StatusOK, func() *int64 { id := int64(42); return &id }(), 10},
+		{"invalid before_id", "/api/feed?before_id=abc", 1, http.StatusBadRequest, nil, 0},
+		{"invalid limit", "/api/feed?limit=abc", 1, http.StatusBadRequest, nil, 0},
+		{"unauthorized", "/api/feed", 0, http.StatusUnauthorized, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeedServiceMock{
+				GetFeedFunc: func(ctx context.Context, userID int, beforeID *int64, limit int) (*feed.Feed, error) {
+					return &feed.Feed{Activities: []feed.Activity{{ID: 41, Type: feed.ActivityProfileUpdated}}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetFeed(rec, handlertest.NewRequest(http.MethodGet, tt.target, nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, service.GetFeedCalls())
+				return
+			}
+
+			call := service.GetFeedCalls()[0]
+			assert.Equal(t, tt.wantBeforeID, call.BeforeID)
+			assert.Equal(t, tt.wantLimit, call.Limit)
+
+			var resp feed.Feed
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Len(t, resp.Activities, 1)
+		})
+	}
+}
+
+func TestFollowUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"self", feed.ErrCannotFollowSelf, http.StatusBadRequest},
+		{"not found", feed.ErrTargetNotFound, http.StatusNotFound},
+		{"server error", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeedServiceMock{
+				FollowUserFunc: func(ctx context.Context, followerID int, userID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.FollowUser(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/2/follow", nil, 1, map[string]string{"userID": "2"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.FollowUserCalls()[0]
+			assert.Equal(t, 1, call.FollowerID)
+			assert.Equal(t, 2, call.UserID)
+		})
+	}
+}
+
+func TestUnfollowTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "10", nil, http.StatusNoContent},
+		{"not following", "10", feed.ErrNotFollowing, http.StatusNotFound},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FeedServiceMock{
+				UnfollowTeamFunc: func(ctx context.Context, followerID int, teamID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.UnfollowTeam(rec, handlertest.NewRequest(http.MethodDelete, "/api/teams/"+tt.teamID+"/follow", nil, 1, map[string]string{"teamID": tt.teamID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
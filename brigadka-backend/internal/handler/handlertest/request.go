@@ -0,0 +1,37 @@
+// Package handlertest holds helpers shared by the tests of HTTP handlers
+package handlertest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NewRequest builds a request with an authenticated user and optional chi URL params.
+// A nil body sends no body; a zero userID leaves the request unauthenticated.
+func NewRequest(method, target string, body interface{}, userID int, params map[string]string) *http.Request {
+	return NewRequestWithKey(method, target, body, "user_id", userID, params)
+}
+
+// NewRequestWithKey is NewRequest for callers identified under another context key, e.g. bots
+func NewRequestWithKey(method, target string, body interface{}, key string, id int, params map[string]string) *http.Request {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, target, &buf)
+
+	rctx := chi.NewRouteContext()
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if id != 0 {
+		ctx = context.WithValue(ctx, key, id)
+	}
+	return req.WithContext(ctx)
+}
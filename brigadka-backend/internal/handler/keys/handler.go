@@ -0,0 +1,195 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/keys"
+)
+
+//go:generate moq -out mocks_test.go ../../service/keys KeyService
+
+// Handler handles the key distribution endpoints of end-to-end encrypted chats
+type Handler struct {
+	service keys.KeyService
+}
+
+// NewHandler creates a new key distribution handler
+func NewHandler(service keys.KeyService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RegisterDeviceRequest carries the public keys of a device
+type RegisterDeviceRequest struct {
+	IdentityKey           string        `json:"identity_key"`
+	SignedPrekeyID        int           `json:"signed_prekey_id"`
+	SignedPrekey          string        `json:"signed_prekey"`
+	SignedPrekeySignature string        `json:"signed_prekey_signature"`
+	OneTimePrekeys        []keys.Prekey `json:"one_time_prekeys"`
+}
+
+// UploadPrekeysRequest carries more one-time prekeys of a device
+type UploadPrekeysRequest struct {
+	OneTimePrekeys []keys.Prekey `json:"one_time_prekeys"`
+}
+
+// PrekeyCountResponse tells how many one-time prekeys a device has left, so that it uploads more in time
+type PrekeyCountResponse struct {
+	OneTimePrekeys int `json:"one_time_prekeys"`
+}
+
+// @Summary      Register device keys
+// @Description  Register the public keys of one of the current user's devices, or replace them. A new identity key drops the one-time prekeys uploaded for the previous one.
+// @Tags         keys
+// @Accept       json
+// @Produce      json
+// @Param        deviceID  path  string                 true  "Device ID"
+// @Param        request   body  RegisterDeviceRequest  true  "Public keys"
+// @Security     BearerAuth
+// @Success      200  {object}  PrekeyCountResponse
+// @Failure      400  {string}  string  "Invalid keys"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /keys/devices/{deviceID} [put]
+func (h *Handler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	deviceKeys := keys.DeviceKeys{
+		DeviceID:              chi.URLParam(r, "deviceID"),
+		IdentityKey:           req.IdentityKey,
+		SignedPrekeyID:        req.SignedPrekeyID,
+		SignedPrekey:          req.SignedPrekey,
+		SignedPrekeySignature: req.SignedPrekeySignature,
+	}
+	count, err := h.service.RegisterDevice(r.Context(), userID, deviceKeys, req.OneTimePrekeys)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PrekeyCountResponse{OneTimePrekeys: count})
+}
+
+// @Summary      Upload one-time prekeys
+// @Description  Add one-time prekeys to a registered device of the current user. Key IDs the device already uploaded are skipped.
+// @Tags         keys
+// @Accept       json
+// @Produce      json
+// @Param        deviceID  path  string                true  "Device ID"
+// @Param        request   body  UploadPrekeysRequest  true  "One-time prekeys"
+// @Security     BearerAuth
+// @Success      200  {object}  PrekeyCountResponse
+// @Failure      400  {string}  string  "Invalid keys"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Device not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /keys/devices/{deviceID}/prekeys [post]
+func (h *Handler) UploadPrekeys(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req UploadPrekeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.service.UploadPrekeys(r.Context(), userID, chi.URLParam(r, "deviceID"), req.OneTimePrekeys)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PrekeyCountResponse{OneTimePrekeys: count})
+}
+
+// @Summary      Remove device keys
+// @Description  Remove the keys of one of the current user's devices, e.g. on logout. Other users stop encrypting for it.
+// @Tags         keys
+// @Param        deviceID  path  string  true  "Device ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Device not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /keys/devices/{deviceID} [delete]
+func (h *Handler) RemoveDevice(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveDevice(r.Context(), userID, chi.URLParam(r, "deviceID")); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Get key bundles of a user
+// @Description  Return the key bundles of all devices of a user, to start encrypted sessions with them. Every bundle hands out one one-time prekey of its device; it is missing when the device ran out.
+// @Tags         keys
+// @Produce      json
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      200  {array}   keys.KeyBundle
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /users/{userID}/keys [get]
+func (h *Handler) GetKeyBundles(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("user_id").(int); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	bundles, err := h.service.GetKeyBundles(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundles)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, keys.ErrDeviceNotFound):
+		http.Error(w, "Device not found", http.StatusNotFound)
+	case errors.Is(err, keys.ErrInvalidDeviceID), errors.Is(err, keys.ErrInvalidKeys), errors.Is(err, keys.ErrTooManyPrekeys):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Key distribution error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,133 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/keys"
+)
+
+func TestRegisterDevice(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid keys", 1, keys.ErrInvalidKeys, http.StatusBadRequest},
+		{"too many prekeys", 1, keys.ErrTooManyPrekeys, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &KeyServiceMock{
+				RegisterDeviceFunc: func(ctx context.Context, userID int, deviceKeys keys.DeviceKeys, prekeys []keys.Prekey) (int, error) {
+					return len(prekeys), tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			body := RegisterDeviceRequest{
+				IdentityKey:           "identity",
+				SignedPrekeyID:        1,
+				SignedPrekey:          "signed",
+				SignedPrekeySignature: "signature",
+				OneTimePrekeys:        []keys.Prekey{{KeyID: 1, PublicKey: "otk-1"}, {KeyID: 2, PublicKey: "otk-2"}},
+			}
+			rec := httptest.NewRecorder()
+			h.RegisterDevice(rec, handlertest.NewRequest(http.MethodPut, "/api/keys/devices/phone", body, tt.userID, map[string]string{"deviceID": "phone"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp PrekeyCountResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 2, resp.OneTimePrekeys)
+
+				call := service.RegisterDeviceCalls()[0]
+				assert.Equal(t, keys.DeviceKeys{
+					DeviceID: "phone", IdentityKey: "identity", SignedPrekeyID: 1, SignedPrekey: "signed", SignedPrekeySignature: "signature",
+				}, call.DeviceKeys)
+			}
+		})
+	}
+}
+
+func TestUploadPrekeysUnknownDevice(t *testing.T) {
+	service := &KeyServiceMock{
+		UploadPrekeysFunc: func(ctx context.Context, userID int, deviceID string, prekeys []keys.Prekey) (int, error) {
+			return 0, keys.ErrDeviceNotFound
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	body := UploadPrekeysRequest{OneTimePrekeys: []keys.Prekey{{KeyID: 3, PublicKey: "otk-3"}}}
+	h.UploadPrekeys(rec, handlertest.NewRequest(http.MethodPost, "/api/keys/devices/tablet/prekeys", body, 1, map[string]string{"deviceID": "tablet"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "tablet", service.UploadPrekeysCalls()[0].DeviceID)
+}
+
+func TestRemoveDevice(t *testing.T) {
+	service := &KeyServiceMock{
+		RemoveDeviceFunc: func(ctx context.Context, userID int, deviceID string) error {
+			return nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.RemoveDevice(rec, handlertest.NewRequest(http.MethodDelete, "/api/keys/devices/phone", nil, 1, map[string]string{"deviceID": "phone"}))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 1, service.RemoveDeviceCalls()[0].UserID)
+}
+
+func TestGetKeyBundles(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantStatus int
+	}{
+		{"success", "2", http.StatusOK},
+		{"invalid user ID", "abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &KeyServiceMock{
+				GetKeyBundlesFunc: func(ctx context.Context, userID int) ([]keys.KeyBundle, error) {
+					return []keys.KeyBundle{{
+						DeviceKeys:    keys.DeviceKeys{DeviceID: "phone", IdentityKey: "identity"},
+						OneTimePrekey: &keys.Prekey{KeyID: 5, PublicKey: "otk-5"},
+					}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetKeyBundles(rec, handlertest.NewRequest(http.MethodGet, "/api/users/"+tt.param+"/keys", nil, 1, map[string]string{"userID": tt.param}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var bundles []keys.KeyBundle
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&bundles))
+				if assert.Len(t, bundles, 1) {
+					assert.Equal(t, "phone", bundles[0].DeviceID)
+					assert.Equal(t, 5, bundles[0].OneTimePrekey.KeyID)
+				}
+				assert.Equal(t, 2, service.GetKeyBundlesCalls()[0].UserID)
+			}
+		})
+	}
+}
@@ -2,15 +2,28 @@ package media
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/media"
 )
 
+//go:generate moq -out mocks_test.go . MediaService
+
 // MediaService определяет интерфейс для работы с медиа
 type MediaService interface {
 	UploadMedia(userID int, fileHeader, thumbnailHeader media.UploadedFile) (*media.Media, error)
+	DeleteMedia(userID, mediaID int) error
+	CropMedia(userID, mediaID int, rect media.CropRect) (*media.Media, error)
+	GetMediaLibrary(userID, page, pageSize int) (*media.MediaLibrary, error)
+	GetQuota(userID int) (*media.Quota, error)
+	GetModerationQueue(page, pageSize int) (*media.ModerationQueue, error)
+	ReviewMedia(moderatorID, mediaID int, approve bool) error
+	GetModerationMetrics() []media.ProviderMetrics
 }
 
 // MediaHandler handles requests for media operations
@@ -27,22 +40,36 @@ func NewMediaHandler(service MediaService) *MediaHandler {
 
 // Response for media operations
 type MediaResponse struct {
-	ID           int    `json:"id"`
-	URL          string `json:"url"`
-	ThumbnailURL string `json:"thumbnail_url"`
+	ID               int    `json:"id"`
+	URL              string `json:"url"`
+	ThumbnailURL     string `json:"thumbnail_url"`
+	ModerationStatus string `json:"moderation_status"`
+	// ThumbnailPending is set while the server generates the thumbnail
+	ThumbnailPending bool `json:"thumbnail_pending"`
+	// Variants maps image sizes ("128", "512", "full") to URLs; only "full" until the smaller sizes are generated
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// ValidationErrorResponse is returned with 422 when an upload breaks a media limit
+type ValidationErrorResponse struct {
+	Error   string   `json:"error"`
+	Message string   `json:"message"`
+	Field   string   `json:"field"`
+	Limit   int64    `json:"limit,omitempty"`
+	Allowed []string `json:"allowed,omitempty"`
 }
 
 // @Summary      Upload media
-// @Description  Upload media file (image or video) with optional thumbnail
+// @Description  Upload media file (JPEG or PNG image, MP4 video, M4A audio) with an optional image thumbnail. Without a thumbnail the server generates one in the background for images and, when video thumbnails are enabled, videos; the response then has thumbnail_pending set and an empty thumbnail_url. The content type is detected from the file content. Uploads over the size, image dimension or duration limits or the user's storage quota are rejected with 422 and an error code: unsupported_media_type, file_too_large, image_too_large, video_too_long, audio_too_long, invalid_media, thumbnail_required or storage_quota_exceeded.
 // @Tags         media
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        file       formData  file  true  "File to upload"
-// @Param        thumbnail  formData  file  true  "Thumbnail file"
+// @Param        thumbnail  formData  file  false "Thumbnail file"
 // @Success      200   {object}  MediaResponse
-// @Failure      400   {string}  string  "Invalid file"
+// @Failure      400   {string}  string  "Invalid form"
 // @Failure      401   {string}  string  "Unauthorized"
-// @Failure      413   {string}  string  "File too large"
+// @Failure      422   {object}  ValidationErrorResponse
 // @Failure      500   {string}  string  "Internal server error"
 // @Router       /api/media [post]
 // @Security     BearerAuth
@@ -72,38 +99,328 @@ func (h *MediaHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
 	// Create wrapper for the main file header
 	fileWrapper := &media.FileHeaderWrapper{FileHeader: header}
 
-	// Check for thumbnail file
-	var thumbnailWrapper *media.FileHeaderWrapper
+	// The thumbnail is optional; without it the server generates one
+	var thumbnailWrapper media.UploadedFile
 	thumbnailFile, thumbnailHeader, err := r.FormFile("thumbnail")
-	if err != nil {
+	switch {
+	case err == nil:
+		defer thumbnailFile.Close()
+		thumbnailWrapper = &media.FileHeaderWrapper{FileHeader: thumbnailHeader}
+	case !errors.Is(err, http.ErrMissingFile):
 		http.Error(w, "Could not get thumbnail", http.StatusBadRequest)
 		return
 	}
 
-	defer thumbnailFile.Close()
-
-	thumbnailWrapper = &media.FileHeaderWrapper{FileHeader: thumbnailHeader}
-
 	// Upload media
 	uploaded, err := h.service.UploadMedia(userID, fileWrapper, thumbnailWrapper)
 	if err != nil {
+		if respondValidationError(w, err) {
+			return
+		}
 		log.Printf("Error uploading media: %v", err)
-		switch err {
-		case media.ErrInvalidFileType:
-			http.Error(w, "Invalid file type", http.StatusBadRequest)
-		case media.ErrFileTooBig:
-			http.Error(w, "File too large", http.StatusRequestEntityTooLarge)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MediaResponse{
+		ID:               uploaded.ID,
+		URL:              uploaded.URL,
+		ThumbnailURL:     uploaded.ThumbnailURL,
+		ModerationStatus: uploaded.ModerationStatus,
+		ThumbnailPending: uploaded.ThumbnailPending,
+		Variants:         uploaded.Variants,
+	})
+}
+
+// respondValidationError writes a 422 response when err is a validation error and reports whether it did
+func respondValidationError(w http.ResponseWriter, err error) bool {
+	var validationErr *media.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(ValidationErrorResponse{
+		Error:   validationErr.Code,
+		Message: validationErr.Error(),
+		Field:   validationErr.Field,
+		Limit:   validationErr.Limit,
+		Allowed: validationErr.Allowed,
+	})
+	return true
+}
+
+// MediaInUseResponse is returned with 409 when deleting media that is still used
+type MediaInUseResponse struct {
+	Error   string   `json:"error"`
+	Message string   `json:"message"`
+	Usages  []string `json:"usages"`
+}
+
+// @Summary      Delete media
+// @Description  Delete media uploaded by the user, with its file, thumbnail and image variants. Media used as a profile avatar, video or audio introduction, a message attachment or a team or chat avatar is kept and the response lists the usages; remove it from there first.
+// @Tags         media
+// @Produce      json
+// @Param        mediaID  path  int  true  "Media ID"
+// @Success      204
+// @Failure      400  {string}  string  "Invalid media ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Media belongs to another user"
+// @Failure      404  {string}  string  "Media not found"
+// @Failure      409  {object}  MediaInUseResponse
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /api/media/{mediaID} [delete]
+// @Security     BearerAuth
+func (h *MediaHandler) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "mediaID"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeleteMedia(userID, mediaID)
+	var inUseErr *media.InUseError
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.As(err, &inUseErr):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(MediaInUseResponse{
+			Error:   "media_in_use",
+			Message: inUseErr.Error(),
+			Usages:  inUseErr.Usages,
+		})
+	case errors.Is(err, media.ErrNotMediaOwner):
+		http.Error(w, "Media belongs to another user", http.StatusForbidden)
+	case errors.Is(err, media.ErrMediaNotFound):
+		http.Error(w, "Media not found", http.StatusNotFound)
+	default:
+		log.Printf("Error deleting media %d: %v", mediaID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// @Summary      Crop image
+// @Description  Store a rectangle of an image uploaded by the user as a new image, keeping the original, e.g. when the user adjusts the framing of their avatar. The rectangle is in pixels of the original from its top left corner. The crop has a thumbnail right away and gets its 128 and 512 variants in the background; cropping the same rectangle again returns the earlier crop. A rectangle outside the image is rejected with 422 invalid_crop, a crop over the storage quota with 422 storage_quota_exceeded.
+// @Tags         media
+// @Accept       json
+// @Produce      json
+// @Param        mediaID  path  int             true  "Media ID"
+// @Param        request  body  media.CropRect  true  "Crop rectangle"
+// @Success      200  {object}  MediaResponse
+// @Failure      400  {string}  string  "Invalid request or media is not an image"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Media belongs to another user"
+// @Failure      404  {string}  string  "Media not found"
+// @Failure      422  {object}  ValidationErrorResponse
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /api/media/{mediaID}/crop [post]
+// @Security     BearerAuth
+func (h *MediaHandler) CropMedia(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "mediaID"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	var rect media.CropRect
+	if err := json.NewDecoder(r.Body).Decode(&rect); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	cropped, err := h.service.CropMedia(userID, mediaID, rect)
+	if err != nil {
+		if respondValidationError(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, media.ErrNotAnImage):
+			http.Error(w, "Only images can be cropped", http.StatusBadRequest)
+		case errors.Is(err, media.ErrNotMediaOwner):
+			http.Error(w, "Media belongs to another user", http.StatusForbidden)
+		case errors.Is(err, media.ErrMediaNotFound):
+			http.Error(w, "Media not found", http.StatusNotFound)
 		default:
+			log.Printf("Error cropping media %d: %v", mediaID, err)
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	// Return success response
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(MediaResponse{
-		ID:           uploaded.ID,
-		URL:          uploaded.URL,
-		ThumbnailURL: uploaded.ThumbnailURL,
+		ID:               cropped.ID,
+		URL:              cropped.URL,
+		ThumbnailURL:     cropped.ThumbnailURL,
+		ModerationStatus: cropped.ModerationStatus,
+		Variants:         cropped.Variants,
 	})
 }
+
+// @Summary      Media library
+// @Description  Media uploaded by the user, newest first, with its moderation status, size and where it is used: avatar, video, audio_intro, message_attachment, team_avatar or chat_avatar. The response also has the storage quota status.
+// @Tags         media
+// @Produce      json
+// @Param        page       query  int  false  "Page number"
+// @Param        page_size  query  int  false  "Page size"
+// @Success      200  {object}  media.MediaLibrary
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /api/media [get]
+// @Security     BearerAuth
+func (h *MediaHandler) GetMediaLibrary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	library, err := h.service.GetMediaLibrary(userID, page, pageSize)
+	if err != nil {
+		log.Printf("Error getting media library of user %d: %v", userID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(library)
+}
+
+// @Summary      Storage quota
+// @Description  Bytes used by the uploads of the user and the quota; limit_bytes is 0 when uploads are not limited. warning is set once 90% of the quota is used, so clients can warn before uploads are rejected.
+// @Tags         media
+// @Produce      json
+// @Success      200  {object}  media.Quota
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /api/media/quota [get]
+// @Security     BearerAuth
+func (h *MediaHandler) GetQuota(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	quota, err := h.service.GetQuota(userID)
+	if err != nil {
+		log.Printf("Error getting storage quota of user %d: %v", userID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quota)
+}
+
+// @Summary      Moderation queue
+// @Description  Media held for manual review by the NSFW classifier, oldest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        page       query  int  false  "Page number"
+// @Param        page_size  query  int  false  "Page size"
+// @Success      200  {object}  media.ModerationQueue
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/moderation/media [get]
+// @Security     BearerAuth
+func (h *MediaHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	queue, err := h.service.GetModerationQueue(page, pageSize)
+	if err != nil {
+		log.Printf("Error getting moderation queue: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+// @Summary      Approve media
+// @Description  Make held media visible on profiles. Admin only.
+// @Tags         admin
+// @Param        mediaID  path  int  true  "Media ID"
+// @Success      204
+// @Failure      400  {string}  string  "Invalid media ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Media not found in the queue"
+// @Router       /admin/moderation/media/{mediaID}/approve [post]
+// @Security     BearerAuth
+func (h *MediaHandler) ApproveMedia(w http.ResponseWriter, r *http.Request) {
+	h.reviewMedia(w, r, true)
+}
+
+// @Summary      Reject media
+// @Description  Keep held media hidden from profiles. Admin only.
+// @Tags         admin
+// @Param        mediaID  path  int  true  "Media ID"
+// @Success      204
+// @Failure      400  {string}  string  "Invalid media ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Media not found in the queue"
+// @Router       /admin/moderation/media/{mediaID}/reject [post]
+// @Security     BearerAuth
+func (h *MediaHandler) RejectMedia(w http.ResponseWriter, r *http.Request) {
+	h.reviewMedia(w, r, false)
+}
+
+func (h *MediaHandler) reviewMedia(w http.ResponseWriter, r *http.Request, approve bool) {
+	moderatorID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "mediaID"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ReviewMedia(moderatorID, mediaID, approve); err != nil {
+		if errors.Is(err, media.ErrMediaNotFound) {
+			http.Error(w, "Media not found in the queue", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error reviewing media %d: %v", mediaID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Moderation metrics
+// @Description  Per-provider NSFW classification counters since startup. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   media.ProviderMetrics
+// @Failure      403  {string}  string  "Forbidden"
+// @Router       /admin/moderation/metrics [get]
+// @Security     BearerAuth
+func (h *MediaHandler) GetModerationMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.GetModerationMetrics())
+}
@@ -0,0 +1,368 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/media"
+)
+
+// newUploadRequest builds a multipart upload request with the given form files
+func newUploadRequest(files map[string]string, userID int) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for field, name := range files {
+		part, _ := writer.CreateFormFile(field, name)
+		part.Write([]byte("content"))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/media", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if userID != 0 {
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", userID))
+	}
+	return req
+}
+
+func TestUploadMedia(t *testing.T) {
+	bothFiles := map[string]string{"file": "a.jpg", "thumbnail": "t.jpg"}
+
+	tests := []struct {
+		name       string
+		files      map[string]string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", bothFiles, 1, nil, http.StatusOK},
+		{"unauthorized", bothFiles, 0, nil, http.StatusUnauthorized},
+		{"missing file", map[string]string{"thumbnail": "t.jpg"}, 1, nil, http.StatusBadRequest},
+		{"thumbnail required", map[string]string{"file": "a.mp4"}, 1, &media.ValidationError{Field: "thumbnail", Code: media.CodeThumbnailRequired}, http.StatusUnprocessableEntity},
+		{"invalid type", bothFiles, 1, &media.ValidationError{Field: "file", Code: media.CodeUnsupportedType}, http.StatusUnprocessableEntity},
+		{"too big", bothFiles, 1, &media.ValidationError{Field: "file", Code: media.CodeFileTooLarge}, http.StatusUnprocessableEntity},
+		{"audio too long", map[string]string{"file": "intro.m4a", "thumbnail": "t.jpg"}, 1, &media.ValidationError{Field: "file", Code: media.CodeAudioTooLong}, http.StatusUnprocessableEntity},
+		{"server error", bothFiles, 1, errors.New("storage down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MediaServiceMock{
+				UploadMediaFunc: func(userID int, fileHeader media.UploadedFile, thumbnailHeader media.UploadedFile) (*media.Media, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &media.Media{ID: 10, URL: "https://cdn/a.jpg", ThumbnailURL: "https://cdn/t.jpg", ModerationStatus: "pending"}, nil
+				},
+			}
+			h := NewMediaHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.UploadMedia(rec, newUploadRequest(tt.files, tt.userID))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp MediaResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 10, resp.ID)
+				assert.Equal(t, "pending", resp.ModerationStatus)
+				assert.Equal(t, tt.userID, service.UploadMediaCalls()[0].UserID)
+			}
+		})
+	}
+}
+
+func TestUploadMediaWithoutThumbnail(t *testing.T) {
+	service := &MediaServiceMock{
+		UploadMediaFunc: func(userID int, fileHeader media.UploadedFile, thumbnailHeader media.UploadedFile) (*media.Media, error) {
+			return &media.Media{ID: 10, URL: "https://cdn/a.jpg", ModerationStatus: "approved", ThumbnailPending: true,
+				Variants: map[string]string{"full": "https://cdn/a.jpg"}}, nil
+		},
+	}
+	h := NewMediaHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.UploadMedia(rec, newUploadRequest(map[string]string{"file": "a.jpg"}, 1))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Nil(t, service.UploadMediaCalls()[0].ThumbnailHeader)
+	var resp MediaResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.True(t, resp.ThumbnailPending)
+	assert.Empty(t, resp.ThumbnailURL)
+	assert.Equal(t, map[string]string{"full": "https://cdn/a.jpg"}, resp.Variants)
+}
+
+func TestUploadMediaValidationError(t *testing.T) {
+	service := &MediaServiceMock{
+		UploadMediaFunc: func(userID int, fileHeader media.UploadedFile, thumbnailHeader media.UploadedFile) (*media.Media, error) {
+			return nil, &media.ValidationError{Field: "thumbnail", Code: media.CodeImageTooLarge, Limit: 8192}
+		},
+	}
+	h := NewMediaHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.UploadMedia(rec, newUploadRequest(map[string]string{"file": "a.jpg", "thumbnail": "t.jpg"}, 1))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var resp ValidationErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "image_too_large", resp.Error)
+	assert.Equal(t, "thumbnail", resp.Field)
+	assert.Equal(t, int64(8192), resp.Limit)
+}
+
+func TestReviewMedia(t *testing.T) {
+	tests := []struct {
+		name        string
+		approve     bool
+		mediaID     string
+		serviceErr  error
+		wantStatus  int
+		wantReviews int
+	}{
+		{"approve", true, "10", nil, http.StatusNoContent, 1},
+		{"reject", false, "10", nil, http.StatusNoContent, 1},
+		{"invalid id", true, "abc", nil, http.StatusBadRequest, 0},
+		{"not pending", true, "10", media.ErrMediaNotFound, http.StatusNotFound, 1},
+		{"server error", false, "10", errors.New("db down"), http.StatusInternalServerError, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MediaServiceMock{
+				ReviewMediaFunc: func(moderatorID int, mediaID int, approve bool) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewMediaHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/moderation/media/"+tt.mediaID+"/approve", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("mediaID", tt.mediaID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, "user_id", 1))
+
+			rec := httptest.NewRecorder()
+			if tt.approve {
+				h.ApproveMedia(rec, req)
+			} else {
+				h.RejectMedia(rec, req)
+			}
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			calls := service.ReviewMediaCalls()
+			if assert.Len(t, calls, tt.wantReviews) && tt.wantReviews > 0 {
+				assert.Equal(t, 1, calls[0].ModeratorID)
+				assert.Equal(t, 10, calls[0].MediaID)
+				assert.Equal(t, tt.approve, calls[0].Approve)
+			}
+		})
+	}
+}
+
+func TestDeleteMedia(t *testing.T) {
+	tests := []struct {
+		name       string
+		mediaID    string
+		serviceErr error
+		wantStatus int
+	}{
+		{"deleted", "10", nil, http.StatusNoContent},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not found", "10", media.ErrMediaNotFound, http.StatusNotFound},
+		{"not owner", "10", media.ErrNotMediaOwner, http.StatusForbidden},
+		{"in use", "10", &media.InUseError{Usages: []string{mediarepo.UsageAvatar}}, http.StatusConflict},
+		{"server error", "10", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MediaServiceMock{
+				DeleteMediaFunc: func(userID int, mediaID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewMediaHandler(service)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/media/"+tt.mediaID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("mediaID", tt.mediaID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, "user_id", 1))
+
+			rec := httptest.NewRecorder()
+			h.DeleteMedia(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusConflict {
+				var resp MediaInUseResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "media_in_use", resp.Error)
+				assert.Equal(t, []string{"avatar"}, resp.Usages)
+			}
+			if tt.mediaID == "10" {
+				calls := service.DeleteMediaCalls()
+				if assert.Len(t, calls, 1) {
+					assert.Equal(t, 1, calls[0].UserID)
+					assert.Equal(t, 10, calls[0].MediaID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetModerationQueue(t *testing.T) {
+	service := &MediaServiceMock{
+		GetModerationQueueFunc: func(page int, pageSize int) (*media.ModerationQueue, error) {
+			return &media.ModerationQueue{Items: []mediarepo.PendingMedia{{Media: mediarepo.Media{ID: 10}}}, TotalCount: 1, Page: 2, PageSize: 5}, nil
+		},
+	}
+	h := NewMediaHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetModerationQueue(rec, httptest.NewRequest(http.MethodGet, "/api/admin/moderation/media?page=2&page_size=5", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.GetModerationQueueCalls()[0]
+	assert.Equal(t, 2, call.Page)
+	assert.Equal(t, 5, call.PageSize)
+
+	var resp media.ModerationQueue
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.TotalCount)
+	assert.Len(t, resp.Items, 1)
+}
+
+func TestGetMediaLibrary(t *testing.T) {
+	service := &MediaServiceMock{
+		GetMediaLibraryFunc: func(userID int, page int, pageSize int) (*media.MediaLibrary, error) {
+			return &media.MediaLibrary{
+				Items: []mediarepo.LibraryItem{{
+					Media:     mediarepo.Media{ID: 10, Role: "image"},
+					SizeBytes: 2048,
+					Usages:    []string{mediarepo.UsageAvatar},
+				}},
+				TotalCount: 1,
+				Page:       page,
+				PageSize:   pageSize,
+				Quota:      media.Quota{UsedBytes: 2048, LimitBytes: 4096},
+			}, nil
+		},
+	}
+	h := NewMediaHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/media?page=2&page_size=5", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", 1))
+	rec := httptest.NewRecorder()
+	h.GetMediaLibrary(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.GetMediaLibraryCalls()[0]
+	assert.Equal(t, 1, call.UserID)
+	assert.Equal(t, 2, call.Page)
+	assert.Equal(t, 5, call.PageSize)
+
+	var resp media.MediaLibrary
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp.Items, 1) {
+		assert.Equal(t, int64(2048), resp.Items[0].SizeBytes)
+		assert.Equal(t, []string{"avatar"}, resp.Items[0].Usages)
+	}
+	assert.Equal(t, int64(4096), resp.Quota.LimitBytes)
+}
+
+func TestGetQuota(t *testing.T) {
+	service := &MediaServiceMock{
+		GetQuotaFunc: func(userID int) (*media.Quota, error) {
+			return &media.Quota{UsedBytes: 950, LimitBytes: 1000, Warning: true}, nil
+		},
+	}
+	h := NewMediaHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetQuota(rec, httptest.NewRequest(http.MethodGet, "/api/media/quota", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/media/quota", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", 1))
+	rec = httptest.NewRecorder()
+	h.GetQuota(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp media.Quota
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, int64(950), resp.UsedBytes)
+	assert.True(t, resp.Warning)
+}
+
+func TestCropMedia(t *testing.T) {
+	tests := []struct {
+		name       string
+		mediaID    string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"cropped", "10", `{"x":10,"y":20,"width":300,"height":300}`, nil, http.StatusOK},
+		{"invalid id", "abc", `{}`, nil, http.StatusBadRequest},
+		{"invalid body", "10", `{`, nil, http.StatusBadRequest},
+		{"invalid crop", "10", `{"width":0}`, &media.ValidationError{Field: "crop", Code: media.CodeInvalidCrop}, http.StatusUnprocessableEntity},
+		{"not an image", "10", `{"width":1,"height":1}`, media.ErrNotAnImage, http.StatusBadRequest},
+		{"not owner", "10", `{"width":1,"height":1}`, media.ErrNotMediaOwner, http.StatusForbidden},
+		{"not found", "10", `{"width":1,"height":1}`, media.ErrMediaNotFound, http.StatusNotFound},
+		{"server error", "10", `{"width":1,"height":1}`, errors.New("storage down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MediaServiceMock{
+				CropMediaFunc: func(userID int, mediaID int, rect media.CropRect) (*media.Media, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &media.Media{ID: 11, URL: "https://cdn/crop.jpg", Variants: map[string]string{"full": "https://cdn/crop.jpg"}}, nil
+				},
+			}
+			h := NewMediaHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/media/"+tt.mediaID+"/crop", bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("mediaID", tt.mediaID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, "user_id", 1))
+
+			rec := httptest.NewRecorder()
+			h.CropMedia(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			switch tt.wantStatus {
+			case http.StatusOK:
+				call := service.CropMediaCalls()[0]
+				assert.Equal(t, 1, call.UserID)
+				assert.Equal(t, 10, call.MediaID)
+				assert.Equal(t, media.CropRect{X: 10, Y: 20, Width: 300, Height: 300}, call.Rect)
+
+				var resp MediaResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 11, resp.ID)
+				assert.Equal(t, "https://cdn/crop.jpg", resp.Variants["full"])
+			case http.StatusUnprocessableEntity:
+				var resp ValidationErrorResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "invalid_crop", resp.Error)
+				assert.Equal(t, "crop", resp.Field)
+			}
+		})
+	}
+}
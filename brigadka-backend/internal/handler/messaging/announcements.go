@@ -0,0 +1,16 @@
+package messaging
+
+import (
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/announcement"
+)
+
+// AnnouncementMessage carries an announcement of the administration to every connected user
+type AnnouncementMessage struct {
+	Type         string                    `json:"type" msgpack:"type"`
+	Announcement announcement.Announcement `json:"announcement" msgpack:"announcement"`
+}
+
+// AnnouncementPosted sends a new announcement to all connected users
+func (h *Handler) AnnouncementPosted(posted announcement.Announcement) {
+	h.deliverToEveryone(newFrame(AnnouncementMessage{Type: MsgTypeAnnouncement, Announcement: posted}))
+}
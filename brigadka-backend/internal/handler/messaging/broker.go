@@ -0,0 +1,227 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/broker"
+)
+
+const (
+	// brokerChannel carries WebSocket deliveries and presence between replicas
+	brokerChannel = "brigadka:ws"
+	// presenceInterval is how often a replica announces the users connected to it
+	presenceInterval = 10 * time.Second
+	// presenceTTL is how long an announcement counts; missing announcements mean the replica is gone
+	presenceTTL = 3 * presenceInterval
+)
+
+// Kinds of broker deliveries
+const (
+	deliveryMessage  = "message"
+	deliveryEveryone = "everyone" // Message for every connected user, UserIDs is empty
+	deliveryPresence = "presence"
+)
+
+// delivery is published to the broker for every replica to act on. Messages are
+// published in every encoding, so no replica re-encodes them for its connections.
+type delivery struct {
+	Kind           string          `json:"kind"`
+	Origin         string          `json:"origin"` // Replica that published the delivery
+	UserIDs        []int           `json:"user_ids"`
+	Message        json.RawMessage `json:"message,omitempty"`
+	MessageMsgpack []byte          `json:"message_msgpack,omitempty"`
+}
+
+// replicaPresence is the set of users connected to another replica
+type replicaPresence struct {
+	users   map[int]struct{}
+	expires time.Time
+}
+
+// brokerState is set when deliveries go through a broker
+type brokerState struct {
+	broker    broker.Broker
+	replicaID string
+
+	mu       sync.RWMutex
+	presence map[string]replicaPresence // By replica ID
+}
+
+// EnableBroker sends WebSocket messages through the broker, so that users connected
+// to other replicas of the service get them, until ctx is done
+func (h *Handler) EnableBroker(ctx context.Context, b broker.Broker) error {
+	state := &brokerState{
+		broker:    b,
+		replicaID: uuid.New().String(),
+		presence:  make(map[string]replicaPresence),
+	}
+	if err := b.Subscribe(ctx, brokerChannel, h.receiveDelivery); err != nil {
+		return err
+	}
+	h.brokerState = state
+
+	go func() {
+		ticker := time.NewTicker(presenceInterval)
+		defer ticker.Stop()
+		for {
+			h.announcePresence(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// deliver sends a message to all connections of the users, on every replica
+func (h *Handler) deliver(userIDs []int, message *frame) {
+	if h.brokerState == nil || len(userIDs) == 0 {
+		h.deliverLocally(userIDs, message)
+		return
+	}
+
+	if err := h.publishMessage(context.Background(), delivery{Kind: deliveryMessage, UserIDs: userIDs}, message); err != nil {
+		log.Printf("Error publishing WebSocket message: %v", err)
+		// Users connected to this replica still get it
+		h.deliverLocally(userIDs, message)
+	}
+}
+
+// deliverLocally sends a message to the connections of the users on this replica
+func (h *Handler) deliverLocally(userIDs []int, message *frame) {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	for _, userID := range userIDs {
+		h.sendToConnections(userID, message)
+	}
+}
+
+// deliverToEveryone sends a message to all connections of all users, on every replica
+func (h *Handler) deliverToEveryone(message *frame) {
+	if h.brokerState == nil {
+		h.deliverToEveryoneLocally(message)
+		return
+	}
+
+	if err := h.publishMessage(context.Background(), delivery{Kind: deliveryEveryone}, message); err != nil {
+		log.Printf("Error publishing WebSocket message: %v", err)
+		h.deliverToEveryoneLocally(message)
+	}
+}
+
+// deliverToEveryoneLocally sends a message to all connections on this replica
+func (h *Handler) deliverToEveryoneLocally(message *frame) {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	for userID := range h.clients {
+		h.sendToConnections(userID, message)
+	}
+}
+
+// publishMessage publishes a delivery carrying the message in every encoding
+func (h *Handler) publishMessage(ctx context.Context, d delivery, message *frame) error {
+	var err error
+	if d.Message, err = message.encode(codecJSON); err != nil {
+		return err
+	}
+	if d.MessageMsgpack, err = message.encode(codecMsgpack); err != nil {
+		return err
+	}
+	return h.publish(ctx, d)
+}
+
+func (h *Handler) publish(ctx context.Context, d delivery) error {
+	d.Origin = h.brokerState.replicaID
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return h.brokerState.broker.Publish(ctx, brokerChannel, data)
+}
+
+// receiveDelivery handles a delivery published by any replica, this one included
+func (h *Handler) receiveDelivery(payload []byte) {
+	var d delivery
+	if err := json.Unmarshal(payload, &d); err != nil {
+		log.Printf("Error parsing broker delivery: %v", err)
+		return
+	}
+
+	switch d.Kind {
+	case deliveryMessage:
+		h.deliverLocally(d.UserIDs, publishedFrame(d.Message, d.MessageMsgpack))
+	case deliveryEveryone:
+		h.deliverToEveryoneLocally(publishedFrame(d.Message, d.MessageMsgpack))
+	case deliveryPresence:
+		if d.Origin == h.brokerState.replicaID {
+			return
+		}
+		users := make(map[int]struct{}, len(d.UserIDs))
+		for _, userID := range d.UserIDs {
+			users[userID] = struct{}{}
+		}
+		h.brokerState.mu.Lock()
+		h.brokerState.presence[d.Origin] = replicaPresence{users: users, expires: time.Now().Add(presenceTTL)}
+		h.brokerState.mu.Unlock()
+	}
+}
+
+// announcePresence tells the other replicas which users are connected to this one
+func (h *Handler) announcePresence(ctx context.Context) {
+	h.clientsMutex.RLock()
+	userIDs := make([]int, 0, len(h.clients))
+	for userID := range h.clients {
+		userIDs = append(userIDs, userID)
+	}
+	h.clientsMutex.RUnlock()
+
+	if err := h.publish(ctx, delivery{Kind: deliveryPresence, UserIDs: userIDs}); err != nil {
+		log.Printf("Error announcing WebSocket presence: %v", err)
+	}
+}
+
+// offlineUsers returns the users connected neither to this replica nor, as far as
+// their latest announcements tell, to another one
+func (h *Handler) offlineUsers(userIDs []int) []int {
+	offline := make([]int, 0, len(userIDs))
+	h.clientsMutex.RLock()
+	for _, userID := range userIDs {
+		if len(h.clients[userID]) == 0 {
+			offline = append(offline, userID)
+		}
+	}
+	h.clientsMutex.RUnlock()
+
+	if h.brokerState == nil || len(offline) == 0 {
+		return offline
+	}
+
+	now := time.Now()
+	h.brokerState.mu.RLock()
+	defer h.brokerState.mu.RUnlock()
+
+	remaining := offline[:0]
+	for _, userID := range offline {
+		online := false
+		for _, replica := range h.brokerState.presence {
+			if _, ok := replica.users[userID]; ok && now.Before(replica.expires) {
+				online = true
+				break
+			}
+		}
+		if !online {
+			remaining = append(remaining, userID)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/broker"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/announcement"
+)
+
+// newReplicas creates two handlers sharing a broker, as two replicas of the service would
+func newReplicas(t *testing.T, service *ServiceMock) (*Handler, *Handler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	b := broker.NewMemory()
+	first, second := newTestHandler(service), newTestHandler(service)
+	assert.NoError(t, first.EnableBroker(ctx, b))
+	assert.NoError(t, second.EnableBroker(ctx, b))
+	return first, second
+}
+
+func TestBrokerDeliversToOtherReplica(t *testing.T) {
+	service := &ServiceMock{
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	first, second := newReplicas(t, service)
+
+	local, remote := &fakeConn{}, &fakeConn{}
+	first.addClient(&Client{conn: local, userID: 1})
+	second.addClient(&Client{conn: remote, userID: 2})
+
+	first.broadcastToChat("c1", newFrame(json.RawMessage(`{"type":"typing"}`)))
+
+	assert.Len(t, local.written, 1)
+	if assert.Len(t, remote.written, 1) {
+		assert.JSONEq(t, `{"type":"typing"}`, string(remote.written[0]))
+	}
+}
+
+func TestBrokerPresenceSkipsUsersOnlineElsewhere(t *testing.T) {
+	first, second := newReplicas(t, &ServiceMock{})
+
+	first.addClient(&Client{conn: &fakeConn{}, userID: 1})
+	second.addClient(&Client{conn: &fakeConn{}, userID: 2})
+	second.announcePresence(context.Background())
+
+	assert.Equal(t, []int{3}, first.offlineUsers([]int{1, 2, 3}))
+	assert.Equal(t, []int{1, 3}, second.offlineUsers([]int{1, 2, 3}))
+}
+
+func TestAnnouncementReachesEveryReplica(t *testing.T) {
+	first, second := newReplicas(t, &ServiceMock{})
+
+	firstConn, secondConn := &fakeConn{}, &fakeConn{}
+	first.addClient(&Client{conn: firstConn, userID: 1})
+	second.addClient(&Client{conn: secondConn, userID: 2})
+
+	first.AnnouncementPosted(announcement.Announcement{ID: "01HZX", Title: "Festival", Body: "Applications are open"})
+
+	for _, conn := range []*fakeConn{firstConn, secondConn} {
+		if assert.Len(t, conn.written, 1) {
+			var msg AnnouncementMessage
+			assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+			assert.Equal(t, MsgTypeAnnouncement, msg.Type)
+			assert.Equal(t, "01HZX", msg.Announcement.ID)
+		}
+	}
+}
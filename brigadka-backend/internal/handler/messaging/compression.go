@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"compress/flate"
+	"errors"
+	"io"
+	"log"
+)
+
+// ErrMessageTooLarge is returned when an inbound message exceeds the limit after decompression
+var ErrMessageTooLarge = errors.New("websocket message too large")
+
+// CompressionConfig configures permessage-deflate on chat WebSockets.
+// Compression is used only with clients that negotiate the extension.
+type CompressionConfig struct {
+	// Level is the flate level from 1 (fastest, smallest buffers) to 9; 0 uses flate.BestSpeed
+	Level int
+	// Threshold is the size in bytes below which messages are sent uncompressed,
+	// since deflate framing outweighs the savings on small events like typing
+	Threshold int
+	// MaxMessageSize limits an inbound message after decompression, so a small
+	// compressed frame cannot inflate into an arbitrarily large buffer. 0 disables the limit.
+	MaxMessageSize int64
+}
+
+// compressor is the part of *websocket.Conn used to control compression
+type compressor interface {
+	EnableWriteCompression(enable bool)
+	SetCompressionLevel(level int) error
+	NextReader() (messageType int, r io.Reader, err error)
+}
+
+// EnableCompression negotiates permessage-deflate with clients that support it
+func (h *Handler) EnableCompression(config CompressionConfig) {
+	if config.Level == 0 {
+		config.Level = flate.BestSpeed
+	}
+	h.upgrader.EnableCompression = true
+	h.compression = &config
+}
+
+// compressingConn compresses outgoing messages of at least threshold bytes
+// and enforces the size limit on decompressed inbound messages
+type compressingConn struct {
+	WSConn
+	raw            compressor
+	threshold      int
+	maxMessageSize int64
+}
+
+// newCompressingConn wraps a connection upgraded with compression enabled
+func newCompressingConn(conn WSConn, raw compressor, config CompressionConfig) *compressingConn {
+	if err := raw.SetCompressionLevel(config.Level); err != nil {
+		log.Printf("Invalid WebSocket compression level %d: %v", config.Level, err)
+	}
+	return &compressingConn{
+		WSConn:         conn,
+		raw:            raw,
+		threshold:      config.Threshold,
+		maxMessageSize: config.MaxMessageSize,
+	}
+}
+
+func (c *compressingConn) ReadMessage() (int, []byte, error) {
+	if c.maxMessageSize <= 0 {
+		return c.WSConn.ReadMessage()
+	}
+
+	messageType, r, err := c.raw.NextReader()
+	if err != nil {
+		return messageType, nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, c.maxMessageSize+1))
+	if err != nil {
+		return messageType, nil, err
+	}
+	if int64(len(data)) > c.maxMessageSize {
+		return messageType, nil, ErrMessageTooLarge
+	}
+	return messageType, data, nil
+}
+
+func (c *compressingConn) WriteMessage(messageType int, data []byte) error {
+	c.raw.EnableWriteCompression(len(data) >= c.threshold)
+	return c.WSConn.WriteMessage(messageType, data)
+}
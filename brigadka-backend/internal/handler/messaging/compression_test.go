@@ -0,0 +1,96 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCompressor records compression toggles and serves a fixed inbound message
+type fakeCompressor struct {
+	compressed []bool
+	level      int
+	inbound    []byte
+}
+
+func (c *fakeCompressor) EnableWriteCompression(enable bool) {
+	c.compressed = append(c.compressed, enable)
+}
+
+func (c *fakeCompressor) SetCompressionLevel(level int) error {
+	c.level = level
+	return nil
+}
+
+func (c *fakeCompressor) NextReader() (int, io.Reader, error) {
+	return websocket.TextMessage, bytes.NewReader(c.inbound), nil
+}
+
+func TestCompressingConnThreshold(t *testing.T) {
+	raw := &fakeCompressor{}
+	conn := newCompressingConn(&fakeConn{}, raw, CompressionConfig{Level: 5, Threshold: 10})
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"a":1}`)))
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"content":"long enough"}`)))
+
+	assert.Equal(t, 5, raw.level)
+	assert.Equal(t, []bool{false, true}, raw.compressed)
+}
+
+func TestCompressingConnMaxMessageSize(t *testing.T) {
+	raw := &fakeCompressor{inbound: []byte("12345")}
+
+	conn := newCompressingConn(&fakeConn{}, raw, CompressionConfig{MaxMessageSize: 5})
+	_, data, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	conn = newCompressingConn(&fakeConn{}, raw, CompressionConfig{MaxMessageSize: 4})
+	_, _, err = conn.ReadMessage()
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestWebSocketNegotiatesCompression(t *testing.T) {
+	h := newTestHandler(&ServiceMock{})
+	h.EnableCompression(CompressionConfig{Threshold: 16, MaxMessageSize: 1 << 10})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocket(w, r.WithContext(context.WithValue(r.Context(), "user_id", 1)))
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	client, resp, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Close()
+	assert.Contains(t, resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
+
+	assert.Eventually(t, func() bool {
+		h.clientsMutex.RLock()
+		defer h.clientsMutex.RUnlock()
+		return len(h.clients[1]) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	_, welcome, err := client.ReadMessage()
+	assert.NoError(t, err)
+	assert.Contains(t, string(welcome), MsgTypeWelcome)
+
+	// A large, repetitive payload goes through compressed and arrives intact
+	payload := []byte(`{"type":"chat_message","content":"` + strings.Repeat("импровизация ", 50) + `"}`)
+	h.sendToUser(1, newFrame(json.RawMessage(payload)))
+
+	_, data, err := client.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, payload, data)
+}
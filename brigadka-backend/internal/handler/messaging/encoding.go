@@ -0,0 +1,117 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Subprotocols offered on chat WebSockets. Clients choose the encoding of frames by
+// listing one in Sec-WebSocket-Protocol; connections that list neither use JSON.
+const (
+	SubprotocolJSON    = "json"
+	SubprotocolMsgpack = "msgpack"
+)
+
+// codec is the encoding of the frames exchanged with one connection
+type codec int
+
+const (
+	codecJSON    codec = iota // JSON in text frames
+	codecMsgpack              // MessagePack in binary frames
+	codecCount
+)
+
+// codecForSubprotocol returns the codec of a connection that negotiated the subprotocol
+func codecForSubprotocol(subprotocol string) codec {
+	if subprotocol == SubprotocolMsgpack {
+		return codecMsgpack
+	}
+	return codecJSON
+}
+
+// messageType returns the WebSocket frame type carrying the encoded frames
+func (c codec) messageType() int {
+	if c == codecMsgpack {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
+func (c codec) marshal(v interface{}) ([]byte, error) {
+	if c != codecMsgpack {
+		return json.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+	encoder := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(encoder)
+	encoder.Reset(&buf)
+	// Fields of service types embedded in frames, e.g. attachments, only have json tags
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c codec) unmarshal(data []byte, v interface{}) error {
+	if c != codecMsgpack {
+		return json.Unmarshal(data, v)
+	}
+
+	decoder := msgpack.GetDecoder()
+	defer msgpack.PutDecoder(decoder)
+	decoder.Reset(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+	return decoder.Decode(v)
+}
+
+// frame is an outgoing message. It is encoded at most once per codec, and the encoded
+// bytes are shared by every connection it is sent to.
+type frame struct {
+	value     interface{}
+	encodings [codecCount]frameEncoding
+}
+
+type frameEncoding struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+func newFrame(value interface{}) *frame {
+	return &frame{value: value}
+}
+
+// errFrameNotEncoded is returned for encodings another replica did not publish
+var errFrameNotEncoded = errors.New("frame was not published in this encoding")
+
+// publishedFrame wraps the encodings of a frame published by another replica
+func publishedFrame(jsonData, msgpackData []byte) *frame {
+	f := &frame{}
+	for c, data := range [codecCount][]byte{jsonData, msgpackData} {
+		encoding := &f.encodings[c]
+		encoding.once.Do(func() {
+			encoding.data = data
+			if data == nil {
+				encoding.err = errFrameNotEncoded
+			}
+		})
+	}
+	return f
+}
+
+// encode returns the frame encoded with the codec
+func (f *frame) encode(c codec) ([]byte, error) {
+	encoding := &f.encodings[c]
+	encoding.once.Do(func() {
+		encoding.data, encoding.err = c.marshal(f.value)
+	})
+	return encoding.data, encoding.err
+}
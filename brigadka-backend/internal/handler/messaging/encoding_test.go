@@ -0,0 +1,215 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// testChatMessage is a chat message as broadcast to the participants
+func testChatMessage() ChatMessage {
+	return ChatMessage{
+		BaseMessage: BaseMessage{Type: MsgTypeChatMessage, ChatID: "c1"},
+		MessageID:   "m1",
+		SenderID:    2,
+		Content:     "Репетиция в четверг в семь, не опаздывайте",
+		SentAt:      time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC),
+		Seq:         42,
+		Attachments: []messaging.Attachment{{MediaID: 7, Type: "image", URL: "https://cdn.example.com/7.jpg"}},
+	}
+}
+
+func TestMsgpackCodecUsesJSONFieldNames(t *testing.T) {
+	msg := testChatMessage()
+	data, err := codecMsgpack.marshal(msg)
+	assert.NoError(t, err)
+
+	var fields map[string]interface{}
+	assert.NoError(t, msgpack.Unmarshal(data, &fields))
+	assert.Equal(t, MsgTypeChatMessage, fields["type"])
+	assert.Equal(t, "c1", fields["chat_id"])
+	assert.Equal(t, msg.SentAt, fields["sent_at"].(time.Time).UTC())
+	assert.NotContains(t, fields, "ciphertext")
+	if attachments, ok := fields["attachments"].([]interface{}); assert.True(t, ok) && assert.Len(t, attachments, 1) {
+		assert.Contains(t, attachments[0], "media_id")
+	}
+
+	var decoded ChatMessage
+	assert.NoError(t, codecMsgpack.unmarshal(data, &decoded))
+	decoded.SentAt = decoded.SentAt.UTC()
+	assert.Equal(t, msg, decoded)
+}
+
+// typedConn records the type of every written frame
+type typedConn struct {
+	fakeConn
+	types []int
+}
+
+func (c *typedConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	c.types = append(c.types, messageType)
+	c.mu.Unlock()
+	return c.fakeConn.WriteMessage(messageType, data)
+}
+
+func TestFrameIsEncodedOncePerCodec(t *testing.T) {
+	h := newTestHandler(&ServiceMock{})
+	phone, tablet, web := &typedConn{}, &typedConn{}, &typedConn{}
+	h.addClient(&Client{conn: phone, userID: 1, codec: codecMsgpack})
+	h.addClient(&Client{conn: tablet, userID: 1, codec: codecMsgpack})
+	h.addClient(&Client{conn: web, userID: 2})
+
+	h.deliverLocally([]int{1, 2}, newFrame(testChatMessage()))
+
+	if assert.Len(t, phone.written, 1) && assert.Len(t, tablet.written, 1) {
+		assert.Equal(t, []int{websocket.BinaryMessage}, phone.types)
+		assert.True(t, &phone.written[0][0] == &tablet.written[0][0], "msgpack connections share the encoded frame")
+	}
+	if assert.Len(t, web.written, 1) {
+		assert.Equal(t, []int{websocket.TextMessage}, web.types)
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(web.written[0], &msg))
+		assert.Equal(t, "m1", msg.MessageID)
+	}
+}
+
+func TestBrokerDeliversMsgpackToOtherReplica(t *testing.T) {
+	first, second := newReplicas(t, &ServiceMock{})
+	remote := &typedConn{}
+	second.addClient(&Client{conn: remote, userID: 2, codec: codecMsgpack})
+
+	first.sendToUser(2, newFrame(testChatMessage()))
+
+	if assert.Len(t, remote.written, 1) {
+		assert.Equal(t, []int{websocket.BinaryMessage}, remote.types)
+		var msg ChatMessage
+		assert.NoError(t, codecMsgpack.unmarshal(remote.written[0], &msg))
+		assert.Equal(t, int64(42), msg.Seq)
+	}
+}
+
+func TestMsgpackClientFrames(t *testing.T) {
+	var mu sync.Mutex
+	var stored []string
+	service := &ServiceMock{
+		IsUserInChatFunc: func(userID int, chatID string) (bool, error) {
+			return true, nil
+		},
+		StoreTypingIndicatorFunc: func(userID int, chatID string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stored = append(stored, chatID)
+			return nil
+		},
+		GetChatParticipantsFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+	other := &fakeConn{}
+	h.addClient(&Client{conn: other, userID: 2})
+
+	typing, err := msgpack.Marshal(map[string]interface{}{"type": MsgTypeTyping, "chat_id": "c1", "is_typing": true})
+	assert.NoError(t, err)
+	// The malformed frame is skipped
+	h.handleWSConnection(&scriptedConn{frames: [][]byte{{0xc1}, typing}}, 1, codecMsgpack)
+
+	assert.Eventually(t, func() bool {
+		other.mu.Lock()
+		defer other.mu.Unlock()
+		return len(other.written) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, []string{"c1"}, stored)
+	mu.Unlock()
+
+	var msg TypingMessage
+	assert.NoError(t, json.Unmarshal(other.written[0], &msg))
+	assert.Equal(t, 1, msg.UserID)
+	assert.True(t, msg.IsTyping)
+}
+
+func TestWebSocketNegotiatesMsgpack(t *testing.T) {
+	h := newTestHandler(&ServiceMock{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocket(w, r.WithContext(context.WithValue(r.Context(), "user_id", 1)))
+	}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dialer := websocket.Dialer{Subprotocols: []string{SubprotocolMsgpack}}
+	client, resp, err := dialer.Dial(url, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Close()
+	assert.Equal(t, SubprotocolMsgpack, resp.Header.Get("Sec-Websocket-Protocol"))
+
+	messageType, welcome, err := client.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	var decoded WelcomeMessage
+	assert.NoError(t, codecMsgpack.unmarshal(welcome, &decoded))
+	assert.Equal(t, MsgTypeWelcome, decoded.Type)
+	assert.False(t, decoded.ServerTime.IsZero())
+
+	// Clients that ask for no subprotocol keep getting JSON text frames
+	plain, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer plain.Close()
+	assert.Empty(t, resp.Header.Get("Sec-Websocket-Protocol"))
+	plain.SetReadDeadline(time.Now().Add(time.Second))
+	messageType, welcome, err = plain.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	assert.Contains(t, string(welcome), MsgTypeWelcome)
+}
+
+// BenchmarkCodec encodes a chat message, as every broadcast does once per codec,
+// and decodes it, as for every frame a client sends
+func BenchmarkCodec(b *testing.B) {
+	msg := testChatMessage()
+	for _, bc := range []struct {
+		name  string
+		codec codec
+	}{{"json", codecJSON}, {"msgpack", codecMsgpack}} {
+		data, err := bc.codec.marshal(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(bc.name+"/encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(len(data)), "frame-bytes")
+			for i := 0; i < b.N; i++ {
+				if _, err := bc.codec.marshal(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bc.name+"/decode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var decoded ChatMessage
+				if err := bc.codec.unmarshal(data, &decoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package messaging
+
+import (
+	"sync"
+	"time"
+)
+
+// WebSocket event directions
+const (
+	EventDirectionIn  = "in"
+	EventDirectionOut = "out"
+)
+
+// Connection lifecycle event types recorded alongside protocol messages
+const (
+	EventTypeConnect    = "connect"
+	EventTypeDisconnect = "disconnect"
+)
+
+// WSEvent describes a single WebSocket frame without its content
+type WSEvent struct {
+	Direction string    `json:"direction"`
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+const (
+	// eventLogShards is the number of independently locked parts of the event log,
+	// so that connections of different users rarely wait for one another
+	eventLogShards = 16
+	// eventLogIdleTTL is how long the events of a user are kept after their last frame
+	eventLogIdleTTL = time.Hour
+)
+
+// EventLog keeps a fixed-size ring buffer of recent WebSocket events per user.
+// Users without frames for eventLogIdleTTL are dropped, so the log holds only
+// recently connected users. Each replica keeps the events of its own connections.
+type EventLog struct {
+	capacity int
+	idleTTL  time.Duration
+	now      func() time.Time
+	shards   [eventLogShards]eventLogShard
+}
+
+// eventLogShard holds the buffers of the users whose ID falls into it
+type eventLogShard struct {
+	mu        sync.Mutex
+	buffers   map[int]*eventRing
+	lastSweep time.Time
+}
+
+type eventRing struct {
+	events     []WSEvent
+	next       int
+	full       bool
+	recordedAt time.Time // When the last event was recorded
+}
+
+// NewEventLog creates an event log keeping up to capacity events per user
+func NewEventLog(capacity int) *EventLog {
+	l := &EventLog{
+		capacity: capacity,
+		idleTTL:  eventLogIdleTTL,
+		now:      time.Now,
+	}
+	for i := range l.shards {
+		l.shards[i].buffers = make(map[int]*eventRing)
+	}
+	return l
+}
+
+func (l *EventLog) shard(userID int) *eventLogShard {
+	return &l.shards[uint(userID)%eventLogShards]
+}
+
+// Record stores an event for the user, overwriting the oldest one when the buffer is full
+func (l *EventLog) Record(userID int, event WSEvent) {
+	if l == nil || l.capacity <= 0 {
+		return
+	}
+
+	now := l.now()
+	shard := l.shard(userID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	// Idle users are dropped at most once per TTL, so the sweep costs little per event
+	if now.Sub(shard.lastSweep) >= l.idleTTL {
+		for id, ring := range shard.buffers {
+			if now.Sub(ring.recordedAt) >= l.idleTTL {
+				delete(shard.buffers, id)
+			}
+		}
+		shard.lastSweep = now
+	}
+
+	ring, ok := shard.buffers[userID]
+	if !ok {
+		ring = &eventRing{events: make([]WSEvent, l.capacity)}
+		shard.buffers[userID] = ring
+	}
+
+	ring.events[ring.next] = event
+	ring.next = (ring.next + 1) % l.capacity
+	if ring.next == 0 {
+		ring.full = true
+	}
+	ring.recordedAt = now
+}
+
+// Events returns the recorded events for the user, oldest first
+func (l *EventLog) Events(userID int) []WSEvent {
+	if l == nil {
+		return []WSEvent{}
+	}
+
+	shard := l.shard(userID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	ring, ok := shard.buffers[userID]
+	if !ok || l.now().Sub(ring.recordedAt) >= l.idleTTL {
+		return []WSEvent{}
+	}
+
+	if !ring.full {
+		return append([]WSEvent{}, ring.events[:ring.next]...)
+	}
+	events := make([]WSEvent, 0, l.capacity)
+	events = append(events, ring.events[ring.next:]...)
+	return append(events, ring.events[:ring.next]...)
+}
+
+// recordFrame records a protocol frame, extracting only its message type
+func (l *EventLog) recordFrame(userID int, direction string, codec codec, data []byte) {
+	if l == nil || l.capacity <= 0 {
+		return
+	}
+
+	var baseMsg BaseMessage
+	if err := codec.unmarshal(data, &baseMsg); err != nil || baseMsg.Type == "" {
+		baseMsg.Type = "unknown"
+	}
+
+	l.Record(userID, WSEvent{
+		Direction: direction,
+		Type:      baseMsg.Type,
+		Size:      len(data),
+		Timestamp: time.Now(),
+	})
+}
+
+// loggingConn records every frame read from or written to the wrapped connection
+type loggingConn struct {
+	WSConn
+	log    *EventLog
+	userID int
+	codec  codec
+}
+
+func (c *loggingConn) ReadMessage() (int, []byte, error) {
+	messageType, data, err := c.WSConn.ReadMessage()
+	if err == nil {
+		c.log.recordFrame(c.userID, EventDirectionIn, c.codec, data)
+	}
+	return messageType, data, err
+}
+
+func (c *loggingConn) WriteMessage(messageType int, data []byte) error {
+	err := c.WSConn.WriteMessage(messageType, data)
+	if err == nil {
+		c.log.recordFrame(c.userID, EventDirectionOut, c.codec, data)
+	}
+	return err
+}
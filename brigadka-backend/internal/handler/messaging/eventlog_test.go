@@ -0,0 +1,97 @@
+package messaging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+)
+
+func TestEventLogKeepsLatestEvents(t *testing.T) {
+	l := NewEventLog(3)
+	for i := 1; i <= 5; i++ {
+		l.Record(1, WSEvent{Type: MsgTypeTyping, Size: i, Timestamp: time.Now()})
+	}
+
+	events := l.Events(1)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, 3, events[0].Size)
+		assert.Equal(t, 5, events[2].Size)
+	}
+	assert.Empty(t, l.Events(2))
+}
+
+func TestEventLogDropsIdleUsers(t *testing.T) {
+	now := time.Now()
+	l := NewEventLog(3)
+	l.now = func() time.Time { return now }
+
+	l.Record(1, WSEvent{Type: MsgTypeTyping})
+	l.Record(1+eventLogShards, WSEvent{Type: MsgTypeTyping}) // Same shard
+
+	now = now.Add(eventLogIdleTTL / 2)
+	l.Record(1, WSEvent{Type: MsgTypeTyping})
+
+	now = now.Add(eventLogIdleTTL / 2)
+	assert.Empty(t, l.Events(1+eventLogShards))
+	assert.Len(t, l.Events(1), 2)
+
+	l.Record(1, WSEvent{Type: MsgTypeTyping})
+	shard := l.shard(1)
+	assert.Len(t, shard.buffers, 1, "the idle user is swept on the next event in the shard")
+	assert.Len(t, l.Events(1), 3)
+}
+
+func TestLoggingConnRecordsFrames(t *testing.T) {
+	l := NewEventLog(10)
+	conn := &loggingConn{WSConn: &fakeConn{}, log: l, userID: 1}
+
+	data := []byte(`{"type":"chat_message","chat_id":"c1","content":"secret"}`)
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, data))
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+
+	events := l.Events(1)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, EventDirectionOut, events[0].Direction)
+		assert.Equal(t, MsgTypeChatMessage, events[0].Type)
+		assert.Equal(t, len(data), events[0].Size)
+		assert.Equal(t, "unknown", events[1].Type)
+	}
+}
+
+func TestGetUserWSEvents(t *testing.T) {
+	h := newTestHandler(&ServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.GetUserWSEvents(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/ws-events/1", nil, 99, map[string]string{"userID": "1"}))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	h.EnableEventLog(10)
+	h.handleWSConnection(&fakeConn{}, 1, codecJSON)
+
+	assert.Eventually(t, func() bool {
+		return len(h.eventLog.Events(1)) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	rec = httptest.NewRecorder()
+	h.GetUserWSEvents(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/ws-events/1", nil, 99, map[string]string{"userID": "1"}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var events []WSEvent
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&events))
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, EventTypeConnect, events[0].Type)
+		assert.Equal(t, MsgTypeWelcome, events[1].Type)
+		assert.Equal(t, EventTypeDisconnect, events[2].Type)
+	}
+
+	rec = httptest.NewRecorder()
+	h.GetUserWSEvents(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/ws-events/x", nil, 99, map[string]string{"userID": "x"}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,208 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// Chat export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatText = "text"
+)
+
+// @Summary      Экспорт чата
+// @Description  Выгружает все сообщения чата, от старых к новым, в JSON (чат и массив messages) или в текстовом виде. Ответ передается потоком по мере чтения сообщений. Доступно участникам чата
+// @Tags         messaging
+// @Produce      json
+// @Produce      plain
+// @Param        chatID path string true "ID чата"
+// @Param        format query string false "Формат: json (по умолчанию) или text"
+// @Security     BearerAuth
+// @Success      200 {file} file "Архив чата"
+// @Failure      400 {string} string "Неизвестный формат"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/export [get]
+func (h *Handler) ExportChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ExportFormatJSON
+	}
+
+	export := &chatExport{w: w, chatID: chatID, exportedAt: time.Now().UTC()}
+	switch format {
+	case ExportFormatJSON:
+		export.encoder = jsonExportEncoder{}
+	case ExportFormatText:
+		export.encoder = textExportEncoder{}
+	default:
+		http.Error(w, "Unknown format", http.StatusBadRequest)
+		return
+	}
+
+	err := h.messagineService.ExportChat(r.Context(), userID, chatID, export)
+	if err == nil {
+		err = export.finish()
+	}
+	if err == nil {
+		return
+	}
+
+	// Once the export started the status is sent; the client gets a truncated file
+	if export.started {
+		log.Printf("Error exporting chat %s: %v", chatID, err)
+		return
+	}
+	if err.Error() == apierrors.ErrorUserNotInChat {
+		http.Error(w, "Chat not found", http.StatusNotFound)
+		return
+	}
+	log.Printf("Error exporting chat %s: %v", chatID, err)
+	http.Error(w, "Server error", http.StatusInternalServerError)
+}
+
+// exportEncoder writes an export in one format
+type exportEncoder interface {
+	contentType() string
+	extension() string
+	header(w io.Writer, chat *messaging.Chat, exportedAt time.Time) error
+	message(w io.Writer, msg messaging.ExportedMessage, first bool) error
+	footer(w io.Writer) error
+}
+
+// chatExport streams an export to the response, flushing after every page of messages
+type chatExport struct {
+	w          http.ResponseWriter
+	encoder    exportEncoder
+	chatID     string
+	exportedAt time.Time
+	started    bool
+	written    int // Messages written so far
+}
+
+func (e *chatExport) WriteChat(chat *messaging.Chat) error {
+	e.w.Header().Set("Content-Type", e.encoder.contentType())
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "chat-"+e.chatID+e.encoder.extension()))
+	e.w.WriteHeader(http.StatusOK)
+	e.started = true
+	return e.encoder.header(e.w, chat, e.exportedAt)
+}
+
+func (e *chatExport) WriteMessages(messages []messaging.ExportedMessage) error {
+	for _, msg := range messages {
+		if err := e.encoder.message(e.w, msg, e.written == 0); err != nil {
+			return err
+		}
+		e.written++
+	}
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (e *chatExport) finish() error {
+	return e.encoder.footer(e.w)
+}
+
+// jsonExportEncoder writes {"chat": ..., "exported_at": ..., "messages": [...]}
+type jsonExportEncoder struct{}
+
+func (jsonExportEncoder) contentType() string { return "application/json" }
+func (jsonExportEncoder) extension() string   { return ".json" }
+
+func (jsonExportEncoder) header(w io.Writer, chat *messaging.Chat, exportedAt time.Time) error {
+	chatData, err := json.Marshal(chat)
+	if err != nil {
+		return err
+	}
+	timeData, err := json.Marshal(exportedAt)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `{"chat":%s,"exported_at":%s,"messages":[`, chatData, timeData)
+	return err
+}
+
+func (jsonExportEncoder) message(w io.Writer, msg messaging.ExportedMessage, first bool) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if !first {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (jsonExportEncoder) footer(w io.Writer) error {
+	_, err := io.WriteString(w, "]}\n")
+	return err
+}
+
+// textExportEncoder writes a readable transcript, one message per paragraph
+type textExportEncoder struct{}
+
+// textTimeLayout formats message times in the transcript; times are in UTC
+const textTimeLayout = "2006-01-02 15:04"
+
+func (textExportEncoder) contentType() string { return "text/plain; charset=utf-8" }
+func (textExportEncoder) extension() string   { return ".txt" }
+
+func (textExportEncoder) header(w io.Writer, chat *messaging.Chat, exportedAt time.Time) error {
+	name := "Chat"
+	if chat.ChatName != nil && *chat.ChatName != "" {
+		name = *chat.ChatName
+	}
+	_, err := fmt.Fprintf(w, "%s\nExported %s UTC\n", name, exportedAt.Format(textTimeLayout))
+	return err
+}
+
+func (textExportEncoder) message(w io.Writer, msg messaging.ExportedMessage, first bool) error {
+	sender := msg.SenderName
+	if sender == "" {
+		sender = fmt.Sprintf("User %d", msg.SenderID)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n[%s] %s:\n", msg.SentAt.UTC().Format(textTimeLayout), sender)
+	if msg.Content != "" {
+		b.WriteString(msg.Content)
+		b.WriteString("\n")
+	}
+	// Only the participants' devices can decrypt the message
+	if msg.Ciphertext != "" {
+		b.WriteString("[encrypted message]\n")
+	}
+	for _, attachment := range msg.Attachments {
+		fmt.Fprintf(&b, "[%s] %s\n", attachment.Type, attachment.URL)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (textExportEncoder) footer(w io.Writer) error {
+	return nil
+}
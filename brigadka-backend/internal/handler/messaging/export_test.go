@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// exportingService plays an export of two pages of messages
+func exportingService() *ServiceMock {
+	sentAt := time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC)
+	name := "Импро-команда"
+	return &ServiceMock{
+		ExportChatFunc: func(ctx context.Context, userID int, chatID string, w messaging.ChatExportWriter) error {
+			if userID != 1 {
+				return errors.New(apierrors.ErrorUserNotInChat)
+			}
+			if err := w.WriteChat(&messagingrepo.Chat{ChatID: chatID, ChatName: &name, IsGroup: true}); err != nil {
+				return err
+			}
+			if err := w.WriteMessages([]messaging.ExportedMessage{
+				{ChatMessage: messagingrepo.ChatMessage{MessageID: "m1", ChatID: chatID, SenderID: 1, Content: "Репетиция в субботу", SentAt: sentAt, Seq: 1}, SenderName: "Anna"},
+			}); err != nil {
+				return err
+			}
+			return w.WriteMessages([]messaging.ExportedMessage{
+				{ChatMessage: messagingrepo.ChatMessage{MessageID: "m2", ChatID: chatID, SenderID: 2, SentAt: sentAt.Add(time.Minute), Seq: 2,
+					Attachments: []messagingrepo.Attachment{{MediaID: 7, Type: "image", URL: "https://cdn/7.jpg"}}}},
+			})
+		},
+	}
+}
+
+func TestExportChatJSON(t *testing.T) {
+	h := newTestHandler(exportingService())
+
+	rec := httptest.NewRecorder()
+	h.ExportChat(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1/export", nil, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="chat-c1.json"`, rec.Header().Get("Content-Disposition"))
+
+	var archive struct {
+		Chat       messagingrepo.Chat          `json:"chat"`
+		ExportedAt time.Time                   `json:"exported_at"`
+		Messages   []messaging.ExportedMessage `json:"messages"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&archive))
+	assert.Equal(t, "Импро-команда", *archive.Chat.ChatName)
+	assert.False(t, archive.ExportedAt.IsZero())
+	if assert.Len(t, archive.Messages, 2) {
+		assert.Equal(t, "m1", archive.Messages[0].MessageID)
+		assert.Equal(t, "Anna", archive.Messages[0].SenderName)
+		assert.Equal(t, "https://cdn/7.jpg", archive.Messages[1].Attachments[0].URL)
+	}
+}
+
+func TestExportChatText(t *testing.T) {
+	h := newTestHandler(exportingService())
+
+	rec := httptest.NewRecorder()
+	h.ExportChat(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1/export?format=text", nil, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="chat-c1.txt"`, rec.Header().Get("Content-Disposition"))
+	assert.Regexp(t, `^Импро-команда\nExported \d{4}-\d\d-\d\d \d\d:\d\d UTC\n`+
+		`\n\[2025-03-01 18:30\] Anna:\nРепетиция в субботу\n`+
+		`\n\[2025-03-01 18:31\] User 2:\n\[image\] https://cdn/7.jpg\n$`, rec.Body.String())
+}
+
+func TestExportChatErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		query      string
+		wantStatus int
+	}{
+		{"not a participant", 2, "", http.StatusNotFound},
+		{"unknown format", 1, "?format=pdf", http.StatusBadRequest},
+		{"unauthorized", 0, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(exportingService())
+
+			rec := httptest.NewRecorder()
+			h.ExportChat(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1/export"+tt.query, nil, tt.userID, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Empty(t, rec.Header().Get("Content-Disposition"))
+		})
+	}
+}
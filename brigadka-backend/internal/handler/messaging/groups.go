@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// UpdateChatRequest holds the group chat fields to change; omitted fields are kept
+type UpdateChatRequest struct {
+	ChatName    *string `json:"chat_name,omitempty"`
+	Description *string `json:"description,omitempty"` // Empty removes the description
+	Avatar      *int    `json:"avatar,omitempty"`      // Media ID of an image, 0 removes the avatar
+}
+
+// @Summary      Покинуть групповой чат
+// @Description  Удаляет текущего пользователя из группового чата. Если уходит последний администратор, администратором становится самый давний участник
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400 {string} string "Чат не групповой"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/leave [post]
+func (h *Handler) LeaveChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.messagineService.LeaveChat(r.Context(), userID, chi.URLParam(r, "chatID")); err != nil {
+		respondGroupError(w, err, "leaving chat")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Изменить групповой чат
+// @Description  Меняет название, описание и аватар группового чата. Доступно администраторам чата. Пустое описание и аватар 0 удаляют их. Участники получают по WebSocket событие chat_updated
+// @Tags         messaging
+// @Accept       json
+// @Produce      json
+// @Param        chatID path string true "ID чата"
+// @Param        request body UpdateChatRequest true "Изменяемые поля"
+// @Security     BearerAuth
+// @Success      200 {object} messaging.Chat
+// @Failure      400 {string} string "Некорректные данные или чат не групповой"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Пользователь не администратор чата"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID} [patch]
+func (h *Handler) UpdateChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req UpdateChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+	chat, err := h.messagineService.UpdateChat(r.Context(), userID, chatID, messaging.ChatUpdate{
+		Name:          req.ChatName,
+		Description:   req.Description,
+		AvatarMediaID: req.Avatar,
+	})
+	if err != nil {
+		respondGroupError(w, err, "updating chat")
+		return
+	}
+
+	h.broadcastToChat(chatID, newFrame(ChatUpdatedMessage{
+		BaseMessage: BaseMessage{Type: MsgTypeChatUpdated, ChatID: chatID},
+		ChatName:    chat.ChatName,
+		Description: chat.Description,
+		Avatar:      chat.Avatar,
+		UpdatedBy:   userID,
+	}))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(chat)
+}
+
+// @Summary      Назначить администратора чата
+// @Description  Делает участника группового чата администратором. Доступно администраторам чата
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Param        userID path int true "ID участника"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400 {string} string "Некорректный ID или чат не групповой"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Пользователь не администратор чата"
+// @Failure      404 {string} string "Чат или участник не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/participants/{userID}/admin [post]
+func (h *Handler) PromoteToAdmin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	targetUserID, err := parseInt(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.messagineService.PromoteToAdmin(r.Context(), userID, chi.URLParam(r, "chatID"), targetUserID); err != nil {
+		respondGroupError(w, err, "promoting admin")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondGroupError writes the response for an error of a group chat action
+func respondGroupError(w http.ResponseWriter, err error, action string) {
+	switch err.Error() {
+	case apierrors.ErrorUserNotInChat, apierrors.ErrorChatNotFound:
+		http.Error(w, "Chat not found", http.StatusNotFound)
+	case apierrors.ErrorParticipantNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case apierrors.ErrorNotChatAdmin:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case apierrors.ErrorNotGroupChat, apierrors.ErrorInvalidChatName,
+		apierrors.ErrorInvalidChatDescription, apierrors.ErrorInvalidChatAvatar:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error %s: %v", action, err)
+	}
+}
@@ -0,0 +1,181 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+func TestLeaveChat(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"direct chat", errors.New(apierrors.ErrorNotGroupChat), http.StatusBadRequest},
+		{"not in chat", errors.New(apierrors.ErrorUserNotInChat), http.StatusNotFound},
+		{"server error", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				LeaveChatFunc: func(ctx context.Context, userID int, chatID string) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.LeaveChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/leave", nil, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if assert.Len(t, service.LeaveChatCalls(), 1) {
+				assert.Equal(t, 1, service.LeaveChatCalls()[0].UserID)
+				assert.Equal(t, "c1", service.LeaveChatCalls()[0].ChatID)
+			}
+		})
+	}
+}
+
+func TestRemoveParticipant(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "2", nil, http.StatusOK},
+		{"not admin", "2", errors.New(apierrors.ErrorNotChatAdmin), http.StatusForbidden},
+		{"not a participant", "9", errors.New(apierrors.ErrorParticipantNotFound), http.StatusNotFound},
+		{"invalid user ID", "abc", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				RemoveMemberFunc: func(ctx context.Context, adminID int, chatID string, userID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.RemoveParticipant(rec, handlertest.NewRequest(http.MethodDelete, "/api/chats/c1/participants/"+tt.target, nil, 1, map[string]string{"chatID": "c1", "userID": tt.target}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				assert.Empty(t, service.RemoveMemberCalls())
+				return
+			}
+			call := service.RemoveMemberCalls()[0]
+			assert.Equal(t, 1, call.AdminID)
+			assert.Equal(t, "c1", call.ChatID)
+		})
+	}
+}
+
+func TestUpdateChat(t *testing.T) {
+	name, description, avatar := "Jam", "", 7
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", UpdateChatRequest{ChatName: &name, Description: &description, Avatar: &avatar}, nil, http.StatusOK},
+		{"invalid name", UpdateChatRequest{ChatName: &name}, errors.New(apierrors.ErrorInvalidChatName), http.StatusBadRequest},
+		{"invalid avatar", UpdateChatRequest{Avatar: &avatar}, errors.New(apierrors.ErrorInvalidChatAvatar), http.StatusBadRequest},
+		{"not admin", UpdateChatRequest{ChatName: &name}, errors.New(apierrors.ErrorNotChatAdmin), http.StatusForbidden},
+		{"invalid body", nil, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				UpdateChatFunc: func(ctx context.Context, adminID int, chatID string, update messagingrepo.ChatUpdate) (*messagingrepo.Chat, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &messagingrepo.Chat{
+						ChatID:   chatID,
+						ChatName: update.Name,
+						IsGroup:  true,
+						Avatar:   &messagingrepo.ChatAvatar{MediaID: *update.AvatarMediaID, URL: "https://cdn/7.jpg"},
+					}, nil
+				},
+				GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+					return []int{1, 2}, nil
+				},
+			}
+			h := newTestHandler(service)
+
+			conn := &fakeConn{}
+			h.addClient(&Client{conn: conn, userID: 2})
+
+			rec := httptest.NewRecorder()
+			h.UpdateChat(rec, handlertest.NewRequest(http.MethodPatch, "/api/chats/c1", tt.body, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.body == nil {
+				assert.Empty(t, service.UpdateChatCalls())
+				return
+			}
+
+			req := tt.body.(UpdateChatRequest)
+			call := service.UpdateChatCalls()[0]
+			assert.Equal(t, 1, call.AdminID)
+			assert.Equal(t, req.ChatName, call.Update.Name)
+			assert.Equal(t, req.Description, call.Update.Description)
+			assert.Equal(t, req.Avatar, call.Update.AvatarMediaID)
+
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, conn.written)
+				return
+			}
+
+			var chat messagingrepo.Chat
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&chat))
+			assert.Equal(t, "Jam", *chat.ChatName)
+
+			if assert.Len(t, conn.written, 1) {
+				var msg ChatUpdatedMessage
+				assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+				assert.Equal(t, MsgTypeChatUpdated, msg.Type)
+				assert.Equal(t, "c1", msg.ChatID)
+				assert.Equal(t, "Jam", *msg.ChatName)
+				assert.Equal(t, 7, msg.Avatar.MediaID)
+				assert.Equal(t, 1, msg.UpdatedBy)
+			}
+		})
+	}
+}
+
+func TestPromoteToAdmin(t *testing.T) {
+	service := &ServiceMock{
+		PromoteToAdminFunc: func(ctx context.Context, adminID int, chatID string, userID int) error {
+			return nil
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.PromoteToAdmin(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/participants/2/admin", nil, 1, map[string]string{"chatID": "c1", "userID": "2"}))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	if assert.Len(t, service.PromoteToAdminCalls(), 1) {
+		call := service.PromoteToAdminCalls()[0]
+		assert.Equal(t, 1, call.AdminID)
+		assert.Equal(t, 2, call.UserID)
+	}
+}
@@ -3,7 +3,9 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
@@ -12,12 +14,20 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
 )
 
+//go:generate moq -out mocks_test.go . Service PushService ProfileService
+
+// Service is the messaging service, declared here so its mock is generated with the others
+type Service interface {
+	messaging.Service
+}
+
 type PushService interface {
 	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
 }
@@ -27,12 +37,17 @@ type ProfileService interface {
 }
 
 type Handler struct {
-	messagineService messaging.Service
+	messagineService Service
 	profileService   ProfileService
 	pushService      PushService
 	upgrader         websocket.Upgrader
-	clients          map[int]*Client // Map of userID to client connection
+	clients          map[int]map[*Client]struct{} // Connections of each user, e.g. from a phone and a tablet
 	clientsMutex     sync.RWMutex
+	eventLog         *EventLog          // Optional log of recent WebSocket events, nil when disabled
+	compression      *CompressionConfig // Set when permessage-deflate is enabled
+	heartbeat        *HeartbeatConfig   // Set when keepalive pings are enabled
+	brokerState      *brokerState       // Set when deliveries go through a broker to other replicas
+	connections      connectionCounters
 }
 
 // CreateChatRequest представляет запрос на создание чата
@@ -55,14 +70,30 @@ type AddReactionRequest struct {
 
 // SendMessageRequest представляет запрос на отправку сообщения
 type SendMessageRequest struct {
-	MessageID string `json:"message_id"`
-	Content   string `json:"content"`
+	MessageID   string `json:"message_id"`
+	Content     string `json:"content"`     // May be empty when there are attachments
+	Attachments []int  `json:"attachments"` // IDs of photos and videos uploaded by the sender
+	// End-to-end encrypted payload sent instead of content and attachments in direct chats
+	Ciphertext string `json:"ciphertext,omitempty"`
 }
 
 type GetOrCreateDirectChatRequest struct {
 	UserID int `json:"user_id"`
 }
 
+// ParticipantLimitResponse is returned with 409 when a group chat would exceed its participant limit
+type ParticipantLimitResponse struct {
+	Error        string `json:"error"`
+	Limit        int    `json:"limit"`
+	Participants int    `json:"participants"`
+}
+
+// RateLimitResponse is returned with 429 when the sender exceeded the message rate limit of the chat
+type RateLimitResponse struct {
+	Error      string `json:"error"`
+	RetryAfter int    `json:"retry_after"` // Seconds until the message can be resent
+}
+
 type ChatIDResponse struct {
 	ChatID string `json:"chat_id"`
 }
@@ -81,9 +112,14 @@ type WSConn interface {
 type Client struct {
 	conn   WSConn
 	userID int
+	codec  codec // Encoding of the frames exchanged with the client
+
+	mu        sync.Mutex
+	replaying bool     // Live messages are held back while missed ones are replayed
+	pending   []*frame // Live messages held back during the replay
 }
 
-func NewHandler(messagineService messaging.Service, profileService ProfileService, pushService PushService) *Handler {
+func NewHandler(messagineService Service, profileService ProfileService, pushService PushService) *Handler {
 	return &Handler{
 		messagineService: messagineService,
 		profileService:   profileService,
@@ -91,12 +127,30 @@ func NewHandler(messagineService messaging.Service, profileService ProfileServic
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
+			Subprotocols:    []string{SubprotocolJSON, SubprotocolMsgpack},
 			CheckOrigin: func(r *http.Request) bool {
 				return true // In production, implement proper origin check
 			},
 		},
-		clients: make(map[int]*Client),
+		clients: make(map[int]map[*Client]struct{}),
+	}
+}
+
+// EnableEventLog starts recording up to capacity recent WebSocket events per user
+func (h *Handler) EnableEventLog(capacity int) {
+	h.eventLog = NewEventLog(capacity)
+}
+
+// ActiveConnections returns the number of open WebSocket connections of all users
+func (h *Handler) ActiveConnections() int {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	count := 0
+	for _, connections := range h.clients {
+		count += len(connections)
 	}
+	return count
 }
 
 // Reaction structure
@@ -109,7 +163,7 @@ type Reaction struct {
 }
 
 // @Summary      Веб-сокет для чата
-// @Description  Устанавливает WebSocket соединение для обмена сообщениями в реальном времени
+// @Description  Устанавливает WebSocket соединение для обмена сообщениями в реальном времени. Кодировка выбирается заголовком Sec-WebSocket-Protocol: json (по умолчанию) или msgpack — тогда кадры передаются в MessagePack бинарными кадрами
 // @Tags         messaging
 // @Accept       json
 // @Produce      json
@@ -132,11 +186,19 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.handleWSConnection(conn, userID)
+	var wsConn WSConn = conn
+	if h.compression != nil {
+		wsConn = newCompressingConn(conn, conn, *h.compression)
+	}
+	if h.heartbeat != nil {
+		wsConn = newHeartbeatConn(wsConn, conn, *h.heartbeat)
+	}
+
+	h.handleWSConnection(wsConn, userID, codecForSubprotocol(conn.Subprotocol()))
 }
 
 // @Summary      Создать новый чат
-// @Description  Создает новый чат с указанными участниками
+// @Description  Создает новый чат с указанными участниками. Для участников, чьи настройки личных сообщений не разрешают связь от создателя, чат становится запросом
 // @Tags         messaging
 // @Accept       json
 // @Produce      json
@@ -145,7 +207,8 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 // @Success      201 {object} ChatIDResponse "Чат успешно создан"
 // @Failure      400 {string} string "Некорректный запрос"
 // @Failure      401 {string} string "Unauthorized"
-// @Failure      409 {string} string "Чат с таким ID уже существует"
+// @Failure      403 {string} string "Участник не разрешил связь от организаторов"
+// @Failure      409 {object} ParticipantLimitResponse "Чат с таким ID уже существует или превышен лимит участников"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats [post]
 func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
@@ -173,10 +236,17 @@ func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 	err := h.messagineService.CreateChat(r.Context(), req.ChatID, userID, req.ChatName, req.Participants)
 	if err != nil {
 		// Check if it's a duplicate chat (UUID constraint violation)
-		if isPrimaryKeyViolation(err) {
+		if database.IsUniqueViolation(err) {
 			http.Error(w, apierrors.ErrorChatAlreadyExistsWithThisID, http.StatusConflict)
 			return
 		}
+		if respondParticipantLimit(w, err) {
+			return
+		}
+		if err.Error() == apierrors.ErrorOrganizerContactNotAllowed {
+			http.Error(w, apierrors.ErrorOrganizerContactNotAllowed, http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Server error", http.StatusInternalServerError)
 		log.Printf("Error creating chat: %v", err)
 		return
@@ -202,6 +272,7 @@ func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 // @Success      200 {object} ChatIDResponse "ID чата"
 // @Failure      400 {string} string "Некорректный запрос или попытка создать чат с самим собой"
 // @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Пользователь не разрешил связь от организаторов"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats/direct [post]
 // GetOrCreateDirectChat finds an existing direct chat or creates a new one
@@ -227,6 +298,10 @@ func (h *Handler) GetOrCreateDirectChat(w http.ResponseWriter, r *http.Request)
 			http.Error(w, apierrors.ErrorCannotCreateChatWithSelf, http.StatusBadRequest)
 			return
 		}
+		if err.Error() == apierrors.ErrorOrganizerContactNotAllowed {
+			http.Error(w, apierrors.ErrorOrganizerContactNotAllowed, http.StatusForbidden)
+			return
+		}
 
 		http.Error(w, "Server error", http.StatusInternalServerError)
 		log.Printf("Error getting/creating direct chat: %v", err)
@@ -247,7 +322,7 @@ func (h *Handler) GetOrCreateDirectChat(w http.ResponseWriter, r *http.Request)
 // @Tags         messaging
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {array} messaging.Chat "Список чатов пользователя"
+// @Success      200 {array} messaging.Chat "Список чатов пользователя с числом непрочитанных сообщений"
 // @Failure      401 {string} string "Unauthorized"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats [get]
@@ -311,15 +386,26 @@ func (h *Handler) GetChat(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(chat)
 }
 
+// Response headers of GetChatMessages with the cursors of the neighbouring pages
+const (
+	BeforeSeqHeader = "X-Before-Seq"
+	AfterSeqHeader  = "X-After-Seq"
+)
+
 // @Summary      Получить сообщения чата
-// @Description  Возвращает сообщения чата с поддержкой пагинации
+// @Description  Возвращает сообщения чата, начиная с новых. Страницы выбираются курсорами before_seq (более старые сообщения) и after_seq (более новые); курсоры соседних страниц возвращаются в заголовках X-Before-Seq и X-After-Seq. Параметр offset устарел и будет удален в следующем релизе
 // @Tags         messaging
 // @Produce      json
 // @Param        chatID path string true "ID чата"
 // @Param        limit query int false "Максимальное количество сообщений (по умолчанию 50)"
-// @Param        offset query int false "Смещение (по умолчанию 0)"
+// @Param        before_seq query int false "Вернуть сообщения старше сообщения с этим seq"
+// @Param        after_seq query int false "Вернуть сообщения новее сообщения с этим seq"
+// @Param        offset query int false "Смещение (устарело, используйте before_seq)"
 // @Security     BearerAuth
 // @Success      200 {array} messaging.ChatMessage "Сообщения чата"
+// @Header       200 {integer} X-Before-Seq "before_seq для более старых сообщений; отсутствует, если их нет"
+// @Header       200 {integer} X-After-Seq "after_seq для более новых сообщений"
+// @Failure      400 {string} string "Некорректный курсор"
 // @Failure      401 {string} string "Unauthorized"
 // @Failure      404 {string} string "Чат не найден"
 // @Failure      500 {string} string "Ошибка сервера"
@@ -336,8 +422,9 @@ func (h *Handler) GetChatMessages(w http.ResponseWriter, r *http.Request) {
 	chatID := chi.URLParam(r, "chatID")
 
 	// Get pagination parameters
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	query := r.URL.Query()
+	limitStr := query.Get("limit")
+	offsetStr := query.Get("offset")
 
 	limit := 50 // Default
 	offset := 0 // Default
@@ -355,8 +442,37 @@ func (h *Handler) GetChatMessages(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get messages
-	messages, err := h.messagineService.GetChatMessages(chatID, userID, limit, offset)
+	var cursor messaging.MessageCursor
+	for param, seq := range map[string]*int64{"before_seq": &cursor.BeforeSeq, "after_seq": &cursor.AfterSeq} {
+		if !query.Has(param) {
+			continue
+		}
+		val, err := strconv.ParseInt(query.Get(param), 10, 64)
+		if err != nil || val <= 0 {
+			http.Error(w, "Invalid "+param, http.StatusBadRequest)
+			return
+		}
+		*seq = val
+	}
+
+	// Clients paging by offset keep getting offset pages until the parameter is removed
+	var messages []messaging.ChatMessage
+	var err error
+	if query.Has("offset") && cursor == (messaging.MessageCursor{}) {
+		messages, err = h.messagineService.GetChatMessages(chatID, userID, limit, offset)
+	} else {
+		var page *messaging.MessagePage
+		page, err = h.messagineService.GetChatMessagePage(chatID, userID, cursor, limit)
+		if err == nil {
+			messages = page.Messages
+			if page.BeforeSeq != nil {
+				w.Header().Set(BeforeSeqHeader, strconv.FormatInt(*page.BeforeSeq, 10))
+			}
+			if page.AfterSeq != nil {
+				w.Header().Set(AfterSeqHeader, strconv.FormatInt(*page.AfterSeq, 10))
+			}
+		}
+	}
 	if err != nil {
 		if err.Error() == apierrors.ErrorUserNotInChat {
 			http.Error(w, "Chat not found", http.StatusNotFound)
@@ -373,7 +489,7 @@ func (h *Handler) GetChatMessages(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Добавить участника в чат
-// @Description  Добавляет нового участника в существующий чат
+// @Description  Добавляет нового участника в существующий чат. Если настройки личных сообщений участника не разрешают связь от добавившего, чат становится для него запросом
 // @Tags         messaging
 // @Accept       json
 // @Produce      json
@@ -383,7 +499,9 @@ func (h *Handler) GetChatMessages(w http.ResponseWriter, r *http.Request) {
 // @Success      201 {string} string "Участник успешно добавлен"
 // @Failure      400 {string} string "Некорректный запрос"
 // @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Пользователь не разрешил связь от организаторов"
 // @Failure      404 {string} string "Чат не найден"
+// @Failure      409 {object} ParticipantLimitResponse "Превышен лимит участников"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats/{chatID}/participants [post]
 func (h *Handler) AddParticipant(w http.ResponseWriter, r *http.Request) {
@@ -417,7 +535,14 @@ func (h *Handler) AddParticipant(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Add new participant
-	if err := h.messagineService.AddParticipant(chatID, req.UserID); err != nil {
+	if err := h.messagineService.AddMember(r.Context(), userID, chatID, req.UserID); err != nil {
+		if respondParticipantLimit(w, err) {
+			return
+		}
+		if err.Error() == apierrors.ErrorOrganizerContactNotAllowed {
+			http.Error(w, apierrors.ErrorOrganizerContactNotAllowed, http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Server error", http.StatusInternalServerError)
 		log.Printf("Error adding participant: %v", err)
 		return
@@ -428,17 +553,17 @@ func (h *Handler) AddParticipant(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Удалить участника из чата
-// @Description  Удаляет участника из чата (пользователь может удалить только себя)
+// @Description  Удаляет участника из группового чата. Администраторы могут удалять любых участников, остальные — только себя (как /leave)
 // @Tags         messaging
 // @Produce      json
 // @Param        chatID path string true "ID чата"
 // @Param        userID path int true "ID пользователя для удаления"
 // @Security     BearerAuth
 // @Success      200 {string} string "Участник успешно удален"
-// @Failure      400 {string} string "Некорректный запрос"
+// @Failure      400 {string} string "Некорректный запрос или чат не групповой"
 // @Failure      401 {string} string "Unauthorized"
 // @Failure      403 {string} string "Нет прав на удаление этого пользователя"
-// @Failure      404 {string} string "Чат не найден"
+// @Failure      404 {string} string "Чат или участник не найден"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats/{chatID}/participants/{userID} [delete]
 func (h *Handler) RemoveParticipant(w http.ResponseWriter, r *http.Request) {
@@ -458,30 +583,9 @@ func (h *Handler) RemoveParticipant(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if the current user is in the chat
-	inChat, err := h.messagineService.IsUserInChat(userID, chatID)
-	if err != nil {
-		http.Error(w, "Server error", http.StatusInternalServerError)
-		log.Printf("Error checking chat participation: %v", err)
-		return
-	}
-	if !inChat {
-		http.Error(w, "Chat not found", http.StatusNotFound)
-		return
-	}
-
-	// Allow users to remove themselves, or check if target is the current user
-	if userID != targetUserID {
-		// In a real app, check if user has permission to remove others (admin/creator)
-		// For simplicity, we'll allow any participant to remove others
-		http.Error(w, "Not authorized to remove this user", http.StatusForbidden)
-		return
-	}
-
-	// Remove participant
-	if err := h.messagineService.RemoveParticipant(chatID, targetUserID); err != nil {
-		http.Error(w, "Server error", http.StatusInternalServerError)
-		log.Printf("Error removing participant: %v", err)
+	// Remove participant; only admins may remove others
+	if err := h.messagineService.RemoveMember(r.Context(), userID, chatID, targetUserID); err != nil {
+		respondGroupError(w, err, "removing participant")
 		return
 	}
 
@@ -526,7 +630,7 @@ func (h *Handler) AddReaction(w http.ResponseWriter, r *http.Request) {
 	err := h.messagineService.AddReaction(req.ReactionID, messageID, userID, req.ReactionCode)
 	if err != nil {
 		// Check if it's a duplicate reaction (UUID constraint violation)
-		if isPrimaryKeyViolation(err) {
+		if database.IsUniqueViolation(err) {
 			http.Error(w, apierrors.ErrorReactionAlreadyExists, http.StatusConflict)
 			return
 		}
@@ -550,7 +654,7 @@ func (h *Handler) AddReaction(w http.ResponseWriter, r *http.Request) {
 		// Continue to return success even if we can't broadcast
 	} else {
 		// Broadcast reaction to chat participants
-		msgData, _ := json.Marshal(ReactionMessage{
+		h.broadcastToChat(chatID, newFrame(ReactionMessage{
 			BaseMessage: BaseMessage{
 				Type:   MsgTypeReaction,
 				ChatID: chatID,
@@ -560,9 +664,7 @@ func (h *Handler) AddReaction(w http.ResponseWriter, r *http.Request) {
 			UserID:       userID,
 			ReactionCode: req.ReactionCode,
 			ReactedAt:    time.Now(),
-		})
-
-		h.broadcastToChat(chatID, msgData)
+		}))
 	}
 
 	// Return success
@@ -570,6 +672,40 @@ func (h *Handler) AddReaction(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(AddReactionResponse{ReactionID: req.ReactionID})
 }
 
+// @Summary      Реакции на сообщение
+// @Description  Возвращает все реакции на сообщение, от ранних к поздним, для подробного списка. Доступно участникам чата
+// @Tags         messaging
+// @Produce      json
+// @Param        messageID path string true "ID сообщения"
+// @Security     BearerAuth
+// @Success      200 {array} messaging.Reaction "Реакции"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Сообщение не найдено"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /messages/{messageID}/reactions [get]
+func (h *Handler) GetMessageReactions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	messageID := chi.URLParam(r, "messageID")
+	reactions, err := h.messagineService.GetMessageReactions(r.Context(), userID, messageID)
+	if err != nil {
+		if err.Error() == apierrors.ErrorMessageNotFound {
+			http.Error(w, "Message not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting reactions of message %s: %v", messageID, err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reactions)
+}
+
 // @Summary      Удалить реакцию с сообщения
 // @Description  Удаляет эмоциональную реакцию с сообщения
 // @Tags         messaging
@@ -610,7 +746,7 @@ func (h *Handler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
 
 	// Broadcast reaction removal if we have a chat ID
 	if chatID != "" {
-		msgData, _ := json.Marshal(ReactionRemovedMessage{
+		h.broadcastToChat(chatID, newFrame(ReactionRemovedMessage{
 			BaseMessage: BaseMessage{
 				Type:   MsgTypeRemoveReaction,
 				ChatID: chatID,
@@ -619,9 +755,7 @@ func (h *Handler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
 			UserID:       userID,
 			ReactionCode: reactionCode,
 			RemovedAt:    time.Now(),
-		})
-
-		h.broadcastToChat(chatID, msgData)
+		}))
 	}
 
 	// Return success
@@ -631,7 +765,7 @@ func (h *Handler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Отправить сообщение
-// @Description  Отправляет новое сообщение в чат
+// @Description  Отправляет новое сообщение в чат. К сообщению можно приложить до 10 фото и видео, загруженных отправителем; текст сообщения с вложениями может быть пустым. В личных чатах вместо текста и вложений можно отправить шифротекст (ciphertext) сквозного шифрования. Повторная отправка сообщения с тем же ID возвращает сохраненное сообщение без повторной рассылки
 // @Tags         messaging
 // @Accept       json
 // @Produce      json
@@ -639,10 +773,11 @@ func (h *Handler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
 // @Param        request body SendMessageRequest true "Данные сообщения"
 // @Security     BearerAuth
 // @Success      200 {object} ChatMessage "Сообщение успешно отправлено"
-// @Failure      400 {string} string "Некорректный запрос"
+// @Failure      400 {string} string "Некорректный запрос, пустое сообщение, недопустимые вложения или шифротекст"
 // @Failure      401 {string} string "Unauthorized"
 // @Failure      404 {string} string "Чат не найден"
-// @Failure      409 {string} string "Сообщение с таким ID уже существует"
+// @Failure      409 {string} string "Сообщение с таким ID уже отправлено другим пользователем"
+// @Failure      429 {object} RateLimitResponse "Превышен лимит сообщений в чат; повторить через retry_after секунд"
 // @Failure      500 {string} string "Ошибка сервера"
 // @Router       /chats/{chatID}/messages [post]
 func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
@@ -664,22 +799,33 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Store message
-	sentAt, err := h.messagineService.AddMessage(req.MessageID, chatID, userID, req.Content)
+	stored, err := h.storeMessage(req.MessageID, chatID, userID, req.Content, req.Ciphertext, req.Attachments)
+	// A resend of a stored message returns it again
+	var dupErr *messaging.DuplicateMessageError
+	duplicate := errors.As(err, &dupErr)
+	if duplicate {
+		stored, err = dupErr.Message, nil
+	}
 	if err != nil {
-		// Check if it's a duplicate message (UUID constraint violation)
-		if isPrimaryKeyViolation(err) {
+		// The ID is taken by another sender
+		if database.IsUniqueViolation(err) {
 			http.Error(w, apierrors.ErrorMessageAlreadyExists, http.StatusConflict)
 			return
 		}
-
-		// Check for user not in chat
-		if err.Error() == apierrors.ErrorUserNotInChat {
-			http.Error(w, "Chat not found", http.StatusNotFound)
+		if respondRateLimited(w, err) {
 			return
 		}
 
-		http.Error(w, "Server error", http.StatusInternalServerError)
-		log.Printf("Error storing message: %v", err)
+		switch err.Error() {
+		case apierrors.ErrorUserNotInChat:
+			http.Error(w, "Chat not found", http.StatusNotFound)
+		case apierrors.ErrorEmptyMessage, apierrors.ErrorInvalidAttachment,
+			apierrors.ErrorInvalidCiphertext, apierrors.ErrorEncryptedGroupChat:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Server error", http.StatusInternalServerError)
+			log.Printf("Error storing message: %v", err)
+		}
 		return
 	}
 
@@ -687,40 +833,223 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	wsMsg := ChatMessage{
 		BaseMessage: BaseMessage{
 			Type:   MsgTypeChatMessage,
-			ChatID: chatID,
+			ChatID: stored.ChatID,
 		},
-		MessageID: req.MessageID,
-		SenderID:  userID,
-		Content:   req.Content,
-		SentAt:    sentAt,
+		MessageID:   stored.MessageID,
+		SenderID:    stored.SenderID,
+		Content:     stored.Content,
+		Ciphertext:  stored.Ciphertext,
+		SentAt:      stored.SentAt,
+		Seq:         stored.Seq,
+		Attachments: stored.Attachments,
+		Kind:        stored.Kind,
 	}
 
-	msgData, _ := json.Marshal(wsMsg)
-
-	// Broadcast message to all participants in the chat
-	h.broadcastToChat(chatID, msgData)
+	// Broadcast message to all participants in the chat; they already got a resent one
+	if !duplicate {
+		h.broadcastToChat(stored.ChatID, newFrame(wsMsg))
+	}
 
 	// Return success with message details
 	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(wsMsg)
 }
 
-// Helper function to parse int from string
-func parseInt(s string) (int, error) {
-	return strconv.Atoi(s)
+// @Summary      WebSocket events of a user
+// @Description  Returns recent WebSocket events (direction, type, size, time) of a user without message content. Admin only. Each replica keeps its own log, so with several replicas only the events of connections to the replica answering the request are returned.
+// @Tags         admin
+// @Produce      json
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      200  {array}   WSEvent
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Event log is disabled"
+// @Router       /admin/ws-events/{userID} [get]
+func (h *Handler) GetUserWSEvents(w http.ResponseWriter, r *http.Request) {
+	if h.eventLog == nil {
+		http.Error(w, "Event log is disabled", http.StatusNotFound)
+		return
+	}
+
+	userID, err := parseInt(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.eventLog.Events(userID))
+}
+
+// MarkReadResponse lists the chats whose read position changed
+type MarkReadResponse struct {
+	Chats []messaging.ReadState `json:"chats"`
+}
+
+// @Summary      Прочитать все чаты
+// @Description  Отмечает все чаты пользователя прочитанными до последнего сообщения
+// @Tags         messaging
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} MarkReadResponse "Чаты, в которых изменилась позиция прочтения"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/read-all [post]
+func (h *Handler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	states, err := h.messagineService.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error marking chats as read: %v", err)
+		return
+	}
+
+	h.respondReadStates(w, userID, states)
+}
+
+// @Summary      Прочитать чат
+// @Description  Отмечает чат прочитанным до последнего сообщения
+// @Tags         messaging
+// @Produce      json
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      200 {object} MarkReadResponse "Чат, если позиция прочтения изменилась"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/read-all [post]
+func (h *Handler) MarkChatRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+
+	states, err := h.messagineService.MarkChatRead(r.Context(), userID, chatID)
+	if err != nil {
+		if err.Error() == apierrors.ErrorUserNotInChat {
+			http.Error(w, "Chat not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Server error", http.StatusInternalServerError)
+			log.Printf("Error marking chat as read: %v", err)
+		}
+		return
+	}
+
+	h.respondReadStates(w, userID, states)
 }
 
-// Helper function to check if error is a primary key violation
-func isPrimaryKeyViolation(err error) bool {
-	// This implementation will depend on the specific database driver
-	// For PostgreSQL, the error message contains "duplicate key value violates unique constraint"
-	if err == nil {
+// MarkReadRequest is the last message the user has read in a chat
+type MarkReadRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// @Summary      Отметить прочтение
+// @Description  Сохраняет позицию прочтения чата до указанного сообщения и уведомляет остальных участников. Позиция не сдвигается назад
+// @Tags         messaging
+// @Accept       json
+// @Produce      json
+// @Param        chatID path string true "ID чата"
+// @Param        request body MarkReadRequest true "Последнее прочитанное сообщение"
+// @Security     BearerAuth
+// @Success      200 {object} MarkReadResponse "Чат с числом непрочитанных, если позиция прочтения изменилась"
+// @Failure      400 {string} string "Invalid request"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат или сообщение не найдены"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+
+	var req MarkReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MessageID == "" {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	state, err := h.messagineService.StoreReadReceipt(userID, chatID, req.MessageID)
+	if err != nil {
+		switch err.Error() {
+		case apierrors.ErrorUserNotInChat:
+			http.Error(w, "Chat not found", http.StatusNotFound)
+		case apierrors.ErrorMessageNotFound:
+			http.Error(w, "Message not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Server error", http.StatusInternalServerError)
+			log.Printf("Error storing read receipt: %v", err)
+		}
+		return
+	}
+
+	h.readReceiptStored(userID, chatID, req.MessageID, state)
+
+	states := []messaging.ReadState{}
+	if state != nil {
+		states = append(states, *state)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MarkReadResponse{Chats: states})
+}
+
+// respondReadStates writes the changed read states and pushes the new unread
+// counters to the user's WebSocket connection so other devices stay in sync
+func (h *Handler) respondReadStates(w http.ResponseWriter, userID int, states []messaging.ReadState) {
+	h.syncReadStates(userID, states)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MarkReadResponse{Chats: states})
+}
+
+// respondParticipantLimit writes a 409 response when err is a participant limit error and reports whether it did
+func respondParticipantLimit(w http.ResponseWriter, err error) bool {
+	var limitErr *messaging.ParticipantLimitError
+	if !errors.As(err, &limitErr) {
 		return false
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(ParticipantLimitResponse{
+		Error:        "participant_limit_reached",
+		Limit:        limitErr.Limit,
+		Participants: limitErr.Participants,
+	})
+	return true
+}
+
+// respondRateLimited writes a 429 response when err is a rate limit error and reports whether it did
+func respondRateLimited(w http.ResponseWriter, err error) bool {
+	var limitErr *messaging.RateLimitError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+	retryAfter := retryAfterSeconds(limitErr)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(RateLimitResponse{Error: "rate_limited", RetryAfter: retryAfter})
+	return true
+}
+
+// retryAfterSeconds rounds the wait up to whole seconds, so a client waiting that long is not limited again
+func retryAfterSeconds(err *messaging.RateLimitError) int {
+	return int(math.Ceil(err.RetryAfter.Seconds()))
+}
 
-	errMsg := err.Error()
-	return (errMsg != "" &&
-		(errMsg == "pq: duplicate key value violates unique constraint" ||
-			errMsg == "UNIQUE constraint failed" ||
-			errMsg == "Duplicate entry" ||
-			errMsg == "duplicate key value violates unique constraint"))
+// Helper function to parse int from string
+func parseInt(s string) (int, error) {
+	return strconv.Atoi(s)
 }
@@ -0,0 +1,836 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// fakeConn records messages written to a WebSocket client
+type fakeConn struct {
+	mu      sync.Mutex
+	written [][]byte
+}
+
+func (c *fakeConn) ReadMessage() (int, []byte, error) {
+	return 0, nil, errors.New("closed")
+}
+
+func (c *fakeConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = append(c.written, data)
+	return nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func newTestHandler(service *ServiceMock) *Handler {
+	return NewHandler(service, &ProfileServiceMock{}, &PushServiceMock{})
+}
+
+func TestCreateChat(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		body       CreateChatRequest
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, CreateChatRequest{ChatID: "c1", ChatName: "Team", Participants: []int{2}}, nil, http.StatusCreated},
+		{"unauthorized", 0, CreateChatRequest{ChatID: "c1", Participants: []int{2}}, nil, http.StatusUnauthorized},
+		{"no participants", 1, CreateChatRequest{ChatID: "c1"}, nil, http.StatusBadRequest},
+		{"duplicate", 1, CreateChatRequest{ChatID: "c1", Participants: []int{2}}, &pq.Error{Code: database.UniqueViolation}, http.StatusConflict},
+		{"too many participants", 1, CreateChatRequest{ChatID: "c1", Participants: []int{2}}, &messaging.ParticipantLimitError{Limit: 50, Participants: 51}, http.StatusConflict},
+		{"server error", 1, CreateChatRequest{ChatID: "c1", Participants: []int{2}}, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				CreateChatFunc: func(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.CreateChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats", tt.body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var resp ChatIDResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "c1", resp.ChatID)
+				assert.Equal(t, 1, service.CreateChatCalls()[0].CreatorID)
+			}
+		})
+	}
+}
+
+func TestGetOrCreateDirectChatWithSelf(t *testing.T) {
+	service := &ServiceMock{
+		GetOrCreateDirectChatFunc: func(ctx context.Context, userID1 int, userID2 int) (string, error) {
+			return "", errors.New(apierrors.ErrorCannotCreateChatWithSelf)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetOrCreateDirectChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/direct", GetOrCreateDirectChatRequest{UserID: 1}, 1, nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestOrganizerContactNotAllowed(t *testing.T) {
+	notAllowed := errors.New(apierrors.ErrorOrganizerContactNotAllowed)
+	service := &ServiceMock{
+		CreateChatFunc: func(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error {
+			return notAllowed
+		},
+		GetOrCreateDirectChatFunc: func(ctx context.Context, userID1 int, userID2 int) (string, error) {
+			return "", notAllowed
+		},
+		IsUserInChatFunc: func(userID int, chatID string) (bool, error) {
+			return true, nil
+		},
+		AddMemberFunc: func(ctx context.Context, actorID int, chatID string, userID int) error {
+			return notAllowed
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.CreateChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats", CreateChatRequest{ChatID: "c1", ChatName: "Jam", Participants: []int{1, 2}}, 1, nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.GetOrCreateDirectChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/direct", GetOrCreateDirectChatRequest{UserID: 2}, 1, nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.AddParticipant(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/participants", AddParticipantRequest{UserID: 2}, 1, map[string]string{"chatID": "c1"}))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestGetChatNotParticipant(t *testing.T) {
+	service := &ServiceMock{
+		GetChatFunc: func(chatID string, userID int) (*messagingrepo.Chat, error) {
+			return nil, errors.New(apierrors.ErrorUserNotInChat)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetChat(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1", nil, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetChatMessagesPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantOffset int
+	}{
+		{"custom", "?limit=10&offset=20", 10, 20},
+		{"invalid values ignored", "?limit=-1&offset=abc", 50, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				GetChatMessagesFunc: func(chatID string, userID int, limit int, offset int) ([]messagingrepo.ChatMessage, error) {
+					return []messagingrepo.ChatMessage{}, nil
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetChatMessages(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1/messages"+tt.query, nil, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			call := service.GetChatMessagesCalls()[0]
+			assert.Equal(t, tt.wantLimit, call.Limit)
+			assert.Equal(t, tt.wantOffset, call.Offset)
+		})
+	}
+}
+
+func TestGetChatMessagesCursor(t *testing.T) {
+	seq := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name          string
+		query         string
+		page          *messagingrepo.MessagePage
+		wantStatus    int
+		wantCursor    messagingrepo.MessageCursor
+		wantLimit     int
+		wantBeforeSeq string
+		wantAfterSeq  string
+	}{
+		{
+			name:          "newest page",
+			query:         "",
+			page:          &messagingrepo.MessagePage{Messages: []messagingrepo.ChatMessage{{MessageID: "m9", Seq: 9}}, BeforeSeq: seq(9), AfterSeq: seq(9)},
+			wantStatus:    http.StatusOK,
+			wantLimit:     50,
+			wantBeforeSeq: "9",
+			wantAfterSeq:  "9",
+		},
+		{
+			name:         "before seq",
+			query:        "?before_seq=9&limit=20",
+			page:         &messagingrepo.MessagePage{Messages: []messagingrepo.ChatMessage{{MessageID: "m8", Seq: 8}}, AfterSeq: seq(8)},
+			wantStatus:   http.StatusOK,
+			wantCursor:   messagingrepo.MessageCursor{BeforeSeq: 9},
+			wantLimit:    20,
+			wantAfterSeq: "8",
+		},
+		{
+			name:         "after seq wins over offset",
+			query:        "?after_seq=9&offset=10",
+			page:         &messagingrepo.MessagePage{Messages: []messagingrepo.ChatMessage{}, AfterSeq: seq(9)},
+			wantStatus:   http.StatusOK,
+			wantCursor:   messagingrepo.MessageCursor{AfterSeq: 9},
+			wantLimit:    50,
+			wantAfterSeq: "9",
+		},
+		{
+			name:       "invalid cursor",
+			query:      "?before_seq=abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "zero cursor",
+			query:      "?after_seq=0",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				GetChatMessagePageFunc: func(chatID string, userID int, cursor messagingrepo.MessageCursor, limit int) (*messagingrepo.MessagePage, error) {
+					return tt.page, nil
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetChatMessages(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/c1/messages"+tt.query, nil, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Empty(t, service.GetChatMessagesCalls())
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, service.GetChatMessagePageCalls())
+				return
+			}
+
+			call := service.GetChatMessagePageCalls()[0]
+			assert.Equal(t, tt.wantCursor, call.Cursor)
+			assert.Equal(t, tt.wantLimit, call.Limit)
+			assert.Equal(t, tt.wantBeforeSeq, rec.Header().Get(BeforeSeqHeader))
+			assert.Equal(t, tt.wantAfterSeq, rec.Header().Get(AfterSeqHeader))
+
+			var messages []messagingrepo.ChatMessage
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&messages))
+			assert.Len(t, messages, len(tt.page.Messages))
+		})
+	}
+}
+
+func TestAddParticipantRequiresMembership(t *testing.T) {
+	service := &ServiceMock{
+		IsUserInChatFunc: func(userID int, chatID string) (bool, error) {
+			return false, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.AddParticipant(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/participants", AddParticipantRequest{UserID: 3}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, service.AddMemberCalls())
+}
+
+func TestAddParticipantLimitReached(t *testing.T) {
+	service := &ServiceMock{
+		IsUserInChatFunc: func(userID int, chatID string) (bool, error) {
+			return true, nil
+		},
+		AddMemberFunc: func(ctx context.Context, actorID int, chatID string, userID int) error {
+			return &messaging.ParticipantLimitError{Limit: 50, Participants: 51}
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.AddParticipant(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/participants", AddParticipantRequest{UserID: 3}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var resp ParticipantLimitResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, ParticipantLimitResponse{Error: "participant_limit_reached", Limit: 50, Participants: 51}, resp)
+}
+
+func TestSendMessageBroadcastsToOnlineParticipants(t *testing.T) {
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return &messagingrepo.ChatMessage{MessageID: messageID, ChatID: chatID, SenderID: senderID, Content: content, SentAt: sentAt}, nil
+		},
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, conn.written, 1) {
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, "m1", msg.MessageID)
+		assert.Equal(t, 1, msg.SenderID)
+	}
+}
+
+func TestWelcomeFrame(t *testing.T) {
+	h := newTestHandler(&ServiceMock{})
+	conn := &fakeConn{}
+
+	before := time.Now()
+	h.handleWSConnection(conn, 1, codecJSON)
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if assert.NotEmpty(t, conn.written) {
+		var msg WelcomeMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, MsgTypeWelcome, msg.Type)
+		assert.False(t, msg.ServerTime.Before(before.Truncate(time.Microsecond)))
+	}
+}
+
+func TestMessagePostedBroadcastsBotMessage(t *testing.T) {
+	service := &ServiceMock{
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	h.MessagePosted(messagingrepo.ChatMessage{MessageID: "m1", ChatID: "c1", SenderID: 1, Content: "Hi!"})
+
+	if assert.Len(t, conn.written, 1) {
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, MsgTypeChatMessage, msg.Type)
+		assert.Equal(t, "c1", msg.ChatID)
+		assert.Equal(t, 1, msg.SenderID)
+		assert.Equal(t, "Hi!", msg.Content)
+	}
+}
+
+func TestPreviewReadyBroadcastsPreview(t *testing.T) {
+	service := &ServiceMock{
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	preview := messagingrepo.LinkPreview{URL: "https://example.com", Title: "Example"}
+	h.PreviewReady("c1", "m1", preview)
+
+	if assert.Len(t, conn.written, 1) {
+		var msg MessagePreviewReadyMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, MessagePreviewReadyMessage{
+			BaseMessage: BaseMessage{Type: MsgTypePreviewReady, ChatID: "c1"},
+			MessageID:   "m1",
+			Preview:     preview,
+		}, msg)
+	}
+}
+
+func TestMessagePostedBroadcastsSystemMessage(t *testing.T) {
+	service := &ServiceMock{
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	target := 3
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	h.MessagePosted(messagingrepo.ChatMessage{
+		MessageID:    "m1",
+		ChatID:       "c1",
+		SenderID:     1,
+		Content:      "Anna added Boris",
+		SentAt:       sentAt,
+		Seq:          7,
+		Kind:         messagingrepo.MessageKindSystem,
+		Event:        messagingrepo.SystemEventParticipantAdded,
+		TargetUserID: &target,
+	})
+
+	if assert.Len(t, conn.written, 1) {
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, ChatMessage{
+			BaseMessage:  BaseMessage{Type: MsgTypeChatMessage, ChatID: "c1"},
+			MessageID:    "m1",
+			SenderID:     1,
+			Content:      "Anna added Boris",
+			SentAt:       sentAt,
+			Seq:          7,
+			Kind:         "system",
+			Event:        "participant_added",
+			TargetUserID: &target,
+		}, msg)
+	}
+}
+
+func TestSendMessageResendReturnsStoredMessage(t *testing.T) {
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return nil, &messaging.DuplicateMessageError{Message: &messagingrepo.ChatMessage{
+				MessageID: messageID, ChatID: chatID, SenderID: senderID, Content: "hi", SentAt: sentAt, Seq: 7,
+			}}
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var msg ChatMessage
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&msg))
+	assert.Equal(t, int64(7), msg.Seq)
+	assert.True(t, sentAt.Equal(msg.SentAt))
+	// Participants already got the message the first time
+	assert.Empty(t, conn.written)
+	assert.Empty(t, service.GetChatParticipantsForBroadcastCalls())
+}
+
+func TestSendMessageIDTakenByAnotherSender(t *testing.T) {
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return nil, &pq.Error{Code: database.UniqueViolation}
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSendMessageNotParticipant(t *testing.T) {
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return nil, errors.New(apierrors.ErrorUserNotInChat)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, service.GetChatParticipantsForBroadcastCalls())
+}
+
+func TestSendMessageRateLimited(t *testing.T) {
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return nil, &messaging.RateLimitError{RetryAfter: 2300 * time.Millisecond}
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+	var resp RateLimitResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, RateLimitResponse{Error: "rate_limited", RetryAfter: 3}, resp)
+	assert.Empty(t, service.GetChatParticipantsForBroadcastCalls())
+}
+
+func TestSendMessageWithAttachments(t *testing.T) {
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+			return &messagingrepo.ChatMessage{
+				MessageID: messageID, ChatID: chatID, SenderID: senderID, SentAt: sentAt,
+				Attachments: []messagingrepo.Attachment{{MediaID: 7, Type: "image", URL: "https://cdn/7.jpg", ThumbnailURL: "https://cdn/7_thumb.jpg"}},
+			}, nil
+		},
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Attachments: []int{7}}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, service.AddMessageWithAttachmentsCalls(), 1) {
+		assert.Equal(t, []int{7}, service.AddMessageWithAttachmentsCalls()[0].MediaIDs)
+	}
+
+	var resp ChatMessage
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp.Attachments, 1) {
+		assert.Equal(t, "https://cdn/7.jpg", resp.Attachments[0].URL)
+	}
+
+	if assert.Len(t, conn.written, 1) {
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		if assert.Len(t, msg.Attachments, 1) {
+			assert.Equal(t, 7, msg.Attachments[0].MediaID)
+		}
+	}
+}
+
+func TestSendMessageRejectsInvalidMessage(t *testing.T) {
+	for _, code := range []string{apierrors.ErrorEmptyMessage, apierrors.ErrorInvalidAttachment} {
+		t.Run(code, func(t *testing.T) {
+			service := &ServiceMock{
+				AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+					return nil, errors.New(code)
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Attachments: []int{7}}, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), code)
+			assert.Empty(t, service.GetChatParticipantsForBroadcastCalls())
+		})
+	}
+}
+
+func TestSendEncryptedMessage(t *testing.T) {
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		AddEncryptedMessageFunc: func(ctx context.Context, messageID string, chatID string, senderID int, ciphertext string) (*messagingrepo.ChatMessage, error) {
+			return &messagingrepo.ChatMessage{MessageID: messageID, ChatID: chatID, SenderID: senderID, Ciphertext: ciphertext, SentAt: sentAt}, nil
+		},
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	conn := &fakeConn{}
+	h.addClient(&Client{conn: conn, userID: 2})
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Ciphertext: "b3BhcXVl"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, service.AddMessageWithAttachmentsCalls())
+	if assert.Len(t, conn.written, 1) {
+		var msg ChatMessage
+		assert.NoError(t, json.Unmarshal(conn.written[0], &msg))
+		assert.Equal(t, "b3BhcXVl", msg.Ciphertext)
+		assert.Empty(t, msg.Content)
+	}
+}
+
+func TestSendEncryptedMessageRejectsContent(t *testing.T) {
+	service := &ServiceMock{}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.SendMessage(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/messages", SendMessageRequest{MessageID: "m1", Content: "hi", Ciphertext: "b3BhcXVl"}, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), apierrors.ErrorInvalidCiphertext)
+	assert.Empty(t, service.AddEncryptedMessageCalls())
+}
+
+func TestMarkAllReadNotifiesOwnConnection(t *testing.T) {
+	service := &ServiceMock{
+		MarkAllReadFunc: func(ctx context.Context, userID int) ([]messagingrepo.ReadState, error) {
+			return []messagingrepo.ReadState{{ChatID: "c1", LastReadSeq: 7}, {ChatID: "c2", LastReadSeq: 3, UnreadCount: 1}}, nil
+		},
+		GetUnreadCountsFunc: func(ctx context.Context, chatID string, userIDs []int) (map[int]messagingrepo.UnreadCounts, error) {
+			return map[int]messagingrepo.UnreadCounts{1: {Chat: 0, Total: 1}}, nil
+		},
+	}
+	payloads := make(chan push.NotificationPayload, 1)
+	pushService := &PushServiceMock{
+		SendNotificationFunc: func(ctx context.Context, userID int, payload push.NotificationPayload) error {
+			assert.Equal(t, 1, userID)
+			payloads <- payload
+			return nil
+		},
+	}
+	h := NewHandler(service, &ProfileServiceMock{}, pushService)
+
+	own := &fakeConn{}
+	other := &fakeConn{}
+	h.addClient(&Client{conn: own, userID: 1})
+	h.addClient(&Client{conn: other, userID: 2})
+
+	rec := httptest.NewRecorder()
+	h.MarkAllRead(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/read-all", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp MarkReadResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp.Chats, 2)
+
+	if assert.Len(t, own.written, 1) {
+		var msg UnreadCountsMessage
+		assert.NoError(t, json.Unmarshal(own.written[0], &msg))
+		assert.Equal(t, MsgTypeUnreadCounts, msg.Type)
+		assert.Equal(t, 0, msg.Chats[0].UnreadCount)
+	}
+	assert.Empty(t, other.written)
+
+	// Other devices clear the notifications of the chat read to the end and update the badge
+	select {
+	case payload := <-payloads:
+		assert.True(t, payload.Silent)
+		assert.Empty(t, payload.Category)
+		assert.Equal(t, map[string]string{"type": "read_state", "badge": "1", "collapse_ids": "chat:c1"}, payload.Data)
+	case <-time.After(time.Second):
+		t.Fatal("no read state push sent")
+	}
+}
+
+func TestMarkAllReadNothingChanged(t *testing.T) {
+	service := &ServiceMock{
+		MarkAllReadFunc: func(ctx context.Context, userID int) ([]messagingrepo.ReadState, error) {
+			return []messagingrepo.ReadState{}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	own := &fakeConn{}
+	h.addClient(&Client{conn: own, userID: 1})
+
+	rec := httptest.NewRecorder()
+	h.MarkAllRead(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/read-all", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, own.written)
+}
+
+func TestMarkChatReadNotParticipant(t *testing.T) {
+	service := &ServiceMock{
+		MarkChatReadFunc: func(ctx context.Context, userID int, chatID string) ([]messagingrepo.ReadState, error) {
+			return nil, errors.New(apierrors.ErrorUserNotInChat)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.MarkChatRead(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/read-all", nil, 1, map[string]string{"chatID": "c1"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "c1", service.MarkChatReadCalls()[0].ChatID)
+}
+
+func TestMarkRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		body       interface{}
+		state      *messagingrepo.ReadState
+		serviceErr error
+		wantStatus int
+		wantChats  int
+	}{
+		{"success", 1, MarkReadRequest{MessageID: "m5"}, &messagingrepo.ReadState{ChatID: "c1", LastReadSeq: 5, UnreadCount: 2}, nil, http.StatusOK, 1},
+		{"already read further", 1, MarkReadRequest{MessageID: "m5"}, nil, nil, http.StatusOK, 0},
+		{"unauthorized", 0, MarkReadRequest{MessageID: "m5"}, nil, nil, http.StatusUnauthorized, 0},
+		{"missing message", 1, MarkReadRequest{}, nil, nil, http.StatusBadRequest, 0},
+		{"not participant", 1, MarkReadRequest{MessageID: "m5"}, nil, errors.New(apierrors.ErrorUserNotInChat), http.StatusNotFound, 0},
+		{"message not in chat", 1, MarkReadRequest{MessageID: "m5"}, nil, errors.New(apierrors.ErrorMessageNotFound), http.StatusNotFound, 0},
+		{"server error", 1, MarkReadRequest{MessageID: "m5"}, nil, errors.New("db down"), http.StatusInternalServerError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				StoreReadReceiptFunc: func(userID int, chatID string, messageID string) (*messagingrepo.ReadState, error) {
+					return tt.state, tt.serviceErr
+				},
+				GetChatParticipantsFunc: func(chatID string) ([]int, error) {
+					return []int{1, 2}, nil
+				},
+				GetUnreadCountsFunc: func(ctx context.Context, chatID string, userIDs []int) (map[int]messagingrepo.UnreadCounts, error) {
+					return nil, nil
+				},
+			}
+			pushService := &PushServiceMock{
+				SendNotificationFunc: func(ctx context.Context, userID int, payload push.NotificationPayload) error {
+					return nil
+				},
+			}
+			h := NewHandler(service, &ProfileServiceMock{}, pushService)
+
+			own := &fakeConn{}
+			other := &fakeConn{}
+			h.addClient(&Client{conn: own, userID: 1})
+			h.addClient(&Client{conn: other, userID: 2})
+
+			rec := httptest.NewRecorder()
+			h.MarkRead(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/read", tt.body, tt.userID, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, other.written)
+				return
+			}
+
+			call := service.StoreReadReceiptCalls()[0]
+			assert.Equal(t, "c1", call.ChatID)
+			assert.Equal(t, "m5", call.MessageID)
+
+			var resp MarkReadResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Len(t, resp.Chats, tt.wantChats)
+
+			if tt.state == nil {
+				assert.Empty(t, own.written)
+				assert.Empty(t, other.written)
+				return
+			}
+
+			// Other participants get the receipt, the user's devices get the new counters
+			if assert.Len(t, other.written, 1) {
+				var msg ReadReceiptMessage
+				assert.NoError(t, json.Unmarshal(other.written[0], &msg))
+				assert.Equal(t, MsgTypeReadReceipt, msg.Type)
+				assert.Equal(t, "c1", msg.ChatID)
+				assert.Equal(t, 1, msg.UserID)
+				assert.Equal(t, "m5", msg.MessageID)
+			}
+			if assert.Len(t, own.written, 1) {
+				var msg UnreadCountsMessage
+				assert.NoError(t, json.Unmarshal(own.written[0], &msg))
+				assert.Equal(t, MsgTypeUnreadCounts, msg.Type)
+				assert.Equal(t, 2, msg.Chats[0].UnreadCount)
+			}
+		})
+	}
+}
+
+func TestBroadcastReachesEveryConnectionOfUser(t *testing.T) {
+	service := &ServiceMock{
+		GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+			return []int{1, 2}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	phone, tablet := &fakeConn{}, &fakeConn{}
+	phoneClient := &Client{conn: phone, userID: 2}
+	h.addClient(phoneClient)
+	h.addClient(&Client{conn: tablet, userID: 2})
+	assert.Equal(t, 2, h.ActiveConnections())
+
+	h.broadcastToChat("c1", newFrame(json.RawMessage(`{"type":"typing"}`)))
+	assert.Len(t, phone.written, 1)
+	assert.Len(t, tablet.written, 1)
+
+	// Closing one device keeps the other connected
+	h.removeClient(phoneClient)
+	assert.Equal(t, 1, h.ActiveConnections())
+
+	h.broadcastToChat("c1", newFrame(json.RawMessage(`{"type":"typing"}`)))
+	assert.Len(t, phone.written, 1)
+	assert.Len(t, tablet.written, 2)
+}
+
+func TestGetMessageReactions(t *testing.T) {
+	reactedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service := &ServiceMock{
+		GetMessageReactionsFunc: func(ctx context.Context, userID int, messageID string) ([]messagingrepo.Reaction, error) {
+			return []messagingrepo.Reaction{{UserID: 2, ReactionCode: "like", ReactedAt: reactedAt}}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetMessageReactions(rec, handlertest.NewRequest(http.MethodGet, "/api/messages/m1/reactions", nil, 1, map[string]string{"messageID": "m1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var reactions []messagingrepo.Reaction
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&reactions))
+	assert.Equal(t, []messagingrepo.Reaction{{UserID: 2, ReactionCode: "like", ReactedAt: reactedAt}}, reactions)
+	assert.Equal(t, 1, service.GetMessageReactionsCalls()[0].UserID)
+	assert.Equal(t, "m1", service.GetMessageReactionsCalls()[0].MessageID)
+}
+
+func TestGetMessageReactionsNotFound(t *testing.T) {
+	service := &ServiceMock{
+		GetMessageReactionsFunc: func(ctx context.Context, userID int, messageID string) ([]messagingrepo.Reaction, error) {
+			return nil, errors.New(apierrors.ErrorMessageNotFound)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetMessageReactions(rec, handlertest.NewRequest(http.MethodGet, "/api/messages/m1/reactions", nil, 1, map[string]string{"messageID": "m1"}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// HeartbeatConfig configures keepalive pings and deadlines on chat WebSockets
+type HeartbeatConfig struct {
+	// PingInterval is how often the server pings the client
+	PingInterval time.Duration
+	// PongWait is how long the server waits for a pong or any other frame before
+	// it closes the connection; it should be longer than PingInterval
+	PongWait time.Duration
+	// WriteWait limits how long a single write may block on a slow client
+	WriteWait time.Duration
+}
+
+// ConnectionStats are WebSocket connection counters since startup
+type ConnectionStats struct {
+	Active  int   `json:"active"`
+	Opened  int64 `json:"opened"`
+	Closed  int64 `json:"closed"`
+	Evicted int64 `json:"evicted"` // Closed because the client stopped answering pings
+}
+
+// connectionCounters are updated as connections open and close
+type connectionCounters struct {
+	opened  atomic.Int64
+	closed  atomic.Int64
+	evicted atomic.Int64
+}
+
+// deadliner is the part of *websocket.Conn used for keepalive
+type deadliner interface {
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	SetPongHandler(h func(appData string) error)
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+}
+
+// EnableHeartbeat pings clients and closes connections that stop answering
+func (h *Handler) EnableHeartbeat(config HeartbeatConfig) {
+	h.heartbeat = &config
+}
+
+// ConnectionStats returns the WebSocket connection counters
+func (h *Handler) ConnectionStats() ConnectionStats {
+	return ConnectionStats{
+		Active:  h.ActiveConnections(),
+		Opened:  h.connections.opened.Load(),
+		Closed:  h.connections.closed.Load(),
+		Evicted: h.connections.evicted.Load(),
+	}
+}
+
+// heartbeatConn pings the client in the background and extends the read deadline
+// on every frame received, so a dead connection fails its next read
+type heartbeatConn struct {
+	WSConn
+	raw       deadliner
+	config    HeartbeatConfig
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newHeartbeatConn sets the first read deadline and starts pinging until the connection is closed
+func newHeartbeatConn(conn WSConn, raw deadliner, config HeartbeatConfig) *heartbeatConn {
+	c := &heartbeatConn{WSConn: conn, raw: raw, config: config, done: make(chan struct{})}
+	c.extendReadDeadline()
+	raw.SetPongHandler(func(string) error {
+		c.extendReadDeadline()
+		return nil
+	})
+	go c.ping()
+	return c
+}
+
+func (c *heartbeatConn) extendReadDeadline() {
+	if err := c.raw.SetReadDeadline(time.Now().Add(c.config.PongWait)); err != nil {
+		log.Printf("Error setting WebSocket read deadline: %v", err)
+	}
+}
+
+func (c *heartbeatConn) ping() {
+	ticker := time.NewTicker(c.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.raw.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.config.WriteWait)); err != nil {
+				// The pending read fails with the connection, which ends the client
+				c.Close()
+				return
+			}
+		}
+	}
+}
+
+func (c *heartbeatConn) ReadMessage() (int, []byte, error) {
+	messageType, data, err := c.WSConn.ReadMessage()
+	if err == nil {
+		c.extendReadDeadline()
+	}
+	return messageType, data, err
+}
+
+func (c *heartbeatConn) WriteMessage(messageType int, data []byte) error {
+	if err := c.raw.SetWriteDeadline(time.Now().Add(c.config.WriteWait)); err != nil {
+		return err
+	}
+	return c.WSConn.WriteMessage(messageType, data)
+}
+
+// Close stops the pings and closes the connection; it is safe to call more than once
+func (c *heartbeatConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.WSConn.Close()
+	})
+	return err
+}
+
+// isTimeout reports whether a read failed because its deadline passed
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,72 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// dialHeartbeatServer connects user 1 to a chat WebSocket with fast heartbeats
+func dialHeartbeatServer(t *testing.T) (*Handler, *websocket.Conn, func()) {
+	h := newTestHandler(&ServiceMock{})
+	h.EnableHeartbeat(HeartbeatConfig{
+		PingInterval: 20 * time.Millisecond,
+		PongWait:     100 * time.Millisecond,
+		WriteWait:    50 * time.Millisecond,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocket(w, r.WithContext(context.WithValue(r.Context(), "user_id", 1)))
+	}))
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if !assert.NoError(t, err) {
+		server.Close()
+		t.FailNow()
+	}
+	return h, client, func() {
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestHeartbeatEvictsSilentConnection(t *testing.T) {
+	h, _, cleanup := dialHeartbeatServer(t)
+	defer cleanup()
+
+	// The client never reads, so it never answers the pings
+	assert.Eventually(t, func() bool {
+		stats := h.ConnectionStats()
+		return stats.Evicted == 1 && stats.Closed == 1 && stats.Active == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), h.ConnectionStats().Opened)
+}
+
+func TestHeartbeatKeepsRespondingConnection(t *testing.T) {
+	h, client, cleanup := dialHeartbeatServer(t)
+	defer cleanup()
+
+	pings := make(chan struct{}, 100)
+	client.SetPingHandler(func(appData string) error {
+		pings <- struct{}{}
+		return client.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	})
+	// Reading processes the pings and answers them
+	go func() {
+		for {
+			if _, _, err := client.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+	assert.NotEmpty(t, pings)
+	assert.Equal(t, ConnectionStats{Active: 1, Opened: 1}, h.ConnectionStats())
+}
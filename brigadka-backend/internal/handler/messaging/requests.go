@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// @Summary      Запросы на переписку
+// @Description  Возвращает чаты от пользователей, которым настройки личных сообщений не разрешают писать напрямую. Такие чаты не входят в список чатов и не присылают push-уведомления, пока пользователь их не примет
+// @Tags         messaging
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} messaging.Chat "Непринятые личные чаты"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/requests [get]
+func (h *Handler) GetChatRequests(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chats, err := h.messagineService.GetChatRequests(userID)
+	if err != nil {
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error fetching chat requests: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(chats)
+}
+
+// @Summary      Принять запрос на переписку
+// @Description  Переносит чат из запросов в список чатов; после этого по нему приходят push-уведомления. Повторный вызов ничего не меняет
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/accept [post]
+func (h *Handler) AcceptChatRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.messagineService.AcceptChatRequest(r.Context(), userID, chi.URLParam(r, "chatID"))
+	h.respondChatSettings(w, err)
+}
+
+// @Summary      Настройки личных сообщений
+// @Description  Возвращает, кто может начинать личные чаты с пользователем: everyone — все; filtered — те, на кого пользователь подписан, а также участники его команд (allow_teammates) и верифицированные пользователи (allow_verified)
+// @Tags         messaging
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} messaging.DirectMessageSettings "Настройки"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/privacy [get]
+func (h *Handler) GetDirectMessageSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.messagineService.GetDirectMessageSettings(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error fetching direct message settings: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// @Summary      Изменить настройки личных сообщений
+// @Description  Задает, кто может начинать личные чаты с пользователем. Новые чаты от остальных попадают в запросы на переписку; уже существующие чаты не меняются
+// @Tags         messaging
+// @Accept       json
+// @Param        request body messaging.DirectMessageSettings true "Настройки"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400 {string} string "Некорректный запрос или неизвестная политика"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/privacy [put]
+func (h *Handler) UpdateDirectMessageSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var settings messaging.DirectMessageSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.messagineService.UpdateDirectMessageSettings(r.Context(), userID, settings)
+	if err != nil {
+		if err.Error() == apierrors.ErrorInvalidDirectMessagePolicy {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error updating direct message settings: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+func TestGetChatRequests(t *testing.T) {
+	service := &ServiceMock{
+		GetChatRequestsFunc: func(userID int) ([]messagingrepo.Chat, error) {
+			return []messagingrepo.Chat{{ChatID: "c1", Participants: []int{1, 2}, Request: true}}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetChatRequests(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/requests", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var chats []messagingrepo.Chat
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&chats))
+	if assert.Len(t, chats, 1) {
+		assert.True(t, chats[0].Request)
+	}
+	assert.Equal(t, 1, service.GetChatRequestsCalls()[0].UserID)
+}
+
+func TestAcceptChatRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"accepted", nil, http.StatusNoContent},
+		{"not in chat", errors.New(apierrors.ErrorUserNotInChat), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				AcceptChatRequestFunc: func(ctx context.Context, userID int, chatID string) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.AcceptChatRequest(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/accept", nil, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "c1", service.AcceptChatRequestCalls()[0].ChatID)
+		})
+	}
+}
+
+func TestUpdateDirectMessageSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"saved", nil, http.StatusNoContent},
+		{"unknown policy", errors.New(apierrors.ErrorInvalidDirectMessagePolicy), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				UpdateDirectMessageSettingsFunc: func(ctx context.Context, userID int, settings messagingrepo.DirectMessageSettings) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			settings := messagingrepo.DirectMessageSettings{Policy: messagingrepo.DirectMessagePolicyFiltered, AllowVerified: true}
+			rec := httptest.NewRecorder()
+			h.UpdateDirectMessageSettings(rec, handlertest.NewRequest(http.MethodPut, "/api/chats/privacy", settings, 1, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, settings, service.UpdateDirectMessageSettingsCalls()[0].Settings)
+		})
+	}
+}
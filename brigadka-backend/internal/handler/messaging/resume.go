@@ -0,0 +1,164 @@
+package messaging
+
+import (
+	"context"
+	"log"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// maxResumeChats caps the chats replayed for one resume request
+const maxResumeChats = 100
+
+// ResumeMessage is sent by a reconnecting client with the seq of the latest
+// message it saw per chat, 0 for chats it has no messages of
+type ResumeMessage struct {
+	Type  string           `json:"type" msgpack:"type"`
+	Chats map[string]int64 `json:"chats" msgpack:"chats"`
+}
+
+// ResumedMessage ends the replay; live messages follow it. Chats holds the seq of the
+// newest replayed message per resumed chat. Chats missing from it were not resumed,
+// e.g. because the user left them. Truncated chats have more missed messages than
+// were replayed, to be loaded with the messages endpoint.
+type ResumedMessage struct {
+	Type      string           `json:"type" msgpack:"type"`
+	Chats     map[string]int64 `json:"chats" msgpack:"chats"`
+	Truncated []string         `json:"truncated,omitempty" msgpack:"truncated,omitempty"`
+}
+
+// send delivers a live message to the client, holding it back while missed messages are replayed
+func (c *Client) send(message *frame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.replaying {
+		c.pending = append(c.pending, message)
+		return nil
+	}
+	return c.write(message)
+}
+
+// replay delivers a missed message to the client ahead of the held back live ones
+func (c *Client) replay(message *frame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.write(message)
+}
+
+// write encodes the message with the client's codec and sends it; callers hold mu
+func (c *Client) write(message *frame) error {
+	data, err := message.encode(c.codec)
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(c.codec.messageType(), data)
+}
+
+// startReplay holds back live messages until finishReplay
+func (c *Client) startReplay() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replaying = true
+}
+
+// finishReplay sends the last replayed message, then the live messages held back during the replay
+func (c *Client) finishReplay(last *frame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages := append([]*frame{last}, c.pending...)
+	c.replaying = false
+	c.pending = nil
+	for _, message := range messages {
+		if err := c.write(message); err != nil {
+			log.Printf("Error sending message to user %d: %v", c.userID, err)
+			return
+		}
+	}
+}
+
+// handleResume replays the messages, reactions and read receipts a reconnecting client
+// missed, then switches it to live messages. Messages broadcast during the replay are
+// delivered after it, so clients may get a message both replayed and live and should
+// deduplicate by message ID.
+func (h *Handler) handleResume(client *Client, msg ResumeMessage) {
+	client.startReplay()
+
+	resumed := ResumedMessage{Type: MsgTypeResumed, Chats: make(map[string]int64, len(msg.Chats))}
+	for chatID, lastSeq := range msg.Chats {
+		if len(resumed.Chats) == maxResumeChats {
+			break
+		}
+		if lastSeq < 0 {
+			continue
+		}
+
+		replay, err := h.messagineService.GetChatReplay(context.Background(), client.userID, chatID, lastSeq)
+		if err != nil {
+			if err.Error() != apierrors.ErrorUserNotInChat {
+				log.Printf("Error loading replay of chat %s for user %d: %v", chatID, client.userID, err)
+			}
+			continue
+		}
+		if err := h.replayChat(client, replay); err != nil {
+			log.Printf("Error replaying chat %s to user %d: %v", chatID, client.userID, err)
+			break
+		}
+
+		resumed.Chats[chatID] = replay.LastSeq
+		if replay.Truncated {
+			resumed.Truncated = append(resumed.Truncated, chatID)
+		}
+	}
+
+	client.finishReplay(newFrame(resumed))
+}
+
+// replayChat sends the missed messages of a chat, then the reactions and read receipts
+func (h *Handler) replayChat(client *Client, replay *messaging.ChatReplay) error {
+	frames := make([]interface{}, 0, len(replay.Messages)+len(replay.Reactions)+len(replay.ReadReceipts))
+	for _, msg := range replay.Messages {
+		frames = append(frames, ChatMessage{
+			BaseMessage:  BaseMessage{Type: MsgTypeChatMessage, ChatID: replay.ChatID},
+			MessageID:    msg.MessageID,
+			SenderID:     msg.SenderID,
+			Content:      msg.Content,
+			Ciphertext:   msg.Ciphertext,
+			SentAt:       msg.SentAt,
+			Seq:          msg.Seq,
+			Attachments:  msg.Attachments,
+			Preview:      msg.Preview,
+			Kind:         msg.Kind,
+			Event:        msg.Event,
+			TargetUserID: msg.TargetUserID,
+			Status:       msg.Status,
+		})
+	}
+	for _, reaction := range replay.Reactions {
+		frames = append(frames, ReactionMessage{
+			BaseMessage:  BaseMessage{Type: MsgTypeReaction, ChatID: replay.ChatID},
+			ReactionID:   reaction.ReactionID,
+			MessageID:    reaction.MessageID,
+			UserID:       reaction.UserID,
+			ReactionCode: reaction.ReactionCode,
+			ReactedAt:    reaction.ReactedAt,
+		})
+	}
+	for _, receipt := range replay.ReadReceipts {
+		frames = append(frames, ReadReceiptMessage{
+			BaseMessage: BaseMessage{Type: MsgTypeReadReceipt, ChatID: replay.ChatID},
+			UserID:      receipt.UserID,
+			MessageID:   receipt.MessageID,
+			ReadAt:      receipt.ReadAt,
+		})
+	}
+
+	for _, value := range frames {
+		if err := client.replay(newFrame(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
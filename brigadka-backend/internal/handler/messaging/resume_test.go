@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+func TestResumeReplaysMissedEventsBeforeLiveMessages(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	conn := &fakeConn{}
+	client := &Client{conn: conn, userID: 1}
+
+	service := &ServiceMock{
+		GetChatReplayFunc: func(ctx context.Context, userID int, chatID string, afterSeq int64) (*messaging.ChatReplay, error) {
+			if chatID == "left" {
+				return nil, errors.New(apierrors.ErrorUserNotInChat)
+			}
+			// A message broadcast while the replay is loaded
+			assert.NoError(t, client.send(newFrame(json.RawMessage(`{"type":"chat_message","message_id":"live"}`))))
+			return &messaging.ChatReplay{
+				ChatID: chatID,
+				Messages: []messagingrepo.ChatMessage{
+					{MessageID: "m1", ChatID: chatID, SenderID: 2, Content: "hi", SentAt: now, Seq: 11},
+					{MessageID: "m2", ChatID: chatID, SenderID: 2, Content: "there", SentAt: now, Seq: 12},
+				},
+				Reactions:    []messagingrepo.ReactionEvent{{ReactionID: "r1", MessageID: "m1", UserID: 2, ReactionCode: "like", ReactedAt: now}},
+				ReadReceipts: []messagingrepo.ReadReceipt{{UserID: 2, MessageID: "m0", ReadAt: now}},
+				LastSeq:      12,
+				Truncated:    true,
+			}, nil
+		},
+	}
+	h := newTestHandler(service)
+
+	h.handleResume(client, ResumeMessage{Type: MsgTypeResume, Chats: map[string]int64{"c1": 10, "left": 3}})
+
+	var types []string
+	for _, data := range conn.written {
+		var msg BaseMessage
+		assert.NoError(t, json.Unmarshal(data, &msg))
+		types = append(types, msg.Type)
+	}
+	assert.Equal(t, []string{
+		MsgTypeChatMessage, MsgTypeChatMessage, MsgTypeReaction, MsgTypeReadReceipt, MsgTypeResumed, MsgTypeChatMessage,
+	}, types)
+
+	var first ChatMessage
+	assert.NoError(t, json.Unmarshal(conn.written[0], &first))
+	assert.Equal(t, "m1", first.MessageID)
+	assert.Equal(t, int64(11), first.Seq)
+
+	var resumed ResumedMessage
+	assert.NoError(t, json.Unmarshal(conn.written[4], &resumed))
+	assert.Equal(t, map[string]int64{"c1": 12}, resumed.Chats)
+	assert.Equal(t, []string{"c1"}, resumed.Truncated)
+
+	var live ChatMessage
+	assert.NoError(t, json.Unmarshal(conn.written[5], &live))
+	assert.Equal(t, "live", live.MessageID)
+
+	// Once resumed, live messages are sent right away
+	assert.NoError(t, client.send(newFrame(json.RawMessage(`{"type":"typing"}`))))
+	assert.Len(t, conn.written, 7)
+}
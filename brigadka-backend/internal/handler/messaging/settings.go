@@ -0,0 +1,131 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+)
+
+// MuteChatRequest sets when notifications of a muted chat turn back on
+type MuteChatRequest struct {
+	Until *time.Time `json:"until,omitempty"` // Muted indefinitely when empty
+}
+
+// @Summary      Отключить уведомления чата
+// @Description  Отключает push-уведомления о новых сообщениях чата для текущего пользователя до указанного времени или бессрочно. Повторный вызов заменяет время окончания
+// @Tags         messaging
+// @Accept       json
+// @Param        chatID path string true "ID чата"
+// @Param        request body MuteChatRequest false "Время, до которого уведомления отключены"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400 {string} string "Некорректный запрос или время в прошлом"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/mute [post]
+func (h *Handler) MuteChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// The body is optional
+	var req MuteChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.messagineService.MuteChat(r.Context(), userID, chi.URLParam(r, "chatID"), req.Until)
+	h.respondChatSettings(w, err)
+}
+
+// @Summary      Включить уведомления чата
+// @Description  Снова включает push-уведомления чата для текущего пользователя
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/mute [delete]
+func (h *Handler) UnmuteChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.messagineService.UnmuteChat(r.Context(), userID, chi.URLParam(r, "chatID"))
+	h.respondChatSettings(w, err)
+}
+
+// @Summary      Архивировать чат
+// @Description  Переносит чат в архив текущего пользователя; в списке чатов он помечается archived
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/archive [post]
+func (h *Handler) ArchiveChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.messagineService.ArchiveChat(r.Context(), userID, chi.URLParam(r, "chatID"))
+	h.respondChatSettings(w, err)
+}
+
+// @Summary      Вернуть чат из архива
+// @Description  Возвращает чат из архива текущего пользователя
+// @Tags         messaging
+// @Param        chatID path string true "ID чата"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Чат не найден"
+// @Failure      500 {string} string "Ошибка сервера"
+// @Router       /chats/{chatID}/archive [delete]
+func (h *Handler) UnarchiveChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.messagineService.UnarchiveChat(r.Context(), userID, chi.URLParam(r, "chatID"))
+	h.respondChatSettings(w, err)
+}
+
+// respondChatSettings writes the response of a chat settings change
+func (h *Handler) respondChatSettings(w http.ResponseWriter, err error) {
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch err.Error() {
+	case apierrors.ErrorUserNotInChat:
+		http.Error(w, "Chat not found", http.StatusNotFound)
+	case apierrors.ErrorInvalidMuteUntil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		log.Printf("Error updating chat settings: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+func TestMuteChat(t *testing.T) {
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+		wantUntil  *time.Time
+	}{
+		{"indefinitely", nil, nil, http.StatusNoContent, nil},
+		{"until", MuteChatRequest{Until: &until}, nil, http.StatusNoContent, &until},
+		{"until in the past", MuteChatRequest{Until: &until}, errors.New(apierrors.ErrorInvalidMuteUntil), http.StatusBadRequest, &until},
+		{"not in chat", nil, errors.New(apierrors.ErrorUserNotInChat), http.StatusNotFound, nil},
+		{"server error", nil, errors.New("db down"), http.StatusInternalServerError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				MuteChatFunc: func(ctx context.Context, userID int, chatID string, until *time.Time) error {
+					return tt.serviceErr
+				},
+			}
+			h := newTestHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.MuteChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/mute", tt.body, 1, map[string]string{"chatID": "c1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if assert.Len(t, service.MuteChatCalls(), 1) {
+				call := service.MuteChatCalls()[0]
+				assert.Equal(t, 1, call.UserID)
+				assert.Equal(t, "c1", call.ChatID)
+				if tt.wantUntil == nil {
+					assert.Nil(t, call.Until)
+				} else if assert.NotNil(t, call.Until) {
+					assert.True(t, tt.wantUntil.Equal(*call.Until))
+				}
+			}
+		})
+	}
+}
+
+func TestMuteChatInvalidBody(t *testing.T) {
+	service := &ServiceMock{}
+	h := newTestHandler(service)
+
+	req := handlertest.NewRequest(http.MethodPost, "/api/chats/c1/mute", nil, 1, map[string]string{"chatID": "c1"})
+	req.Body = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"until":"tomorrow"}`)).Body
+
+	rec := httptest.NewRecorder()
+	h.MuteChat(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, service.MuteChatCalls())
+}
+
+func TestArchiveChat(t *testing.T) {
+	service := &ServiceMock{
+		ArchiveChatFunc: func(ctx context.Context, userID int, chatID string) error {
+			return nil
+		},
+		UnarchiveChatFunc: func(ctx context.Context, userID int, chatID string) error {
+			return errors.New(apierrors.ErrorUserNotInChat)
+		},
+	}
+	h := newTestHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.ArchiveChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/archive", nil, 1, map[string]string{"chatID": "c1"}))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.UnarchiveChat(rec, handlertest.NewRequest(http.MethodDelete, "/api/chats/c1/archive", nil, 1, map[string]string{"chatID": "c1"}))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ArchiveChat(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/c1/archive", nil, 0, map[string]string{"chatID": "c1"}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Len(t, service.ArchiveChatCalls(), 1)
+}
+
+func TestChatPushSkipsMutedParticipants(t *testing.T) {
+	service := &ServiceMock{
+		GetMutedParticipantsFunc: func(ctx context.Context, chatID string) (map[int]struct{}, error) {
+			return map[int]struct{}{3: {}}, nil
+		},
+		GetChatFunc: func(chatID string, userID int) (*messagingrepo.Chat, error) {
+			return &messagingrepo.Chat{ChatID: chatID}, nil
+		},
+		GetUnreadCountsFunc: func(ctx context.Context, chatID string, userIDs []int) (map[int]messagingrepo.UnreadCounts, error) {
+			return nil, nil
+		},
+	}
+	notified := make(chan int, 2)
+	pushService := &PushServiceMock{
+		SendNotificationFunc: func(ctx context.Context, userID int, payload push.NotificationPayload) error {
+			notified <- userID
+			return nil
+		},
+	}
+	profileService := &ProfileServiceMock{
+		GetProfileFunc: func(userID int) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, FullName: "Anna"}, nil
+		},
+	}
+	h := NewHandler(service, profileService, pushService)
+
+	h.sendChatPushNotifications(1, ChatMessage{BaseMessage: BaseMessage{ChatID: "c1"}, Content: "hi"}, []int{2, 3})
+
+	select {
+	case userID := <-notified:
+		assert.Equal(t, 2, userID)
+	case <-time.After(time.Second):
+		t.Fatal("no push notification sent")
+	}
+	select {
+	case userID := <-notified:
+		t.Fatalf("unexpected push notification to user %d", userID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestChatPushAllMuted(t *testing.T) {
+	service := &ServiceMock{
+		GetMutedParticipantsFunc: func(ctx context.Context, chatID string) (map[int]struct{}, error) {
+			return map[int]struct{}{2: {}}, nil
+		},
+	}
+	profileService := &ProfileServiceMock{}
+	h := NewHandler(service, profileService, &PushServiceMock{})
+
+	h.sendChatPushNotifications(1, ChatMessage{BaseMessage: BaseMessage{ChatID: "c1"}, Content: "hi"}, []int{2})
+
+	// Nothing to send, so the sender profile and chat are not even loaded
+	assert.Empty(t, profileService.GetProfileCalls())
+	assert.Empty(t, service.GetChatCalls())
+}
+
+func TestChatPushHidesEncryptedContent(t *testing.T) {
+	service := &ServiceMock{
+		GetMutedParticipantsFunc: func(ctx context.Context, chatID string) (map[int]struct{}, error) {
+			return nil, nil
+		},
+		GetChatFunc: func(chatID string, userID int) (*messagingrepo.Chat, error) {
+			return &messagingrepo.Chat{ChatID: chatID}, nil
+		},
+		GetUnreadCountsFunc: func(ctx context.Context, chatID string, userIDs []int) (map[int]messagingrepo.UnreadCounts, error) {
+			return nil, nil
+		},
+	}
+	bodies := make(chan string, 1)
+	pushService := &PushServiceMock{
+		SendNotificationFunc: func(ctx context.Context, userID int, payload push.NotificationPayload) error {
+			bodies <- payload.Body
+			return nil
+		},
+	}
+	profileService := &ProfileServiceMock{
+		GetProfileFunc: func(userID int) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, FullName: "Anna"}, nil
+		},
+	}
+	h := NewHandler(service, profileService, pushService)
+
+	h.sendChatPushNotifications(1, ChatMessage{BaseMessage: BaseMessage{ChatID: "c1"}, Ciphertext: "b3BhcXVl"}, []int{2})
+
+	select {
+	case body := <-bodies:
+		assert.Equal(t, encryptedMessagePreview, body)
+	case <-time.After(time.Second):
+		t.Fatal("no push notification sent")
+	}
+}
+
+func TestChatPushCollapsesByChatWithUnreadBadge(t *testing.T) {
+	service := &ServiceMock{
+		GetMutedParticipantsFunc: func(ctx context.Context, chatID string) (map[int]struct{}, error) {
+			return nil, nil
+		},
+		GetChatFunc: func(chatID string, userID int) (*messagingrepo.Chat, error) {
+			return &messagingrepo.Chat{ChatID: chatID}, nil
+		},
+		GetUnreadCountsFunc: func(ctx context.Context, chatID string, userIDs []int) (map[int]messagingrepo.UnreadCounts, error) {
+			return map[int]messagingrepo.UnreadCounts{2: {Chat: 3, Total: 12}}, nil
+		},
+	}
+	payloads := make(chan push.NotificationPayload, 2)
+	pushService := &PushServiceMock{
+		SendNotificationFunc: func(ctx context.Context, userID int, payload push.NotificationPayload) error {
+			payloads <- payload
+			return nil
+		},
+	}
+	profileService := &ProfileServiceMock{
+		GetProfileFunc: func(userID int) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, FullName: "Anna"}, nil
+		},
+	}
+	h := NewHandler(service, profileService, pushService)
+
+	h.sendChatPushNotifications(1, ChatMessage{BaseMessage: BaseMessage{ChatID: "c1"}, Content: "hi"}, []int{2, 3})
+
+	badges := map[int]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case payload := <-payloads:
+			assert.Equal(t, "chat:c1", payload.CollapseID)
+			badges[payload.Badge] = true
+		case <-time.After(time.Second):
+			t.Fatal("no push notification sent")
+		}
+	}
+	// User 3 has no counts loaded and gets a badge for this message
+	assert.Equal(t, map[int]bool{12: true, 1: true}, badges)
+}
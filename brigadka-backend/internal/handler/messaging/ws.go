@@ -2,119 +2,238 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
 	"github.com/gorilla/websocket"
 )
 
 // BaseMessage defines the common fields for all WebSocket messages
 type BaseMessage struct {
-	Type   string `json:"type"`
-	ChatID string `json:"chat_id,omitempty"`
+	Type   string `json:"type" msgpack:"type"`
+	ChatID string `json:"chat_id,omitempty" msgpack:"chat_id,omitempty"`
+}
+
+// WelcomeMessage is the first frame sent on a new connection. Clients use
+// ServerTime to correct local clock skew when ordering and dating messages.
+type WelcomeMessage struct {
+	BaseMessage
+	ServerTime time.Time `json:"server_time" msgpack:"server_time"`
 }
 
 // ChatMessage represents a message sent in a chat
 type ChatMessage struct {
 	BaseMessage
-	MessageID string    `json:"message_id"`
-	SenderID  int       `json:"sender_id"`
-	Content   string    `json:"content"`
-	SentAt    time.Time `json:"sent_at,omitempty"`
+	MessageID string `json:"message_id" msgpack:"message_id"`
+	SenderID  int    `json:"sender_id" msgpack:"sender_id"`
+	Content   string `json:"content" msgpack:"content"`
+	// End-to-end encrypted payload sent instead of content and attachments in direct chats,
+	// stored and forwarded as is
+	Ciphertext string    `json:"ciphertext,omitempty" msgpack:"ciphertext,omitempty"`
+	SentAt     time.Time `json:"sent_at,omitempty" msgpack:"sent_at,omitempty"`
+	Seq        int64     `json:"seq,omitempty" msgpack:"seq,omitempty"` // Set by the server; resuming clients send the latest seq they saw
+	// Clients send the media IDs of their uploads; broadcasts carry the URLs
+	Attachments []messaging.Attachment `json:"attachments,omitempty" msgpack:"attachments,omitempty"`
+	Preview     *messaging.LinkPreview `json:"preview,omitempty" msgpack:"preview,omitempty"` // Sent in replays; live messages get message_preview_ready
+	// Set by the server: system messages record changes of the chat, e.g. a participant added
+	Kind         string `json:"kind,omitempty" msgpack:"kind,omitempty"`
+	Event        string `json:"event,omitempty" msgpack:"event,omitempty"`
+	TargetUserID *int   `json:"target_user_id,omitempty" msgpack:"target_user_id,omitempty"`
+	// Delivery status of the client's own messages in direct chats, sent in replays;
+	// live updates come as delivery_receipt and read_receipt
+	Status string `json:"status,omitempty" msgpack:"status,omitempty"`
 }
 
 // JoinMessage represents a user joining a chat
 type JoinMessage struct {
 	BaseMessage
-	UserID   int       `json:"user_id"`
-	JoinedAt time.Time `json:"joined_at"`
+	UserID   int       `json:"user_id" msgpack:"user_id"`
+	JoinedAt time.Time `json:"joined_at" msgpack:"joined_at"`
 }
 
 // LeaveMessage represents a user leaving a chat
 type LeaveMessage struct {
 	BaseMessage
-	UserID int       `json:"user_id"`
-	LeftAt time.Time `json:"left_at"`
+	UserID int       `json:"user_id" msgpack:"user_id"`
+	LeftAt time.Time `json:"left_at" msgpack:"left_at"`
 }
 
 // ReactionMessage represents a reaction to a message
 type ReactionMessage struct {
 	BaseMessage
-	ReactionID   string    `json:"reaction_id"`
-	MessageID    string    `json:"message_id"`
-	UserID       int       `json:"user_id"`
-	ReactionCode string    `json:"reaction_code"`
-	ReactedAt    time.Time `json:"reacted_at,omitempty"`
+	ReactionID   string    `json:"reaction_id" msgpack:"reaction_id"`
+	MessageID    string    `json:"message_id" msgpack:"message_id"`
+	UserID       int       `json:"user_id" msgpack:"user_id"`
+	ReactionCode string    `json:"reaction_code" msgpack:"reaction_code"`
+	ReactedAt    time.Time `json:"reacted_at,omitempty" msgpack:"reacted_at,omitempty"`
 }
 
 // ReactionMessage represents a reaction to a message
 type ReactionRemovedMessage struct {
 	BaseMessage
-	ReactionID   string    `json:"reaction_id"`
-	MessageID    string    `json:"message_id"`
-	UserID       int       `json:"user_id"`
-	ReactionCode string    `json:"reaction_code"`
-	RemovedAt    time.Time `json:"reacted_at,omitempty"`
+	ReactionID   string    `json:"reaction_id" msgpack:"reaction_id"`
+	MessageID    string    `json:"message_id" msgpack:"message_id"`
+	UserID       int       `json:"user_id" msgpack:"user_id"`
+	ReactionCode string    `json:"reaction_code" msgpack:"reaction_code"`
+	RemovedAt    time.Time `json:"reacted_at,omitempty" msgpack:"reacted_at,omitempty"`
 }
 
 // TypingMessage represents a typing indicator
 type TypingMessage struct {
 	BaseMessage
-	UserID    int       `json:"user_id"`
-	IsTyping  bool      `json:"is_typing"`
-	Timestamp time.Time `json:"timestamp"`
+	UserID    int       `json:"user_id" msgpack:"user_id"`
+	IsTyping  bool      `json:"is_typing" msgpack:"is_typing"`
+	Timestamp time.Time `json:"timestamp" msgpack:"timestamp"`
 }
 
 // ReadReceiptMessage represents a read receipt notification
 type ReadReceiptMessage struct {
 	BaseMessage
-	UserID    int       `json:"user_id"`
-	MessageID string    `json:"message_id"`
-	ReadAt    time.Time `json:"read_at"`
+	UserID    int       `json:"user_id" msgpack:"user_id"`
+	MessageID string    `json:"message_id" msgpack:"message_id"`
+	ReadAt    time.Time `json:"read_at" msgpack:"read_at"`
+}
+
+// DeliveryReceiptMessage is sent by a device once it received the messages of a direct chat
+// up to MessageID, and forwarded to the sender, who shows them as delivered
+type DeliveryReceiptMessage struct {
+	BaseMessage
+	UserID      int       `json:"user_id" msgpack:"user_id"`
+	MessageID   string    `json:"message_id" msgpack:"message_id"`
+	DeliveredAt time.Time `json:"delivered_at" msgpack:"delivered_at"`
+}
+
+// UnreadCountsMessage notifies a user's devices that unread counters changed
+type UnreadCountsMessage struct {
+	Type  string                `json:"type" msgpack:"type"`
+	Chats []messaging.ReadState `json:"chats" msgpack:"chats"`
+}
+
+// ChatUpdatedMessage notifies participants that an admin changed the chat name, description or avatar
+type ChatUpdatedMessage struct {
+	BaseMessage
+	ChatName    *string               `json:"chat_name" msgpack:"chat_name"`
+	Description *string               `json:"description" msgpack:"description"`
+	Avatar      *messaging.ChatAvatar `json:"avatar" msgpack:"avatar"`
+	UpdatedBy   int                   `json:"updated_by" msgpack:"updated_by"`
 }
 
+// AckMessage confirms to the sender that a chat message is stored. Seq and SentAt
+// are those of the stored message, also when a retry repeated an already stored one.
+type AckMessage struct {
+	Type      string    `json:"type" msgpack:"type"`
+	MessageID string    `json:"message_id" msgpack:"message_id"`
+	Seq       int64     `json:"seq" msgpack:"seq"`
+	SentAt    time.Time `json:"sent_at" msgpack:"sent_at"`
+}
+
+// MessagePreviewReadyMessage carries the link preview of a message, sent once the link has been fetched
+type MessagePreviewReadyMessage struct {
+	BaseMessage
+	MessageID string                `json:"message_id" msgpack:"message_id"`
+	Preview   messaging.LinkPreview `json:"preview" msgpack:"preview"`
+}
+
+// ErrorMessage tells the sender that a chat message was not stored
+type ErrorMessage struct {
+	Type       string `json:"type" msgpack:"type"`
+	MessageID  string `json:"message_id,omitempty" msgpack:"message_id,omitempty"`
+	Code       string `json:"code" msgpack:"code"`
+	RetryAfter int    `json:"retry_after,omitempty" msgpack:"retry_after,omitempty"` // Seconds until the message can be resent, for ErrorCodeRateLimited
+}
+
+// Error codes of ErrorMessage. Only ErrorCodeInternal and ErrorCodeRateLimited
+// are worth retrying, with the same message ID.
+const (
+	ErrorCodeInvalidMessage    = "invalid_message"
+	ErrorCodeNotInChat         = "not_in_chat"
+	ErrorCodeEmptyMessage      = "empty_message"
+	ErrorCodeInvalidAttachment = "invalid_attachment"
+	ErrorCodeInvalidCiphertext = "invalid_ciphertext"
+	ErrorCodeNotDirectChat     = "not_direct_chat"
+	ErrorCodeDuplicateID       = "duplicate_message_id"
+	ErrorCodeRateLimited       = "rate_limited"
+	ErrorCodeInternal          = "internal_error"
+)
+
 // Message type constants
 const (
-	MsgTypeChatMessage    = "chat_message"
-	MsgTypeReaction       = "reaction"
-	MsgTypeRemoveReaction = "remove_reaction"
-	MsgTypeTyping         = "typing"
-	MsgTypeReadReceipt    = "read_receipt"
+	MsgTypeChatMessage     = "chat_message"
+	MsgTypeReaction        = "reaction"
+	MsgTypeRemoveReaction  = "remove_reaction"
+	MsgTypeTyping          = "typing"
+	MsgTypeReadReceipt     = "read_receipt"
+	MsgTypeDeliveryReceipt = "delivery_receipt"
+	MsgTypeUnreadCounts    = "unread_counts"
+	MsgTypeWelcome         = "welcome"
+	MsgTypeChatUpdated     = "chat_updated"
+	MsgTypeAck             = "ack"
+	MsgTypeError           = "error"
+	MsgTypeResume          = "resume"
+	MsgTypeResumed         = "resumed"
+	MsgTypePreviewReady    = "message_preview_ready"
+	MsgTypeAnnouncement    = "announcement"
 )
 
-func (h *Handler) handleWSConnection(conn WSConn, userID int) {
+func (h *Handler) handleWSConnection(conn WSConn, userID int, codec codec) {
+	h.connections.opened.Add(1)
+	if h.eventLog != nil {
+		conn = &loggingConn{WSConn: conn, log: h.eventLog, userID: userID, codec: codec}
+		h.eventLog.Record(userID, WSEvent{Type: EventTypeConnect, Timestamp: time.Now()})
+	}
+
 	// Create new client
 	client := &Client{
 		conn:   conn,
 		userID: userID,
+		codec:  codec,
 	}
 
-	// Add client to clients map
-	h.clientsMutex.Lock()
-	h.clients[userID] = client
-	h.clientsMutex.Unlock()
+	// Sent before the client is registered, so no broadcast can precede it
+	h.sendWelcome(client)
+
+	h.addClient(client)
 
 	// Handle WebSocket connection
 	go h.handleClient(client)
 }
 
+// sendWelcome sends the welcome frame with the server time
+func (h *Handler) sendWelcome(client *Client) {
+	err := client.send(newFrame(WelcomeMessage{
+		BaseMessage: BaseMessage{Type: MsgTypeWelcome},
+		ServerTime:  time.Now().UTC(),
+	}))
+	if err != nil {
+		log.Printf("Error sending welcome message to user %d: %v", client.userID, err)
+	}
+}
+
 // handleClient handles messages from a specific client
 func (h *Handler) handleClient(client *Client) {
 	defer func() {
 		client.conn.Close()
-		h.clientsMutex.Lock()
-		delete(h.clients, client.userID)
-		h.clientsMutex.Unlock()
+		h.removeClient(client)
+		h.connections.closed.Add(1)
+		h.eventLog.Record(client.userID, WSEvent{Type: EventTypeDisconnect, Timestamp: time.Now()})
 	}()
 
 	for {
 		// Read message from client
 		_, data, err := client.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			if isTimeout(err) {
+				h.connections.evicted.Add(1)
+				log.Printf("Closing stale WebSocket of user %d", client.userID)
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
@@ -122,52 +241,81 @@ func (h *Handler) handleClient(client *Client) {
 
 		// Parse message to get the type
 		var baseMsg BaseMessage
-		if err := json.Unmarshal(data, &baseMsg); err != nil {
+		if err := client.codec.unmarshal(data, &baseMsg); err != nil {
 			log.Printf("Error parsing message: %v", err)
 			continue
 		}
 
+		// Resuming spans several chats, so it is handled before the membership check
+		if baseMsg.Type == MsgTypeResume {
+			var resumeMsg ResumeMessage
+			if err := client.codec.unmarshal(data, &resumeMsg); err != nil {
+				log.Printf("Error parsing resume message: %v", err)
+				continue
+			}
+			h.handleResume(client, resumeMsg)
+			continue
+		}
+
+		// Chat messages are parsed first, so that every one of them is answered with an ack or an error
+		var chatMsg ChatMessage
+		if baseMsg.Type == MsgTypeChatMessage {
+			if err := client.codec.unmarshal(data, &chatMsg); err != nil || chatMsg.MessageID == "" {
+				log.Printf("Error parsing chat message: %v", err)
+				h.sendMessageError(client, chatMsg.MessageID, ErrorCodeInvalidMessage)
+				continue
+			}
+		}
+
 		isUserInChat, err := h.messagineService.IsUserInChat(client.userID, baseMsg.ChatID)
 		if err != nil {
 			log.Printf("Error checking if user is in chat: %v", err)
+			if baseMsg.Type == MsgTypeChatMessage {
+				h.sendMessageError(client, chatMsg.MessageID, ErrorCodeInternal)
+			}
 			continue
 		}
 
 		if !isUserInChat {
 			log.Printf("User %d not in chat %s", client.userID, baseMsg.ChatID)
+			if baseMsg.Type == MsgTypeChatMessage {
+				h.sendMessageError(client, chatMsg.MessageID, ErrorCodeNotInChat)
+			}
 			continue
 		}
 
 		// Handle message based on type
 		switch baseMsg.Type {
 		case MsgTypeChatMessage:
-			var chatMsg ChatMessage
-			if err := json.Unmarshal(data, &chatMsg); err != nil {
-				log.Printf("Error parsing chat message: %v", err)
-				continue
-			}
 			h.handleChatMessage(client, chatMsg)
 		case MsgTypeReaction:
 			var reactionMsg ReactionMessage
-			if err := json.Unmarshal(data, &reactionMsg); err != nil {
+			if err := client.codec.unmarshal(data, &reactionMsg); err != nil {
 				log.Printf("Error parsing reaction message: %v", err)
 				continue
 			}
 			h.handleReaction(client, reactionMsg)
 		case MsgTypeTyping:
 			var typingMsg TypingMessage
-			if err := json.Unmarshal(data, &typingMsg); err != nil {
+			if err := client.codec.unmarshal(data, &typingMsg); err != nil {
 				log.Printf("Error parsing typing message: %v", err)
 				continue
 			}
 			h.handleTypingIndicator(client, typingMsg)
 		case MsgTypeReadReceipt:
 			var readReceiptMsg ReadReceiptMessage
-			if err := json.Unmarshal(data, &readReceiptMsg); err != nil {
+			if err := client.codec.unmarshal(data, &readReceiptMsg); err != nil {
 				log.Printf("Error parsing read receipt message: %v", err)
 				continue
 			}
 			h.handleReadReceipt(client, readReceiptMsg)
+		case MsgTypeDeliveryReceipt:
+			var deliveryReceiptMsg DeliveryReceiptMessage
+			if err := client.codec.unmarshal(data, &deliveryReceiptMsg); err != nil {
+				log.Printf("Error parsing delivery receipt message: %v", err)
+				continue
+			}
+			h.handleDeliveryReceipt(client, deliveryReceiptMsg)
 		default:
 			log.Printf("Unknown message type: %s", baseMsg.Type)
 		}
@@ -176,28 +324,66 @@ func (h *Handler) handleClient(client *Client) {
 
 // handleChatMessage handles a chat message from a client
 func (h *Handler) handleChatMessage(client *Client, msg ChatMessage) {
+	mediaIDs := make([]int, len(msg.Attachments))
+	for i, attachment := range msg.Attachments {
+		mediaIDs[i] = attachment.MediaID
+	}
+
 	// Store message using the service
-	sentAt, err := h.messagineService.AddMessage(msg.MessageID, msg.ChatID, client.userID, msg.Content)
+	stored, err := h.storeMessage(msg.MessageID, msg.ChatID, client.userID, msg.Content, msg.Ciphertext, mediaIDs)
 	if err != nil {
-		// Check if it's a duplicate message (UUID constraint violation)
-		if isPrimaryKeyViolation(err) {
-			log.Printf("Duplicate message detected (ID: %s), ignoring", msg.MessageID)
+		// A resend of a stored message gets its ack again, without another broadcast
+		var dupErr *messaging.DuplicateMessageError
+		if errors.As(err, &dupErr) {
+			h.sendAck(client, dupErr.Message)
+			return
+		}
+		// The ID is taken by another sender
+		if database.IsUniqueViolation(err) {
+			h.sendMessageError(client, msg.MessageID, ErrorCodeDuplicateID)
+			return
+		}
+		var limitErr *messaging.RateLimitError
+		if errors.As(err, &limitErr) {
+			h.writeToClient(client, ErrorMessage{
+				Type:       MsgTypeError,
+				MessageID:  msg.MessageID,
+				Code:       ErrorCodeRateLimited,
+				RetryAfter: retryAfterSeconds(limitErr),
+			})
 			return
 		}
-		log.Printf("Error storing message: %v", err)
+
+		switch err.Error() {
+		case apierrors.ErrorUserNotInChat:
+			h.sendMessageError(client, msg.MessageID, ErrorCodeNotInChat)
+		case apierrors.ErrorEmptyMessage:
+			h.sendMessageError(client, msg.MessageID, ErrorCodeEmptyMessage)
+		case apierrors.ErrorInvalidAttachment:
+			h.sendMessageError(client, msg.MessageID, ErrorCodeInvalidAttachment)
+		case apierrors.ErrorInvalidCiphertext:
+			h.sendMessageError(client, msg.MessageID, ErrorCodeInvalidCiphertext)
+		case apierrors.ErrorEncryptedGroupChat:
+			h.sendMessageError(client, msg.MessageID, ErrorCodeNotDirectChat)
+		default:
+			log.Printf("Error storing message: %v", err)
+			h.sendMessageError(client, msg.MessageID, ErrorCodeInternal)
+		}
 		return
 	}
 
-	// Update the sent time and sender ID in the message
-	msg.SentAt = sentAt
-	msg.SenderID = client.userID
+	h.sendAck(client, stored)
 
-	// Marshal message to JSON
-	msgData, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("Error marshaling chat message: %v", err)
-		return
-	}
+	// Update the sent time, seq, sender ID, attachments and kind in the message
+	msg.Content = stored.Content
+	msg.Ciphertext = stored.Ciphertext
+	msg.SentAt = stored.SentAt
+	msg.Seq = stored.Seq
+	msg.SenderID = client.userID
+	msg.Attachments = stored.Attachments
+	msg.Kind = stored.Kind
+	msg.Event = ""
+	msg.TargetUserID = nil
 
 	// Get all participants in the chat
 	participants, err := h.messagineService.GetChatParticipantsForBroadcast(msg.ChatID)
@@ -206,32 +392,68 @@ func (h *Handler) handleChatMessage(client *Client, msg ChatMessage) {
 		return
 	}
 
-	// Track which participants are offline to send push notifications
-	offlineParticipants := make([]int, 0)
-
-	// Send message to all online participants
-	h.clientsMutex.RLock()
-	for _, userID := range participants {
-		if client, ok := h.clients[userID]; ok {
-			// Participant is online, send via WebSocket
-			if err := client.conn.WriteMessage(websocket.TextMessage, msgData); err != nil {
-				log.Printf("Error sending message to user %d: %v", userID, err)
-			}
-		} else {
-			// Participant is offline, add to list for push notification
-			offlineParticipants = append(offlineParticipants, userID)
-		}
-	}
-	h.clientsMutex.RUnlock()
+	// Send message to every device of the online participants
+	h.deliver(participants, newFrame(msg))
 
 	// Send push notifications to offline participants
+	offlineParticipants := h.offlineUsers(participants)
 	if len(offlineParticipants) > 0 {
 		h.sendChatPushNotifications(client.userID, msg, offlineParticipants)
 	}
 }
 
-// sendChatPushNotifications sends push notifications to offline participants
+// storeMessage stores a message sent by a user, end-to-end encrypted when it carries a ciphertext.
+// Encrypted messages have neither content nor attachments.
+func (h *Handler) storeMessage(messageID string, chatID string, senderID int, content string, ciphertext string, mediaIDs []int) (*messaging.ChatMessage, error) {
+	if ciphertext == "" {
+		return h.messagineService.AddMessageWithAttachments(messageID, chatID, senderID, content, mediaIDs)
+	}
+	if content != "" || len(mediaIDs) > 0 {
+		return nil, errors.New(apierrors.ErrorInvalidCiphertext)
+	}
+	return h.messagineService.AddEncryptedMessage(context.Background(), messageID, chatID, senderID, ciphertext)
+}
+
+// sendAck confirms to the sender that the message is stored
+func (h *Handler) sendAck(client *Client, stored *messaging.ChatMessage) {
+	h.writeToClient(client, AckMessage{
+		Type:      MsgTypeAck,
+		MessageID: stored.MessageID,
+		Seq:       stored.Seq,
+		SentAt:    stored.SentAt,
+	})
+}
+
+// sendMessageError tells the sender that the message was not stored
+func (h *Handler) sendMessageError(client *Client, messageID string, code string) {
+	h.writeToClient(client, ErrorMessage{Type: MsgTypeError, MessageID: messageID, Code: code})
+}
+
+// writeToClient sends a message to a single connection
+func (h *Handler) writeToClient(client *Client, message interface{}) {
+	if err := client.send(newFrame(message)); err != nil {
+		log.Printf("Error sending message to user %d: %v", client.userID, err)
+	}
+}
+
+// sendChatPushNotifications sends push notifications to offline participants who have not muted the chat
 func (h *Handler) sendChatPushNotifications(senderID int, msg ChatMessage, recipients []int) {
+	// When the settings cannot be loaded, notifying everyone beats losing notifications
+	muted, err := h.messagineService.GetMutedParticipants(context.Background(), msg.ChatID)
+	if err != nil {
+		log.Printf("Error fetching muted participants for push notification: %v", err)
+	}
+	unmuted := make([]int, 0, len(recipients))
+	for _, userID := range recipients {
+		if _, ok := muted[userID]; !ok {
+			unmuted = append(unmuted, userID)
+		}
+	}
+	if len(unmuted) == 0 {
+		return
+	}
+	recipients = unmuted
+
 	// Get sender profile to include name in notification
 	senderProfile, err := h.profileService.GetProfile(senderID)
 	if err != nil {
@@ -252,12 +474,21 @@ func (h *Handler) sendChatPushNotifications(senderID int, msg ChatMessage, recip
 		title = fmt.Sprintf("%s in %s", senderProfile.FullName, *chatDetails.ChatName)
 	}
 
-	// Create notification payload
+	// Create notification payload; encrypted messages get a generic preview
+	body := msg.Content
+	if msg.Ciphertext != "" {
+		body = encryptedMessagePreview
+	} else if body == "" && len(msg.Attachments) > 0 {
+		body = attachmentPreview(msg.Attachments)
+	}
+
+	// Notifications of a chat replace each other on the device, so a busy chat shows one
 	payload := push.NotificationPayload{
-		Title: title,
-		Body:  msg.Content,
-		Sound: "default",
-		Badge: 1,
+		Title:      title,
+		Body:       body,
+		Sound:      "default",
+		Category:   push.CategoryNewMessage,
+		CollapseID: chatCollapseID(msg.ChatID),
 	}
 
 	// If sender has avatar, include it
@@ -265,26 +496,39 @@ func (h *Handler) sendChatPushNotifications(senderID int, msg ChatMessage, recip
 		payload.ImageURL = senderProfile.Avatar.URL
 	}
 
+	// The badge shows every unread message of the recipient; at least this one when the counts are unknown
+	unread, err := h.messagineService.GetUnreadCounts(context.Background(), msg.ChatID, recipients)
+	if err != nil {
+		log.Printf("Error fetching unread counts for push notification: %v", err)
+	}
+
 	// Send notifications to each offline recipient
 	for _, recipientID := range recipients {
-		go func(userID int) {
+		recipientPayload := payload
+		recipientPayload.Badge = max(unread[recipientID].Total, 1)
+		go func(userID int, payload push.NotificationPayload) {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
 			if err := h.pushService.SendNotification(ctx, userID, payload); err != nil {
 				log.Printf("Error sending push notification to user %d: %v", userID, err)
 			}
-		}(recipientID)
+		}(recipientID, recipientPayload)
 	}
 }
 
+// chatCollapseID groups the push notifications of a chat
+func chatCollapseID(chatID string) string {
+	return "chat:" + chatID
+}
+
 // handleReaction handles client adding a reaction via WebSocket
 func (h *Handler) handleReaction(client *Client, msg ReactionMessage) {
 	// Add reaction using service
 	err := h.messagineService.AddReaction(msg.ReactionID, msg.MessageID, client.userID, msg.ReactionCode)
 	if err != nil {
 		// Check if it's a duplicate reaction (UUID constraint violation)
-		if isPrimaryKeyViolation(err) {
+		if database.IsUniqueViolation(err) {
 			log.Printf("Duplicate reaction detected (ID: %s), ignoring", msg.ReactionID)
 			return
 		}
@@ -304,15 +548,8 @@ func (h *Handler) handleReaction(client *Client, msg ReactionMessage) {
 	msg.ReactedAt = time.Now()
 	msg.ChatID = chatID
 
-	// Marshal message
-	msgData, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("Error marshaling reaction: %v", err)
-		return
-	}
-
 	// Broadcast reaction to all participants in the chat
-	h.broadcastToChat(chatID, msgData)
+	h.broadcastToChat(chatID, newFrame(msg))
 }
 
 // handleTypingIndicator handles typing indicators from clients
@@ -327,42 +564,191 @@ func (h *Handler) handleTypingIndicator(client *Client, msg TypingMessage) {
 	msg.UserID = client.userID
 	msg.Timestamp = time.Now()
 
-	// Marshal message
-	msgData, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("Error marshaling typing notification: %v", err)
-		return
-	}
-
 	// Broadcast to other participants (excluding the sender)
-	h.broadcastToChatExcept(msg.ChatID, msgData, client.userID)
+	h.broadcastToChatExcept(msg.ChatID, newFrame(msg), client.userID)
+}
+
+// encryptedMessagePreview stands for end-to-end encrypted messages in notifications,
+// the server cannot show their content
+const encryptedMessagePreview = "🔒 New message"
+
+// attachmentPreview describes a message without text in notifications
+func attachmentPreview(attachments []messaging.Attachment) string {
+	if attachments[0].Type == "video" {
+		return "🎬 Video"
+	}
+	return "📷 Photo"
 }
 
 // handleReadReceipt handles read receipts from clients
 func (h *Handler) handleReadReceipt(client *Client, msg ReadReceiptMessage) {
 	// Store read receipt
-	if err := h.messagineService.StoreReadReceipt(client.userID, msg.ChatID, msg.MessageID); err != nil {
+	state, err := h.messagineService.StoreReadReceipt(client.userID, msg.ChatID, msg.MessageID)
+	if err != nil {
 		log.Printf("Error storing read receipt: %v", err)
 		return
 	}
 
-	// Update with user ID and current time
-	msg.UserID = client.userID
-	msg.ReadAt = time.Now()
+	h.readReceiptStored(client.userID, msg.ChatID, msg.MessageID, state)
+}
 
-	// Marshal message
-	msgData, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("Error marshaling read receipt notification: %v", err)
+// readReceiptStored tells the other participants that the user read the chat up to the message
+// and updates the unread counters on the user's connection. Nothing is sent when the
+// read position did not move.
+func (h *Handler) readReceiptStored(userID int, chatID string, messageID string, state *messaging.ReadState) {
+	if state == nil {
 		return
 	}
 
 	// Broadcast read receipt to other participants
-	h.broadcastToChatExcept(msg.ChatID, msgData, client.userID)
+	h.broadcastToChatExcept(chatID, newFrame(ReadReceiptMessage{
+		BaseMessage: BaseMessage{Type: MsgTypeReadReceipt, ChatID: chatID},
+		UserID:      userID,
+		MessageID:   messageID,
+		ReadAt:      time.Now(),
+	}), userID)
+
+	h.syncReadStates(userID, []messaging.ReadState{*state})
+}
+
+// syncReadStates updates the unread counters on the user's connections and sends the
+// user's devices a silent push, so notifications of chats read on one device are cleared
+// and the badge is updated on the others
+func (h *Handler) syncReadStates(userID int, states []messaging.ReadState) {
+	if len(states) == 0 {
+		return
+	}
+
+	h.sendToUser(userID, newFrame(UnreadCountsMessage{Type: MsgTypeUnreadCounts, Chats: states}))
+
+	go h.sendReadStatePush(userID, states)
+}
+
+// sendReadStatePush sends a silent push listing the collapse IDs of the chats read to the end,
+// whose notifications the app removes, and the new badge
+func (h *Handler) sendReadStatePush(userID int, states []messaging.ReadState) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Without the total a stale badge is better than a wrong one
+	unread, err := h.messagineService.GetUnreadCounts(ctx, states[0].ChatID, []int{userID})
+	if err != nil {
+		log.Printf("Error fetching unread counts for read state push: %v", err)
+		return
+	}
+
+	cleared := make([]string, 0, len(states))
+	for _, state := range states {
+		if state.UnreadCount == 0 {
+			cleared = append(cleared, chatCollapseID(state.ChatID))
+		}
+	}
+
+	payload := push.NotificationPayload{
+		Silent: true,
+		Data: map[string]string{
+			"type":         readStatePushType,
+			"badge":        strconv.Itoa(unread[userID].Total),
+			"collapse_ids": strings.Join(cleared, ","),
+		},
+	}
+	if err := h.pushService.SendNotification(ctx, userID, payload); err != nil {
+		log.Printf("Error sending read state push to user %d: %v", userID, err)
+	}
+}
+
+// readStatePushType marks the silent pushes sent when the user reads chats on another device
+const readStatePushType = "read_state"
+
+// handleDeliveryReceipt stores a delivery receipt and tells the other participant of the
+// direct chat, unless the device had already acked a later message
+func (h *Handler) handleDeliveryReceipt(client *Client, msg DeliveryReceiptMessage) {
+	moved, err := h.messagineService.StoreDeliveryReceipt(context.Background(), client.userID, msg.ChatID, msg.MessageID)
+	if err != nil {
+		log.Printf("Error storing delivery receipt: %v", err)
+		return
+	}
+	if !moved {
+		return
+	}
+
+	h.broadcastToChatExcept(msg.ChatID, newFrame(DeliveryReceiptMessage{
+		BaseMessage: BaseMessage{Type: MsgTypeDeliveryReceipt, ChatID: msg.ChatID},
+		UserID:      client.userID,
+		MessageID:   msg.MessageID,
+		DeliveredAt: time.Now(),
+	}), client.userID)
+}
+
+// MessagePosted broadcasts a message created by the server, e.g. a bot reply or a system message
+func (h *Handler) MessagePosted(msg messaging.ChatMessage) {
+	h.broadcastToChat(msg.ChatID, newFrame(ChatMessage{
+		BaseMessage: BaseMessage{
+			Type:   MsgTypeChatMessage,
+			ChatID: msg.ChatID,
+		},
+		MessageID:    msg.MessageID,
+		SenderID:     msg.SenderID,
+		Content:      msg.Content,
+		SentAt:       msg.SentAt,
+		Seq:          msg.Seq,
+		Attachments:  msg.Attachments,
+		Kind:         msg.Kind,
+		Event:        msg.Event,
+		TargetUserID: msg.TargetUserID,
+	}))
+}
+
+// PreviewReady broadcasts the link preview of a message once it has been stored
+func (h *Handler) PreviewReady(chatID string, messageID string, preview messaging.LinkPreview) {
+	h.broadcastToChat(chatID, newFrame(MessagePreviewReadyMessage{
+		BaseMessage: BaseMessage{Type: MsgTypePreviewReady, ChatID: chatID},
+		MessageID:   messageID,
+		Preview:     preview,
+	}))
+}
+
+// addClient registers a connection; a user may have several at once
+func (h *Handler) addClient(client *Client) {
+	h.clientsMutex.Lock()
+	defer h.clientsMutex.Unlock()
+
+	connections, ok := h.clients[client.userID]
+	if !ok {
+		connections = make(map[*Client]struct{})
+		h.clients[client.userID] = connections
+	}
+	connections[client] = struct{}{}
+}
+
+// removeClient unregisters a closed connection, keeping the user's other ones
+func (h *Handler) removeClient(client *Client) {
+	h.clientsMutex.Lock()
+	defer h.clientsMutex.Unlock()
+
+	connections := h.clients[client.userID]
+	delete(connections, client)
+	if len(connections) == 0 {
+		delete(h.clients, client.userID)
+	}
+}
+
+// sendToUser sends a message to all connections of the user, if any
+func (h *Handler) sendToUser(userID int, message *frame) {
+	h.deliver([]int{userID}, message)
+}
+
+// sendToConnections sends a message to all connections of the user; callers hold clientsMutex
+func (h *Handler) sendToConnections(userID int, message *frame) {
+	for client := range h.clients[userID] {
+		if err := client.send(message); err != nil {
+			log.Printf("Error sending message to user %d: %v", userID, err)
+		}
+	}
 }
 
 // broadcastToChat sends a message to all clients in a chat
-func (h *Handler) broadcastToChat(chatID string, message []byte) {
+func (h *Handler) broadcastToChat(chatID string, message *frame) {
 	// Get all participants in the chat
 	participants, err := h.messagineService.GetChatParticipantsForBroadcast(chatID)
 	if err != nil {
@@ -371,38 +757,23 @@ func (h *Handler) broadcastToChat(chatID string, message []byte) {
 	}
 
 	// Send message to all online participants
-	h.clientsMutex.RLock()
-	defer h.clientsMutex.RUnlock()
-
-	for _, userID := range participants {
-		if client, ok := h.clients[userID]; ok {
-			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
-				log.Printf("Error sending message to user %d: %v", userID, err)
-			}
-		}
-	}
+	h.deliver(participants, message)
 }
 
 // broadcastToChatExcept sends a message to all clients in a chat except the specified user
-func (h *Handler) broadcastToChatExcept(chatID string, message []byte, exceptUserID int) {
+func (h *Handler) broadcastToChatExcept(chatID string, message *frame, exceptUserID int) {
 	participants, err := h.messagineService.GetChatParticipants(chatID)
 	if err != nil {
 		log.Printf("Error fetching chat participants: %v", err)
 		return
 	}
 
-	h.clientsMutex.RLock()
-	defer h.clientsMutex.RUnlock()
-
+	recipients := make([]int, 0, len(participants))
 	for _, userID := range participants {
 		if userID == exceptUserID {
 			continue // Skip the excluded user
 		}
-
-		if client, ok := h.clients[userID]; ok {
-			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
-				log.Printf("Error sending message to user %d: %v", userID, err)
-			}
-		}
+		recipients = append(recipients, userID)
 	}
+	h.deliver(recipients, message)
 }
@@ -0,0 +1,166 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	messagingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// scriptedConn plays the given frames as if a client sent them, then closes
+type scriptedConn struct {
+	fakeConn
+	frames [][]byte
+}
+
+func (c *scriptedConn) ReadMessage() (int, []byte, error) {
+	if len(c.frames) == 0 {
+		return 0, nil, errors.New("closed")
+	}
+	frame := c.frames[0]
+	c.frames = c.frames[1:]
+	return 1, frame, nil
+}
+
+func TestChatMessageAcknowledgement(t *testing.T) {
+	sentAt := time.Now().UTC().Truncate(time.Second)
+	tests := []struct {
+		name          string
+		frame         string
+		inChat        bool
+		storeErr      error
+		wantAck       bool
+		wantCode      string
+		wantBroadcast bool
+	}{
+		{"stored", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, true, nil, true, "", true},
+		{"retry of stored message", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, true,
+			&messaging.DuplicateMessageError{Message: &messagingrepo.ChatMessage{MessageID: "m1", SenderID: 1, Seq: 7, SentAt: sentAt}}, true, "", false},
+		{"id taken by another sender", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, true, &pq.Error{Code: database.UniqueViolation},
+			false, ErrorCodeDuplicateID, false},
+		{"empty message", `{"type":"chat_message","chat_id":"c1","message_id":"m1"}`, true, errors.New(apierrors.ErrorEmptyMessage), false, ErrorCodeEmptyMessage, false},
+		{"rate limited", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, true, &messaging.RateLimitError{RetryAfter: 4 * time.Second}, false, ErrorCodeRateLimited, false},
+		{"storage failure", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, true, errors.New("db down"), false, ErrorCodeInternal, false},
+		{"not in chat", `{"type":"chat_message","chat_id":"c1","message_id":"m1","content":"hi"}`, false, nil, false, ErrorCodeNotInChat, false},
+		{"missing message ID", `{"type":"chat_message","chat_id":"c1","content":"hi"}`, true, nil, false, ErrorCodeInvalidMessage, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				IsUserInChatFunc: func(userID int, chatID string) (bool, error) {
+					return tt.inChat, nil
+				},
+				AddMessageWithAttachmentsFunc: func(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messagingrepo.ChatMessage, error) {
+					if tt.storeErr != nil {
+						return nil, tt.storeErr
+					}
+					return &messagingrepo.ChatMessage{MessageID: messageID, ChatID: chatID, SenderID: senderID, Content: content, Seq: 7, SentAt: sentAt}, nil
+				},
+				GetChatParticipantsForBroadcastFunc: func(chatID string) ([]int, error) {
+					return []int{1}, nil
+				},
+			}
+			h := newTestHandler(service)
+
+			conn := &scriptedConn{frames: [][]byte{[]byte(tt.frame)}}
+			client := &Client{conn: conn, userID: 1}
+			h.addClient(client)
+			h.handleClient(client)
+
+			var frames []map[string]interface{}
+			for _, data := range conn.written {
+				var frame map[string]interface{}
+				assert.NoError(t, json.Unmarshal(data, &frame))
+				frames = append(frames, frame)
+			}
+			if !assert.NotEmpty(t, frames) {
+				return
+			}
+
+			reply := frames[0]
+			if tt.wantAck {
+				var ack AckMessage
+				assert.NoError(t, json.Unmarshal(conn.written[0], &ack))
+				assert.Equal(t, AckMessage{Type: MsgTypeAck, MessageID: "m1", Seq: 7, SentAt: sentAt}, ack)
+			} else {
+				assert.Equal(t, MsgTypeError, reply["type"])
+				assert.Equal(t, tt.wantCode, reply["code"])
+			}
+			if tt.wantCode == ErrorCodeRateLimited {
+				assert.Equal(t, float64(4), reply["retry_after"])
+			}
+
+			if tt.wantBroadcast {
+				if assert.Len(t, frames, 2) {
+					assert.Equal(t, MsgTypeChatMessage, frames[1]["type"])
+				}
+			} else {
+				assert.Len(t, frames, 1)
+			}
+		})
+	}
+}
+
+func TestDeliveryReceiptForwardedToSender(t *testing.T) {
+	tests := []struct {
+		name        string
+		moved       bool
+		wantForward bool
+	}{
+		{"new position", true, true},
+		{"already delivered further", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServiceMock{
+				StoreDeliveryReceiptFunc: func(ctx context.Context, userID int, chatID string, messageID string) (bool, error) {
+					return tt.moved, nil
+				},
+				GetChatParticipantsFunc: func(chatID string) ([]int, error) {
+					return []int{1, 2}, nil
+				},
+			}
+			h := newTestHandler(service)
+
+			recipient := &fakeConn{}
+			sender := &fakeConn{}
+			client := &Client{conn: recipient, userID: 2}
+			h.addClient(client)
+			h.addClient(&Client{conn: sender, userID: 1})
+
+			h.handleDeliveryReceipt(client, DeliveryReceiptMessage{
+				BaseMessage: BaseMessage{Type: MsgTypeDeliveryReceipt, ChatID: "c1"},
+				MessageID:   "m5",
+			})
+
+			call := service.StoreDeliveryReceiptCalls()[0]
+			assert.Equal(t, 2, call.UserID)
+			assert.Equal(t, "c1", call.ChatID)
+			assert.Equal(t, "m5", call.MessageID)
+			assert.Empty(t, recipient.written)
+			if !tt.wantForward {
+				assert.Empty(t, sender.written)
+				return
+			}
+			if assert.Len(t, sender.written, 1) {
+				var msg DeliveryReceiptMessage
+				assert.NoError(t, json.Unmarshal(sender.written[0], &msg))
+				assert.Equal(t, MsgTypeDeliveryReceipt, msg.Type)
+				assert.Equal(t, "c1", msg.ChatID)
+				assert.Equal(t, 2, msg.UserID)
+				assert.Equal(t, "m5", msg.MessageID)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/onboarding"
+)
+
+//go:generate moq -out mocks_test.go . OnboardingService
+
+// OnboardingService defines the onboarding operations used by the handler
+type OnboardingService interface {
+	GetAnswers(ctx context.Context, userID int) (*onboarding.Answers, error)
+	SaveAnswers(ctx context.Context, userID int, answers onboarding.Answers) (*onboarding.Answers, error)
+	GetStatus(ctx context.Context, userID int) (*onboarding.Status, error)
+}
+
+// Handler handles onboarding quiz and progress endpoints
+type Handler struct {
+	service OnboardingService
+}
+
+// NewHandler creates a new onboarding handler
+func NewHandler(service OnboardingService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// QuizRequest represents the onboarding quiz answers
+type QuizRequest struct {
+	PreferredFormats []string `json:"preferred_formats"`
+	Schedule         *string  `json:"schedule,omitempty"`
+	Experience       *string  `json:"experience,omitempty"`
+}
+
+// @Summary      Get onboarding quiz answers
+// @Description  Get the onboarding quiz answers of the current user. Answers are not part of the public profile.
+// @Tags         onboarding
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  onboarding.Answers
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Quiz not answered yet"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /onboarding/quiz [get]
+func (h *Handler) GetQuiz(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	answers, err := h.service.GetAnswers(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(answers)
+}
+
+// @Summary      Save onboarding quiz answers
+// @Description  Save the onboarding quiz answers of the current user, replacing previous answers.
+// @Description  Preferred formats are used to recommend profiles and teams until the user fills in improv styles.
+// @Tags         onboarding
+// @Accept       json
+// @Produce      json
+// @Param        request  body  QuizRequest  true  "Quiz answers"
+// @Security     BearerAuth
+// @Success      200  {object}  onboarding.Answers
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /onboarding/quiz [put]
+func (h *Handler) SaveQuiz(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req QuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	answers, err := h.service.SaveAnswers(r.Context(), userID, onboarding.Answers{
+		PreferredFormats: req.PreferredFormats,
+		Schedule:         req.Schedule,
+		Experience:       req.Experience,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(answers)
+}
+
+// @Summary      Get onboarding status
+// @Description  Onboarding steps of the current user and which of them remain, so the app can drive the onboarding UI.
+// @Description  Steps: profile_created, avatar_uploaded (including avatars in review), quiz_answered, first_search_done.
+// @Tags         onboarding
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  onboarding.Status
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /profiles/me/onboarding [get]
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.service.GetStatus(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, onboarding.ErrAnswersNotFound):
+		http.Error(w, "Quiz not answered yet", http.StatusNotFound)
+	case errors.Is(err, onboarding.ErrInvalidImprovStyle):
+		http.Error(w, "Invalid improv style", http.StatusBadRequest)
+	case errors.Is(err, onboarding.ErrInvalidSchedule):
+		http.Error(w, "Invalid schedule", http.StatusBadRequest)
+	case errors.Is(err, onboarding.ErrInvalidExperience):
+		http.Error(w, "Invalid experience", http.StatusBadRequest)
+	default:
+		log.Printf("Onboarding error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,140 @@
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/onboarding"
+)
+
+func TestGetQuiz(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"not answered", 1, onboarding.ErrAnswersNotFound, http.StatusNotFound},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &OnboardingServiceMock{
+				GetAnswersFunc: func(ctx context.Context, userID int) (*onboarding.Answers, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &onboarding.Answers{PreferredFormats: []string{"longform"}}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetQuiz(rec, handlertest.NewRequest(http.MethodGet, "/api/onboarding/quiz", nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp onboarding.Answers
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, []string{"longform"}, resp.PreferredFormats)
+			}
+		})
+	}
+}
+
+func TestSaveQuiz(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid style", onboarding.ErrInvalidImprovStyle, http.StatusBadRequest},
+		{"invalid schedule", onboarding.ErrInvalidSchedule, http.StatusBadRequest},
+		{"invalid experience", onboarding.ErrInvalidExperience, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &OnboardingServiceMock{
+				SaveAnswersFunc: func(ctx context.Context, userID int, answers onboarding.Answers) (*onboarding.Answers, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &answers, nil
+				},
+			}
+			h := NewHandler(service)
+
+			schedule := onboarding.ScheduleWeekends
+			body := QuizRequest{PreferredFormats: []string{"shortform"}, Schedule: &schedule}
+
+			rec := httptest.NewRecorder()
+			h.SaveQuiz(rec, handlertest.NewRequest(http.MethodPut, "/api/onboarding/quiz", body, 3, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.SaveAnswersCalls()[0]
+			assert.Equal(t, 3, call.UserID)
+			assert.Equal(t, []string{"shortform"}, call.Answers.PreferredFormats)
+			assert.Equal(t, onboarding.ScheduleWeekends, *call.Answers.Schedule)
+			assert.Nil(t, call.Answers.Experience)
+		})
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &OnboardingServiceMock{
+				GetStatusFunc: func(ctx context.Context, userID int) (*onboarding.Status, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &onboarding.Status{
+						Steps: []onboarding.Step{
+							{Code: onboarding.StepProfileCreated, Done: true},
+							{Code: onboarding.StepAvatarUploaded, Done: false},
+						},
+						Remaining: []string{onboarding.StepAvatarUploaded},
+					}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetStatus(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/me/onboarding", nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				assert.Equal(t, 1, service.GetStatusCalls()[0].UserID)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp onboarding.Status
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, []string{onboarding.StepAvatarUploaded}, resp.Remaining)
+				assert.False(t, resp.Completed)
+			}
+		})
+	}
+}
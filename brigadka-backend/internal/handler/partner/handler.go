@@ -0,0 +1,157 @@
+package partner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/partner"
+)
+
+//go:generate moq -out mocks_test.go . PartnerService
+
+// PartnerService defines the partner queue operations used by the handler
+type PartnerService interface {
+	Join(ctx context.Context, userID int, req partner.JoinRequest) (*partner.QueueEntry, error)
+	GetQueueEntry(ctx context.Context, userID int) (*partner.QueueEntry, error)
+	Leave(ctx context.Context, userID int) error
+}
+
+// Handler handles partner queue endpoints
+type Handler struct {
+	service PartnerService
+}
+
+// NewHandler creates a new partner queue handler
+func NewHandler(service PartnerService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// JoinQueueRequest represents the request body for joining the partner queue
+type JoinQueueRequest struct {
+	CityID         int       `json:"city_id"`
+	Level          string    `json:"level"` // beginner, intermediate or advanced
+	AvailableFrom  time.Time `json:"available_from"`
+	AvailableUntil time.Time `json:"available_until"`
+}
+
+// @Summary      Find Practice Partner
+// @Description  Puts the current user in the queue for a scene partner in a city within a time window ending within a day. Users at most one level apart, sharing an improv style and free for an hour together are paired, get a direct chat and a push notification.
+// @Tags         partners
+// @Accept       json
+// @Produce      json
+// @Param        request  body  JoinQueueRequest  true  "City, level and time window"
+// @Security     BearerAuth
+// @Success      201  {object}  partner.QueueEntry
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      409  {string}  string  "Already waiting for a partner"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /partners/queue [post]
+func (h *Handler) JoinQueue(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req JoinQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.service.Join(r.Context(), userID, partner.JoinRequest{
+		CityID:         req.CityID,
+		Level:          req.Level,
+		AvailableFrom:  req.AvailableFrom,
+		AvailableUntil: req.AvailableUntil,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// @Summary      Get Partner Queue Entry
+// @Description  Returns the current user's request for a practice partner, with the partner and chat once matched
+// @Tags         partners
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  partner.QueueEntry
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Not waiting for a partner"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /partners/queue [get]
+func (h *Handler) GetQueueEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entry, err := h.service.GetQueueEntry(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// @Summary      Leave Partner Queue
+// @Description  Takes the current user out of the queue for a practice partner
+// @Tags         partners
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Not waiting for a partner"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /partners/queue [delete]
+func (h *Handler) LeaveQueue(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Leave(r.Context(), userID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, partner.ErrNotWaiting):
+		http.Error(w, "Not waiting for a partner", http.StatusNotFound)
+	case errors.Is(err, partner.ErrAlreadyWaiting):
+		http.Error(w, "Already waiting for a partner", http.StatusConflict)
+	case errors.Is(err, partner.ErrInvalidCity):
+		http.Error(w, "Invalid city", http.StatusBadRequest)
+	case errors.Is(err, partner.ErrInvalidLevel):
+		http.Error(w, "Invalid level", http.StatusBadRequest)
+	case errors.Is(err, partner.ErrInvalidWindow):
+		http.Error(w, "Time window must last at least an hour and end within a day", http.StatusBadRequest)
+	default:
+		log.Printf("Partner queue error: %v", err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,130 @@
+package partner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/partner"
+)
+
+func TestJoinQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 7, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"already waiting", 7, partner.ErrAlreadyWaiting, http.StatusConflict},
+		{"invalid window", 7, partner.ErrInvalidWindow, http.StatusBadRequest},
+		{"invalid level", 7, partner.ErrInvalidLevel, http.StatusBadRequest},
+		{"server error", 7, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	from := time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PartnerServiceMock{
+				JoinFunc: func(ctx context.Context, userID int, req partner.JoinRequest) (*partner.QueueEntry, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &partner.QueueEntry{ID: 10, CityID: req.CityID, Level: req.Level, Status: "waiting"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := JoinQueueRequest{CityID: 1, Level: partner.LevelBeginner, AvailableFrom: from, AvailableUntil: from.Add(3 * time.Hour)}
+			rec := httptest.NewRecorder()
+			h.JoinQueue(rec, handlertest.NewRequest(http.MethodPost, "/api/partners/queue", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.JoinCalls()[0]
+				assert.Equal(t, tt.userID, call.UserID)
+				assert.Equal(t, 1, call.Req.CityID)
+				assert.True(t, from.Add(3*time.Hour).Equal(call.Req.AvailableUntil))
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp partner.QueueEntry
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 10, resp.ID)
+				assert.Nil(t, resp.Match)
+			}
+		})
+	}
+}
+
+func TestGetQueueEntry(t *testing.T) {
+	chatID := "chat-1"
+	tests := []struct {
+		name       string
+		entry      *partner.QueueEntry
+		serviceErr error
+		wantStatus int
+	}{
+		{"matched", &partner.QueueEntry{ID: 10, Status: "matched", Match: &partner.Match{PartnerID: 8, ChatID: &chatID}}, nil, http.StatusOK},
+		{"not waiting", nil, partner.ErrNotWaiting, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PartnerServiceMock{
+				GetQueueEntryFunc: func(ctx context.Context, userID int) (*partner.QueueEntry, error) {
+					return tt.entry, tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.GetQueueEntry(rec, handlertest.NewRequest(http.MethodGet, "/api/partners/queue", nil, 7, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp partner.QueueEntry
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				if assert.NotNil(t, resp.Match) {
+					assert.Equal(t, 8, resp.Match.PartnerID)
+					assert.Equal(t, "chat-1", *resp.Match.ChatID)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaveQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not waiting", partner.ErrNotWaiting, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PartnerServiceMock{
+				LeaveFunc: func(ctx context.Context, userID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.LeaveQueue(rec, handlertest.NewRequest(http.MethodDelete, "/api/partners/queue", nil, 7, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, 7, service.LeaveCalls()[0].UserID)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EndorseRequest names the improv style a profile is endorsed for
+type EndorseRequest struct {
+	Style string `json:"style"`
+}
+
+// @Summary      Endorse Profile
+// @Description  Endorses another user's profile for an improv style, e.g. longform. Only members of a team the profile owner is also in can endorse it; endorsing the same style again is a no-op. Profiles show the counts in endorsements.
+// @Tags         profile
+// @Accept       json
+// @Param        userID   path  int             true  "User ID of the profile"
+// @Param        request  body  EndorseRequest  true  "Improv style"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request, invalid improv style or own profile"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only teammates can endorse a profile"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/{userID}/endorsements [post]
+func (h *ProfileHandler) Endorse(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileUserID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req EndorseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.profileService.Endorse(currentUserID, profileUserID, req.Style); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Remove Endorsement
+// @Description  Withdraws the current user's endorsement of a profile for an improv style
+// @Tags         profile
+// @Param        userID  path  int     true  "User ID of the profile"
+// @Param        style   path  string  true  "Improv style"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/{userID}/endorsements/{style} [delete]
+func (h *ProfileHandler) RemoveEndorsement(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileUserID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.profileService.RemoveEndorsement(currentUserID, profileUserID, chi.URLParam(r, "style")); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
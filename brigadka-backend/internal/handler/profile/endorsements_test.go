@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+func TestEndorse(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "2", nil, http.StatusNoContent},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"self", "1", profile.ErrCannotEndorseSelf, http.StatusBadRequest},
+		{"invalid style", "2", profile.ErrInvalidImprovStyle, http.StatusBadRequest},
+		{"not a teammate", "3", profile.ErrNoSharedTeam, http.StatusForbidden},
+		{"missing profile", "4", profile.ErrProfileNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				EndorseFunc: func(userID int, profileUserID int, style string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			body := EndorseRequest{Style: "longform"}
+			h.Endorse(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/"+tt.userID+"/endorsements", body, 1, map[string]string{"userID": tt.userID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != "abc" {
+				call := service.EndorseCalls()[0]
+				assert.Equal(t, 1, call.UserID)
+				assert.Equal(t, "longform", call.Style)
+			}
+		})
+	}
+}
+
+func TestRemoveEndorsement(t *testing.T) {
+	service := &ProfileServiceMock{
+		RemoveEndorsementFunc: func(userID int, profileUserID int, style string) error {
+			return nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.RemoveEndorsement(rec, handlertest.NewRequest(http.MethodDelete, "/api/profiles/2/endorsements/longform", nil, 1, map[string]string{"userID": "2", "style": "longform"}))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	call := service.RemoveEndorsementCalls()[0]
+	assert.Equal(t, 1, call.UserID)
+	assert.Equal(t, 2, call.ProfileUserID)
+	assert.Equal(t, "longform", call.Style)
+}
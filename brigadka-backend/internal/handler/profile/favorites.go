@@ -0,0 +1,116 @@
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// @Summary      Add Favorite
+// @Description  Adds a profile to the current user's favorites
+// @Tags         profile
+// @Param        userID  path  int  true  "User ID of the profile"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Profile not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/{userID}/favorite [post]
+func (h *ProfileHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileUserID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.profileService.AddFavorite(currentUserID, profileUserID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Remove Favorite
+// @Description  Removes a profile from the current user's favorites
+// @Tags         profile
+// @Param        userID  path  int  true  "User ID of the profile"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/{userID}/favorite [delete]
+func (h *ProfileHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileUserID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.profileService.RemoveFavorite(currentUserID, profileUserID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      List Favorites
+// @Description  Returns the current user's favorite profiles, most recently added first
+// @Tags         profile
+// @Produce      json
+// @Param        page       query  int  false  "Page number (default: 1)"
+// @Param        page_size  query  int  false  "Page size (default: 20, max: 100)"
+// @Security     BearerAuth
+// @Success      200  {object}  SearchResponse
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/favorites [get]
+func (h *ProfileHandler) GetFavorites(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Invalid values fall back to service defaults
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	result, err := h.profileService.GetFavorites(currentUserID, page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	profiles := make([]ProfileResponse, 0, len(result.Profiles))
+	for _, p := range result.Profiles {
+		profiles = append(profiles, convertToProfileResponse(&p))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(SearchResponse{
+		Profiles:   profiles,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
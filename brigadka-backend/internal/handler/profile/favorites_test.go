@@ -0,0 +1,74 @@
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+func TestAddFavorite(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "2", nil, http.StatusNoContent},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"self", "1", profile.ErrCannotFavoriteSelf, http.StatusBadRequest},
+		{"missing profile", "3", profile.ErrProfileNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				AddFavoriteFunc: func(userID int, profileUserID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.AddFavorite(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/"+tt.userID+"/favorite", nil, 1, map[string]string{"userID": tt.userID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != "abc" {
+				assert.Equal(t, 1, service.AddFavoriteCalls()[0].UserID)
+			}
+		})
+	}
+}
+
+func TestGetFavorites(t *testing.T) {
+	service := &ProfileServiceMock{
+		GetFavoritesFunc: func(userID int, page int, pageSize int) (*profile.SearchResult, error) {
+			return &profile.SearchResult{
+				Profiles:   []profile.Profile{{UserID: 2, IsFavorite: true}},
+				TotalCount: 1,
+				Page:       2,
+				PageSize:   10,
+			}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.GetFavorites(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/favorites?page=2&page_size=10", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.GetFavoritesCalls()[0]
+	assert.Equal(t, 2, call.Page)
+	assert.Equal(t, 10, call.PageSize)
+
+	var resp SearchResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp.Profiles, 1) {
+		assert.True(t, resp.Profiles[0].IsFavorite)
+	}
+}
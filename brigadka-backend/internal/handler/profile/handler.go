@@ -1,12 +1,16 @@
 package profile
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/export"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
 	"github.com/go-chi/chi/v5"
 )
@@ -49,72 +53,105 @@ func (d Date) MarshalJSON() ([]byte, error) {
 
 // ProfileResponse represents profile data for response
 type ProfileResponse struct {
-	UserID         int             `json:"user_id"`
-	FullName       string          `json:"full_name"`
-	Birthday       Date            `json:"birthday,omitempty"`
-	Gender         string          `json:"gender,omitempty"`
-	CityID         int             `json:"city_id,omitempty"`
-	Bio            string          `json:"bio,omitempty"`
-	Goal           string          `json:"goal,omitempty"`
-	LookingForTeam bool            `json:"looking_for_team"`
-	ImprovStyles   []string        `json:"improv_styles,omitempty"`
-	Avatar         *profile.Media  `json:"avatar,omitempty"`
-	Videos         []profile.Media `json:"videos,omitempty"`
-	CreatedAt      time.Time       `json:"created_at,omitempty"`
+	UserID                int                        `json:"user_id"`
+	FullName              string                     `json:"full_name"`
+	Birthday              Date                       `json:"birthday,omitempty"`
+	Gender                string                     `json:"gender,omitempty"`
+	CityID                int                        `json:"city_id,omitempty"`
+	Bio                   string                     `json:"bio,omitempty"`
+	Goal                  string                     `json:"goal,omitempty"` // Primary goal, the first of goals
+	Goals                 []string                   `json:"goals,omitempty"`
+	LookingForTeam        bool                       `json:"looking_for_team"`
+	AllowOrganizerContact bool                       `json:"allow_organizer_contact"`
+	IsFavorite            bool                       `json:"is_favorite"`
+	IsVerified            bool                       `json:"is_verified"` // Set by admins for well-known teachers and theaters
+	ImprovStyles          []string                   `json:"improv_styles,omitempty"`
+	Tags                  []string                   `json:"tags,omitempty"`
+	Avatar                *profile.Media             `json:"avatar,omitempty"`
+	AudioIntro            *profile.Media             `json:"audio_intro,omitempty"`
+	Videos                []profile.Media            `json:"videos,omitempty"`
+	Links                 *profile.Links             `json:"links,omitempty"`
+	Availability          []profile.AvailabilitySlot `json:"availability,omitempty"`
+	Endorsements          []profile.Endorsement      `json:"endorsements,omitempty"` // Counts by improv style, most endorsed first
+	CreatedAt             time.Time                  `json:"created_at,omitempty"`
+	DistanceKm            *float64                   `json:"distance_km,omitempty"` // Distance to the city, in searches near a point
 }
 
 // ProfileCreateRequest represents data needed to create a profile
 type ProfileCreateRequest struct {
-	UserID         int      `json:"user_id" validate:"required"`
-	FullName       string   `json:"full_name" validate:"required"`
-	Birthday       Date     `json:"birthday" validate:"required"`
-	Gender         string   `json:"gender" validate:"required"`
-	CityID         int      `json:"city_id" validate:"required"`
-	Bio            string   `json:"bio" validate:"required"`
-	Goal           string   `json:"goal" validate:"required"`
-	ImprovStyles   []string `json:"improv_styles" validate:"required"`
-	LookingForTeam bool     `json:"looking_for_team"`
-	Avatar         *int     `json:"avatar,omitempty"`
-	Videos         []int    `json:"videos,omitempty"`
+	UserID                int                        `json:"user_id" validate:"required"`
+	FullName              string                     `json:"full_name" validate:"required"`
+	Birthday              Date                       `json:"birthday" validate:"required"`
+	Gender                string                     `json:"gender" validate:"required"`
+	CityID                int                        `json:"city_id" validate:"required"`
+	Bio                   string                     `json:"bio" validate:"required"`
+	Goal                  string                     `json:"goal,omitempty"`  // Primary goal; goal or goals is required
+	Goals                 []string                   `json:"goals,omitempty"` // Additional goals
+	ImprovStyles          []string                   `json:"improv_styles" validate:"required"`
+	Tags                  []string                   `json:"tags,omitempty"` // Free-form, at most 10
+	LookingForTeam        bool                       `json:"looking_for_team"`
+	AllowOrganizerContact bool                       `json:"allow_organizer_contact"`
+	Avatar                *int                       `json:"avatar,omitempty"`
+	AudioIntro            *int                       `json:"audio_intro,omitempty"`
+	Videos                []int                      `json:"videos,omitempty"`
+	Links                 *profile.Links             `json:"links,omitempty"`
+	Availability          []profile.AvailabilitySlot `json:"availability,omitempty"`
 }
 
 // ProfileUpdateRequest represents data needed to update a profile
 type ProfileUpdateRequest struct {
-	FullName       *string  `json:"full_name,omitempty"`
-	Birthday       *Date    `json:"birthday,omitempty"`
-	Gender         *string  `json:"gender,omitempty"`
-	CityID         *int     `json:"city_id,omitempty"`
-	Bio            *string  `json:"bio,omitempty"`
-	Goal           *string  `json:"goal,omitempty"`
-	ImprovStyles   []string `json:"improv_styles,omitempty"`
-	LookingForTeam *bool    `json:"looking_for_team,omitempty"`
-	Avatar         *int     `json:"avatar,omitempty"`
-	Videos         []int    `json:"videos,omitempty"`
+	FullName              *string                    `json:"full_name,omitempty"`
+	Birthday              *Date                      `json:"birthday,omitempty"`
+	Gender                *string                    `json:"gender,omitempty"`
+	CityID                *int                       `json:"city_id,omitempty"`
+	Bio                   *string                    `json:"bio,omitempty"`
+	Goal                  *string                    `json:"goal,omitempty"`  // Replaces all goals when sent alone
+	Goals                 []string                   `json:"goals,omitempty"` // Replaces all goals; goal, if sent, becomes the primary one
+	ImprovStyles          []string                   `json:"improv_styles,omitempty"`
+	Tags                  []string                   `json:"tags,omitempty"` // Replaces all tags; an empty list clears them
+	LookingForTeam        *bool                      `json:"looking_for_team,omitempty"`
+	AllowOrganizerContact *bool                      `json:"allow_organizer_contact,omitempty"`
+	Avatar                *int                       `json:"avatar,omitempty"`
+	AudioIntro            *int                       `json:"audio_intro,omitempty"`
+	Videos                []int                      `json:"videos,omitempty"`
+	Links                 *profile.Links             `json:"links,omitempty"`
+	Availability          []profile.AvailabilitySlot `json:"availability,omitempty"`
 }
 
 // SearchRequest represents the search query parameters
 type SearchRequest struct {
-	FullName       *string    `json:"full_name,omitempty"`
-	LookingForTeam *bool      `json:"looking_for_team,omitempty"`
-	Goals          []string   `json:"goals,omitempty"`
-	ImprovStyles   []string   `json:"improv_styles,omitempty"`
-	AgeMin         *int       `json:"age_min,omitempty"`
-	AgeMax         *int       `json:"age_max,omitempty"`
-	Genders        []string   `json:"genders,omitempty"`
-	CityID         *int       `json:"city_id,omitempty"`
-	HasAvatar      *bool      `json:"has_avatar,omitempty"`
-	HasVideo       *bool      `json:"has_video,omitempty"`
-	CreatedAfter   *time.Time `json:"created_after,omitempty"`
-	Page           int        `json:"page"`
-	PageSize       int        `json:"page_size"`
+	Query            string                     `json:"query,omitempty"`     // Full-text search over name and bio, most relevant first
+	FullName         *string                    `json:"full_name,omitempty"` // Deprecated: use query
+	LookingForTeam   *bool                      `json:"looking_for_team,omitempty"`
+	Goals            []string                   `json:"goals,omitempty"`
+	ImprovStyles     []string                   `json:"improv_styles,omitempty"`
+	Tags             []string                   `json:"tags,omitempty"` // Profiles must have all of the tags
+	AgeMin           *int                       `json:"age_min,omitempty"`
+	AgeMax           *int                       `json:"age_max,omitempty"`
+	Genders          []string                   `json:"genders,omitempty"`
+	CityID           *int                       `json:"city_id,omitempty"`
+	HasAvatar        *bool                      `json:"has_avatar,omitempty"`
+	HasVideo         *bool                      `json:"has_video,omitempty"`
+	CreatedAfter     *time.Time                 `json:"created_after,omitempty"`
+	AvailableOn      []profile.AvailabilitySlot `json:"available_on,omitempty"`
+	VerifiedOnly     bool                       `json:"verified_only,omitempty"`
+	Near             *profile.NearFilter        `json:"near,omitempty"`              // Nearest profiles first
+	ExcludeContacted bool                       `json:"exclude_contacted,omitempty"` // Hide users with a direct chat, for "new people" flows
+	Shuffle          bool                       `json:"shuffle,omitempty"`           // Random order, stable for the seed
+	Seed             *int64                     `json:"seed,omitempty"`              // Seed from the first shuffled page
+	IncludeFacets    bool                       `json:"include_facets,omitempty"`    // Count matches per city, style and goal
+	Page             int                        `json:"page"`
+	PageSize         int                        `json:"page_size"`
 }
 
 // SearchResponse represents the search response
 type SearchResponse struct {
-	Profiles   []ProfileResponse `json:"profiles"`
-	TotalCount int               `json:"total_count"`
-	Page       int               `json:"page"`
-	PageSize   int               `json:"page_size"`
+	Profiles   []ProfileResponse     `json:"profiles"`
+	TotalCount int                   `json:"total_count"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	Seed       *int64                `json:"seed,omitempty"`   // Set for shuffled searches
+	Facets     *profile.SearchFacets `json:"facets,omitempty"` // Set when include_facets is requested
 }
 
 // TranslatedItem represents a catalog item with translations
@@ -132,6 +169,8 @@ type City struct {
 	Name string
 }
 
+//go:generate moq -out mocks_test.go . ProfileService ExportService
+
 // ProfileService defines the interface for profile operations
 type ProfileService interface {
 	CreateProfile(req profile.ProfileCreateRequest) (*profile.Profile, error)
@@ -140,19 +179,33 @@ type ProfileService interface {
 	GetImprovStyles(lang string) ([]profile.TranslatedItem, error)
 	GetImprovGoals(lang string) ([]profile.TranslatedItem, error)
 	GetGenders(lang string) ([]profile.TranslatedItem, error)
-	GetCities() ([]profile.City, error)
+	GetCities(lang, country, query string) ([]profile.City, error)
+	SuggestTags(query string) ([]profile.TagSuggestion, error)
 	Search(userID int, filter profile.SearchFilter) (*profile.SearchResult, error)
+	AddFavorite(userID int, profileUserID int) error
+	RemoveFavorite(userID int, profileUserID int) error
+	GetFavorites(userID int, page int, pageSize int) (*profile.SearchResult, error)
+	Endorse(userID int, profileUserID int, style string) error
+	RemoveEndorsement(userID int, profileUserID int, style string) error
+	GetRecommendations(userID int, limit int) ([]profile.Recommendation, error)
+}
+
+// ExportService defines the interface for asynchronous exports
+type ExportService interface {
+	Submit(ctx context.Context, userID int, kind, fileName string, params interface{}) (*export.Export, error)
 }
 
 // ProfileHandler handles requests related to profiles
 type ProfileHandler struct {
 	profileService ProfileService
+	exportService  ExportService
 }
 
 // NewProfileHandler creates a new instance of ProfileHandler
-func NewProfileHandler(profileService ProfileService) *ProfileHandler {
+func NewProfileHandler(profileService ProfileService, exportService ExportService) *ProfileHandler {
 	return &ProfileHandler{
 		profileService: profileService,
+		exportService:  exportService,
 	}
 }
 
@@ -174,6 +227,24 @@ func handleError(w http.ResponseWriter, err error) {
 		http.Error(w, "Invalid gender", http.StatusBadRequest)
 	case errors.Is(err, profile.ErrInvalidCity):
 		http.Error(w, "Invalid city", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrCannotFavoriteSelf):
+		http.Error(w, "Cannot add own profile to favorites", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrCannotEndorseSelf):
+		http.Error(w, "Cannot endorse own profile", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrNoSharedTeam):
+		http.Error(w, "Only teammates can endorse a profile", http.StatusForbidden)
+	case errors.Is(err, profile.ErrInvalidAudioIntro):
+		http.Error(w, "Invalid audio introduction", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrInvalidLink):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, profile.ErrInvalidAvailability):
+		http.Error(w, "Invalid availability", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrInvalidTag):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, profile.ErrInvalidLocation):
+		http.Error(w, "Invalid search location", http.StatusBadRequest)
+	case errors.Is(err, profile.ErrInvalidSearchQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
 	default:
 		http.Error(w, "Server error: "+err.Error(), http.StatusInternalServerError)
 	}
@@ -181,34 +252,50 @@ func handleError(w http.ResponseWriter, err error) {
 
 func convertToProfileResponse(profile *profile.Profile) ProfileResponse {
 	return ProfileResponse{
-		UserID:         profile.UserID,
-		FullName:       profile.FullName,
-		Birthday:       Date{Time: profile.Birthday},
-		Gender:         profile.Gender,
-		CityID:         profile.CityID,
-		Bio:            profile.Bio,
-		Goal:           profile.Goal,
-		ImprovStyles:   profile.ImprovStyles,
-		LookingForTeam: profile.LookingForTeam,
-		Avatar:         profile.Avatar,
-		Videos:         profile.Videos,
-		CreatedAt:      profile.CreatedAt,
+		UserID:                profile.UserID,
+		FullName:              profile.FullName,
+		Birthday:              Date{Time: profile.Birthday},
+		Gender:                profile.Gender,
+		CityID:                profile.CityID,
+		Bio:                   profile.Bio,
+		Goal:                  profile.Goal,
+		Goals:                 profile.Goals,
+		ImprovStyles:          profile.ImprovStyles,
+		Tags:                  profile.Tags,
+		LookingForTeam:        profile.LookingForTeam,
+		AllowOrganizerContact: profile.AllowOrganizerContact,
+		IsFavorite:            profile.IsFavorite,
+		IsVerified:            profile.IsVerified,
+		Avatar:                profile.Avatar,
+		AudioIntro:            profile.AudioIntro,
+		Videos:                profile.Videos,
+		Links:                 profile.Links,
+		Availability:          profile.Availability,
+		Endorsements:          profile.Endorsements,
+		CreatedAt:             profile.CreatedAt,
+		DistanceKm:            profile.DistanceKm,
 	}
 }
 
 func convertToCreateProfileRequest(req ProfileCreateRequest) profile.ProfileCreateRequest {
 	return profile.ProfileCreateRequest{
-		UserID:         req.UserID,
-		FullName:       req.FullName,
-		Birthday:       req.Birthday.Time,
-		Gender:         req.Gender,
-		CityID:         req.CityID,
-		Bio:            req.Bio,
-		Goal:           req.Goal,
-		ImprovStyles:   req.ImprovStyles,
-		LookingForTeam: req.LookingForTeam,
-		Avatar:         req.Avatar,
-		Videos:         req.Videos,
+		UserID:                req.UserID,
+		FullName:              req.FullName,
+		Birthday:              req.Birthday.Time,
+		Gender:                req.Gender,
+		CityID:                req.CityID,
+		Bio:                   req.Bio,
+		Goal:                  req.Goal,
+		Goals:                 req.Goals,
+		ImprovStyles:          req.ImprovStyles,
+		Tags:                  req.Tags,
+		LookingForTeam:        req.LookingForTeam,
+		AllowOrganizerContact: req.AllowOrganizerContact,
+		Avatar:                req.Avatar,
+		AudioIntro:            req.AudioIntro,
+		Videos:                req.Videos,
+		Links:                 req.Links,
+		Availability:          req.Availability,
 	}
 }
 
@@ -219,16 +306,49 @@ func convertToUpdateProfileRequest(req ProfileUpdateRequest) profile.ProfileUpda
 	}
 
 	return profile.ProfileUpdateRequest{
-		FullName:       req.FullName,
-		Birthday:       birthday,
-		Gender:         req.Gender,
-		CityID:         req.CityID,
-		Bio:            req.Bio,
-		Goal:           req.Goal,
-		ImprovStyles:   req.ImprovStyles,
-		LookingForTeam: req.LookingForTeam,
-		Avatar:         req.Avatar,
-		Videos:         req.Videos,
+		FullName:              req.FullName,
+		Birthday:              birthday,
+		Gender:                req.Gender,
+		CityID:                req.CityID,
+		Bio:                   req.Bio,
+		Goal:                  req.Goal,
+		Goals:                 req.Goals,
+		ImprovStyles:          req.ImprovStyles,
+		Tags:                  req.Tags,
+		LookingForTeam:        req.LookingForTeam,
+		AllowOrganizerContact: req.AllowOrganizerContact,
+		Avatar:                req.Avatar,
+		AudioIntro:            req.AudioIntro,
+		Videos:                req.Videos,
+		Links:                 req.Links,
+		Availability:          req.Availability,
+	}
+}
+
+func convertToSearchFilter(req SearchRequest) profile.SearchFilter {
+	return profile.SearchFilter{
+		Query:            req.Query,
+		FullName:         req.FullName,
+		LookingForTeam:   req.LookingForTeam,
+		Goals:            req.Goals,
+		ImprovStyles:     req.ImprovStyles,
+		AgeMin:           req.AgeMin,
+		AgeMax:           req.AgeMax,
+		Genders:          req.Genders,
+		CityID:           req.CityID,
+		HasAvatar:        req.HasAvatar,
+		HasVideo:         req.HasVideo,
+		CreatedAfter:     req.CreatedAfter,
+		AvailableOn:      req.AvailableOn,
+		VerifiedOnly:     req.VerifiedOnly,
+		Tags:             req.Tags,
+		Near:             req.Near,
+		ExcludeContacted: req.ExcludeContacted,
+		Shuffle:          req.Shuffle,
+		Seed:             req.Seed,
+		IncludeFacets:    req.IncludeFacets,
+		Page:             req.Page,
+		PageSize:         req.PageSize,
 	}
 }
 
@@ -443,15 +563,24 @@ func (h *ProfileHandler) GetGenders(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Get Cities
-// @Description  Retrieves a list of available cities
+// @Description  Retrieves cities with country, region and timezone. With q, returns up to 20 cities whose name or any word in it starts with q, for search-as-you-type.
 // @Tags         catalog
 // @Produce      json
+// @Param        lang     query  string  false  "Language code (default: ru)"
+// @Param        country  query  string  false  "ISO 3166-1 alpha-2 country code"
+// @Param        q        query  string  false  "Name prefix"
 // @Success      200  {array}  profile.City
 // @Failure      500  {string}  string  "Server error"
 // @Router       /profiles/catalog/cities [get]
 func (h *ProfileHandler) GetCities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lang := query.Get("lang")
+	if lang == "" {
+		lang = "ru" // Default language
+	}
+
 	// Call the service to get the cities
-	cities, err := h.profileService.GetCities()
+	cities, err := h.profileService.GetCities(lang, query.Get("country"), query.Get("q"))
 	if err != nil {
 		handleError(w, err)
 		return
@@ -464,8 +593,29 @@ func (h *ProfileHandler) GetCities(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// @Summary      Suggest Tags
+// @Description  Autocomplete for profile tags: tags starting with the query, most used first
+// @Tags         catalog
+// @Produce      json
+// @Param        q  query  string  false  "Tag prefix; empty returns the most used tags"
+// @Success      200  {array}  profile.TagSuggestion
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/catalog/tags [get]
+func (h *ProfileHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.profileService.SuggestTags(r.URL.Query().Get("q"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tags); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
 // @Summary      Search Profiles
-// @Description  Search for profiles with various filters
+// @Description  Search for profiles with various filters. With shuffle the results come in a random order that is stable for the returned seed. Avatar URLs point to the 512px variant; the original is in variants.full.
 // @Tags         profile
 // @Accept       json
 // @Produce      json
@@ -490,21 +640,7 @@ func (h *ProfileHandler) SearchProfiles(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Convert request to service filter
-	filter := profile.SearchFilter{
-		FullName:       req.FullName,
-		LookingForTeam: req.LookingForTeam,
-		Goals:          req.Goals,
-		ImprovStyles:   req.ImprovStyles,
-		AgeMin:         req.AgeMin,
-		AgeMax:         req.AgeMax,
-		Genders:        req.Genders,
-		CityID:         req.CityID,
-		HasAvatar:      req.HasAvatar,
-		HasVideo:       req.HasVideo,
-		CreatedAfter:   req.CreatedAfter,
-		Page:           req.Page,
-		PageSize:       req.PageSize,
-	}
+	filter := convertToSearchFilter(req)
 
 	// Call the service to perform the search
 	result, err := h.profileService.Search(userID, filter)
@@ -525,6 +661,8 @@ func (h *ProfileHandler) SearchProfiles(w http.ResponseWriter, r *http.Request)
 		TotalCount: result.TotalCount,
 		Page:       result.Page,
 		PageSize:   result.PageSize,
+		Seed:       result.Seed,
+		Facets:     result.Facets,
 	}
 
 	// Return the response
@@ -533,3 +671,52 @@ func (h *ProfileHandler) SearchProfiles(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+// @Summary      Export Search Results
+// @Description  Starts an asynchronous CSV export of profiles matching the filters that allow organizer contact. Available to organizers.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        request  body      SearchRequest  true   "Search filters (pagination is ignored)"
+// @Param        lang     query     string         false  "Language of catalog labels (default: ru)"
+// @Security     BearerAuth
+// @Success      202      {object}  export.Export
+// @Failure      400      {string}  string  "Invalid request"
+// @Failure      403      {string}  string  "Forbidden"
+// @Failure      500      {string}  string  "Server error"
+// @Router       /profiles/search/export [post]
+func (h *ProfileHandler) ExportSearch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req SearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		lang = "ru" // Default language
+	}
+
+	filter := convertToSearchFilter(req)
+	fileName := fmt.Sprintf("profiles-%s.csv", time.Now().Format("2006-01-02"))
+
+	result, err := h.exportService.Submit(r.Context(), userID, profile.SearchExportKind, fileName, profile.SearchExportParams{
+		Filter: filter,
+		Lang:   lang,
+	})
+	if err != nil {
+		log.Printf("Error starting profile export: %v", err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(result)
+}
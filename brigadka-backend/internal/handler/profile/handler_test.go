@@ -0,0 +1,526 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/export"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+func TestCreateProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", map[string]interface{}{"user_id": 1, "full_name": "Test", "birthday": "1990-01-01"}, nil, http.StatusCreated},
+		{"invalid body", "not an object", nil, http.StatusBadRequest},
+		{"user not found", map[string]interface{}{"user_id": 1}, profile.ErrUserNotFound, http.StatusNotFound},
+		{"profile exists", map[string]interface{}{"user_id": 1}, profile.ErrProfileAlreadyExists, http.StatusConflict},
+		{"invalid style", map[string]interface{}{"user_id": 1}, profile.ErrInvalidImprovStyle, http.StatusBadRequest},
+		{"invalid city", map[string]interface{}{"user_id": 1}, profile.ErrInvalidCity, http.StatusBadRequest},
+		{"server error", map[string]interface{}{"user_id": 1}, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				CreateProfileFunc: func(req profile.ProfileCreateRequest) (*profile.Profile, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.Profile{UserID: req.UserID, FullName: req.FullName}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.CreateProfile(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles", tt.body, 1, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var resp ProfileResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "Test", resp.FullName)
+				assert.Equal(t, "1990-01-01", service.CreateProfileCalls()[0].Req.Birthday.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestGetProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "42", nil, http.StatusOK},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not found", "42", profile.ErrProfileNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				GetProfileFunc: func(userID int) (*profile.Profile, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.Profile{UserID: userID}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.GetProfile(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/"+tt.userID, nil, 1, map[string]string{"userID": tt.userID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				assert.Empty(t, service.GetProfileCalls())
+			}
+		})
+	}
+}
+
+func TestUpdateProfileRequiresUser(t *testing.T) {
+	h := NewProfileHandler(&ProfileServiceMock{}, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/1", map[string]string{"bio": "x"}, 0, nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestUpdateProfileUsesContextUser(t *testing.T) {
+	service := &ProfileServiceMock{
+		UpdateProfileFunc: func(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, Bio: *req.Bio}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/1", map[string]string{"bio": "new bio"}, 7, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 7, service.UpdateProfileCalls()[0].UserID)
+}
+
+func TestUpdateProfileGoals(t *testing.T) {
+	service := &ProfileServiceMock{
+		UpdateProfileFunc: func(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, Goal: *req.Goal, Goals: []string{*req.Goal, "hobby"}}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	body := map[string]interface{}{"goal": "career", "goals": []string{"hobby"}}
+	rec := httptest.NewRecorder()
+	h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/7", body, 7, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	req := service.UpdateProfileCalls()[0].Req
+	assert.Equal(t, "career", *req.Goal)
+	assert.Equal(t, []string{"hobby"}, req.Goals)
+
+	var resp ProfileResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "career", resp.Goal)
+	assert.Equal(t, []string{"career", "hobby"}, resp.Goals)
+}
+
+func TestUpdateProfileOrganizerContact(t *testing.T) {
+	service := &ProfileServiceMock{
+		UpdateProfileFunc: func(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+			return &profile.Profile{UserID: userID, AllowOrganizerContact: *req.AllowOrganizerContact}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/7", map[string]bool{"allow_organizer_contact": true}, 7, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if req := service.UpdateProfileCalls()[0].Req; assert.NotNil(t, req.AllowOrganizerContact) {
+		assert.True(t, *req.AllowOrganizerContact)
+	}
+
+	var resp ProfileResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.True(t, resp.AllowOrganizerContact)
+}
+
+func TestUpdateProfileAudioIntro(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not an audio clip", profile.ErrInvalidAudioIntro, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				UpdateProfileFunc: func(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.Profile{UserID: userID, AudioIntro: &profile.Media{ID: *req.AudioIntro, URL: "https://cdn/intro.m4a"}}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/7", map[string]int{"audio_intro": 15}, 7, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if req := service.UpdateProfileCalls()[0].Req; assert.NotNil(t, req.AudioIntro) {
+				assert.Equal(t, 15, *req.AudioIntro)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp ProfileResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				if assert.NotNil(t, resp.AudioIntro) {
+					assert.Equal(t, 15, resp.AudioIntro.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateProfileLinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid link", fmt.Errorf("%w: %s", profile.ErrInvalidLink, profile.LinkTelegram), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				UpdateProfileFunc: func(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.Profile{UserID: userID, Links: &profile.Links{Telegram: "https://t.me/impro_team"}}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			body := map[string]interface{}{"links": map[string]string{"telegram": "@impro_team"}}
+			rec := httptest.NewRecorder()
+			h.UpdateProfile(rec, handlertest.NewRequest(http.MethodPatch, "/api/profiles/7", body, 7, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if req := service.UpdateProfileCalls()[0].Req; assert.NotNil(t, req.Links) {
+				assert.Equal(t, "@impro_team", req.Links.Telegram)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp ProfileResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				if assert.NotNil(t, resp.Links) {
+					assert.Equal(t, "https://t.me/impro_team", resp.Links.Telegram)
+				}
+			}
+		})
+	}
+}
+
+func TestCatalogDefaultLanguage(t *testing.T) {
+	service := &ProfileServiceMock{
+		GetImprovStylesFunc: func(lang string) ([]profile.TranslatedItem, error) {
+			return []profile.TranslatedItem{{Code: "shortform", Label: "Короткая форма"}}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.GetImprovStyles(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/catalog/improv-styles", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ru", service.GetImprovStylesCalls()[0].Lang)
+}
+
+func TestGetCities(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		wantLang    string
+		wantCountry string
+		wantQuery   string
+	}{
+		{"defaults", "/api/profiles/catalog/cities", "ru", "", ""},
+		{"search", "/api/profiles/catalog/cities?lang=en&country=ru&q=nov", "en", "ru", "nov"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				GetCitiesFunc: func(lang string, country string, query string) ([]profile.City, error) {
+					return []profile.City{{ID: 3, Name: "Novosibirsk", CountryCode: "RU", Region: "Novosibirsk Oblast", Timezone: "Asia/Novosibirsk"}}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.GetCities(rec, handlertest.NewRequest(http.MethodGet, tt.target, nil, 0, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			call := service.GetCitiesCalls()[0]
+			assert.Equal(t, tt.wantLang, call.Lang)
+			assert.Equal(t, tt.wantCountry, call.Country)
+			assert.Equal(t, tt.wantQuery, call.Query)
+
+			var resp []profile.City
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			if assert.Len(t, resp, 1) {
+				assert.Equal(t, "Asia/Novosibirsk", resp[0].Timezone)
+			}
+		})
+	}
+}
+
+func TestGetTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"server error", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				SuggestTagsFunc: func(query string) ([]profile.TagSuggestion, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return []profile.TagSuggestion{{Name: "музимпров", Profiles: 3}}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.GetTags(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/catalog/tags?q=%23Муз", nil, 1, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "#Муз", service.SuggestTagsCalls()[0].Query)
+			if tt.wantStatus == http.StatusOK {
+				var resp []profile.TagSuggestion
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, []profile.TagSuggestion{{Name: "музимпров", Profiles: 3}}, resp)
+			}
+		})
+	}
+}
+
+func TestSearchProfiles(t *testing.T) {
+	service := &ProfileServiceMock{
+		SearchFunc: func(userID int, filter profile.SearchFilter) (*profile.SearchResult, error) {
+			return &profile.SearchResult{
+				Profiles:   []profile.Profile{{UserID: 2, IsVerified: true}},
+				TotalCount: 1,
+				Page:       1,
+				PageSize:   20,
+			}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	body := map[string]interface{}{"query": "плейбек театр", "goals": []string{"hobby"}, "verified_only": true, "tags": []string{"плейбек"}, "exclude_contacted": true}
+	rec := httptest.NewRecorder()
+	h.SearchProfiles(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search", body, 5, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	calls := service.SearchCalls()
+	assert.Equal(t, 5, calls[0].UserID)
+	assert.Equal(t, "плейбек театр", calls[0].Filter.Query)
+	assert.Equal(t, []string{"hobby"}, calls[0].Filter.Goals)
+	assert.True(t, calls[0].Filter.VerifiedOnly)
+	assert.Equal(t, []string{"плейбек"}, calls[0].Filter.Tags)
+	assert.True(t, calls[0].Filter.ExcludeContacted)
+
+	var resp SearchResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.TotalCount)
+	if assert.Len(t, resp.Profiles, 1) {
+		assert.True(t, resp.Profiles[0].IsVerified)
+	}
+}
+
+func TestSearchProfilesAvailableOn(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid slot", profile.ErrInvalidAvailability, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				SearchFunc: func(userID int, filter profile.SearchFilter) (*profile.SearchResult, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.SearchResult{Profiles: []profile.Profile{{UserID: 2}}, TotalCount: 1}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			body := map[string]interface{}{
+				"available_on": []map[string]string{{"day": "tue", "start": "19:00", "end": "22:00"}},
+			}
+			rec := httptest.NewRecorder()
+			h.SearchProfiles(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search", body, 5, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, []profile.AvailabilitySlot{{Day: "tue", Start: "19:00", End: "22:00"}}, service.SearchCalls()[0].Filter.AvailableOn)
+		})
+	}
+}
+
+func TestSearchProfilesNear(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid location", profile.ErrInvalidLocation, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance := 12.5
+			service := &ProfileServiceMock{
+				SearchFunc: func(userID int, filter profile.SearchFilter) (*profile.SearchResult, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &profile.SearchResult{Profiles: []profile.Profile{{UserID: 2, DistanceKm: &distance}}, TotalCount: 1}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			body := map[string]interface{}{"near": map[string]float64{"lat": 55.75, "lon": 37.62, "radius_km": 50}}
+			rec := httptest.NewRecorder()
+			h.SearchProfiles(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search", body, 5, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, &profile.NearFilter{Lat: 55.75, Lon: 37.62, RadiusKm: 50}, service.SearchCalls()[0].Filter.Near)
+			if tt.wantStatus == http.StatusOK {
+				var resp SearchResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				if assert.Len(t, resp.Profiles, 1) {
+					assert.Equal(t, 12.5, *resp.Profiles[0].DistanceKm)
+				}
+			}
+		})
+	}
+}
+
+func TestExportSearch(t *testing.T) {
+	exports := &ExportServiceMock{
+		SubmitFunc: func(ctx context.Context, userID int, kind string, fileName string, params interface{}) (*export.Export, error) {
+			return &export.Export{ID: "e1", UserID: userID, Kind: kind, Status: "pending", FileName: fileName}, nil
+		},
+	}
+	h := NewProfileHandler(&ProfileServiceMock{}, exports)
+
+	rec := httptest.NewRecorder()
+	h.ExportSearch(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search/export?lang=en", map[string]interface{}{"city_id": 1}, 5, nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	call := exports.SubmitCalls()[0]
+	assert.Equal(t, 5, call.UserID)
+	assert.Equal(t, profile.SearchExportKind, call.Kind)
+	params, ok := call.Params.(profile.SearchExportParams)
+	if assert.True(t, ok) {
+		assert.Equal(t, "en", params.Lang)
+		if assert.NotNil(t, params.Filter.CityID) {
+			assert.Equal(t, 1, *params.Filter.CityID)
+		}
+	}
+
+	var resp export.Export
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "e1", resp.ID)
+}
+
+func TestSearchProfilesShuffle(t *testing.T) {
+	seed := int64(42)
+	service := &ProfileServiceMock{
+		SearchFunc: func(userID int, filter profile.SearchFilter) (*profile.SearchResult, error) {
+			return &profile.SearchResult{Profiles: []profile.Profile{}, Page: 2, PageSize: 20, Seed: &seed}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	body := map[string]interface{}{"shuffle": true, "seed": 42, "page": 2}
+	rec := httptest.NewRecorder()
+	h.SearchProfiles(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search", body, 5, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.SearchCalls()[0]
+	assert.True(t, call.Filter.Shuffle)
+	assert.Equal(t, &seed, call.Filter.Seed)
+
+	var resp SearchResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, &seed, resp.Seed)
+}
+
+func TestSearchProfilesFacets(t *testing.T) {
+	service := &ProfileServiceMock{
+		SearchFunc: func(userID int, filter profile.SearchFilter) (*profile.SearchResult, error) {
+			return &profile.SearchResult{
+				Profiles:   []profile.Profile{{UserID: 2}},
+				TotalCount: 1,
+				Page:       1,
+				PageSize:   20,
+				Facets: &profile.SearchFacets{
+					Cities:       []profile.CityFacet{{CityID: 1, Count: 1}},
+					ImprovStyles: []profile.Facet{{Code: "shortform", Count: 1}},
+					Goals:        []profile.Facet{},
+				},
+			}, nil
+		},
+	}
+	h := NewProfileHandler(service, &ExportServiceMock{})
+
+	body := map[string]interface{}{"include_facets": true}
+	rec := httptest.NewRecorder()
+	h.SearchProfiles(rec, handlertest.NewRequest(http.MethodPost, "/api/profiles/search", body, 5, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, service.SearchCalls()[0].Filter.IncludeFacets)
+
+	var resp SearchResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.NotNil(t, resp.Facets) {
+		assert.Equal(t, []profile.CityFacet{{CityID: 1, Count: 1}}, resp.Facets.Cities)
+		assert.Equal(t, []profile.Facet{{Code: "shortform", Count: 1}}, resp.Facets.ImprovStyles)
+		assert.Empty(t, resp.Facets.Goals)
+	}
+}
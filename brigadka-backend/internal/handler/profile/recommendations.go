@@ -0,0 +1,62 @@
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// RecommendationResponse represents a recommended profile
+type RecommendationResponse struct {
+	Profile ProfileResponse `json:"profile"`
+	Score   float64         `json:"score"`   // From 0 to 1
+	Reasons []string        `json:"reasons"` // shared_styles, same_city, shared_goals, recently_active
+}
+
+// RecommendationsResponse represents the recommended profiles, best matches first
+type RecommendationsResponse struct {
+	Recommendations []RecommendationResponse `json:"recommendations"`
+}
+
+// @Summary      Recommended profiles
+// @Description  Returns profiles ranked by shared improv styles, the same city, shared goals and recent activity. Excludes users the current user already has a direct chat with, profiles they reported, and hidden or suspended accounts.
+// @Tags         profile
+// @Produce      json
+// @Param        limit  query  int  false  "Number of profiles (default: 20, max: 50)"
+// @Security     BearerAuth
+// @Success      200  {object}  RecommendationsResponse
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /profiles/recommended [get]
+func (h *ProfileHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	currentUserID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Invalid values fall back to the service default
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	recommendations, err := h.profileService.GetRecommendations(currentUserID, limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response := RecommendationsResponse{
+		Recommendations: make([]RecommendationResponse, 0, len(recommendations)),
+	}
+	for _, rec := range recommendations {
+		response.Recommendations = append(response.Recommendations, RecommendationResponse{
+			Profile: convertToProfileResponse(&rec.Profile),
+			Score:   rec.Score,
+			Reasons: rec.Reasons,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
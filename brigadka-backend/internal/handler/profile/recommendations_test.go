@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		query      string
+		serviceErr error
+		wantStatus int
+		wantLimit  int
+	}{
+		{"success", 1, "?limit=5", nil, http.StatusOK, 5},
+		{"default limit", 1, "", nil, http.StatusOK, 0},
+		{"invalid limit", 1, "?limit=abc", nil, http.StatusOK, 0},
+		{"unauthorized", 0, "", nil, http.StatusUnauthorized, 0},
+		{"server error", 1, "", errors.New("db down"), http.StatusInternalServerError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ProfileServiceMock{
+				GetRecommendationsFunc: func(userID int, limit int) ([]profile.Recommendation, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return []profile.Recommendation{{
+						Profile: profile.Profile{UserID: 2, FullName: "Anna"},
+						Score:   0.8,
+						Reasons: []string{profile.ReasonSharedStyles, profile.ReasonSameCity},
+					}}, nil
+				},
+			}
+			h := NewProfileHandler(service, &ExportServiceMock{})
+
+			rec := httptest.NewRecorder()
+			h.GetRecommendations(rec, handlertest.NewRequest(http.MethodGet, "/api/profiles/recommended"+tt.query, nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID == 0 {
+				assert.Empty(t, service.GetRecommendationsCalls())
+				return
+			}
+
+			call := service.GetRecommendationsCalls()[0]
+			assert.Equal(t, 1, call.UserID)
+			assert.Equal(t, tt.wantLimit, call.Limit)
+
+			if tt.wantStatus == http.StatusOK {
+				var resp RecommendationsResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				if assert.Len(t, resp.Recommendations, 1) {
+					assert.Equal(t, 2, resp.Recommendations[0].Profile.UserID)
+					assert.Equal(t, 0.8, resp.Recommendations[0].Score)
+					assert.Equal(t, []string{"shared_styles", "same_city"}, resp.Recommendations[0].Reasons)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package push
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// TestNotificationResponse holds the answer of the push provider for each device of the user
+type TestNotificationResponse struct {
+	Results []pushservice.TokenResult `json:"results"`
+}
+
+// SendTestNotification godoc
+// @Summary Send a test notification
+// @Description Sends a test notification to every device registered by the current user, ignoring notification preferences, and returns the provider response for each token. Tokens the provider reports as dead are removed.
+// @Tags push
+// @Produce json
+// @Success 200 {object} TestNotificationResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "No registered devices"
+// @Failure 500 {string} string "Server error"
+// @Security BearerAuth
+// @Router /api/push/test [post]
+func (h *Handler) SendTestNotification(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	results, err := h.service.SendTestNotification(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pushservice.ErrNoTokens) {
+			http.Error(w, "No registered devices", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to send test notification to user %d: %v", userID, err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, TestNotificationResponse{Results: results})
+}
+
+// GetTokens godoc
+// @Summary List push notification tokens
+// @Description Lists the push tokens registered by the current user with their platform, when the app last registered them and when a notification was last delivered to them
+// @Tags push
+// @Produce json
+// @Success 200 {array} pushservice.RegisteredToken
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Server error"
+// @Security BearerAuth
+// @Router /api/push/tokens [get]
+func (h *Handler) GetTokens(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := h.service.GetTokens(r.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get push tokens of user %d: %v", userID, err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tokens)
+}
+
+// QueueStatser reports the counters of the push delivery queue
+type QueueStatser interface {
+	Stats() pushservice.QueueStats
+}
+
+// SetQueue adds the counters of the delivery queue to the push stats
+func (h *Handler) SetQueue(queue QueueStatser) {
+	h.queue = queue
+}
+
+// PushStatsResponse holds the push delivery counters since startup
+type PushStatsResponse struct {
+	Deliveries []pushservice.DeliveryMetrics `json:"deliveries"`
+	Queue      *pushservice.QueueStats       `json:"queue,omitempty"`
+}
+
+// GetStats godoc
+// @Summary Push delivery stats
+// @Description Push delivery counters since startup per platform and category: sent, failed, removed and disabled tokens, provider latency histogram and the last provider error, plus the delivery queue counters. A failing provider, e.g. after an APNS key was revoked, shows up as failures with its last error. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} PushStatsResponse
+// @Failure 403 {string} string "Forbidden"
+// @Security BearerAuth
+// @Router /api/admin/push/stats [get]
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	resp := PushStatsResponse{Deliveries: h.service.GetMetrics()}
+	if h.queue != nil {
+		stats := h.queue.Stats()
+		resp.Queue = &stats
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,133 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+func TestSendTestNotification(t *testing.T) {
+	results := []pushservice.TokenResult{
+		{Token: "token1", Platform: "android", Success: true},
+		{Token: "token2", Platform: "ios", Error: "410 Unregistered", Removed: true},
+	}
+
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"no devices", 1, pushservice.ErrNoTokens, http.StatusNotFound},
+		{"service error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PushServiceMock{
+				SendTestNotificationFunc: func(ctx context.Context, userID int) ([]pushservice.TokenResult, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return results, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.SendTestNotification(rec, handlertest.NewRequest(http.MethodPost, "/api/push/test", nil, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp TestNotificationResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, results, resp.Results)
+				assert.Equal(t, 1, service.SendTestNotificationCalls()[0].UserID)
+			}
+		})
+	}
+}
+
+func TestGetTokens(t *testing.T) {
+	seen := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	delivered := time.Date(2026, 10, 2, 9, 30, 0, 0, time.UTC)
+	service := &PushServiceMock{
+		GetTokensFunc: func(ctx context.Context, userID int) ([]pushservice.RegisteredToken, error) {
+			return []pushservice.RegisteredToken{
+				{Token: "token1", Platform: "android", LastSeenAt: seen, LastSuccessAt: &delivered},
+				{Token: "token2", Platform: "ios", LastSeenAt: seen},
+			}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetTokens(rec, handlertest.NewRequest(http.MethodGet, "/api/push/tokens", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var tokens []pushservice.RegisteredToken
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&tokens))
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, "android", tokens[0].Platform)
+	assert.True(t, delivered.Equal(*tokens[0].LastSuccessAt))
+	assert.Nil(t, tokens[1].LastSuccessAt)
+	assert.Equal(t, 1, service.GetTokensCalls()[0].UserID)
+}
+
+func TestGetTokens_Unauthorized(t *testing.T) {
+	h := NewHandler(&PushServiceMock{})
+
+	rec := httptest.NewRecorder()
+	h.GetTokens(rec, handlertest.NewRequest(http.MethodGet, "/api/push/tokens", nil, 0, nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+type fakeQueue struct {
+	stats pushservice.QueueStats
+}
+
+func (q fakeQueue) Stats() pushservice.QueueStats {
+	return q.stats
+}
+
+func TestGetStats(t *testing.T) {
+	metrics := []pushservice.DeliveryMetrics{
+		{Platform: "ios", Category: "new_message", Sent: 10, Failed: 3, LastError: "403 InvalidProviderToken",
+			Latency: []pushservice.LatencyBucket{{LeMs: 50, Count: 4}, {Count: 1}}},
+	}
+	service := &PushServiceMock{
+		GetMetricsFunc: func() []pushservice.DeliveryMetrics {
+			return metrics
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetStats(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/push/stats", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp PushStatsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, metrics, resp.Deliveries)
+	assert.Nil(t, resp.Queue)
+
+	h.SetQueue(fakeQueue{stats: pushservice.QueueStats{Enqueued: 5, DeadLettered: 2}})
+	rec = httptest.NewRecorder()
+	h.GetStats(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/push/stats", nil, 1, nil))
+
+	resp = PushStatsResponse{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, &pushservice.QueueStats{Enqueued: 5, DeadLettered: 2}, resp.Queue)
+}
@@ -20,9 +20,12 @@ type UnregisterTokenRequest struct {
 	Token string `json:"token"`
 }
 
+//go:generate moq -out mocks_test.go ../../service/push PushService
+
 // Handler handles push notification endpoints
 type Handler struct {
 	service pushservice.PushService
+	queue   QueueStatser // Optional
 }
 
 // NewHandler creates a new push notification handler
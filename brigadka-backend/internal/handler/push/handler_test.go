@@ -0,0 +1,82 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+func TestRegisterToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		body       RegisterTokenRequest
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, RegisterTokenRequest{Token: "tok", Platform: "ios", DeviceID: "d1"}, nil, http.StatusOK},
+		{"unauthorized", 0, RegisterTokenRequest{Token: "tok", Platform: "ios"}, nil, http.StatusUnauthorized},
+		{"missing token", 1, RegisterTokenRequest{Platform: "ios"}, nil, http.StatusBadRequest},
+		{"missing platform", 1, RegisterTokenRequest{Token: "tok"}, nil, http.StatusBadRequest},
+		{"server error", 1, RegisterTokenRequest{Token: "tok", Platform: "ios"}, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PushServiceMock{
+				SaveTokenFunc: func(ctx context.Context, userID int, token string, platform string, deviceID string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.RegisterToken(rec, handlertest.NewRequest(http.MethodPost, "/api/push/register", tt.body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				call := service.SaveTokenCalls()[0]
+				assert.Equal(t, 1, call.UserID)
+				assert.Equal(t, "tok", call.Token)
+				assert.Equal(t, "d1", call.DeviceID)
+			}
+		})
+	}
+}
+
+func TestUnregisterToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       UnregisterTokenRequest
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", UnregisterTokenRequest{Token: "tok"}, nil, http.StatusOK},
+		{"missing token", UnregisterTokenRequest{}, nil, http.StatusBadRequest},
+		{"unknown token", UnregisterTokenRequest{Token: "tok"}, pushservice.ErrTokenNotFound, http.StatusBadRequest},
+		{"server error", UnregisterTokenRequest{Token: "tok"}, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PushServiceMock{
+				DeleteTokenFunc: func(ctx context.Context, userID int, token string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.UnregisterToken(rec, handlertest.NewRequest(http.MethodDelete, "/api/push/unregister", tt.body, 1, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package push
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Returns the notification categories the current user receives and their quiet hours
+// @Tags push
+// @Produce json
+// @Success 200 {object} pushservice.NotificationPreferences
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Server error"
+// @Security BearerAuth
+// @Router /api/notifications/preferences [get]
+func (h *Handler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	prefs, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get notification preferences of user %d: %v", userID, err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Replaces the notification preferences of the current user. Notifications of turned off categories and the ones sent during quiet hours are not delivered; reminders and account notices are always delivered.
+// @Tags push
+// @Accept json
+// @Produce json
+// @Param preferences body pushservice.NotificationPreferences true "Notification preferences"
+// @Success 200 {object} pushservice.NotificationPreferences
+// @Failure 400 {string} string "Invalid quiet hours or time zone"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Server error"
+// @Security BearerAuth
+// @Router /api/notifications/preferences [put]
+func (h *Handler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var prefs pushservice.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdatePreferences(r.Context(), userID, prefs); err != nil {
+		if errors.Is(err, pushservice.ErrInvalidQuietHours) || errors.Is(err, pushservice.ErrInvalidTimezone) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Failed to update notification preferences of user %d: %v", userID, err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, prefs)
+}
@@ -0,0 +1,76 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	pushservice "github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+func TestGetPreferences(t *testing.T) {
+	service := &PushServiceMock{
+		GetPreferencesFunc: func(ctx context.Context, userID int) (*pushservice.NotificationPreferences, error) {
+			return &pushservice.NotificationPreferences{
+				NewMessage: true,
+				QuietHours: &pushservice.QuietHours{Start: "22:00", End: "08:00"},
+			}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetPreferences(rec, handlertest.NewRequest(http.MethodGet, "/api/notifications/preferences", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var prefs pushservice.NotificationPreferences
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&prefs))
+	assert.True(t, prefs.NewMessage)
+	assert.False(t, prefs.Announcements)
+	assert.Equal(t, &pushservice.QuietHours{Start: "22:00", End: "08:00"}, prefs.QuietHours)
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid quiet hours", 1, pushservice.ErrInvalidQuietHours, http.StatusBadRequest},
+		{"invalid time zone", 1, pushservice.ErrInvalidTimezone, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &PushServiceMock{
+				UpdatePreferencesFunc: func(ctx context.Context, userID int, prefs pushservice.NotificationPreferences) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			body := pushservice.NotificationPreferences{
+				NewMessage: true,
+				QuietHours: &pushservice.QuietHours{Start: "23:30", End: "07:00"},
+				Timezone:   "Europe/Moscow",
+			}
+			rec := httptest.NewRecorder()
+			h.UpdatePreferences(rec, handlertest.NewRequest(http.MethodPut, "/api/notifications/preferences", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				call := service.UpdatePreferencesCalls()[0]
+				assert.Equal(t, 1, call.UserID)
+				assert.Equal(t, body, call.Prefs)
+			}
+		})
+	}
+}
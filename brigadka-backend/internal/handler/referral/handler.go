@@ -0,0 +1,56 @@
+package referral
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/referral"
+)
+
+//go:generate moq -out mocks_test.go . ReferralService
+
+// ReferralService defines the referral operations used by the handler
+type ReferralService interface {
+	GetStats(ctx context.Context, userID int) (*referral.Stats, error)
+}
+
+// Handler handles referral endpoints
+type Handler struct {
+	service ReferralService
+}
+
+// NewHandler creates a new referral handler
+func NewHandler(service ReferralService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// @Summary      Referral stats
+// @Description  Get the user's referral code with the number of users who signed up with it and of those who created a profile and sent their first message. New users pass the code as referral_code to /auth/register.
+// @Tags         referrals
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  referral.Stats
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /referrals/stats [get]
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := h.service.GetStats(r.Context(), userID)
+	if err != nil {
+		log.Printf("Error fetching referral stats: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
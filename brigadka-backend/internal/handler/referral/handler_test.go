@@ -0,0 +1,60 @@
+package referral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/referral"
+)
+
+func TestGetStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 7, nil, http.StatusOK},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"server error", 7, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ReferralServiceMock{
+				GetStatsFunc: func(ctx context.Context, userID int) (*referral.Stats, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &referral.Stats{Code: "K7M2QX9A", SignedUp: 5, Activated: 2}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/referrals/stats", nil)
+			if tt.userID != 0 {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			rec := httptest.NewRecorder()
+			h.GetStats(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID == 0 {
+				assert.Empty(t, service.GetStatsCalls())
+				return
+			}
+			assert.Equal(t, tt.userID, service.GetStatsCalls()[0].UserID)
+			if tt.wantStatus == http.StatusOK {
+				var resp referral.Stats
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, referral.Stats{Code: "K7M2QX9A", SignedUp: 5, Activated: 2}, resp)
+			}
+		})
+	}
+}
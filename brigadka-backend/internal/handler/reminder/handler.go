@@ -0,0 +1,151 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/reminder"
+)
+
+//go:generate moq -out mocks_test.go . ReminderService
+
+// ReminderService defines the reminder operations used by the handler
+type ReminderService interface {
+	CreateReminder(ctx context.Context, userID int, chatID, text string, remindAt time.Time) (*reminder.Reminder, error)
+	GetReminders(ctx context.Context, userID int, chatID string) ([]reminder.Reminder, error)
+	CancelReminder(ctx context.Context, userID int, chatID, reminderID string) error
+}
+
+// Handler handles chat reminders
+type Handler struct {
+	service ReminderService
+}
+
+// NewHandler creates a new reminder handler
+func NewHandler(service ReminderService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateReminderRequest represents the request to schedule a reminder
+type CreateReminderRequest struct {
+	Text     string    `json:"text"`
+	RemindAt time.Time `json:"remind_at"`
+}
+
+// @Summary      Create reminder
+// @Description  Schedule a reminder that is posted to the chat and pushed to its participants at the given time
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Param        chatID   path  string                 true  "Chat ID"
+// @Param        request  body  CreateReminderRequest  true  "Reminder data"
+// @Security     BearerAuth
+// @Success      201  {object}  reminder.Reminder
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Chat not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /chats/{chatID}/reminders [post]
+func (h *Handler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateReminder(r.Context(), userID, chi.URLParam(r, "chatID"), req.Text, req.RemindAt)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// @Summary      List reminders
+// @Description  Pending reminders of a chat, soonest first
+// @Tags         reminders
+// @Produce      json
+// @Param        chatID  path  string  true  "Chat ID"
+// @Security     BearerAuth
+// @Success      200  {array}   reminder.Reminder
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Chat not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /chats/{chatID}/reminders [get]
+func (h *Handler) GetReminders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reminders, err := h.service.GetReminders(r.Context(), userID, chi.URLParam(r, "chatID"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reminders)
+}
+
+// @Summary      Cancel reminder
+// @Description  Cancel a pending reminder; only its author can cancel it
+// @Tags         reminders
+// @Param        chatID      path  string  true  "Chat ID"
+// @Param        reminderID  path  string  true  "Reminder ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Not the author"
+// @Failure      404  {string}  string  "Reminder not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /chats/{chatID}/reminders/{reminderID} [delete]
+func (h *Handler) CancelReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.CancelReminder(r.Context(), userID, chi.URLParam(r, "chatID"), chi.URLParam(r, "reminderID"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, reminder.ErrChatNotFound):
+		http.Error(w, "Chat not found", http.StatusNotFound)
+	case errors.Is(err, reminder.ErrReminderNotFound):
+		http.Error(w, "Reminder not found", http.StatusNotFound)
+	case errors.Is(err, reminder.ErrNotAuthor):
+		http.Error(w, "Only the author can cancel a reminder", http.StatusForbidden)
+	case errors.Is(err, reminder.ErrInvalidText), errors.Is(err, reminder.ErrInvalidTime):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Reminder error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
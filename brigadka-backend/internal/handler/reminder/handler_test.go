@@ -0,0 +1,113 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/reminder"
+)
+
+func TestCreateReminder(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"not in chat", 1, reminder.ErrChatNotFound, http.StatusNotFound},
+		{"in the past", 1, reminder.ErrInvalidTime, http.StatusBadRequest},
+		{"empty text", 1, reminder.ErrInvalidText, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	remindAt := time.Date(2030, 5, 14, 16, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ReminderServiceMock{
+				CreateReminderFunc: func(ctx context.Context, userID int, chatID, text string, at time.Time) (*reminder.Reminder, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &reminder.Reminder{ID: "01HZX", ChatID: chatID, CreatedBy: userID, Text: text, RemindAt: at, Status: "pending"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := CreateReminderRequest{Text: "Rehearsal at 19:00", RemindAt: remindAt}
+			rec := httptest.NewRecorder()
+			h.CreateReminder(rec, handlertest.NewRequest(http.MethodPost, "/api/chats/chat-1/reminders", body, tt.userID, map[string]string{"chatID": "chat-1"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.CreateReminderCalls()[0]
+				assert.Equal(t, "chat-1", call.ChatID)
+				assert.Equal(t, "Rehearsal at 19:00", call.Text)
+				assert.True(t, remindAt.Equal(call.RemindAt))
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp reminder.Reminder
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "01HZX", resp.ID)
+			}
+		})
+	}
+}
+
+func TestGetReminders(t *testing.T) {
+	service := &ReminderServiceMock{
+		GetRemindersFunc: func(ctx context.Context, userID int, chatID string) ([]reminder.Reminder, error) {
+			return []reminder.Reminder{{ID: "01HZX", ChatID: chatID, Text: "Rehearsal"}}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetReminders(rec, handlertest.NewRequest(http.MethodGet, "/api/chats/chat-1/reminders", nil, 1, map[string]string{"chatID": "chat-1"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp []reminder.Reminder
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+}
+
+func TestCancelReminder(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not author", reminder.ErrNotAuthor, http.StatusForbidden},
+		{"already sent", reminder.ErrReminderNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ReminderServiceMock{
+				CancelReminderFunc: func(ctx context.Context, userID int, chatID, reminderID string) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			params := map[string]string{"chatID": "chat-1", "reminderID": "01HZX"}
+			h.CancelReminder(rec, handlertest.NewRequest(http.MethodDelete, "/api/chats/chat-1/reminders/01HZX", nil, 1, params))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.CancelReminderCalls()[0]
+			assert.Equal(t, 1, call.UserID)
+			assert.Equal(t, "01HZX", call.ReminderID)
+		})
+	}
+}
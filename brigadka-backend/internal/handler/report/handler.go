@@ -0,0 +1,204 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/report"
+)
+
+//go:generate moq -out mocks_test.go . ReportService
+
+// ReportService defines the report operations used by the handler
+type ReportService interface {
+	GetReasons(ctx context.Context, lang string) ([]report.Reason, error)
+	CreateReport(ctx context.Context, reporterID int, req report.CreateRequest) (*report.Report, error)
+	GetReports(ctx context.Context, status string, page, pageSize int) (*report.ReportQueue, error)
+	ResolveReport(ctx context.Context, moderatorID, reportID int, action string) (*report.Resolution, error)
+}
+
+// Handler handles content reports
+type Handler struct {
+	service ReportService
+}
+
+// NewHandler creates a new report handler
+func NewHandler(service ReportService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateReportRequest represents a report about a profile, message or media
+type CreateReportRequest struct {
+	TargetType string `json:"target_type"` // profile, message or media
+	TargetID   string `json:"target_id"`   // User ID for profiles, message UUID or media ID
+	Reason     string `json:"reason"`      // Code from /reports/reasons
+	Comment    string `json:"comment,omitempty"`
+}
+
+// ResolveReportRequest represents a moderator's decision on a report
+type ResolveReportRequest struct {
+	Action string `json:"action"` // hide or dismiss
+}
+
+// @Summary      Report reasons
+// @Description  Catalog of reasons for reporting content
+// @Tags         reports
+// @Produce      json
+// @Param        lang  query  string  false  "Language code (default: ru)"
+// @Security     BearerAuth
+// @Success      200  {array}   report.Reason
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /reports/reasons [get]
+func (h *Handler) GetReasons(w http.ResponseWriter, r *http.Request) {
+	reasons, err := h.service.GetReasons(r.Context(), r.URL.Query().Get("lang"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reasons)
+}
+
+// @Summary      Report content
+// @Description  Report a profile, message or media to moderators
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        request  body  CreateReportRequest  true  "Report"
+// @Security     BearerAuth
+// @Success      201  {object}  report.Report
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Reported content not found"
+// @Failure      409  {string}  string  "Content already reported"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /reports [post]
+func (h *Handler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateReport(r.Context(), userID, report.CreateRequest{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Reason:     req.Reason,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// @Summary      Report queue
+// @Description  Reports in the given status, oldest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status     query  string  false  "open (default), resolved or dismissed"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Security     BearerAuth
+// @Success      200  {object}  report.ReportQueue
+// @Failure      400  {string}  string  "Invalid status"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/moderation/reports [get]
+func (h *Handler) GetReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	queue, err := h.service.GetReports(r.Context(), query.Get("status"), page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+// @Summary      Resolve report
+// @Description  Hide the reported content or dismiss the report. Closes all open reports on the same content. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        reportID  path  int                   true  "Report ID"
+// @Param        request   body  ResolveReportRequest  true  "Decision"
+// @Security     BearerAuth
+// @Success      200  {object}  report.Resolution
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Report not found"
+// @Failure      409  {string}  string  "Report already resolved"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/moderation/reports/{reportID}/resolve [post]
+func (h *Handler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	moderatorID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reportID, err := strconv.Atoi(chi.URLParam(r, "reportID"))
+	if err != nil {
+		http.Error(w, "Invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ResolveReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resolution, err := h.service.ResolveReport(r.Context(), moderatorID, reportID, req.Action)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resolution)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, report.ErrTargetNotFound):
+		http.Error(w, "Reported content not found", http.StatusNotFound)
+	case errors.Is(err, report.ErrReportNotFound):
+		http.Error(w, "Report not found", http.StatusNotFound)
+	case errors.Is(err, report.ErrAlreadyReported):
+		http.Error(w, "Content already reported", http.StatusConflict)
+	case errors.Is(err, report.ErrReportClosed):
+		http.Error(w, "Report already resolved", http.StatusConflict)
+	case errors.Is(err, report.ErrInvalidTarget), errors.Is(err, report.ErrInvalidReason),
+		errors.Is(err, report.ErrInvalidComment), errors.Is(err, report.ErrInvalidAction),
+		errors.Is(err, report.ErrInvalidStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Report error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,124 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/report"
+)
+
+func TestCreateReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 3, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid target", 3, report.ErrInvalidTarget, http.StatusBadRequest},
+		{"unknown reason", 3, report.ErrInvalidReason, http.StatusBadRequest},
+		{"target not found", 3, report.ErrTargetNotFound, http.StatusNotFound},
+		{"already reported", 3, report.ErrAlreadyReported, http.StatusConflict},
+		{"server error", 3, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ReportServiceMock{
+				CreateReportFunc: func(ctx context.Context, reporterID int, req report.CreateRequest) (*report.Report, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &report.Report{ID: 9, ReporterID: reporterID, TargetType: req.TargetType, TargetID: req.TargetID, Status: "open"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := CreateReportRequest{TargetType: "profile", TargetID: "5", Reason: "spam", Comment: "Ads in bio"}
+			rec := httptest.NewRecorder()
+			h.CreateReport(rec, handlertest.NewRequest(http.MethodPost, "/api/reports", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.CreateReportCalls()[0]
+				assert.Equal(t, 3, call.ReporterID)
+				assert.Equal(t, report.CreateRequest{TargetType: "profile", TargetID: "5", Reason: "spam", Comment: "Ads in bio"}, call.Req)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp report.Report
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 9, resp.ID)
+			}
+		})
+	}
+}
+
+func TestGetReports(t *testing.T) {
+	service := &ReportServiceMock{
+		GetReportsFunc: func(ctx context.Context, status string, page int, pageSize int) (*report.ReportQueue, error) {
+			return &report.ReportQueue{Items: []report.Report{{ID: 9}}, TotalCount: 1, Page: page, PageSize: pageSize}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetReports(rec, handlertest.NewRequest(http.MethodGet, "/api/admin/moderation/reports?status=dismissed&page=2&page_size=10", nil, 1, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.GetReportsCalls()[0]
+	assert.Equal(t, "dismissed", call.Status)
+	assert.Equal(t, 2, call.Page)
+	assert.Equal(t, 10, call.PageSize)
+}
+
+func TestResolveReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		reportID   string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "9", nil, http.StatusOK},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"invalid action", "9", report.ErrInvalidAction, http.StatusBadRequest},
+		{"not found", "9", report.ErrReportNotFound, http.StatusNotFound},
+		{"already resolved", "9", report.ErrReportClosed, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ReportServiceMock{
+				ResolveReportFunc: func(ctx context.Context, moderatorID int, reportID int, action string) (*report.Resolution, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &report.Resolution{TargetType: "media", TargetID: "10", Action: action, ResolvedReports: 2}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			params := map[string]string{"reportID": tt.reportID}
+			h.ResolveReport(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/moderation/reports/"+tt.reportID+"/resolve", ResolveReportRequest{Action: report.ActionHide}, 1, params))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp report.Resolution
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 2, resp.ResolvedReports)
+				call := service.ResolveReportCalls()[0]
+				assert.Equal(t, 1, call.ModeratorID)
+				assert.Equal(t, 9, call.ReportID)
+				assert.Equal(t, report.ActionHide, call.Action)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package suspension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
+)
+
+//go:generate moq -out mocks_test.go . SuspensionService
+
+// SuspensionService defines the suspension operations used by the handler
+type SuspensionService interface {
+	SuspendUser(ctx context.Context, adminID, userID int, reason string, duration time.Duration) (*suspension.Suspension, error)
+	LiftSuspension(ctx context.Context, adminID, userID int) error
+	GetStatus(ctx context.Context, userID int) (*suspension.Status, error)
+	SubmitAppeal(ctx context.Context, userID int, message string) (*suspension.Appeal, error)
+	GetAppeals(ctx context.Context, page, pageSize int) (*suspension.AppealQueue, error)
+	ReviewAppeal(ctx context.Context, adminID, appealID int, accept bool) error
+}
+
+// Handler handles account suspensions and appeals
+type Handler struct {
+	service SuspensionService
+}
+
+// NewHandler creates a new suspension handler
+func NewHandler(service SuspensionService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// SuspendRequest represents an admin request to suspend a user
+type SuspendRequest struct {
+	Reason        string `json:"reason"`
+	DurationHours int    `json:"duration_hours,omitempty"` // 0 suspends until lifted by an admin
+}
+
+// AppealRequest represents a suspended user's appeal
+type AppealRequest struct {
+	Message string `json:"message"`
+}
+
+// @Summary      Suspension status
+// @Description  Active suspension of the current user and its appeal. Available to suspended users.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  suspension.Status
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /auth/suspension [get]
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.service.GetStatus(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// @Summary      Appeal suspension
+// @Description  Send the active suspension to the moderation queue for review. Each suspension can be appealed once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  AppealRequest  true  "Appeal"
+// @Security     BearerAuth
+// @Success      201  {object}  suspension.Appeal
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      409  {string}  string  "Not suspended or already appealed"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /auth/suspension/appeal [post]
+func (h *Handler) SubmitAppeal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req AppealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	appeal, err := h.service.SubmitAppeal(r.Context(), userID, req.Message)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(appeal)
+}
+
+// @Summary      Suspend user
+// @Description  Block the user from the API for the given number of hours, or until lifted. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        userID   path  int             true  "User ID"
+// @Param        request  body  SuspendRequest  true  "Suspension"
+// @Security     BearerAuth
+// @Success      201  {object}  suspension.Suspension
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "User not found"
+// @Failure      409  {string}  string  "User is already suspended"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/users/{userID}/suspension [post]
+func (h *Handler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req SuspendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.SuspendUser(r.Context(), adminID, userID, req.Reason, time.Duration(req.DurationHours)*time.Hour)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// @Summary      Lift suspension
+// @Description  Lift the active suspension of the user ahead of time. Admin only.
+// @Tags         admin
+// @Param        userID  path  int  true  "User ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid user ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      409  {string}  string  "User is not suspended"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/users/{userID}/suspension [delete]
+func (h *Handler) LiftSuspension(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.LiftSuspension(r.Context(), adminID, userID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Appeal queue
+// @Description  Pending suspension appeals, oldest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        page       query  int  false  "Page number"
+// @Param        page_size  query  int  false  "Page size"
+// @Security     BearerAuth
+// @Success      200  {object}  suspension.AppealQueue
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /admin/moderation/appeals [get]
+func (h *Handler) GetAppeals(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	queue, err := h.service.GetAppeals(r.Context(), page, pageSize)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+// @Summary      Accept appeal
+// @Description  Accept a pending appeal and lift the suspension. Admin only.
+// @Tags         admin
+// @Param        appealID  path  int  true  "Appeal ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid appeal ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Appeal not found"
+// @Router       /admin/moderation/appeals/{appealID}/accept [post]
+func (h *Handler) AcceptAppeal(w http.ResponseWriter, r *http.Request) {
+	h.reviewAppeal(w, r, true)
+}
+
+// @Summary      Reject appeal
+// @Description  Reject a pending appeal; the suspension stays in effect. Admin only.
+// @Tags         admin
+// @Param        appealID  path  int  true  "Appeal ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid appeal ID"
+// @Failure      403  {string}  string  "Forbidden"
+// @Failure      404  {string}  string  "Appeal not found"
+// @Router       /admin/moderation/appeals/{appealID}/reject [post]
+func (h *Handler) RejectAppeal(w http.ResponseWriter, r *http.Request) {
+	h.reviewAppeal(w, r, false)
+}
+
+func (h *Handler) reviewAppeal(w http.ResponseWriter, r *http.Request, accept bool) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	appealID, err := strconv.Atoi(chi.URLParam(r, "appealID"))
+	if err != nil {
+		http.Error(w, "Invalid appeal ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ReviewAppeal(r.Context(), adminID, appealID, accept); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, suspension.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, suspension.ErrAppealNotFound):
+		http.Error(w, "Appeal not found", http.StatusNotFound)
+	case errors.Is(err, suspension.ErrCannotSuspendAdmin):
+		http.Error(w, "Admins cannot be suspended", http.StatusForbidden)
+	case errors.Is(err, suspension.ErrAlreadySuspended):
+		http.Error(w, "User is already suspended", http.StatusConflict)
+	case errors.Is(err, suspension.ErrNotSuspended):
+		http.Error(w, "User is not suspended", http.StatusConflict)
+	case errors.Is(err, suspension.ErrAppealExists):
+		http.Error(w, "Suspension already appealed", http.StatusConflict)
+	case errors.Is(err, suspension.ErrInvalidReason), errors.Is(err, suspension.ErrInvalidDuration),
+		errors.Is(err, suspension.ErrInvalidMessage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Suspension error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,154 @@
+package suspension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
+)
+
+func TestSuspendUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "5", nil, http.StatusCreated},
+		{"invalid user id", "abc", nil, http.StatusBadRequest},
+		{"user not found", "5", suspension.ErrUserNotFound, http.StatusNotFound},
+		{"admin", "5", suspension.ErrCannotSuspendAdmin, http.StatusForbidden},
+		{"already suspended", "5", suspension.ErrAlreadySuspended, http.StatusConflict},
+		{"empty reason", "5", suspension.ErrInvalidReason, http.StatusBadRequest},
+		{"server error", "5", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &SuspensionServiceMock{
+				SuspendUserFunc: func(ctx context.Context, adminID int, userID int, reason string, duration time.Duration) (*suspension.Suspension, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &suspension.Suspension{ID: 3, UserID: userID, Reason: reason}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := SuspendRequest{Reason: "Spam", DurationHours: 48}
+			rec := httptest.NewRecorder()
+			h.SuspendUser(rec, handlertest.NewRequest(http.MethodPost, "/api/admin/users/"+tt.userID+"/suspension", body, 1, map[string]string{"userID": tt.userID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID == "5" {
+				call := service.SuspendUserCalls()[0]
+				assert.Equal(t, 1, call.AdminID)
+				assert.Equal(t, 5, call.UserID)
+				assert.Equal(t, 48*time.Hour, call.Duration)
+			}
+		})
+	}
+}
+
+func TestLiftSuspensionNotSuspended(t *testing.T) {
+	service := &SuspensionServiceMock{
+		LiftSuspensionFunc: func(ctx context.Context, adminID int, userID int) error {
+			return suspension.ErrNotSuspended
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.LiftSuspension(rec, handlertest.NewRequest(http.MethodDelete, "/api/admin/users/5/suspension", nil, 1, map[string]string{"userID": "5"}))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSubmitAppeal(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 5, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"not suspended", 5, suspension.ErrNotSuspended, http.StatusConflict},
+		{"already appealed", 5, suspension.ErrAppealExists, http.StatusConflict},
+		{"empty message", 5, suspension.ErrInvalidMessage, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &SuspensionServiceMock{
+				SubmitAppealFunc: func(ctx context.Context, userID int, message string) (*suspension.Appeal, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &suspension.Appeal{ID: 7, UserID: userID, Message: message, Status: "pending"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.SubmitAppeal(rec, handlertest.NewRequest(http.MethodPost, "/api/auth/suspension/appeal", AppealRequest{Message: "It was a mistake"}, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var resp suspension.Appeal
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 7, resp.ID)
+				assert.Equal(t, "It was a mistake", service.SubmitAppealCalls()[0].Message)
+			}
+		})
+	}
+}
+
+func TestReviewAppeal(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     bool
+		appealID   string
+		serviceErr error
+		wantStatus int
+	}{
+		{"accept", true, "7", nil, http.StatusNoContent},
+		{"reject", false, "7", nil, http.StatusNoContent},
+		{"invalid id", true, "abc", nil, http.StatusBadRequest},
+		{"already reviewed", false, "7", suspension.ErrAppealNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &SuspensionServiceMock{
+				ReviewAppealFunc: func(ctx context.Context, adminID int, appealID int, accept bool) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			req := handlertest.NewRequest(http.MethodPost, "/api/admin/moderation/appeals/"+tt.appealID, nil, 1, map[string]string{"appealID": tt.appealID})
+			rec := httptest.NewRecorder()
+			if tt.accept {
+				h.AcceptAppeal(rec, req)
+			} else {
+				h.RejectAppeal(rec, req)
+			}
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.appealID == "7" {
+				call := service.ReviewAppealCalls()[0]
+				assert.Equal(t, 7, call.AppealID)
+				assert.Equal(t, tt.accept, call.Accept)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+)
+
+// ApplyRequest represents the request body for applying to a team
+type ApplyRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// @Summary      Apply to Team
+// @Description  Sends a request to join a team; team owners get a push notification
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        teamID   path  int           true   "Team ID"
+// @Param        request  body  ApplyRequest  false  "Message to the team owners"
+// @Security     BearerAuth
+// @Success      201  {object}  team.Application
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      409  {string}  string  "Already a member, application pending or no open slots"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/applications [post]
+func (h *Handler) Apply(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	// The message is optional, so an empty body is accepted
+	var req ApplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	app, err := h.service.Apply(r.Context(), userID, teamID, req.Message)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, app)
+}
+
+// @Summary      List Team Applications
+// @Description  Lists applications to join a team, newest first; only team owners may do this
+// @Tags         teams
+// @Produce      json
+// @Param        teamID  path   int     true   "Team ID"
+// @Param        status  query  string  false  "Filter by status (pending, accepted, rejected)"
+// @Security     BearerAuth
+// @Success      200  {array}   team.Application
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/applications [get]
+func (h *Handler) GetApplications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	apps, err := h.service.GetApplications(r.Context(), userID, teamID, r.URL.Query().Get("status"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apps)
+}
+
+// @Summary      Accept Team Application
+// @Description  Adds the applicant to the team and the team chat; only team owners may do this
+// @Tags         teams
+// @Produce      json
+// @Param        teamID         path  int  true  "Team ID"
+// @Param        applicationID  path  int  true  "Application ID"
+// @Security     BearerAuth
+// @Success      200  {object}  team.Application
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team or application not found"
+// @Failure      409  {string}  string  "Application is already decided"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/applications/{applicationID}/accept [post]
+func (h *Handler) AcceptApplication(w http.ResponseWriter, r *http.Request) {
+	h.decideApplication(w, r, h.service.AcceptApplication)
+}
+
+// @Summary      Reject Team Application
+// @Description  Declines an application to join a team; only team owners may do this
+// @Tags         teams
+// @Produce      json
+// @Param        teamID         path  int  true  "Team ID"
+// @Param        applicationID  path  int  true  "Application ID"
+// @Security     BearerAuth
+// @Success      200  {object}  team.Application
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team or application not found"
+// @Failure      409  {string}  string  "Application is already decided"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/applications/{applicationID}/reject [post]
+func (h *Handler) RejectApplication(w http.ResponseWriter, r *http.Request) {
+	h.decideApplication(w, r, h.service.RejectApplication)
+}
+
+func (h *Handler) decideApplication(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, userID, teamID, applicationID int) (*team.Application, error)) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	applicationID, err := strconv.Atoi(chi.URLParam(r, "applicationID"))
+	if err != nil {
+		http.Error(w, "Invalid application ID", http.StatusBadRequest)
+		return
+	}
+
+	app, err := decide(r.Context(), userID, teamID, applicationID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, app)
+}
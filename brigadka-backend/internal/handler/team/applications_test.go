@@ -0,0 +1,125 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", ApplyRequest{Message: "Hi!"}, nil, http.StatusCreated},
+		{"empty body", nil, nil, http.StatusCreated},
+		{"already pending", ApplyRequest{}, team.ErrApplicationExists, http.StatusConflict},
+		{"no open slots", ApplyRequest{}, team.ErrNoOpenSlots, http.StatusConflict},
+		{"already member", ApplyRequest{}, team.ErrAlreadyMember, http.StatusConflict},
+		{"team not found", ApplyRequest{}, team.ErrTeamNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				ApplyFunc: func(ctx context.Context, userID int, teamID int, message string) (*team.Application, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Application{ID: 1, TeamID: teamID, UserID: userID, Message: message, Status: team.ApplicationPending}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.Apply(rec, handlertest.NewRequest(http.MethodPost, "/api/teams/10/applications", tt.body, 2, map[string]string{"teamID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.ApplyCalls()[0]
+			assert.Equal(t, 2, call.UserID)
+			assert.Equal(t, 10, call.TeamID)
+		})
+	}
+}
+
+func TestGetApplications(t *testing.T) {
+	service := &TeamServiceMock{
+		GetApplicationsFunc: func(ctx context.Context, userID int, teamID int, status string) ([]team.Application, error) {
+			return []team.Application{{ID: 1, TeamID: teamID, UserID: 2, Status: status}}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.GetApplications(rec, handlertest.NewRequest(http.MethodGet, "/api/teams/10/applications?status=pending", nil, 1, map[string]string{"teamID": "10"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, team.ApplicationPending, service.GetApplicationsCalls()[0].Status)
+
+	var apps []team.Application
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&apps))
+	assert.Len(t, apps, 1)
+}
+
+func TestAcceptApplication(t *testing.T) {
+	tests := []struct {
+		name          string
+		applicationID string
+		serviceErr    error
+		wantStatus    int
+	}{
+		{"success", "5", nil, http.StatusOK},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not owner", "5", team.ErrNotTeamOwner, http.StatusForbidden},
+		{"not found", "5", team.ErrApplicationNotFound, http.StatusNotFound},
+		{"already decided", "5", team.ErrApplicationDecided, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				AcceptApplicationFunc: func(ctx context.Context, userID int, teamID int, applicationID int) (*team.Application, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Application{ID: applicationID, TeamID: teamID, Status: team.ApplicationAccepted}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			params := map[string]string{"teamID": "10", "applicationID": tt.applicationID}
+			h.AcceptApplication(rec, handlertest.NewRequest(http.MethodPost, "/api/teams/10/applications/"+tt.applicationID+"/accept", nil, 1, params))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestRejectApplication(t *testing.T) {
+	service := &TeamServiceMock{
+		RejectApplicationFunc: func(ctx context.Context, userID int, teamID int, applicationID int) (*team.Application, error) {
+			return &team.Application{ID: applicationID, TeamID: teamID, Status: team.ApplicationRejected}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	params := map[string]string{"teamID": "10", "applicationID": "5"}
+	h.RejectApplication(rec, handlertest.NewRequest(http.MethodPost, "/api/teams/10/applications/5/reject", nil, 1, params))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	call := service.RejectApplicationCalls()[0]
+	assert.Equal(t, 1, call.UserID)
+	assert.Equal(t, 5, call.ApplicationID)
+	assert.Empty(t, service.AcceptApplicationCalls())
+}
@@ -0,0 +1,492 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+)
+
+//go:generate moq -out mocks_test.go . TeamService
+
+// TeamService defines the team operations used by the handler
+type TeamService interface {
+	CreateTeam(ctx context.Context, userID int, req team.CreateRequest) (*team.Team, error)
+	GetTeam(ctx context.Context, teamID int) (*team.Team, error)
+	UpdateTeam(ctx context.Context, userID, teamID int, req team.UpdateRequest) (*team.Team, error)
+	DeleteTeam(ctx context.Context, userID, teamID int) error
+	GetMembers(ctx context.Context, teamID int) ([]team.Member, error)
+	AddMember(ctx context.Context, userID, teamID, memberID int, role string) (*team.Member, error)
+	UpdateMemberRole(ctx context.Context, userID, teamID, memberID int, role string) (*team.Member, error)
+	RemoveMember(ctx context.Context, userID, teamID, memberID int) error
+	Search(ctx context.Context, userID int, filter team.SearchFilter) (*team.SearchResult, error)
+	Apply(ctx context.Context, userID, teamID int, message string) (*team.Application, error)
+	GetApplications(ctx context.Context, userID, teamID int, status string) ([]team.Application, error)
+	AcceptApplication(ctx context.Context, userID, teamID, applicationID int) (*team.Application, error)
+	RejectApplication(ctx context.Context, userID, teamID, applicationID int) (*team.Application, error)
+}
+
+// Handler handles team endpoints
+type Handler struct {
+	service TeamService
+}
+
+// NewHandler creates a new team handler
+func NewHandler(service TeamService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateTeamRequest represents the request body for team creation
+type CreateTeamRequest struct {
+	Name         string   `json:"name"`
+	CityID       int      `json:"city_id"`
+	Bio          string   `json:"bio"`
+	ImprovStyles []string `json:"improv_styles"`
+	OpenSlots    int      `json:"open_slots"`
+	Avatar       *int     `json:"avatar,omitempty"`
+}
+
+// UpdateTeamRequest represents the request body for team update
+type UpdateTeamRequest struct {
+	Name         *string  `json:"name,omitempty"`
+	CityID       *int     `json:"city_id,omitempty"`
+	Bio          *string  `json:"bio,omitempty"`
+	ImprovStyles []string `json:"improv_styles,omitempty"`
+	OpenSlots    *int     `json:"open_slots,omitempty"`
+	Avatar       *int     `json:"avatar,omitempty"`
+}
+
+// AddMemberRequest represents the request body for adding a team member
+type AddMemberRequest struct {
+	UserID int    `json:"user_id"`
+	Role   string `json:"role,omitempty"`
+}
+
+// UpdateMemberRequest represents the request body for changing a member role
+type UpdateMemberRequest struct {
+	Role string `json:"role"`
+}
+
+// SearchRequest represents the request body for team search
+type SearchRequest struct {
+	Name         *string  `json:"name,omitempty"`
+	CityID       *int     `json:"city_id,omitempty"`
+	ImprovStyles []string `json:"improv_styles,omitempty"`
+	HasOpenSlots *bool    `json:"has_open_slots,omitempty"`
+	Page         int      `json:"page"`
+	PageSize     int      `json:"page_size"`
+}
+
+// @Summary      Create Team
+// @Description  Creates a team; the current user becomes its owner
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        request  body  CreateTeamRequest  true  "Team data"
+// @Security     BearerAuth
+// @Success      201  {object}  team.Team
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams [post]
+func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.CreateTeam(r.Context(), userID, team.CreateRequest{
+		Name:         req.Name,
+		CityID:       req.CityID,
+		Bio:          req.Bio,
+		ImprovStyles: req.ImprovStyles,
+		OpenSlots:    req.OpenSlots,
+		Avatar:       req.Avatar,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// @Summary      Get Team
+// @Description  Get a team by ID
+// @Tags         teams
+// @Produce      json
+// @Param        teamID  path  int  true  "Team ID"
+// @Security     BearerAuth
+// @Success      200  {object}  team.Team
+// @Failure      400  {string}  string  "Invalid team ID"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID} [get]
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetTeam(r.Context(), teamID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary      Update Team
+// @Description  Updates a team; only team owners may do this
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        teamID   path  int                true  "Team ID"
+// @Param        request  body  UpdateTeamRequest  true  "Fields to update"
+// @Security     BearerAuth
+// @Success      200  {object}  team.Team
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID} [patch]
+func (h *Handler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.UpdateTeam(r.Context(), userID, teamID, team.UpdateRequest{
+		Name:         req.Name,
+		CityID:       req.CityID,
+		Bio:          req.Bio,
+		ImprovStyles: req.ImprovStyles,
+		OpenSlots:    req.OpenSlots,
+		Avatar:       req.Avatar,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary      Delete Team
+// @Description  Deletes a team; only team owners may do this
+// @Tags         teams
+// @Param        teamID  path  int  true  "Team ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid team ID"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID} [delete]
+func (h *Handler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTeam(r.Context(), userID, teamID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      List Team Members
+// @Description  Returns the members of a team, owners first
+// @Tags         teams
+// @Produce      json
+// @Param        teamID  path  int  true  "Team ID"
+// @Security     BearerAuth
+// @Success      200  {array}   team.Member
+// @Failure      400  {string}  string  "Invalid team ID"
+// @Failure      404  {string}  string  "Team not found"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/members [get]
+func (h *Handler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	members, err := h.service.GetMembers(r.Context(), teamID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, members)
+}
+
+// @Summary      Add Team Member
+// @Description  Adds a user to a team; only team owners may do this
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        teamID   path  int               true  "Team ID"
+// @Param        request  body  AddMemberRequest  true  "Member to add (role defaults to member)"
+// @Security     BearerAuth
+// @Success      201  {object}  team.Member
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team or user not found"
+// @Failure      409  {string}  string  "User is already a team member"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/members [post]
+func (h *Handler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), userID, teamID, req.UserID, req.Role)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, member)
+}
+
+// @Summary      Update Team Member
+// @Description  Changes the role of a team member; only team owners may do this
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        teamID   path  int                  true  "Team ID"
+// @Param        userID   path  int                  true  "Member user ID"
+// @Param        request  body  UpdateMemberRequest  true  "New role"
+// @Security     BearerAuth
+// @Success      200  {object}  team.Member
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team or member not found"
+// @Failure      409  {string}  string  "Team must have at least one owner"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/members/{userID} [patch]
+func (h *Handler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, memberID, ok := parseMemberPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.service.UpdateMemberRole(r.Context(), userID, teamID, memberID, req.Role)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, member)
+}
+
+// @Summary      Remove Team Member
+// @Description  Removes a member from a team. Owners may remove anyone, members may only leave themselves.
+// @Tags         teams
+// @Param        teamID  path  int  true  "Team ID"
+// @Param        userID  path  int  true  "Member user ID"
+// @Security     BearerAuth
+// @Success      204
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      403  {string}  string  "Only team owners can do this"
+// @Failure      404  {string}  string  "Team or member not found"
+// @Failure      409  {string}  string  "Team must have at least one owner"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/{teamID}/members/{userID} [delete]
+func (h *Handler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	teamID, memberID, ok := parseMemberPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), userID, teamID, memberID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary      Search Teams
+// @Description  Search for teams; results are sorted by improv styles shared with the current user
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        request  body  SearchRequest  true  "Search filters"
+// @Security     BearerAuth
+// @Success      200  {object}  team.SearchResult
+// @Failure      400  {string}  string  "Invalid request"
+// @Failure      500  {string}  string  "Server error"
+// @Router       /teams/search [post]
+func (h *Handler) SearchTeams(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req SearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Search(r.Context(), userID, team.SearchFilter{
+		Name:         req.Name,
+		CityID:       req.CityID,
+		ImprovStyles: req.ImprovStyles,
+		HasOpenSlots: req.HasOpenSlots,
+		Page:         req.Page,
+		PageSize:     req.PageSize,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func parseMemberPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	teamID, err := strconv.Atoi(chi.URLParam(r, "teamID"))
+	if err != nil {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	memberID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	return teamID, memberID, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, team.ErrTeamNotFound):
+		http.Error(w, "Team not found", http.StatusNotFound)
+	case errors.Is(err, team.ErrMemberNotFound):
+		http.Error(w, "Team member not found", http.StatusNotFound)
+	case errors.Is(err, team.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, team.ErrNotTeamOwner):
+		http.Error(w, "Only team owners can do this", http.StatusForbidden)
+	case errors.Is(err, team.ErrAlreadyMember):
+		http.Error(w, "User is already a team member", http.StatusConflict)
+	case errors.Is(err, team.ErrLastOwner):
+		http.Error(w, "Team must have at least one owner", http.StatusConflict)
+	case errors.Is(err, team.ErrInvalidName):
+		http.Error(w, "Invalid team name", http.StatusBadRequest)
+	case errors.Is(err, team.ErrInvalidRole):
+		http.Error(w, "Invalid team role", http.StatusBadRequest)
+	case errors.Is(err, team.ErrInvalidCity):
+		http.Error(w, "Invalid city", http.StatusBadRequest)
+	case errors.Is(err, team.ErrInvalidImprovStyle):
+		http.Error(w, "Invalid improv style", http.StatusBadRequest)
+	case errors.Is(err, team.ErrInvalidOpenSlots):
+		http.Error(w, "Open slots must not be negative", http.StatusBadRequest)
+	case errors.Is(err, team.ErrInvalidAvatar):
+		http.Error(w, "Invalid avatar", http.StatusBadRequest)
+	case errors.Is(err, team.ErrApplicationNotFound):
+		http.Error(w, "Application not found", http.StatusNotFound)
+	case errors.Is(err, team.ErrApplicationExists):
+		http.Error(w, "Application is already pending", http.StatusConflict)
+	case errors.Is(err, team.ErrApplicationDecided):
+		http.Error(w, "Application is already decided", http.StatusConflict)
+	case errors.Is(err, team.ErrNoOpenSlots):
+		http.Error(w, "Team has no open slots", http.StatusConflict)
+	case errors.Is(err, team.ErrInvalidStatus):
+		http.Error(w, "Invalid application status", http.StatusBadRequest)
+	default:
+		log.Printf("Team error: %v", err)
+		http.Error(w, "Server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,260 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/handler/handlertest"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/team"
+)
+
+func TestCreateTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", 1, nil, http.StatusCreated},
+		{"unauthorized", 0, nil, http.StatusUnauthorized},
+		{"invalid city", 1, team.ErrInvalidCity, http.StatusBadRequest},
+		{"invalid name", 1, team.ErrInvalidName, http.StatusBadRequest},
+		{"server error", 1, errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				CreateTeamFunc: func(ctx context.Context, userID int, req team.CreateRequest) (*team.Team, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Team{ID: 10, Name: req.Name, CityID: req.CityID, MemberCount: 1}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			body := CreateTeamRequest{Name: "Impro", CityID: 1, ImprovStyles: []string{"shortform"}, OpenSlots: 2}
+			rec := httptest.NewRecorder()
+			h.CreateTeam(rec, handlertest.NewRequest(http.MethodPost, "/api/teams", body, tt.userID, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.userID != 0 {
+				call := service.CreateTeamCalls()[0]
+				assert.Equal(t, tt.userID, call.UserID)
+				assert.Equal(t, "Impro", call.Req.Name)
+				assert.Equal(t, 2, call.Req.OpenSlots)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				var resp team.Team
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, 10, resp.ID)
+			}
+		})
+	}
+}
+
+func TestGetTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamID     string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "10", nil, http.StatusOK},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not found", "10", team.ErrTeamNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				GetTeamFunc: func(ctx context.Context, teamID int) (*team.Team, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Team{ID: teamID, Name: "Impro"}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			// Teams are visible to guests, so no user is set
+			rec := httptest.NewRecorder()
+			h.GetTeam(rec, handlertest.NewRequest(http.MethodGet, "/api/teams/"+tt.teamID, nil, 0, map[string]string{"teamID": tt.teamID}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestUpdateTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"not owner", team.ErrNotTeamOwner, http.StatusForbidden},
+		{"not found", team.ErrTeamNotFound, http.StatusNotFound},
+		{"negative open slots", team.ErrInvalidOpenSlots, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				UpdateTeamFunc: func(ctx context.Context, userID int, teamID int, req team.UpdateRequest) (*team.Team, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Team{ID: teamID, OpenSlots: *req.OpenSlots}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			openSlots := 3
+			rec := httptest.NewRecorder()
+			h.UpdateTeam(rec, handlertest.NewRequest(http.MethodPatch, "/api/teams/10", UpdateTeamRequest{OpenSlots: &openSlots}, 1, map[string]string{"teamID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.UpdateTeamCalls()[0]
+			assert.Equal(t, 1, call.UserID)
+			assert.Equal(t, 10, call.TeamID)
+		})
+	}
+}
+
+func TestDeleteTeam(t *testing.T) {
+	service := &TeamServiceMock{
+		DeleteTeamFunc: func(ctx context.Context, userID int, teamID int) error {
+			return nil
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	h.DeleteTeam(rec, handlertest.NewRequest(http.MethodDelete, "/api/teams/10", nil, 1, map[string]string{"teamID": "10"}))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Len(t, service.DeleteTeamCalls(), 1)
+}
+
+func TestAddMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"already member", team.ErrAlreadyMember, http.StatusConflict},
+		{"user not found", team.ErrUserNotFound, http.StatusNotFound},
+		{"invalid role", team.ErrInvalidRole, http.StatusBadRequest},
+		{"not owner", team.ErrNotTeamOwner, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				AddMemberFunc: func(ctx context.Context, userID int, teamID int, memberID int, role string) (*team.Member, error) {
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &team.Member{UserID: memberID, Role: team.RoleMember}, nil
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			h.AddMember(rec, handlertest.NewRequest(http.MethodPost, "/api/teams/10/members", AddMemberRequest{UserID: 2}, 1, map[string]string{"teamID": "10"}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			call := service.AddMemberCalls()[0]
+			assert.Equal(t, 2, call.MemberID)
+			assert.Equal(t, "", call.Role)
+		})
+	}
+}
+
+func TestUpdateMember(t *testing.T) {
+	service := &TeamServiceMock{
+		UpdateMemberRoleFunc: func(ctx context.Context, userID int, teamID int, memberID int, role string) (*team.Member, error) {
+			return nil, team.ErrLastOwner
+		},
+	}
+	h := NewHandler(service)
+
+	rec := httptest.NewRecorder()
+	params := map[string]string{"teamID": "10", "userID": "1"}
+	h.UpdateMember(rec, handlertest.NewRequest(http.MethodPatch, "/api/teams/10/members/1", UpdateMemberRequest{Role: team.RoleMember}, 1, params))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, team.RoleMember, service.UpdateMemberRoleCalls()[0].Role)
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		memberID   string
+		serviceErr error
+		wantStatus int
+	}{
+		{"success", "2", nil, http.StatusNoContent},
+		{"invalid user id", "abc", nil, http.StatusBadRequest},
+		{"not member", "2", team.ErrMemberNotFound, http.StatusNotFound},
+		{"last owner", "1", team.ErrLastOwner, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TeamServiceMock{
+				RemoveMemberFunc: func(ctx context.Context, userID int, teamID int, memberID int) error {
+					return tt.serviceErr
+				},
+			}
+			h := NewHandler(service)
+
+			rec := httptest.NewRecorder()
+			params := map[string]string{"teamID": "10", "userID": tt.memberID}
+			h.RemoveMember(rec, handlertest.NewRequest(http.MethodDelete, "/api/teams/10/members/"+tt.memberID, nil, 1, params))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestSearchTeams(t *testing.T) {
+	service := &TeamServiceMock{
+		SearchFunc: func(ctx context.Context, userID int, filter team.SearchFilter) (*team.SearchResult, error) {
+			return &team.SearchResult{
+				Teams:      []team.Team{{ID: 10, Name: "Impro", OpenSlots: 2}},
+				TotalCount: 1,
+				Page:       1,
+				PageSize:   20,
+			}, nil
+		},
+	}
+	h := NewHandler(service)
+
+	cityID := 1
+	hasOpenSlots := true
+	body := SearchRequest{CityID: &cityID, HasOpenSlots: &hasOpenSlots, ImprovStyles: []string{"shortform"}}
+
+	rec := httptest.NewRecorder()
+	h.SearchTeams(rec, handlertest.NewRequest(http.MethodPost, "/api/teams/search", body, 5, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	filter := service.SearchCalls()[0].Filter
+	assert.Equal(t, 1, *filter.CityID)
+	assert.True(t, *filter.HasOpenSlots)
+	assert.Equal(t, []string{"shortform"}, filter.ImprovStyles)
+
+	var resp team.SearchResult
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.TotalCount)
+	assert.Len(t, resp.Teams, 1)
+}
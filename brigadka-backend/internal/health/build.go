@@ -0,0 +1,72 @@
+// Package health collects the runtime details reported by /health/details
+package health
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time:
+//
+//	go build -ldflags "-X github.com/bulatminnakhmetov/brigadka-backend/internal/health.GitSHA=$(git rev-parse HEAD) \
+//	  -X github.com/bulatminnakhmetov/brigadka-backend/internal/health.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When unset, the VCS information embedded by the Go toolchain is used.
+var (
+	GitSHA    string
+	BuildTime string
+)
+
+// Module is a dependency compiled into the binary
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	GitSHA    string   `json:"git_sha,omitempty"`
+	BuildTime string   `json:"build_time,omitempty"`
+	Modified  bool     `json:"modified,omitempty"` // Built from a tree with uncommitted changes
+	GoVersion string   `json:"go_version"`
+	Modules   []Module `json:"modules"`
+}
+
+// Build returns the build information of the running binary
+func Build() BuildInfo {
+	info := BuildInfo{
+		GitSHA:    GitSHA,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Modules:   []Module{},
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.GitSHA == "" {
+				info.GitSHA = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildTime == "" {
+				info.BuildTime = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	for _, dep := range build.Deps {
+		module := dep
+		if dep.Replace != nil {
+			module = dep.Replace
+		}
+		info.Modules = append(info.Modules, Module{Path: dep.Path, Version: module.Version})
+	}
+	return info
+}
@@ -0,0 +1,26 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// MigrationStatus is the schema version recorded by golang-migrate
+type MigrationStatus struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"` // A migration failed halfway and needs manual repair
+}
+
+// GetMigrationStatus reads the applied schema version. Returns nil when no migration has been applied.
+func GetMigrationStatus(ctx context.Context, db *sql.DB) (*MigrationStatus, error) {
+	var status MigrationStatus
+	err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&status.Version, &status.Dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
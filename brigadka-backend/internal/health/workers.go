@@ -0,0 +1,110 @@
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// staleAfterRuns is how many intervals a worker may miss before it is reported unhealthy
+const staleAfterRuns = 3
+
+// WorkerStatus is a snapshot of a background worker
+type WorkerStatus struct {
+	Name          string     `json:"name"`
+	Interval      string     `json:"interval"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"` // Error of the last run, empty when it succeeded
+	Healthy       bool       `json:"healthy"`
+}
+
+// Worker tracks the runs of a periodic background worker
+type Worker struct {
+	name      string
+	interval  time.Duration
+	startedAt time.Time
+
+	mu            sync.Mutex
+	lastRunAt     time.Time
+	lastSuccessAt time.Time
+	lastError     error
+}
+
+// ObserveRun records the outcome of a run
+func (w *Worker) ObserveRun(err error) {
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastRunAt = now
+	w.lastError = err
+	if err == nil {
+		w.lastSuccessAt = now
+	}
+}
+
+// Status returns the worker state at now. A worker is healthy while it has
+// succeeded within the last few intervals, or has only just started.
+func (w *Worker) Status(now time.Time) WorkerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := WorkerStatus{
+		Name:     w.name,
+		Interval: w.interval.String(),
+	}
+	if !w.lastRunAt.IsZero() {
+		lastRunAt := w.lastRunAt
+		status.LastRunAt = &lastRunAt
+	}
+	if !w.lastSuccessAt.IsZero() {
+		lastSuccessAt := w.lastSuccessAt
+		status.LastSuccessAt = &lastSuccessAt
+	}
+	if w.lastError != nil {
+		status.LastError = w.lastError.Error()
+	}
+
+	since := w.startedAt
+	if !w.lastSuccessAt.IsZero() {
+		since = w.lastSuccessAt
+	}
+	status.Healthy = now.Sub(since) <= staleAfterRuns*w.interval
+	return status
+}
+
+// Workers is the registry of background workers
+type Workers struct {
+	mu      sync.Mutex
+	workers map[string]*Worker
+}
+
+// NewWorkers creates an empty worker registry
+func NewWorkers() *Workers {
+	return &Workers{workers: make(map[string]*Worker)}
+}
+
+// Register adds a worker that runs every interval
+func (r *Workers) Register(name string, interval time.Duration) *Worker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	worker := &Worker{name: name, interval: interval, startedAt: time.Now()}
+	r.workers[name] = worker
+	return worker
+}
+
+// Statuses returns the state of every worker, sorted by name
+func (r *Workers) Statuses(now time.Time) []WorkerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]WorkerStatus, 0, len(r.workers))
+	for _, worker := range r.workers {
+		statuses = append(statuses, worker.Status(now))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerStatus(t *testing.T) {
+	workers := NewWorkers()
+	worker := workers.Register("reminders", time.Minute)
+	now := time.Now()
+
+	// A worker that has not run yet is healthy until it misses a few intervals
+	statuses := workers.Statuses(now)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, "reminders", statuses[0].Name)
+		assert.Equal(t, "1m0s", statuses[0].Interval)
+		assert.Nil(t, statuses[0].LastRunAt)
+		assert.True(t, statuses[0].Healthy)
+	}
+	assert.False(t, worker.Status(now.Add(4*time.Minute)).Healthy)
+
+	worker.ObserveRun(nil)
+	worker.ObserveRun(errors.New("db down"))
+	status := worker.Status(time.Now())
+	assert.NotNil(t, status.LastSuccessAt)
+	assert.Equal(t, "db down", status.LastError)
+	assert.True(t, status.Healthy)
+
+	// Failing runs do not count as progress
+	assert.False(t, worker.Status(time.Now().Add(4*time.Minute)).Healthy)
+}
+
+func TestWorkersSortedByName(t *testing.T) {
+	workers := NewWorkers()
+	workers.Register("suspensions", time.Minute)
+	workers.Register("reminders", time.Minute)
+
+	statuses := workers.Statuses(time.Now())
+	assert.Equal(t, "reminders", statuses[0].Name)
+	assert.Equal(t, "suspensions", statuses[1].Name)
+}
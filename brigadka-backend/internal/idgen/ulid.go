@@ -0,0 +1,136 @@
+// Package idgen generates identifiers for server-created entities.
+//
+// IDs are ULIDs (https://github.com/ulid/spec): a 48-bit millisecond
+// timestamp followed by 80 random bits, encoded as 26 characters of
+// Crockford base32. They sort lexicographically in creation order, which
+// keeps B-tree inserts local and lets rows be paged by ID.
+package idgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Length is the length of an encoded ULID
+const Length = 26
+
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var ErrInvalidID = errors.New("invalid ULID")
+
+var (
+	mu         sync.Mutex
+	lastMillis uint64
+	lastRandom [10]byte
+)
+
+// New returns a new ULID for the current time. IDs generated within the
+// same millisecond are strictly increasing.
+func New() string {
+	return NewAt(time.Now())
+}
+
+// NewAt returns a new ULID for the given time
+func NewAt(t time.Time) string {
+	millis := uint64(t.UnixMilli())
+
+	mu.Lock()
+	// Within the same millisecond the sequence continues from the previous ID
+	if millis != lastMillis || !increment(&lastRandom) {
+		lastMillis = millis
+		if _, err := rand.Read(lastRandom[:]); err != nil {
+			mu.Unlock()
+			panic("idgen: failed to read random bytes: " + err.Error())
+		}
+	}
+	random := lastRandom
+	mu.Unlock()
+
+	var id [16]byte
+	id[0] = byte(millis >> 40)
+	id[1] = byte(millis >> 32)
+	id[2] = byte(millis >> 24)
+	id[3] = byte(millis >> 16)
+	id[4] = byte(millis >> 8)
+	id[5] = byte(millis)
+	copy(id[6:], random[:])
+
+	return encode(id)
+}
+
+// Time returns the creation time encoded in a ULID
+func Time(id string) (time.Time, error) {
+	if !Valid(id) {
+		return time.Time{}, ErrInvalidID
+	}
+
+	var millis uint64
+	for i := 0; i < 10; i++ {
+		millis = millis<<5 | uint64(decodeChar(id[i]))
+	}
+	return time.UnixMilli(int64(millis)), nil
+}
+
+// Valid reports whether id is a well-formed ULID
+func Valid(id string) bool {
+	if len(id) != Length {
+		return false
+	}
+	// The first character only carries 3 bits
+	if decodeChar(id[0]) > 7 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if decodeChar(id[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// increment adds one to the random component, reporting false on overflow
+func increment(b *[10]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode renders 128 bits as 26 base32 characters, most significant first
+func encode(id [16]byte) string {
+	out := make([]byte, Length)
+	// 130 output bits for 128 input bits: the first character holds the top 3 bits
+	var acc uint32
+	bits := 2
+	j := 0
+	for _, b := range id {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[j] = alphabet[(acc>>uint(bits))&31]
+			j++
+		}
+	}
+	return string(out)
+}
+
+func decodeChar(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		c -= 'a' - 'A'
+	}
+	for i := 10; i < len(alphabet); i++ {
+		if alphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
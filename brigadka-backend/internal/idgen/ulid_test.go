@@ -0,0 +1,45 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAtEncodesTimestamp(t *testing.T) {
+	// Timestamp example from the ULID specification
+	id := NewAt(time.UnixMilli(1469918176385))
+
+	assert.Len(t, id, Length)
+	assert.Equal(t, "01ARYZ6S41", id[:10])
+	assert.True(t, Valid(id))
+
+	ts, err := Time(id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1469918176385), ts.UnixMilli())
+}
+
+func TestNewIsMonotonic(t *testing.T) {
+	now := time.Now()
+	prev := NewAt(now)
+	for i := 0; i < 1000; i++ {
+		next := NewAt(now)
+		assert.True(t, next > prev, "%s should sort after %s", next, prev)
+		prev = next
+	}
+
+	assert.True(t, NewAt(now.Add(time.Millisecond)) > prev)
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid(New()))
+	assert.True(t, Valid("01arz3ndektsv4rrffq69g5fav"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("01ARZ3NDEKTSV4RRFFQ69G5FA"))
+	assert.False(t, Valid("01ARZ3NDEKTSV4RRFFQ69G5FAU"))
+	assert.False(t, Valid("81ARZ3NDEKTSV4RRFFQ69G5FAV"))
+
+	_, err := Time("not-a-ulid")
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
@@ -0,0 +1,171 @@
+// Package linkpreview builds link cards from the OpenGraph metadata of web pages.
+package linkpreview
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/netguard"
+)
+
+const (
+	// maxPageSize is how much of a page is read looking for metadata; it is in the head
+	maxPageSize = 512 << 10
+	// maxRedirects limits the redirects followed to reach a page
+	maxRedirects = 3
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+// ErrForbiddenAddress is returned for links to loopback, private and other non-public addresses
+var ErrForbiddenAddress = netguard.ErrForbiddenAddress
+
+// Preview is the card of a link
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string // Absolute URL of the og:image
+	SiteName    string
+}
+
+// Fetcher loads pages and reads their OpenGraph metadata.
+// Only public addresses are fetched, so users cannot make the server call internal services.
+type Fetcher struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewFetcher creates a fetcher giving up on a page after timeout
+func NewFetcher(timeout time.Duration) *Fetcher {
+	return newFetcher(netguard.NewClient(timeout, maxRedirects))
+}
+
+func newFetcher(client *http.Client) *Fetcher {
+	return &Fetcher{
+		client:    client,
+		userAgent: "BrigadkaBot/1.0 (+https://brigadka.app)",
+	}
+}
+
+// Fetch returns the preview of the page at rawURL, or nil when the page is not HTML
+// or has neither a title nor a description
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil || (pageURL.Scheme != "http" && pageURL.Scheme != "https") || pageURL.Host == "" {
+		return nil, fmt.Errorf("unsupported link %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("page responded with status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" {
+		return nil, nil
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// The final URL after redirects is the one the relative image URL is based on
+	preview := parse(string(page), resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" {
+		return nil, nil
+	}
+	preview.URL = rawURL
+	return preview, nil
+}
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*("[^"]*"|'[^']*')`)
+	titlePattern     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// parse reads the OpenGraph tags of a page, falling back to the title
+// and the description meta tag
+func parse(page string, pageURL *url.URL) *Preview {
+	meta := map[string]string{}
+	for _, tag := range metaTagPattern.FindAllString(page, -1) {
+		var key, content string
+		for _, attr := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			value := html.UnescapeString(strings.TrimSpace(attr[2][1 : len(attr[2])-1]))
+			switch strings.ToLower(attr[1]) {
+			case "property", "name":
+				key = strings.ToLower(value)
+			case "content":
+				content = value
+			}
+		}
+		// The first occurrence of a tag wins
+		if _, seen := meta[key]; key != "" && content != "" && !seen {
+			meta[key] = content
+		}
+	}
+
+	preview := &Preview{
+		Title:       firstOf(meta["og:title"], meta["twitter:title"]),
+		Description: firstOf(meta["og:description"], meta["twitter:description"], meta["description"]),
+		SiteName:    meta["og:site_name"],
+	}
+	if preview.Title == "" {
+		if match := titlePattern.FindStringSubmatch(page); match != nil {
+			preview.Title = html.UnescapeString(strings.TrimSpace(match[1]))
+		}
+	}
+	preview.Title = truncate(collapseSpaces(preview.Title), maxTitleLength)
+	preview.Description = truncate(collapseSpaces(preview.Description), maxDescriptionLength)
+
+	if image := firstOf(meta["og:image"], meta["og:image:url"], meta["twitter:image"]); image != "" {
+		if imageURL, err := pageURL.Parse(image); err == nil && (imageURL.Scheme == "http" || imageURL.Scheme == "https") {
+			preview.ImageURL = imageURL.String()
+		}
+	}
+	return preview
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate cuts s to at most limit runes, marking the cut with an ellipsis
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
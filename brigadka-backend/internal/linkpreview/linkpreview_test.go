@@ -0,0 +1,96 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const page = `<!DOCTYPE html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Импро-джем &amp; мастер-класс">
+<meta property="og:description"
+      content="Приходите   играть
+      в субботу">
+<meta content="/img/poster.jpg" property="og:image" />
+<meta property="og:site_name" content='Brigadka'>
+<meta property="og:title" content="Second title">
+</head><body></body></html>`
+
+func TestFetchReadsOpenGraphTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/event":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(page))
+		case "/old":
+			http.Redirect(w, r, "/event", http.StatusMovedPermanently)
+		case "/plain":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><title> Just a title </title><meta name="description" content="About"></head></html>`))
+		case "/empty":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body>nothing</body></html>`))
+		case "/file.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which NewFetcher refuses
+	fetcher := newFetcher(&http.Client{Timeout: time.Second})
+	ctx := context.Background()
+
+	preview, err := fetcher.Fetch(ctx, server.URL+"/old")
+	assert.NoError(t, err)
+	assert.Equal(t, &Preview{
+		URL:         server.URL + "/old",
+		Title:       "Импро-джем & мастер-класс",
+		Description: "Приходите играть в субботу",
+		ImageURL:    server.URL + "/img/poster.jpg",
+		SiteName:    "Brigadka",
+	}, preview)
+
+	preview, err = fetcher.Fetch(ctx, server.URL+"/plain")
+	assert.NoError(t, err)
+	assert.Equal(t, &Preview{URL: server.URL + "/plain", Title: "Just a title", Description: "About"}, preview)
+
+	for _, path := range []string{"/empty", "/file.pdf"} {
+		preview, err = fetcher.Fetch(ctx, server.URL+path)
+		assert.NoError(t, err, path)
+		assert.Nil(t, preview, path)
+	}
+
+	_, err = fetcher.Fetch(ctx, server.URL+"/missing")
+	assert.EqualError(t, err, "page responded with status 404")
+
+	_, err = fetcher.Fetch(ctx, "ftp://example.com/file")
+	assert.Error(t, err)
+}
+
+func TestFetchRefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address was fetched")
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(time.Second).Fetch(context.Background(), server.URL)
+	assert.True(t, errors.Is(err, ErrForbiddenAddress), "unexpected error: %v", err)
+}
+
+func TestParseTruncatesLongText(t *testing.T) {
+	long := strings.Repeat("a", maxTitleLength+10)
+	preview := parse(`<meta property="og:title" content="`+long+`">`, nil)
+	assert.Equal(t, maxTitleLength, len([]rune(preview.Title)))
+	assert.True(t, strings.HasSuffix(preview.Title, "…"))
+}
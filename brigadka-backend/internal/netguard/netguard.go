@@ -0,0 +1,77 @@
+// Package netguard keeps requests the server makes to user-supplied URLs away
+// from internal services: loopback, private and link-local addresses such as the
+// cloud metadata endpoint are refused.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for loopback, private and other non-public addresses
+var ErrForbiddenAddress = errors.New("address is not public")
+
+// NewClient returns an HTTP client that connects only to public addresses and
+// follows at most maxRedirects redirects; with 0 the redirect response is returned as is
+func NewClient(timeout time.Duration, maxRedirects int) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicAddressesOnly}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
+// CheckHost resolves the host and fails with ErrForbiddenAddress if any of its
+// addresses is not public. It is meant for validating URLs when they are saved;
+// the client from NewClient checks again on every connection, since DNS can change.
+func CheckHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !IsPublic(ip) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !IsPublic(addr.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// IsPublic reports whether the address is reachable from the internet
+func IsPublic(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback()
+}
+
+// publicAddressesOnly rejects connections to addresses that are not public.
+// It runs after name resolution, so hostnames resolving to internal addresses are rejected too.
+func publicAddressesOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublic(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
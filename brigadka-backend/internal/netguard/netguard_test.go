@@ -0,0 +1,43 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublic(t *testing.T) {
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1::1"} {
+		assert.True(t, IsPublic(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"0.0.0.0", "255.255.255.255", "224.0.0.1", "::1", "fc00::1", "fe80::1",
+	} {
+		assert.False(t, IsPublic(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, CheckHost(ctx, "93.184.216.34"))
+	assert.True(t, errors.Is(CheckHost(ctx, "169.254.169.254"), ErrForbiddenAddress))
+	assert.True(t, errors.Is(CheckHost(ctx, "::1"), ErrForbiddenAddress))
+	assert.True(t, errors.Is(CheckHost(ctx, "localhost"), ErrForbiddenAddress))
+}
+
+func TestClientRefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address was requested")
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second, 0).Get(server.URL)
+	assert.True(t, errors.Is(err, ErrForbiddenAddress), "unexpected error: %v", err)
+}
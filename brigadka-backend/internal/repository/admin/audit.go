@@ -0,0 +1,140 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Audited admin actions
+const (
+	ActionProfileUpdate   = "profile.update"
+	ActionProfileBan      = "profile.ban"
+	ActionProfileUnban    = "profile.unban"
+	ActionProfileVerify   = "profile.verify"
+	ActionProfileUnverify = "profile.unverify"
+
+	ActionProfileShadowban   = "profile.shadowban"
+	ActionProfileUnshadowban = "profile.unshadowban"
+	ActionMessageRemove      = "message.remove"
+	ActionMediaRemove        = "media.remove"
+
+	ActionImpersonationStart = "impersonation.start"
+	// ActionImpersonationRequest records every request made with an impersonation token
+	ActionImpersonationRequest = "impersonation.request"
+)
+
+// Audit target types. Profiles and users are identified by user ID.
+const (
+	TargetProfile = "profile"
+	TargetUser    = "user"
+	TargetMessage = "message"
+	TargetMedia   = "media"
+)
+
+// AuditEntry records an action an admin took
+type AuditEntry struct {
+	ID         int             `json:"id"`
+	AdminID    *int            `json:"admin_id"` // Cleared when the admin account is deleted
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows the audit log. Zero values do not filter.
+type AuditFilter struct {
+	AdminID    *int
+	Action     string
+	TargetType string
+	TargetID   string
+}
+
+const insertAuditEntryQuery = `
+        INSERT INTO admin_audit_log (admin_id, action, target_type, target_id, details)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insertAuditEntry writes the entry with db or a transaction and fills its ID and time
+func insertAuditEntry(ctx context.Context, q queryRower, entry *AuditEntry) error {
+	var details []byte
+	if len(entry.Details) > 0 {
+		details = entry.Details
+	}
+	return q.QueryRowContext(ctx, insertAuditEntryQuery,
+		entry.AdminID, entry.Action, entry.TargetType, entry.TargetID, details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// AddAuditEntry appends an entry to the audit log
+func (r *postgresRepository) AddAuditEntry(ctx context.Context, entry *AuditEntry) error {
+	return insertAuditEntry(ctx, r.db, entry)
+}
+
+// GetAuditLog returns a page of audit entries matching the filter
+func (r *postgresRepository) GetAuditLog(ctx context.Context, filter AuditFilter, limit, offset int) ([]AuditEntry, int, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	if filter.AdminID != nil {
+		addCondition("admin_id", *filter.AdminID)
+	}
+	if filter.Action != "" {
+		addCondition("action", filter.Action)
+	}
+	if filter.TargetType != "" {
+		addCondition("target_type", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		addCondition("target_id", filter.TargetID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM admin_audit_log"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT id, admin_id, action, target_type, target_id, details, created_at
+        FROM admin_audit_log` + where + fmt.Sprintf(`
+        ORDER BY id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []AuditEntry{}
+	for rows.Next() {
+		var entry AuditEntry
+		var details []byte
+		if err := rows.Scan(&entry.ID, &entry.AdminID, &entry.Action, &entry.TargetType, &entry.TargetID, &details, &entry.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		if len(details) > 0 {
+			entry.Details = details
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
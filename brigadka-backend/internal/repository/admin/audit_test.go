@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	adminID := 1
+	filter := AuditFilter{AdminID: &adminID, TargetType: TargetProfile, TargetID: "7"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM admin_audit_log WHERE admin_id = $1 AND target_type = $2 AND target_id = $3`)).
+		WithArgs(1, TargetProfile, "7").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY id DESC LIMIT $4 OFFSET $5`)).
+		WithArgs(1, TargetProfile, "7", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "admin_id", "action", "target_type", "target_id", "details", "created_at"}).
+			AddRow(4, 1, ActionProfileVerify, TargetProfile, "7", nil, now).
+			AddRow(3, nil, ActionProfileBan, TargetProfile, "7", []byte(`{"reason":"spam"}`), now))
+
+	entries, total, err := repo.GetAuditLog(context.Background(), filter, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, 1, *entries[0].AdminID)
+		assert.Nil(t, entries[0].Details)
+		assert.Nil(t, entries[1].AdminID)
+		assert.JSONEq(t, `{"reason":"spam"}`, string(entries[1].Details))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
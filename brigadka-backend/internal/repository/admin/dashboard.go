@@ -0,0 +1,213 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var ErrContentNotFound = errors.New("content not found")
+
+// DashboardCounts are the totals shown on the moderation dashboard
+type DashboardCounts struct {
+	OpenReports          int
+	NewUsersDay          int
+	NewUsersWeek         int
+	PendingMedia         int
+	RejectedMediaWeek    int
+	MessagesDay          int
+	MessagesWeek         int
+	ShadowbannedProfiles int
+}
+
+// ReportedTarget is content with open reports, most reported first
+type ReportedTarget struct {
+	TargetType     string    `json:"target_type"`
+	TargetID       string    `json:"target_id"`
+	OpenReports    int       `json:"open_reports"`
+	LastReportedAt time.Time `json:"last_reported_at"`
+}
+
+// FlaggedMedia is media waiting for review or rejected by the classifier without a moderator
+type FlaggedMedia struct {
+	ID                 int       `json:"id"`
+	OwnerID            int       `json:"owner_id"`
+	Role               string    `json:"role"`
+	URL                string    `json:"url"`
+	ThumbnailURL       string    `json:"thumbnail_url"`
+	ModerationStatus   string    `json:"moderation_status"`
+	NSFWScore          *float64  `json:"nsfw_score,omitempty"`
+	ModerationProvider *string   `json:"moderation_provider,omitempty"`
+	UploadedAt         time.Time `json:"uploaded_at"`
+}
+
+// DailyCount is the number of events on a UTC day
+type DailyCount struct {
+	Day   time.Time `json:"day"`
+	Count int       `json:"count"`
+}
+
+// GetDashboardCounts counts reports, sign-ups, flagged media, messages and
+// shadowbanned profiles; the day and week end at now
+func (r *postgresRepository) GetDashboardCounts(ctx context.Context, now time.Time) (*DashboardCounts, error) {
+	var counts DashboardCounts
+	err := r.db.QueryRowContext(ctx, `
+        SELECT
+            (SELECT COUNT(*) FROM reports WHERE status = 'open'),
+            (SELECT COUNT(*) FROM users WHERE created_at >= $1),
+            (SELECT COUNT(*) FROM users WHERE created_at >= $2),
+            (SELECT COUNT(*) FROM media WHERE moderation_status = 'pending'),
+            (SELECT COUNT(*) FROM media WHERE moderation_status = 'rejected' AND uploaded_at >= $2),
+            (SELECT COUNT(*) FROM messages WHERE sent_at >= $1),
+            (SELECT COUNT(*) FROM messages WHERE sent_at >= $2),
+            (SELECT COUNT(*) FROM profiles WHERE shadowbanned_at IS NOT NULL)`,
+		now.Add(-24*time.Hour), now.Add(-7*24*time.Hour),
+	).Scan(&counts.OpenReports, &counts.NewUsersDay, &counts.NewUsersWeek, &counts.PendingMedia,
+		&counts.RejectedMediaWeek, &counts.MessagesDay, &counts.MessagesWeek, &counts.ShadowbannedProfiles)
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// GetReportedTargets returns up to limit reported targets with their open report counts
+func (r *postgresRepository) GetReportedTargets(ctx context.Context, limit int) ([]ReportedTarget, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT target_type, target_id, COUNT(*), MAX(created_at)
+        FROM reports
+        WHERE status = 'open'
+        GROUP BY target_type, target_id
+        ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+        LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := []ReportedTarget{}
+	for rows.Next() {
+		var target ReportedTarget
+		if err := rows.Scan(&target.TargetType, &target.TargetID, &target.OpenReports, database.Time(&target.LastReportedAt)); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, rows.Err()
+}
+
+// GetFlaggedMedia returns up to limit flagged media, newest first
+func (r *postgresRepository) GetFlaggedMedia(ctx context.Context, limit int) ([]FlaggedMedia, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, owner_id, type, url, thumbnail_url, moderation_status, nsfw_score, moderation_provider, uploaded_at
+        FROM media
+        WHERE moderation_status = 'pending' OR (moderation_status = 'rejected' AND moderated_by IS NULL)
+        ORDER BY uploaded_at DESC, id DESC
+        LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []FlaggedMedia{}
+	for rows.Next() {
+		var item FlaggedMedia
+		var score sql.NullFloat64
+		var provider sql.NullString
+		err := rows.Scan(&item.ID, &item.OwnerID, &item.Role, &item.URL, &item.ThumbnailURL,
+			&item.ModerationStatus, &score, &provider, &item.UploadedAt)
+		if err != nil {
+			return nil, err
+		}
+		if score.Valid {
+			item.NSFWScore = &score.Float64
+		}
+		if provider.Valid {
+			item.ModerationProvider = &provider.String
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// GetDailyMessageCounts counts the messages sent on each of the days starting
+// at the UTC midnight of from, oldest first
+func (r *postgresRepository) GetDailyMessageCounts(ctx context.Context, from time.Time, days int) ([]DailyCount, error) {
+	start := from.UTC().Truncate(24 * time.Hour)
+
+	buckets := make([]string, days)
+	args := make([]interface{}, 0, days+1)
+	for i := 0; i < days; i++ {
+		args = append(args, start.AddDate(0, 0, i))
+		buckets[i] = fmt.Sprintf("COALESCE(SUM(CASE WHEN sent_at >= $%d AND sent_at < $%d THEN 1 ELSE 0 END), 0)", i+1, i+2)
+	}
+	args = append(args, start.AddDate(0, 0, days))
+
+	values := make([]int, days)
+	dest := make([]interface{}, days)
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	err := r.db.QueryRowContext(ctx, fmt.Sprintf(`
+        SELECT %s
+        FROM messages
+        WHERE sent_at >= $1 AND sent_at < $%d`, strings.Join(buckets, ", "), days+1),
+		args...,
+	).Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]DailyCount, days)
+	for i := range counts {
+		counts[i] = DailyCount{Day: start.AddDate(0, 0, i), Count: values[i]}
+	}
+	return counts, nil
+}
+
+// RemoveContent hides a message from chat history or rejects media, closes the open
+// reports on it as resolved and records the audit entry, all in one transaction
+func (r *postgresRepository) RemoveContent(ctx context.Context, targetType, targetID string, adminID int, now time.Time, entry *AuditEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var result sql.Result
+	switch targetType {
+	case TargetMessage:
+		result, err = tx.ExecContext(ctx, `UPDATE messages SET hidden_at = $1 WHERE id = $2`, now, targetID)
+	case TargetMedia:
+		result, err = tx.ExecContext(ctx, `
+            UPDATE media SET moderation_status = 'rejected', moderated_by = $1, moderated_at = $2
+            WHERE id = $3`, adminID, now, targetID)
+	default:
+		return fmt.Errorf("cannot remove content of type %q", targetType)
+	}
+	if err != nil {
+		return err
+	}
+	if err := database.RequireRow(result, ErrContentNotFound); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE reports SET status = 'resolved', resolved_by = $1, resolved_at = $2
+        WHERE target_type = $3 AND target_id = $4 AND status = 'open'`,
+		adminID, now, targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertAuditEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
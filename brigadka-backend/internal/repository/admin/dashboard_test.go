@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDashboardCounts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`(SELECT COUNT(*) FROM reports WHERE status = 'open')`)).
+		WithArgs(now.Add(-24*time.Hour), now.Add(-7*24*time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d", "e", "f", "g", "h"}).
+			AddRow(4, 2, 9, 3, 1, 120, 800, 1))
+
+	counts, err := repo.GetDashboardCounts(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, &DashboardCounts{
+		OpenReports:          4,
+		NewUsersDay:          2,
+		NewUsersWeek:         9,
+		PendingMedia:         3,
+		RejectedMediaWeek:    1,
+		MessagesDay:          120,
+		MessagesWeek:         800,
+		ShadowbannedProfiles: 1,
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReportedTargets(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY target_type, target_id`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"target_type", "target_id", "count", "max"}).
+			AddRow("profile", "7", 3, now).
+			AddRow("message", "b3f6", 1, now))
+
+	targets, err := repo.GetReportedTargets(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReportedTarget{
+		{TargetType: "profile", TargetID: "7", OpenReports: 3, LastReportedAt: now},
+		{TargetType: "message", TargetID: "b3f6", OpenReports: 1, LastReportedAt: now},
+	}, targets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFlaggedMedia(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE moderation_status = 'pending' OR (moderation_status = 'rejected' AND moderated_by IS NULL)`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "moderation_status", "nsfw_score", "moderation_provider", "uploaded_at"}).
+			AddRow(5, 7, "image", "https://cdn/5.jpg", "https://cdn/5_thumb.jpg", "pending", 0.7, "nsfw_http", now).
+			AddRow(6, 8, "video", "https://cdn/6.mp4", "", "rejected", nil, nil, now))
+
+	items, err := repo.GetFlaggedMedia(context.Background(), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, 0.7, *items[0].NSFWScore)
+		assert.Equal(t, "nsfw_http", *items[0].ModerationProvider)
+		assert.Nil(t, items[1].NSFWScore)
+		assert.Equal(t, "rejected", items[1].ModerationStatus)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDailyMessageCounts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	start := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT COALESCE(SUM(CASE WHEN sent_at >= $1 AND sent_at < $2 THEN 1 ELSE 0 END), 0), COALESCE(SUM(CASE WHEN sent_at >= $2 AND sent_at < $3 THEN 1 ELSE 0 END), 0)
+        FROM messages
+        WHERE sent_at >= $1 AND sent_at < $3`)).
+		WithArgs(start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2)).
+		WillReturnRows(sqlmock.NewRows([]string{"d1", "d2"}).AddRow(12, 30))
+
+	counts, err := repo.GetDailyMessageCounts(context.Background(), start.Add(15*time.Hour), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []DailyCount{
+		{Day: start, Count: 12},
+		{Day: start.AddDate(0, 0, 1), Count: 30},
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		targetType  string
+		targetID    string
+		action      string
+		updateQuery string
+		updateArgs  func(now time.Time) []driver.Value
+		affected    int64
+		wantErr     error
+	}{
+		{
+			name:        "message",
+			targetType:  TargetMessage,
+			action:      ActionMessageRemove,
+			targetID:    "0b9d2a6e-4f0c-4f7c-9b1e-2d8f1c0b7a11",
+			updateQuery: `UPDATE messages SET hidden_at = $1 WHERE id = $2`,
+			updateArgs: func(now time.Time) []driver.Value {
+				return []driver.Value{now, "0b9d2a6e-4f0c-4f7c-9b1e-2d8f1c0b7a11"}
+			},
+			affected: 1,
+		},
+		{
+			name:        "media",
+			targetType:  TargetMedia,
+			action:      ActionMediaRemove,
+			targetID:    "5",
+			updateQuery: `UPDATE media SET moderation_status = 'rejected', moderated_by = $1, moderated_at = $2`,
+			updateArgs:  func(now time.Time) []driver.Value { return []driver.Value{1, now, "5"} },
+			affected:    1,
+		},
+		{
+			name:        "not found",
+			targetType:  TargetMedia,
+			action:      ActionMediaRemove,
+			targetID:    "6",
+			updateQuery: `UPDATE media SET moderation_status = 'rejected', moderated_by = $1, moderated_at = $2`,
+			updateArgs:  func(now time.Time) []driver.Value { return []driver.Value{1, now, "6"} },
+			affected:    0,
+			wantErr:     ErrContentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			now := time.Now()
+			adminID := 1
+			entry := &AuditEntry{AdminID: &adminID, Action: tt.action, TargetType: tt.targetType, TargetID: tt.targetID}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.updateQuery)).
+				WithArgs(tt.updateArgs(now)...).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if tt.wantErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = 'resolved', resolved_by = $1, resolved_at = $2`)).
+					WithArgs(1, now, tt.targetType, tt.targetID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO admin_audit_log`)).
+					WithArgs(&adminID, tt.action, tt.targetType, tt.targetID, []byte(nil)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, now))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.RemoveContent(context.Background(), tt.targetType, tt.targetID, adminID, now, entry)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ProfileFilter narrows the admin profile list. Zero values do not filter.
+type ProfileFilter struct {
+	// Query matches the full name or email as a substring, or the user ID exactly
+	Query    string
+	CityID   *int
+	Verified *bool
+	Banned   *bool
+}
+
+// ProfileSummary is a row of the admin profile list
+type ProfileSummary struct {
+	UserID    int       `json:"user_id"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"full_name"`
+	CityID    *int      `json:"city_id,omitempty"`
+	CityName  *string   `json:"city_name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	// BannedAt is set while the profile is hidden from the community,
+	// either banned by an admin or hidden after a report
+	BannedAt *time.Time `json:"banned_at,omitempty"`
+	// ShadowbannedAt is set while the profile is left out of discovery without the user knowing
+	ShadowbannedAt *time.Time `json:"shadowbanned_at,omitempty"`
+}
+
+// SearchProfiles returns a page of profiles matching the filter
+func (r *postgresRepository) SearchProfiles(ctx context.Context, filter ProfileFilter, limit, offset int) ([]ProfileSummary, int, error) {
+	from := `
+        FROM profiles p
+        JOIN users u ON u.id = p.user_id
+        LEFT JOIN cities c ON c.city_id = p.city_id`
+
+	conditions := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		match := fmt.Sprintf("(p.full_name %[1]s $%[2]d OR u.email %[1]s $%[2]d", r.dialect.ILike(), argIndex)
+		args = append(args, "%"+query+"%")
+		argIndex++
+		if id, err := parseUserID(query); err == nil {
+			match += fmt.Sprintf(" OR p.user_id = $%d", argIndex)
+			args = append(args, id)
+			argIndex++
+		}
+		conditions = append(conditions, match+")")
+	}
+
+	if filter.CityID != nil {
+		conditions = append(conditions, fmt.Sprintf("p.city_id = $%d", argIndex))
+		args = append(args, *filter.CityID)
+		argIndex++
+	}
+
+	if filter.Verified != nil {
+		if *filter.Verified {
+			conditions = append(conditions, "p.verified_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "p.verified_at IS NULL")
+		}
+	}
+
+	if filter.Banned != nil {
+		if *filter.Banned {
+			conditions = append(conditions, "p.hidden_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "p.hidden_at IS NULL")
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT p.user_id, u.email, p.full_name, p.city_id, c.name, p.created_at, p.verified_at, p.hidden_at, p.shadowbanned_at` +
+		from + where + fmt.Sprintf(`
+        ORDER BY p.created_at DESC, p.user_id DESC
+        LIMIT $%d OFFSET $%d`, argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	profiles := []ProfileSummary{}
+	for rows.Next() {
+		var profile ProfileSummary
+		var cityID sql.NullInt64
+		var cityName sql.NullString
+		var verifiedAt, bannedAt, shadowbannedAt sql.NullTime
+		err := rows.Scan(&profile.UserID, &profile.Email, &profile.FullName, &cityID, &cityName,
+			&profile.CreatedAt, &verifiedAt, &bannedAt, &shadowbannedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		if cityID.Valid {
+			id := int(cityID.Int64)
+			profile.CityID = &id
+		}
+		if cityName.Valid {
+			profile.CityName = &cityName.String
+		}
+		if verifiedAt.Valid {
+			profile.VerifiedAt = &verifiedAt.Time
+		}
+		if bannedAt.Valid {
+			profile.BannedAt = &bannedAt.Time
+		}
+		if shadowbannedAt.Valid {
+			profile.ShadowbannedAt = &shadowbannedAt.Time
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, total, rows.Err()
+}
+
+// SetProfileBanned stores the ban in profiles.hidden_at, the column search and reports already use
+func (r *postgresRepository) SetProfileBanned(ctx context.Context, userID int, bannedAt *time.Time, entry *AuditEntry) error {
+	return r.updateProfileWithAudit(ctx, `UPDATE profiles SET hidden_at = $1 WHERE user_id = $2`, bannedAt, userID, entry)
+}
+
+// SetProfileShadowbanned sets or clears the shadowban time of the profile
+func (r *postgresRepository) SetProfileShadowbanned(ctx context.Context, userID int, shadowbannedAt *time.Time, entry *AuditEntry) error {
+	return r.updateProfileWithAudit(ctx, `UPDATE profiles SET shadowbanned_at = $1 WHERE user_id = $2`, shadowbannedAt, userID, entry)
+}
+
+// SetProfileVerified sets or clears the verification time of the profile
+func (r *postgresRepository) SetProfileVerified(ctx context.Context, userID int, verifiedAt *time.Time, entry *AuditEntry) error {
+	return r.updateProfileWithAudit(ctx, `UPDATE profiles SET verified_at = $1 WHERE user_id = $2`, verifiedAt, userID, entry)
+}
+
+// updateProfileWithAudit runs the profile update and writes the audit entry in one transaction
+func (r *postgresRepository) updateProfileWithAudit(ctx context.Context, query string, at *time.Time, userID int, entry *AuditEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, at, userID)
+	if err != nil {
+		return err
+	}
+	if err := database.RequireRow(result, ErrProfileNotFound); err != nil {
+		return err
+	}
+
+	if err := insertAuditEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
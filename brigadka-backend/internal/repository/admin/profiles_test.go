@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchProfiles(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	verified := true
+	banned := false
+	filter := ProfileFilter{Query: "anna", Verified: &verified, Banned: &banned}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`) + `.*` +
+		regexp.QuoteMeta(`WHERE (p.full_name ILIKE $1 OR u.email ILIKE $1) AND p.verified_at IS NOT NULL AND p.hidden_at IS NULL`)).
+		WithArgs("%anna%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY p.created_at DESC, p.user_id DESC LIMIT $2 OFFSET $3`)).
+		WithArgs("%anna%", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "full_name", "city_id", "name", "created_at", "verified_at", "hidden_at", "shadowbanned_at"}).
+			AddRow(7, "anna@example.com", "Anna", nil, nil, now, now, nil, nil))
+
+	profiles, total, err := repo.SearchProfiles(context.Background(), filter, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, profiles, 1) {
+		assert.Equal(t, 7, profiles[0].UserID)
+		assert.Nil(t, profiles[0].CityID)
+		assert.Equal(t, now, *profiles[0].VerifiedAt)
+		assert.Nil(t, profiles[0].BannedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProfileBanned(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	adminID := 1
+	entry := &AuditEntry{AdminID: &adminID, Action: ActionProfileBan, TargetType: TargetProfile, TargetID: "7", Details: []byte(`{"reason":"spam"}`)}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE profiles SET hidden_at = $1 WHERE user_id = $2`)).
+		WithArgs(&now, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO admin_audit_log`)).
+		WithArgs(&adminID, ActionProfileBan, TargetProfile, "7", []byte(`{"reason":"spam"}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, now))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.SetProfileBanned(context.Background(), 7, &now, entry))
+	assert.Equal(t, 3, entry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProfileShadowbanned(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	adminID := 1
+	entry := &AuditEntry{AdminID: &adminID, Action: ActionProfileUnshadowban, TargetType: TargetProfile, TargetID: "7"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE profiles SET shadowbanned_at = $1 WHERE user_id = $2`)).
+		WithArgs(nil, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO admin_audit_log`)).
+		WithArgs(&adminID, ActionProfileUnshadowban, TargetProfile, "7", []byte(nil)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, now))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.SetProfileShadowbanned(context.Background(), 7, nil, entry))
+	assert.Equal(t, 4, entry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProfileVerifiedNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE profiles SET verified_at = $1 WHERE user_id = $2`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.SetProfileVerified(context.Background(), 7, nil, &AuditEntry{Action: ActionProfileUnverify})
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
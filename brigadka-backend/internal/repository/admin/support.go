@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrNoSupportAccess = errors.New("support access not granted")
+
+// SupportAccess is a user's consent to support viewing the app as them
+type SupportAccess struct {
+	UserID    int       `json:"user_id"`
+	GrantedAt time.Time `json:"granted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetSupportAccess returns the user's grant if it has not expired by now
+func (r *postgresRepository) GetSupportAccess(ctx context.Context, userID int, now time.Time) (*SupportAccess, error) {
+	var access SupportAccess
+	err := r.db.QueryRowContext(ctx, `
+        SELECT user_id, granted_at, expires_at
+        FROM support_access_grants
+        WHERE user_id = $1 AND expires_at > $2`,
+		userID, now,
+	).Scan(&access.UserID, &access.GrantedAt, &access.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoSupportAccess
+		}
+		return nil, err
+	}
+	return &access, nil
+}
+
+// GrantSupportAccess creates or renews the user's grant
+func (r *postgresRepository) GrantSupportAccess(ctx context.Context, access *SupportAccess) error {
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO support_access_grants (user_id, granted_at, expires_at)
+        VALUES ($1, $2, $3)
+        %s`, r.dialect.OnConflictUpdate("user_id", "granted_at = excluded.granted_at, expires_at = excluded.expires_at")),
+		access.UserID, access.GrantedAt, access.ExpiresAt)
+	return err
+}
+
+// RevokeSupportAccess removes the user's grant; revoking without a grant is a no-op
+func (r *postgresRepository) RevokeSupportAccess(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM support_access_grants WHERE user_id = $1`, userID)
+	return err
+}
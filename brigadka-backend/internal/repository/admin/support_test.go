@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSupportAccess(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`FROM support_access_grants WHERE user_id = $1 AND expires_at > $2`)
+
+	mock.ExpectQuery(query).
+		WithArgs(7, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "granted_at", "expires_at"}).AddRow(7, now, now.Add(time.Hour)))
+	access, err := repo.GetSupportAccess(context.Background(), 7, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), access.ExpiresAt)
+
+	mock.ExpectQuery(query).
+		WithArgs(8, now).
+		WillReturnError(sql.ErrNoRows)
+	_, err = repo.GetSupportAccess(context.Background(), 8, now)
+	assert.ErrorIs(t, err, ErrNoSupportAccess)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantSupportAccess(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	access := &SupportAccess{UserID: 7, GrantedAt: now, ExpiresAt: now.Add(72 * time.Hour)}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO support_access_grants (user_id, granted_at, expires_at)`)+`.*`+
+		regexp.QuoteMeta(`ON CONFLICT (user_id) DO UPDATE SET granted_at = excluded.granted_at, expires_at = excluded.expires_at`)).
+		WithArgs(7, now, access.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.GrantSupportAccess(context.Background(), access))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// User statuses in the directory
+const (
+	UserActive    = "active"
+	UserSuspended = "suspended"
+	UserHidden    = "hidden"
+)
+
+// UserFilter narrows the user directory. Zero values do not filter.
+type UserFilter struct {
+	// Query matches the email or full name as a substring, or the user ID exactly
+	Query  string
+	Status string
+	CityID *int
+}
+
+// UserSummary is a row of the user directory
+type UserSummary struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+
+	FullName *string `json:"full_name,omitempty"` // Absent until the user creates a profile
+	CityID   *int    `json:"city_id,omitempty"`
+	CityName *string `json:"city_name,omitempty"`
+
+	ProfileHidden  bool       `json:"profile_hidden"`
+	Suspended      bool       `json:"suspended"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	OpenReports    int        `json:"open_reports"`
+	TotalReports   int        `json:"total_reports"`
+
+	// LastActiveAt is the latest message sent or push token refresh
+	LastActiveAt *time.Time `json:"last_active_at,omitempty"`
+}
+
+// Repository defines the queries behind admin tools
+type Repository interface {
+	// SearchUsers returns a page of users matching the filter, newest first, and the total count
+	SearchUsers(ctx context.Context, filter UserFilter, now time.Time, limit, offset int) ([]UserSummary, int, error)
+
+	// SearchProfiles returns a page of profiles matching the filter, newest first, and the total count
+	SearchProfiles(ctx context.Context, filter ProfileFilter, limit, offset int) ([]ProfileSummary, int, error)
+	// SetProfileBanned bans the profile at bannedAt, or lifts the ban when it is nil, and records the audit entry
+	SetProfileBanned(ctx context.Context, userID int, bannedAt *time.Time, entry *AuditEntry) error
+	// SetProfileVerified verifies the profile at verifiedAt, or revokes it when it is nil, and records the audit entry
+	SetProfileVerified(ctx context.Context, userID int, verifiedAt *time.Time, entry *AuditEntry) error
+	// SetProfileShadowbanned shadowbans the profile at shadowbannedAt, or lifts it when it is nil, and records the audit entry
+	SetProfileShadowbanned(ctx context.Context, userID int, shadowbannedAt *time.Time, entry *AuditEntry) error
+
+	GetDashboardCounts(ctx context.Context, now time.Time) (*DashboardCounts, error)
+	GetReportedTargets(ctx context.Context, limit int) ([]ReportedTarget, error)
+	GetFlaggedMedia(ctx context.Context, limit int) ([]FlaggedMedia, error)
+	GetDailyMessageCounts(ctx context.Context, from time.Time, days int) ([]DailyCount, error)
+	// RemoveContent hides a message or rejects media, resolves its open reports and records the audit entry
+	RemoveContent(ctx context.Context, targetType, targetID string, adminID int, now time.Time, entry *AuditEntry) error
+
+	GetSupportAccess(ctx context.Context, userID int, now time.Time) (*SupportAccess, error)
+	GrantSupportAccess(ctx context.Context, access *SupportAccess) error
+	RevokeSupportAccess(ctx context.Context, userID int) error
+
+	AddAuditEntry(ctx context.Context, entry *AuditEntry) error
+	// GetAuditLog returns a page of audit entries matching the filter, newest first, and the total count
+	GetAuditLog(ctx context.Context, filter AuditFilter, limit, offset int) ([]AuditEntry, int, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new admin repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// SearchUsers returns a page of users matching the filter
+func (r *postgresRepository) SearchUsers(ctx context.Context, filter UserFilter, now time.Time, limit, offset int) ([]UserSummary, int, error) {
+	// The active suspension is joined so the status filter and the output use the same row
+	from := `
+        FROM users u
+        LEFT JOIN profiles p ON p.user_id = u.id
+        LEFT JOIN cities c ON c.city_id = p.city_id
+        LEFT JOIN user_suspensions s ON s.user_id = u.id AND s.lifted_at IS NULL AND (s.ends_at IS NULL OR s.ends_at > $1)`
+
+	conditions := []string{}
+	args := []interface{}{now}
+	argIndex := 2
+
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		match := fmt.Sprintf("(u.email %[1]s $%[2]d OR p.full_name %[1]s $%[2]d", r.dialect.ILike(), argIndex)
+		args = append(args, "%"+query+"%")
+		argIndex++
+		if id, err := parseUserID(query); err == nil {
+			match += fmt.Sprintf(" OR u.id = $%d", argIndex)
+			args = append(args, id)
+			argIndex++
+		}
+		conditions = append(conditions, match+")")
+	}
+
+	switch filter.Status {
+	case UserActive:
+		conditions = append(conditions, "s.id IS NULL AND p.hidden_at IS NULL")
+	case UserSuspended:
+		conditions = append(conditions, "s.id IS NOT NULL")
+	case UserHidden:
+		conditions = append(conditions, "p.hidden_at IS NOT NULL")
+	}
+
+	if filter.CityID != nil {
+		conditions = append(conditions, fmt.Sprintf("p.city_id = $%d", argIndex))
+		args = append(args, *filter.CityID)
+		argIndex++
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT u.id, u.email, u.role, u.created_at, p.full_name, p.city_id, c.name,
+               p.hidden_at IS NOT NULL, s.id IS NOT NULL, s.ends_at,
+               (SELECT COUNT(*) FROM reports rp
+                WHERE rp.target_type = 'profile' AND rp.target_id = CAST(u.id AS VARCHAR(64)) AND rp.status = 'open'),
+               (SELECT COUNT(*) FROM reports rp
+                WHERE rp.target_type = 'profile' AND rp.target_id = CAST(u.id AS VARCHAR(64))),
+               (SELECT MAX(activity.at) FROM (
+                    SELECT MAX(m.sent_at) AS at FROM messages m WHERE m.sender_id = u.id
+                    UNION ALL
+                    SELECT MAX(pt.last_seen_at) FROM push_tokens pt WHERE pt.user_id = u.id
+                ) activity)` + from + where + fmt.Sprintf(`
+        ORDER BY u.created_at DESC, u.id DESC
+        LIMIT $%d OFFSET $%d`, argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []UserSummary{}
+	for rows.Next() {
+		var user UserSummary
+		var fullName, cityName sql.NullString
+		var cityID sql.NullInt64
+		var suspendedUntil, lastActiveAt sql.NullTime
+		err := rows.Scan(&user.ID, &user.Email, &user.Role, &user.CreatedAt, &fullName, &cityID, &cityName,
+			&user.ProfileHidden, &user.Suspended, &suspendedUntil, &user.OpenReports, &user.TotalReports, database.NullTime(&lastActiveAt))
+		if err != nil {
+			return nil, 0, err
+		}
+		if fullName.Valid {
+			user.FullName = &fullName.String
+		}
+		if cityID.Valid {
+			id := int(cityID.Int64)
+			user.CityID = &id
+		}
+		if cityName.Valid {
+			user.CityName = &cityName.String
+		}
+		if suspendedUntil.Valid {
+			user.SuspendedUntil = &suspendedUntil.Time
+		}
+		if lastActiveAt.Valid {
+			user.LastActiveAt = &lastActiveAt.Time
+		}
+		users = append(users, user)
+	}
+	return users, total, rows.Err()
+}
+
+// parseUserID accepts queries like "42" or "#42" as user IDs
+func parseUserID(query string) (int, error) {
+	return strconv.Atoi(strings.TrimPrefix(query, "#"))
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var userSummaryColumns = []string{"id", "email", "role", "created_at", "full_name", "city_id", "name",
+	"hidden", "suspended", "ends_at", "open_reports", "total_reports", "last_active_at"}
+
+func TestSearchUsers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	cityID := 1
+	filter := UserFilter{Query: "42", Status: UserSuspended, CityID: &cityID}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`)+`.*`+
+		regexp.QuoteMeta(`WHERE (u.email ILIKE $2 OR p.full_name ILIKE $2 OR u.id = $3) AND s.id IS NOT NULL AND p.city_id = $4`)).
+		WithArgs(now, "%42%", 42, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	endsAt := now.Add(time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY u.created_at DESC, u.id DESC LIMIT $5 OFFSET $6`)).
+		WithArgs(now, "%42%", 42, 1, 20, 0).
+		WillReturnRows(sqlmock.NewRows(userSummaryColumns).
+			AddRow(42, "a@example.com", "user", now, "Anna", 1, "Москва", false, true, endsAt, 2, 3, nil))
+
+	users, total, err := repo.SearchUsers(context.Background(), filter, now, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "Anna", *users[0].FullName)
+		assert.Equal(t, "Москва", *users[0].CityName)
+		assert.True(t, users[0].Suspended)
+		assert.Equal(t, endsAt, *users[0].SuspendedUntil)
+		assert.Equal(t, 2, users[0].OpenReports)
+		assert.Nil(t, users[0].LastActiveAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchUsersWithoutFilters(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`LIMIT $2 OFFSET $3`)).
+		WithArgs(now, 20, 40).
+		WillReturnRows(sqlmock.NewRows(userSummaryColumns))
+
+	users, total, err := repo.SearchUsers(context.Background(), UserFilter{}, now, 20, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,174 @@
+package announcement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var (
+	ErrAnnouncementNotFound = errors.New("announcement not found")
+)
+
+// Announcement is a post of the administration to all users
+type Announcement struct {
+	ID        string    `json:"id"`
+	AuthorID  *int      `json:"author_id"` // Nil once the author's account is deleted
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	Read      bool      `json:"read"` // Whether the requesting user has read it
+}
+
+// Repository defines methods for announcements and their read positions
+type Repository interface {
+	CreateAnnouncement(ctx context.Context, announcement *Announcement) error
+	// GetAnnouncements returns up to limit announcements older than beforeID, newest first;
+	// an empty beforeID starts from the newest
+	GetAnnouncements(ctx context.Context, beforeID string, limit int) ([]Announcement, error)
+
+	// GetLastReadID returns the newest announcement the user has read, or "" when none
+	GetLastReadID(ctx context.Context, userID int) (string, error)
+	// MarkRead moves the user's read position forward to the announcement and
+	// reports whether it moved
+	MarkRead(ctx context.Context, userID int, announcementID string) (bool, error)
+	// CountUnread returns the number of announcements newer than the user's read position
+	CountUnread(ctx context.Context, userID int) (int, error)
+
+	// GetPushRecipients returns up to limit users with push tokens and IDs above afterUserID, by ID
+	GetPushRecipients(ctx context.Context, afterUserID int, limit int) ([]int, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new announcement repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// CreateAnnouncement stores an announcement; CreatedAt is filled in
+func (r *postgresRepository) CreateAnnouncement(ctx context.Context, announcement *Announcement) error {
+	return r.db.QueryRowContext(ctx, `
+        INSERT INTO announcements (id, author_id, title, body)
+        VALUES ($1, $2, $3, $4)
+        RETURNING created_at`,
+		announcement.ID, announcement.AuthorID, announcement.Title, announcement.Body,
+	).Scan(&announcement.CreatedAt)
+}
+
+// GetAnnouncements returns a page of announcements, newest first
+func (r *postgresRepository) GetAnnouncements(ctx context.Context, beforeID string, limit int) ([]Announcement, error) {
+	args := []interface{}{limit}
+	filter := ""
+	if beforeID != "" {
+		filter = "WHERE id < $2"
+		args = append(args, beforeID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, author_id, title, body, created_at
+        FROM announcements
+        `+filter+`
+        ORDER BY id DESC
+        LIMIT $1`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	announcements := []Announcement{}
+	for rows.Next() {
+		var announcement Announcement
+		if err := rows.Scan(&announcement.ID, &announcement.AuthorID, &announcement.Title,
+			&announcement.Body, &announcement.CreatedAt); err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, announcement)
+	}
+	return announcements, rows.Err()
+}
+
+// GetLastReadID returns the user's read position
+func (r *postgresRepository) GetLastReadID(ctx context.Context, userID int) (string, error) {
+	var lastReadID string
+	err := r.db.QueryRowContext(ctx, `SELECT last_read_id FROM announcement_reads WHERE user_id = $1`, userID).Scan(&lastReadID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return lastReadID, err
+}
+
+// MarkRead moves the user's read position forward. Positions never move back, so a
+// late request from another device does not mark announcements unread again.
+func (r *postgresRepository) MarkRead(ctx context.Context, userID int, announcementID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM announcements WHERE id = $1)`, announcementID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, ErrAnnouncementNotFound
+	}
+
+	now := r.dialect.Now()
+	result, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO announcement_reads (user_id, last_read_id, read_at)
+        VALUES ($1, $2, %s)
+        %s
+        WHERE announcement_reads.last_read_id < excluded.last_read_id`,
+		now, r.dialect.OnConflictUpdate("user_id", "last_read_id = excluded.last_read_id, read_at = "+now)),
+		userID, announcementID)
+	if err != nil {
+		return false, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return moved > 0, nil
+}
+
+// CountUnread counts the announcements after the user's read position
+func (r *postgresRepository) CountUnread(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM announcements
+        WHERE id > COALESCE((SELECT last_read_id FROM announcement_reads WHERE user_id = $1), '')`,
+		userID).Scan(&count)
+	return count, err
+}
+
+// GetPushRecipients pages through the users who registered a device for push notifications
+func (r *postgresRepository) GetPushRecipients(ctx context.Context, afterUserID int, limit int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT DISTINCT user_id FROM push_tokens
+        WHERE user_id > $1
+        ORDER BY user_id
+        LIMIT $2`,
+		afterUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,128 @@
+package announcement
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestCreateAnnouncement(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	authorID := 1
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO announcements (id, author_id, title, body)`)).
+		WithArgs("01HZX", &authorID, "Festival", "Applications are open").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	announcement := &Announcement{ID: "01HZX", AuthorID: &authorID, Title: "Festival", Body: "Applications are open"}
+	err := repo.CreateAnnouncement(context.Background(), announcement)
+
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, announcement.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnnouncements(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	columns := []string{"id", "author_id", "title", "body", "created_at"}
+	mock.ExpectQuery(`SELECT id, author_id, title, body, created_at FROM announcements ORDER BY id DESC LIMIT \$1`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("01B", 1, "Second", "Text", createdAt).
+			AddRow("01A", nil, "First", "Text", createdAt))
+	mock.ExpectQuery(`SELECT id, author_id, title, body, created_at FROM announcements WHERE id < \$2 ORDER BY id DESC LIMIT \$1`).
+		WithArgs(2, "01A").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	announcements, err := repo.GetAnnouncements(context.Background(), "", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, announcements, 2) {
+		assert.Equal(t, "01B", announcements[0].ID)
+		assert.Equal(t, 1, *announcements[0].AuthorID)
+		assert.Nil(t, announcements[1].AuthorID)
+	}
+
+	announcements, err = repo.GetAnnouncements(context.Background(), "01A", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, announcements)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkRead(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM announcements WHERE id = $1)`)).
+		WithArgs("01B").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO announcement_reads (user_id, last_read_id, read_at) VALUES ($1, $2, NOW()) ON CONFLICT (user_id) DO UPDATE SET last_read_id = excluded.last_read_id, read_at = NOW() WHERE announcement_reads.last_read_id < excluded.last_read_id`)).
+		WithArgs(3, "01B").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	moved, err := repo.MarkRead(context.Background(), 3, "01B")
+
+	assert.NoError(t, err)
+	assert.True(t, moved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkReadUnknownAnnouncement(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM announcements WHERE id = $1)`)).
+		WithArgs("01Z").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, err := repo.MarkRead(context.Background(), 3, "01Z")
+
+	assert.Equal(t, ErrAnnouncementNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLastReadIDNothingRead(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT last_read_id FROM announcement_reads WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnError(sql.ErrNoRows)
+
+	lastReadID, err := repo.GetLastReadID(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", lastReadID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPushRecipients(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT user_id FROM push_tokens`)).
+		WithArgs(10, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(11).AddRow(15))
+
+	userIDs, err := repo.GetPushRecipients(context.Background(), 10, 500)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{11, 15}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
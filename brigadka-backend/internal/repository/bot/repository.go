@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var (
+	ErrBotNotFound = errors.New("bot not found")
+)
+
+// BotModel represents a bot account. The bot writes to chats as its own user.
+type BotModel struct {
+	ID            int
+	OwnerID       int
+	Name          string
+	APIKeyHash    string
+	WebhookURL    *string
+	WebhookSecret string
+	CreatedAt     time.Time
+}
+
+// Repository defines methods for bot accounts
+type Repository interface {
+	CreateBot(ctx context.Context, bot *BotModel) error
+	GetBot(ctx context.Context, botID int) (*BotModel, error)
+	GetBotByAPIKeyHash(ctx context.Context, apiKeyHash string) (*BotModel, error)
+	GetBotsByOwner(ctx context.Context, ownerID int) ([]BotModel, error)
+	UpdateWebhook(ctx context.Context, botID int, webhookURL *string) error
+	DeleteBot(ctx context.Context, botID int) error
+
+	// GetChatWebhooks returns bots with a webhook that participate in a group chat
+	GetChatWebhooks(ctx context.Context, chatID string) ([]BotModel, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new bot repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+const botColumns = `b.user_id, b.owner_id, b.name, b.api_key_hash, b.webhook_url, b.webhook_secret, b.created_at`
+
+// CreateBot creates the bot user and its account; ID and CreatedAt are filled in
+func (r *postgresRepository) CreateBot(ctx context.Context, bot *BotModel) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Bot users cannot log in: the password hash matches no password
+	email := fmt.Sprintf("bot-%s@bots.brigadka.app", uuid.New().String())
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO users (email, password_hash, role)
+        VALUES ($1, '!', 'bot')
+        RETURNING id`,
+		email,
+	).Scan(&bot.ID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO bot_accounts (user_id, owner_id, name, api_key_hash, webhook_url, webhook_secret)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at`,
+		bot.ID, bot.OwnerID, bot.Name, bot.APIKeyHash, bot.WebhookURL, bot.WebhookSecret,
+	).Scan(&bot.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetBot returns a bot by its user ID
+func (r *postgresRepository) GetBot(ctx context.Context, botID int) (*BotModel, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+botColumns+` FROM bot_accounts b WHERE b.user_id = $1`, botID)
+	return scanBot(row)
+}
+
+// GetBotByAPIKeyHash returns the bot owning an API key
+func (r *postgresRepository) GetBotByAPIKeyHash(ctx context.Context, apiKeyHash string) (*BotModel, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+botColumns+` FROM bot_accounts b WHERE b.api_key_hash = $1`, apiKeyHash)
+	return scanBot(row)
+}
+
+// GetBotsByOwner returns bots created by a user, oldest first
+func (r *postgresRepository) GetBotsByOwner(ctx context.Context, ownerID int) ([]BotModel, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+botColumns+` FROM bot_accounts b WHERE b.owner_id = $1 ORDER BY b.user_id`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanBots(rows)
+}
+
+// UpdateWebhook sets or clears (nil) the webhook URL of a bot
+func (r *postgresRepository) UpdateWebhook(ctx context.Context, botID int, webhookURL *string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE bot_accounts SET webhook_url = $2
+        WHERE user_id = $1`,
+		botID, webhookURL)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrBotNotFound)
+}
+
+// DeleteBot removes the bot user together with its account and chat memberships
+func (r *postgresRepository) DeleteBot(ctx context.Context, botID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        DELETE FROM users
+        WHERE id = $1 AND role = 'bot'`,
+		botID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrBotNotFound)
+}
+
+// GetChatWebhooks returns bots with a webhook that participate in a group chat
+func (r *postgresRepository) GetChatWebhooks(ctx context.Context, chatID string) ([]BotModel, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+botColumns+`
+        FROM bot_accounts b
+        JOIN chat_participants cp ON cp.user_id = b.user_id
+        JOIN chats c ON c.id = cp.chat_id
+        WHERE cp.chat_id = $1 AND c.is_group AND b.webhook_url IS NOT NULL`,
+		chatID)
+	if err != nil {
+		return nil, err
+	}
+	return scanBots(rows)
+}
+
+func scanBot(row *sql.Row) (*BotModel, error) {
+	var bot BotModel
+	err := row.Scan(&bot.ID, &bot.OwnerID, &bot.Name, &bot.APIKeyHash, &bot.WebhookURL, &bot.WebhookSecret, &bot.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &bot, nil
+}
+
+func scanBots(rows *sql.Rows) ([]BotModel, error) {
+	defer rows.Close()
+
+	bots := []BotModel{}
+	for rows.Next() {
+		var bot BotModel
+		if err := rows.Scan(&bot.ID, &bot.OwnerID, &bot.Name, &bot.APIKeyHash, &bot.WebhookURL, &bot.WebhookSecret, &bot.CreatedAt); err != nil {
+			return nil, err
+		}
+		bots = append(bots, bot)
+	}
+	return bots, rows.Err()
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var botRowColumns = []string{"user_id", "owner_id", "name", "api_key_hash", "webhook_url", "webhook_secret", "created_at"}
+
+func TestCreateBot(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO users (email, password_hash, role)`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bot_accounts`)).
+		WithArgs(42, 1, "Reminders", "hash", nil, "secret").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	mock.ExpectCommit()
+
+	bot := &BotModel{OwnerID: 1, Name: "Reminders", APIKeyHash: "hash", WebhookSecret: "secret"}
+	err := repo.CreateBot(context.Background(), bot)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, bot.ID)
+	assert.Equal(t, createdAt, bot.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBotByAPIKeyHashNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE b.api_key_hash = $1`)).
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	bot, err := repo.GetBotByAPIKeyHash(context.Background(), "unknown")
+	assert.Nil(t, bot)
+	assert.Equal(t, ErrBotNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBotNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// Only bot users can be deleted this way
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users`)).
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteBot(context.Background(), 5)
+	assert.Equal(t, ErrBotNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChatWebhooks(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	webhookURL := "https://example.com/hook"
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE cp.chat_id = $1 AND c.is_group AND b.webhook_url IS NOT NULL`)).
+		WithArgs("chat1").
+		WillReturnRows(sqlmock.NewRows(botRowColumns).
+			AddRow(42, 1, "Reminders", "hash", webhookURL, "secret", time.Now()))
+
+	bots, err := repo.GetChatWebhooks(context.Background(), "chat1")
+	assert.NoError(t, err)
+	if assert.Len(t, bots, 1) {
+		assert.Equal(t, 42, bots[0].ID)
+		assert.Equal(t, webhookURL, *bots[0].WebhookURL)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,282 @@
+package campaign
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Campaign statuses
+const (
+	StatusScheduled = "scheduled"
+	StatusSending   = "sending"
+	StatusSent      = "sent"
+	StatusCancelled = "cancelled"
+)
+
+var (
+	ErrCampaignNotFound = errors.New("campaign not found")
+)
+
+// Segment selects the users a campaign is sent to. Every filter that is set must match;
+// without filters the campaign goes to everyone with a registered device.
+type Segment struct {
+	CityID         *int  `json:"city_id,omitempty"`
+	LookingForTeam *bool `json:"looking_for_team,omitempty"`
+	// Users who have not sent a message or opened the app for this many days
+	InactiveDays *int `json:"inactive_days,omitempty"`
+}
+
+// Campaign is a push notification an admin scheduled for a segment of users
+type Campaign struct {
+	ID          string     `json:"id"`
+	AuthorID    *int       `json:"author_id"` // Nil once the author's account is deleted
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Segment     Segment    `json:"segment"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	Status      string     `json:"status"`
+	Recipients  *int       `json:"recipients,omitempty"` // Size of the segment when it was sent
+	Queued      int        `json:"queued"`               // Notifications queued for delivery
+	Failed      int        `json:"failed"`               // Notifications that could not be queued
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// Repository defines methods for push campaigns and their audience
+type Repository interface {
+	CreateCampaign(ctx context.Context, campaign *Campaign) error
+	GetCampaign(ctx context.Context, campaignID string) (*Campaign, error)
+	// GetCampaigns returns up to limit campaigns, the latest scheduled first
+	GetCampaigns(ctx context.Context, limit int) ([]Campaign, error)
+	CancelCampaign(ctx context.Context, campaignID string) error
+	// ClaimDueCampaigns marks up to limit due campaigns as sending and returns them.
+	// Concurrent callers never receive the same campaign.
+	ClaimDueCampaigns(ctx context.Context, now time.Time, limit int) ([]Campaign, error)
+	// FinishCampaign marks a sending campaign as sent with its delivery counters
+	FinishCampaign(ctx context.Context, campaignID string, recipients, queued, failed int, finishedAt time.Time) error
+
+	// CountAudience counts the users in the segment at now
+	CountAudience(ctx context.Context, segment Segment, now time.Time) (int, error)
+	// GetAudience returns up to limit users in the segment at now with IDs above afterUserID, by ID
+	GetAudience(ctx context.Context, segment Segment, now time.Time, afterUserID int, limit int) ([]int, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new campaign repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+const campaignColumns = `id, author_id, title, body, segment_city_id, segment_looking_for_team, segment_inactive_days,
+               scheduled_at, status, recipients, queued, failed, created_at, started_at, finished_at`
+
+// CreateCampaign stores a scheduled campaign; Status and CreatedAt are filled in
+func (r *postgresRepository) CreateCampaign(ctx context.Context, campaign *Campaign) error {
+	campaign.Status = StatusScheduled
+	return r.db.QueryRowContext(ctx, `
+        INSERT INTO push_campaigns (id, author_id, title, body, segment_city_id, segment_looking_for_team, segment_inactive_days, scheduled_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING created_at`,
+		campaign.ID, campaign.AuthorID, campaign.Title, campaign.Body,
+		campaign.Segment.CityID, campaign.Segment.LookingForTeam, campaign.Segment.InactiveDays, campaign.ScheduledAt,
+	).Scan(&campaign.CreatedAt)
+}
+
+// GetCampaign returns a campaign by ID
+func (r *postgresRepository) GetCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	var campaign Campaign
+	err := scanCampaign(r.db.QueryRowContext(ctx, `SELECT `+campaignColumns+` FROM push_campaigns WHERE id = $1`, campaignID), &campaign)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// GetCampaigns returns up to limit campaigns, the latest scheduled first
+func (r *postgresRepository) GetCampaigns(ctx context.Context, limit int) ([]Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+campaignColumns+`
+        FROM push_campaigns
+        ORDER BY scheduled_at DESC, id DESC
+        LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCampaigns(rows)
+}
+
+// CancelCampaign cancels a campaign that has not started sending
+func (r *postgresRepository) CancelCampaign(ctx context.Context, campaignID string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE push_campaigns SET status = 'cancelled'
+        WHERE id = $1 AND status = 'scheduled'`,
+		campaignID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrCampaignNotFound)
+}
+
+// ClaimDueCampaigns marks up to limit due campaigns as sending and returns them
+func (r *postgresRepository) ClaimDueCampaigns(ctx context.Context, now time.Time, limit int) ([]Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        UPDATE push_campaigns SET status = 'sending', started_at = $1
+        WHERE id IN (
+            SELECT id FROM push_campaigns
+            WHERE status = 'scheduled' AND scheduled_at <= $1
+            ORDER BY scheduled_at
+            LIMIT $2
+            %s
+        )
+        RETURNING `+campaignColumns,
+		r.dialect.SkipLocked()),
+		now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCampaigns(rows)
+}
+
+// FinishCampaign marks a sending campaign as sent with its delivery counters
+func (r *postgresRepository) FinishCampaign(ctx context.Context, campaignID string, recipients, queued, failed int, finishedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE push_campaigns SET status = 'sent', recipients = $2, queued = $3, failed = $4, finished_at = $5
+        WHERE id = $1`,
+		campaignID, recipients, queued, failed, finishedAt)
+	return err
+}
+
+// audienceQuery builds the FROM and WHERE clauses selecting the users of the segment.
+// Activity is the last message sent and the last time an app registered its push token.
+func audienceQuery(segment Segment, now time.Time) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if segment.CityID != nil {
+		args = append(args, *segment.CityID)
+		conditions = append(conditions, fmt.Sprintf("p.city_id = $%d", len(args)))
+	}
+	if segment.LookingForTeam != nil {
+		args = append(args, *segment.LookingForTeam)
+		conditions = append(conditions, fmt.Sprintf("p.looking_for_team = $%d", len(args)))
+	}
+	if segment.InactiveDays != nil {
+		args = append(args, now.AddDate(0, 0, -*segment.InactiveDays))
+		conditions = append(conditions, fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM messages m WHERE m.sender_id = pt.user_id AND m.sent_at >= $%[1]d)
+          AND NOT EXISTS (SELECT 1 FROM push_tokens t WHERE t.user_id = pt.user_id AND t.last_seen_at >= $%[1]d)`, len(args)))
+	}
+
+	query := `
+        FROM push_tokens pt
+        LEFT JOIN profiles p ON p.user_id = pt.user_id`
+	if len(conditions) > 0 {
+		query += `
+        WHERE ` + strings.Join(conditions, " AND ")
+	}
+	return query, args
+}
+
+// CountAudience counts the users in the segment at now
+func (r *postgresRepository) CountAudience(ctx context.Context, segment Segment, now time.Time) (int, error) {
+	from, args := audienceQuery(segment, now)
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT pt.user_id)`+from, args...).Scan(&count)
+	return count, err
+}
+
+// GetAudience returns up to limit users in the segment at now with IDs above afterUserID, by ID
+func (r *postgresRepository) GetAudience(ctx context.Context, segment Segment, now time.Time, afterUserID int, limit int) ([]int, error) {
+	from, args := audienceQuery(segment, now)
+	joiner := "WHERE"
+	if len(args) > 0 {
+		joiner = "AND"
+	}
+	args = append(args, afterUserID, limit)
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT pt.user_id`+from+fmt.Sprintf(`
+        %s pt.user_id > $%d
+        ORDER BY pt.user_id
+        LIMIT $%d`, joiner, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCampaign(row rowScanner, campaign *Campaign) error {
+	var authorID, cityID, inactiveDays, recipients sql.NullInt64
+	var lookingForTeam sql.NullBool
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(&campaign.ID, &authorID, &campaign.Title, &campaign.Body, &cityID, &lookingForTeam, &inactiveDays,
+		&campaign.ScheduledAt, &campaign.Status, &recipients, &campaign.Queued, &campaign.Failed,
+		&campaign.CreatedAt, &startedAt, &finishedAt)
+	if err != nil {
+		return err
+	}
+	campaign.AuthorID = nullInt(authorID)
+	campaign.Segment.CityID = nullInt(cityID)
+	campaign.Segment.InactiveDays = nullInt(inactiveDays)
+	campaign.Recipients = nullInt(recipients)
+	if lookingForTeam.Valid {
+		campaign.Segment.LookingForTeam = &lookingForTeam.Bool
+	}
+	if startedAt.Valid {
+		campaign.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		campaign.FinishedAt = &finishedAt.Time
+	}
+	return nil
+}
+
+func scanCampaigns(rows *sql.Rows) ([]Campaign, error) {
+	defer rows.Close()
+
+	campaigns := []Campaign{}
+	for rows.Next() {
+		var campaign Campaign
+		if err := scanCampaign(rows, &campaign); err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, rows.Err()
+}
+
+func nullInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
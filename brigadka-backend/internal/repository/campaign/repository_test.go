@@ -0,0 +1,123 @@
+package campaign
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var campaignRowColumns = []string{"id", "author_id", "title", "body", "segment_city_id", "segment_looking_for_team", "segment_inactive_days",
+	"scheduled_at", "status", "recipients", "queued", "failed", "created_at", "started_at", "finished_at"}
+
+func TestCreateCampaign(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	authorID, cityID := 1, 5
+	scheduledAt := time.Now().Add(time.Hour)
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO push_campaigns (id, author_id, title, body, segment_city_id, segment_looking_for_team, segment_inactive_days, scheduled_at)`)).
+		WithArgs("01HZX", &authorID, "Jam tonight", "Open stage at 20:00", &cityID, nil, nil, scheduledAt).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	campaign := &Campaign{ID: "01HZX", AuthorID: &authorID, Title: "Jam tonight", Body: "Open stage at 20:00",
+		Segment: Segment{CityID: &cityID}, ScheduledAt: scheduledAt}
+	err := repo.CreateCampaign(context.Background(), campaign)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusScheduled, campaign.Status)
+	assert.Equal(t, createdAt, campaign.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCampaignNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM push_campaigns WHERE id = $1`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(campaignRowColumns))
+
+	_, err := repo.GetCampaign(context.Background(), "missing")
+	assert.Equal(t, ErrCampaignNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelCampaignAlreadySent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE push_campaigns SET status = 'cancelled'`)).
+		WithArgs("01HZX").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.CancelCampaign(context.Background(), "01HZX")
+	assert.Equal(t, ErrCampaignNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDueCampaigns(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows(campaignRowColumns).
+			AddRow("01HZX", 1, "Jam tonight", "Open stage at 20:00", 5, true, nil, now, StatusSending, nil, 0, 0, now, now, nil))
+
+	campaigns, err := repo.ClaimDueCampaigns(context.Background(), now, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, campaigns, 1)
+	assert.Equal(t, StatusSending, campaigns[0].Status)
+	assert.Equal(t, 5, *campaigns[0].Segment.CityID)
+	assert.True(t, *campaigns[0].Segment.LookingForTeam)
+	assert.Nil(t, campaigns[0].Segment.InactiveDays)
+	assert.NotNil(t, campaigns[0].StartedAt)
+	assert.Nil(t, campaigns[0].FinishedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountAudienceWithoutFilters(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT pt.user_id\)\s+FROM push_tokens pt\s+LEFT JOIN profiles p ON p.user_id = pt.user_id$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := repo.CountAudience(context.Background(), Segment{}, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAudienceInactiveInCity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	cityID, inactiveDays := 5, 30
+	mock.ExpectQuery(`WHERE p.city_id = \$1 AND NOT EXISTS .+m.sent_at >= \$2.+t.last_seen_at >= \$2\)\s+AND pt.user_id > \$3\s+ORDER BY pt.user_id\s+LIMIT \$4`).
+		WithArgs(cityID, now.AddDate(0, 0, -30), 100, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(101).AddRow(140))
+
+	userIDs, err := repo.GetAudience(context.Background(), Segment{CityID: &cityID, InactiveDays: &inactiveDays}, now, 100, 500)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{101, 140}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Reaction is an entry of the message reaction catalog
+type Reaction struct {
+	Code  string `json:"code"`
+	Emoji string `json:"emoji"`
+}
+
+// Repository defines the catalog queries not owned by another domain
+type Repository interface {
+	GetReactions(ctx context.Context) ([]Reaction, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new catalog repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// GetReactions returns the reaction catalog
+func (r *postgresRepository) GetReactions(ctx context.Context) ([]Reaction, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT reaction_code, emoji FROM reaction_catalog ORDER BY reaction_code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := []Reaction{}
+	for rows.Next() {
+		var reaction Reaction
+		if err := rows.Scan(&reaction.Code, &reaction.Emoji); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, rows.Err()
+}
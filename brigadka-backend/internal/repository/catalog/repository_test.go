@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestGetReactions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT reaction_code, emoji FROM reaction_catalog ORDER BY reaction_code`)).
+		WillReturnRows(sqlmock.NewRows([]string{"reaction_code", "emoji"}).
+			AddRow("clap", "👏").
+			AddRow("like", "👍"))
+
+	reactions, err := repo.GetReactions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Reaction{{Code: "clap", Emoji: "👏"}, {Code: "like", Emoji: "👍"}}, reactions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
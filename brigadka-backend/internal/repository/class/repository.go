@@ -0,0 +1,314 @@
+package class
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Class levels
+const (
+	LevelBeginner     = "beginner"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+	LevelAll          = "all"
+)
+
+var (
+	ErrClassNotFound   = errors.New("class not found")
+	ErrAlreadyEnrolled = errors.New("user is already enrolled")
+	ErrNotEnrolled     = errors.New("user is not enrolled")
+	ErrClassFull       = errors.New("class is full")
+)
+
+// ClassModel represents a class stored in the database
+type ClassModel struct {
+	ID            int
+	TeacherID     int
+	TeacherName   string
+	Title         string
+	Description   string
+	CityID        *int
+	Level         string
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Schedule      string
+	Capacity      int
+	PriceCents    int
+	Currency      string // Empty for free classes
+	EnrolledCount int
+	CreatedAt     time.Time
+}
+
+// EnrollmentModel represents a student enrolled in a class
+type EnrollmentModel struct {
+	ClassID    int
+	UserID     int
+	FullName   string
+	EnrolledAt time.Time
+}
+
+// SearchParams defines the filters for class searches
+type SearchParams struct {
+	EndsAfter time.Time // Classes that are over by then are skipped
+	CityID    *int
+	Level     *string
+	TeacherID *int
+	Page      int
+	PageSize  int
+}
+
+// Repository defines methods for class storage
+type Repository interface {
+	CreateClass(ctx context.Context, class *ClassModel) error
+	GetClass(ctx context.Context, classID int) (*ClassModel, error)
+	DeleteClass(ctx context.Context, classID int) error
+	SearchClasses(ctx context.Context, params SearchParams) ([]*ClassModel, int, error)
+
+	Enroll(ctx context.Context, classID, userID int) error
+	Unenroll(ctx context.Context, classID, userID int) error
+	IsEnrolled(ctx context.Context, classID, userID int) (bool, error)
+	GetEnrollments(ctx context.Context, classID int) ([]EnrollmentModel, error)
+
+	GetClassChat(ctx context.Context, classID int) (*string, error)
+	SetClassChat(ctx context.Context, classID int, chatID string) error
+
+	ValidateCity(ctx context.Context, cityID int) (bool, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new class repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// classColumns are scanned by scanClass
+const classColumns = `
+            c.id, c.teacher_id, COALESCE(p.full_name, ''), c.title, c.description, c.city_id, c.level,
+            c.starts_at, c.ends_at, c.schedule, c.capacity, c.price_cents, COALESCE(c.currency, ''), c.created_at,
+            (SELECT COUNT(*) FROM class_enrollments ce WHERE ce.class_id = c.id) AS enrolled_count`
+
+func scanClass(row interface{ Scan(dest ...any) error }) (*ClassModel, error) {
+	class := &ClassModel{}
+	err := row.Scan(
+		&class.ID, &class.TeacherID, &class.TeacherName, &class.Title, &class.Description, &class.CityID, &class.Level,
+		&class.StartsAt, &class.EndsAt, &class.Schedule, &class.Capacity, &class.PriceCents, &class.Currency, &class.CreatedAt,
+		&class.EnrolledCount,
+	)
+	return class, err
+}
+
+// CreateClass inserts a new class
+func (r *postgresRepository) CreateClass(ctx context.Context, class *ClassModel) error {
+	return r.db.QueryRowContext(ctx, `
+        INSERT INTO classes (teacher_id, title, description, city_id, level, starts_at, ends_at, schedule, capacity, price_cents, currency)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
+        RETURNING id, created_at`,
+		class.TeacherID, class.Title, class.Description, class.CityID, class.Level, class.StartsAt, class.EndsAt,
+		class.Schedule, class.Capacity, class.PriceCents, class.Currency,
+	).Scan(&class.ID, &class.CreatedAt)
+}
+
+// GetClass retrieves a class by ID
+func (r *postgresRepository) GetClass(ctx context.Context, classID int) (*ClassModel, error) {
+	class, err := scanClass(r.db.QueryRowContext(ctx, `
+        SELECT`+classColumns+`
+        FROM classes c
+        LEFT JOIN profiles p ON p.user_id = c.teacher_id
+        WHERE c.id = $1`, classID))
+	if err == sql.ErrNoRows {
+		return nil, ErrClassNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// DeleteClass deletes a class together with its enrollments
+func (r *postgresRepository) DeleteClass(ctx context.Context, classID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM classes WHERE id = $1`, classID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrClassNotFound)
+}
+
+// SearchClasses returns a page of classes matching the filters, soonest first
+func (r *postgresRepository) SearchClasses(ctx context.Context, params SearchParams) ([]*ClassModel, int, error) {
+	conditions := []string{"c.ends_at > $1"}
+	args := []interface{}{params.EndsAfter}
+	argIndex := 2
+
+	if params.CityID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.city_id = $%d", argIndex))
+		args = append(args, *params.CityID)
+		argIndex++
+	}
+	if params.Level != nil {
+		conditions = append(conditions, fmt.Sprintf("c.level = $%d", argIndex))
+		args = append(args, *params.Level)
+		argIndex++
+	}
+	if params.TeacherID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.teacher_id = $%d", argIndex))
+		args = append(args, *params.TeacherID)
+		argIndex++
+	}
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var totalCount int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM classes c`+whereClause, args...).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT` + classColumns + `
+        FROM classes c
+        LEFT JOIN profiles p ON p.user_id = c.teacher_id` + whereClause + `
+        ORDER BY c.starts_at, c.id` + fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	classes := []*ClassModel{}
+	for rows.Next() {
+		class, err := scanClass(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		classes = append(classes, class)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return classes, totalCount, nil
+}
+
+// Enroll adds a student to a class. The class row is locked while the seats are
+// counted, so concurrent enrollments cannot exceed the capacity.
+func (r *postgresRepository) Enroll(ctx context.Context, classID, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var capacity int
+	err = tx.QueryRowContext(ctx, `SELECT capacity FROM classes WHERE id = $1 `+r.dialect.ForUpdate(), classID).Scan(&capacity)
+	if err == sql.ErrNoRows {
+		return ErrClassNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var enrolled, alreadyEnrolled int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COUNT(*), COALESCE(SUM(CASE WHEN user_id = $2 THEN 1 ELSE 0 END), 0)
+        FROM class_enrollments
+        WHERE class_id = $1`, classID, userID,
+	).Scan(&enrolled, &alreadyEnrolled)
+	if err != nil {
+		return err
+	}
+	if alreadyEnrolled > 0 {
+		return ErrAlreadyEnrolled
+	}
+	if enrolled >= capacity {
+		return ErrClassFull
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO class_enrollments (class_id, user_id) VALUES ($1, $2)`, classID, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Unenroll removes a student from a class
+func (r *postgresRepository) Unenroll(ctx context.Context, classID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM class_enrollments WHERE class_id = $1 AND user_id = $2`, classID, userID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrNotEnrolled)
+}
+
+// IsEnrolled checks whether a user is enrolled in a class
+func (r *postgresRepository) IsEnrolled(ctx context.Context, classID, userID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM class_enrollments WHERE class_id = $1 AND user_id = $2)`,
+		classID, userID).Scan(&exists)
+	return exists, err
+}
+
+// GetEnrollments retrieves the students of a class in the order they enrolled
+func (r *postgresRepository) GetEnrollments(ctx context.Context, classID int) ([]EnrollmentModel, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT ce.class_id, ce.user_id, COALESCE(p.full_name, ''), ce.enrolled_at
+        FROM class_enrollments ce
+        LEFT JOIN profiles p ON p.user_id = ce.user_id
+        WHERE ce.class_id = $1
+        ORDER BY ce.enrolled_at, ce.user_id`, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enrollments := []EnrollmentModel{}
+	for rows.Next() {
+		var enrollment EnrollmentModel
+		if err := rows.Scan(&enrollment.ClassID, &enrollment.UserID, &enrollment.FullName, &enrollment.EnrolledAt); err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	return enrollments, rows.Err()
+}
+
+// GetClassChat returns the ID of the class group chat, nil until the first enrollment
+func (r *postgresRepository) GetClassChat(ctx context.Context, classID int) (*string, error) {
+	var chatID sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT chat_id FROM classes WHERE id = $1`, classID).Scan(&chatID)
+	if err == sql.ErrNoRows {
+		return nil, ErrClassNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !chatID.Valid {
+		return nil, nil
+	}
+	return &chatID.String, nil
+}
+
+// SetClassChat links a group chat to a class
+func (r *postgresRepository) SetClassChat(ctx context.Context, classID int, chatID string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE classes SET chat_id = $2 WHERE id = $1`, classID, chatID)
+	return err
+}
+
+// ValidateCity checks that a city exists
+func (r *postgresRepository) ValidateCity(ctx context.Context, cityID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM cities WHERE city_id = $1)`, cityID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,231 @@
+package class
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var classRowColumns = []string{
+	"id", "teacher_id", "full_name", "title", "description", "city_id", "level",
+	"starts_at", "ends_at", "schedule", "capacity", "price_cents", "currency", "created_at", "enrolled_count",
+}
+
+func TestCreateClass(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	startsAt := time.Date(2026, 11, 3, 19, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(6 * 7 * 24 * time.Hour)
+	createdAt := time.Now()
+	cityID := 1
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        INSERT INTO classes (teacher_id, title, description, city_id, level, starts_at, ends_at, schedule, capacity, price_cents, currency)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
+        RETURNING id, created_at`)).
+		WithArgs(5, "Longform basics", "Harold and friends", &cityID, LevelBeginner, startsAt, endsAt, "Tuesdays 19:00", 12, 300000, "RUB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, createdAt))
+
+	class := &ClassModel{
+		TeacherID:   5,
+		Title:       "Longform basics",
+		Description: "Harold and friends",
+		CityID:      &cityID,
+		Level:       LevelBeginner,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Schedule:    "Tuesdays 19:00",
+		Capacity:    12,
+		PriceCents:  300000,
+		Currency:    "RUB",
+	}
+	err := repo.CreateClass(context.Background(), class)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, class.ID)
+	assert.Equal(t, createdAt, class.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClass(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	query := regexp.QuoteMeta(`
+        SELECT` + classColumns + `
+        FROM classes c
+        LEFT JOIN profiles p ON p.user_id = c.teacher_id
+        WHERE c.id = $1`)
+	mock.ExpectQuery(query).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows(classRowColumns).
+			AddRow(10, 5, "Teacher", "Jam", "", nil, LevelAll, startsAt, startsAt.Add(2*time.Hour), "", 20, 0, "", time.Now(), 3))
+	mock.ExpectQuery(query).
+		WithArgs(11).
+		WillReturnError(sql.ErrNoRows)
+
+	class, err := repo.GetClass(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "Teacher", class.TeacherName)
+	assert.Nil(t, class.CityID)
+	assert.Equal(t, 3, class.EnrolledCount)
+
+	_, err = repo.GetClass(context.Background(), 11)
+	assert.ErrorIs(t, err, ErrClassNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchClasses(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	cityID := 1
+	level := LevelBeginner
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM classes c WHERE c.ends_at > $1 AND c.city_id = $2 AND c.level = $3`)).
+		WithArgs(now, 1, LevelBeginner).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        FROM classes c
+        LEFT JOIN profiles p ON p.user_id = c.teacher_id WHERE c.ends_at > $1 AND c.city_id = $2 AND c.level = $3
+        ORDER BY c.starts_at, c.id LIMIT $4 OFFSET $5`)).
+		WithArgs(now, 1, LevelBeginner, 20, 20).
+		WillReturnRows(sqlmock.NewRows(classRowColumns).
+			AddRow(10, 5, "Teacher", "Jam", "", 1, LevelBeginner, now, now.Add(time.Hour), "", 20, 0, "", now, 0))
+
+	classes, total, err := repo.SearchClasses(context.Background(), SearchParams{
+		EndsAfter: now,
+		CityID:    &cityID,
+		Level:     &level,
+		Page:      2,
+		PageSize:  20,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, classes, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnroll(t *testing.T) {
+	tests := []struct {
+		name            string
+		enrolled        int
+		alreadyEnrolled int
+		wantErr         error
+	}{
+		{"enrolled", 3, 0, nil},
+		{"full", 12, 0, ErrClassFull},
+		{"already enrolled", 12, 1, ErrAlreadyEnrolled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity FROM classes WHERE id = $1 FOR UPDATE`)).
+				WithArgs(10).
+				WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(12))
+			mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT COUNT(*), COALESCE(SUM(CASE WHEN user_id = $2 THEN 1 ELSE 0 END), 0)
+        FROM class_enrollments
+        WHERE class_id = $1`)).
+				WithArgs(10, 7).
+				WillReturnRows(sqlmock.NewRows([]string{"count", "enrolled"}).AddRow(tt.enrolled, tt.alreadyEnrolled))
+			if tt.wantErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO class_enrollments (class_id, user_id) VALUES ($1, $2)`)).
+					WithArgs(10, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.Enroll(context.Background(), 10, 7)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEnrollClassNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity FROM classes WHERE id = $1 FOR UPDATE`)).
+		WithArgs(10).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err := repo.Enroll(context.Background(), 10, 7)
+	assert.ErrorIs(t, err, ErrClassNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnenroll(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`DELETE FROM class_enrollments WHERE class_id = $1 AND user_id = $2`)
+	mock.ExpectExec(query).WithArgs(10, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(10, 8).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.Unenroll(context.Background(), 10, 7))
+	assert.ErrorIs(t, repo.Unenroll(context.Background(), 10, 8), ErrNotEnrolled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEnrollments(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	enrolledAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT ce.class_id, ce.user_id, COALESCE(p.full_name, ''), ce.enrolled_at
+        FROM class_enrollments ce
+        LEFT JOIN profiles p ON p.user_id = ce.user_id
+        WHERE ce.class_id = $1
+        ORDER BY ce.enrolled_at, ce.user_id`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"class_id", "user_id", "full_name", "enrolled_at"}).
+			AddRow(10, 7, "Student", enrolledAt))
+
+	enrollments, err := repo.GetEnrollments(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []EnrollmentModel{{ClassID: 10, UserID: 7, FullName: "Student", EnrolledAt: enrolledAt}}, enrollments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClassChat(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT chat_id FROM classes WHERE id = $1`)
+	mock.ExpectQuery(query).WithArgs(10).WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow(nil))
+	mock.ExpectQuery(query).WithArgs(11).WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow("chat-1"))
+
+	chatID, err := repo.GetClassChat(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Nil(t, chatID)
+
+	chatID, err = repo.GetClassChat(context.Background(), 11)
+	assert.NoError(t, err)
+	if assert.NotNil(t, chatID) {
+		assert.Equal(t, "chat-1", *chatID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,117 @@
+package consent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Consent represents acceptance of a legal document version by a user
+type Consent struct {
+	UserID     int       `json:"user_id"`
+	Document   string    `json:"document"`
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+// Repository defines methods for consent storage
+type Repository interface {
+	SaveConsent(ctx context.Context, userID int, document, version string) error
+	GetUserConsents(ctx context.Context, userID int) ([]Consent, error)
+	GetAcceptedDocuments(ctx context.Context, userID int, versions map[string]string) (map[string]bool, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new consent repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// SaveConsent records that the user accepted a document version; repeated acceptance is a no-op
+func (r *postgresRepository) SaveConsent(ctx context.Context, userID int, document, version string) error {
+	query := fmt.Sprintf(`
+        INSERT INTO user_consents (user_id, document, version, accepted_at)
+        VALUES ($1, $2, $3, %s)
+        ON CONFLICT (user_id, document, version) DO NOTHING`, r.dialect.Now())
+
+	_, err := r.db.ExecContext(ctx, query, userID, document, version)
+	return err
+}
+
+// GetUserConsents returns all accepted document versions for a user, newest first
+func (r *postgresRepository) GetUserConsents(ctx context.Context, userID int) ([]Consent, error) {
+	query := `
+        SELECT user_id, document, version, accepted_at
+        FROM user_consents
+        WHERE user_id = $1
+        ORDER BY accepted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var consents []Consent
+	for rows.Next() {
+		var c Consent
+		if err := rows.Scan(&c.UserID, &c.Document, &c.Version, &c.AcceptedAt); err != nil {
+			return nil, err
+		}
+		consents = append(consents, c)
+	}
+
+	return consents, rows.Err()
+}
+
+// GetAcceptedDocuments reports which of the given document versions the user accepted,
+// keyed by document, checking all of them in one query
+func (r *postgresRepository) GetAcceptedDocuments(ctx context.Context, userID int, versions map[string]string) (map[string]bool, error) {
+	accepted := make(map[string]bool, len(versions))
+	if len(versions) == 0 {
+		return accepted, nil
+	}
+
+	documents := make([]string, 0, len(versions))
+	for document := range versions {
+		documents = append(documents, document)
+	}
+	sort.Strings(documents)
+
+	args := []interface{}{userID}
+	conditions := make([]string, len(documents))
+	for i, document := range documents {
+		args = append(args, document, versions[document])
+		conditions[i] = fmt.Sprintf("(document = $%d AND version = $%d)", len(args)-1, len(args))
+	}
+	query := `
+        SELECT DISTINCT document FROM user_consents
+        WHERE user_id = $1 AND (` + strings.Join(conditions, " OR ") + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		accepted[document] = true
+	}
+	return accepted, rows.Err()
+}
@@ -0,0 +1,98 @@
+package consent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestSaveConsent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO user_consents (user_id, document, version, accepted_at)
+        VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (user_id, document, version) DO NOTHING`)).
+		WithArgs(1, "tos", "2025-01").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SaveConsent(context.Background(), 1, "tos", "2025-01")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserConsents(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	acceptedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT user_id, document, version, accepted_at
+        FROM user_consents
+        WHERE user_id = $1
+        ORDER BY accepted_at DESC`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "document", "version", "accepted_at"}).
+			AddRow(1, "tos", "2025-01", acceptedAt).
+			AddRow(1, "privacy", "2024-06", acceptedAt))
+
+	consents, err := repo.GetUserConsents(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, consents, 2)
+	assert.Equal(t, "tos", consents[0].Document)
+	assert.Equal(t, "2024-06", consents[1].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserConsentsError(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, document, version, accepted_at`)).
+		WithArgs(1).
+		WillReturnError(errors.New("database error"))
+
+	consents, err := repo.GetUserConsents(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Nil(t, consents)
+}
+
+func TestGetAcceptedDocuments(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT DISTINCT document FROM user_consents
+        WHERE user_id = $1 AND ((document = $2 AND version = $3) OR (document = $4 AND version = $5))`)).
+		WithArgs(1, "privacy", "2025-02", "tos", "2025-01").
+		WillReturnRows(sqlmock.NewRows([]string{"document"}).AddRow("tos"))
+
+	accepted, err := repo.GetAcceptedDocuments(context.Background(), 1, map[string]string{"tos": "2025-01", "privacy": "2025-02"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"tos": true}, accepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAcceptedDocumentsNoVersions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	accepted, err := repo.GetAcceptedDocuments(context.Background(), 1, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, accepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
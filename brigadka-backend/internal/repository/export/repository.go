@@ -0,0 +1,210 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Export statuses
+const (
+	StatusPending = "pending"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+var (
+	ErrExportNotFound = errors.New("export not found")
+)
+
+// Export represents an asynchronously generated file
+type Export struct {
+	ID          string     `json:"id"`
+	UserID      int        `json:"user_id"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Error       *string    `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Data        []byte     `json:"-"`
+	// Params are what the generator of the kind needs to produce the file, as JSON
+	Params   json.RawMessage `json:"-"`
+	Attempts int             `json:"-"` // Including the attempt the export was claimed for
+}
+
+// Repository defines methods for export storage
+type Repository interface {
+	CreateExport(ctx context.Context, export *Export) error
+	ClaimExports(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]Export, error)
+	CompleteExport(ctx context.Context, id string, data []byte) error
+	FailExport(ctx context.Context, id string, message string) error
+	GetExport(ctx context.Context, id string) (*Export, error)
+	GetExportData(ctx context.Context, id string) ([]byte, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new export repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// CreateExport stores a new pending export
+func (r *postgresRepository) CreateExport(ctx context.Context, export *Export) error {
+	query := `
+        INSERT INTO exports (id, user_id, kind, status, file_name, content_type, params)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at`
+
+	params := export.Params
+	if params == nil {
+		params = json.RawMessage("{}")
+	}
+	return r.db.QueryRowContext(ctx, query,
+		export.ID, export.UserID, export.Kind, StatusPending, export.FileName, export.ContentType, string(params),
+	).Scan(&export.CreatedAt)
+}
+
+// ClaimExports returns up to limit pending exports, oldest first, and counts an attempt
+// for each. They are not claimed again until leaseUntil, so an export whose worker
+// stopped mid-run is picked up then. Concurrent workers claim different exports.
+func (r *postgresRepository) ClaimExports(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]Export, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, user_id, kind, file_name, content_type, params, attempts, created_at FROM exports
+        WHERE status = $1 AND (lease_until IS NULL OR lease_until <= $2)
+        ORDER BY created_at, id
+        LIMIT $3 `+r.dialect.SkipLocked(), StatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := []Export{}
+	for rows.Next() {
+		e := Export{Status: StatusPending}
+		var params string
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Kind, &e.FileName, &e.ContentType, &params, &e.Attempts, &e.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		e.Params = json.RawMessage(params)
+		e.Attempts++
+		exports = append(exports, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(exports) == 0 {
+		return exports, nil
+	}
+
+	args := []interface{}{leaseUntil}
+	placeholders := make([]string, len(exports))
+	for i, e := range exports {
+		args = append(args, e.ID)
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE exports SET attempts = attempts + 1, lease_until = $1
+        WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return exports, tx.Commit()
+}
+
+// CompleteExport stores the generated file and marks the export as done
+func (r *postgresRepository) CompleteExport(ctx context.Context, id string, data []byte) error {
+	query := fmt.Sprintf(`
+        UPDATE exports
+        SET status = $2, data = $3, completed_at = %s
+        WHERE id = $1`, r.dialect.Now())
+
+	_, err := r.db.ExecContext(ctx, query, id, StatusDone, data)
+	return err
+}
+
+// FailExport marks the export as failed with the given message
+func (r *postgresRepository) FailExport(ctx context.Context, id string, message string) error {
+	query := fmt.Sprintf(`
+        UPDATE exports
+        SET status = $2, error = $3, completed_at = %s
+        WHERE id = $1`, r.dialect.Now())
+
+	_, err := r.db.ExecContext(ctx, query, id, StatusFailed, message)
+	return err
+}
+
+// GetExport returns export metadata without the file content
+func (r *postgresRepository) GetExport(ctx context.Context, id string) (*Export, error) {
+	query := `
+        SELECT id, user_id, kind, status, file_name, content_type, error, created_at, completed_at
+        FROM exports
+        WHERE id = $1`
+
+	var export Export
+	var errMsg sql.NullString
+	var completedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Kind,
+		&export.Status,
+		&export.FileName,
+		&export.ContentType,
+		&errMsg,
+		&export.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	if errMsg.Valid {
+		export.Error = &errMsg.String
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+
+	return &export, nil
+}
+
+// GetExportData returns the generated file content
+func (r *postgresRepository) GetExportData(ctx context.Context, id string) ([]byte, error) {
+	query := `SELECT data FROM exports WHERE id = $1`
+
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
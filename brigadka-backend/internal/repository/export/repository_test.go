@@ -0,0 +1,137 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestCreateExport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        INSERT INTO exports (id, user_id, kind, status, file_name, content_type, params)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at`)).
+		WithArgs("e1", 1, "profile_search", StatusPending, "profiles.csv", "text/csv", `{"lang":"en"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	export := &Export{ID: "e1", UserID: 1, Kind: "profile_search", FileName: "profiles.csv", ContentType: "text/csv", Params: json.RawMessage(`{"lang":"en"}`)}
+	err := repo.CreateExport(context.Background(), export)
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, export.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimExports(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	createdAt := now.Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT id, user_id, kind, file_name, content_type, params, attempts, created_at FROM exports
+        WHERE status = $1 AND (lease_until IS NULL OR lease_until <= $2)
+        ORDER BY created_at, id
+        LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(StatusPending, now, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "kind", "file_name", "content_type", "params", "attempts", "created_at"}).
+			AddRow("e1", 1, "profile_search", "profiles.csv", "text/csv", `{"lang":"en"}`, 0, createdAt).
+			AddRow("e2", 2, "profile_search", "profiles.csv", "text/csv", `{}`, 1, createdAt))
+	mock.ExpectExec(regexp.QuoteMeta(`
+        UPDATE exports SET attempts = attempts + 1, lease_until = $1
+        WHERE id IN ($2, $3)`)).
+		WithArgs(leaseUntil, "e1", "e2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	exports, err := repo.ClaimExports(context.Background(), now, leaseUntil, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []Export{
+		{ID: "e1", UserID: 1, Kind: "profile_search", Status: StatusPending, FileName: "profiles.csv", ContentType: "text/csv", Params: json.RawMessage(`{"lang":"en"}`), Attempts: 1, CreatedAt: createdAt},
+		{ID: "e2", UserID: 2, Kind: "profile_search", Status: StatusPending, FileName: "profiles.csv", ContentType: "text/csv", Params: json.RawMessage(`{}`), Attempts: 2, CreatedAt: createdAt},
+	}, exports)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimExportsNonePending(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, kind, file_name, content_type, params, attempts, created_at FROM exports`)).
+		WithArgs(StatusPending, now, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "kind", "file_name", "content_type", "params", "attempts", "created_at"}))
+	mock.ExpectRollback()
+
+	exports, err := repo.ClaimExports(context.Background(), now, now.Add(time.Minute), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, exports)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteExport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        UPDATE exports
+        SET status = $2, data = $3, completed_at = NOW()
+        WHERE id = $1`)).
+		WithArgs("e1", StatusDone, []byte("a,b\n")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.CompleteExport(context.Background(), "e1", []byte("a,b\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, kind, status, file_name, content_type, error, created_at, completed_at`)).
+		WithArgs("e1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "kind", "status", "file_name", "content_type", "error", "created_at", "completed_at"}).
+			AddRow("e1", 1, "profile_search", StatusFailed, "profiles.csv", "text/csv", "boom", createdAt, createdAt))
+
+	export, err := repo.GetExport(context.Background(), "e1")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, export.Status)
+	if assert.NotNil(t, export.Error) {
+		assert.Equal(t, "boom", *export.Error)
+	}
+	assert.NotNil(t, export.CompletedAt)
+}
+
+func TestGetExportNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT data FROM exports WHERE id = $1`)).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	data, err := repo.GetExportData(context.Background(), "missing")
+	assert.Nil(t, data)
+	assert.Equal(t, ErrExportNotFound, err)
+}
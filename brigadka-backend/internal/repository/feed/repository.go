@@ -0,0 +1,148 @@
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var (
+	ErrTargetNotFound = errors.New("follow target not found")
+	ErrNotFollowing   = errors.New("not following")
+)
+
+// ActivityModel represents an entry of the activity log
+type ActivityModel struct {
+	ID        int64
+	Type      string
+	UserID    *int
+	UserName  *string
+	TeamID    *int
+	TeamName  *string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Repository defines methods for follows and the activity log
+type Repository interface {
+	FollowUser(ctx context.Context, followerID, userID int) error
+	UnfollowUser(ctx context.Context, followerID, userID int) error
+	FollowTeam(ctx context.Context, followerID, teamID int) error
+	UnfollowTeam(ctx context.Context, followerID, teamID int) error
+
+	AddActivity(ctx context.Context, activity *ActivityModel) error
+	GetFeed(ctx context.Context, userID int, beforeID *int64, limit int) ([]ActivityModel, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new feed repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// FollowUser subscribes followerID to the activity of userID; following twice is a no-op
+func (r *postgresRepository) FollowUser(ctx context.Context, followerID, userID int) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO user_follows (follower_id, user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (follower_id, user_id) DO NOTHING`,
+		followerID, userID)
+	if database.IsForeignKeyViolation(err) {
+		return ErrTargetNotFound
+	}
+	return err
+}
+
+// UnfollowUser removes a subscription to a user
+func (r *postgresRepository) UnfollowUser(ctx context.Context, followerID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        DELETE FROM user_follows
+        WHERE follower_id = $1 AND user_id = $2`,
+		followerID, userID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrNotFollowing)
+}
+
+// FollowTeam subscribes followerID to the activity of a team; following twice is a no-op
+func (r *postgresRepository) FollowTeam(ctx context.Context, followerID, teamID int) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO team_follows (follower_id, team_id)
+        VALUES ($1, $2)
+        ON CONFLICT (follower_id, team_id) DO NOTHING`,
+		followerID, teamID)
+	if database.IsForeignKeyViolation(err) {
+		return ErrTargetNotFound
+	}
+	return err
+}
+
+// UnfollowTeam removes a subscription to a team
+func (r *postgresRepository) UnfollowTeam(ctx context.Context, followerID, teamID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        DELETE FROM team_follows
+        WHERE follower_id = $1 AND team_id = $2`,
+		followerID, teamID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrNotFollowing)
+}
+
+// AddActivity appends an entry to the activity log
+func (r *postgresRepository) AddActivity(ctx context.Context, activity *ActivityModel) error {
+	return r.db.QueryRowContext(ctx, `
+        INSERT INTO activities (type, user_id, team_id, payload)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at`,
+		activity.Type, activity.UserID, activity.TeamID, activity.Payload,
+	).Scan(&activity.ID, &activity.CreatedAt)
+}
+
+// GetFeed returns the activity of users and teams followed by userID, newest first.
+// Entries older than beforeID are returned when it is set.
+func (r *postgresRepository) GetFeed(ctx context.Context, userID int, beforeID *int64, limit int) ([]ActivityModel, error) {
+	query := `
+        SELECT a.id, a.type, a.user_id, p.full_name, a.team_id, t.name, a.payload, a.created_at
+        FROM activities a
+        LEFT JOIN profiles p ON p.user_id = a.user_id
+        LEFT JOIN teams t ON t.id = a.team_id
+        WHERE (
+            a.user_id IN (SELECT user_id FROM user_follows WHERE follower_id = $1)
+            OR a.team_id IN (SELECT team_id FROM team_follows WHERE follower_id = $1)
+        )`
+	args := []interface{}{userID, limit}
+	if beforeID != nil {
+		query += " AND a.id < $3"
+		args = append(args, *beforeID)
+	}
+	query += " ORDER BY a.id DESC LIMIT $2"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feed: %w", err)
+	}
+	defer rows.Close()
+
+	activities := []ActivityModel{}
+	for rows.Next() {
+		var a ActivityModel
+		if err := rows.Scan(&a.ID, &a.Type, &a.UserID, &a.UserName, &a.TeamID, &a.TeamName, &a.Payload, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		activities = append(activities, a)
+	}
+	return activities, rows.Err()
+}
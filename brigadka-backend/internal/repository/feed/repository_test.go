@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestFollowUserTargetNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_follows`)).
+		WithArgs(1, 99).
+		WillReturnError(&pq.Error{Code: database.ForeignKeyViolation})
+
+	err := repo.FollowUser(context.Background(), 1, 99)
+	assert.Equal(t, ErrTargetNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnfollowTeamNotFollowing(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM team_follows`)).
+		WithArgs(1, 10).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UnfollowTeam(context.Background(), 1, 10)
+	assert.Equal(t, ErrNotFollowing, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFeedBeforeID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	beforeID := int64(100)
+	mock.ExpectQuery(regexp.QuoteMeta(`AND a.id < $3 ORDER BY a.id DESC LIMIT $2`)).
+		WithArgs(1, 20, beforeID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "user_id", "full_name", "team_id", "name", "payload", "created_at"}).
+			AddRow(int64(99), "profile_updated", 2, "John", nil, nil, nil, createdAt))
+
+	activities, err := repo.GetFeed(context.Background(), 1, &beforeID, 20)
+	assert.NoError(t, err)
+	if assert.Len(t, activities, 1) {
+		assert.Equal(t, int64(99), activities[0].ID)
+		assert.Equal(t, "John", *activities[0].UserName)
+		assert.Nil(t, activities[0].TeamID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
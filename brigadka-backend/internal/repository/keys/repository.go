@@ -0,0 +1,224 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device keys not found")
+)
+
+// DeviceKeys are the long-term public keys of a device. Keys are opaque to the server,
+// base64 as produced by the client library.
+type DeviceKeys struct {
+	DeviceID              string `json:"device_id"`
+	IdentityKey           string `json:"identity_key"`
+	SignedPrekeyID        int    `json:"signed_prekey_id"`
+	SignedPrekey          string `json:"signed_prekey"`
+	SignedPrekeySignature string `json:"signed_prekey_signature"`
+}
+
+// Prekey is a one-time public key of a device, handed out once
+type Prekey struct {
+	KeyID     int    `json:"key_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// KeyBundle is what another user needs to start an encrypted session with a device
+type KeyBundle struct {
+	DeviceKeys
+	OneTimePrekey *Prekey `json:"one_time_prekey"` // Nil when the device ran out of one-time prekeys
+}
+
+// Repository defines methods for the public keys of user devices
+type Repository interface {
+	// SaveDeviceKeys registers the device or replaces its keys. One-time prekeys of
+	// a previous identity key are dropped, they cannot be used with the new one.
+	SaveDeviceKeys(ctx context.Context, userID int, keys DeviceKeys) error
+	// AddPrekeys stores one-time prekeys of a registered device, skipping known key IDs,
+	// and returns how many the device has
+	AddPrekeys(ctx context.Context, userID int, deviceID string, prekeys []Prekey) (int, error)
+	DeleteDevice(ctx context.Context, userID int, deviceID string) error
+	// ClaimKeyBundles returns the bundles of all devices of the user. Each bundle takes
+	// one one-time prekey of its device, which is deleted so it is never handed out twice.
+	ClaimKeyBundles(ctx context.Context, userID int) ([]KeyBundle, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new device keys repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+func (r *postgresRepository) SaveDeviceKeys(ctx context.Context, userID int, keys DeviceKeys) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previousIdentity string
+	err = tx.QueryRowContext(ctx,
+		"SELECT identity_key FROM device_keys WHERE user_id = $1 AND device_id = $2",
+		userID, keys.DeviceID,
+	).Scan(&previousIdentity)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	now := r.dialect.Now()
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO device_keys (user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        %s
+    `, r.dialect.OnConflictUpdate("user_id, device_id",
+		"identity_key = excluded.identity_key, signed_prekey_id = excluded.signed_prekey_id, "+
+			"signed_prekey = excluded.signed_prekey, signed_prekey_signature = excluded.signed_prekey_signature, updated_at = "+now)),
+		userID, keys.DeviceID, keys.IdentityKey, keys.SignedPrekeyID, keys.SignedPrekey, keys.SignedPrekeySignature)
+	if err != nil {
+		return err
+	}
+
+	if previousIdentity != "" && previousIdentity != keys.IdentityKey {
+		_, err = tx.ExecContext(ctx,
+			"DELETE FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2",
+			userID, keys.DeviceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresRepository) AddPrekeys(ctx context.Context, userID int, deviceID string, prekeys []Prekey) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM device_keys WHERE user_id = $1 AND device_id = $2)",
+		userID, deviceID,
+	).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrDeviceNotFound
+	}
+
+	for _, prekey := range prekeys {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO device_one_time_prekeys (user_id, device_id, key_id, public_key)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (user_id, device_id, key_id) DO NOTHING
+        `, userID, deviceID, prekey.KeyID, prekey.PublicKey)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2",
+		userID, deviceID,
+	).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, tx.Commit()
+}
+
+func (r *postgresRepository) DeleteDevice(ctx context.Context, userID int, deviceID string) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM device_keys WHERE user_id = $1 AND device_id = $2",
+		userID, deviceID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) ClaimKeyBundles(ctx context.Context, userID int) ([]KeyBundle, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature
+        FROM device_keys
+        WHERE user_id = $1
+        ORDER BY device_id
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := []KeyBundle{}
+	for rows.Next() {
+		var bundle KeyBundle
+		if err := rows.Scan(&bundle.DeviceID, &bundle.IdentityKey, &bundle.SignedPrekeyID,
+			&bundle.SignedPrekey, &bundle.SignedPrekeySignature); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Concurrent claims skip the prekeys locked by each other, so no prekey is handed out twice
+	for i := range bundles {
+		var prekey Prekey
+		err := tx.QueryRowContext(ctx, `
+            SELECT key_id, public_key FROM device_one_time_prekeys
+            WHERE user_id = $1 AND device_id = $2
+            ORDER BY key_id
+            LIMIT 1 `+r.dialect.SkipLocked(),
+			userID, bundles[i].DeviceID,
+		).Scan(&prekey.KeyID, &prekey.PublicKey)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"DELETE FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2 AND key_id = $3",
+			userID, bundles[i].DeviceID, prekey.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		bundles[i].OneTimePrekey = &prekey
+	}
+
+	return bundles, tx.Commit()
+}
@@ -0,0 +1,163 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var testKeys = DeviceKeys{
+	DeviceID:              "phone",
+	IdentityKey:           "identity-2",
+	SignedPrekeyID:        3,
+	SignedPrekey:          "signed",
+	SignedPrekeySignature: "signature",
+}
+
+func TestSaveDeviceKeys(t *testing.T) {
+	tests := []struct {
+		name             string
+		previousIdentity *string
+		wantPrekeysReset bool
+	}{
+		{"new device", nil, false},
+		{"rotated signed prekey", strPtr("identity-2"), false},
+		{"new identity", strPtr("identity-1"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			lookup := mock.ExpectQuery(regexp.QuoteMeta("SELECT identity_key FROM device_keys WHERE user_id = $1 AND device_id = $2")).
+				WithArgs(1, "phone")
+			if tt.previousIdentity == nil {
+				lookup.WillReturnError(sql.ErrNoRows)
+			} else {
+				lookup.WillReturnRows(sqlmock.NewRows([]string{"identity_key"}).AddRow(*tt.previousIdentity))
+			}
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO device_keys (user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (user_id, device_id) DO UPDATE SET")).
+				WithArgs(1, "phone", "identity-2", 3, "signed", "signature").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if tt.wantPrekeysReset {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2")).
+					WithArgs(1, "phone").
+					WillReturnResult(sqlmock.NewResult(0, 5))
+			}
+			mock.ExpectCommit()
+
+			err := repo.SaveDeviceKeys(context.Background(), 1, testKeys)
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddPrekeys(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM device_keys WHERE user_id = $1 AND device_id = $2)")).
+		WithArgs(1, "phone").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	insert := regexp.QuoteMeta("INSERT INTO device_one_time_prekeys (user_id, device_id, key_id, public_key) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, device_id, key_id) DO NOTHING")
+	mock.ExpectExec(insert).WithArgs(1, "phone", 10, "k10").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WithArgs(1, "phone", 11, "k11").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2")).
+		WithArgs(1, "phone").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	mock.ExpectCommit()
+
+	count, err := repo.AddPrekeys(context.Background(), 1, "phone", []Prekey{{KeyID: 10, PublicKey: "k10"}, {KeyID: 11, PublicKey: "k11"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddPrekeysUnknownDevice(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM device_keys")).
+		WithArgs(1, "tablet").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+
+	_, err := repo.AddPrekeys(context.Background(), 1, "tablet", []Prekey{{KeyID: 1, PublicKey: "k1"}})
+
+	assert.ErrorIs(t, err, ErrDeviceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDevice(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("DELETE FROM device_keys WHERE user_id = $1 AND device_id = $2")
+	mock.ExpectExec(query).WithArgs(1, "phone").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(1, "tablet").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.DeleteDevice(context.Background(), 1, "phone"))
+	assert.ErrorIs(t, repo.DeleteDevice(context.Background(), 1, "tablet"), ErrDeviceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimKeyBundles(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature FROM device_keys WHERE user_id = $1 ORDER BY device_id")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"device_id", "identity_key", "signed_prekey_id", "signed_prekey", "signed_prekey_signature"}).
+			AddRow("laptop", "id-l", 1, "spk-l", "sig-l").
+			AddRow("phone", "id-p", 4, "spk-p", "sig-p"))
+
+	claim := regexp.QuoteMeta("SELECT key_id, public_key FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2 ORDER BY key_id LIMIT 1 FOR UPDATE SKIP LOCKED")
+	mock.ExpectQuery(claim).
+		WithArgs(2, "laptop").
+		WillReturnRows(sqlmock.NewRows([]string{"key_id", "public_key"}).AddRow(8, "otk-8"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM device_one_time_prekeys WHERE user_id = $1 AND device_id = $2 AND key_id = $3")).
+		WithArgs(2, "laptop", 8).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The phone ran out of one-time prekeys
+	mock.ExpectQuery(claim).
+		WithArgs(2, "phone").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectCommit()
+
+	bundles, err := repo.ClaimKeyBundles(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []KeyBundle{
+		{
+			DeviceKeys:    DeviceKeys{DeviceID: "laptop", IdentityKey: "id-l", SignedPrekeyID: 1, SignedPrekey: "spk-l", SignedPrekeySignature: "sig-l"},
+			OneTimePrekey: &Prekey{KeyID: 8, PublicKey: "otk-8"},
+		},
+		{
+			DeviceKeys: DeviceKeys{DeviceID: "phone", IdentityKey: "id-p", SignedPrekeyID: 4, SignedPrekey: "spk-p", SignedPrekeySignature: "sig-p"},
+		},
+	}, bundles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,38 @@
+package media
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// DuplicateMedia is earlier media of the user with the same content
+type DuplicateMedia struct {
+	Media
+	ModerationStatus string
+}
+
+// FindMediaByContentHash returns the earliest media of the user whose file has the
+// SHA-256 contentHash, or nil when the user has not uploaded the file before
+func (r *RepositoryImpl) FindMediaByContentHash(userID int, contentHash string) (*DuplicateMedia, error) {
+	var m DuplicateMedia
+	var variants []byte
+	err := r.db.QueryRow(`
+        SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants, moderation_status
+        FROM media
+        WHERE owner_id = $1 AND content_hash = $2
+        ORDER BY id
+        LIMIT 1`,
+		userID, contentHash,
+	).Scan(&m.ID, &m.UserID, &m.Role, &m.URL, &m.ThumbnailURL, &m.UploadedAt, &variants, &m.ModerationStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find media by content hash: %w", err)
+	}
+	if m.Variants, err = decodeVariants(variants); err != nil {
+		return nil, fmt.Errorf("failed to decode media variants: %w", err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,49 @@
+package media
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testContentHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestFindMediaByContentHash(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE owner_id = $1 AND content_hash = $2")).
+		WithArgs(1, testContentHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "variants", "moderation_status"}).
+			AddRow(42, 1, "image", "https://example.com/image.jpg", "https://example.com/thumb.jpg", now,
+				[]byte(`{"128": "https://example.com/image-128.jpg"}`), ModerationApproved))
+
+	media, err := repo.FindMediaByContentHash(1, testContentHash)
+	assert.NoError(t, err)
+	if assert.NotNil(t, media) {
+		assert.Equal(t, 42, media.ID)
+		assert.Equal(t, "https://example.com/image.jpg", media.URL)
+		assert.Equal(t, map[string]string{VariantSmall: "https://example.com/image-128.jpg"}, media.Variants)
+		assert.Equal(t, ModerationApproved, media.ModerationStatus)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindMediaByContentHashNotFound(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, owner_id").
+		WithArgs(1, testContentHash).
+		WillReturnError(sql.ErrNoRows)
+
+	media, err := repo.FindMediaByContentHash(1, testContentHash)
+	assert.NoError(t, err)
+	assert.Nil(t, media)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
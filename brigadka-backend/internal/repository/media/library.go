@@ -0,0 +1,111 @@
+package media
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LibraryItem is media in its owner's library with its moderation status, size and usages
+type LibraryItem struct {
+	Media
+	ModerationStatus string `json:"moderation_status"`
+	SizeBytes        int64  `json:"size_bytes"`
+	// Usages are the Usage* values of where the media is used; empty when it can be deleted
+	Usages []string `json:"usages"`
+}
+
+// GetStorageUsed returns the bytes of all media of the user
+func (r *RepositoryImpl) GetStorageUsed(userID int) (int64, error) {
+	var used int64
+	err := r.db.QueryRow(`SELECT COALESCE(SUM(size_bytes), 0) FROM media WHERE owner_id = $1`, userID).Scan(&used)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get storage used: %w", err)
+	}
+	return used, nil
+}
+
+// GetUserMedia returns a page of the user's media, newest first, with their usages,
+// and the total count
+func (r *RepositoryImpl) GetUserMedia(userID, limit, offset int) ([]LibraryItem, int, error) {
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM media WHERE owner_id = $1`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count user media: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+        SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants, moderation_status, size_bytes
+        FROM media
+        WHERE owner_id = $1
+        ORDER BY id DESC
+        LIMIT $2 OFFSET $3`,
+		userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user media: %w", err)
+	}
+	defer rows.Close()
+
+	items := []LibraryItem{}
+	for rows.Next() {
+		var item LibraryItem
+		var variants []byte
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Role, &item.URL, &item.ThumbnailURL, &item.UploadedAt,
+			&variants, &item.ModerationStatus, &item.SizeBytes); err != nil {
+			return nil, 0, fmt.Errorf("failed to get user media: %w", err)
+		}
+		if item.Variants, err = decodeVariants(variants); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode media variants: %w", err)
+		}
+		item.Usages = []string{}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to get user media: %w", err)
+	}
+
+	if err := r.loadUsages(items); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// loadUsages fills in the usages of a page of media with one query
+func (r *RepositoryImpl) loadUsages(items []LibraryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(items))
+	args := make([]interface{}, len(items))
+	index := make(map[int]int, len(items))
+	for i, item := range items {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = item.ID
+		index[item.ID] = i
+	}
+	ids := strings.Join(placeholders, ", ")
+
+	rows, err := r.db.Query(`
+        SELECT media_id, role FROM profile_media WHERE media_id IN (`+ids+`)
+        UNION SELECT media_id, 'message_attachment' FROM message_attachments WHERE media_id IN (`+ids+`)
+        UNION SELECT avatar_media_id, 'team_avatar' FROM teams WHERE avatar_media_id IN (`+ids+`)
+        UNION SELECT avatar_media_id, 'chat_avatar' FROM chats WHERE avatar_media_id IN (`+ids+`)
+        ORDER BY 1, 2`,
+		args...)
+	if err != nil {
+		return fmt.Errorf("failed to get media usages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mediaID int
+		var usage string
+		if err := rows.Scan(&mediaID, &usage); err != nil {
+			return fmt.Errorf("failed to get media usages: %w", err)
+		}
+		if i, ok := index[mediaID]; ok {
+			items[i].Usages = append(items[i].Usages, usage)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,57 @@
+package media
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStorageUsed(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(size_bytes), 0) FROM media WHERE owner_id = $1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(5000)))
+
+	used, err := repo.GetStorageUsed(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5000), used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserMedia(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM media WHERE owner_id = $1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY id DESC")).
+		WithArgs(1, 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "variants", "moderation_status", "size_bytes"}).
+			AddRow(43, 1, "video", "https://example.com/video.mp4", "https://example.com/thumb.jpg", now, []byte("{}"), ModerationApproved, int64(3000)).
+			AddRow(42, 1, "image", "https://example.com/image.jpg", "https://example.com/thumb.jpg", now, []byte("{}"), ModerationPending, int64(2000)))
+	// Usages of the whole page come from one query
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT media_id, role FROM profile_media WHERE media_id IN ($1, $2)")).
+		WithArgs(43, 42).
+		WillReturnRows(sqlmock.NewRows([]string{"media_id", "role"}).
+			AddRow(42, UsageAvatar).
+			AddRow(42, UsageMessageAttachment))
+
+	items, total, err := repo.GetUserMedia(1, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, total)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, 43, items[0].ID)
+		assert.Equal(t, int64(3000), items[0].SizeBytes)
+		assert.Equal(t, []string{}, items[0].Usages)
+		assert.Equal(t, ModerationPending, items[1].ModerationStatus)
+		assert.Equal(t, []string{UsageAvatar, UsageMessageAttachment}, items[1].Usages)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,93 @@
+package media
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Moderation statuses. Only approved media is shown on profiles.
+const (
+	ModerationApproved = "approved"
+	ModerationPending  = "pending"
+	ModerationRejected = "rejected"
+)
+
+// Moderation is the result of the automated check of an upload
+type Moderation struct {
+	Status   string
+	Score    *float64
+	Provider string
+}
+
+// PendingMedia is a media item waiting in the moderation queue
+type PendingMedia struct {
+	Media
+	NSFWScore *float64 `json:"nsfw_score,omitempty"`
+	Provider  *string  `json:"provider,omitempty"`
+}
+
+// CreateModeratedMedia saves media information together with the moderation result.
+// Fails with ErrQuotaExceeded when the upload does not fit into stored.QuotaBytes.
+func (r *RepositoryImpl) CreateModeratedMedia(userID int, mediaType, mediaURL, thumbnailURL string, stored StoredFile, moderation Moderation) (int, error) {
+	return r.insertMedia(userID, mediaType, mediaURL, thumbnailURL, stored, &moderation, false)
+}
+
+// GetPendingMedia returns a page of the moderation queue, oldest uploads first
+func (r *RepositoryImpl) GetPendingMedia(limit, offset int) ([]PendingMedia, int, error) {
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM media WHERE moderation_status = 'pending'`).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pending media: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+        SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, nsfw_score, moderation_provider
+        FROM media
+        WHERE moderation_status = 'pending'
+        ORDER BY uploaded_at, id
+        LIMIT $1 OFFSET $2`,
+		limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get pending media: %w", err)
+	}
+	defer rows.Close()
+
+	items := []PendingMedia{}
+	for rows.Next() {
+		var item PendingMedia
+		var score sql.NullFloat64
+		var provider sql.NullString
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Role, &item.URL, &item.ThumbnailURL, &item.UploadedAt, &score, &provider); err != nil {
+			return nil, 0, err
+		}
+		if score.Valid {
+			item.NSFWScore = &score.Float64
+		}
+		if provider.Valid {
+			item.Provider = &provider.String
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+// ReviewMedia records a moderator decision on a pending media item
+func (r *RepositoryImpl) ReviewMedia(mediaID, moderatorID int, status string) error {
+	result, err := r.db.Exec(`
+        UPDATE media
+        SET moderation_status = $2, moderated_by = $3, moderated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND moderation_status = 'pending'`,
+		mediaID, status, moderatorID)
+	if err != nil {
+		return fmt.Errorf("failed to review media: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrMediaNotFound
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package media
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateModeratedMedia(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	score := 0.93
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO media").
+		WithArgs(1, "image", "https://example.com/image.jpg", "https://example.com/thumb.jpg", testContentHash, int64(100), ModerationPending, &score, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectCommit()
+
+	mediaID, err := repo.CreateModeratedMedia(1, "image", "https://example.com/image.jpg", "https://example.com/thumb.jpg",
+		StoredFile{ContentHash: testContentHash, SizeBytes: 100},
+		Moderation{Status: ModerationPending, Score: &score, Provider: "http"})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mediaID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPendingMedia(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	uploadedAt := time.Now()
+	mock.ExpectQuery("SELECT COUNT").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("FROM media").
+		WithArgs(1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "nsfw_score", "moderation_provider"}).
+			AddRow(42, 1, "image", "https://example.com/image.jpg", "https://example.com/thumb.jpg", uploadedAt, 0.93, "http"))
+
+	items, total, err := repo.GetPendingMedia(1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, 42, items[0].ID)
+		assert.Equal(t, 0.93, *items[0].NSFWScore)
+		assert.Equal(t, "http", *items[0].Provider)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewMediaNotPending(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE media").
+		WithArgs(42, ModerationApproved, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.ReviewMedia(42, 7, ModerationApproved)
+	assert.Equal(t, ErrMediaNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,16 +2,59 @@ package media
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"strings"
 	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 )
 
 var (
 	ErrMediaNotFound = errors.New("media not found")
+	ErrMediaInUse    = errors.New("media is in use")
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// Media usages that keep it from being deleted. Profile usages are the profile_media roles.
+const (
+	UsageAvatar            = "avatar"
+	UsageVideo             = "video"
+	UsageAudioIntro        = "audio_intro"
+	UsageMessageAttachment = "message_attachment"
+	UsageTeamAvatar        = "team_avatar"
+	UsageChatAvatar        = "chat_avatar"
+)
+
+// mediaUsagesQuery lists the usages of the media $1
+const mediaUsagesQuery = `
+    SELECT role FROM profile_media WHERE media_id = $1
+    UNION SELECT 'message_attachment' FROM message_attachments WHERE media_id = $1
+    UNION SELECT 'team_avatar' FROM teams WHERE avatar_media_id = $1
+    UNION SELECT 'chat_avatar' FROM chats WHERE avatar_media_id = $1`
+
+// Image variant names. The sized variants are scaled down to fit the size in pixels;
+// the full variant is the original upload.
+const (
+	VariantSmall = "128"
+	VariantLarge = "512"
+	VariantFull  = "full"
 )
 
+// VariantSizes maps the sized image variants to their longest side in pixels
+var VariantSizes = map[string]int{
+	VariantSmall: 128,
+	VariantLarge: 512,
+}
+
+// StoredFile describes the content of a new upload
+type StoredFile struct {
+	ContentHash string // SHA-256 of the file, used to find duplicates; empty when unknown
+	SizeBytes   int64  // Of the file and the uploaded thumbnail, counted against the owner's quota
+	QuotaBytes  int64  // Storage quota of the owner the upload must fit into; 0 when unlimited
+}
+
 // Media представляет запись о медиафайле
 type Media struct {
 	ID           int       `json:"id"`
@@ -20,51 +63,185 @@ type Media struct {
 	URL          string    `json:"url"`
 	ThumbnailURL string    `json:"thumbnail_url"`
 	UploadedAt   time.Time `json:"uploaded_at"`
+	// Variants holds the URLs of the generated sizes of an image by variant name;
+	// empty until the thumbnail worker has made them
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// VariantURLs returns the URLs of an image by variant name, including the original
+// as VariantFull. Other media have no variants.
+func (m Media) VariantURLs() map[string]string {
+	if m.Role != "image" {
+		return nil
+	}
+	urls := make(map[string]string, len(m.Variants)+1)
+	for name, url := range m.Variants {
+		urls[name] = url
+	}
+	urls[VariantFull] = m.URL
+	return urls
+}
+
+// encodeVariants stores missing variants as an empty object
+func encodeVariants(variants map[string]string) ([]byte, error) {
+	if len(variants) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(variants)
+}
+
+func decodeVariants(data []byte) (map[string]string, error) {
+	var variants map[string]string
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &variants); err != nil {
+			return nil, err
+		}
+	}
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	return variants, nil
 }
 
 // RepositoryImpl implements the Repository interface
 type RepositoryImpl struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 // NewRepository creates a new MediaRepository
 func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{
-		db: db,
+		db:      db,
+		dialect: database.DialectFor(db),
 	}
 }
 
-// CreateMedia saves media information in the database
-func (r *RepositoryImpl) CreateMedia(userID int, mediaType, mediaURL, thumbnailURL string) (int, error) {
-	var mediaID int
-	err := r.db.QueryRow(
-		"INSERT INTO media (owner_id, type, url, thumbnail_url) VALUES ($1, $2, $3, $4) RETURNING id",
-		userID, mediaType, mediaURL, thumbnailURL,
-	).Scan(&mediaID)
+// CreateMedia saves media information in the database.
+// Fails with ErrQuotaExceeded when the upload does not fit into stored.QuotaBytes.
+func (r *RepositoryImpl) CreateMedia(userID int, mediaType, mediaURL, thumbnailURL string, stored StoredFile) (int, error) {
+	return r.insertMedia(userID, mediaType, mediaURL, thumbnailURL, stored, nil, false)
+}
 
+// insertMedia saves an upload, with the moderation result when it is set, and queues
+// its thumbnail job when queueJob is set. The quota is checked in the same transaction.
+func (r *RepositoryImpl) insertMedia(userID int, mediaType, mediaURL, thumbnailURL string, stored StoredFile, moderation *Moderation, queueJob bool) (int, error) {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("failed to save media info: %w", err)
 	}
+	defer tx.Rollback()
 
+	if err := r.reserveStorage(tx, userID, stored); err != nil {
+		return 0, err
+	}
+
+	var mediaID int
+	if moderation == nil {
+		err = tx.QueryRow(
+			"INSERT INTO media (owner_id, type, url, thumbnail_url, content_hash, size_bytes) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6) RETURNING id",
+			userID, mediaType, mediaURL, thumbnailURL, stored.ContentHash, stored.SizeBytes,
+		).Scan(&mediaID)
+	} else {
+		var provider *string
+		if moderation.Provider != "" {
+			provider = &moderation.Provider
+		}
+		err = tx.QueryRow(`
+        INSERT INTO media (owner_id, type, url, thumbnail_url, content_hash, size_bytes, moderation_status, nsfw_score, moderation_provider)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9) RETURNING id`,
+			userID, mediaType, mediaURL, thumbnailURL, stored.ContentHash, stored.SizeBytes, moderation.Status, moderation.Score, provider,
+		).Scan(&mediaID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to save media info: %w", err)
+	}
+
+	if queueJob {
+		if _, err := tx.Exec(`INSERT INTO media_thumbnail_jobs (media_id) VALUES ($1)`, mediaID); err != nil {
+			return 0, fmt.Errorf("failed to queue thumbnail job: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to save media info: %w", err)
+	}
 	return mediaID, nil
 }
 
-// DeleteMediaByID deletes media by its ID
+// reserveStorage fails with ErrQuotaExceeded when the upload does not fit into the quota.
+// It locks the owner's row until the transaction ends, so concurrent uploads of the user
+// are counted one after another and cannot both fit into the last free bytes.
+func (r *RepositoryImpl) reserveStorage(tx *sql.Tx, userID int, stored StoredFile) error {
+	if stored.QuotaBytes <= 0 {
+		return nil
+	}
+
+	var id int
+	if err := tx.QueryRow(`SELECT id FROM users WHERE id = $1 `+r.dialect.ForUpdate(), userID).Scan(&id); err != nil {
+		return fmt.Errorf("failed to lock storage of user: %w", err)
+	}
+	var used int64
+	if err := tx.QueryRow(`SELECT COALESCE(SUM(size_bytes), 0) FROM media WHERE owner_id = $1`, userID).Scan(&used); err != nil {
+		return fmt.Errorf("failed to get storage used: %w", err)
+	}
+	if used+stored.SizeBytes > stored.QuotaBytes {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// DeleteMedia deletes media of the user unless it is in use. It fails with ErrMediaInUse
+// when the media is used and ErrMediaNotFound when the user has no such media.
 func (r *RepositoryImpl) DeleteMedia(userID, mediaID int) error {
-	_, err := r.db.Exec("DELETE FROM media WHERE id = $1 AND owner_id = $2", mediaID, userID)
+	result, err := r.db.Exec(
+		"DELETE FROM media WHERE id = $1 AND owner_id = $2 AND NOT EXISTS ("+mediaUsagesQuery+")",
+		mediaID, userID,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to delete media from DB: %w", err)
 	}
-	return nil
+	if rows, err := result.RowsAffected(); err != nil || rows > 0 {
+		return err
+	}
+
+	var exists bool
+	err = r.db.QueryRow("SELECT EXISTS (SELECT 1 FROM media WHERE id = $1 AND owner_id = $2)", mediaID, userID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to delete media from DB: %w", err)
+	}
+	if exists {
+		return ErrMediaInUse
+	}
+	return ErrMediaNotFound
+}
+
+// GetMediaUsages returns where the media is used, sorted; none when it is unused
+func (r *RepositoryImpl) GetMediaUsages(mediaID int) ([]string, error) {
+	rows, err := r.db.Query(mediaUsagesQuery+" ORDER BY 1", mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media usages: %w", err)
+	}
+	defer rows.Close()
+
+	usages := []string{}
+	for rows.Next() {
+		var usage string
+		if err := rows.Scan(&usage); err != nil {
+			return nil, fmt.Errorf("failed to get media usages: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+	return usages, rows.Err()
 }
 
 // GetMediaByID retrieves media by its ID
 func (r *RepositoryImpl) GetMediaByID(mediaID int) (*Media, error) {
 	var m Media
+	var variants []byte
 	err := r.db.QueryRow(
-		"SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media WHERE id = $1",
+		"SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media WHERE id = $1",
 		mediaID,
-	).Scan(&m.ID, &m.UserID, &m.Role, &m.URL, &m.ThumbnailURL, &m.UploadedAt)
+	).Scan(&m.ID, &m.UserID, &m.Role, &m.URL, &m.ThumbnailURL, &m.UploadedAt, &variants)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -72,23 +249,57 @@ func (r *RepositoryImpl) GetMediaByID(mediaID int) (*Media, error) {
 		}
 		return nil, fmt.Errorf("failed to get media from DB: %w", err)
 	}
+	if m.Variants, err = decodeVariants(variants); err != nil {
+		return nil, fmt.Errorf("failed to decode media variants: %w", err)
+	}
 
 	return &m, nil
 }
 
-// GetMediaByID retrieves media by its ID
+// GetMediaByIDs retrieves media by their IDs with one query, in the order of the IDs.
+// IDs without media are skipped.
 func (r *RepositoryImpl) GetMediaByIDs(mediaIDs []int) ([]Media, error) {
 	if len(mediaIDs) == 0 {
 		return nil, nil
 	}
+
+	placeholders := make([]string, len(mediaIDs))
+	args := make([]interface{}, len(mediaIDs))
+	for i, id := range mediaIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	rows, err := r.db.Query(
+		"SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media WHERE id IN ("+strings.Join(placeholders, ", ")+")",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media from DB: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[int]Media, len(mediaIDs))
+	for rows.Next() {
+		var m Media
+		var variants []byte
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Role, &m.URL, &m.ThumbnailURL, &m.UploadedAt, &variants); err != nil {
+			return nil, fmt.Errorf("failed to get media from DB: %w", err)
+		}
+		if m.Variants, err = decodeVariants(variants); err != nil {
+			return nil, fmt.Errorf("failed to decode media variants: %w", err)
+		}
+		found[m.ID] = m
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get media from DB: %w", err)
+	}
+
 	result := make([]Media, 0, len(mediaIDs))
 	for _, id := range mediaIDs {
-		media, err := r.GetMediaByID(id)
-		if err != nil {
-			log.Printf("failed to get media by ID %d: %v", id, err)
-			continue
+		if m, ok := found[id]; ok {
+			result = append(result, m)
 		}
-		result = append(result, *media)
 	}
 	return result, nil
 }
@@ -3,6 +3,7 @@ package media
 import (
 	"database/sql"
 	"errors"
+	"regexp"
 	"testing"
 	"time"
 
@@ -27,14 +28,17 @@ func TestCreateMedia(t *testing.T) {
 	mediaType := "image"
 	mediaURL := "https://example.com/image.jpg"
 	thumbnailURL := "https://example.com/thumbnail.jpg"
+	stored := StoredFile{ContentHash: testContentHash, SizeBytes: 2048}
 	expectedID := 42
 
 	rows := sqlmock.NewRows([]string{"id"}).AddRow(expectedID)
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO media").
-		WithArgs(userID, mediaType, mediaURL, thumbnailURL).
+		WithArgs(userID, mediaType, mediaURL, thumbnailURL, stored.ContentHash, stored.SizeBytes).
 		WillReturnRows(rows)
+	mock.ExpectCommit()
 
-	mediaID, err := repo.CreateMedia(userID, mediaType, mediaURL, thumbnailURL)
+	mediaID, err := repo.CreateMedia(userID, mediaType, mediaURL, thumbnailURL, stored)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedID, mediaID)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -49,16 +53,58 @@ func TestCreateMediaError(t *testing.T) {
 	mediaURL := "https://example.com/image.jpg"
 	thumbnailURL := "https://example.com/thumbnail.jpg"
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO media").
-		WithArgs(userID, mediaType, mediaURL, thumbnailURL).
+		WithArgs(userID, mediaType, mediaURL, thumbnailURL, "", int64(0)).
 		WillReturnError(errors.New("database error"))
+	mock.ExpectRollback()
 
-	mediaID, err := repo.CreateMedia(userID, mediaType, mediaURL, thumbnailURL)
+	mediaID, err := repo.CreateMedia(userID, mediaType, mediaURL, thumbnailURL, StoredFile{})
 	assert.Error(t, err)
 	assert.Equal(t, 0, mediaID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCreateMediaWithinQuota(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM users WHERE id = $1 FOR UPDATE`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(size_bytes), 0) FROM media WHERE owner_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(900)))
+	mock.ExpectQuery("INSERT INTO media").
+		WithArgs(1, "image", "https://example.com/image.jpg", "", "", int64(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectCommit()
+
+	mediaID, err := repo.CreateMedia(1, "image", "https://example.com/image.jpg", "", StoredFile{SizeBytes: 100, QuotaBytes: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mediaID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMediaQuotaExceeded(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM users WHERE id = $1 FOR UPDATE`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(size_bytes), 0) FROM media WHERE owner_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(901)))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateMedia(1, "image", "https://example.com/image.jpg", "", StoredFile{SizeBytes: 100, QuotaBytes: 1000})
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteMedia(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
@@ -75,6 +121,52 @@ func TestDeleteMedia(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDeleteMediaInUse(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM media WHERE id = $1 AND owner_id = $2 AND NOT EXISTS (")).
+		WithArgs(42, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM media WHERE id = $1 AND owner_id = $2)")).
+		WithArgs(42, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	err := repo.DeleteMedia(1, 42)
+	assert.ErrorIs(t, err, ErrMediaInUse)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMediaNotFound(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM media").
+		WithArgs(42, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS")).
+		WithArgs(42, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	err := repo.DeleteMedia(1, 42)
+	assert.ErrorIs(t, err, ErrMediaNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMediaUsages(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("UNION SELECT 'message_attachment' FROM message_attachments WHERE media_id = $1")).
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(UsageAvatar).AddRow(UsageMessageAttachment))
+
+	usages, err := repo.GetMediaUsages(42)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{UsageAvatar, UsageMessageAttachment}, usages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteMediaError(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
@@ -104,12 +196,17 @@ func TestGetMediaByID(t *testing.T) {
 		URL:          "https://example.com/image.jpg",
 		ThumbnailURL: "https://example.com/thumbnail.jpg",
 		UploadedAt:   now,
+		Variants: map[string]string{
+			VariantSmall: "https://example.com/image-128.jpg",
+			VariantLarge: "https://example.com/image-512.jpg",
+		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at"}).
-		AddRow(expectedMedia.ID, expectedMedia.UserID, expectedMedia.Role, expectedMedia.URL, expectedMedia.ThumbnailURL, expectedMedia.UploadedAt)
+	rows := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "variants"}).
+		AddRow(expectedMedia.ID, expectedMedia.UserID, expectedMedia.Role, expectedMedia.URL, expectedMedia.ThumbnailURL, expectedMedia.UploadedAt,
+			[]byte(`{"128": "https://example.com/image-128.jpg", "512": "https://example.com/image-512.jpg"}`))
 
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
+	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media").
 		WithArgs(mediaID).
 		WillReturnRows(rows)
 
@@ -125,7 +222,7 @@ func TestGetMediaByIDNotFound(t *testing.T) {
 
 	mediaID := 42
 
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
+	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media").
 		WithArgs(mediaID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -142,7 +239,7 @@ func TestGetMediaByIDError(t *testing.T) {
 
 	mediaID := 42
 
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
+	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media").
 		WithArgs(mediaID).
 		WillReturnError(errors.New("database error"))
 
@@ -157,7 +254,7 @@ func TestGetMediaByIDs(t *testing.T) {
 	defer db.Close()
 
 	now := time.Now()
-	mediaIDs := []int{1, 2}
+	mediaIDs := []int{2, 1}
 
 	expectedMedia1 := Media{
 		ID:           1,
@@ -177,27 +274,21 @@ func TestGetMediaByIDs(t *testing.T) {
 		UploadedAt:   now,
 	}
 
-	// For first media
-	rows1 := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at"}).
-		AddRow(expectedMedia1.ID, expectedMedia1.UserID, expectedMedia1.Role, expectedMedia1.URL, expectedMedia1.ThumbnailURL, expectedMedia1.UploadedAt)
+	// Both media are fetched with one query, in any order
+	rows := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "variants"}).
+		AddRow(expectedMedia1.ID, expectedMedia1.UserID, expectedMedia1.Role, expectedMedia1.URL, expectedMedia1.ThumbnailURL, expectedMedia1.UploadedAt, []byte("{}")).
+		AddRow(expectedMedia2.ID, expectedMedia2.UserID, expectedMedia2.Role, expectedMedia2.URL, expectedMedia2.ThumbnailURL, expectedMedia2.UploadedAt, []byte("{}"))
 
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
-		WithArgs(1).
-		WillReturnRows(rows1)
-
-	// For second media
-	rows2 := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at"}).
-		AddRow(expectedMedia2.ID, expectedMedia2.UserID, expectedMedia2.Role, expectedMedia2.URL, expectedMedia2.ThumbnailURL, expectedMedia2.UploadedAt)
-
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
-		WithArgs(2).
-		WillReturnRows(rows2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media WHERE id IN ($1, $2)")).
+		WithArgs(2, 1).
+		WillReturnRows(rows)
 
 	media, err := repo.GetMediaByIDs(mediaIDs)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(media))
-	assert.Equal(t, expectedMedia1, media[0])
-	assert.Equal(t, expectedMedia2, media[1])
+	// Results follow the order of the requested IDs
+	assert.Equal(t, expectedMedia2, media[0])
+	assert.Equal(t, expectedMedia1, media[1])
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -213,13 +304,13 @@ func TestGetMediaByIDsEmpty(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetMediaByIDsWithError(t *testing.T) {
+func TestGetMediaByIDsMissing(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
 
 	mediaIDs := []int{1, 2}
 
-	// First media found
+	// Only the first media exists
 	now := time.Now()
 	expectedMedia1 := Media{
 		ID:           1,
@@ -230,17 +321,12 @@ func TestGetMediaByIDsWithError(t *testing.T) {
 		UploadedAt:   now,
 	}
 
-	rows1 := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at"}).
-		AddRow(expectedMedia1.ID, expectedMedia1.UserID, expectedMedia1.Role, expectedMedia1.URL, expectedMedia1.ThumbnailURL, expectedMedia1.UploadedAt)
-
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
-		WithArgs(1).
-		WillReturnRows(rows1)
+	rows := sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "variants"}).
+		AddRow(expectedMedia1.ID, expectedMedia1.UserID, expectedMedia1.Role, expectedMedia1.URL, expectedMedia1.ThumbnailURL, expectedMedia1.UploadedAt, []byte("{}"))
 
-	// Second media returns error
-	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at FROM media").
-		WithArgs(2).
-		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media").
+		WithArgs(1, 2).
+		WillReturnRows(rows)
 
 	media, err := repo.GetMediaByIDs(mediaIDs)
 	assert.NoError(t, err)
@@ -248,3 +334,35 @@ func TestGetMediaByIDsWithError(t *testing.T) {
 	assert.Equal(t, expectedMedia1, media[0])
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetMediaByIDsWithError(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, owner_id, type, url, thumbnail_url, uploaded_at, variants FROM media").
+		WithArgs(1, 2).
+		WillReturnError(sql.ErrConnDone)
+
+	media, err := repo.GetMediaByIDs([]int{1, 2})
+	assert.Error(t, err)
+	assert.Nil(t, media)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaVariantURLs(t *testing.T) {
+	image := Media{
+		Role:     "image",
+		URL:      "https://example.com/image.jpg",
+		Variants: map[string]string{VariantSmall: "https://example.com/image-128.jpg"},
+	}
+	assert.Equal(t, map[string]string{
+		VariantSmall: "https://example.com/image-128.jpg",
+		VariantFull:  "https://example.com/image.jpg",
+	}, image.VariantURLs())
+
+	// Before the variants are generated only the original is available
+	assert.Equal(t, map[string]string{VariantFull: "https://example.com/image.jpg"},
+		Media{Role: "image", URL: "https://example.com/image.jpg"}.VariantURLs())
+
+	assert.Nil(t, Media{Role: "video", URL: "https://example.com/video.mp4"}.VariantURLs())
+}
@@ -0,0 +1,134 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ThumbnailJob is an upload waiting for a thumbnail or, for images, variants generated by the server
+type ThumbnailJob struct {
+	Media
+	Attempts int // Including the attempt the job was claimed for
+}
+
+// CreateMediaWithThumbnailJob saves an upload together with the moderation result and
+// queues the generation of its thumbnail, when thumbnailURL is empty, and image variants.
+// Fails with ErrQuotaExceeded when the upload does not fit into stored.QuotaBytes.
+func (r *RepositoryImpl) CreateMediaWithThumbnailJob(userID int, mediaType, mediaURL, thumbnailURL string, stored StoredFile, moderation Moderation) (int, error) {
+	return r.insertMedia(userID, mediaType, mediaURL, thumbnailURL, stored, &moderation, true)
+}
+
+// ClaimThumbnailJobs returns up to limit jobs due at now and counts an attempt for each.
+// They are not due again until leaseUntil, so a job whose worker stopped mid-attempt is
+// retried then. Concurrent workers claim different jobs.
+func (r *RepositoryImpl) ClaimThumbnailJobs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]ThumbnailJob, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim thumbnail jobs: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT m.id, m.owner_id, m.type, m.url, m.thumbnail_url, m.uploaded_at, j.attempts
+        FROM media_thumbnail_jobs j
+        JOIN media m ON m.id = j.media_id
+        WHERE j.failed_at IS NULL AND j.next_attempt_at <= $1
+        ORDER BY j.next_attempt_at, j.media_id
+        LIMIT $2 `+r.dialect.SkipLocked(), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim thumbnail jobs: %w", err)
+	}
+
+	jobs := []ThumbnailJob{}
+	for rows.Next() {
+		var job ThumbnailJob
+		if err := rows.Scan(&job.ID, &job.UserID, &job.Role, &job.URL, &job.ThumbnailURL, &job.UploadedAt, &job.Attempts); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		job.Attempts++
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	args := []interface{}{leaseUntil}
+	placeholders := make([]string, len(jobs))
+	for i, job := range jobs {
+		args = append(args, job.ID)
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE media_thumbnail_jobs SET attempts = attempts + 1, next_attempt_at = $1
+        WHERE media_id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim thumbnail jobs: %w", err)
+	}
+
+	return jobs, tx.Commit()
+}
+
+// CompleteThumbnailJob stores the thumbnail and image variants and removes the job.
+// A moderation result replaces the one stored with the upload, which was made without
+// a picture, unless a moderator has reviewed the media in the meantime.
+func (r *RepositoryImpl) CompleteThumbnailJob(ctx context.Context, mediaID int, thumbnailURL string, variants map[string]string, moderation *Moderation) error {
+	encoded, err := encodeVariants(variants)
+	if err != nil {
+		return fmt.Errorf("failed to encode media variants: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete thumbnail job: %w", err)
+	}
+	defer tx.Rollback()
+
+	if moderation == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE media SET thumbnail_url = $2, variants = $3 WHERE id = $1`,
+			mediaID, thumbnailURL, encoded)
+	} else {
+		var provider *string
+		if moderation.Provider != "" {
+			provider = &moderation.Provider
+		}
+		_, err = tx.ExecContext(ctx, `
+            UPDATE media SET thumbnail_url = $2, variants = $3,
+                moderation_status = CASE WHEN moderated_by IS NULL THEN $4 ELSE moderation_status END,
+                nsfw_score = $5, moderation_provider = $6
+            WHERE id = $1`,
+			mediaID, thumbnailURL, encoded, moderation.Status, moderation.Score, provider)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to complete thumbnail job: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM media_thumbnail_jobs WHERE media_id = $1`, mediaID); err != nil {
+		return fmt.Errorf("failed to complete thumbnail job: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to complete thumbnail job: %w", err)
+	}
+	return nil
+}
+
+// RetryThumbnailJob schedules another attempt of a job that failed
+func (r *RepositoryImpl) RetryThumbnailJob(ctx context.Context, mediaID int, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE media_thumbnail_jobs SET next_attempt_at = $2, last_error = $3
+        WHERE media_id = $1`, mediaID, nextAttemptAt, lastError)
+	return err
+}
+
+// FailThumbnailJob stops a job that ran out of attempts; the media keeps an empty thumbnail
+func (r *RepositoryImpl) FailThumbnailJob(ctx context.Context, mediaID int, failedAt time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE media_thumbnail_jobs SET failed_at = $2, last_error = $3
+        WHERE media_id = $1`, mediaID, failedAt, lastError)
+	return err
+}
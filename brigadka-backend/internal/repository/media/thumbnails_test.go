@@ -0,0 +1,116 @@
+package media
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMediaWithThumbnailJob(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO media (owner_id, type, url, thumbnail_url`)).
+		WithArgs(1, "video", "https://example.com/video.mp4", "", "", int64(0), ModerationPending, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO media_thumbnail_jobs (media_id) VALUES ($1)`)).
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mediaID, err := repo.CreateMediaWithThumbnailJob(1, "video", "https://example.com/video.mp4", "", StoredFile{},
+		Moderation{Status: ModerationPending, Provider: "http"})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mediaID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimThumbnailJobs(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(5 * time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "type", "url", "thumbnail_url", "uploaded_at", "attempts"}).
+			AddRow(42, 1, "image", "https://example.com/image.jpg", "", now, 0).
+			AddRow(43, 2, "video", "https://example.com/video.mp4", "", now, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE media_thumbnail_jobs SET attempts = attempts + 1, next_attempt_at = $1
+        WHERE media_id IN ($2, $3)`)).
+		WithArgs(leaseUntil, 42, 43).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	jobs, err := repo.ClaimThumbnailJobs(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, 42, jobs[0].ID)
+		assert.Equal(t, "image", jobs[0].Role)
+		assert.Equal(t, 1, jobs[0].Attempts)
+		assert.Equal(t, "video", jobs[1].Role)
+		assert.Equal(t, 3, jobs[1].Attempts)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteThumbnailJob(t *testing.T) {
+	t.Run("without moderation", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE media SET thumbnail_url = $2, variants = $3 WHERE id = $1`)).
+			WithArgs(42, "https://example.com/thumb.jpg", []byte(`{"128":"https://example.com/128.jpg"}`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM media_thumbnail_jobs WHERE media_id = $1`)).
+			WithArgs(42).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.CompleteThumbnailJob(context.Background(), 42, "https://example.com/thumb.jpg",
+			map[string]string{VariantSmall: "https://example.com/128.jpg"}, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("with moderation", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		score := 0.1
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`moderation_status = CASE WHEN moderated_by IS NULL THEN $4 ELSE moderation_status END`)).
+			WithArgs(42, "https://example.com/thumb.jpg", []byte("{}"), ModerationApproved, &score, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM media_thumbnail_jobs WHERE media_id = $1`)).
+			WithArgs(42).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.CompleteThumbnailJob(context.Background(), 42, "https://example.com/thumb.jpg", nil,
+			&Moderation{Status: ModerationApproved, Score: &score, Provider: "http"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFailThumbnailJob(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	failedAt := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE media_thumbnail_jobs SET failed_at = $2, last_error = $3`)).
+		WithArgs(42, failedAt, "ffmpeg failed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.FailThumbnailJob(context.Background(), 42, failedAt, "ffmpeg failed")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
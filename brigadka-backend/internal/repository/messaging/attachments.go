@@ -0,0 +1,88 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Attachment is a photo or video attached to a message
+type Attachment struct {
+	MediaID      int    `json:"media_id"`
+	Type         string `json:"type"` // image or video
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// AddMessageWithAttachments adds a message together with its attachments, in the given order,
+// and returns the sent time and seq
+func (r *MessagingRepositoryImpl) AddMessageWithAttachments(messageID string, chatID string, senderID int, content string, mediaIDs []int) (time.Time, int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	defer tx.Rollback()
+
+	var sentAt time.Time
+	var seq int64
+	err = tx.QueryRow(
+		"INSERT INTO messages (id, chat_id, sender_id, content) VALUES ($1, $2, $3, $4) RETURNING sent_at, seq",
+		messageID, chatID, senderID, content,
+	).Scan(&sentAt, &seq)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	for position, mediaID := range mediaIDs {
+		_, err = tx.Exec(
+			"INSERT INTO message_attachments (message_id, media_id, position) VALUES ($1, $2, $3)",
+			messageID, mediaID, position,
+		)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, 0, err
+	}
+	return sentAt, seq, nil
+}
+
+// GetMessageAttachments returns the attachments of the messages by message ID.
+// Media waiting for moderation or rejected are left out, as on profiles.
+func (r *MessagingRepositoryImpl) GetMessageAttachments(messageIDs []string) (map[string][]Attachment, error) {
+	attachments := make(map[string][]Attachment)
+	if len(messageIDs) == 0 {
+		return attachments, nil
+	}
+
+	placeholders := make([]string, len(messageIDs))
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	rows, err := r.db.Query(`
+        SELECT ma.message_id, m.id, m.type, m.url, m.thumbnail_url
+        FROM message_attachments ma
+        JOIN media m ON m.id = ma.media_id
+        WHERE ma.message_id IN (`+strings.Join(placeholders, ", ")+`) AND m.moderation_status = 'approved'
+        ORDER BY ma.message_id, ma.position
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var attachment Attachment
+		if err := rows.Scan(&messageID, &attachment.MediaID, &attachment.Type, &attachment.URL, &attachment.ThumbnailURL); err != nil {
+			return nil, err
+		}
+		attachments[messageID] = append(attachments[messageID], attachment)
+	}
+	return attachments, rows.Err()
+}
@@ -0,0 +1,54 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GetUserRole returns the role of a user, or an empty string when the user does not exist
+func (r *MessagingRepositoryImpl) GetUserRole(ctx context.Context, userID int) (string, error) {
+	var role string
+	err := r.db.QueryRowContext(ctx, "SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// GetUsersClosedToOrganizers returns the users among userIDs who have not allowed contact
+// from organizers. Users without a profile have not allowed it.
+func (r *MessagingRepositoryImpl) GetUsersClosedToOrganizers(ctx context.Context, userIDs []int) ([]int, error) {
+	if len(userIDs) == 0 {
+		return []int{}, nil
+	}
+
+	args := make([]interface{}, len(userIDs))
+	placeholders := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		args[i] = id
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT u.id FROM users u
+        LEFT JOIN profiles p ON p.user_id = u.id
+        WHERE u.id IN (`+strings.Join(placeholders, ", ")+`)
+          AND NOT COALESCE(p.allow_organizer_contact, FALSE)
+        ORDER BY u.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closed := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		closed = append(closed, id)
+	}
+	return closed, rows.Err()
+}
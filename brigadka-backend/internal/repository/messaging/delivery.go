@@ -0,0 +1,96 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+)
+
+// Delivery statuses of a message for its sender in a direct chat
+const (
+	MessageStatusSent      = "sent"      // Stored, not yet received by the recipient's devices
+	MessageStatusDelivered = "delivered" // A device of the recipient received it
+	MessageStatusRead      = "read"      // The recipient read it
+)
+
+// DeliveryState is how far the other participant of a direct chat has received and read it
+type DeliveryState struct {
+	LastDeliveredSeq int64
+	LastReadSeq      int64
+}
+
+// Status returns the status of the message with seq. Read messages count as delivered,
+// so a read receipt from a device that never acked still marks them read.
+func (d DeliveryState) Status(seq int64) string {
+	switch {
+	case seq <= d.LastReadSeq:
+		return MessageStatusRead
+	case seq <= d.LastDeliveredSeq:
+		return MessageStatusDelivered
+	default:
+		return MessageStatusSent
+	}
+}
+
+// StoreDeliveryReceipt moves the user's delivery position in a direct chat to the message.
+// Like read positions, delivery positions only move forward. It reports whether the
+// position moved; receipts in group chats are not tracked and never move it.
+func (r *MessagingRepositoryImpl) StoreDeliveryReceipt(ctx context.Context, userID int, chatID string, messageID string) (bool, error) {
+	var seq int64
+	var isGroup bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT m.seq, c.is_group
+        FROM messages m
+        JOIN chats c ON c.id = m.chat_id
+        WHERE m.id = $1 AND m.chat_id = $2
+    `, messageID, chatID).Scan(&seq, &isGroup)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, errors.New(apierrors.ErrorMessageNotFound)
+	}
+	if err != nil {
+		return false, err
+	}
+	if isGroup {
+		return false, nil
+	}
+
+	now := r.dialect.Now()
+	result, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO message_delivery_receipts (user_id, chat_id, last_delivered_seq, delivered_at)
+        VALUES ($1, $2, $3, %s)
+        %s
+        WHERE message_delivery_receipts.last_delivered_seq < excluded.last_delivered_seq
+    `, now, r.dialect.OnConflictUpdate("user_id, chat_id", "last_delivered_seq = excluded.last_delivered_seq, delivered_at = "+now)), userID, chatID, seq)
+	if err != nil {
+		return false, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return moved > 0, nil
+}
+
+// GetDeliveryState returns how far the participant of a direct chat other than userID
+// has received and read it, or nil for group chats
+func (r *MessagingRepositoryImpl) GetDeliveryState(ctx context.Context, chatID string, userID int) (*DeliveryState, error) {
+	var state DeliveryState
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COALESCE(dr.last_delivered_seq, 0), COALESCE(rr.last_read_seq, 0)
+        FROM chats c
+        JOIN chat_participants cp ON cp.chat_id = c.id AND cp.user_id <> $2
+        LEFT JOIN message_delivery_receipts dr ON dr.chat_id = c.id AND dr.user_id = cp.user_id
+        LEFT JOIN message_read_receipts rr ON rr.chat_id = c.id AND rr.user_id = cp.user_id
+        WHERE c.id = $1 AND NOT c.is_group
+    `, chatID, userID).Scan(&state.LastDeliveredSeq, &state.LastReadSeq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,19 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// AddEncryptedMessage stores an end-to-end encrypted message with an empty content
+// and returns the sent time and seq. The ciphertext is stored and forwarded as is.
+func (r *MessagingRepositoryImpl) AddEncryptedMessage(ctx context.Context, messageID string, chatID string, senderID int, ciphertext string) (time.Time, int64, error) {
+	var sentAt time.Time
+	var seq int64
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO messages (id, chat_id, sender_id, content, ciphertext)
+        VALUES ($1, $2, $3, '', $4)
+        RETURNING sent_at, seq
+    `, messageID, chatID, senderID, ciphertext).Scan(&sentAt, &seq)
+	return sentAt, seq, err
+}
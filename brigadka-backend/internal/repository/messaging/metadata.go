@@ -0,0 +1,48 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ChatUpdate holds the chat fields to change; nil fields are kept
+type ChatUpdate struct {
+	Name          *string
+	Description   *string // Empty removes the description
+	AvatarMediaID *int    // Zero removes the avatar
+}
+
+// UpdateChat changes the name, description and avatar of a chat
+func (r *MessagingRepositoryImpl) UpdateChat(ctx context.Context, chatID string, update ChatUpdate) error {
+	sets := []string{}
+	args := []interface{}{chatID}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		set("chat_name", *update.Name)
+	}
+	if update.Description != nil {
+		if *update.Description == "" {
+			set("description", nil)
+		} else {
+			set("description", *update.Description)
+		}
+	}
+	if update.AvatarMediaID != nil {
+		if *update.AvatarMediaID == 0 {
+			set("avatar_media_id", nil)
+		} else {
+			set("avatar_media_id", *update.AvatarMediaID)
+		}
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, "UPDATE chats SET "+strings.Join(sets, ", ")+" WHERE id = $1", args...)
+	return err
+}
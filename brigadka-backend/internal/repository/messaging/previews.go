@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LinkPreview is the card of the first link in a message, built from the page's OpenGraph metadata
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// SaveMessagePreview stores the link preview of a message; a message keeps its first preview
+func (r *MessagingRepositoryImpl) SaveMessagePreview(ctx context.Context, messageID string, preview LinkPreview) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO message_previews (message_id, url, title, description, image_url, site_name)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (message_id) DO NOTHING
+    `, messageID, preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName)
+	return err
+}
+
+// GetMessagePreviews returns the link previews of the messages by message ID
+func (r *MessagingRepositoryImpl) GetMessagePreviews(messageIDs []string) (map[string]LinkPreview, error) {
+	previews := make(map[string]LinkPreview)
+	if len(messageIDs) == 0 {
+		return previews, nil
+	}
+
+	placeholders := make([]string, len(messageIDs))
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	rows, err := r.db.Query(`
+        SELECT message_id, url, title, description, image_url, site_name
+        FROM message_previews
+        WHERE message_id IN (`+strings.Join(placeholders, ", ")+`)
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var preview LinkPreview
+		if err := rows.Scan(&messageID, &preview.URL, &preview.Title, &preview.Description, &preview.ImageURL, &preview.SiteName); err != nil {
+			return nil, err
+		}
+		previews[messageID] = preview
+	}
+	return previews, rows.Err()
+}
@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReactionSampleSize is the number of users listed in a reaction summary; the
+// detail sheet loads the rest with GetMessageReactions
+const ReactionSampleSize = 3
+
+// ReactionSummary aggregates the reactions with one code to a message
+type ReactionSummary struct {
+	Code    string `json:"code"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"`  // Whether the requesting user is among them
+	UserIDs []int  `json:"user_ids"` // The first users who reacted, up to ReactionSampleSize
+}
+
+// Reaction is one user's reaction to a message
+type Reaction struct {
+	UserID       int       `json:"user_id"`
+	ReactionCode string    `json:"reaction_code"`
+	ReactedAt    time.Time `json:"reacted_at"`
+}
+
+// GetReactionSummaries aggregates the reactions to the messages with one query.
+// Summaries of a message are ordered by their first reaction.
+func (r *MessagingRepositoryImpl) GetReactionSummaries(ctx context.Context, messageIDs []string, userID int) (map[string][]ReactionSummary, error) {
+	summaries := make(map[string][]ReactionSummary)
+	if len(messageIDs) == 0 {
+		return summaries, nil
+	}
+
+	placeholders := make([]string, len(messageIDs))
+	args := make([]interface{}, 0, len(messageIDs)+2)
+	args = append(args, userID, ReactionSampleSize)
+	for i, id := range messageIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+3)
+		args = append(args, id)
+	}
+
+	// Every reaction is ranked within its message and code; only the first few and
+	// the requesting user's own are returned, each carrying the total count
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT message_id, reaction_code, user_id, total, rn
+        FROM (
+            SELECT mr.message_id, mr.reaction_code, mr.user_id,
+                   COUNT(*) OVER (PARTITION BY mr.message_id, mr.reaction_code) AS total,
+                   MIN(mr.reacted_at) OVER (PARTITION BY mr.message_id, mr.reaction_code) AS first_reacted_at,
+                   ROW_NUMBER() OVER (PARTITION BY mr.message_id, mr.reaction_code ORDER BY mr.reacted_at, mr.user_id) AS rn
+            FROM message_reactions mr
+            WHERE mr.message_id IN (`+strings.Join(placeholders, ", ")+`)
+        ) ranked
+        WHERE rn <= $2 OR user_id = $1
+        ORDER BY message_id, first_reacted_at, reaction_code, rn
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID, code string
+		var reactorID, total, rank int
+		if err := rows.Scan(&messageID, &code, &reactorID, &total, &rank); err != nil {
+			return nil, err
+		}
+
+		list := summaries[messageID]
+		if len(list) == 0 || list[len(list)-1].Code != code {
+			list = append(list, ReactionSummary{Code: code, Count: total, UserIDs: []int{}})
+		}
+		summary := &list[len(list)-1]
+		if rank <= ReactionSampleSize {
+			summary.UserIDs = append(summary.UserIDs, reactorID)
+		}
+		if reactorID == userID {
+			summary.Reacted = true
+		}
+		summaries[messageID] = list
+	}
+	return summaries, rows.Err()
+}
+
+// GetMessageReactions returns all reactions to a message, oldest first
+func (r *MessagingRepositoryImpl) GetMessageReactions(ctx context.Context, messageID string) ([]Reaction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT user_id, reaction_code, reacted_at
+        FROM message_reactions
+        WHERE message_id = $1
+        ORDER BY reacted_at, user_id
+    `, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := []Reaction{}
+	for rows.Next() {
+		var reaction Reaction
+		if err := rows.Scan(&reaction.UserID, &reaction.ReactionCode, &reaction.ReactedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, rows.Err()
+}
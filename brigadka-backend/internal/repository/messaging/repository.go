@@ -4,70 +4,269 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
 	"github.com/google/uuid"
 )
 
 // Chat message structure
 type ChatMessage struct {
-	MessageID string    `json:"message_id"`
-	ChatID    string    `json:"chat_id"`
-	SenderID  int       `json:"sender_id"`
-	Content   string    `json:"content"`
-	SentAt    time.Time `json:"sent_at"`
+	MessageID string `json:"message_id"`
+	ChatID    string `json:"chat_id"`
+	SenderID  int    `json:"sender_id"`
+	Content   string `json:"content"`
+	// Set instead of Content in end-to-end encrypted direct chats; the server cannot read it
+	Ciphertext  string       `json:"ciphertext,omitempty"`
+	SentAt      time.Time    `json:"sent_at"`
+	Seq         int64        `json:"seq"` // Increases with every message, used as the pagination cursor
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Preview     *LinkPreview `json:"preview,omitempty"` // Set once the link in the message has been fetched
+	Kind        string       `json:"kind"`              // MessageKindUser or MessageKindSystem
+	// System messages tell what changed and whom it concerns; Content holds a readable fallback
+	Event        string `json:"event,omitempty"`
+	TargetUserID *int   `json:"target_user_id,omitempty"`
+	// MessageStatusSent, MessageStatusDelivered or MessageStatusRead; set only for
+	// the requesting user's own messages in direct chats
+	Status    string            `json:"status,omitempty"`
+	Reactions []ReactionSummary `json:"reactions,omitempty"`
+}
+
+// MessageCursor selects chat messages by seq; zero fields are not applied
+type MessageCursor struct {
+	BeforeSeq int64 // Only messages older than this seq
+	AfterSeq  int64 // Only messages newer than this seq
+}
+
+// MessagePage is a page of chat messages, newest first, with the cursors of the neighbouring pages
+type MessagePage struct {
+	Messages  []ChatMessage
+	BeforeSeq *int64 // Cursor for older messages; nil when there are none
+	AfterSeq  *int64 // Cursor for newer messages, the newest seq seen; nil when the chat has no messages yet
 }
 
 // Chat structure
 type Chat struct {
-	ChatID       string    `json:"chat_id"`
-	ChatName     *string   `json:"chat_name"`
-	CreatedAt    time.Time `json:"created_at"`
-	IsGroup      bool      `json:"is_group"`
-	Participants []int     `json:"participants"`
+	ChatID       string      `json:"chat_id"`
+	ChatName     *string     `json:"chat_name"`
+	Description  *string     `json:"description"`
+	Avatar       *ChatAvatar `json:"avatar"` // Nil when not set or not approved by moderation
+	CreatedAt    time.Time   `json:"created_at"`
+	IsGroup      bool        `json:"is_group"`
+	Participants []int       `json:"participants"`
+	Admins       []int       `json:"admins,omitempty"` // Loaded for a single group chat
+	Role         string      `json:"role"`             // Role of the requesting user
+	// Read state of the requesting user: messages from others after the last read one
+	UnreadCount       int     `json:"unread_count"`
+	LastReadMessageID *string `json:"last_read_message_id"`
+	// Newest visible message; nil when the chat has no messages yet
+	LastMessage    *LastMessage `json:"last_message"`
+	LastActivityAt time.Time    `json:"last_activity_at"` // Sent time of the last message, or the chat creation
+	// Settings of the requesting user
+	Muted      bool       `json:"muted"`
+	MutedUntil *time.Time `json:"muted_until"` // Nil when muted indefinitely or not muted
+	Archived   bool       `json:"archived"`
+	Request    bool       `json:"request"` // Direct chat from a stranger, not accepted yet
+}
+
+// ChatAvatar is the image of a group chat
+type ChatAvatar struct {
+	MediaID      int    `json:"media_id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// LastMessage is a preview of the newest message of a chat in the chat list
+type LastMessage struct {
+	MessageID      string    `json:"message_id"`
+	SenderID       int       `json:"sender_id"`
+	Snippet        string    `json:"snippet"` // Up to the first 100 characters
+	HasAttachments bool      `json:"has_attachments"`
+	SentAt         time.Time `json:"sent_at"`
+}
+
+// ChatSize describes how many participants a chat has and who created it
+type ChatSize struct {
+	IsGroup         bool
+	Participants    int
+	CreatorVerified bool   // False when the creator is unknown or has no verified profile
+	CreatorRole     string // Empty when the creator is unknown
+}
+
+// UnreadCounts are the messages a participant has not read, in one chat and in all their chats
+type UnreadCounts struct {
+	Chat  int `json:"chat"`
+	Total int `json:"total"`
+}
+
+// ReadState describes a user's read position in a chat
+type ReadState struct {
+	ChatID      string `json:"chat_id"`
+	LastReadSeq int64  `json:"last_read_seq"`
+	UnreadCount int    `json:"unread_count"`
 }
 
 type MessagingRepository interface {
 	GetUserChats(userID int) ([]Chat, error)
 	GetChat(chatID string, userID int) (*Chat, error)
-	CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error
-	AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error)
+	CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int, requests []int) error
+	AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, int64, error)
+	AddMessageWithAttachments(messageID string, chatID string, senderID int, content string, mediaIDs []int) (time.Time, int64, error)
+	AddSystemMessage(ctx context.Context, msg ChatMessage) (time.Time, int64, error)
+	AddEncryptedMessage(ctx context.Context, messageID string, chatID string, senderID int, ciphertext string) (time.Time, int64, error)
+	GetMessage(ctx context.Context, messageID string) (*ChatMessage, error)
+	GetReactionsSince(ctx context.Context, chatID string, afterSeq int64) ([]ReactionEvent, error)
+	GetReadReceiptsSince(ctx context.Context, chatID string, afterSeq int64, userID int) ([]ReadReceipt, error)
+	GetMessageAttachments(messageIDs []string) (map[string][]Attachment, error)
+	SaveMessagePreview(ctx context.Context, messageID string, preview LinkPreview) error
+	GetMessagePreviews(messageIDs []string) (map[string]LinkPreview, error)
 	GetChatParticipants(chatID string) ([]int, error)
 	IsUserInChat(userID int, chatID string) (bool, error)
-	AddParticipant(chatID string, userID int) error
+	AddParticipant(chatID string, userID int, asRequest bool) error
+	GetChatSize(chatID string) (*ChatSize, error)
+	GetUserRole(ctx context.Context, userID int) (string, error)
+	GetUsersClosedToOrganizers(ctx context.Context, userIDs []int) ([]int, error)
+	GetUnreadCounts(ctx context.Context, chatID string, userIDs []int) (map[int]UnreadCounts, error)
 	RemoveParticipant(chatID string, userID int) error
 	AddReaction(reactionID string, messageID string, userID int, reactionCode string) error
 	RemoveReaction(messageID string, userID int, reactionCode string) error
+	GetReactionSummaries(ctx context.Context, messageIDs []string, userID int) (map[string][]ReactionSummary, error)
+	GetMessageReactions(ctx context.Context, messageID string) ([]Reaction, error)
 	GetChatIDForMessage(messageID string) (string, error)
 	GetChatMessages(chatID string, userID int, limit, offset int) ([]ChatMessage, error)
+	GetChatMessagePage(chatID string, cursor MessageCursor, limit int) (*MessagePage, error)
+	GetChatMessagesAfter(ctx context.Context, chatID string, afterSeq int64, limit int) ([]ChatMessage, error)
 	StoreTypingIndicator(userID int, chatID string) error
-	StoreReadReceipt(userID int, chatID string, messageID string) error
+	StoreReadReceipt(userID int, chatID string, messageID string) (*ReadState, error)
+	MarkChatsRead(ctx context.Context, userID int, chatID string) ([]ReadState, error)
+	StoreDeliveryReceipt(ctx context.Context, userID int, chatID string, messageID string) (bool, error)
+	GetDeliveryState(ctx context.Context, chatID string, userID int) (*DeliveryState, error)
 	GetUserChatRooms(userID int) (map[string]struct{}, error)
 	GetChatParticipantsForBroadcast(chatID string) ([]int, error)
-	GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int) (string, error)
+	GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int, asRequest bool) (string, error)
+	GetChatRequests(userID int) ([]Chat, error)
+	AcceptChatRequest(ctx context.Context, chatID string, userID int) error
+	GetDirectMessageSettings(ctx context.Context, userID int) (*DirectMessageSettings, error)
+	SaveDirectMessageSettings(ctx context.Context, userID int, settings DirectMessageSettings) error
+	IsDirectMessageAllowed(ctx context.Context, recipientID int, senderID int) (bool, error)
+	SaveBotConversation(ctx context.Context, chatID string, userID int, lang string) error
+	GetBotConversationLang(ctx context.Context, chatID string) (string, error)
+	MuteChat(ctx context.Context, chatID string, userID int, until *time.Time) error
+	UnmuteChat(ctx context.Context, chatID string, userID int) error
+	ArchiveChat(ctx context.Context, chatID string, userID int) error
+	UnarchiveChat(ctx context.Context, chatID string, userID int) error
+	GetMutedParticipants(ctx context.Context, chatID string, now time.Time) (map[int]struct{}, error)
+	GetParticipantRole(ctx context.Context, chatID string, userID int) (string, error)
+	SetParticipantRole(ctx context.Context, chatID string, userID int, role string) error
+	UpdateChat(ctx context.Context, chatID string, update ChatUpdate) error
 }
 
 // MessagingRepositoryImpl encapsulates database operations for messaging
 type MessagingRepositoryImpl struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 // NewRepository creates a new messaging repository
 func NewRepository(db *sql.DB) *MessagingRepositoryImpl {
 	return &MessagingRepositoryImpl{
-		db: db,
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// readStateColumns select the last read message and the unread count of the
+// participant cp, joined with their receipt rr and the last read message lm
+const readStateColumns = `lm.id AS last_read_message_id,
+               (
+                   SELECT COUNT(*) FROM messages m
+                   WHERE m.chat_id = c.id AND m.hidden_at IS NULL AND m.sender_id <> cp.user_id
+                     AND m.seq > COALESCE(rr.last_read_seq, 0)
+               ) AS unread_count`
+
+const readStateJoins = `
+        LEFT JOIN message_read_receipts rr ON rr.chat_id = c.id AND rr.user_id = cp.user_id
+        LEFT JOIN messages lm ON lm.chat_id = c.id AND lm.seq = rr.last_read_seq`
+
+// lastMessageColumns select the preview of the newest visible message lmsg of chat c
+// and the time of the last activity in the chat
+const lastMessageColumns = `lmsg.id, lmsg.sender_id, SUBSTR(lmsg.content, 1, 100), lmsg.sent_at,
+               EXISTS(SELECT 1 FROM message_attachments ma WHERE ma.message_id = lmsg.id) AS last_message_has_attachments,
+               COALESCE(lmsg.sent_at, c.created_at) AS last_activity_at`
+
+const lastMessageJoin = `
+        LEFT JOIN messages lmsg ON lmsg.id = (
+            SELECT m.id FROM messages m
+            WHERE m.chat_id = c.id AND m.hidden_at IS NULL
+            ORDER BY m.seq DESC LIMIT 1
+        )`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// chatColumns select chat c with its avatar av and the role of the participant cp
+const chatColumns = `c.id, c.chat_name, c.description, av.id, av.url, av.thumbnail_url, c.created_at, c.is_group, cp.role`
+
+const avatarJoin = `
+        LEFT JOIN media av ON av.id = c.avatar_media_id AND av.moderation_status = 'approved'`
+
+// scanChat scans chatColumns, readStateColumns, lastMessageColumns and settingsColumns
+func scanChat(row rowScanner) (*Chat, error) {
+	var chat Chat
+	var messageID, snippet, avatarURL, avatarThumbnailURL *string
+	var senderID, avatarID *int
+	var sentAt, mutedAt, mutedUntil, archivedAt, requestedAt *time.Time
+	var hasAttachments bool
+	if err := row.Scan(&chat.ChatID, &chat.ChatName, &chat.Description,
+		&avatarID, &avatarURL, &avatarThumbnailURL, &chat.CreatedAt, &chat.IsGroup, &chat.Role,
+		&chat.LastReadMessageID, &chat.UnreadCount,
+		&messageID, &senderID, &snippet, &sentAt, &hasAttachments, database.Time(&chat.LastActivityAt),
+		&mutedAt, &mutedUntil, &archivedAt, &requestedAt); err != nil {
+		return nil, err
+	}
+	applySettings(&chat, mutedAt, mutedUntil, archivedAt, requestedAt, time.Now())
+
+	if avatarID != nil {
+		chat.Avatar = &ChatAvatar{MediaID: *avatarID, URL: *avatarURL}
+		if avatarThumbnailURL != nil {
+			chat.Avatar.ThumbnailURL = *avatarThumbnailURL
+		}
+	}
+
+	if messageID != nil {
+		chat.LastMessage = &LastMessage{
+			MessageID:      *messageID,
+			SenderID:       *senderID,
+			Snippet:        *snippet,
+			HasAttachments: hasAttachments,
+			SentAt:         *sentAt,
+		}
 	}
+	return &chat, nil
 }
 
-// GetUserChats retrieves all chats for a user
+// GetUserChats retrieves the chats of a user with the user's read state and the last message,
+// except for chat requests the user has not accepted.
+// Chats with unread messages come first, then the ones with the latest activity.
 func (r *MessagingRepositoryImpl) GetUserChats(userID int) ([]Chat, error) {
+	return r.queryUserChats(userID, "s.requested_at IS NULL")
+}
+
+// queryUserChats loads the chats of a user matching the condition on the chat c and the settings s
+func (r *MessagingRepositoryImpl) queryUserChats(userID int, condition string) ([]Chat, error) {
 	rows, err := r.db.Query(`
-        SELECT c.id, c.chat_name, c.created_at, c.is_group
-        FROM chats c
-        JOIN chat_participants cp ON c.id = cp.chat_id
-        WHERE cp.user_id = $1
-        ORDER BY c.created_at DESC
+        SELECT * FROM (
+            SELECT `+chatColumns+`, `+readStateColumns+`,
+               `+lastMessageColumns+`, `+settingsColumns+`
+            FROM chats c
+            JOIN chat_participants cp ON c.id = cp.chat_id`+readStateJoins+lastMessageJoin+settingsJoin+avatarJoin+`
+            WHERE cp.user_id = $1 AND `+condition+`
+        ) user_chats
+        ORDER BY unread_count > 0 DESC, last_activity_at DESC
     `, userID)
 
 	if err != nil {
@@ -76,51 +275,58 @@ func (r *MessagingRepositoryImpl) GetUserChats(userID int) ([]Chat, error) {
 	defer rows.Close()
 
 	var chats []Chat
+	directChats := make(map[string]int)
 
 	for rows.Next() {
-		var chat Chat
-		if err := rows.Scan(&chat.ChatID, &chat.ChatName, &chat.CreatedAt, &chat.IsGroup); err != nil {
+		chat, err := scanChat(rows)
+		if err != nil {
 			return nil, err
 		}
-		chats = append(chats, chat)
+		if !chat.IsGroup {
+			directChats[chat.ChatID] = len(chats)
+		}
+		chats = append(chats, *chat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	// If no chats found, return empty array
-	if len(chats) == 0 {
+	// Group chats don't load participants
+	if len(directChats) == 0 {
 		return chats, nil
 	}
 
-	// TODO: use batch query for participants retrieval
-
-	// For each chat, get its participants
-	for i, chat := range chats {
-		if chat.IsGroup {
-			continue
-		}
+	// Participants of all direct chats of the user are loaded with one query
+	participantRows, err := r.db.Query(`
+        SELECT p.chat_id, p.user_id
+        FROM chat_participants p
+        JOIN chat_participants me ON me.chat_id = p.chat_id AND me.user_id = $1
+        JOIN chats c ON c.id = p.chat_id
+        WHERE NOT c.is_group
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer participantRows.Close()
 
-		participantRows, err := r.db.Query("SELECT user_id FROM chat_participants WHERE chat_id = $1", chat.ChatID)
-		if err != nil {
+	for participantRows.Next() {
+		var chatID string
+		var participantID int
+		if err := participantRows.Scan(&chatID, &participantID); err != nil {
 			return nil, err
 		}
-
-		participants := make([]int, 0)
-		for participantRows.Next() {
-			var participantID int
-			if err := participantRows.Scan(&participantID); err != nil {
-				participantRows.Close()
-				return nil, err
-			}
-			participants = append(participants, participantID)
+		if i, ok := directChats[chatID]; ok {
+			chats[i].Participants = append(chats[i].Participants, participantID)
 		}
-		participantRows.Close()
-
-		chats[i].Participants = participants
+	}
+	if err := participantRows.Err(); err != nil {
+		return nil, err
 	}
 
 	return chats, nil
 }
 
-// GetChat retrieves details for a specific chat
+// GetChat retrieves details for a specific chat with the user's read state and the last message
 func (r *MessagingRepositoryImpl) GetChat(chatID string, userID int) (*Chat, error) {
 	// Check if user is a participant in the chat
 	var count int
@@ -133,17 +339,19 @@ func (r *MessagingRepositoryImpl) GetChat(chatID string, userID int) (*Chat, err
 	}
 
 	// Get chat details
-	var chat Chat
-	err = r.db.QueryRow(`
-        SELECT c.id, c.chat_name, c.created_at, c.is_group
-        FROM chats c WHERE c.id = $1
-    `, chatID).Scan(&chat.ChatID, &chat.ChatName, &chat.CreatedAt, &chat.IsGroup)
+	chat, err := scanChat(r.db.QueryRow(`
+        SELECT `+chatColumns+`, `+readStateColumns+`,
+               `+lastMessageColumns+`, `+settingsColumns+`
+        FROM chats c
+        JOIN chat_participants cp ON cp.chat_id = c.id AND cp.user_id = $2`+readStateJoins+lastMessageJoin+settingsJoin+avatarJoin+`
+        WHERE c.id = $1
+    `, chatID, userID))
 	if err != nil {
 		return nil, err
 	}
 
 	// Get chat participants
-	rows, err := r.db.Query("SELECT user_id FROM chat_participants WHERE chat_id = $1", chatID)
+	rows, err := r.db.Query("SELECT user_id, role FROM chat_participants WHERE chat_id = $1", chatID)
 	if err != nil {
 		return nil, err
 	}
@@ -151,17 +359,22 @@ func (r *MessagingRepositoryImpl) GetChat(chatID string, userID int) (*Chat, err
 
 	for rows.Next() {
 		var participantID int
-		if err := rows.Scan(&participantID); err != nil {
+		var role string
+		if err := rows.Scan(&participantID, &role); err != nil {
 			return nil, err
 		}
 		chat.Participants = append(chat.Participants, participantID)
+		if chat.IsGroup && role == RoleAdmin {
+			chat.Admins = append(chat.Admins, participantID)
+		}
 	}
 
-	return &chat, nil
+	return chat, nil
 }
 
-// CreateChat creates a new chat with the specified participants
-func (r *MessagingRepositoryImpl) CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error {
+// CreateChat creates a new chat with the specified participants.
+// The chat is a request for the participants listed in requests.
+func (r *MessagingRepositoryImpl) CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int, requests []int) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -169,13 +382,13 @@ func (r *MessagingRepositoryImpl) CreateChat(ctx context.Context, chatID string,
 	defer tx.Rollback()
 
 	// Create chat
-	_, err = tx.Exec("INSERT INTO chats (id, chat_name, is_group) VALUES ($1, $2, true)", chatID, chatName)
+	_, err = tx.Exec("INSERT INTO chats (id, chat_name, is_group, created_by) VALUES ($1, $2, true, $3)", chatID, chatName, creatorID)
 	if err != nil {
 		return err
 	}
 
-	// Add creator as a participant
-	_, err = tx.Exec("INSERT INTO chat_participants (chat_id, user_id) VALUES ($1, $2)", chatID, creatorID)
+	// Add creator as a participant, the first admin of the group
+	_, err = tx.Exec("INSERT INTO chat_participants (chat_id, user_id, role) VALUES ($1, $2, $3)", chatID, creatorID, RoleAdmin)
 	if err != nil {
 		return err
 	}
@@ -191,6 +404,12 @@ func (r *MessagingRepositoryImpl) CreateChat(ctx context.Context, chatID string,
 		}
 	}
 
+	for _, userID := range requests {
+		if err := r.markRequest(tx, chatID, userID); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -198,8 +417,9 @@ func (r *MessagingRepositoryImpl) CreateChat(ctx context.Context, chatID string,
 	return nil
 }
 
-// GetOrCreateDirectChat finds an existing direct chat between two users or creates a new one
-func (r *MessagingRepositoryImpl) GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int) (string, error) {
+// GetOrCreateDirectChat finds an existing direct chat between two users or creates a new one.
+// A new chat is a request for userID2 when asRequest is set; existing chats are left as they are.
+func (r *MessagingRepositoryImpl) GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int, asRequest bool) (string, error) {
 	// First try to find an existing direct chat
 	var chatID string
 	err := r.db.QueryRow(`
@@ -247,6 +467,12 @@ func (r *MessagingRepositoryImpl) GetOrCreateDirectChat(ctx context.Context, use
 		return "", err
 	}
 
+	if asRequest {
+		if err := r.markRequest(tx, chatID, userID2); err != nil {
+			return "", err
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return "", err
 	}
@@ -254,17 +480,33 @@ func (r *MessagingRepositoryImpl) GetOrCreateDirectChat(ctx context.Context, use
 	return chatID, nil
 }
 
-// AddMessage adds a message to the database and returns the sent time
-func (r *MessagingRepositoryImpl) AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error) {
+// AddMessage adds a message to the database and returns the sent time and seq
+func (r *MessagingRepositoryImpl) AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, int64, error) {
 	var sentAt time.Time
+	var seq int64
 	err := r.db.QueryRow(
-		"INSERT INTO messages (id, chat_id, sender_id, content) VALUES ($1, $2, $3, $4) RETURNING sent_at",
+		"INSERT INTO messages (id, chat_id, sender_id, content) VALUES ($1, $2, $3, $4) RETURNING sent_at, seq",
 		messageID, chatID, senderID, content,
-	).Scan(&sentAt)
+	).Scan(&sentAt, &seq)
 	if err != nil {
-		return time.Time{}, err
+		return time.Time{}, 0, err
 	}
-	return sentAt, nil
+	return sentAt, seq, nil
+}
+
+// GetMessage retrieves a message without its attachments
+func (r *MessagingRepositoryImpl) GetMessage(ctx context.Context, messageID string) (*ChatMessage, error) {
+	msg, err := scanMessage(r.db.QueryRowContext(ctx,
+		"SELECT "+messageColumns+" FROM messages WHERE id = $1",
+		messageID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(apierrors.ErrorMessageNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
 }
 
 // GetChatParticipants retrieves all participants in a chat
@@ -296,16 +538,77 @@ func (r *MessagingRepositoryImpl) IsUserInChat(userID int, chatID string) (bool,
 	return count > 0, nil
 }
 
-// AddParticipant adds a user to a chat
-func (r *MessagingRepositoryImpl) AddParticipant(chatID string, userID int) error {
-	_, err := r.db.Exec("INSERT INTO chat_participants (chat_id, user_id) VALUES ($1, $2)", chatID, userID)
-	return err
+// AddParticipant adds a user to a chat, as a request for the user when asRequest is set
+func (r *MessagingRepositoryImpl) AddParticipant(chatID string, userID int, asRequest bool) error {
+	if !asRequest {
+		_, err := r.db.Exec("INSERT INTO chat_participants (chat_id, user_id) VALUES ($1, $2)", chatID, userID)
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("INSERT INTO chat_participants (chat_id, user_id) VALUES ($1, $2)", chatID, userID); err != nil {
+		return err
+	}
+	if err := r.markRequest(tx, chatID, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// RemoveParticipant removes a user from a chat
+// GetChatSize counts the participants of a chat and checks whether its creator is verified
+// and what role the creator has
+func (r *MessagingRepositoryImpl) GetChatSize(chatID string) (*ChatSize, error) {
+	var size ChatSize
+	err := r.db.QueryRow(`
+        SELECT c.is_group,
+               (SELECT COUNT(*) FROM chat_participants cp WHERE cp.chat_id = c.id),
+               p.verified_at IS NOT NULL,
+               COALESCE(u.role, '')
+        FROM chats c
+        LEFT JOIN profiles p ON p.user_id = c.created_by
+        LEFT JOIN users u ON u.id = c.created_by
+        WHERE c.id = $1
+    `, chatID).Scan(&size.IsGroup, &size.Participants, &size.CreatorVerified, &size.CreatorRole)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New(apierrors.ErrorChatNotFound)
+		}
+		return nil, err
+	}
+	return &size, nil
+}
+
+// RemoveParticipant removes a user from a chat. A group left without admins
+// gets its longest-standing participant as the new admin.
 func (r *MessagingRepositoryImpl) RemoveParticipant(chatID string, userID int) error {
-	_, err := r.db.Exec("DELETE FROM chat_participants WHERE chat_id = $1 AND user_id = $2", chatID, userID)
-	return err
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM chat_participants WHERE chat_id = $1 AND user_id = $2", chatID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+        UPDATE chat_participants SET role = $2
+        WHERE chat_id = $1
+          AND user_id = (SELECT user_id FROM chat_participants WHERE chat_id = $1 ORDER BY joined_at, user_id LIMIT 1)
+          AND NOT EXISTS (SELECT 1 FROM chat_participants WHERE chat_id = $1 AND role = $2)
+          AND EXISTS (SELECT 1 FROM chats WHERE id = $1 AND is_group)
+    `, chatID, RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // AddReaction adds a reaction to a message
@@ -345,16 +648,113 @@ func (r *MessagingRepositoryImpl) GetChatIDForMessage(messageID string) (string,
 	return chatID, err
 }
 
-// GetChatMessages retrieves messages for a chat with pagination
+// GetChatMessages retrieves messages for a chat with pagination, skipping messages hidden by moderators.
+// Attachments and link previews of the page are loaded with one more query each.
+//
+// Deprecated: offsets slow down on long chats, use GetChatMessagePage.
 func (r *MessagingRepositoryImpl) GetChatMessages(chatID string, userID int, limit, offset int) ([]ChatMessage, error) {
-	// Get messages
-	rows, err := r.db.Query(`
-        SELECT id, chat_id, sender_id, content, sent_at
+	messages, err := r.queryChatMessages(`
+        SELECT `+messageColumns+`
         FROM messages
-        WHERE chat_id = $1
+        WHERE chat_id = $1 AND hidden_at IS NULL
         ORDER BY sent_at DESC
         LIMIT $2 OFFSET $3
     `, chatID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(messages); err != nil {
+		return nil, err
+	}
+	return messages, r.loadPreviews(messages)
+}
+
+// GetChatMessagePage retrieves up to limit messages of a chat around the cursor, skipping
+// messages hidden by moderators. Without AfterSeq the newest messages before the cursor are
+// returned; with only AfterSeq, the messages right after it, so a client can catch up page by page.
+func (r *MessagingRepositoryImpl) GetChatMessagePage(chatID string, cursor MessageCursor, limit int) (*MessagePage, error) {
+	conditions := "chat_id = $1 AND hidden_at IS NULL"
+	args := []interface{}{chatID}
+	if cursor.BeforeSeq > 0 {
+		args = append(args, cursor.BeforeSeq)
+		conditions += fmt.Sprintf(" AND seq < $%d", len(args))
+	}
+	if cursor.AfterSeq > 0 {
+		args = append(args, cursor.AfterSeq)
+		conditions += fmt.Sprintf(" AND seq > $%d", len(args))
+	}
+
+	// One extra message tells whether there are more in the paging direction
+	forward := cursor.AfterSeq > 0 && cursor.BeforeSeq == 0
+	order := "DESC"
+	if forward {
+		order = "ASC"
+	}
+	args = append(args, limit+1)
+
+	messages, err := r.queryChatMessages(`
+        SELECT `+messageColumns+`
+        FROM messages
+        WHERE `+conditions+`
+        ORDER BY seq `+order+`
+        LIMIT `+fmt.Sprintf("$%d", len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	if forward {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	page := &MessagePage{Messages: messages}
+	if len(messages) > 0 {
+		newest, oldest := messages[0].Seq, messages[len(messages)-1].Seq
+		page.AfterSeq = &newest
+		// Paging forward started after a message, so there are older ones
+		if hasMore || forward {
+			page.BeforeSeq = &oldest
+		}
+	} else if cursor.AfterSeq > 0 {
+		page.AfterSeq = &cursor.AfterSeq
+	}
+
+	if err := r.loadAttachments(page.Messages); err != nil {
+		return nil, err
+	}
+	if err := r.loadPreviews(page.Messages); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetChatMessagesAfter returns up to limit messages of a chat sent after afterSeq, oldest first,
+// skipping messages hidden by moderators. Starting from 0, it walks the whole chat page by page.
+func (r *MessagingRepositoryImpl) GetChatMessagesAfter(ctx context.Context, chatID string, afterSeq int64, limit int) ([]ChatMessage, error) {
+	messages, err := r.queryChatMessages(`
+        SELECT `+messageColumns+`
+        FROM messages
+        WHERE chat_id = $1 AND hidden_at IS NULL AND seq > $2
+        ORDER BY seq ASC
+        LIMIT $3
+    `, chatID, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(messages); err != nil {
+		return nil, err
+	}
+	return messages, r.loadPreviews(messages)
+}
+
+// queryChatMessages runs a query selecting the messageColumns of messages
+func (r *MessagingRepositoryImpl) queryChatMessages(query string, args ...interface{}) ([]ChatMessage, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -362,13 +762,47 @@ func (r *MessagingRepositoryImpl) GetChatMessages(chatID string, userID int, lim
 
 	messages := []ChatMessage{}
 	for rows.Next() {
-		var msg ChatMessage
-		if err := rows.Scan(&msg.MessageID, &msg.ChatID, &msg.SenderID, &msg.Content, &msg.SentAt); err != nil {
+		msg, err := scanMessage(rows)
+		if err != nil {
 			return nil, err
 		}
 		messages = append(messages, msg)
 	}
-	return messages, nil
+	return messages, rows.Err()
+}
+
+// loadAttachments sets the attachments of a page of messages with one query
+func (r *MessagingRepositoryImpl) loadAttachments(messages []ChatMessage) error {
+	messageIDs := make([]string, len(messages))
+	for i, msg := range messages {
+		messageIDs[i] = msg.MessageID
+	}
+	attachments, err := r.GetMessageAttachments(messageIDs)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		messages[i].Attachments = attachments[messages[i].MessageID]
+	}
+	return nil
+}
+
+// loadPreviews sets the link previews of a page of messages with one query
+func (r *MessagingRepositoryImpl) loadPreviews(messages []ChatMessage) error {
+	messageIDs := make([]string, len(messages))
+	for i, msg := range messages {
+		messageIDs[i] = msg.MessageID
+	}
+	previews, err := r.GetMessagePreviews(messageIDs)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		if preview, ok := previews[messages[i].MessageID]; ok {
+			messages[i].Preview = &preview
+		}
+	}
+	return nil
 }
 
 // StoreTypingIndicator records that a user is typing in a chat
@@ -379,23 +813,131 @@ func (r *MessagingRepositoryImpl) StoreTypingIndicator(userID int, chatID string
 	return nil
 }
 
-// StoreReadReceipt records that a user has read messages up to a certain point
-func (r *MessagingRepositoryImpl) StoreReadReceipt(userID int, chatID string, messageID string) error {
+// StoreReadReceipt moves the user's read position in a chat to the message.
+// Read positions only move forward, so a late receipt from another device does not
+// mark messages unread again; nil is returned when the position did not move.
+func (r *MessagingRepositoryImpl) StoreReadReceipt(userID int, chatID string, messageID string) (*ReadState, error) {
 	// First, get the sequence number for the message
 	var seq int64
-	err := r.db.QueryRow("SELECT seq FROM messages WHERE id = $1", messageID).Scan(&seq)
+	err := r.db.QueryRow("SELECT seq FROM messages WHERE id = $1 AND chat_id = $2", messageID, chatID).Scan(&seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(apierrors.ErrorMessageNotFound)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Now update the read receipt with the sequence number
-	_, err = r.db.Exec(`
+	now := r.dialect.Now()
+	result, err := r.db.Exec(fmt.Sprintf(`
         INSERT INTO message_read_receipts (user_id, chat_id, last_read_seq, read_at)
-        VALUES ($1, $2, $3, NOW())
-        ON CONFLICT (user_id, chat_id) DO UPDATE 
-        SET last_read_seq = $3, read_at = NOW()
-    `, userID, chatID, seq)
-	return err
+        VALUES ($1, $2, $3, %s)
+        %s
+        WHERE message_read_receipts.last_read_seq IS NULL
+           OR message_read_receipts.last_read_seq < excluded.last_read_seq
+    `, now, r.dialect.OnConflictUpdate("user_id, chat_id", "last_read_seq = excluded.last_read_seq, read_at = "+now)), userID, chatID, seq)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if moved == 0 {
+		return nil, nil
+	}
+
+	state := &ReadState{ChatID: chatID, LastReadSeq: seq}
+	err = r.db.QueryRow(`
+        SELECT COUNT(*) FROM messages
+        WHERE chat_id = $1 AND hidden_at IS NULL AND sender_id <> $2 AND seq > $3
+    `, chatID, userID, seq).Scan(&state.UnreadCount)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// GetUnreadCounts counts the unread messages of users in the chat and in all their chats.
+// Users without unread messages are left out.
+func (r *MessagingRepositoryImpl) GetUnreadCounts(ctx context.Context, chatID string, userIDs []int) (map[int]UnreadCounts, error) {
+	counts := make(map[int]UnreadCounts, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	args := []interface{}{chatID}
+	placeholders := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		args = append(args, userID)
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT cp.user_id, SUM(CASE WHEN m.chat_id = $1 THEN 1 ELSE 0 END), COUNT(*)
+        FROM chat_participants cp
+        LEFT JOIN message_read_receipts rr ON rr.chat_id = cp.chat_id AND rr.user_id = cp.user_id
+        JOIN messages m ON m.chat_id = cp.chat_id AND m.hidden_at IS NULL AND m.sender_id <> cp.user_id
+            AND m.seq > COALESCE(rr.last_read_seq, 0)
+        WHERE cp.user_id IN (`+strings.Join(placeholders, ", ")+`)
+        GROUP BY cp.user_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var c UnreadCounts
+		if err := rows.Scan(&userID, &c.Chat, &c.Total); err != nil {
+			return nil, err
+		}
+		counts[userID] = c
+	}
+	return counts, rows.Err()
+}
+
+// MarkChatsRead moves the user's read receipts to the latest message of every chat
+// they participate in, or of a single chat when chatID is not empty.
+// Only the receipts that actually moved forward are returned.
+func (r *MessagingRepositoryImpl) MarkChatsRead(ctx context.Context, userID int, chatID string) ([]ReadState, error) {
+	args := []interface{}{userID}
+	chatFilter := ""
+	if chatID != "" {
+		chatFilter = " AND cp.chat_id = $2"
+		args = append(args, chatID)
+	}
+
+	now := r.dialect.Now()
+	query := fmt.Sprintf(`
+        INSERT INTO message_read_receipts (user_id, chat_id, last_read_seq, read_at)
+        SELECT cp.user_id, cp.chat_id, MAX(m.seq), %s
+        FROM chat_participants cp
+        JOIN messages m ON m.chat_id = cp.chat_id
+        WHERE cp.user_id = $1%s
+        GROUP BY cp.user_id, cp.chat_id
+        %s
+        WHERE message_read_receipts.last_read_seq IS NULL
+           OR message_read_receipts.last_read_seq < excluded.last_read_seq
+        RETURNING chat_id, last_read_seq
+    `, now, chatFilter, r.dialect.OnConflictUpdate("user_id, chat_id", "last_read_seq = excluded.last_read_seq, read_at = "+now))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := []ReadState{}
+	for rows.Next() {
+		var state ReadState
+		if err := rows.Scan(&state.ChatID, &state.LastReadSeq); err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
 }
 
 // GetUserChatRooms retrieves all chat IDs a user is part of
@@ -422,3 +964,24 @@ func (r *MessagingRepositoryImpl) GetUserChatRooms(userID int) (map[string]struc
 func (r *MessagingRepositoryImpl) GetChatParticipantsForBroadcast(chatID string) ([]int, error) {
 	return r.GetChatParticipants(chatID)
 }
+
+// SaveBotConversation marks a direct chat as a conversation with the bot
+func (r *MessagingRepositoryImpl) SaveBotConversation(ctx context.Context, chatID string, userID int, lang string) error {
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO bot_conversations (chat_id, user_id, lang)
+        VALUES ($1, $2, $3)
+        %s
+    `, r.dialect.OnConflictUpdate("chat_id", "lang = excluded.lang")), chatID, userID, lang)
+	return err
+}
+
+// GetBotConversationLang returns the language of a bot conversation,
+// or an empty string when the chat is not a bot conversation
+func (r *MessagingRepositoryImpl) GetBotConversationLang(ctx context.Context, chatID string) (string, error) {
+	var lang string
+	err := r.db.QueryRowContext(ctx, "SELECT lang FROM bot_conversations WHERE chat_id = $1", chatID).Scan(&lang)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return lang, err
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
 )
 
 func setupMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *MessagingRepositoryImpl) {
@@ -20,32 +22,37 @@ func setupMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *MessagingRepositoryImpl
 	return db, mock, repo
 }
 
+// chatRowColumns are the columns selected for a chat with the read state and the last message
+var chatRowColumns = []string{"id", "chat_name", "description", "id", "url", "thumbnail_url", "created_at", "is_group", "role", "last_read_message_id", "unread_count",
+	"id", "sender_id", "substr", "sent_at", "last_message_has_attachments", "last_activity_at",
+	"muted_at", "muted_until", "archived_at", "requested_at"}
+
 func TestGetUserChats(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
 
 	userID := 1
 	mockTime := time.Now()
+	sentAt := mockTime.Add(time.Hour)
 
-	chatRows := sqlmock.NewRows([]string{"id", "chat_name", "created_at", "is_group"}).
-		AddRow("chat1", nil, mockTime, false).
-		AddRow("chat2", sql.NullString{String: "Group Chat", Valid: true}, mockTime, true)
+	chatRows := sqlmock.NewRows(chatRowColumns).
+		AddRow("chat1", nil, nil, nil, nil, nil, mockTime, false, "member", "msg7", 2, "msg9", 2, "See you", sentAt, true, sentAt, mockTime, nil, nil, nil).
+		AddRow("chat2", sql.NullString{String: "Group Chat", Valid: true}, "Weekly jams", 5, "https://cdn/5.jpg", "https://cdn/5_thumb.jpg", mockTime, true, "admin", nil, 0, nil, nil, nil, nil, false, mockTime,
+			mockTime, mockTime.Add(-time.Minute), mockTime, nil)
 
-	mock.ExpectQuery(`SELECT c.id, c.chat_name, c.created_at, c.is_group FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id WHERE cp.user_id = \$1`).
+	mock.ExpectQuery(`SELECT \* FROM \( SELECT c.id, c.chat_name, c.description, av.id, av.url, av.thumbnail_url, c.created_at, c.is_group, cp.role, lm.id AS last_read_message_id, .+ lmsg.id, lmsg.sender_id, SUBSTR\(lmsg.content, 1, 100\), lmsg.sent_at, .+ FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id .+ LEFT JOIN messages lmsg ON .+ LEFT JOIN chat_user_settings s ON .+ LEFT JOIN media av ON av.id = c.avatar_media_id AND av.moderation_status = 'approved' WHERE cp.user_id = \$1 AND s.requested_at IS NULL \) user_chats ORDER BY unread_count > 0 DESC, last_activity_at DESC`).
 		WithArgs(userID).
 		WillReturnRows(chatRows)
 
-	// For the direct chat, expect query for participants
-	participantRows := sqlmock.NewRows([]string{"user_id"}).
-		AddRow(1).
-		AddRow(2)
+	// Participants of direct chats are loaded with one query
+	participantRows := sqlmock.NewRows([]string{"chat_id", "user_id"}).
+		AddRow("chat1", 1).
+		AddRow("chat1", 2)
 
-	mock.ExpectQuery(`SELECT user_id FROM chat_participants WHERE chat_id = \$1`).
-		WithArgs("chat1").
+	mock.ExpectQuery(`SELECT p.chat_id, p.user_id FROM chat_participants p JOIN chat_participants me ON me.chat_id = p.chat_id AND me.user_id = \$1 JOIN chats c ON c.id = p.chat_id WHERE NOT c.is_group`).
+		WithArgs(userID).
 		WillReturnRows(participantRows)
 
-	// Group chat doesn't query for participants
-
 	chats, err := repo.GetUserChats(userID)
 
 	assert.NoError(t, err)
@@ -53,26 +60,62 @@ func TestGetUserChats(t *testing.T) {
 	assert.Equal(t, "chat1", chats[0].ChatID)
 	assert.Equal(t, false, chats[0].IsGroup)
 	assert.Nil(t, chats[0].ChatName)
+	assert.Nil(t, chats[0].Avatar)
 	assert.Equal(t, []int{1, 2}, chats[0].Participants)
+	assert.Equal(t, 2, chats[0].UnreadCount)
+	if assert.NotNil(t, chats[0].LastReadMessageID) {
+		assert.Equal(t, "msg7", *chats[0].LastReadMessageID)
+	}
+	assert.Equal(t, &LastMessage{MessageID: "msg9", SenderID: 2, Snippet: "See you", HasAttachments: true, SentAt: sentAt}, chats[0].LastMessage)
+	assert.Equal(t, sentAt, chats[0].LastActivityAt)
+	assert.True(t, chats[0].Muted) // Muted indefinitely
+	assert.Nil(t, chats[0].MutedUntil)
+	assert.False(t, chats[0].Archived)
 
 	assert.Equal(t, "chat2", chats[1].ChatID)
 	assert.Equal(t, true, chats[1].IsGroup)
 	assert.NotNil(t, chats[1].ChatName)
 	assert.Equal(t, "Group Chat", *chats[1].ChatName)
+	assert.Equal(t, "Weekly jams", *chats[1].Description)
+	assert.Equal(t, &ChatAvatar{MediaID: 5, URL: "https://cdn/5.jpg", ThumbnailURL: "https://cdn/5_thumb.jpg"}, chats[1].Avatar)
 	assert.Empty(t, chats[1].Participants) // Group chats don't load participants
+	assert.Equal(t, 0, chats[1].UnreadCount)
+	assert.Nil(t, chats[1].LastReadMessageID) // Nothing read yet
+	assert.Nil(t, chats[1].LastMessage)       // No messages yet
+	assert.Equal(t, mockTime, chats[1].LastActivityAt)
+	assert.False(t, chats[1].Muted) // Mute has expired
+	assert.True(t, chats[1].Archived)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetUserChatsGroupOnly(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mockTime := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM \( SELECT c.id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(chatRowColumns).
+			AddRow("chat2", "Group Chat", nil, nil, nil, nil, mockTime, true, "member", nil, 0, nil, nil, nil, nil, false, mockTime, nil, nil, nil, nil))
+
+	// No direct chats, so participants are not queried
+	chats, err := repo.GetUserChats(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, chats, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetUserChatsEmpty(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
 
 	userID := 1
 
-	emptyRows := sqlmock.NewRows([]string{"id", "chat_name", "created_at", "is_group"})
+	emptyRows := sqlmock.NewRows(chatRowColumns)
 
-	mock.ExpectQuery(`SELECT c.id, c.chat_name, c.created_at, c.is_group FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id WHERE cp.user_id = \$1`).
+	mock.ExpectQuery(`SELECT c.id, c.chat_name, .+ c.is_group, cp.role, lm.id AS last_read_message_id, .+ FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id .+ WHERE cp.user_id = \$1`).
 		WithArgs(userID).
 		WillReturnRows(emptyRows)
 
@@ -90,7 +133,7 @@ func TestGetUserChatsError(t *testing.T) {
 	userID := 1
 	expectedErr := errors.New("database error")
 
-	mock.ExpectQuery(`SELECT c.id, c.chat_name, c.created_at, c.is_group FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id WHERE cp.user_id = \$1`).
+	mock.ExpectQuery(`SELECT c.id, c.chat_name, .+ c.is_group, cp.role, lm.id AS last_read_message_id, .+ FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id .+ WHERE cp.user_id = \$1`).
 		WithArgs(userID).
 		WillReturnError(expectedErr)
 
@@ -116,18 +159,18 @@ func TestGetChat(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 	// Get chat details
-	mock.ExpectQuery(`SELECT c.id, c.chat_name, c.created_at, c.is_group FROM chats c WHERE c.id = \$1`).
-		WithArgs(chatID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_name", "created_at", "is_group"}).
-			AddRow(chatID, chatName, mockTime, true))
+	mock.ExpectQuery(`SELECT c.id, c.chat_name, .+ c.is_group, cp.role, lm.id AS last_read_message_id, .+ WHERE c.id = \$1`).
+		WithArgs(chatID, userID).
+		WillReturnRows(sqlmock.NewRows(chatRowColumns).
+			AddRow(chatID, chatName, nil, nil, nil, nil, mockTime, true, "admin", "msg3", 4, "msg5", 2, "Hello", mockTime, false, mockTime, nil, nil, nil, nil))
 
 	// Get participants
-	mock.ExpectQuery(`SELECT user_id FROM chat_participants WHERE chat_id = \$1`).
+	mock.ExpectQuery(`SELECT user_id, role FROM chat_participants WHERE chat_id = \$1`).
 		WithArgs(chatID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).
-			AddRow(1).
-			AddRow(2).
-			AddRow(3))
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "role"}).
+			AddRow(1, "admin").
+			AddRow(2, "member").
+			AddRow(3, "admin"))
 
 	chat, err := repo.GetChat(chatID, userID)
 
@@ -138,6 +181,14 @@ func TestGetChat(t *testing.T) {
 	assert.Equal(t, chatName, *chat.ChatName)
 	assert.Equal(t, true, chat.IsGroup)
 	assert.Equal(t, []int{1, 2, 3}, chat.Participants)
+	assert.Equal(t, []int{1, 3}, chat.Admins)
+	assert.Equal(t, "admin", chat.Role)
+	assert.Equal(t, 4, chat.UnreadCount)
+	assert.Equal(t, "msg3", *chat.LastReadMessageID)
+	if assert.NotNil(t, chat.LastMessage) {
+		assert.Equal(t, "msg5", chat.LastMessage.MessageID)
+		assert.Equal(t, "Hello", chat.LastMessage.Snippet)
+	}
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -171,12 +222,12 @@ func TestCreateChat(t *testing.T) {
 	participants := []int{1, 2, 3}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`INSERT INTO chats \(id, chat_name, is_group\) VALUES \(\$1, \$2, true\)`).
-		WithArgs(chatID, chatName).
+	mock.ExpectExec(`INSERT INTO chats \(id, chat_name, is_group, created_by\) VALUES \(\$1, \$2, true, \$3\)`).
+		WithArgs(chatID, chatName, creatorID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
-		WithArgs(chatID, creatorID).
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id, role\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs(chatID, creatorID, "admin").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// Skip creator as already added
@@ -190,7 +241,36 @@ func TestCreateChat(t *testing.T) {
 
 	mock.ExpectCommit()
 
-	err := repo.CreateChat(ctx, chatID, creatorID, chatName, participants)
+	err := repo.CreateChat(ctx, chatID, creatorID, chatName, participants, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateChatAsRequest(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO chats`).
+		WithArgs("chat1", "New Group", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id, role\)`).
+		WithArgs("chat1", 1, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs("chat1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs("chat1", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Only user 3 does not allow the creator
+	mock.ExpectExec(`INSERT INTO chat_user_settings \(chat_id, user_id, requested_at\) VALUES \(\$1, \$2, NOW\(\)\)`).
+		WithArgs("chat1", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateChat(context.Background(), "chat1", 1, "New Group", []int{2, 3}, []int{3})
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -210,7 +290,7 @@ func TestGetOrCreateDirectChat_Existing(t *testing.T) {
 		WithArgs(userID1, userID2).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(existingChatID))
 
-	chatID, err := repo.GetOrCreateDirectChat(ctx, userID1, userID2)
+	chatID, err := repo.GetOrCreateDirectChat(ctx, userID1, userID2, false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, existingChatID, chatID)
@@ -246,7 +326,7 @@ func TestGetOrCreateDirectChat_New(t *testing.T) {
 
 	mock.ExpectCommit()
 
-	chatID, err := repo.GetOrCreateDirectChat(ctx, userID1, userID2)
+	chatID, err := repo.GetOrCreateDirectChat(ctx, userID1, userID2, false)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, chatID)
@@ -263,14 +343,38 @@ func TestAddMessage(t *testing.T) {
 	content := "Hello world"
 	mockTime := time.Now()
 
-	mock.ExpectQuery(`INSERT INTO messages \(id, chat_id, sender_id, content\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING sent_at`).
+	mock.ExpectQuery(`INSERT INTO messages \(id, chat_id, sender_id, content\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING sent_at, seq`).
 		WithArgs(messageID, chatID, senderID, content).
-		WillReturnRows(sqlmock.NewRows([]string{"sent_at"}).AddRow(mockTime))
+		WillReturnRows(sqlmock.NewRows([]string{"sent_at", "seq"}).AddRow(mockTime, 12))
 
-	sentAt, err := repo.AddMessage(messageID, chatID, senderID, content)
+	sentAt, seq, err := repo.AddMessage(messageID, chatID, senderID, content)
 
 	assert.NoError(t, err)
 	assert.Equal(t, mockTime, sentAt)
+	assert.Equal(t, int64(12), seq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessage(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	sentAt := time.Now()
+	query := `SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE id = \$1`
+	mock.ExpectQuery(query).
+		WithArgs("msg1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "sender_id", "content", "sent_at", "seq", "kind", "system_event", "target_user_id", "ciphertext"}).
+			AddRow("msg1", "chat1", 1, "Hello", sentAt, 12, "user", nil, nil, nil))
+	mock.ExpectQuery(query).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	msg, err := repo.GetMessage(context.Background(), "msg1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChatMessage{MessageID: "msg1", ChatID: "chat1", SenderID: 1, Content: "Hello", SentAt: sentAt, Seq: 12, Kind: MessageKindUser}, msg)
+
+	_, err = repo.GetMessage(context.Background(), "missing")
+	assert.EqualError(t, err, apierrors.ErrorMessageNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -323,12 +427,61 @@ func TestAddParticipant(t *testing.T) {
 		WithArgs(chatID, userID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := repo.AddParticipant(chatID, userID)
+	err := repo.AddParticipant(chatID, userID, false)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddParticipantAsRequest(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs("chat1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_user_settings \(chat_id, user_id, requested_at\) VALUES \(\$1, \$2, NOW\(\)\)`).
+		WithArgs("chat1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.AddParticipant("chat1", 2, true)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetChatSize(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM chats c\s+LEFT JOIN profiles p ON p.user_id = c.created_by\s+LEFT JOIN users u ON u.id = c.created_by\s+WHERE c.id = \$1`).
+		WithArgs("chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"is_group", "participants", "creator_verified", "creator_role"}).AddRow(true, 12, true, "organizer"))
+
+	size, err := repo.GetChatSize("chat1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ChatSize{IsGroup: true, Participants: 12, CreatorVerified: true, CreatorRole: "organizer"}, size)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChatSizeNotFound(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM chats c`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	size, err := repo.GetChatSize("missing")
+
+	assert.Nil(t, size)
+	assert.EqualError(t, err, "chat not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRemoveParticipant(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
@@ -336,9 +489,15 @@ func TestRemoveParticipant(t *testing.T) {
 	chatID := "chat1"
 	userID := 1
 
+	mock.ExpectBegin()
 	mock.ExpectExec(`DELETE FROM chat_participants WHERE chat_id = \$1 AND user_id = \$2`).
 		WithArgs(chatID, userID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The longest-standing participant becomes admin when no admin is left in a group
+	mock.ExpectExec(`UPDATE chat_participants SET role = \$2 WHERE chat_id = \$1 AND user_id = \(SELECT user_id FROM chat_participants WHERE chat_id = \$1 ORDER BY joined_at, user_id LIMIT 1\) AND NOT EXISTS \(SELECT 1 FROM chat_participants WHERE chat_id = \$1 AND role = \$2\) AND EXISTS \(SELECT 1 FROM chats WHERE id = \$1 AND is_group\)`).
+		WithArgs(chatID, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.RemoveParticipant(chatID, userID)
 
@@ -437,11 +596,22 @@ func TestGetChatMessages(t *testing.T) {
 	offset := 0
 	mockTime := time.Now()
 
-	mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at FROM messages WHERE chat_id = \$1 ORDER BY sent_at DESC LIMIT \$2 OFFSET \$3`).
+	mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL ORDER BY sent_at DESC LIMIT \$2 OFFSET \$3`).
 		WithArgs(chatID, limit, offset).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "sender_id", "content", "sent_at"}).
-			AddRow("msg1", chatID, userID, "Hello", mockTime).
-			AddRow("msg2", chatID, userID+1, "Hi there", mockTime.Add(-1*time.Minute)))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "sender_id", "content", "sent_at", "seq", "kind", "system_event", "target_user_id", "ciphertext"}).
+			AddRow("msg1", chatID, userID, "Hello", mockTime, 2, "user", nil, nil, nil).
+			AddRow("msg2", chatID, userID+1, "Hi there", mockTime.Add(-1*time.Minute), 1, "user", nil, nil, nil))
+
+	mock.ExpectQuery(`SELECT ma.message_id, m.id, m.type, m.url, m.thumbnail_url FROM message_attachments ma JOIN media m ON m.id = ma.media_id WHERE ma.message_id IN \(\$1, \$2\)`).
+		WithArgs("msg1", "msg2").
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "id", "type", "url", "thumbnail_url"}).
+			AddRow("msg2", 7, "image", "https://cdn/7.jpg", "https://cdn/7_thumb.jpg").
+			AddRow("msg2", 8, "video", "https://cdn/8.mp4", "https://cdn/8_thumb.jpg"))
+
+	mock.ExpectQuery(`SELECT message_id, url, title, description, image_url, site_name FROM message_previews WHERE message_id IN \(\$1, \$2\)`).
+		WithArgs("msg1", "msg2").
+		WillReturnRows(sqlmock.NewRows(previewColumns).
+			AddRow("msg1", "https://example.com", "Example", "", "https://example.com/og.png", ""))
 
 	messages, err := repo.GetChatMessages(chatID, userID, limit, offset)
 
@@ -453,7 +623,235 @@ func TestGetChatMessages(t *testing.T) {
 	assert.Equal(t, "Hello", messages[0].Content)
 	assert.Equal(t, mockTime, messages[0].SentAt)
 
+	assert.Nil(t, messages[0].Attachments)
+	assert.Equal(t, &LinkPreview{URL: "https://example.com", Title: "Example", ImageURL: "https://example.com/og.png"}, messages[0].Preview)
+
 	assert.Equal(t, "msg2", messages[1].MessageID)
+	assert.Equal(t, []Attachment{
+		{MediaID: 7, Type: "image", URL: "https://cdn/7.jpg", ThumbnailURL: "https://cdn/7_thumb.jpg"},
+		{MediaID: 8, Type: "video", URL: "https://cdn/8.mp4", ThumbnailURL: "https://cdn/8_thumb.jpg"},
+	}, messages[1].Attachments)
+	assert.Nil(t, messages[1].Preview)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var previewColumns = []string{"message_id", "url", "title", "description", "image_url", "site_name"}
+
+func TestGetChatMessagePage(t *testing.T) {
+	mockTime := time.Now()
+	columns := []string{"id", "chat_id", "sender_id", "content", "sent_at", "seq", "kind", "system_event", "target_user_id", "ciphertext"}
+	seqs := func(messages []ChatMessage) []int64 {
+		result := []int64{}
+		for _, msg := range messages {
+			result = append(result, msg.Seq)
+		}
+		return result
+	}
+
+	t.Run("newest messages", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL ORDER BY seq DESC LIMIT \$2`).
+			WithArgs("chat1", 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("m9", "chat1", 1, "c", mockTime, 9, "user", nil, nil, nil).
+				AddRow("m8", "chat1", 2, "b", mockTime, 8, "user", nil, nil, nil).
+				AddRow("m7", "chat1", 1, "a", mockTime, 7, "user", nil, nil, nil))
+		mock.ExpectQuery(`SELECT ma.message_id`).
+			WithArgs("m9", "m8").
+			WillReturnRows(sqlmock.NewRows([]string{"message_id", "id", "type", "url", "thumbnail_url"}))
+		mock.ExpectQuery(`SELECT message_id, url, title, description, image_url, site_name FROM message_previews`).
+			WithArgs("m9", "m8").
+			WillReturnRows(sqlmock.NewRows(previewColumns))
+
+		page, err := repo.GetChatMessagePage("chat1", MessageCursor{}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{9, 8}, seqs(page.Messages))
+		assert.Equal(t, int64(8), *page.BeforeSeq)
+		assert.Equal(t, int64(9), *page.AfterSeq)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("oldest page", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL AND seq < \$2 ORDER BY seq DESC LIMIT \$3`).
+			WithArgs("chat1", int64(8), 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("m7", "chat1", 1, "a", mockTime, 7, "user", nil, nil, nil))
+		mock.ExpectQuery(`SELECT ma.message_id`).
+			WithArgs("m7").
+			WillReturnRows(sqlmock.NewRows([]string{"message_id", "id", "type", "url", "thumbnail_url"}))
+		mock.ExpectQuery(`SELECT message_id, url, title, description, image_url, site_name FROM message_previews`).
+			WithArgs("m7").
+			WillReturnRows(sqlmock.NewRows(previewColumns))
+
+		page, err := repo.GetChatMessagePage("chat1", MessageCursor{BeforeSeq: 8}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{7}, seqs(page.Messages))
+		assert.Nil(t, page.BeforeSeq)
+		assert.Equal(t, int64(7), *page.AfterSeq)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("newer messages", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL AND seq > \$2 ORDER BY seq ASC LIMIT \$3`).
+			WithArgs("chat1", int64(7), 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("m8", "chat1", 2, "b", mockTime, 8, "user", nil, nil, nil).
+				AddRow("m9", "chat1", 1, "c", mockTime, 9, "user", nil, nil, nil))
+		mock.ExpectQuery(`SELECT ma.message_id`).
+			WithArgs("m9", "m8").
+			WillReturnRows(sqlmock.NewRows([]string{"message_id", "id", "type", "url", "thumbnail_url"}))
+		mock.ExpectQuery(`SELECT message_id, url, title, description, image_url, site_name FROM message_previews`).
+			WithArgs("m9", "m8").
+			WillReturnRows(sqlmock.NewRows(previewColumns))
+
+		page, err := repo.GetChatMessagePage("chat1", MessageCursor{AfterSeq: 7}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{9, 8}, seqs(page.Messages))
+		assert.Equal(t, int64(8), *page.BeforeSeq)
+		assert.Equal(t, int64(9), *page.AfterSeq)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no newer messages", func(t *testing.T) {
+		db, mock, repo := setupMock(t)
+		defer db.Close()
+
+		mock.ExpectQuery(`ORDER BY seq ASC`).
+			WithArgs("chat1", int64(9), 3).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		page, err := repo.GetChatMessagePage("chat1", MessageCursor{AfterSeq: 9}, 2)
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Messages)
+		assert.Nil(t, page.BeforeSeq)
+		assert.Equal(t, int64(9), *page.AfterSeq)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetChatMessagesAfter(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mockTime := time.Now()
+	mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL AND seq > \$2 ORDER BY seq ASC LIMIT \$3`).
+		WithArgs("chat1", int64(7), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "sender_id", "content", "sent_at", "seq", "kind", "system_event", "target_user_id", "ciphertext"}).
+			AddRow("m8", "chat1", 2, "b", mockTime, 8, "user", nil, nil, nil).
+			AddRow("m9", "chat1", 1, "c", mockTime, 9, "user", nil, nil, nil))
+	mock.ExpectQuery(`SELECT ma.message_id`).
+		WithArgs("m8", "m9").
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "id", "type", "url", "thumbnail_url"}))
+	mock.ExpectQuery(`FROM message_previews`).
+		WithArgs("m8", "m9").
+		WillReturnRows(sqlmock.NewRows(previewColumns))
+
+	messages, err := repo.GetChatMessagesAfter(context.Background(), "chat1", 7, 2)
+
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "m8", messages[0].MessageID)
+		assert.Equal(t, "m9", messages[1].MessageID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveMessagePreview(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	preview := LinkPreview{URL: "https://example.com", Title: "Example", Description: "About", ImageURL: "https://example.com/og.png", SiteName: "Example"}
+	mock.ExpectExec(`INSERT INTO message_previews \(message_id, url, title, description, image_url, site_name\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) ON CONFLICT \(message_id\) DO NOTHING`).
+		WithArgs("msg1", preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SaveMessagePreview(context.Background(), "msg1", preview))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSystemMessage(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	sentAt := time.Now()
+	target := 3
+	mock.ExpectQuery(`INSERT INTO messages \(id, chat_id, sender_id, content, kind, system_event, target_user_id\)\s+VALUES \(\$1, \$2, \$3, \$4, 'system', \$5, \$6\)\s+RETURNING sent_at, seq`).
+		WithArgs("msg1", "chat1", 1, "Anna added Boris", SystemEventParticipantAdded, &target).
+		WillReturnRows(sqlmock.NewRows([]string{"sent_at", "seq"}).AddRow(sentAt, 9))
+
+	result, seq, err := repo.AddSystemMessage(context.Background(), ChatMessage{
+		MessageID:    "msg1",
+		ChatID:       "chat1",
+		SenderID:     1,
+		Content:      "Anna added Boris",
+		Event:        SystemEventParticipantAdded,
+		TargetUserID: &target,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sentAt, result)
+	assert.Equal(t, int64(9), seq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddEncryptedMessage(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	sentAt := time.Now()
+	mock.ExpectQuery(`INSERT INTO messages \(id, chat_id, sender_id, content, ciphertext\)\s+VALUES \(\$1, \$2, \$3, '', \$4\)\s+RETURNING sent_at, seq`).
+		WithArgs("msg1", "chat1", 1, "b3BhcXVl").
+		WillReturnRows(sqlmock.NewRows([]string{"sent_at", "seq"}).AddRow(sentAt, 4))
+	mock.ExpectQuery(`SELECT id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext FROM messages WHERE id = \$1`).
+		WithArgs("msg1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "sender_id", "content", "sent_at", "seq", "kind", "system_event", "target_user_id", "ciphertext"}).
+			AddRow("msg1", "chat1", 1, "", sentAt, 4, "user", nil, nil, "b3BhcXVl"))
+
+	result, seq, err := repo.AddEncryptedMessage(context.Background(), "msg1", "chat1", 1, "b3BhcXVl")
+	assert.NoError(t, err)
+	assert.Equal(t, sentAt, result)
+	assert.Equal(t, int64(4), seq)
+
+	msg, err := repo.GetMessage(context.Background(), "msg1")
+	assert.NoError(t, err)
+	assert.Equal(t, "b3BhcXVl", msg.Ciphertext)
+	assert.Empty(t, msg.Content)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddMessageWithAttachments(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	sentAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO messages \(id, chat_id, sender_id, content\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING sent_at, seq`).
+		WithArgs("msg1", "chat1", 1, "").
+		WillReturnRows(sqlmock.NewRows([]string{"sent_at", "seq"}).AddRow(sentAt, 5))
+	mock.ExpectExec(`INSERT INTO message_attachments \(message_id, media_id, position\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs("msg1", 8, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO message_attachments \(message_id, media_id, position\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs("msg1", 7, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, seq, err := repo.AddMessageWithAttachments("msg1", "chat1", 1, "", []int{8, 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, sentAt, result)
+	assert.Equal(t, int64(5), seq)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -467,18 +865,166 @@ func TestStoreReadReceipt(t *testing.T) {
 	seq := int64(42)
 
 	// Get message sequence
-	mock.ExpectQuery(`SELECT seq FROM messages WHERE id = \$1`).
-		WithArgs(messageID).
+	mock.ExpectQuery(`SELECT seq FROM messages WHERE id = \$1 AND chat_id = \$2`).
+		WithArgs(messageID, chatID).
 		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(seq))
 
 	// Store read receipt
-	mock.ExpectExec(`INSERT INTO message_read_receipts \(user_id, chat_id, last_read_seq, read_at\) VALUES \(\$1, \$2, \$3, NOW\(\)\) ON CONFLICT \(user_id, chat_id\) DO UPDATE SET last_read_seq = \$3, read_at = NOW\(\)`).
+	mock.ExpectExec(`INSERT INTO message_read_receipts \(user_id, chat_id, last_read_seq, read_at\) VALUES \(\$1, \$2, \$3, NOW\(\)\) ON CONFLICT \(user_id, chat_id\) DO UPDATE SET last_read_seq = excluded.last_read_seq, read_at = NOW\(\) WHERE message_read_receipts.last_read_seq IS NULL OR message_read_receipts.last_read_seq < excluded.last_read_seq`).
 		WithArgs(userID, chatID, seq).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := repo.StoreReadReceipt(userID, chatID, messageID)
+	// Count messages from others after the read one
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM messages WHERE chat_id = \$1 AND hidden_at IS NULL AND sender_id <> \$2 AND seq > \$3`).
+		WithArgs(chatID, userID, seq).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	state, err := repo.StoreReadReceipt(userID, chatID, messageID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ReadState{ChatID: chatID, LastReadSeq: seq, UnreadCount: 3}, state)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUnreadCounts(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT cp.user_id, SUM\(CASE WHEN m.chat_id = \$1 THEN 1 ELSE 0 END\), COUNT\(\*\) FROM chat_participants cp .* WHERE cp.user_id IN \(\$2, \$3\) GROUP BY cp.user_id`).
+		WithArgs("chat1", 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "chat", "total"}).AddRow(2, 3, 7))
+
+	counts, err := repo.GetUnreadCounts(context.Background(), "chat1", []int{2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]UnreadCounts{2: {Chat: 3, Total: 7}}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreReadReceiptOlderMessage(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT seq FROM messages WHERE id = \$1 AND chat_id = \$2`).
+		WithArgs("msg1", "chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(int64(10)))
+	// The user has already read further, so the receipt is not moved back
+	mock.ExpectExec(`INSERT INTO message_read_receipts`).
+		WithArgs(1, "chat1", int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	state, err := repo.StoreReadReceipt(1, "chat1", "msg1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreReadReceiptMessageNotInChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT seq FROM messages WHERE id = \$1 AND chat_id = \$2`).
+		WithArgs("msg1", "chat2").
+		WillReturnError(sql.ErrNoRows)
+
+	state, err := repo.StoreReadReceipt(1, "chat2", "msg1")
+
+	assert.EqualError(t, err, apierrors.ErrorMessageNotFound)
+	assert.Nil(t, state)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreDeliveryReceipt(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT m.seq, c.is_group FROM messages m JOIN chats c ON c.id = m.chat_id WHERE m.id = \$1 AND m.chat_id = \$2`).
+		WithArgs("msg1", "chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"seq", "is_group"}).AddRow(int64(42), false))
+	mock.ExpectExec(`INSERT INTO message_delivery_receipts \(user_id, chat_id, last_delivered_seq, delivered_at\) VALUES \(\$1, \$2, \$3, NOW\(\)\) ON CONFLICT \(user_id, chat_id\) DO UPDATE SET last_delivered_seq = excluded.last_delivered_seq, delivered_at = NOW\(\) WHERE message_delivery_receipts.last_delivered_seq < excluded.last_delivered_seq`).
+		WithArgs(2, "chat1", int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	moved, err := repo.StoreDeliveryReceipt(context.Background(), 2, "chat1", "msg1")
+
+	assert.NoError(t, err)
+	assert.True(t, moved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreDeliveryReceiptGroupChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	// Deliveries are tracked in direct chats only
+	mock.ExpectQuery(`SELECT m.seq, c.is_group FROM messages m`).
+		WithArgs("msg1", "chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"seq", "is_group"}).AddRow(int64(42), true))
+
+	moved, err := repo.StoreDeliveryReceipt(context.Background(), 2, "chat1", "msg1")
+
+	assert.NoError(t, err)
+	assert.False(t, moved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeliveryState(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	query := `SELECT COALESCE\(dr.last_delivered_seq, 0\), COALESCE\(rr.last_read_seq, 0\) FROM chats c JOIN chat_participants cp ON cp.chat_id = c.id AND cp.user_id <> \$2 .* WHERE c.id = \$1 AND NOT c.is_group`
+	mock.ExpectQuery(query).
+		WithArgs("chat1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"delivered", "read"}).AddRow(int64(12), int64(10)))
+	mock.ExpectQuery(query).
+		WithArgs("group1", 1).
+		WillReturnError(sql.ErrNoRows)
+
+	state, err := repo.GetDeliveryState(context.Background(), "chat1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &DeliveryState{LastDeliveredSeq: 12, LastReadSeq: 10}, state)
+	assert.Equal(t, MessageStatusRead, state.Status(10))
+	assert.Equal(t, MessageStatusDelivered, state.Status(12))
+	assert.Equal(t, MessageStatusSent, state.Status(13))
+
+	state, err = repo.GetDeliveryState(context.Background(), "group1", 1)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkChatsRead(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`INSERT INTO message_read_receipts \(user_id, chat_id, last_read_seq, read_at\) SELECT cp.user_id, cp.chat_id, MAX\(m.seq\), NOW\(\) FROM chat_participants cp JOIN messages m ON m.chat_id = cp.chat_id WHERE cp.user_id = \$1 GROUP BY cp.user_id, cp.chat_id ON CONFLICT \(user_id, chat_id\) DO UPDATE SET last_read_seq = excluded.last_read_seq`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id", "last_read_seq"}).
+			AddRow("chat1", int64(10)).
+			AddRow("chat2", int64(42)))
+
+	states, err := repo.MarkChatsRead(context.Background(), 1, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ReadState{
+		{ChatID: "chat1", LastReadSeq: 10},
+		{ChatID: "chat2", LastReadSeq: 42},
+	}, states)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkChatsReadSingleChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`WHERE cp.user_id = \$1 AND cp.chat_id = \$2 GROUP BY`).
+		WithArgs(1, "chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id", "last_read_seq"}))
+
+	states, err := repo.MarkChatsRead(context.Background(), 1, "chat1")
 
 	assert.NoError(t, err)
+	assert.Empty(t, states)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -528,6 +1074,42 @@ func TestGetChatParticipantsForBroadcast(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSaveBotConversation(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO bot_conversations \(chat_id, user_id, lang\)`).
+		WithArgs("chat1", 2, "en").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SaveBotConversation(context.Background(), "chat1", 2, "en")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBotConversationLang(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT lang FROM bot_conversations WHERE chat_id = \$1`).
+		WithArgs("chat1").
+		WillReturnRows(sqlmock.NewRows([]string{"lang"}).AddRow("en"))
+	mock.ExpectQuery(`SELECT lang FROM bot_conversations WHERE chat_id = \$1`).
+		WithArgs("chat2").
+		WillReturnError(sql.ErrNoRows)
+
+	lang, err := repo.GetBotConversationLang(context.Background(), "chat1")
+	assert.NoError(t, err)
+	assert.Equal(t, "en", lang)
+
+	// Regular chats are not bot conversations
+	lang, err = repo.GetBotConversationLang(context.Background(), "chat2")
+	assert.NoError(t, err)
+	assert.Equal(t, "", lang)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestNewRepository(t *testing.T) {
 	db, _, _ := sqlmock.New()
 	defer db.Close()
@@ -537,3 +1119,352 @@ func TestNewRepository(t *testing.T) {
 	assert.NotNil(t, repo)
 	assert.Equal(t, db, repo.db)
 }
+
+func TestMuteChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	until := time.Now().Add(time.Hour)
+	mock.ExpectExec(`INSERT INTO chat_user_settings \(chat_id, user_id, muted_at, muted_until\) VALUES \(\$1, \$2, NOW\(\), \$3\) ON CONFLICT \(chat_id, user_id\) DO UPDATE SET muted_at = excluded.muted_at, muted_until = excluded.muted_until`).
+		WithArgs("chat1", 1, &until).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MuteChat(context.Background(), "chat1", 1, &until))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchiveChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	// Archiving again keeps the original archive time
+	mock.ExpectExec(`INSERT INTO chat_user_settings \(chat_id, user_id, archived_at\) VALUES \(\$1, \$2, NOW\(\)\) ON CONFLICT \(chat_id, user_id\) DO UPDATE SET archived_at = COALESCE\(chat_user_settings.archived_at, NOW\(\)\)`).
+		WithArgs("chat1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE chat_user_settings SET archived_at = NULL WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.ArchiveChat(context.Background(), "chat1", 1))
+	assert.NoError(t, repo.UnarchiveChat(context.Background(), "chat1", 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMutedParticipants(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT user_id FROM chat_user_settings WHERE chat_id = \$1 AND \(requested_at IS NOT NULL OR \(muted_at IS NOT NULL AND \(muted_until IS NULL OR muted_until > \$2\)\)\)`).
+		WithArgs("chat1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2).AddRow(3))
+
+	muted, err := repo.GetMutedParticipants(context.Background(), "chat1", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{2: {}, 3: {}}, muted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetParticipantRole(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT role FROM chat_participants WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin"))
+	mock.ExpectQuery(`SELECT role FROM chat_participants WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 9).
+		WillReturnError(sql.ErrNoRows)
+
+	role, err := repo.GetParticipantRole(context.Background(), "chat1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleAdmin, role)
+
+	_, err = repo.GetParticipantRole(context.Background(), "chat1", 9)
+	assert.EqualError(t, err, apierrors.ErrorUserNotInChat)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetParticipantRole(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE chat_participants SET role = \$3 WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 2, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE chat_participants SET role = \$3 WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 9, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.SetParticipantRole(context.Background(), "chat1", 2, RoleAdmin))
+	assert.EqualError(t, repo.SetParticipantRole(context.Background(), "chat1", 9, RoleAdmin), apierrors.ErrorUserNotInChat)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateChat(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	name, description, avatar := "Jam", "", 7
+	mock.ExpectExec(`UPDATE chats SET chat_name = \$2, description = \$3, avatar_media_id = \$4 WHERE id = \$1`).
+		WithArgs("chat1", "Jam", nil, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateChat(context.Background(), "chat1", ChatUpdate{Name: &name, Description: &description, AvatarMediaID: &avatar})
+	assert.NoError(t, err)
+
+	// Nothing to change, nothing to run
+	assert.NoError(t, repo.UpdateChat(context.Background(), "chat1", ChatUpdate{}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReactionsSince(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	reactedAt := time.Now()
+	mock.ExpectQuery(`SELECT mr.id, mr.message_id, mr.user_id, mr.reaction_code, mr.reacted_at FROM message_reactions mr JOIN messages m ON m.id = mr.message_id WHERE m.chat_id = \$1 AND m.hidden_at IS NULL AND mr.reacted_at > \(SELECT sent_at FROM messages WHERE chat_id = \$1 AND seq = \$2\) ORDER BY mr.reacted_at`).
+		WithArgs("chat1", int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "message_id", "user_id", "reaction_code", "reacted_at"}).
+			AddRow("r1", "msg1", 2, "like", reactedAt))
+
+	reactions, err := repo.GetReactionsSince(context.Background(), "chat1", 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ReactionEvent{{ReactionID: "r1", MessageID: "msg1", UserID: 2, ReactionCode: "like", ReactedAt: reactedAt}}, reactions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReadReceiptsSince(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	readAt := time.Now()
+	mock.ExpectQuery(`SELECT rr.user_id, m.id, rr.read_at FROM message_read_receipts rr JOIN messages m ON m.chat_id = rr.chat_id AND m.seq = rr.last_read_seq WHERE rr.chat_id = \$1 AND rr.user_id <> \$3 AND rr.read_at > \(SELECT sent_at FROM messages WHERE chat_id = \$1 AND seq = \$2\) ORDER BY rr.read_at`).
+		WithArgs("chat1", int64(42), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "id", "read_at"}).AddRow(2, "msg5", readAt))
+
+	receipts, err := repo.GetReadReceiptsSince(context.Background(), "chat1", 42, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ReadReceipt{{UserID: 2, MessageID: "msg5", ReadAt: readAt}}, receipts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReactionSummaries(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	// Samples come ranked within their code, plus the requesting user's own reaction
+	mock.ExpectQuery(`SELECT message_id, reaction_code, user_id, total, rn FROM \(.*ROW_NUMBER\(\) OVER \(PARTITION BY mr.message_id, mr.reaction_code ORDER BY mr.reacted_at, mr.user_id\) AS rn FROM message_reactions mr WHERE mr.message_id IN \(\$3, \$4\) \) ranked WHERE rn <= \$2 OR user_id = \$1 ORDER BY message_id, first_reacted_at, reaction_code, rn`).
+		WithArgs(7, ReactionSampleSize, "msg1", "msg2").
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "reaction_code", "user_id", "total", "rn"}).
+			AddRow("msg1", "like", 2, 5, 1).
+			AddRow("msg1", "like", 3, 5, 2).
+			AddRow("msg1", "like", 4, 5, 3).
+			AddRow("msg1", "like", 7, 5, 5).
+			AddRow("msg1", "fire", 2, 1, 1))
+
+	summaries, err := repo.GetReactionSummaries(context.Background(), []string{"msg1", "msg2"}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]ReactionSummary{
+		"msg1": {
+			{Code: "like", Count: 5, Reacted: true, UserIDs: []int{2, 3, 4}},
+			{Code: "fire", Count: 1, UserIDs: []int{2}},
+		},
+	}, summaries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReactionSummariesNoMessages(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	summaries, err := repo.GetReactionSummaries(context.Background(), nil, 7)
+
+	assert.NoError(t, err)
+	assert.Empty(t, summaries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageReactions(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	reactedAt := time.Now()
+	mock.ExpectQuery(`SELECT user_id, reaction_code, reacted_at FROM message_reactions WHERE message_id = \$1 ORDER BY reacted_at, user_id`).
+		WithArgs("msg1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "reaction_code", "reacted_at"}).AddRow(2, "like", reactedAt))
+
+	reactions, err := repo.GetMessageReactions(context.Background(), "msg1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Reaction{{UserID: 2, ReactionCode: "like", ReactedAt: reactedAt}}, reactions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOrCreateDirectChatAsRequest(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT c.id FROM chats c`).
+		WithArgs(1, 2).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO chats \(id, is_group\) VALUES \(\$1, false\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO chat_participants \(chat_id, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The new chat is a request for the recipient only
+	mock.ExpectExec(`INSERT INTO chat_user_settings \(chat_id, user_id, requested_at\) VALUES \(\$1, \$2, NOW\(\)\)`).
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	chatID, err := repo.GetOrCreateDirectChat(context.Background(), 1, 2, true)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, chatID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChatRequests(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mockTime := time.Now()
+	mock.ExpectQuery(`FROM chats c JOIN chat_participants cp ON c.id = cp.chat_id .+ WHERE cp.user_id = \$1 AND s.requested_at IS NOT NULL \) user_chats`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(chatRowColumns).
+			AddRow("chat1", nil, nil, nil, nil, nil, mockTime, false, "member", nil, 1, "msg1", 2, "Hi", mockTime, false, mockTime, nil, nil, nil, mockTime))
+	mock.ExpectQuery(`SELECT p.chat_id, p.user_id FROM chat_participants p`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id", "user_id"}).AddRow("chat1", 1).AddRow("chat1", 2))
+
+	chats, err := repo.GetChatRequests(1)
+
+	assert.NoError(t, err)
+	if assert.Len(t, chats, 1) {
+		assert.True(t, chats[0].Request)
+		assert.Equal(t, []int{1, 2}, chats[0].Participants)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptChatRequest(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE chat_user_settings SET requested_at = NULL WHERE chat_id = \$1 AND user_id = \$2`).
+		WithArgs("chat1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.AcceptChatRequest(context.Background(), "chat1", 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDirectMessageSettings(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	query := `SELECT policy, allow_teammates, allow_verified FROM direct_message_settings WHERE user_id = \$1`
+	mock.ExpectQuery(query).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"policy", "allow_teammates", "allow_verified"}).AddRow("filtered", false, true))
+	mock.ExpectQuery(query).
+		WithArgs(2).
+		WillReturnError(sql.ErrNoRows)
+
+	settings, err := repo.GetDirectMessageSettings(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &DirectMessageSettings{Policy: DirectMessagePolicyFiltered, AllowVerified: true}, settings)
+
+	// Users who never saved settings get the defaults
+	settings, err = repo.GetDirectMessageSettings(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &DefaultDirectMessageSettings, settings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveDirectMessageSettings(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO direct_message_settings \(user_id, policy, allow_teammates, allow_verified, updated_at\) VALUES \(\$1, \$2, \$3, \$4, NOW\(\)\) ON CONFLICT \(user_id\) DO UPDATE SET policy = excluded.policy, allow_teammates = excluded.allow_teammates, allow_verified = excluded.allow_verified, updated_at = NOW\(\)`).
+		WithArgs(1, "filtered", true, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SaveDirectMessageSettings(context.Background(), 1, DirectMessageSettings{Policy: DirectMessagePolicyFiltered, AllowTeammates: true})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsDirectMessageAllowed(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	query := `SELECT COALESCE\(s.policy, 'everyone'\) = 'everyone' OR EXISTS \(SELECT 1 FROM user_follows f WHERE f.follower_id = \$1 AND f.user_id = \$2\) .+ FROM users u LEFT JOIN direct_message_settings s ON s.user_id = u.id WHERE u.id = \$1`
+	mock.ExpectQuery(query).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"allowed"}).AddRow(false))
+	mock.ExpectQuery(query).
+		WithArgs(99, 1).
+		WillReturnError(sql.ErrNoRows)
+
+	allowed, err := repo.IsDirectMessageAllowed(context.Background(), 2, 1)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	// Unknown recipients are left to fail when the chat is created
+	allowed, err = repo.IsDirectMessageAllowed(context.Background(), 99, 1)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserRole(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT role FROM users WHERE id = \$1`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("organizer"))
+	mock.ExpectQuery(`SELECT role FROM users WHERE id = \$1`).
+		WithArgs(8).
+		WillReturnError(sql.ErrNoRows)
+
+	role, err := repo.GetUserRole(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "organizer", role)
+
+	role, err = repo.GetUserRole(context.Background(), 8)
+	assert.NoError(t, err)
+	assert.Empty(t, role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUsersClosedToOrganizers(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`WHERE u.id IN \(\$1, \$2, \$3\)\s+AND NOT COALESCE\(p.allow_organizer_contact, FALSE\)`).
+		WithArgs(2, 3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+
+	closed, err := repo.GetUsersClosedToOrganizers(context.Background(), []int{2, 3, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, closed)
+
+	closed, err = repo.GetUsersClosedToOrganizers(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, closed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
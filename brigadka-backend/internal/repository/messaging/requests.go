@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Who can start direct chats with a user
+const (
+	DirectMessagePolicyEveryone = "everyone"
+	// Chats from people the user does not follow and who match none of the filters are requests
+	DirectMessagePolicyFiltered = "filtered"
+)
+
+// DirectMessageSettings control who can start direct chats with a user.
+// The filters apply to the filtered policy, on top of the people the user follows.
+type DirectMessageSettings struct {
+	Policy         string `json:"policy"`
+	AllowTeammates bool   `json:"allow_teammates"` // Members of the user's teams
+	AllowVerified  bool   `json:"allow_verified"`  // Users with a verified profile
+}
+
+// DefaultDirectMessageSettings are the settings of users who never changed them
+var DefaultDirectMessageSettings = DirectMessageSettings{
+	Policy:         DirectMessagePolicyEveryone,
+	AllowTeammates: true,
+}
+
+// GetChatRequests returns the direct chats the user has not accepted yet
+func (r *MessagingRepositoryImpl) GetChatRequests(userID int) ([]Chat, error) {
+	return r.queryUserChats(userID, "s.requested_at IS NOT NULL")
+}
+
+// markRequest makes a chat the user was just added to a request for the user
+func (r *MessagingRepositoryImpl) markRequest(tx *sql.Tx, chatID string, userID int) error {
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO chat_user_settings (chat_id, user_id, requested_at) VALUES ($1, $2, %s)", r.dialect.Now()), chatID, userID)
+	return err
+}
+
+// AcceptChatRequest turns a chat request into a regular chat of the user; accepting twice is a no-op
+func (r *MessagingRepositoryImpl) AcceptChatRequest(ctx context.Context, chatID string, userID int) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE chat_user_settings SET requested_at = NULL WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID)
+	return err
+}
+
+// GetDirectMessageSettings returns the settings of the user, or the defaults when never saved
+func (r *MessagingRepositoryImpl) GetDirectMessageSettings(ctx context.Context, userID int) (*DirectMessageSettings, error) {
+	var settings DirectMessageSettings
+	err := r.db.QueryRowContext(ctx,
+		"SELECT policy, allow_teammates, allow_verified FROM direct_message_settings WHERE user_id = $1",
+		userID,
+	).Scan(&settings.Policy, &settings.AllowTeammates, &settings.AllowVerified)
+	if errors.Is(err, sql.ErrNoRows) {
+		settings = DefaultDirectMessageSettings
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveDirectMessageSettings replaces the settings of the user
+func (r *MessagingRepositoryImpl) SaveDirectMessageSettings(ctx context.Context, userID int, settings DirectMessageSettings) error {
+	now := r.dialect.Now()
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO direct_message_settings (user_id, policy, allow_teammates, allow_verified, updated_at)
+        VALUES ($1, $2, $3, $4, %s)
+        %s
+    `, now, r.dialect.OnConflictUpdate("user_id",
+		"policy = excluded.policy, allow_teammates = excluded.allow_teammates, allow_verified = excluded.allow_verified, updated_at = "+now)),
+		userID, settings.Policy, settings.AllowTeammates, settings.AllowVerified)
+	return err
+}
+
+// IsDirectMessageAllowed reports whether the settings of the recipient let the sender start
+// a direct chat: the policy is everyone, the recipient follows the sender, or the sender
+// matches a filter the recipient enabled
+func (r *MessagingRepositoryImpl) IsDirectMessageAllowed(ctx context.Context, recipientID int, senderID int) (bool, error) {
+	var allowed bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COALESCE(s.policy, 'everyone') = 'everyone'
+            OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $1 AND f.user_id = $2)
+            OR (COALESCE(s.allow_teammates, TRUE) AND EXISTS (
+                SELECT 1 FROM team_members mine
+                JOIN team_members theirs ON theirs.team_id = mine.team_id
+                WHERE mine.user_id = $1 AND theirs.user_id = $2
+            ))
+            OR (COALESCE(s.allow_verified, FALSE) AND EXISTS (
+                SELECT 1 FROM profiles p WHERE p.user_id = $2 AND p.verified_at IS NOT NULL
+            ))
+        FROM users u
+        LEFT JOIN direct_message_settings s ON s.user_id = u.id
+        WHERE u.id = $1
+    `, recipientID, senderID).Scan(&allowed)
+	// Chats with unknown users fail when they are created
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	return allowed, err
+}
@@ -0,0 +1,78 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// ReactionEvent is a reaction added to a message
+type ReactionEvent struct {
+	ReactionID   string
+	MessageID    string
+	UserID       int
+	ReactionCode string
+	ReactedAt    time.Time
+}
+
+// ReadReceipt is the position a participant has read a chat up to
+type ReadReceipt struct {
+	UserID    int
+	MessageID string
+	ReadAt    time.Time
+}
+
+// sinceSeq selects the sent time of the message with seq $2 in chat $1.
+// Comparisons with it match nothing when there is no such message.
+const sinceSeq = "(SELECT sent_at FROM messages WHERE chat_id = $1 AND seq = $2)"
+
+// GetReactionsSince returns the reactions added in a chat after the message with
+// seq afterSeq was sent, oldest first
+func (r *MessagingRepositoryImpl) GetReactionsSince(ctx context.Context, chatID string, afterSeq int64) ([]ReactionEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT mr.id, mr.message_id, mr.user_id, mr.reaction_code, mr.reacted_at
+        FROM message_reactions mr
+        JOIN messages m ON m.id = mr.message_id
+        WHERE m.chat_id = $1 AND m.hidden_at IS NULL AND mr.reacted_at > `+sinceSeq+`
+        ORDER BY mr.reacted_at
+    `, chatID, afterSeq)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := []ReactionEvent{}
+	for rows.Next() {
+		var reaction ReactionEvent
+		if err := rows.Scan(&reaction.ReactionID, &reaction.MessageID, &reaction.UserID, &reaction.ReactionCode, &reaction.ReactedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, rows.Err()
+}
+
+// GetReadReceiptsSince returns the read receipts of the other participants of a chat
+// that moved after the message with seq afterSeq was sent, oldest first
+func (r *MessagingRepositoryImpl) GetReadReceiptsSince(ctx context.Context, chatID string, afterSeq int64, userID int) ([]ReadReceipt, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT rr.user_id, m.id, rr.read_at
+        FROM message_read_receipts rr
+        JOIN messages m ON m.chat_id = rr.chat_id AND m.seq = rr.last_read_seq
+        WHERE rr.chat_id = $1 AND rr.user_id <> $3 AND rr.read_at > `+sinceSeq+`
+        ORDER BY rr.read_at
+    `, chatID, afterSeq, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []ReadReceipt{}
+	for rows.Next() {
+		var receipt ReadReceipt
+		if err := rows.Scan(&receipt.UserID, &receipt.MessageID, &receipt.ReadAt); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, rows.Err()
+}
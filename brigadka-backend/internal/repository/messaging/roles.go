@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+)
+
+// Participant roles in group chats
+const (
+	RoleAdmin  = "admin"  // Removes members, renames the chat and promotes admins
+	RoleMember = "member" // Default role
+)
+
+// GetParticipantRole returns the role of a chat participant
+func (r *MessagingRepositoryImpl) GetParticipantRole(ctx context.Context, chatID string, userID int) (string, error) {
+	var role string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT role FROM chat_participants WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errors.New(apierrors.ErrorUserNotInChat)
+	}
+	return role, err
+}
+
+// SetParticipantRole changes the role of a chat participant
+func (r *MessagingRepositoryImpl) SetParticipantRole(ctx context.Context, chatID string, userID int, role string) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE chat_participants SET role = $3 WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID, role)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(apierrors.ErrorUserNotInChat)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// settingsColumns select the mute, archive and request settings s of the participant cp
+const settingsColumns = `s.muted_at, s.muted_until, s.archived_at, s.requested_at`
+
+const settingsJoin = `
+        LEFT JOIN chat_user_settings s ON s.chat_id = c.id AND s.user_id = cp.user_id`
+
+// applySettings sets the mute, archive and request flags of a chat from the scanned settings
+func applySettings(chat *Chat, mutedAt, mutedUntil, archivedAt, requestedAt *time.Time, now time.Time) {
+	if mutedAt != nil && (mutedUntil == nil || mutedUntil.After(now)) {
+		chat.Muted = true
+		chat.MutedUntil = mutedUntil
+	}
+	chat.Archived = archivedAt != nil
+	chat.Request = requestedAt != nil
+}
+
+// MuteChat turns off notifications of a chat for the user until the given time, or indefinitely when until is nil
+func (r *MessagingRepositoryImpl) MuteChat(ctx context.Context, chatID string, userID int, until *time.Time) error {
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO chat_user_settings (chat_id, user_id, muted_at, muted_until)
+        VALUES ($1, $2, %s, $3)
+        %s
+    `, r.dialect.Now(), r.dialect.OnConflictUpdate("chat_id, user_id", "muted_at = excluded.muted_at, muted_until = excluded.muted_until")),
+		chatID, userID, until)
+	return err
+}
+
+// UnmuteChat turns notifications of a chat back on for the user
+func (r *MessagingRepositoryImpl) UnmuteChat(ctx context.Context, chatID string, userID int) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE chat_user_settings SET muted_at = NULL, muted_until = NULL WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID)
+	return err
+}
+
+// ArchiveChat moves a chat to the user's archive
+func (r *MessagingRepositoryImpl) ArchiveChat(ctx context.Context, chatID string, userID int) error {
+	now := r.dialect.Now()
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        INSERT INTO chat_user_settings (chat_id, user_id, archived_at)
+        VALUES ($1, $2, %s)
+        %s
+    `, now, r.dialect.OnConflictUpdate("chat_id, user_id", "archived_at = COALESCE(chat_user_settings.archived_at, "+now+")")),
+		chatID, userID)
+	return err
+}
+
+// UnarchiveChat moves a chat out of the user's archive
+func (r *MessagingRepositoryImpl) UnarchiveChat(ctx context.Context, chatID string, userID int) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE chat_user_settings SET archived_at = NULL WHERE chat_id = $1 AND user_id = $2",
+		chatID, userID)
+	return err
+}
+
+// GetMutedParticipants returns the participants of a chat who have muted it at the given time,
+// along with the ones who have not accepted it as a request yet
+func (r *MessagingRepositoryImpl) GetMutedParticipants(ctx context.Context, chatID string, now time.Time) (map[int]struct{}, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT user_id FROM chat_user_settings
+        WHERE chat_id = $1
+          AND (requested_at IS NOT NULL OR (muted_at IS NOT NULL AND (muted_until IS NULL OR muted_until > $2)))
+    `, chatID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	muted := make(map[int]struct{})
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		muted[userID] = struct{}{}
+	}
+	return muted, rows.Err()
+}
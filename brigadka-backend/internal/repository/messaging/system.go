@@ -0,0 +1,57 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// Message kinds
+const (
+	MessageKindUser   = "user"   // Sent by a participant
+	MessageKindSystem = "system" // Records a change of the chat, sent on behalf of the participant who made it
+)
+
+// Events recorded by system messages
+const (
+	SystemEventChatCreated        = "chat_created"
+	SystemEventChatRenamed        = "chat_renamed"
+	SystemEventChatUpdated        = "chat_updated" // Description or avatar changed
+	SystemEventParticipantAdded   = "participant_added"
+	SystemEventParticipantJoined  = "participant_joined"
+	SystemEventParticipantRemoved = "participant_removed"
+	SystemEventParticipantLeft    = "participant_left"
+	SystemEventAdminPromoted      = "admin_promoted"
+)
+
+// messageColumns select a message for scanMessage
+const messageColumns = `id, chat_id, sender_id, content, sent_at, seq, kind, system_event, target_user_id, ciphertext`
+
+// scanMessage scans messageColumns
+func scanMessage(row rowScanner) (ChatMessage, error) {
+	var msg ChatMessage
+	var event, ciphertext *string
+	if err := row.Scan(&msg.MessageID, &msg.ChatID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.Seq,
+		&msg.Kind, &event, &msg.TargetUserID, &ciphertext); err != nil {
+		return msg, err
+	}
+	if event != nil {
+		msg.Event = *event
+	}
+	if ciphertext != nil {
+		msg.Ciphertext = *ciphertext
+	}
+	return msg, nil
+}
+
+// AddSystemMessage stores a system message recording msg.Event, sent by the participant
+// who made the change, and returns the sent time and seq
+func (r *MessagingRepositoryImpl) AddSystemMessage(ctx context.Context, msg ChatMessage) (time.Time, int64, error) {
+	var sentAt time.Time
+	var seq int64
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO messages (id, chat_id, sender_id, content, kind, system_event, target_user_id)
+        VALUES ($1, $2, $3, $4, 'system', $5, $6)
+        RETURNING sent_at, seq
+    `, msg.MessageID, msg.ChatID, msg.SenderID, msg.Content, msg.Event, msg.TargetUserID).Scan(&sentAt, &seq)
+	return sentAt, seq, err
+}
@@ -0,0 +1,141 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var ErrAnswersNotFound = errors.New("onboarding answers not found")
+
+// AnswersModel represents the onboarding quiz answers of a user
+type AnswersModel struct {
+	UserID           int
+	PreferredFormats []string
+	Schedule         *string
+	Experience       *string
+	UpdatedAt        time.Time
+}
+
+// Repository defines methods for onboarding quiz storage
+type Repository interface {
+	GetAnswers(ctx context.Context, userID int) (*AnswersModel, error)
+	SaveAnswers(ctx context.Context, answers *AnswersModel) error
+	ValidateImprovStyle(ctx context.Context, style string) (bool, error)
+
+	// GetFirstSearchAt returns when the user first searched profiles, nil if never
+	GetFirstSearchAt(ctx context.Context, userID int) (*time.Time, error)
+	// RecordFirstSearch stores the time of the first search; later calls keep the original time
+	RecordFirstSearch(ctx context.Context, userID int, at time.Time) error
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new onboarding repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// GetAnswers retrieves the quiz answers of a user
+func (r *postgresRepository) GetAnswers(ctx context.Context, userID int) (*AnswersModel, error) {
+	answers := &AnswersModel{UserID: userID}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT schedule, experience, updated_at
+        FROM onboarding_answers
+        WHERE user_id = $1`, userID,
+	).Scan(&answers.Schedule, &answers.Experience, &answers.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrAnswersNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT style FROM onboarding_formats WHERE user_id = $1 ORDER BY style`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers.PreferredFormats = []string{}
+	for rows.Next() {
+		var style string
+		if err := rows.Scan(&style); err != nil {
+			return nil, err
+		}
+		answers.PreferredFormats = append(answers.PreferredFormats, style)
+	}
+	return answers, rows.Err()
+}
+
+// SaveAnswers creates or replaces the quiz answers of a user
+func (r *postgresRepository) SaveAnswers(ctx context.Context, answers *AnswersModel) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`
+        INSERT INTO onboarding_answers (user_id, schedule, experience, updated_at)
+        VALUES ($1, $2, $3, %s)
+        %s
+        RETURNING updated_at`,
+		r.dialect.Now(),
+		r.dialect.OnConflictUpdate("user_id", "schedule = excluded.schedule, experience = excluded.experience, updated_at = excluded.updated_at"))
+
+	err = tx.QueryRowContext(ctx, query, answers.UserID, answers.Schedule, answers.Experience).Scan(&answers.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM onboarding_formats WHERE user_id = $1`, answers.UserID); err != nil {
+		return err
+	}
+	for _, style := range answers.PreferredFormats {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO onboarding_formats (user_id, style) VALUES ($1, $2)`, answers.UserID, style); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ValidateImprovStyle checks if an improv style exists in the catalog
+func (r *postgresRepository) ValidateImprovStyle(ctx context.Context, style string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM improv_style_catalog WHERE style_code = $1)", style).Scan(&exists)
+	return exists, err
+}
+
+// GetFirstSearchAt returns when the user first searched profiles
+func (r *postgresRepository) GetFirstSearchAt(ctx context.Context, userID int) (*time.Time, error) {
+	var at time.Time
+	err := r.db.QueryRowContext(ctx, `SELECT first_search_at FROM onboarding_progress WHERE user_id = $1`, userID).Scan(&at)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &at, nil
+}
+
+// RecordFirstSearch stores the time of the first search unless one is already stored
+func (r *postgresRepository) RecordFirstSearch(ctx context.Context, userID int, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO onboarding_progress (user_id, first_search_at)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO NOTHING`, userID, at)
+	return err
+}
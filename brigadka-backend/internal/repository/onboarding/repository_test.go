@@ -0,0 +1,96 @@
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestGetAnswers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	updatedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM onboarding_answers`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"schedule", "experience", "updated_at"}).AddRow("weekends", nil, updatedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT style FROM onboarding_formats WHERE user_id = $1 ORDER BY style`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"style"}).AddRow("longform").AddRow("shortform"))
+
+	answers, err := repo.GetAnswers(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "weekends", *answers.Schedule)
+	assert.Nil(t, answers.Experience)
+	assert.Equal(t, []string{"longform", "shortform"}, answers.PreferredFormats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnswersNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM onboarding_answers`)).
+		WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetAnswers(context.Background(), 5)
+	assert.Equal(t, ErrAnswersNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveAnswers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	experience := "beginner"
+	updatedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (user_id) DO UPDATE SET`)).
+		WithArgs(5, nil, &experience).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM onboarding_formats WHERE user_id = $1`)).
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO onboarding_formats (user_id, style) VALUES ($1, $2)`)).
+		WithArgs(5, "musical").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	answers := &AnswersModel{UserID: 5, PreferredFormats: []string{"musical"}, Experience: &experience}
+	assert.NoError(t, repo.SaveAnswers(context.Background(), answers))
+	assert.Equal(t, updatedAt, answers.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFirstSearch(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT first_search_at FROM onboarding_progress WHERE user_id = $1`)).
+		WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+	at, err := repo.GetFirstSearchAt(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Nil(t, at)
+
+	// A repeated search keeps the time of the first one
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT (user_id) DO NOTHING`)).
+		WithArgs(5, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, repo.RecordFirstSearch(context.Background(), 5, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
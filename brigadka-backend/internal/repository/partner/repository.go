@@ -0,0 +1,269 @@
+package partner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Request statuses
+const (
+	StatusWaiting   = "waiting"
+	StatusMatched   = "matched"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+)
+
+// Practice levels
+const (
+	LevelBeginner     = "beginner"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+)
+
+var (
+	ErrRequestNotFound = errors.New("partner request not found")
+	ErrAlreadyWaiting  = errors.New("user is already waiting for a partner")
+	// ErrRequestTaken means one of the requests left the queue before it could be matched
+	ErrRequestTaken = errors.New("partner request is no longer waiting")
+)
+
+// Request is a user's request for a practice partner within a time window
+type Request struct {
+	ID             int
+	UserID         int
+	CityID         int
+	Level          string
+	AvailableFrom  time.Time
+	AvailableUntil time.Time
+	Status         string
+	CreatedAt      time.Time
+	ImprovStyles   []string // Filled by GetWaitingRequests
+	Match          *Match   // Filled by GetCurrentRequest for matched requests
+}
+
+// Match is the partner a request was paired with
+type Match struct {
+	ID          int
+	PartnerID   int
+	PartnerName string
+	ChatID      *string
+	MatchedAt   time.Time
+}
+
+// Repository defines methods for the partner queue
+type Repository interface {
+	// CreateRequest puts a request in the queue. Requests of the user whose window is
+	// over by now are expired first, so only a live request makes it ErrAlreadyWaiting.
+	CreateRequest(ctx context.Context, request *Request, now time.Time) error
+	// GetCurrentRequest returns the latest waiting or matched request of the user whose window is not over
+	GetCurrentRequest(ctx context.Context, userID int, now time.Time) (*Request, error)
+	CancelRequest(ctx context.Context, userID int) error
+
+	// ExpireRequests takes the waiting requests whose window is over out of the queue
+	ExpireRequests(ctx context.Context, now time.Time) (int64, error)
+	// GetWaitingRequests returns the queue with the improv styles of each user, oldest first
+	GetWaitingRequests(ctx context.Context, now time.Time) ([]Request, error)
+	// CreateMatch pairs two waiting requests. Fails with ErrRequestTaken if either has left the queue.
+	CreateMatch(ctx context.Context, requestID1, requestID2 int) (int, error)
+	SetMatchChat(ctx context.Context, matchID int, chatID string) error
+
+	ValidateCity(ctx context.Context, cityID int) (bool, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new partner queue repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// CreateRequest inserts a waiting request; ID, Status and CreatedAt are filled in
+func (r *postgresRepository) CreateRequest(ctx context.Context, request *Request, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE partner_requests SET status = 'expired'
+        WHERE user_id = $1 AND status = 'waiting' AND available_until <= $2`,
+		request.UserID, now)
+	if err != nil {
+		return err
+	}
+
+	request.Status = StatusWaiting
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO partner_requests (user_id, city_id, level, available_from, available_until)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`,
+		request.UserID, request.CityID, request.Level, request.AvailableFrom, request.AvailableUntil,
+	).Scan(&request.ID, &request.CreatedAt)
+	if database.IsUniqueViolation(err) {
+		return ErrAlreadyWaiting
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetCurrentRequest retrieves the current request of a user together with its match
+func (r *postgresRepository) GetCurrentRequest(ctx context.Context, userID int, now time.Time) (*Request, error) {
+	var request Request
+	var matchID, partnerID sql.NullInt64
+	var partnerName, chatID sql.NullString
+	var matchedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+        SELECT pr.id, pr.user_id, pr.city_id, pr.level, pr.available_from, pr.available_until, pr.status, pr.created_at,
+               pm.id, CASE WHEN pm.user_id1 = pr.user_id THEN pm.user_id2 ELSE pm.user_id1 END,
+               p.full_name, pm.chat_id, pm.matched_at
+        FROM partner_requests pr
+        LEFT JOIN partner_matches pm ON pm.id = pr.match_id
+        LEFT JOIN profiles p ON p.user_id = CASE WHEN pm.user_id1 = pr.user_id THEN pm.user_id2 ELSE pm.user_id1 END
+        WHERE pr.user_id = $1 AND pr.status IN ('waiting', 'matched') AND pr.available_until > $2
+        ORDER BY pr.created_at DESC, pr.id DESC
+        LIMIT 1`,
+		userID, now,
+	).Scan(&request.ID, &request.UserID, &request.CityID, &request.Level, &request.AvailableFrom, &request.AvailableUntil,
+		&request.Status, &request.CreatedAt, &matchID, &partnerID, &partnerName, &chatID, &matchedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if matchID.Valid {
+		request.Match = &Match{
+			ID:          int(matchID.Int64),
+			PartnerID:   int(partnerID.Int64),
+			PartnerName: partnerName.String,
+			MatchedAt:   matchedAt.Time,
+		}
+		if chatID.Valid {
+			request.Match.ChatID = &chatID.String
+		}
+	}
+	return &request, nil
+}
+
+// CancelRequest takes the waiting request of a user out of the queue
+func (r *postgresRepository) CancelRequest(ctx context.Context, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE partner_requests SET status = 'cancelled'
+        WHERE user_id = $1 AND status = 'waiting'`,
+		userID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrRequestNotFound)
+}
+
+// ExpireRequests marks waiting requests whose window is over as expired
+func (r *postgresRepository) ExpireRequests(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE partner_requests SET status = 'expired'
+        WHERE status = 'waiting' AND available_until <= $1`,
+		now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetWaitingRequests retrieves the waiting requests whose window is not over
+func (r *postgresRepository) GetWaitingRequests(ctx context.Context, now time.Time) ([]Request, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT pr.id, pr.user_id, pr.city_id, pr.level, pr.available_from, pr.available_until, pr.created_at, ips.style
+        FROM partner_requests pr
+        LEFT JOIN improv_profile_styles ips ON ips.user_id = pr.user_id
+        WHERE pr.status = 'waiting' AND pr.available_until > $1
+        ORDER BY pr.created_at, pr.id, ips.style`,
+		now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// A request comes in one row per improv style of the user
+	requests := []Request{}
+	for rows.Next() {
+		var request Request
+		var style sql.NullString
+		err := rows.Scan(&request.ID, &request.UserID, &request.CityID, &request.Level,
+			&request.AvailableFrom, &request.AvailableUntil, &request.CreatedAt, &style)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(requests); n == 0 || requests[n-1].ID != request.ID {
+			request.Status = StatusWaiting
+			requests = append(requests, request)
+		}
+		if style.Valid {
+			last := &requests[len(requests)-1]
+			last.ImprovStyles = append(last.ImprovStyles, style.String)
+		}
+	}
+	return requests, rows.Err()
+}
+
+// CreateMatch records a match and takes both requests out of the queue
+func (r *postgresRepository) CreateMatch(ctx context.Context, requestID1, requestID2 int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var matchID int
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO partner_matches (user_id1, user_id2)
+        SELECT (SELECT user_id FROM partner_requests WHERE id = $1), (SELECT user_id FROM partner_requests WHERE id = $2)
+        RETURNING id`,
+		requestID1, requestID2,
+	).Scan(&matchID)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE partner_requests SET status = 'matched', match_id = $3
+        WHERE id IN ($1, $2) AND status = 'waiting'`,
+		requestID1, requestID2, matchID)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected != 2 {
+		return 0, ErrRequestTaken
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return matchID, nil
+}
+
+// SetMatchChat links the direct chat of the pair to a match
+func (r *postgresRepository) SetMatchChat(ctx context.Context, matchID int, chatID string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE partner_matches SET chat_id = $2 WHERE id = $1`, matchID, chatID)
+	return err
+}
+
+// ValidateCity checks that a city exists
+func (r *postgresRepository) ValidateCity(ctx context.Context, cityID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM cities WHERE city_id = $1)`, cityID).Scan(&exists)
+	return exists, err
+}
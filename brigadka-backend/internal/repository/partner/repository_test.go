@@ -0,0 +1,195 @@
+package partner
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+const (
+	expireUserQuery = `
+        UPDATE partner_requests SET status = 'expired'
+        WHERE user_id = $1 AND status = 'waiting' AND available_until <= $2`
+	insertRequestQuery = `
+        INSERT INTO partner_requests (user_id, city_id, level, available_from, available_until)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+)
+
+func TestCreateRequest(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	createdAt := now
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(expireUserQuery)).
+		WithArgs(7, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(insertRequestQuery)).
+		WithArgs(7, 1, LevelBeginner, now, now.Add(3*time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, createdAt))
+	mock.ExpectCommit()
+
+	request := &Request{UserID: 7, CityID: 1, Level: LevelBeginner, AvailableFrom: now, AvailableUntil: now.Add(3 * time.Hour)}
+	err := repo.CreateRequest(context.Background(), request, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, request.ID)
+	assert.Equal(t, StatusWaiting, request.Status)
+	assert.Equal(t, createdAt, request.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRequestAlreadyWaiting(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(expireUserQuery)).
+		WithArgs(7, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(insertRequestQuery)).
+		WithArgs(7, 1, LevelBeginner, now, now.Add(3*time.Hour)).
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	request := &Request{UserID: 7, CityID: 1, Level: LevelBeginner, AvailableFrom: now, AvailableUntil: now.Add(3 * time.Hour)}
+	err := repo.CreateRequest(context.Background(), request, now)
+	assert.ErrorIs(t, err, ErrAlreadyWaiting)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCurrentRequest(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	columns := []string{"id", "user_id", "city_id", "level", "available_from", "available_until", "status", "created_at",
+		"match_id", "partner_id", "full_name", "chat_id", "matched_at"}
+	query := regexp.QuoteMeta(`
+        FROM partner_requests pr
+        LEFT JOIN partner_matches pm ON pm.id = pr.match_id`)
+	mock.ExpectQuery(query).
+		WithArgs(7, now).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(10, 7, 1, LevelBeginner, now, now.Add(time.Hour), StatusMatched, now, 3, 8, "Partner", "chat-1", now))
+	mock.ExpectQuery(query).
+		WithArgs(8, now).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(11, 8, 1, LevelBeginner, now, now.Add(time.Hour), StatusWaiting, now, nil, nil, nil, nil, nil))
+	mock.ExpectQuery(query).
+		WithArgs(9, now).
+		WillReturnError(sql.ErrNoRows)
+
+	request, err := repo.GetCurrentRequest(context.Background(), 7, now)
+	assert.NoError(t, err)
+	if assert.NotNil(t, request.Match) {
+		assert.Equal(t, 8, request.Match.PartnerID)
+		assert.Equal(t, "Partner", request.Match.PartnerName)
+		assert.Equal(t, "chat-1", *request.Match.ChatID)
+	}
+
+	request, err = repo.GetCurrentRequest(context.Background(), 8, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusWaiting, request.Status)
+	assert.Nil(t, request.Match)
+
+	_, err = repo.GetCurrentRequest(context.Background(), 9, now)
+	assert.ErrorIs(t, err, ErrRequestNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelRequest(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`
+        UPDATE partner_requests SET status = 'cancelled'
+        WHERE user_id = $1 AND status = 'waiting'`)
+	mock.ExpectExec(query).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(8).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.CancelRequest(context.Background(), 7))
+	assert.ErrorIs(t, repo.CancelRequest(context.Background(), 8), ErrRequestNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWaitingRequests(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        FROM partner_requests pr
+        LEFT JOIN improv_profile_styles ips ON ips.user_id = pr.user_id
+        WHERE pr.status = 'waiting' AND pr.available_until > $1
+        ORDER BY pr.created_at, pr.id, ips.style`)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "city_id", "level", "available_from", "available_until", "created_at", "style"}).
+			AddRow(10, 7, 1, LevelBeginner, now, now.Add(time.Hour), now, "longform").
+			AddRow(10, 7, 1, LevelBeginner, now, now.Add(time.Hour), now, "shortform").
+			AddRow(11, 8, 1, LevelAdvanced, now, now.Add(time.Hour), now, nil))
+
+	requests, err := repo.GetWaitingRequests(context.Background(), now)
+	assert.NoError(t, err)
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, []string{"longform", "shortform"}, requests[0].ImprovStyles)
+		assert.Equal(t, 8, requests[1].UserID)
+		assert.Empty(t, requests[1].ImprovStyles)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{"matched", 2, nil},
+		{"taken", 1, ErrRequestTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO partner_matches (user_id1, user_id2)`)).
+				WithArgs(10, 11).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectExec(regexp.QuoteMeta(`
+        UPDATE partner_requests SET status = 'matched', match_id = $3
+        WHERE id IN ($1, $2) AND status = 'waiting'`)).
+				WithArgs(10, 11, 3).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if tt.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			matchID, err := repo.CreateMatch(context.Background(), 10, 11)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, 3, matchID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
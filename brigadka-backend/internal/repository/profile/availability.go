@@ -0,0 +1,54 @@
+package profile
+
+import "database/sql"
+
+// AvailabilitySlot is a weekly time slot. Day is the ISO weekday (1 = Monday),
+// minutes are counted from midnight.
+type AvailabilitySlot struct {
+	Day         int
+	StartMinute int
+	EndMinute   int
+}
+
+// GetProfileAvailability returns the profile's weekly availability ordered by day and time
+func (r *PostgresRepository) GetProfileAvailability(userID int) ([]AvailabilitySlot, error) {
+	rows, err := r.db.Query(`
+        SELECT day_of_week, start_minute, end_minute
+        FROM profile_availability
+        WHERE user_id = $1
+        ORDER BY day_of_week, start_minute
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []AvailabilitySlot
+	for rows.Next() {
+		var slot AvailabilitySlot
+		if err := rows.Scan(&slot.Day, &slot.StartMinute, &slot.EndMinute); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, rows.Err()
+}
+
+// SetProfileAvailability replaces the profile's weekly availability
+func (r *PostgresRepository) SetProfileAvailability(tx *sql.Tx, userID int, slots []AvailabilitySlot) error {
+	_, err := tx.Exec(`DELETE FROM profile_availability WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, slot := range slots {
+		_, err := tx.Exec(`
+            INSERT INTO profile_availability (user_id, day_of_week, start_minute, end_minute)
+            VALUES ($1, $2, $3, $4)
+        `, userID, slot.Day, slot.StartMinute, slot.EndMinute)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
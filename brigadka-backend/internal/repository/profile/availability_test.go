@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProfileAvailability(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM profile_availability WHERE user_id = $1`)).
+		WithArgs(6).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`
+            INSERT INTO profile_availability (user_id, day_of_week, start_minute, end_minute)
+            VALUES ($1, $2, $3, $4)
+        `)).
+		WithArgs(6, 2, 19*60, 22*60).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SetProfileAvailability(tx, 6, []AvailabilitySlot{{Day: 2, StartMinute: 19 * 60, EndMinute: 22 * 60}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	tx.Rollback()
+}
+
+func TestGetProfileAvailability(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT day_of_week, start_minute, end_minute
+        FROM profile_availability
+        WHERE user_id = $1
+        ORDER BY day_of_week, start_minute
+    `)).
+		WithArgs(6).
+		WillReturnRows(sqlmock.NewRows([]string{"day_of_week", "start_minute", "end_minute"}).
+			AddRow(2, 19*60, 22*60).
+			AddRow(4, 18*60, 24*60))
+
+	slots, err := repo.GetProfileAvailability(6)
+	assert.NoError(t, err)
+	assert.Equal(t, []AvailabilitySlot{
+		{Day: 2, StartMinute: 19 * 60, EndMinute: 22 * 60},
+		{Day: 4, StartMinute: 18 * 60, EndMinute: 24 * 60},
+	}, slots)
+}
@@ -0,0 +1,96 @@
+package profile
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// City is a catalog city with its name and region in the requested language
+type City struct {
+	ID          int
+	Name        string
+	CountryCode string  // ISO 3166-1 alpha-2
+	Region      *string // Absent for cities without a region, e.g. capitals
+	Timezone    string  // IANA time zone
+}
+
+// Near limits a profile search to cities within the radius of a point
+type Near struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+// CityFilter narrows the city catalog. Zero values do not filter.
+type CityFilter struct {
+	Lang        string
+	CountryCode string
+	// Query matches the start of the city name or of any word in it
+	Query string
+	Limit int
+}
+
+// GetCities retrieves cities matching the filter, ordered by name.
+// Names and regions fall back to the untranslated values when the language has no translation.
+func (r *PostgresRepository) GetCities(filter CityFilter) ([]City, error) {
+	lang := filter.Lang
+	if lang == "" {
+		lang = "ru" // Default language
+	}
+
+	query := `
+        SELECT c.city_id, COALESCE(ct.name, c.name), c.country_code, COALESCE(ct.region, c.region), c.timezone
+        FROM cities c
+        LEFT JOIN city_translation ct ON ct.city_id = c.city_id AND ct.lang = $1`
+
+	conditions := []string{}
+	args := []interface{}{lang}
+	argIndex := 2
+
+	if filter.CountryCode != "" {
+		conditions = append(conditions, fmt.Sprintf("c.country_code = $%d", argIndex))
+		args = append(args, filter.CountryCode)
+		argIndex++
+	}
+
+	if filter.Query != "" {
+		// The untranslated name also matches, so a city is found whichever language the user types in
+		conditions = append(conditions, fmt.Sprintf(
+			"(COALESCE(ct.name, c.name) %[1]s $%[2]d OR COALESCE(ct.name, c.name) %[1]s $%[3]d OR c.name %[1]s $%[2]d)",
+			r.dialect.ILike(), argIndex, argIndex+1))
+		escaped := escapeLike(filter.Query)
+		args = append(args, escaped+"%", "% "+escaped+"%")
+		argIndex += 2
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY COALESCE(ct.name, c.name)"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cities := []City{}
+	for rows.Next() {
+		var city City
+		var region sql.NullString
+		if err := rows.Scan(&city.ID, &city.Name, &city.CountryCode, &region, &city.Timezone); err != nil {
+			return nil, err
+		}
+		if region.Valid {
+			city.Region = &region.String
+		}
+		cities = append(cities, city)
+	}
+	return cities, rows.Err()
+}
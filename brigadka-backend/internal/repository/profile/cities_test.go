@@ -0,0 +1,73 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var cityColumns = []string{"city_id", "name", "country_code", "region", "timezone"}
+
+func TestGetCities(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// Without filters every city is returned in the default language
+	mock.ExpectQuery(regexp.QuoteMeta(`LEFT JOIN city_translation ct ON ct.city_id = c.city_id AND ct.lang = $1 ORDER BY COALESCE(ct.name, c.name)`)).
+		WithArgs("ru").
+		WillReturnRows(sqlmock.NewRows(cityColumns).
+			AddRow(1, "Москва", "RU", "Москва", "Europe/Moscow").
+			AddRow(11, "Тбилиси", "GE", nil, "Asia/Tbilisi"))
+	cities, err := repo.GetCities(CityFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, cities, 2) {
+		assert.Equal(t, "Москва", *cities[0].Region)
+		assert.Nil(t, cities[1].Region)
+		assert.Equal(t, "Asia/Tbilisi", cities[1].Timezone)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCitiesFiltered(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE c.country_code = $2 AND (COALESCE(ct.name, c.name) ILIKE $3 OR COALESCE(ct.name, c.name) ILIKE $4 OR c.name ILIKE $3)`)+`.*`+
+		regexp.QuoteMeta(`LIMIT $5`)).
+		WithArgs("en", "RU", "nov%", "% nov%", 20).
+		WillReturnRows(sqlmock.NewRows(cityColumns).
+			AddRow(5, "Nizhny Novgorod", "RU", "Nizhny Novgorod Oblast", "Europe/Moscow").
+			AddRow(3, "Novosibirsk", "RU", "Novosibirsk Oblast", "Asia/Novosibirsk"))
+
+	cities, err := repo.GetCities(CityFilter{Lang: "en", CountryCode: "RU", Query: "nov", Limit: 20})
+	assert.NoError(t, err)
+	if assert.Len(t, cities, 2) {
+		assert.Equal(t, "Nizhny Novgorod", cities[0].Name)
+		assert.Equal(t, "Novosibirsk Oblast", *cities[1].Region)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchProfilesNear(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	near := &Near{Latitude: 55.75, Longitude: 37.62, RadiusKm: 50}
+
+	// The radius is passed in meters, right after the current user
+	mock.ExpectQuery(regexp.QuoteMeta(`JOIN cities pc ON pc.city_id = p.city_id WHERE`)+`.*`+
+		regexp.QuoteMeta(`earth_distance(ll_to_earth(pc.latitude, pc.longitude), ll_to_earth($2, $3)) <= $4) SELECT COUNT(*)`)).
+		WithArgs(5, 55.75, 37.62, 50000.0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY distance_km, style_match_count DESC, created_at DESC LIMIT $5 OFFSET $6`)).
+		WithArgs(5, 55.75, 37.62, 50000.0, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	profiles, total, _, err := repo.SearchProfiles(5, "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, near, false, nil, false, 1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, profiles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package profile
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// EndorsementCount is the number of users who endorsed a profile for a style
+type EndorsementCount struct {
+	Style string
+	Count int
+}
+
+// GetSharedTeam returns a team both users are members of, or 0 when they have none
+func (r *PostgresRepository) GetSharedTeam(userID int, otherUserID int) (int, error) {
+	var teamID int
+	err := r.db.QueryRow(`
+        SELECT a.team_id
+        FROM team_members a
+        JOIN team_members b ON b.team_id = a.team_id
+        WHERE a.user_id = $1 AND b.user_id = $2
+        ORDER BY a.team_id
+        LIMIT 1
+    `, userID, otherUserID).Scan(&teamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return teamID, err
+}
+
+// AddEndorsement endorses a profile for a style; endorsing it again is a no-op
+func (r *PostgresRepository) AddEndorsement(endorserID int, profileUserID int, style string, teamID int) error {
+	_, err := r.db.Exec(`
+        INSERT INTO profile_endorsements (endorser_id, profile_user_id, style, team_id)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (endorser_id, profile_user_id, style) DO NOTHING
+    `, endorserID, profileUserID, style, teamID)
+	return err
+}
+
+// RemoveEndorsement withdraws the user's endorsement of a profile for a style
+func (r *PostgresRepository) RemoveEndorsement(endorserID int, profileUserID int, style string) error {
+	_, err := r.db.Exec(`
+        DELETE FROM profile_endorsements
+        WHERE endorser_id = $1 AND profile_user_id = $2 AND style = $3
+    `, endorserID, profileUserID, style)
+	return err
+}
+
+// GetEndorsementCounts returns the endorsements of a profile by style, most endorsed first
+func (r *PostgresRepository) GetEndorsementCounts(userID int) ([]EndorsementCount, error) {
+	rows, err := r.db.Query(`
+        SELECT style, COUNT(*)
+        FROM profile_endorsements
+        WHERE profile_user_id = $1
+        GROUP BY style
+        ORDER BY COUNT(*) DESC, style
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []EndorsementCount
+	for rows.Next() {
+		var count EndorsementCount
+		if err := rows.Scan(&count.Style, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
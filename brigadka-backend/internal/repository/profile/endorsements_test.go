@@ -0,0 +1,93 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSharedTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`
+        SELECT a.team_id
+        FROM team_members a
+        JOIN team_members b ON b.team_id = a.team_id
+        WHERE a.user_id = $1 AND b.user_id = $2
+        ORDER BY a.team_id
+        LIMIT 1
+    `)
+	mock.ExpectQuery(query).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id"}).AddRow(7))
+	mock.ExpectQuery(query).
+		WithArgs(1, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id"}))
+
+	teamID, err := repo.GetSharedTeam(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, teamID)
+
+	teamID, err = repo.GetSharedTeam(1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, teamID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddEndorsement(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO profile_endorsements (endorser_id, profile_user_id, style, team_id)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (endorser_id, profile_user_id, style) DO NOTHING
+    `)).
+		WithArgs(1, 2, "longform", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.AddEndorsement(1, 2, "longform", 7)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveEndorsement(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        DELETE FROM profile_endorsements
+        WHERE endorser_id = $1 AND profile_user_id = $2 AND style = $3
+    `)).
+		WithArgs(1, 2, "longform").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.RemoveEndorsement(1, 2, "longform")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEndorsementCounts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT style, COUNT(*)
+        FROM profile_endorsements
+        WHERE profile_user_id = $1
+        GROUP BY style
+        ORDER BY COUNT(*) DESC, style
+    `)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"style", "count"}).
+			AddRow("longform", 3).
+			AddRow("shortform", 1))
+
+	counts, err := repo.GetEndorsementCounts(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []EndorsementCount{{Style: "longform", Count: 3}, {Style: "shortform", Count: 1}}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
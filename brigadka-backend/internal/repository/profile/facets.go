@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"sync"
+)
+
+// FacetCount is the number of matching profiles with a facet value
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// SearchFacets holds the matching profiles counted per filter value, most common first
+type SearchFacets struct {
+	Cities       []FacetCount // Values are city IDs
+	ImprovStyles []FacetCount
+	Goals        []FacetCount
+}
+
+// Facet queries select the facet value and the number of matches from the profile_matches CTE
+const (
+	cityFacetQuery = `) SELECT CAST(p.city_id AS TEXT), COUNT(*) FROM profile_matches pm
+        JOIN profiles p ON p.user_id = pm.user_id
+        WHERE p.city_id IS NOT NULL
+        GROUP BY p.city_id ORDER BY COUNT(*) DESC, p.city_id`
+	styleFacetQuery = `) SELECT ips.style, COUNT(*) FROM profile_matches pm
+        JOIN improv_profile_styles ips ON ips.user_id = pm.user_id
+        GROUP BY ips.style ORDER BY COUNT(*) DESC, ips.style`
+	goalFacetQuery = `) SELECT ipg.goal, COUNT(*) FROM profile_matches pm
+        JOIN improv_profile_goals ipg ON ipg.user_id = pm.user_id
+        GROUP BY ipg.goal ORDER BY COUNT(*) DESC, ipg.goal`
+)
+
+// searchFacets counts the matches of a search per city, improv style and goal.
+// matches is the search query up to the end of the profile_matches CTE; the
+// three GROUP BY queries run in parallel with the search arguments.
+func (r *PostgresRepository) searchFacets(matches string, args []interface{}) (*SearchFacets, error) {
+	facets := &SearchFacets{}
+	targets := []struct {
+		query  string
+		counts *[]FacetCount
+	}{
+		{cityFacetQuery, &facets.Cities},
+		{styleFacetQuery, &facets.ImprovStyles},
+		{goalFacetQuery, &facets.Goals},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, query string, counts *[]FacetCount) {
+			defer wg.Done()
+			*counts, errs[i] = r.countFacet(matches+query, args)
+		}(i, target.query, target.counts)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+func (r *PostgresRepository) countFacet(query string, args []interface{}) ([]FacetCount, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []FacetCount{}
+	for rows.Next() {
+		var count FacetCount
+		if err := rows.Scan(&count.Value, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,68 @@
+package profile
+
+// AddFavorite adds a profile to the user's favorites; adding it again is a no-op
+func (r *PostgresRepository) AddFavorite(userID int, profileUserID int) error {
+	_, err := r.db.Exec(`
+        INSERT INTO profile_favorites (user_id, profile_user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, profile_user_id) DO NOTHING
+    `, userID, profileUserID)
+	return err
+}
+
+// RemoveFavorite removes a profile from the user's favorites
+func (r *PostgresRepository) RemoveFavorite(userID int, profileUserID int) error {
+	_, err := r.db.Exec(`
+        DELETE FROM profile_favorites
+        WHERE user_id = $1 AND profile_user_id = $2
+    `, userID, profileUserID)
+	return err
+}
+
+// GetFavorites returns a page of the user's favorite profiles, most recently added first
+func (r *PostgresRepository) GetFavorites(userID int, page int, pageSize int) ([]*ProfileModel, int, error) {
+	var totalCount int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM profile_favorites WHERE user_id = $1`, userID).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+        SELECT p.user_id, p.full_name, p.birthday, p.gender, p.city_id,
+               p.bio, p.goal, p.looking_for_team, p.allow_organizer_contact, p.created_at,
+               p.verified_at IS NOT NULL
+        FROM profile_favorites pf
+        JOIN profiles p ON p.user_id = pf.profile_user_id
+        WHERE pf.user_id = $1
+        ORDER BY pf.created_at DESC
+        LIMIT $2 OFFSET $3
+    `, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	profiles := []*ProfileModel{}
+	for rows.Next() {
+		profile := &ProfileModel{IsFavorite: true}
+		if err := rows.Scan(
+			&profile.UserID, &profile.FullName, &profile.Birthday,
+			&profile.Gender, &profile.CityID, &profile.Bio,
+			&profile.Goal, &profile.LookingForTeam, &profile.AllowOrganizerContact,
+			&profile.CreatedAt, &profile.IsVerified,
+		); err != nil {
+			return nil, 0, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.hydrateProfiles(profiles); err != nil {
+		return nil, 0, err
+	}
+
+	return profiles, totalCount, nil
+}
@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFavorite(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO profile_favorites (user_id, profile_user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, profile_user_id) DO NOTHING
+    `)).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.AddFavorite(1, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        DELETE FROM profile_favorites
+        WHERE user_id = $1 AND profile_user_id = $2
+    `)).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.RemoveFavorite(1, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFavorites(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM profile_favorites WHERE user_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM profile_favorites pf`)).
+		WithArgs(1, 20, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "full_name", "birthday", "gender", "city_id", "bio", "goal", "looking_for_team", "allow_organizer_contact", "created_at", "is_verified"}).
+			AddRow(2, "Fav User", now, "female", 1, "bio", "hobby", true, false, now, true))
+
+	expectHydration(mock, 2)
+
+	profiles, total, err := repo.GetFavorites(1, 2, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 21, total)
+	if assert.Len(t, profiles, 1) {
+		assert.Equal(t, 2, profiles[0].UserID)
+		assert.True(t, profiles[0].IsFavorite)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
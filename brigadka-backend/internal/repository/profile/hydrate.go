@@ -0,0 +1,154 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetProfilesByIDs returns the visible profiles among userIDs in the given order,
+// with is_favorite set for the current user. It loads the pages of external search results.
+func (r *PostgresRepository) GetProfilesByIDs(currentUserID int, userIDs []int) ([]*ProfileModel, error) {
+	if len(userIDs) == 0 {
+		return []*ProfileModel{}, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := []interface{}{currentUserID}
+	for i, userID := range userIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, userID)
+	}
+
+	rows, err := r.db.Query(`
+        SELECT p.user_id, p.full_name, p.birthday, p.gender, p.city_id,
+               p.bio, p.goal, p.looking_for_team, p.allow_organizer_contact, p.created_at,
+               p.verified_at IS NOT NULL,
+               EXISTS(
+                   SELECT 1 FROM profile_favorites pf
+                   WHERE pf.user_id = $1 AND pf.profile_user_id = p.user_id
+               )
+        FROM profiles p
+        WHERE p.user_id IN (`+strings.Join(placeholders, ", ")+`) AND p.hidden_at IS NULL AND p.shadowbanned_at IS NULL
+    `, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byUserID := make(map[int]*ProfileModel, len(userIDs))
+	for rows.Next() {
+		profile := &ProfileModel{}
+		if err := rows.Scan(
+			&profile.UserID, &profile.FullName, &profile.Birthday,
+			&profile.Gender, &profile.CityID, &profile.Bio,
+			&profile.Goal, &profile.LookingForTeam, &profile.AllowOrganizerContact,
+			&profile.CreatedAt, &profile.IsVerified, &profile.IsFavorite,
+		); err != nil {
+			return nil, err
+		}
+		byUserID[profile.UserID] = profile
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Profiles hidden or deleted since they were found are skipped
+	profiles := make([]*ProfileModel, 0, len(byUserID))
+	for _, userID := range userIDs {
+		if profile, ok := byUserID[userID]; ok {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	if err := r.hydrateProfiles(profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// hydrateProfiles loads the media, improv styles and goals of a page of profiles
+// with one query each, instead of several queries per profile
+func (r *PostgresRepository) hydrateProfiles(profiles []*ProfileModel) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	byUserID := make(map[int]*ProfileModel, len(profiles))
+	placeholders := make([]string, len(profiles))
+	args := make([]interface{}, len(profiles))
+	for i, profile := range profiles {
+		byUserID[profile.UserID] = profile
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = profile.UserID
+		// Empty rather than nil marks the lists as loaded
+		profile.ImprovStyles = []string{}
+		profile.Goals = []string{}
+	}
+	userIDs := strings.Join(placeholders, ", ")
+
+	rows, err := r.db.Query(`
+        SELECT user_id, media_id, role FROM profile_media
+        WHERE user_id IN (`+userIDs+`) AND role IN ('avatar', 'audio_intro', 'video') AND `+approvedMedia+`
+        ORDER BY user_id, media_id
+    `, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, mediaID int
+		var role string
+		if err := rows.Scan(&userID, &mediaID, &role); err != nil {
+			return err
+		}
+		profile := byUserID[userID]
+		switch role {
+		case roleAvatar:
+			if profile.Avatar == nil {
+				profile.Avatar = &mediaID
+			}
+		case roleAudioIntro:
+			if profile.AudioIntro == nil {
+				profile.AudioIntro = &mediaID
+			}
+		case roleVideo:
+			profile.Videos = append(profile.Videos, mediaID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	err = r.scanUserValues(`SELECT user_id, style FROM improv_profile_styles WHERE user_id IN (`+userIDs+`)`, args,
+		func(userID int, style string) {
+			byUserID[userID].ImprovStyles = append(byUserID[userID].ImprovStyles, style)
+		})
+	if err != nil {
+		return err
+	}
+
+	return r.scanUserValues(`SELECT user_id, goal FROM improv_profile_goals WHERE user_id IN (`+userIDs+`)`, args,
+		func(userID int, goal string) {
+			byUserID[userID].Goals = append(byUserID[userID].Goals, goal)
+		})
+}
+
+// scanUserValues runs a query returning (user_id, value) rows and passes each row to add
+func (r *PostgresRepository) scanUserValues(query string, args []interface{}, add func(userID int, value string)) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var value string
+		if err := rows.Scan(&userID, &value); err != nil {
+			return err
+		}
+		add(userID, value)
+	}
+	return rows.Err()
+}
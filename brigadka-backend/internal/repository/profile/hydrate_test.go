@@ -0,0 +1,98 @@
+package profile
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectHydration expects the batched media, styles and goals queries for profiles without any
+func expectHydration(mock sqlmock.Sqlmock, userIDs ...driver.Value) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, media_id, role FROM profile_media`)).
+		WithArgs(userIDs...).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "media_id", "role"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, style FROM improv_profile_styles`)).
+		WithArgs(userIDs...).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "style"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, goal FROM improv_profile_goals`)).
+		WithArgs(userIDs...).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "goal"}))
+}
+
+func TestSearchProfilesHydratesPage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM profile_matches`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM profile_matches`)).
+		WithArgs(5, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "full_name", "birthday", "gender", "city_id", "bio", "goal",
+			"looking_for_team", "allow_organizer_contact", "created_at", "is_verified", "is_favorite",
+			"style_match_count", "distance_km", "relevance"}).
+			AddRow(2, "Anna", now, "female", 1, "", "hobby", true, false, now, false, false, 1, nil, 0.0).
+			AddRow(3, "Boris", now, "male", 1, "", "career", false, false, now, false, false, 0, nil, 0.0))
+
+	// One query per kind of data for the whole page, whatever its size
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id IN ($1, $2) AND role IN ('avatar', 'audio_intro', 'video')`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "media_id", "role"}).
+			AddRow(2, 10, "avatar").
+			AddRow(2, 11, "video").
+			AddRow(2, 12, "video").
+			AddRow(3, 13, "audio_intro"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, style FROM improv_profile_styles WHERE user_id IN ($1, $2)`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "style"}).AddRow(2, "shortform").AddRow(2, "longform"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, goal FROM improv_profile_goals WHERE user_id IN ($1, $2)`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "goal"}).AddRow(2, "hobby").AddRow(3, "career"))
+
+	profiles, total, _, err := repo.SearchProfiles(5, "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil, false, nil, false, 1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	if assert.Len(t, profiles, 2) {
+		assert.Equal(t, 10, *profiles[0].Avatar)
+		assert.Nil(t, profiles[0].AudioIntro)
+		assert.Equal(t, []int{11, 12}, profiles[0].Videos)
+		assert.Equal(t, []string{"shortform", "longform"}, profiles[0].ImprovStyles)
+		assert.Equal(t, []string{"hobby"}, profiles[0].Goals)
+
+		assert.Nil(t, profiles[1].Avatar)
+		assert.Equal(t, 13, *profiles[1].AudioIntro)
+		// Loaded but empty, so the service does not query the styles again
+		assert.Equal(t, []string{}, profiles[1].ImprovStyles)
+		assert.Equal(t, []string{"career"}, profiles[1].Goals)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProfilesByIDsKeepsOrder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE p.user_id IN ($2, $3, $4) AND p.hidden_at IS NULL`)).
+		WithArgs(5, 3, 9, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "full_name", "birthday", "gender", "city_id", "bio", "goal",
+			"looking_for_team", "allow_organizer_contact", "created_at", "is_verified", "is_favorite"}).
+			AddRow(2, "Anna", now, "female", 1, "", "hobby", true, false, now, false, true).
+			AddRow(3, "Boris", now, "male", 1, "", "career", false, false, now, false, false))
+	// Profile 9 was hidden after it was indexed
+	expectHydration(mock, 3, 2)
+
+	profiles, err := repo.GetProfilesByIDs(5, []int{3, 9, 2})
+	assert.NoError(t, err)
+	if assert.Len(t, profiles, 2) {
+		assert.Equal(t, 3, profiles[0].UserID)
+		assert.Equal(t, 2, profiles[1].UserID)
+		assert.True(t, profiles[1].IsFavorite)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,52 @@
+package profile
+
+import (
+	"database/sql"
+	"sort"
+)
+
+// GetProfileLinks returns the profile's links keyed by kind (instagram, telegram, youtube, website)
+func (r *PostgresRepository) GetProfileLinks(userID int) (map[string]string, error) {
+	rows, err := r.db.Query(`
+        SELECT kind, url FROM profile_links WHERE user_id = $1
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := map[string]string{}
+	for rows.Next() {
+		var kind, url string
+		if err := rows.Scan(&kind, &url); err != nil {
+			return nil, err
+		}
+		links[kind] = url
+	}
+	return links, rows.Err()
+}
+
+// SetProfileLinks replaces all links of a profile
+func (r *PostgresRepository) SetProfileLinks(tx *sql.Tx, userID int, links map[string]string) error {
+	_, err := tx.Exec(`DELETE FROM profile_links WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	kinds := make([]string, 0, len(links))
+	for kind := range links {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		_, err := tx.Exec(`
+            INSERT INTO profile_links (user_id, kind, url)
+            VALUES ($1, $2, $3)
+        `, userID, kind, links[kind])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
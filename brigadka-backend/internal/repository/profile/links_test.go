@@ -0,0 +1,63 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProfileLinks(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT kind, url FROM profile_links WHERE user_id = $1
+    `)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "url"}).
+			AddRow("instagram", "https://instagram.com/impro").
+			AddRow("website", "https://impro.example.com"))
+
+	links, err := repo.GetProfileLinks(4)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"instagram": "https://instagram.com/impro",
+		"website":   "https://impro.example.com",
+	}, links)
+}
+
+func TestSetProfileLinks(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	// Existing links are replaced, new ones are inserted in kind order
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM profile_links WHERE user_id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`
+            INSERT INTO profile_links (user_id, kind, url)
+            VALUES ($1, $2, $3)
+        `)).
+		WithArgs(4, "telegram", "https://t.me/impro_team").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`
+            INSERT INTO profile_links (user_id, kind, url)
+            VALUES ($1, $2, $3)
+        `)).
+		WithArgs(4, "youtube", "https://youtube.com/@impro").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SetProfileLinks(tx, 4, map[string]string{
+		"youtube":  "https://youtube.com/@impro",
+		"telegram": "https://t.me/impro_team",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	tx.Rollback()
+}
@@ -0,0 +1,123 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// RecommendationCandidate is a profile considered for recommendations
+// together with the signals it is ranked by
+type RecommendationCandidate struct {
+	Profile      *ProfileModel
+	SharedStyles int
+	SharedGoals  int
+	SameCity     bool
+	LastActiveAt time.Time // Latest message sent, or the profile creation when there are none
+}
+
+// GetRecommendationCandidates returns up to limit profiles to recommend to the user,
+// preferring ones that share styles, goals or the city, then recently active ones.
+// The user, hidden profiles, suspended users, profiles the user reported and users
+// the user already has a direct chat with are excluded.
+func (r *PostgresRepository) GetRecommendationCandidates(userID int, now time.Time, limit int) ([]RecommendationCandidate, error) {
+	// Users without improv styles are matched by the formats from their onboarding quiz, as in search
+	rows, err := r.db.Query(`
+        WITH current_user_styles AS (
+            SELECT style FROM improv_profile_styles WHERE user_id = $1
+            UNION
+            SELECT style FROM onboarding_formats
+            WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM improv_profile_styles WHERE user_id = $1)
+        ),
+        current_user_goals AS (
+            SELECT goal FROM improv_profile_goals WHERE user_id = $1
+        ),
+        candidates AS (
+            SELECT
+                p.user_id,
+                p.full_name,
+                p.birthday,
+                p.gender,
+                p.city_id,
+                p.bio,
+                p.goal,
+                p.looking_for_team,
+                p.allow_organizer_contact,
+                p.created_at,
+                p.verified_at IS NOT NULL AS is_verified,
+                EXISTS(
+                    SELECT 1 FROM profile_favorites pf
+                    WHERE pf.user_id = $1 AND pf.profile_user_id = p.user_id
+                ) AS is_favorite,
+                (
+                    SELECT COUNT(*)
+                    FROM improv_profile_styles ips
+                    JOIN current_user_styles cus ON ips.style = cus.style
+                    WHERE ips.user_id = p.user_id
+                ) AS shared_styles,
+                (
+                    SELECT COUNT(*)
+                    FROM improv_profile_goals ipg
+                    JOIN current_user_goals cug ON ipg.goal = cug.goal
+                    WHERE ipg.user_id = p.user_id
+                ) AS shared_goals,
+                COALESCE(p.city_id = (SELECT city_id FROM profiles WHERE user_id = $1), FALSE) AS same_city,
+                COALESCE((SELECT MAX(m.sent_at) FROM messages m WHERE m.sender_id = p.user_id), p.created_at) AS last_active_at
+            FROM profiles p
+            WHERE p.user_id <> $1
+              AND p.hidden_at IS NULL
+              AND p.shadowbanned_at IS NULL
+              AND NOT EXISTS (
+                  SELECT 1 FROM user_suspensions s
+                  WHERE s.user_id = p.user_id AND s.lifted_at IS NULL AND (s.ends_at IS NULL OR s.ends_at > $2)
+              )
+              AND NOT EXISTS (
+                  SELECT 1 FROM reports rp
+                  WHERE rp.reporter_id = $1 AND rp.target_type = 'profile' AND rp.target_id = CAST(p.user_id AS VARCHAR(64))
+              )
+              AND NOT EXISTS (
+                  SELECT 1 FROM chats c
+                  JOIN chat_participants me ON me.chat_id = c.id AND me.user_id = $1
+                  JOIN chat_participants them ON them.chat_id = c.id AND them.user_id = p.user_id
+                  WHERE NOT c.is_group
+              )
+        )
+        SELECT * FROM candidates
+        ORDER BY shared_styles + shared_goals + CASE WHEN same_city THEN 1 ELSE 0 END DESC, last_active_at DESC
+        LIMIT $3
+    `, userID, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []RecommendationCandidate{}
+	for rows.Next() {
+		profile := &ProfileModel{}
+		candidate := RecommendationCandidate{Profile: profile}
+		if err := rows.Scan(
+			&profile.UserID, &profile.FullName, &profile.Birthday,
+			&profile.Gender, &profile.CityID, &profile.Bio,
+			&profile.Goal, &profile.LookingForTeam, &profile.AllowOrganizerContact,
+			&profile.CreatedAt, &profile.IsVerified, &profile.IsFavorite,
+			&candidate.SharedStyles, &candidate.SharedGoals, &candidate.SameCity, database.Time(&candidate.LastActiveAt),
+		); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Media, styles and goals are loaded only for the candidates, not for every scanned profile
+	profiles := make([]*ProfileModel, len(candidates))
+	for i, candidate := range candidates {
+		profiles[i] = candidate.Profile
+	}
+	if err := r.hydrateProfiles(profiles); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecommendationCandidates(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	lastActive := now.Add(-time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE NOT c.is_group`)+`.*`+
+		regexp.QuoteMeta(`ORDER BY shared_styles + shared_goals + CASE WHEN same_city THEN 1 ELSE 0 END DESC, last_active_at DESC`)).
+		WithArgs(1, now, 200).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "full_name", "birthday", "gender", "city_id", "bio", "goal",
+			"looking_for_team", "allow_organizer_contact", "created_at", "is_verified", "is_favorite",
+			"shared_styles", "shared_goals", "same_city", "last_active_at"}).
+			AddRow(2, "Anna", now, "female", 1, "bio", "hobby", true, false, now, false, true, 2, 1, true, lastActive))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, media_id, role FROM profile_media`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "media_id", "role"}).AddRow(2, 10, "avatar"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, style FROM improv_profile_styles`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "style"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, goal FROM improv_profile_goals`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "goal"}))
+
+	candidates, err := repo.GetRecommendationCandidates(1, now, 200)
+	assert.NoError(t, err)
+	if assert.Len(t, candidates, 1) {
+		assert.Equal(t, 2, candidates[0].Profile.UserID)
+		assert.True(t, candidates[0].Profile.IsFavorite)
+		assert.Equal(t, 10, *candidates[0].Profile.Avatar)
+		assert.Equal(t, 2, candidates[0].SharedStyles)
+		assert.Equal(t, 1, candidates[0].SharedGoals)
+		assert.True(t, candidates[0].SameCity)
+		assert.Equal(t, lastActive, candidates[0].LastActiveAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 )
 
 // Repository errors
@@ -20,38 +23,52 @@ var (
 	ErrInvalidMediaRole = errors.New("invalid media role")
 )
 
+// approvedMedia limits profile media to uploads that passed moderation
+const approvedMedia = `EXISTS (SELECT 1 FROM media m WHERE m.id = media_id AND m.moderation_status = 'approved')`
+
 var (
-	roleVideo  = "video"
-	roleAvatar = "avatar"
+	roleVideo      = "video"
+	roleAvatar     = "avatar"
+	roleAudioIntro = "audio_intro"
 )
 
 // ProfileModel represents the profile data
 type ProfileModel struct {
-	UserID         int
-	FullName       string
-	Birthday       time.Time
-	Gender         string
-	CityID         int
-	Bio            string
-	Goal           string
-	LookingForTeam bool
-	CreatedAt      time.Time
-	Avatar         *int
-	Videos         []int
+	UserID                int
+	FullName              string
+	Birthday              time.Time
+	Gender                string
+	CityID                int
+	Bio                   string
+	Goal                  string // Primary goal; all goals are in improv_profile_goals
+	LookingForTeam        bool
+	AllowOrganizerContact bool
+	IsFavorite            bool
+	IsVerified            bool
+	CreatedAt             time.Time
+	DistanceKm            *float64 // Set only by searches near a point
+	Avatar                *int
+	AudioIntro            *int
+	Videos                []int
+	// Loaded together with the media for pages of profiles; nil when not loaded
+	ImprovStyles []string
+	Goals        []string
 }
 
 // UpdateProfileModel represents the updated profile data
 type UpdateProfileModel struct {
-	UserID         int
-	FullName       *string
-	Birthday       *time.Time
-	Gender         *string
-	CityID         *int
-	Bio            *string
-	Goal           *string
-	LookingForTeam *bool
-	Avatar         *int
-	Videos         []int
+	UserID                int
+	FullName              *string
+	Birthday              *time.Time
+	Gender                *string
+	CityID                *int
+	Bio                   *string
+	Goal                  *string
+	LookingForTeam        *bool
+	AllowOrganizerContact *bool
+	Avatar                *int
+	AudioIntro            *int
+	Videos                []int
 }
 
 // TranslatedItem represents a catalog item with translations
@@ -63,12 +80,13 @@ type TranslatedItem struct {
 
 // PostgresRepository implements Repository interface
 type PostgresRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 // NewPostgresRepository creates a new PostgreSQL repository
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{db: db}
+	return &PostgresRepository{db: db, dialect: database.DialectFor(db)}
 }
 
 // BeginTx starts a new transaction
@@ -97,11 +115,12 @@ func (r *PostgresRepository) CreateProfile(tx *sql.Tx, profile *ProfileModel) (t
 	err := tx.QueryRow(`
         INSERT INTO profiles (
             user_id, full_name, birthday, gender, city_id, 
-            bio, goal, looking_for_team
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+            bio, goal, looking_for_team, allow_organizer_contact
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) 
         RETURNING created_at
     `, profile.UserID, profile.FullName, profile.Birthday, profile.Gender,
-		profile.CityID, profile.Bio, profile.Goal, profile.LookingForTeam).Scan(&profile.CreatedAt)
+		profile.CityID, profile.Bio, profile.Goal, profile.LookingForTeam,
+		profile.AllowOrganizerContact).Scan(&profile.CreatedAt)
 
 	return createdAt, err
 }
@@ -120,17 +139,33 @@ func (r *PostgresRepository) AddImprovStyles(tx *sql.Tx, userID int, styles []st
 	return nil
 }
 
+// AddImprovGoals adds goals to a profile
+func (r *PostgresRepository) AddImprovGoals(tx *sql.Tx, userID int, goals []string) error {
+	for _, goal := range goals {
+		_, err := tx.Exec(`
+            INSERT INTO improv_profile_goals (user_id, goal)
+            VALUES ($1, $2)
+        `, userID, goal)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetProfile retrieves a profile by user ID
 func (r *PostgresRepository) GetProfile(userID int) (*ProfileModel, error) {
 	profile := &ProfileModel{}
 	err := r.db.QueryRow(`
         SELECT user_id, full_name, birthday, gender, city_id, 
-               bio, goal, looking_for_team, created_at 
+               bio, goal, looking_for_team, allow_organizer_contact, created_at,
+               verified_at IS NOT NULL
         FROM profiles WHERE user_id = $1
     `, userID).Scan(
 		&profile.UserID, &profile.FullName, &profile.Birthday,
 		&profile.Gender, &profile.CityID, &profile.Bio,
-		&profile.Goal, &profile.LookingForTeam, &profile.CreatedAt)
+		&profile.Goal, &profile.LookingForTeam, &profile.AllowOrganizerContact,
+		&profile.CreatedAt, &profile.IsVerified)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -145,6 +180,12 @@ func (r *PostgresRepository) GetProfile(userID int) (*ProfileModel, error) {
 		profile.Avatar = avatar
 	}
 
+	// Get audio introduction
+	audioIntro, err := r.GetProfileAudioIntro(userID)
+	if err == nil && audioIntro != nil {
+		profile.AudioIntro = audioIntro
+	}
+
 	// Get videos
 	videos, err := r.GetProfileVideos(userID)
 	if err == nil {
@@ -165,7 +206,7 @@ func (r *PostgresRepository) GetProfileAvatar(userID int) (*int, error) {
 	var mediaID int
 	err := r.db.QueryRow(`
         SELECT media_id FROM profile_media 
-        WHERE user_id = $1 AND role = 'avatar'
+        WHERE user_id = $1 AND role = 'avatar' AND `+approvedMedia+`
         LIMIT 1
     `, userID).Scan(&mediaID)
 
@@ -178,11 +219,43 @@ func (r *PostgresRepository) GetProfileAvatar(userID int) (*int, error) {
 	return &mediaID, nil
 }
 
+// HasAvatar checks whether the profile has an avatar, counting ones still in review.
+// Unlike GetProfileAvatar it only skips rejected media.
+func (r *PostgresRepository) HasAvatar(userID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+        SELECT EXISTS(
+            SELECT 1 FROM profile_media pm
+            JOIN media m ON m.id = pm.media_id
+            WHERE pm.user_id = $1 AND pm.role = 'avatar' AND m.moderation_status <> 'rejected'
+        )
+    `, userID).Scan(&exists)
+	return exists, err
+}
+
+// GetProfileAudioIntro retrieves the audio introduction for a profile
+func (r *PostgresRepository) GetProfileAudioIntro(userID int) (*int, error) {
+	var mediaID int
+	err := r.db.QueryRow(`
+        SELECT media_id FROM profile_media 
+        WHERE user_id = $1 AND role = 'audio_intro' AND `+approvedMedia+`
+        LIMIT 1
+    `, userID).Scan(&mediaID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // No audio introduction, not an error
+		}
+		return nil, err
+	}
+	return &mediaID, nil
+}
+
 // GetProfileVideos retrieves videos for a profile
 func (r *PostgresRepository) GetProfileVideos(userID int) ([]int, error) {
 	rows, err := r.db.Query(`
         SELECT media_id FROM profile_media 
-        WHERE user_id = $1 AND role = 'video'
+        WHERE user_id = $1 AND role = 'video' AND `+approvedMedia+`
     `, userID)
 	if err != nil {
 		return nil, err
@@ -248,6 +321,16 @@ func (r *PostgresRepository) SetProfileAvatar(tx *sql.Tx, userID int, mediaID in
 	return r.addProfileMedia(tx, userID, mediaID, "avatar")
 }
 
+// SetProfileAudioIntro sets the audio introduction for a profile
+// It replaces any existing audio introduction
+func (r *PostgresRepository) SetProfileAudioIntro(tx *sql.Tx, userID int, mediaID int) error {
+	err := r.RemoveProfileMediaByRole(tx, userID, roleAudioIntro)
+	if err != nil {
+		return err
+	}
+	return r.addProfileMedia(tx, userID, mediaID, roleAudioIntro)
+}
+
 // RemoveProfileMedia removes specific media from a profile
 func (r *PostgresRepository) RemoveProfileMedia(tx *sql.Tx, userID int, mediaID int) error {
 	_, err := tx.Exec(`
@@ -285,6 +368,27 @@ func (r *PostgresRepository) GetImprovStyles(userID int) ([]string, error) {
 	return styles, rows.Err()
 }
 
+// GetImprovGoals retrieves all goals of a profile, including the primary one
+func (r *PostgresRepository) GetImprovGoals(userID int) ([]string, error) {
+	rows, err := r.db.Query(`
+        SELECT goal FROM improv_profile_goals WHERE user_id = $1 ORDER BY goal
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []string
+	for rows.Next() {
+		var goal string
+		if err = rows.Scan(&goal); err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+	return goals, rows.Err()
+}
+
 // UpdateProfile updates a profile, only changing fields that are not nil in the update model
 func (r *PostgresRepository) UpdateProfile(tx *sql.Tx, profile *UpdateProfileModel) error {
 	// Start with base query
@@ -338,6 +442,12 @@ func (r *PostgresRepository) UpdateProfile(tx *sql.Tx, profile *UpdateProfileMod
 		paramPositions = append(paramPositions, fmt.Sprintf("looking_for_team = $%d", paramCount))
 	}
 
+	if profile.AllowOrganizerContact != nil {
+		paramCount++
+		params = append(params, *profile.AllowOrganizerContact)
+		paramPositions = append(paramPositions, fmt.Sprintf("allow_organizer_contact = $%d", paramCount))
+	}
+
 	// If no parameters were provided, return without executing query
 	if len(params) == 0 {
 		return nil
@@ -356,12 +466,27 @@ func (r *PostgresRepository) UpdateProfile(tx *sql.Tx, profile *UpdateProfileMod
 	return err
 }
 
+// AddConsentAudit records a change of a profile consent setting
+func (r *PostgresRepository) AddConsentAudit(tx *sql.Tx, userID int, setting string, allowed bool) error {
+	_, err := tx.Exec(`
+        INSERT INTO profile_consent_audit (user_id, setting, allowed)
+        VALUES ($1, $2, $3)
+    `, userID, setting, allowed)
+	return err
+}
+
 // ClearImprovStyles removes all styles from a profile
 func (r *PostgresRepository) ClearImprovStyles(tx *sql.Tx, userID int) error {
 	_, err := tx.Exec(`DELETE FROM improv_profile_styles WHERE user_id = $1`, userID)
 	return err
 }
 
+// ClearImprovGoals removes all goals from a profile
+func (r *PostgresRepository) ClearImprovGoals(tx *sql.Tx, userID int) error {
+	_, err := tx.Exec(`DELETE FROM improv_profile_goals WHERE user_id = $1`, userID)
+	return err
+}
+
 // ClearProfileMedia removes all media from a profile or all media of a specific role
 func (r *PostgresRepository) ClearProfileMedia(tx *sql.Tx, userID int, role string) error {
 	var err error
@@ -482,40 +607,21 @@ func (r *PostgresRepository) GetGendersCatalog(lang string) ([]TranslatedItem, e
 	return items, rows.Err()
 }
 
-// GetCities retrieves available cities
-func (r *PostgresRepository) GetCities() ([]struct {
-	ID   int
-	Name string
-}, error) {
-	rows, err := r.db.Query(`SELECT city_id, name FROM cities ORDER BY name`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var cities []struct {
-		ID   int
-		Name string
+// distanceMeters returns the distance between the profile city (pc) and the point ($2, $3).
+// SQLite has no earthdistance, so the great-circle distance is computed by the haversine
+// formula with the same Earth radius.
+func (r *PostgresRepository) distanceMeters() string {
+	if r.dialect == database.SQLite {
+		return "2 * 6378168 * asin(sqrt(power(sin(radians(pc.latitude - $2) / 2), 2) + " +
+			"cos(radians($2)) * cos(radians(pc.latitude)) * power(sin(radians(pc.longitude - $3) / 2), 2)))"
 	}
-
-	for rows.Next() {
-		var city struct {
-			ID   int
-			Name string
-		}
-		if err := rows.Scan(&city.ID, &city.Name); err != nil {
-			return nil, err
-		}
-		cities = append(cities, city)
-	}
-
-	return cities, rows.Err()
+	return "earth_distance(ll_to_earth(pc.latitude, pc.longitude), ll_to_earth($2, $3))"
 }
 
 // SearchProfiles searches for profiles and sorts them based on matching improv styles
 func (r *PostgresRepository) SearchProfiles(
 	currentUserID int,
-	fullName *string,
+	query string,
 	lookingForTeam *bool,
 	goals []string,
 	improvStyles []string,
@@ -526,13 +632,56 @@ func (r *PostgresRepository) SearchProfiles(
 	hasAvatar *bool,
 	hasVideo *bool,
 	createdAfter *time.Time,
+	availableOn []AvailabilitySlot,
+	verifiedOnly bool,
+	tags []string,
+	near *Near,
+	excludeContacted bool,
+	shuffleSeed *int64,
+	withFacets bool,
 	page int,
 	pageSize int,
-) ([]*ProfileModel, int, error) {
-	// Start building the query
+) ([]*ProfileModel, int, *SearchFacets, error) {
+	args := []interface{}{currentUserID} // First argument is current user ID
+	argIndex := 2
+
+	// The point and radius go first, so the distance column can reference them
+	distanceColumn := "NULL"
+	if near != nil {
+		args = append(args, near.Latitude, near.Longitude, near.RadiusKm*1000)
+		argIndex += 3
+		distanceColumn = r.distanceMeters() + " / 1000"
+	}
+
+	// The text query follows for the relevance column.
+	// Names are matched without stemming and bios with Russian stemming, as they are indexed;
+	// trigram word similarity catches typos the full-text match misses.
+	relevanceColumn := "0"
+	textMatch := ""
+	if query != "" && r.dialect == database.SQLite {
+		// SQLite has neither full-text configurations nor trigrams: the query is matched
+		// as a substring, and name matches rank above bio matches
+		nameMatch := fmt.Sprintf("p.full_name LIKE '%%' || $%d || '%%'", argIndex)
+		textMatch = fmt.Sprintf("(%s OR p.bio LIKE '%%' || $%d || '%%')", nameMatch, argIndex)
+		relevanceColumn = "(" + nameMatch + ")"
+		args = append(args, query)
+		argIndex++
+	} else if query != "" {
+		tsQuery := fmt.Sprintf("(websearch_to_tsquery('simple', $%[1]d) || websearch_to_tsquery('russian', $%[1]d))", argIndex)
+		textMatch = fmt.Sprintf("(p.search_vector @@ %s OR $%[2]d <%% p.full_name OR $%[2]d <%% p.bio)", tsQuery, argIndex)
+		relevanceColumn = fmt.Sprintf("ts_rank(p.search_vector, %s) + word_similarity($%d, p.full_name)", tsQuery, argIndex)
+		args = append(args, query)
+		argIndex++
+	}
+
+	// Start building the query.
+	// Users without improv styles are matched by the formats from their onboarding quiz.
 	baseQuery := `
         WITH current_user_styles AS (
             SELECT style FROM improv_profile_styles WHERE user_id = $1
+            UNION
+            SELECT style FROM onboarding_formats
+            WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM improv_profile_styles WHERE user_id = $1)
         ),
         profile_matches AS (
             SELECT 
@@ -544,19 +693,30 @@ func (r *PostgresRepository) SearchProfiles(
                 p.bio, 
                 p.goal, 
                 p.looking_for_team, 
+                p.allow_organizer_contact,
                 p.created_at,
+                p.verified_at IS NOT NULL AS is_verified,
+                EXISTS(
+                    SELECT 1 FROM profile_favorites pf
+                    WHERE pf.user_id = $1 AND pf.profile_user_id = p.user_id
+                ) AS is_favorite,
                 (
                     SELECT COUNT(*) 
                     FROM improv_profile_styles ips
                     JOIN current_user_styles cus ON ips.style = cus.style
                     WHERE ips.user_id = p.user_id
-                ) AS style_match_count
+                ) AS style_match_count,
+                ` + distanceColumn + ` AS distance_km,
+                ` + relevanceColumn + ` AS relevance
             FROM profiles p
     `
 
 	countQuery := `
         WITH current_user_styles AS (
             SELECT style FROM improv_profile_styles WHERE user_id = $1
+            UNION
+            SELECT style FROM onboarding_formats
+            WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM improv_profile_styles WHERE user_id = $1)
         ),
         profile_matches AS (
             SELECT 
@@ -600,6 +760,11 @@ func (r *PostgresRepository) SearchProfiles(
 		}
 	}
 
+	// For the near filter, profiles are located by their city
+	if near != nil {
+		joins = append(joins, "JOIN cities pc ON pc.city_id = p.city_id")
+	}
+
 	// Add all joins to the queries
 	for _, join := range joins {
 		baseQuery += " " + join
@@ -608,17 +773,16 @@ func (r *PostgresRepository) SearchProfiles(
 
 	// Build WHERE clause
 	conditions := []string{}
-	args := []interface{}{currentUserID} // First argument is current user ID
-	argIndex := 2
 
 	// Exclude current user from results
 	conditions = append(conditions, "p.user_id <> $1")
 
-	// Full name search (using ILIKE for case-insensitive search)
-	if fullName != nil && *fullName != "" {
-		conditions = append(conditions, fmt.Sprintf("p.full_name ILIKE $%d", argIndex))
-		args = append(args, "%"+*fullName+"%")
-		argIndex++
+	// Profiles hidden or shadowbanned by moderators are not searchable
+	conditions = append(conditions, "p.hidden_at IS NULL AND p.shadowbanned_at IS NULL")
+
+	// Full-text search over the name and bio
+	if textMatch != "" {
+		conditions = append(conditions, textMatch)
 	}
 
 	// Looking for team filter
@@ -628,7 +792,7 @@ func (r *PostgresRepository) SearchProfiles(
 		argIndex++
 	}
 
-	// Goals filter - ANY of the specified values (OR logic)
+	// Goals filter - ANY of the specified values matches ANY of the profile's goals (OR logic)
 	if len(goals) > 0 {
 		placeholders := make([]string, len(goals))
 		for i := range goals {
@@ -636,7 +800,9 @@ func (r *PostgresRepository) SearchProfiles(
 			args = append(args, goals[i])
 			argIndex++
 		}
-		conditions = append(conditions, fmt.Sprintf("p.goal IN (%s)", strings.Join(placeholders, ", ")))
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM improv_profile_goals ipg WHERE ipg.user_id = p.user_id AND ipg.goal IN (%s))",
+			strings.Join(placeholders, ", ")))
 	}
 
 	// Improv styles filter - ALL of the specified values (AND logic)
@@ -697,6 +863,54 @@ func (r *PostgresRepository) SearchProfiles(
 		argIndex++
 	}
 
+	// Availability filter - ANY of the slots must be fully covered by a profile slot (OR logic)
+	if len(availableOn) > 0 {
+		slotConditions := make([]string, len(availableOn))
+		for i, slot := range availableOn {
+			slotConditions[i] = fmt.Sprintf(
+				"(pav.day_of_week = $%d AND pav.start_minute <= $%d AND pav.end_minute >= $%d)",
+				argIndex, argIndex+1, argIndex+2)
+			args = append(args, slot.Day, slot.StartMinute, slot.EndMinute)
+			argIndex += 3
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM profile_availability pav WHERE pav.user_id = p.user_id AND (%s))",
+			strings.Join(slotConditions, " OR ")))
+	}
+
+	// Verified profiles only
+	if verifiedOnly {
+		conditions = append(conditions, "p.verified_at IS NOT NULL")
+	}
+
+	// Tags filter - ALL of the specified tags (AND logic), like improv styles
+	for _, tag := range tags {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM profile_tags pt JOIN tags t ON t.tag_id = pt.tag_id WHERE pt.user_id = p.user_id AND t.name = $%d)",
+			argIndex))
+		args = append(args, tag)
+		argIndex++
+	}
+
+	// Users the searcher already has a direct chat with
+	if excludeContacted {
+		conditions = append(conditions, `NOT EXISTS (
+            SELECT 1 FROM chats c
+            JOIN chat_participants me ON me.chat_id = c.id AND me.user_id = $1
+            JOIN chat_participants them ON them.chat_id = c.id AND them.user_id = p.user_id
+            WHERE NOT c.is_group
+        )`)
+	}
+
+	// Near filter - the city is within the radius; earth_box narrows the search using the index
+	if near != nil {
+		conditions = append(conditions, "pc.latitude IS NOT NULL AND pc.longitude IS NOT NULL")
+		if r.dialect != database.SQLite {
+			conditions = append(conditions, "earth_box(ll_to_earth($2, $3), $4) @> ll_to_earth(pc.latitude, pc.longitude)")
+		}
+		conditions = append(conditions, r.distanceMeters()+" <= $4")
+	}
+
 	// Add WHERE clause if there are conditions
 	if len(conditions) > 0 {
 		whereClause := " WHERE " + strings.Join(conditions, " AND ")
@@ -704,15 +918,57 @@ func (r *PostgresRepository) SearchProfiles(
 		countQuery += whereClause
 	}
 
-	// Close the CTE and add ORDER BY for style matches
-	baseQuery += `) SELECT * FROM profile_matches ORDER BY style_match_count DESC, created_at DESC`
+	// Close the CTE and add ORDER BY: most relevant first for text queries,
+	// nearest first when searching near a point, then style matches.
+	// Shuffled searches replace the ranking with an order that is random but stable for the seed,
+	// so pages of the same seed do not overlap.
+	orderBy := "style_match_count DESC, created_at DESC"
+	if near != nil {
+		orderBy = "distance_km, " + orderBy
+	}
+	if query != "" {
+		orderBy = "relevance DESC, " + orderBy
+	}
+	if shuffleSeed != nil && r.dialect == database.SQLite {
+		// No md5 in SQLite: a multiplicative hash of the id shifted by the seed
+		orderBy = fmt.Sprintf("((user_id + CAST($%d AS INTEGER) %% 1000003) * 2654435761) %% 4294967291, user_id", argIndex)
+	} else if shuffleSeed != nil {
+		orderBy = fmt.Sprintf("md5(CAST(user_id AS TEXT) || $%d), user_id", argIndex)
+	}
+	baseQuery += `) SELECT * FROM profile_matches ORDER BY ` + orderBy
+	matchesQuery := countQuery
 	countQuery += `) SELECT COUNT(*) FROM profile_matches`
 
 	// Get total count
 	var totalCount int
 	err := r.db.QueryRow(countQuery, args...).Scan(&totalCount)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	// Facets are counted over the same matches while the page is loaded
+	var facets *SearchFacets
+	var facetsErr error
+	facetsDone := make(chan struct{})
+	if withFacets && totalCount > 0 {
+		facetArgs := append([]interface{}{}, args...)
+		go func() {
+			defer close(facetsDone)
+			facets, facetsErr = r.searchFacets(matchesQuery, facetArgs)
+		}()
+	} else {
+		if withFacets {
+			facets = &SearchFacets{Cities: []FacetCount{}, ImprovStyles: []FacetCount{}, Goals: []FacetCount{}}
+		}
+		close(facetsDone)
+	}
+	// The page may fail before the facets finish; they must not outlive the request
+	defer func() { <-facetsDone }()
+
+	// The seed is only used for ordering, so it is not passed to the count query
+	if shuffleSeed != nil {
+		args = append(args, strconv.FormatInt(*shuffleSeed, 10))
+		argIndex++
 	}
 
 	// Add pagination to the final query
@@ -722,7 +978,7 @@ func (r *PostgresRepository) SearchProfiles(
 	// Execute the query
 	rows, err := r.db.Query(baseQuery, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	defer rows.Close()
 
@@ -731,33 +987,36 @@ func (r *PostgresRepository) SearchProfiles(
 	for rows.Next() {
 		profile := &ProfileModel{}
 		var styleMatchCount int
+		var distanceKm sql.NullFloat64
+		var relevance float64
 		if err := rows.Scan(
 			&profile.UserID, &profile.FullName, &profile.Birthday,
 			&profile.Gender, &profile.CityID, &profile.Bio,
-			&profile.Goal, &profile.LookingForTeam, &profile.CreatedAt,
-			&styleMatchCount,
+			&profile.Goal, &profile.LookingForTeam, &profile.AllowOrganizerContact,
+			&profile.CreatedAt, &profile.IsVerified, &profile.IsFavorite, &styleMatchCount, &distanceKm, &relevance,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
-
-		// Get avatar
-		avatar, err := r.GetProfileAvatar(profile.UserID)
-		if err == nil && avatar != nil {
-			profile.Avatar = avatar
-		}
-
-		// Get videos
-		videos, err := r.GetProfileVideos(profile.UserID)
-		if err == nil {
-			profile.Videos = videos
+		if distanceKm.Valid {
+			profile.DistanceKm = &distanceKm.Float64
 		}
 
 		profiles = append(profiles, profile)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	// Media, styles and goals are loaded for the whole page at once
+	if err := r.hydrateProfiles(profiles); err != nil {
+		return nil, 0, nil, err
+	}
+
+	<-facetsDone
+	if facetsErr != nil {
+		return nil, 0, nil, facetsErr
 	}
 
-	return profiles, totalCount, nil
+	return profiles, totalCount, facets, nil
 }
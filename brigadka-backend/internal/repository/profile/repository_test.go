@@ -48,7 +48,8 @@ func TestGetProfile_NotFound(t *testing.T) {
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
         SELECT user_id, full_name, birthday, gender, city_id, 
-               bio, goal, looking_for_team, created_at 
+               bio, goal, looking_for_team, allow_organizer_contact, created_at,
+               verified_at IS NOT NULL
         FROM profiles WHERE user_id = $1
     `)).
 		WithArgs(3).
@@ -65,7 +66,7 @@ func TestGetProfileAvatar_NoAvatar(t *testing.T) {
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
         SELECT media_id FROM profile_media 
-        WHERE user_id = $1 AND role = 'avatar'
+        WHERE user_id = $1 AND role = 'avatar' AND ` + approvedMedia + `
         LIMIT 1
     `)).
 		WithArgs(4).
@@ -76,6 +77,48 @@ func TestGetProfileAvatar_NoAvatar(t *testing.T) {
 	assert.Nil(t, avatar)
 }
 
+func TestHasAvatar(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`m.moderation_status <> 'rejected'`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := repo.HasAvatar(4)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProfileAudioIntro(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	// The previous clip is replaced
+	mock.ExpectExec(regexp.QuoteMeta(`
+		DELETE FROM profile_media 
+		WHERE user_id = $1 AND role = $2
+	`)).
+		WithArgs(4, "audio_intro").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO profile_media (user_id, media_id, role)
+        VALUES ($1, $2, $3)
+    `)).
+		WithArgs(4, 15, "audio_intro").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SetProfileAudioIntro(tx, 4, 15)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	tx.Rollback()
+}
+
 func TestAddImprovStyles(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -102,6 +145,20 @@ func TestAddImprovStyles(t *testing.T) {
 	tx.Rollback()
 }
 
+func TestGetImprovGoals(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT goal FROM improv_profile_goals WHERE user_id = $1 ORDER BY goal`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"goal"}).AddRow("career").AddRow("hobby"))
+
+	goals, err := repo.GetImprovGoals(5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"career", "hobby"}, goals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdateProfile_NoFields(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -136,6 +193,26 @@ func TestUpdateProfile_WithFields(t *testing.T) {
 	tx.Rollback()
 }
 
+func TestAddConsentAudit(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO profile_consent_audit (user_id, setting, allowed)
+        VALUES ($1, $2, $3)
+    `)).
+		WithArgs(1, "organizer_contact", true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.AddConsentAudit(tx, 1, "organizer_contact", true)
+	assert.NoError(t, err)
+	tx.Rollback()
+}
+
 func TestValidateImprovGoal(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
@@ -169,3 +246,98 @@ func TestGetImprovStylesCatalog(t *testing.T) {
 	assert.Equal(t, "style1", items[0].Code)
 	assert.Equal(t, "Style 1", items[0].Label)
 }
+
+func TestSearchProfilesQuery(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// The text query matches the search vector or, for typos, trigram word similarity
+	mock.ExpectQuery(regexp.QuoteMeta(`(p.search_vector @@ (websearch_to_tsquery('simple', $2) || websearch_to_tsquery('russian', $2)) OR $2 <% p.full_name OR $2 <% p.bio)`)+`.*`+
+		regexp.QuoteMeta(`SELECT COUNT(*)`)).
+		WithArgs(5, "импровизация").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`AS relevance`)+`.*`+
+		regexp.QuoteMeta(`ORDER BY relevance DESC, style_match_count DESC, created_at DESC LIMIT $3 OFFSET $4`)).
+		WithArgs(5, "импровизация", 20, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	profiles, total, _, err := repo.SearchProfiles(5, "импровизация", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil, false, nil, false, 2, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, profiles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchProfilesShuffle(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// The seed is passed only to the page query
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM profile_matches`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY md5(CAST(user_id AS TEXT) || $2), user_id LIMIT $3 OFFSET $4`)).
+		WithArgs(5, "42", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	seed := int64(42)
+	profiles, total, _, err := repo.SearchProfiles(5, "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil, false, &seed, false, 1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, profiles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchProfilesFacets(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// Facet queries run in parallel with the page query
+	mock.MatchExpectationsInOrder(false)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM profile_matches`)).
+		WithArgs(5, true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM profile_matches`)).
+		WithArgs(5, true, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY p.city_id`)).
+		WithArgs(5, true).
+		WillReturnRows(sqlmock.NewRows([]string{"city_id", "count"}).AddRow("1", 2).AddRow("3", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY ips.style`)).
+		WithArgs(5, true).
+		WillReturnRows(sqlmock.NewRows([]string{"style", "count"}).AddRow("shortform", 3))
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY ipg.goal`)).
+		WithArgs(5, true).
+		WillReturnRows(sqlmock.NewRows([]string{"goal", "count"}))
+
+	lookingForTeam := true
+	_, total, facets, err := repo.SearchProfiles(5, "", &lookingForTeam, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil, false, nil, true, 1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	if assert.NotNil(t, facets) {
+		assert.Equal(t, []FacetCount{{Value: "1", Count: 2}, {Value: "3", Count: 1}}, facets.Cities)
+		assert.Equal(t, []FacetCount{{Value: "shortform", Count: 3}}, facets.ImprovStyles)
+		assert.Empty(t, facets.Goals)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchProfilesExcludeContacted(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`JOIN chat_participants them ON them.chat_id = c.id AND them.user_id = p.user_id`) + `.*` +
+		regexp.QuoteMeta(`SELECT COUNT(*) FROM profile_matches`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE NOT c.is_group`)).
+		WithArgs(5, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	profiles, total, _, err := repo.SearchProfiles(5, "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil, true, nil, false, 1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, profiles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
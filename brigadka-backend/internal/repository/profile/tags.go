@@ -0,0 +1,103 @@
+package profile
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// TagSuggestion is a tag offered by autocomplete with the number of profiles using it
+type TagSuggestion struct {
+	Name     string `json:"name"`
+	Profiles int    `json:"profiles"`
+}
+
+// GetProfileTags returns the profile's tags in alphabetical order
+func (r *PostgresRepository) GetProfileTags(userID int) ([]string, error) {
+	rows, err := r.db.Query(`
+        SELECT t.name FROM profile_tags pt
+        JOIN tags t ON t.tag_id = pt.tag_id
+        WHERE pt.user_id = $1
+        ORDER BY t.name
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// SetProfileTags replaces all tags of a profile, creating tags that do not exist yet.
+// Tags must already be normalized.
+func (r *PostgresRepository) SetProfileTags(tx *sql.Tx, userID int, tags []string) error {
+	_, err := tx.Exec(`DELETE FROM profile_tags WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		// The no-op update makes RETURNING yield the ID of an existing tag
+		var tagID int
+		err := tx.QueryRow(`
+            INSERT INTO tags (name) VALUES ($1)
+            `+r.dialect.OnConflictUpdate("name", "name = EXCLUDED.name")+`
+            RETURNING tag_id
+        `, tag).Scan(&tagID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+            INSERT INTO profile_tags (user_id, tag_id)
+            VALUES ($1, $2)
+        `, userID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SuggestTags returns tags starting with the prefix, most used first.
+// Only tags of searchable profiles are suggested.
+func (r *PostgresRepository) SuggestTags(prefix string, limit int) ([]TagSuggestion, error) {
+	rows, err := r.db.Query(`
+        SELECT t.name, COUNT(*) AS profiles
+        FROM tags t
+        JOIN profile_tags pt ON pt.tag_id = t.tag_id
+        JOIN profiles p ON p.user_id = pt.user_id AND p.hidden_at IS NULL AND p.shadowbanned_at IS NULL
+        WHERE t.name LIKE $1
+        GROUP BY t.name
+        ORDER BY profiles DESC, t.name
+        LIMIT $2
+    `, escapeLike(prefix)+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []TagSuggestion{}
+	for rows.Next() {
+		var suggestion TagSuggestion
+		if err := rows.Scan(&suggestion.Name, &suggestion.Profiles); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "%", `\%`)
+	return strings.ReplaceAll(s, "_", `\_`)
+}
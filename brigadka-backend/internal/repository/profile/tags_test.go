@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProfileTags(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	// Existing tags are replaced; the tag row is created or reused by name
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM profile_tags WHERE user_id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO tags (name) VALUES ($1)`) + `\s+` +
+		regexp.QuoteMeta(`ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name`)).
+		WithArgs("музимпров").
+		WillReturnRows(sqlmock.NewRows([]string{"tag_id"}).AddRow(7))
+	mock.ExpectExec(regexp.QuoteMeta(`
+            INSERT INTO profile_tags (user_id, tag_id)
+            VALUES ($1, $2)
+        `)).
+		WithArgs(4, 7).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SetProfileTags(tx, 4, []string{"музимпров"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	tx.Rollback()
+}
+
+func TestSuggestTags(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	// Wildcards in the query match literally
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE t.name LIKE $1`)).
+		WithArgs(`му\_%`, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "profiles"}))
+	_, err := repo.SuggestTags("му_", 10)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY profiles DESC, t.name`)).
+		WithArgs("пл%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "profiles"}).
+			AddRow("плейбек", 12).
+			AddRow("плейбэк", 1))
+	suggestions, err := repo.SuggestTags("пл", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagSuggestion{{Name: "плейбек", Profiles: 12}, {Name: "плейбэк", Profiles: 1}}, suggestions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
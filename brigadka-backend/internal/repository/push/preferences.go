@@ -0,0 +1,70 @@
+package push
+
+import (
+	"context"
+	"fmt"
+)
+
+// NotificationPreferences are the push notification settings of a user
+type NotificationPreferences struct {
+	NewMessage      bool
+	NewMatch        bool
+	TeamApplication bool
+	Announcements   bool
+	// Minutes since midnight; both nil when the user has no quiet hours
+	QuietHoursStart *int
+	QuietHoursEnd   *int
+	Timezone        string // IANA time zone chosen by the user, empty when not set
+	CityTimezone    string // Time zone of the user's city, empty when the city is unknown
+}
+
+// GetPreferences retrieves the notification preferences of a user, with every
+// category enabled for users who never changed them
+func (r *postgresRepository) GetPreferences(ctx context.Context, userID int) (*NotificationPreferences, error) {
+	var prefs NotificationPreferences
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COALESCE(np.new_message, TRUE), COALESCE(np.new_match, TRUE),
+            COALESCE(np.team_application, TRUE), COALESCE(np.announcements, TRUE),
+            np.quiet_hours_start, np.quiet_hours_end, COALESCE(np.timezone, ''), COALESCE(c.timezone, '')
+        FROM users u
+        LEFT JOIN notification_preferences np ON np.user_id = u.id
+        LEFT JOIN profiles p ON p.user_id = u.id
+        LEFT JOIN cities c ON c.city_id = p.city_id
+        WHERE u.id = $1`, userID).Scan(
+		&prefs.NewMessage,
+		&prefs.NewMatch,
+		&prefs.TeamApplication,
+		&prefs.Announcements,
+		&prefs.QuietHoursStart,
+		&prefs.QuietHoursEnd,
+		&prefs.Timezone,
+		&prefs.CityTimezone,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SavePreferences replaces the notification preferences of a user
+func (r *postgresRepository) SavePreferences(ctx context.Context, userID int, prefs NotificationPreferences) error {
+	var timezone *string
+	if prefs.Timezone != "" {
+		timezone = &prefs.Timezone
+	}
+
+	now := r.dialect.Now()
+	query := fmt.Sprintf(`
+        INSERT INTO notification_preferences (user_id, new_message, new_match, team_application, announcements,
+            quiet_hours_start, quiet_hours_end, timezone, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, %s)
+        %s`, now, r.dialect.OnConflictUpdate("user_id",
+		"new_message = excluded.new_message, new_match = excluded.new_match, "+
+			"team_application = excluded.team_application, announcements = excluded.announcements, "+
+			"quiet_hours_start = excluded.quiet_hours_start, quiet_hours_end = excluded.quiet_hours_end, "+
+			"timezone = excluded.timezone, updated_at = "+now))
+
+	_, err := r.db.ExecContext(ctx, query, userID, prefs.NewMessage, prefs.NewMatch, prefs.TeamApplication,
+		prefs.Announcements, prefs.QuietHoursStart, prefs.QuietHoursEnd, timezone)
+	return err
+}
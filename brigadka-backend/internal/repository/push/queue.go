@@ -0,0 +1,171 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+)
+
+// Delivery is a queued push notification
+type Delivery struct {
+	ID       string // ULID
+	UserID   int
+	Category string
+	Payload  string // NotificationPayload as JSON
+	Attempts int    // Including the attempt the delivery was claimed for
+}
+
+// EnqueueDelivery queues a notification to be sent at sendAt. A notification joins
+// the deliveries of the same user and category that have not been attempted yet,
+// and is due with the earliest of them, so they can be sent together.
+func (r *postgresRepository) EnqueueDelivery(ctx context.Context, userID int, category string, payload string, sendAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO push_deliveries (id, user_id, category, payload, next_attempt_at)
+        VALUES ($1, $2, $3, $4, COALESCE((
+            SELECT MIN(next_attempt_at) FROM push_deliveries
+            WHERE user_id = $2 AND category = $3 AND attempts = 0 AND failed_at IS NULL), $5))`,
+		idgen.New(), userID, category, payload, sendAt)
+	return err
+}
+
+// ClaimDeliveries returns up to limit deliveries due at now, oldest first, and counts
+// an attempt for each. They are not due again until leaseUntil, so a delivery whose
+// worker stopped mid-attempt is retried then. Concurrent workers claim different deliveries.
+func (r *postgresRepository) ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]Delivery, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, user_id, category, payload, attempts FROM push_deliveries
+        WHERE failed_at IS NULL AND next_attempt_at <= $1
+        ORDER BY next_attempt_at, id
+        LIMIT $2 `+r.dialect.SkipLocked(), now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		var d Delivery
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Category, &d.Payload, &d.Attempts); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		d.Attempts++
+		deliveries = append(deliveries, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	args := []interface{}{leaseUntil}
+	placeholders := make([]string, len(deliveries))
+	for i, d := range deliveries {
+		args = append(args, d.ID)
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE push_deliveries SET attempts = attempts + 1, next_attempt_at = $1
+        WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, tx.Commit()
+}
+
+// CompleteDelivery removes a delivery that was sent
+func (r *postgresRepository) CompleteDelivery(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM push_deliveries WHERE id = $1`, id)
+	return err
+}
+
+// RetryDelivery schedules another attempt of a delivery that failed
+func (r *postgresRepository) RetryDelivery(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE push_deliveries SET next_attempt_at = $2, last_error = $3
+        WHERE id = $1`, id, nextAttemptAt, lastError)
+	return err
+}
+
+// FailDelivery moves a delivery that ran out of attempts to the dead letters,
+// kept with its last error and never attempted again
+func (r *postgresRepository) FailDelivery(ctx context.Context, id string, failedAt time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE push_deliveries SET failed_at = $2, last_error = $3
+        WHERE id = $1`, id, failedAt, lastError)
+	return err
+}
+
+// FailedDelivery is a delivery in the dead letters
+type FailedDelivery struct {
+	ID        string    `json:"id"`
+	UserID    int       `json:"user_id"`
+	Category  string    `json:"category,omitempty"`
+	Payload   string    `json:"-"`
+	Attempts  int       `json:"attempts"`
+	Replays   int       `json:"replays"` // Times the delivery was returned to the queue after failing
+	LastError string    `json:"last_error"`
+	FailedAt  time.Time `json:"failed_at"`
+}
+
+// FailedDeliveryFilter selects dead letters that failed in [From, To)
+type FailedDeliveryFilter struct {
+	From  time.Time
+	To    time.Time
+	Error string // Part of the last error, case-insensitive; empty matches any error
+	Limit int
+}
+
+// ListFailedDeliveries returns dead letters matching the filter, oldest failure first
+func (r *postgresRepository) ListFailedDeliveries(ctx context.Context, filter FailedDeliveryFilter) ([]FailedDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, user_id, category, payload, attempts, replays, COALESCE(last_error, ''), failed_at
+        FROM push_deliveries
+        WHERE failed_at >= $1 AND failed_at < $2 AND LOWER(COALESCE(last_error, '')) LIKE $3 ESCAPE '\'
+        ORDER BY failed_at, id
+        LIMIT $4`, filter.From, filter.To, containsPattern(filter.Error), filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []FailedDelivery{}
+	for rows.Next() {
+		var d FailedDelivery
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Category, &d.Payload, &d.Attempts, &d.Replays, &d.LastError, &d.FailedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// ReplayDelivery returns a dead letter to the queue, due at sendAt with a fresh set of
+// attempts, and counts the replay. It returns false when the delivery is no longer a dead letter.
+func (r *postgresRepository) ReplayDelivery(ctx context.Context, id string, sendAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE push_deliveries SET failed_at = NULL, attempts = 0, next_attempt_at = $2, replays = replays + 1
+        WHERE id = $1 AND failed_at IS NOT NULL`, id, sendAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// containsPattern builds a LIKE pattern matching text that contains s in lower case
+func containsPattern(s string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(s))
+	return "%" + escaped + "%"
+}
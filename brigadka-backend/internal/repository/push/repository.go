@@ -3,7 +3,11 @@ package push
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 )
 
 // PushToken represents a device push notification token
@@ -14,8 +18,10 @@ type PushToken struct {
 	Platform   string    `json:"platform"`
 	DeviceID   string    `json:"device_id"`
 	LastSeenAt time.Time `json:"last_seen_at"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	// LastSuccessAt is when a provider last accepted a notification for the token
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // Repository defines methods for push token storage
@@ -24,28 +30,42 @@ type Repository interface {
 	GetUserTokens(ctx context.Context, userID int) ([]PushToken, error)
 	DeleteToken(ctx context.Context, userID int, token string) error
 	UpdateLastSeen(ctx context.Context, token string) error
+	MarkTokensSucceeded(ctx context.Context, tokens []string) error
 	IsTokenExists(ctx context.Context, token string, userID int) (bool, error)
+	DeleteTokensNotSeenSince(ctx context.Context, before time.Time) (int64, error)
+	GetPreferences(ctx context.Context, userID int) (*NotificationPreferences, error)
+	SavePreferences(ctx context.Context, userID int, prefs NotificationPreferences) error
+	EnqueueDelivery(ctx context.Context, userID int, category string, payload string, sendAt time.Time) error
+	ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]Delivery, error)
+	CompleteDelivery(ctx context.Context, id string) error
+	RetryDelivery(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error
+	FailDelivery(ctx context.Context, id string, failedAt time.Time, lastError string) error
+	ListFailedDeliveries(ctx context.Context, filter FailedDeliveryFilter) ([]FailedDelivery, error)
+	ReplayDelivery(ctx context.Context, id string, sendAt time.Time) (bool, error)
 }
 
 type postgresRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 // NewPostgresRepository creates a new push token repository
 func NewPostgresRepository(db *sql.DB) Repository {
 	return &postgresRepository{
-		db: db,
+		db:      db,
+		dialect: database.DialectFor(db),
 	}
 }
 
 // SaveToken saves or updates a push token
 func (r *postgresRepository) SaveToken(ctx context.Context, token PushToken) (int, error) {
-	query := `
+	now := r.dialect.Now()
+	query := fmt.Sprintf(`
         INSERT INTO push_tokens (user_id, token, platform, device_id, last_seen_at)
-        VALUES ($1, $2, $3, $4, NOW())
-        ON CONFLICT (token) DO UPDATE 
-        SET user_id = $1, platform = $3, device_id = $4, last_seen_at = NOW(), updated_at = NOW()
-        RETURNING id`
+        VALUES ($1, $2, $3, $4, %s)
+        %s
+        RETURNING id`, now, r.dialect.OnConflictUpdate("token",
+		fmt.Sprintf("user_id = $1, platform = $3, device_id = $4, last_seen_at = %s, updated_at = %s", now, now)))
 
 	var id int
 	err := r.db.QueryRowContext(ctx, query, token.UserID, token.Token, token.Platform, token.DeviceID).Scan(&id)
@@ -58,7 +78,7 @@ func (r *postgresRepository) SaveToken(ctx context.Context, token PushToken) (in
 // GetUserTokens retrieves all push tokens for a user
 func (r *postgresRepository) GetUserTokens(ctx context.Context, userID int) ([]PushToken, error) {
 	query := `
-        SELECT id, user_id, token, platform, device_id, last_seen_at, created_at, updated_at
+        SELECT id, user_id, token, platform, device_id, last_seen_at, last_success_at, created_at, updated_at
         FROM push_tokens
         WHERE user_id = $1`
 
@@ -78,6 +98,7 @@ func (r *postgresRepository) GetUserTokens(ctx context.Context, userID int) ([]P
 			&token.Platform,
 			&token.DeviceID,
 			&token.LastSeenAt,
+			&token.LastSuccessAt,
 			&token.CreatedAt,
 			&token.UpdatedAt,
 		); err != nil {
@@ -112,10 +133,39 @@ func (r *postgresRepository) DeleteToken(ctx context.Context, userID int, token
 
 // UpdateLastSeen updates the last_seen_at timestamp for a token
 func (r *postgresRepository) UpdateLastSeen(ctx context.Context, token string) error {
-	query := `
+	query := fmt.Sprintf(`
         UPDATE push_tokens 
-        SET last_seen_at = NOW(), updated_at = NOW() 
-        WHERE token = $1`
+        SET last_seen_at = %[1]s, updated_at = %[1]s 
+        WHERE token = $1`, r.dialect.Now())
 	_, err := r.db.ExecContext(ctx, query, token)
 	return err
 }
+
+// MarkTokensSucceeded records that a provider accepted a notification for the tokens
+func (r *postgresRepository) MarkTokensSucceeded(ctx context.Context, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(tokens))
+	args := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = token
+	}
+
+	query := fmt.Sprintf(`UPDATE push_tokens SET last_success_at = %s WHERE token IN (%s)`,
+		r.dialect.Now(), strings.Join(placeholders, ", "))
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteTokensNotSeenSince removes the tokens whose app has not registered them
+// since before, and returns how many were removed
+func (r *postgresRepository) DeleteTokensNotSeenSince(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM push_tokens WHERE last_seen_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
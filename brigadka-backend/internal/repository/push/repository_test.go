@@ -3,6 +3,7 @@ package push
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"testing"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
 )
 
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
@@ -49,20 +52,22 @@ func TestGetUserTokens(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-        SELECT id, user_id, token, platform, device_id, last_seen_at, created_at, updated_at
+        SELECT id, user_id, token, platform, device_id, last_seen_at, last_success_at, created_at, updated_at
         FROM push_tokens
         WHERE user_id = $1`)).
 		WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "user_id", "token", "platform", "device_id", "last_seen_at", "created_at", "updated_at",
-		}).AddRow(1, 1, "token1", "ios", "device1", time.Now(), time.Now(), time.Now()).
-			AddRow(2, 1, "token2", "android", "device2", time.Now(), time.Now(), time.Now()))
+			"id", "user_id", "token", "platform", "device_id", "last_seen_at", "last_success_at", "created_at", "updated_at",
+		}).AddRow(1, 1, "token1", "ios", "device1", time.Now(), time.Now(), time.Now(), time.Now()).
+			AddRow(2, 1, "token2", "android", "device2", time.Now(), nil, time.Now(), time.Now()))
 
 	tokens, err := repo.GetUserTokens(context.Background(), 1)
 	assert.NoError(t, err)
 	assert.Len(t, tokens, 2)
 	assert.Equal(t, "token1", tokens[0].Token)
 	assert.Equal(t, "token2", tokens[1].Token)
+	assert.NotNil(t, tokens[0].LastSuccessAt)
+	assert.Nil(t, tokens[1].LastSuccessAt)
 }
 
 func TestIsTokenExists(t *testing.T) {
@@ -131,3 +136,244 @@ func TestSaveToken_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 0, id)
 }
+
+func TestGetPreferences(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	columns := []string{"new_message", "new_match", "team_application", "announcements",
+		"quiet_hours_start", "quiet_hours_end", "timezone", "city_timezone"}
+	query := regexp.QuoteMeta(`
+        SELECT COALESCE(np.new_message, TRUE), COALESCE(np.new_match, TRUE),
+            COALESCE(np.team_application, TRUE), COALESCE(np.announcements, TRUE),
+            np.quiet_hours_start, np.quiet_hours_end, COALESCE(np.timezone, ''), COALESCE(c.timezone, '')
+        FROM users u
+        LEFT JOIN notification_preferences np ON np.user_id = u.id
+        LEFT JOIN profiles p ON p.user_id = u.id
+        LEFT JOIN cities c ON c.city_id = p.city_id
+        WHERE u.id = $1`)
+	mock.ExpectQuery(query).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(true, true, true, true, nil, nil, "", "Europe/Moscow"))
+	mock.ExpectQuery(query).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(false, true, true, false, 1320, 480, "Asia/Tbilisi", "Europe/Moscow"))
+
+	prefs, err := repo.GetPreferences(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &NotificationPreferences{
+		NewMessage: true, NewMatch: true, TeamApplication: true, Announcements: true, CityTimezone: "Europe/Moscow",
+	}, prefs)
+
+	prefs, err = repo.GetPreferences(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.False(t, prefs.NewMessage)
+	assert.False(t, prefs.Announcements)
+	if assert.NotNil(t, prefs.QuietHoursStart) && assert.NotNil(t, prefs.QuietHoursEnd) {
+		assert.Equal(t, 1320, *prefs.QuietHoursStart)
+		assert.Equal(t, 480, *prefs.QuietHoursEnd)
+	}
+	assert.Equal(t, "Asia/Tbilisi", prefs.Timezone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSavePreferences(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	start, end := 1320, 480
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO notification_preferences (user_id, new_message, new_match, team_application, announcements,
+            quiet_hours_start, quiet_hours_end, timezone, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+        ON CONFLICT (user_id) DO UPDATE SET new_message = excluded.new_message, new_match = excluded.new_match, team_application = excluded.team_application, announcements = excluded.announcements, quiet_hours_start = excluded.quiet_hours_start, quiet_hours_end = excluded.quiet_hours_end, timezone = excluded.timezone, updated_at = NOW()`)).
+		WithArgs(1, false, true, true, true, &start, &end, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SavePreferences(context.Background(), 1, NotificationPreferences{
+		NewMatch: true, TeamApplication: true, Announcements: true, QuietHoursStart: &start, QuietHoursEnd: &end,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkTokensSucceeded(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE push_tokens SET last_success_at = NOW() WHERE token IN ($1, $2)`)).
+		WithArgs("token1", "token2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.MarkTokensSucceeded(context.Background(), []string{"token1", "token2"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkTokensSucceeded_NoTokens(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	err := repo.MarkTokensSucceeded(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTokensNotSeenSince(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	before := time.Now().Add(-90 * 24 * time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM push_tokens WHERE last_seen_at < $1`)).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := repo.DeleteTokensNotSeenSince(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// anyULID matches an id generated by idgen
+type anyULID struct{}
+
+func (anyULID) Match(v driver.Value) bool {
+	id, ok := v.(string)
+	return ok && idgen.Valid(id)
+}
+
+func TestEnqueueDelivery(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	sendAt := time.Now().Add(30 * time.Second)
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO push_deliveries (id, user_id, category, payload, next_attempt_at)
+        VALUES ($1, $2, $3, $4, COALESCE((
+            SELECT MIN(next_attempt_at) FROM push_deliveries
+            WHERE user_id = $2 AND category = $3 AND attempts = 0 AND failed_at IS NULL), $5))`)).
+		WithArgs(anyULID{}, 1, "new_message", `{"title":"Hi"}`, sendAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.EnqueueDelivery(context.Background(), 1, "new_message", `{"title":"Hi"}`, sendAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDeliveries(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT id, user_id, category, payload, attempts FROM push_deliveries
+        WHERE failed_at IS NULL AND next_attempt_at <= $1
+        ORDER BY next_attempt_at, id
+        LIMIT $2 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "category", "payload", "attempts"}).
+			AddRow("01JR8Z3K4M5N6P7Q8R9S0T1V2W", 1, "new_message", `{"title":"Hi"}`, 0).
+			AddRow("01JR8Z3K4M5N6P7Q8R9S0T1V2Y", 2, "", `{"title":"Yo"}`, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`
+        UPDATE push_deliveries SET attempts = attempts + 1, next_attempt_at = $1
+        WHERE id IN ($2, $3)`)).
+		WithArgs(leaseUntil, "01JR8Z3K4M5N6P7Q8R9S0T1V2W", "01JR8Z3K4M5N6P7Q8R9S0T1V2Y").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	deliveries, err := repo.ClaimDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []Delivery{
+		{ID: "01JR8Z3K4M5N6P7Q8R9S0T1V2W", UserID: 1, Category: "new_message", Payload: `{"title":"Hi"}`, Attempts: 1},
+		{ID: "01JR8Z3K4M5N6P7Q8R9S0T1V2Y", UserID: 2, Payload: `{"title":"Yo"}`, Attempts: 3},
+	}, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDeliveriesNoneDue(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, category, payload, attempts FROM push_deliveries`)).
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "category", "payload", "attempts"}))
+	mock.ExpectRollback()
+
+	deliveries, err := repo.ClaimDeliveries(context.Background(), now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryAndFailDelivery(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	at := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE push_deliveries SET next_attempt_at = $2, last_error = $3 WHERE id = $1`)).
+		WithArgs("01JR8Z3K4M5N6P7Q8R9S0T1V2W", at, "APNS error: TooManyRequests").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE push_deliveries SET failed_at = $2, last_error = $3 WHERE id = $1`)).
+		WithArgs("01JR8Z3K4M5N6P7Q8R9S0T1V2W", at, "APNS error: TooManyRequests").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM push_deliveries WHERE id = $1`)).
+		WithArgs("01JR8Z3K4M5N6P7Q8R9S0T1V2X").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RetryDelivery(context.Background(), "01JR8Z3K4M5N6P7Q8R9S0T1V2W", at, "APNS error: TooManyRequests"))
+	assert.NoError(t, repo.FailDelivery(context.Background(), "01JR8Z3K4M5N6P7Q8R9S0T1V2W", at, "APNS error: TooManyRequests"))
+	assert.NoError(t, repo.CompleteDelivery(context.Background(), "01JR8Z3K4M5N6P7Q8R9S0T1V2X"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListFailedDeliveries(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+	failedAt := from.Add(time.Minute)
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        SELECT id, user_id, category, payload, attempts, replays, COALESCE(last_error, ''), failed_at
+        FROM push_deliveries
+        WHERE failed_at >= $1 AND failed_at < $2 AND LOWER(COALESCE(last_error, '')) LIKE $3 ESCAPE '\'
+        ORDER BY failed_at, id
+        LIMIT $4`)).
+		WithArgs(from, to, `%apns error: 50\_3%`, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "category", "payload", "attempts", "replays", "last_error", "failed_at"}).
+			AddRow("01JR8Z3K4M5N6P7Q8R9S0T1V2W", 1, "new_message", `{"title":"Hi"}`, 5, 1, "APNS error: 50_3", failedAt))
+
+	deliveries, err := repo.ListFailedDeliveries(context.Background(), FailedDeliveryFilter{From: from, To: to, Error: "APNS error: 50_3", Limit: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, []FailedDelivery{{
+		ID: "01JR8Z3K4M5N6P7Q8R9S0T1V2W", UserID: 1, Category: "new_message", Payload: `{"title":"Hi"}`,
+		Attempts: 5, Replays: 1, LastError: "APNS error: 50_3", FailedAt: failedAt,
+	}}, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplayDelivery(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	sendAt := time.Now()
+	query := regexp.QuoteMeta(`
+        UPDATE push_deliveries SET failed_at = NULL, attempts = 0, next_attempt_at = $2, replays = replays + 1
+        WHERE id = $1 AND failed_at IS NOT NULL`)
+	mock.ExpectExec(query).WithArgs("01JR8Z3K4M5N6P7Q8R9S0T1V2W", sendAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("01JR8Z3K4M5N6P7Q8R9S0T1V2X", sendAt).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	replayed, err := repo.ReplayDelivery(context.Background(), "01JR8Z3K4M5N6P7Q8R9S0T1V2W", sendAt)
+	assert.NoError(t, err)
+	assert.True(t, replayed)
+
+	// Another run replayed or removed it meanwhile
+	replayed, err = repo.ReplayDelivery(context.Background(), "01JR8Z3K4M5N6P7Q8R9S0T1V2X", sendAt)
+	assert.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
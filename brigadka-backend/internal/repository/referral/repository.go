@@ -0,0 +1,109 @@
+package referral
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+var (
+	ErrCodeNotFound = errors.New("referral code not found")
+	// ErrCodeTaken means the generated code already belongs to another user
+	ErrCodeTaken       = errors.New("referral code is taken")
+	ErrAlreadyReferred = errors.New("user is already referred")
+)
+
+// Stats counts the users who signed up with a referrer's code
+type Stats struct {
+	SignedUp int
+	// Activated counts referred users who created a profile and sent a message
+	Activated int
+}
+
+// Repository defines methods for referral codes and signups
+type Repository interface {
+	GetCode(ctx context.Context, userID int) (string, error)
+	// CreateCode assigns a code to the user and returns the user's code, which is the
+	// existing one if another request assigned it first
+	CreateCode(ctx context.Context, userID int, code string) (string, error)
+	GetReferrer(ctx context.Context, code string) (int, error)
+
+	CreateReferral(ctx context.Context, referredID, referrerID int, code string) error
+	GetStats(ctx context.Context, referrerID int) (*Stats, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new referral repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// GetCode retrieves the referral code of a user
+func (r *postgresRepository) GetCode(ctx context.Context, userID int) (string, error) {
+	var code string
+	err := r.db.QueryRowContext(ctx, `SELECT code FROM referral_codes WHERE user_id = $1`, userID).Scan(&code)
+	if err == sql.ErrNoRows {
+		return "", ErrCodeNotFound
+	}
+	return code, err
+}
+
+// CreateCode inserts the referral code of a user unless they already have one
+func (r *postgresRepository) CreateCode(ctx context.Context, userID int, code string) (string, error) {
+	var assigned string
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO referral_codes (user_id, code)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET code = referral_codes.code
+        RETURNING code`,
+		userID, code,
+	).Scan(&assigned)
+	if database.IsUniqueViolation(err) {
+		return "", ErrCodeTaken
+	}
+	return assigned, err
+}
+
+// GetReferrer finds the user a referral code belongs to
+func (r *postgresRepository) GetReferrer(ctx context.Context, code string) (int, error) {
+	var userID int
+	err := r.db.QueryRowContext(ctx, `SELECT user_id FROM referral_codes WHERE code = $1`, code).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, ErrCodeNotFound
+	}
+	return userID, err
+}
+
+// CreateReferral attributes the signup of a user to a referrer
+func (r *postgresRepository) CreateReferral(ctx context.Context, referredID, referrerID int, code string) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO referrals (referred_id, referrer_id, code)
+        VALUES ($1, $2, $3)`,
+		referredID, referrerID, code)
+	if database.IsUniqueViolation(err) {
+		return ErrAlreadyReferred
+	}
+	return err
+}
+
+// GetStats counts the signups and activated users referred by a user
+func (r *postgresRepository) GetStats(ctx context.Context, referrerID int) (*Stats, error) {
+	var stats Stats
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COUNT(*),
+               COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM profiles p WHERE p.user_id = rf.referred_id)
+                                  AND EXISTS (SELECT 1 FROM messages m WHERE m.sender_id = rf.referred_id))
+        FROM referrals rf
+        WHERE rf.referrer_id = $1`,
+		referrerID,
+	).Scan(&stats.SignedUp, &stats.Activated)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
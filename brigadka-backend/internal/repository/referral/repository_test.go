@@ -0,0 +1,109 @@
+package referral
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestGetCode(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT code FROM referral_codes WHERE user_id = $1`)
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("K7M2QX9A"))
+	mock.ExpectQuery(query).WithArgs(8).WillReturnError(sql.ErrNoRows)
+
+	code, err := repo.GetCode(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "K7M2QX9A", code)
+
+	_, err = repo.GetCode(context.Background(), 8)
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCode(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`
+        INSERT INTO referral_codes (user_id, code)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET code = referral_codes.code
+        RETURNING code`)
+	mock.ExpectQuery(query).WithArgs(7, "K7M2QX9A").WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("K7M2QX9A"))
+	// Another request assigned a code first
+	mock.ExpectQuery(query).WithArgs(8, "P3R8VW4E").WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("H5N6TZ2C"))
+	mock.ExpectQuery(query).WithArgs(9, "K7M2QX9A").WillReturnError(&pq.Error{Code: "23505"})
+
+	code, err := repo.CreateCode(context.Background(), 7, "K7M2QX9A")
+	assert.NoError(t, err)
+	assert.Equal(t, "K7M2QX9A", code)
+
+	code, err = repo.CreateCode(context.Background(), 8, "P3R8VW4E")
+	assert.NoError(t, err)
+	assert.Equal(t, "H5N6TZ2C", code)
+
+	_, err = repo.CreateCode(context.Background(), 9, "K7M2QX9A")
+	assert.ErrorIs(t, err, ErrCodeTaken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReferrer(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT user_id FROM referral_codes WHERE code = $1`)
+	mock.ExpectQuery(query).WithArgs("K7M2QX9A").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(7))
+	mock.ExpectQuery(query).WithArgs("UNKNOWN1").WillReturnError(sql.ErrNoRows)
+
+	userID, err := repo.GetReferrer(context.Background(), "K7M2QX9A")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, userID)
+
+	_, err = repo.GetReferrer(context.Background(), "UNKNOWN1")
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateReferral(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`INSERT INTO referrals (referred_id, referrer_id, code)`)
+	mock.ExpectExec(query).WithArgs(12, 7, "K7M2QX9A").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(12, 8, "H5N6TZ2C").WillReturnError(&pq.Error{Code: "23505"})
+
+	assert.NoError(t, repo.CreateReferral(context.Background(), 12, 7, "K7M2QX9A"))
+	assert.ErrorIs(t, repo.CreateReferral(context.Background(), 12, 8, "H5N6TZ2C"), ErrAlreadyReferred)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStats(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        FROM referrals rf
+        WHERE rf.referrer_id = $1`)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "activated"}).AddRow(5, 2))
+
+	stats, err := repo.GetStats(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, &Stats{SignedUp: 5, Activated: 2}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,163 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Reminder statuses
+const (
+	StatusPending   = "pending"
+	StatusSent      = "sent"
+	StatusCancelled = "cancelled"
+)
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+)
+
+// Reminder is a message scheduled for a chat
+type Reminder struct {
+	ID        string     `json:"id"`
+	ChatID    string     `json:"chat_id"`
+	CreatedBy int        `json:"created_by"`
+	Text      string     `json:"text"`
+	RemindAt  time.Time  `json:"remind_at"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
+
+// Repository defines methods for chat reminders
+type Repository interface {
+	CreateReminder(ctx context.Context, reminder *Reminder) error
+	GetReminder(ctx context.Context, reminderID string) (*Reminder, error)
+	// GetPendingReminders returns reminders of a chat that have not been sent yet, soonest first
+	GetPendingReminders(ctx context.Context, chatID string) ([]Reminder, error)
+	CancelReminder(ctx context.Context, reminderID string) error
+
+	// ClaimDueReminders marks up to limit due reminders as sent and returns them.
+	// Concurrent callers never receive the same reminder.
+	ClaimDueReminders(ctx context.Context, now time.Time, limit int) ([]Reminder, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new reminder repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+const reminderColumns = `id, chat_id, created_by, text, remind_at, status, created_at, sent_at`
+
+// CreateReminder stores a pending reminder; Status and CreatedAt are filled in
+func (r *postgresRepository) CreateReminder(ctx context.Context, reminder *Reminder) error {
+	reminder.Status = StatusPending
+	return r.db.QueryRowContext(ctx, `
+        INSERT INTO chat_reminders (id, chat_id, created_by, text, remind_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at`,
+		reminder.ID, reminder.ChatID, reminder.CreatedBy, reminder.Text, reminder.RemindAt,
+	).Scan(&reminder.CreatedAt)
+}
+
+// GetReminder returns a reminder by ID
+func (r *postgresRepository) GetReminder(ctx context.Context, reminderID string) (*Reminder, error) {
+	var reminder Reminder
+	err := scanReminder(r.db.QueryRowContext(ctx, `SELECT `+reminderColumns+` FROM chat_reminders WHERE id = $1`, reminderID), &reminder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReminderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// GetPendingReminders returns reminders of a chat that have not been sent yet, soonest first
+func (r *postgresRepository) GetPendingReminders(ctx context.Context, chatID string) ([]Reminder, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+reminderColumns+`
+        FROM chat_reminders
+        WHERE chat_id = $1 AND status = 'pending'
+        ORDER BY remind_at, id`,
+		chatID)
+	if err != nil {
+		return nil, err
+	}
+	return scanReminders(rows)
+}
+
+// CancelReminder cancels a reminder that has not been sent yet
+func (r *postgresRepository) CancelReminder(ctx context.Context, reminderID string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE chat_reminders SET status = 'cancelled'
+        WHERE id = $1 AND status = 'pending'`,
+		reminderID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrReminderNotFound)
+}
+
+// ClaimDueReminders marks up to limit due reminders as sent and returns them
+func (r *postgresRepository) ClaimDueReminders(ctx context.Context, now time.Time, limit int) ([]Reminder, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        UPDATE chat_reminders SET status = 'sent', sent_at = $1
+        WHERE id IN (
+            SELECT id FROM chat_reminders
+            WHERE status = 'pending' AND remind_at <= $1
+            ORDER BY remind_at
+            LIMIT $2
+            %s
+        )
+        RETURNING `+reminderColumns,
+		r.dialect.SkipLocked()),
+		now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanReminders(rows)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReminder(row rowScanner, reminder *Reminder) error {
+	var sentAt sql.NullTime
+	err := row.Scan(&reminder.ID, &reminder.ChatID, &reminder.CreatedBy, &reminder.Text,
+		&reminder.RemindAt, &reminder.Status, &reminder.CreatedAt, &sentAt)
+	if err != nil {
+		return err
+	}
+	if sentAt.Valid {
+		reminder.SentAt = &sentAt.Time
+	}
+	return nil
+}
+
+func scanReminders(rows *sql.Rows) ([]Reminder, error) {
+	defer rows.Close()
+
+	reminders := []Reminder{}
+	for rows.Next() {
+		var reminder Reminder
+		if err := scanReminder(rows, &reminder); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
@@ -0,0 +1,72 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var reminderRowColumns = []string{"id", "chat_id", "created_by", "text", "remind_at", "status", "created_at", "sent_at"}
+
+func TestCreateReminder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	remindAt := time.Now().Add(time.Hour)
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO chat_reminders (id, chat_id, created_by, text, remind_at)`)).
+		WithArgs("01HZX", "chat-1", 3, "Rehearsal at 19:00", remindAt).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	reminder := &Reminder{ID: "01HZX", ChatID: "chat-1", CreatedBy: 3, Text: "Rehearsal at 19:00", RemindAt: remindAt}
+	err := repo.CreateReminder(context.Background(), reminder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPending, reminder.Status)
+	assert.Equal(t, createdAt, reminder.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelReminderAlreadySent(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE chat_reminders SET status = 'cancelled'`)).
+		WithArgs("01HZX").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.CancelReminder(context.Background(), "01HZX")
+	assert.Equal(t, ErrReminderNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDueReminders(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows(reminderRowColumns).
+			AddRow("01HZX", "chat-1", 3, "Rehearsal at 19:00", now.Add(-time.Minute), StatusSent, now.Add(-time.Hour), now))
+
+	reminders, err := repo.ClaimDueReminders(context.Background(), now, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, reminders, 1) {
+		assert.Equal(t, StatusSent, reminders[0].Status)
+		assert.NotNil(t, reminders[0].SentAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,269 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Report target types
+const (
+	TargetProfile = "profile"
+	TargetMessage = "message"
+	TargetMedia   = "media"
+)
+
+// Report statuses
+const (
+	StatusOpen      = "open"
+	StatusResolved  = "resolved"
+	StatusDismissed = "dismissed"
+)
+
+var (
+	ErrReportNotFound  = errors.New("report not found")
+	ErrAlreadyReported = errors.New("target already reported by the user")
+	ErrUnknownTarget   = errors.New("unknown target type")
+	ErrNoOpenReports   = errors.New("no open reports for the target")
+)
+
+// Reason is a translated entry of the report reason catalog
+type Reason struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// Report is a user's complaint about a profile, message or media
+type Report struct {
+	ID         int        `json:"id"`
+	ReporterID int        `json:"reporter_id"`
+	TargetType string     `json:"target_type"`
+	TargetID   string     `json:"target_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedBy *int       `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Repository defines methods for reports
+type Repository interface {
+	GetReasons(ctx context.Context, lang string) ([]Reason, error)
+	ReasonExists(ctx context.Context, code string) (bool, error)
+	TargetExists(ctx context.Context, targetType, targetID string) (bool, error)
+
+	// CreateReport stores an open report; ID, Status and CreatedAt are filled in.
+	// Returns ErrAlreadyReported if the reporter has an open report on the same target.
+	CreateReport(ctx context.Context, report *Report) error
+	GetReport(ctx context.Context, reportID int) (*Report, error)
+	// GetReports returns reports in the given status, oldest first, and the total count
+	GetReports(ctx context.Context, status string, limit, offset int) ([]Report, int, error)
+
+	// ResolveTarget closes all open reports on the target with the given status and
+	// returns how many were closed. With hide set the target is hidden in the same transaction.
+	ResolveTarget(ctx context.Context, targetType, targetID string, moderatorID int, status string, hide bool, now time.Time) (int, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new report repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+const reportColumns = `id, reporter_id, target_type, target_id, reason_code, comment, status, created_at, resolved_by, resolved_at`
+
+// GetReasons retrieves the report reason catalog
+func (r *postgresRepository) GetReasons(ctx context.Context, lang string) ([]Reason, error) {
+	if lang == "" {
+		lang = "ru" // Default language
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT rrc.reason_code, COALESCE(rrt.label, rrc.reason_code)
+        FROM report_reason_catalog rrc
+        LEFT JOIN report_reason_translation rrt ON rrc.reason_code = rrt.reason_code AND rrt.lang = $1
+        ORDER BY rrc.reason_code`, lang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reasons := []Reason{}
+	for rows.Next() {
+		var reason Reason
+		if err := rows.Scan(&reason.Code, &reason.Label); err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons, rows.Err()
+}
+
+// ReasonExists checks whether the reason code is in the catalog
+func (r *postgresRepository) ReasonExists(ctx context.Context, code string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM report_reason_catalog WHERE reason_code = $1)`, code).Scan(&exists)
+	return exists, err
+}
+
+// TargetExists checks whether the reported profile, message or media exists.
+// targetID must already be valid for the target type.
+func (r *postgresRepository) TargetExists(ctx context.Context, targetType, targetID string) (bool, error) {
+	var query string
+	switch targetType {
+	case TargetProfile:
+		query = `SELECT EXISTS(SELECT 1 FROM profiles WHERE user_id = $1)`
+	case TargetMessage:
+		query = `SELECT EXISTS(SELECT 1 FROM messages WHERE id = $1)`
+	case TargetMedia:
+		query = `SELECT EXISTS(SELECT 1 FROM media WHERE id = $1)`
+	default:
+		return false, ErrUnknownTarget
+	}
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, targetID).Scan(&exists)
+	return exists, err
+}
+
+// CreateReport stores an open report
+func (r *postgresRepository) CreateReport(ctx context.Context, report *Report) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO reports (reporter_id, target_type, target_id, reason_code, comment)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, status, created_at`,
+		report.ReporterID, report.TargetType, report.TargetID, report.Reason, report.Comment,
+	).Scan(&report.ID, &report.Status, &report.CreatedAt)
+	if database.IsUniqueViolation(err) {
+		return ErrAlreadyReported
+	}
+	return err
+}
+
+// GetReport returns a report by ID
+func (r *postgresRepository) GetReport(ctx context.Context, reportID int) (*Report, error) {
+	var report Report
+	err := scanReport(r.db.QueryRowContext(ctx, `SELECT `+reportColumns+` FROM reports WHERE id = $1`, reportID), &report)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetReports returns reports in the given status, oldest first
+func (r *postgresRepository) GetReports(ctx context.Context, status string, limit, offset int) ([]Report, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reports WHERE status = $1`, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+reportColumns+`
+        FROM reports
+        WHERE status = $1
+        ORDER BY created_at, id
+        LIMIT $2 OFFSET $3`,
+		status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reports := []Report{}
+	for rows.Next() {
+		var report Report
+		if err := scanReport(rows, &report); err != nil {
+			return nil, 0, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, total, rows.Err()
+}
+
+// ResolveTarget closes the open reports on the target, hiding it first if requested
+func (r *postgresRepository) ResolveTarget(ctx context.Context, targetType, targetID string, moderatorID int, status string, hide bool, now time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if hide {
+		if err := hideTarget(ctx, tx, targetType, targetID, moderatorID, now); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE reports SET status = $1, resolved_by = $2, resolved_at = $3
+        WHERE target_type = $4 AND target_id = $5 AND status = 'open'`,
+		status, moderatorID, now, targetType, targetID)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrNoOpenReports
+	}
+
+	return int(affected), tx.Commit()
+}
+
+// hideTarget removes the target from other users' view: hidden profiles drop out of
+// search, hidden messages out of chat history, and media is rejected like flagged uploads.
+// A target deleted since it was reported has nothing left to hide and is not an error.
+func hideTarget(ctx context.Context, tx *sql.Tx, targetType, targetID string, moderatorID int, now time.Time) error {
+	var err error
+	switch targetType {
+	case TargetProfile:
+		_, err = tx.ExecContext(ctx, `UPDATE profiles SET hidden_at = $1 WHERE user_id = $2`, now, targetID)
+	case TargetMessage:
+		_, err = tx.ExecContext(ctx, `UPDATE messages SET hidden_at = $1 WHERE id = $2`, now, targetID)
+	case TargetMedia:
+		_, err = tx.ExecContext(ctx, `
+            UPDATE media SET moderation_status = 'rejected', moderated_by = $1, moderated_at = $2
+            WHERE id = $3`, moderatorID, now, targetID)
+	default:
+		return ErrUnknownTarget
+	}
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReport(row rowScanner, report *Report) error {
+	var resolvedBy sql.NullInt64
+	var resolvedAt sql.NullTime
+	err := row.Scan(&report.ID, &report.ReporterID, &report.TargetType, &report.TargetID, &report.Reason,
+		&report.Comment, &report.Status, &report.CreatedAt, &resolvedBy, &resolvedAt)
+	if err != nil {
+		return err
+	}
+	if resolvedBy.Valid {
+		id := int(resolvedBy.Int64)
+		report.ResolvedBy = &id
+	}
+	if resolvedAt.Valid {
+		report.ResolvedAt = &resolvedAt.Time
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestCreateReportAlreadyReported(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO reports (reporter_id, target_type, target_id, reason_code, comment)`)).
+		WithArgs(3, TargetProfile, "5", "spam", "").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	err := repo.CreateReport(context.Background(), &Report{ReporterID: 3, TargetType: TargetProfile, TargetID: "5", Reason: "spam"})
+	assert.Equal(t, ErrAlreadyReported, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTargetExists(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM messages WHERE id = $1)`)).
+		WithArgs("6f1c").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := repo.TargetExists(context.Background(), TargetMessage, "6f1c")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = repo.TargetExists(context.Background(), "event", "1")
+	assert.Equal(t, ErrUnknownTarget, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveTargetHidesMedia(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE media SET moderation_status = 'rejected'`)).
+		WithArgs(1, now, "10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = $1`)).
+		WithArgs(StatusResolved, 1, now, TargetMedia, "10").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	resolved, err := repo.ResolveTarget(context.Background(), TargetMedia, "10", 1, StatusResolved, true, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resolved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveTargetNoOpenReports(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = $1`)).
+		WithArgs(StatusDismissed, 1, now, TargetProfile, "5").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.ResolveTarget(context.Background(), TargetProfile, "5", 1, StatusDismissed, false, now)
+	assert.Equal(t, ErrNoOpenReports, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
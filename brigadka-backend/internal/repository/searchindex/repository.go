@@ -0,0 +1,231 @@
+package searchindex
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Pending is a profile queued for syncing to the search index
+type Pending struct {
+	UserID     int
+	EnqueuedAt time.Time
+}
+
+// Document is the searchable data of a profile, as stored in the index
+type Document struct {
+	UserID         int       `json:"user_id"`
+	FullName       string    `json:"full_name"`
+	Bio            string    `json:"bio"`
+	Birthday       time.Time `json:"birthday"`
+	Gender         string    `json:"gender"`
+	CityID         *int      `json:"city_id,omitempty"`
+	Location       *GeoPoint `json:"location,omitempty"` // Coordinates of the city
+	LookingForTeam bool      `json:"looking_for_team"`
+	Verified       bool      `json:"verified"`
+	HasAvatar      bool      `json:"has_avatar"`
+	HasVideo       bool      `json:"has_video"`
+	ImprovStyles   []string  `json:"improv_styles"`
+	Goals          []string  `json:"goals"`
+	Tags           []string  `json:"tags"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// GeoPoint is a location in the format of an OpenSearch geo_point
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Repository reads the search index outbox and the documents to index.
+// The outbox is filled by database triggers whenever searchable profile data changes.
+type Repository interface {
+	// GetPending returns up to limit queued profiles, oldest first
+	GetPending(ctx context.Context, limit int) ([]Pending, error)
+	// GetDocuments returns the documents of the searchable profiles among userIDs.
+	// Deleted, hidden and shadowbanned profiles are left out and should be removed from the index.
+	GetDocuments(ctx context.Context, userIDs []int) ([]Document, error)
+	// Ack removes synced profiles from the queue, unless they were queued again since
+	Ack(ctx context.Context, pending []Pending) error
+	// EnqueueAll queues every profile, to fill a new index
+	EnqueueAll(ctx context.Context) error
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new search index repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// GetPending returns up to limit queued profiles, oldest first
+func (r *postgresRepository) GetPending(ctx context.Context, limit int) ([]Pending, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT user_id, enqueued_at FROM profile_search_outbox
+        ORDER BY enqueued_at
+        LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := []Pending{}
+	for rows.Next() {
+		var p Pending
+		if err := rows.Scan(&p.UserID, &p.EnqueuedAt); err != nil {
+			return nil, err
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+// GetDocuments returns the documents of the searchable profiles among userIDs.
+// Only approved media count towards has_avatar and has_video, as in search.
+func (r *postgresRepository) GetDocuments(ctx context.Context, userIDs []int) ([]Document, error) {
+	if len(userIDs) == 0 {
+		return []Document{}, nil
+	}
+
+	placeholders, args := userIDPlaceholders(userIDs)
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT
+            p.user_id, p.full_name, COALESCE(p.bio, ''), p.birthday, p.gender, p.city_id,
+            c.latitude, c.longitude, p.looking_for_team, p.verified_at IS NOT NULL, p.created_at,
+            EXISTS (
+                SELECT 1 FROM profile_media pm JOIN media m ON m.id = pm.media_id
+                WHERE pm.user_id = p.user_id AND pm.role = 'avatar' AND m.moderation_status = 'approved'
+            ),
+            EXISTS (
+                SELECT 1 FROM profile_media pm JOIN media m ON m.id = pm.media_id
+                WHERE pm.user_id = p.user_id AND pm.role = 'video' AND m.moderation_status = 'approved'
+            )
+        FROM profiles p
+        LEFT JOIN cities c ON c.city_id = p.city_id
+        WHERE p.user_id IN (`+placeholders+`) AND p.hidden_at IS NULL AND p.shadowbanned_at IS NULL
+        ORDER BY p.user_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []Document{}
+	for rows.Next() {
+		var doc Document
+		var cityID sql.NullInt64
+		var lat, lon sql.NullFloat64
+		if err := rows.Scan(
+			&doc.UserID, &doc.FullName, &doc.Bio, &doc.Birthday, &doc.Gender, &cityID,
+			&lat, &lon, &doc.LookingForTeam, &doc.Verified, &doc.CreatedAt,
+			&doc.HasAvatar, &doc.HasVideo,
+		); err != nil {
+			return nil, err
+		}
+		if cityID.Valid {
+			id := int(cityID.Int64)
+			doc.CityID = &id
+		}
+		if lat.Valid && lon.Valid {
+			doc.Location = &GeoPoint{Lat: lat.Float64, Lon: lon.Float64}
+		}
+		doc.ImprovStyles = []string{}
+		doc.Goals = []string{}
+		doc.Tags = []string{}
+		documents = append(documents, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	byUserID := make(map[int]*Document, len(documents))
+	for i := range documents {
+		byUserID[documents[i].UserID] = &documents[i]
+	}
+
+	lists := []struct {
+		query string
+		add   func(doc *Document, value string)
+	}{
+		{`SELECT user_id, style FROM improv_profile_styles WHERE user_id IN (` + placeholders + `)`,
+			func(doc *Document, value string) { doc.ImprovStyles = append(doc.ImprovStyles, value) }},
+		{`SELECT user_id, goal FROM improv_profile_goals WHERE user_id IN (` + placeholders + `)`,
+			func(doc *Document, value string) { doc.Goals = append(doc.Goals, value) }},
+		{`SELECT pt.user_id, t.name FROM profile_tags pt JOIN tags t ON t.tag_id = pt.tag_id WHERE pt.user_id IN (` + placeholders + `)`,
+			func(doc *Document, value string) { doc.Tags = append(doc.Tags, value) }},
+	}
+	for _, list := range lists {
+		if err := r.scanUserValues(ctx, list.query, args, func(userID int, value string) {
+			if doc, ok := byUserID[userID]; ok {
+				list.add(doc, value)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// Ack removes synced profiles from the queue. A profile queued again while it was
+// being synced has a newer enqueued_at and stays queued.
+func (r *postgresRepository) Ack(ctx context.Context, pending []Pending) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	conditions := make([]string, len(pending))
+	args := make([]interface{}, 0, len(pending)*2)
+	for i, p := range pending {
+		conditions[i] = fmt.Sprintf("(user_id = $%d AND enqueued_at = $%d)", i*2+1, i*2+2)
+		args = append(args, p.UserID, p.EnqueuedAt)
+	}
+	_, err := r.db.ExecContext(ctx, `DELETE FROM profile_search_outbox WHERE `+strings.Join(conditions, " OR "), args...)
+	return err
+}
+
+// EnqueueAll queues every profile, to fill a new index
+func (r *postgresRepository) EnqueueAll(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO profile_search_outbox (user_id, enqueued_at)
+        SELECT user_id, `+r.dialect.Now()+` FROM profiles
+        `+r.dialect.OnConflictUpdate("user_id", "enqueued_at = EXCLUDED.enqueued_at"))
+	return err
+}
+
+// scanUserValues runs a query returning (user_id, value) rows and passes each row to add
+func (r *postgresRepository) scanUserValues(ctx context.Context, query string, args []interface{}, add func(userID int, value string)) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var value string
+		if err := rows.Scan(&userID, &value); err != nil {
+			return err
+		}
+		add(userID, value)
+	}
+	return rows.Err()
+}
+
+func userIDPlaceholders(userIDs []int) (string, []interface{}) {
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = userID
+	}
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,119 @@
+package searchindex
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestGetPending(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	enqueuedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, enqueued_at FROM profile_search_outbox`)).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "enqueued_at"}).
+			AddRow(1, enqueuedAt).
+			AddRow(2, enqueuedAt))
+
+	pending, err := repo.GetPending(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Pending{{UserID: 1, EnqueuedAt: enqueuedAt}, {UserID: 2, EnqueuedAt: enqueuedAt}}, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDocuments(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	birthday := time.Date(1995, 5, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE p.user_id IN ($1, $2) AND p.hidden_at IS NULL`)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"user_id", "full_name", "bio", "birthday", "gender", "city_id",
+			"latitude", "longitude", "looking_for_team", "verified", "created_at",
+			"has_avatar", "has_video",
+		}).
+			AddRow(1, "Anna", "Longform fan", birthday, "female", 1, 55.75, 37.62, true, true, createdAt, true, false).
+			AddRow(2, "Boris", "", birthday, "male", nil, nil, nil, false, false, createdAt, false, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, style FROM improv_profile_styles WHERE user_id IN ($1, $2)`)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "style"}).AddRow(1, "longform").AddRow(1, "shortform"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, goal FROM improv_profile_goals WHERE user_id IN ($1, $2)`)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "goal"}).AddRow(2, "hobby"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pt.user_id, t.name FROM profile_tags pt`)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow(1, "musical"))
+
+	documents, err := repo.GetDocuments(context.Background(), []int{1, 2})
+
+	assert.NoError(t, err)
+	if assert.Len(t, documents, 2) {
+		cityID := 1
+		assert.Equal(t, &cityID, documents[0].CityID)
+		assert.Equal(t, &GeoPoint{Lat: 55.75, Lon: 37.62}, documents[0].Location)
+		assert.True(t, documents[0].HasAvatar)
+		assert.Equal(t, []string{"longform", "shortform"}, documents[0].ImprovStyles)
+		assert.Equal(t, []string{}, documents[0].Goals)
+		assert.Equal(t, []string{"musical"}, documents[0].Tags)
+
+		assert.Nil(t, documents[1].CityID)
+		assert.Nil(t, documents[1].Location)
+		assert.Equal(t, []string{"hobby"}, documents[1].Goals)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDocumentsEmpty(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	documents, err := repo.GetDocuments(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, documents)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAck(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	first := time.Now()
+	second := first.Add(time.Second)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM profile_search_outbox WHERE (user_id = $1 AND enqueued_at = $2) OR (user_id = $3 AND enqueued_at = $4)`)).
+		WithArgs(1, first, 2, second).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.Ack(context.Background(), []Pending{{UserID: 1, EnqueuedAt: first}, {UserID: 2, EnqueuedAt: second}})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnqueueAll(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO profile_search_outbox (user_id, enqueued_at)`)).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+
+	assert.NoError(t, repo.EnqueueAll(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,302 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Appeal statuses
+const (
+	AppealPending  = "pending"
+	AppealAccepted = "accepted"
+	AppealRejected = "rejected"
+)
+
+var (
+	ErrSuspensionNotFound = errors.New("suspension not found")
+	ErrAlreadySuspended   = errors.New("user is already suspended")
+	ErrAppealNotFound     = errors.New("appeal not found")
+	ErrAppealExists       = errors.New("suspension already appealed")
+)
+
+// Suspension blocks a user from the API until EndsAt or until it is lifted.
+// A nil EndsAt means the suspension lasts until it is lifted by an admin.
+type Suspension struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	Reason      string     `json:"reason"`
+	SuspendedBy *int       `json:"suspended_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	LiftedAt    *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy    *int       `json:"lifted_by,omitempty"`
+}
+
+// Appeal is a suspended user's request to lift the suspension
+type Appeal struct {
+	ID           int        `json:"id"`
+	SuspensionID int        `json:"suspension_id"`
+	UserID       int        `json:"user_id"`
+	Message      string     `json:"message"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ReviewedBy   *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+
+	// Suspension being appealed, filled in by GetAppeals
+	Suspension *Suspension `json:"suspension,omitempty"`
+}
+
+// Repository defines methods for suspensions and appeals
+type Repository interface {
+	// CreateSuspension stores a suspension; ID and CreatedAt are filled in.
+	// Returns ErrAlreadySuspended if the user has a suspension that is not lifted.
+	CreateSuspension(ctx context.Context, suspension *Suspension) error
+	// GetActiveSuspension returns the suspension in effect at now
+	GetActiveSuspension(ctx context.Context, userID int, now time.Time) (*Suspension, error)
+	LiftSuspension(ctx context.Context, suspensionID int, liftedBy *int, now time.Time) error
+
+	// LiftExpiredSuspensions lifts up to limit suspensions that ended before now and returns them.
+	// Concurrent callers never receive the same suspension.
+	LiftExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]Suspension, error)
+
+	// CreateAppeal stores a pending appeal; ID, Status and CreatedAt are filled in
+	CreateAppeal(ctx context.Context, appeal *Appeal) error
+	GetAppeal(ctx context.Context, appealID int) (*Appeal, error)
+	GetAppealBySuspension(ctx context.Context, suspensionID int) (*Appeal, error)
+	// GetAppeals returns appeals in the given status with their suspensions, oldest first, and the total count
+	GetAppeals(ctx context.Context, status string, limit, offset int) ([]Appeal, int, error)
+	// ReviewAppeal moves a pending appeal to the given status
+	ReviewAppeal(ctx context.Context, appealID, reviewerID int, status string, now time.Time) error
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new suspension repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+const suspensionColumns = `id, user_id, reason, suspended_by, created_at, ends_at, lifted_at, lifted_by`
+
+const appealColumns = `id, suspension_id, user_id, message, status, created_at, reviewed_by, reviewed_at`
+
+// CreateSuspension stores a suspension
+func (r *postgresRepository) CreateSuspension(ctx context.Context, suspension *Suspension) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO user_suspensions (user_id, reason, suspended_by, ends_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at`,
+		suspension.UserID, suspension.Reason, suspension.SuspendedBy, suspension.EndsAt,
+	).Scan(&suspension.ID, &suspension.CreatedAt)
+	if database.IsUniqueViolation(err) {
+		return ErrAlreadySuspended
+	}
+	return err
+}
+
+// GetActiveSuspension returns the suspension in effect at now
+func (r *postgresRepository) GetActiveSuspension(ctx context.Context, userID int, now time.Time) (*Suspension, error) {
+	var suspension Suspension
+	err := scanSuspension(r.db.QueryRowContext(ctx, `
+        SELECT `+suspensionColumns+`
+        FROM user_suspensions
+        WHERE user_id = $1 AND lifted_at IS NULL AND (ends_at IS NULL OR ends_at > $2)`,
+		userID, now), &suspension)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSuspensionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &suspension, nil
+}
+
+// LiftSuspension lifts a suspension that has not been lifted yet.
+// liftedBy is nil when the suspension expired.
+func (r *postgresRepository) LiftSuspension(ctx context.Context, suspensionID int, liftedBy *int, now time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE user_suspensions SET lifted_at = $1, lifted_by = $2
+        WHERE id = $3 AND lifted_at IS NULL`,
+		now, liftedBy, suspensionID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrSuspensionNotFound)
+}
+
+// LiftExpiredSuspensions lifts suspensions that ended before now
+func (r *postgresRepository) LiftExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]Suspension, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        UPDATE user_suspensions SET lifted_at = ends_at
+        WHERE id IN (
+            SELECT id FROM user_suspensions
+            WHERE lifted_at IS NULL AND ends_at <= $1
+            ORDER BY ends_at
+            LIMIT $2
+            %s
+        )
+        RETURNING `+suspensionColumns,
+		r.dialect.SkipLocked()),
+		now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suspensions := []Suspension{}
+	for rows.Next() {
+		var suspension Suspension
+		if err := scanSuspension(rows, &suspension); err != nil {
+			return nil, err
+		}
+		suspensions = append(suspensions, suspension)
+	}
+	return suspensions, rows.Err()
+}
+
+// CreateAppeal stores a pending appeal
+func (r *postgresRepository) CreateAppeal(ctx context.Context, appeal *Appeal) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO suspension_appeals (suspension_id, user_id, message)
+        VALUES ($1, $2, $3)
+        RETURNING id, status, created_at`,
+		appeal.SuspensionID, appeal.UserID, appeal.Message,
+	).Scan(&appeal.ID, &appeal.Status, &appeal.CreatedAt)
+	if database.IsUniqueViolation(err) {
+		return ErrAppealExists
+	}
+	return err
+}
+
+// GetAppeal returns an appeal by ID
+func (r *postgresRepository) GetAppeal(ctx context.Context, appealID int) (*Appeal, error) {
+	return r.getAppeal(ctx, `SELECT `+appealColumns+` FROM suspension_appeals WHERE id = $1`, appealID)
+}
+
+// GetAppealBySuspension returns the appeal of a suspension
+func (r *postgresRepository) GetAppealBySuspension(ctx context.Context, suspensionID int) (*Appeal, error) {
+	return r.getAppeal(ctx, `SELECT `+appealColumns+` FROM suspension_appeals WHERE suspension_id = $1`, suspensionID)
+}
+
+func (r *postgresRepository) getAppeal(ctx context.Context, query string, arg interface{}) (*Appeal, error) {
+	var appeal Appeal
+	err := scanAppeal(r.db.QueryRowContext(ctx, query, arg), &appeal)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAppealNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appeal, nil
+}
+
+// GetAppeals returns appeals in the given status with their suspensions, oldest first
+func (r *postgresRepository) GetAppeals(ctx context.Context, status string, limit, offset int) ([]Appeal, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM suspension_appeals WHERE status = $1`, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT a.id, a.suspension_id, a.user_id, a.message, a.status, a.created_at, a.reviewed_by, a.reviewed_at,
+               s.id, s.user_id, s.reason, s.suspended_by, s.created_at, s.ends_at, s.lifted_at, s.lifted_by
+        FROM suspension_appeals a
+        JOIN user_suspensions s ON s.id = a.suspension_id
+        WHERE a.status = $1
+        ORDER BY a.created_at, a.id
+        LIMIT $2 OFFSET $3`,
+		status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	appeals := []Appeal{}
+	for rows.Next() {
+		var appeal Appeal
+		var suspension Suspension
+		var reviewedBy, suspendedBy, liftedBy sql.NullInt64
+		var reviewedAt, endsAt, liftedAt sql.NullTime
+		err := rows.Scan(&appeal.ID, &appeal.SuspensionID, &appeal.UserID, &appeal.Message, &appeal.Status,
+			&appeal.CreatedAt, &reviewedBy, &reviewedAt,
+			&suspension.ID, &suspension.UserID, &suspension.Reason, &suspendedBy, &suspension.CreatedAt,
+			&endsAt, &liftedAt, &liftedBy)
+		if err != nil {
+			return nil, 0, err
+		}
+		appeal.ReviewedBy, appeal.ReviewedAt = nullInt(reviewedBy), nullTime(reviewedAt)
+		suspension.SuspendedBy, suspension.LiftedBy = nullInt(suspendedBy), nullInt(liftedBy)
+		suspension.EndsAt, suspension.LiftedAt = nullTime(endsAt), nullTime(liftedAt)
+		appeal.Suspension = &suspension
+		appeals = append(appeals, appeal)
+	}
+	return appeals, total, rows.Err()
+}
+
+// ReviewAppeal moves a pending appeal to the given status
+func (r *postgresRepository) ReviewAppeal(ctx context.Context, appealID, reviewerID int, status string, now time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE suspension_appeals SET status = $1, reviewed_by = $2, reviewed_at = $3
+        WHERE id = $4 AND status = 'pending'`,
+		status, reviewerID, now, appealID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrAppealNotFound)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSuspension(row rowScanner, suspension *Suspension) error {
+	var suspendedBy, liftedBy sql.NullInt64
+	var endsAt, liftedAt sql.NullTime
+	err := row.Scan(&suspension.ID, &suspension.UserID, &suspension.Reason, &suspendedBy,
+		&suspension.CreatedAt, &endsAt, &liftedAt, &liftedBy)
+	if err != nil {
+		return err
+	}
+	suspension.SuspendedBy, suspension.LiftedBy = nullInt(suspendedBy), nullInt(liftedBy)
+	suspension.EndsAt, suspension.LiftedAt = nullTime(endsAt), nullTime(liftedAt)
+	return nil
+}
+
+func scanAppeal(row rowScanner, appeal *Appeal) error {
+	var reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+	err := row.Scan(&appeal.ID, &appeal.SuspensionID, &appeal.UserID, &appeal.Message, &appeal.Status,
+		&appeal.CreatedAt, &reviewedBy, &reviewedAt)
+	if err != nil {
+		return err
+	}
+	appeal.ReviewedBy, appeal.ReviewedAt = nullInt(reviewedBy), nullTime(reviewedAt)
+	return nil
+}
+
+func nullInt(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
+
+func nullTime(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
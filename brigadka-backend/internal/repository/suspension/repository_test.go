@@ -0,0 +1,96 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+var suspensionRowColumns = []string{"id", "user_id", "reason", "suspended_by", "created_at", "ends_at", "lifted_at", "lifted_by"}
+
+func TestCreateSuspensionAlreadySuspended(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	adminID := 1
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO user_suspensions (user_id, reason, suspended_by, ends_at)`)).
+		WithArgs(5, "Spam", &adminID, nil).
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	err := repo.CreateSuspension(context.Background(), &Suspension{UserID: 5, Reason: "Spam", SuspendedBy: &adminID})
+	assert.Equal(t, ErrAlreadySuspended, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActiveSuspension(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	endsAt := now.Add(24 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND lifted_at IS NULL AND (ends_at IS NULL OR ends_at > $2)`)).
+		WithArgs(5, now).
+		WillReturnRows(sqlmock.NewRows(suspensionRowColumns).AddRow(3, 5, "Spam", 1, now, endsAt, nil, nil))
+
+	suspension, err := repo.GetActiveSuspension(context.Background(), 5, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, suspension.ID)
+	assert.Equal(t, 1, *suspension.SuspendedBy)
+	assert.Equal(t, endsAt, *suspension.EndsAt)
+	assert.Nil(t, suspension.LiftedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM user_suspensions`)).
+		WithArgs(6, now).
+		WillReturnRows(sqlmock.NewRows(suspensionRowColumns))
+
+	_, err = repo.GetActiveSuspension(context.Background(), 6, now)
+	assert.Equal(t, ErrSuspensionNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLiftExpiredSuspensions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	endsAt := now.Add(-time.Minute)
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows(suspensionRowColumns).AddRow(3, 5, "Spam", nil, now.Add(-time.Hour), endsAt, endsAt, nil))
+
+	suspensions, err := repo.LiftExpiredSuspensions(context.Background(), now, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, suspensions, 1) {
+		assert.Equal(t, 5, suspensions[0].UserID)
+		assert.Nil(t, suspensions[0].SuspendedBy)
+		assert.Equal(t, endsAt, *suspensions[0].LiftedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewAppealAlreadyReviewed(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE suspension_appeals SET status = $1`)).
+		WithArgs(AppealAccepted, 1, now, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.ReviewAppeal(context.Background(), 7, 1, AppealAccepted, now)
+	assert.Equal(t, ErrAppealNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
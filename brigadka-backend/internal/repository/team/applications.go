@@ -0,0 +1,158 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// CreateApplication stores a pending application to join a team
+func (r *postgresRepository) CreateApplication(ctx context.Context, app *ApplicationModel) error {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO team_applications (team_id, user_id, message)
+        VALUES ($1, $2, $3)
+        RETURNING id, status, created_at`,
+		app.TeamID, app.UserID, app.Message,
+	).Scan(&app.ID, &app.Status, &app.CreatedAt)
+	if database.IsUniqueViolation(err) {
+		return ErrApplicationExists
+	}
+	return err
+}
+
+// GetApplication retrieves an application by ID
+func (r *postgresRepository) GetApplication(ctx context.Context, applicationID int) (*ApplicationModel, error) {
+	app := &ApplicationModel{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT a.id, a.team_id, a.user_id, COALESCE(p.full_name, ''), COALESCE(a.message, ''),
+               a.status, a.created_at, a.decided_at
+        FROM team_applications a
+        LEFT JOIN profiles p ON p.user_id = a.user_id
+        WHERE a.id = $1`, applicationID,
+	).Scan(&app.ID, &app.TeamID, &app.UserID, &app.FullName, &app.Message, &app.Status, &app.CreatedAt, &app.DecidedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrApplicationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// GetApplications lists the applications of a team, newest first.
+// An empty status returns applications in any status.
+func (r *postgresRepository) GetApplications(ctx context.Context, teamID int, status string) ([]ApplicationModel, error) {
+	query := `
+        SELECT a.id, a.team_id, a.user_id, COALESCE(p.full_name, ''), COALESCE(a.message, ''),
+               a.status, a.created_at, a.decided_at
+        FROM team_applications a
+        LEFT JOIN profiles p ON p.user_id = a.user_id
+        WHERE a.team_id = $1`
+	args := []interface{}{teamID}
+	if status != "" {
+		query += " AND a.status = $2"
+		args = append(args, status)
+	}
+	query += " ORDER BY a.created_at DESC, a.id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apps := []ApplicationModel{}
+	for rows.Next() {
+		var app ApplicationModel
+		if err := rows.Scan(&app.ID, &app.TeamID, &app.UserID, &app.FullName, &app.Message, &app.Status, &app.CreatedAt, &app.DecidedAt); err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	return apps, rows.Err()
+}
+
+// AcceptApplication marks a pending application as accepted, adds the
+// applicant to the team and takes one open slot, all in one transaction
+func (r *postgresRepository) AcceptApplication(ctx context.Context, applicationID, decidedBy int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var teamID, userID int
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`
+        UPDATE team_applications SET status = $2, decided_at = %s, decided_by = $3
+        WHERE id = $1 AND status = $4
+        RETURNING team_id, user_id`, r.dialect.Now()),
+		applicationID, ApplicationAccepted, decidedBy, ApplicationPending,
+	).Scan(&teamID, &userID)
+	if err == sql.ErrNoRows {
+		return ErrApplicationDecided
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        INSERT INTO team_members (team_id, user_id, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (team_id, user_id) DO NOTHING`,
+		teamID, userID, RoleMember)
+	if err != nil {
+		return err
+	}
+	if err := database.RequireRow(result, ErrAlreadyMember); err != nil {
+		return err
+	}
+
+	// Owners may accept more people than advertised; slots never go negative
+	_, err = tx.ExecContext(ctx, `
+        UPDATE teams SET open_slots = open_slots - 1
+        WHERE id = $1 AND open_slots > 0`, teamID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RejectApplication marks a pending application as rejected
+func (r *postgresRepository) RejectApplication(ctx context.Context, applicationID, decidedBy int) error {
+	result, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+        UPDATE team_applications SET status = $2, decided_at = %s, decided_by = $3
+        WHERE id = $1 AND status = $4`, r.dialect.Now()),
+		applicationID, ApplicationRejected, decidedBy, ApplicationPending)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrApplicationDecided)
+}
+
+// GetTeamChat returns the ID of the team group chat, or nil if it has not been created yet
+func (r *postgresRepository) GetTeamChat(ctx context.Context, teamID int) (*string, error) {
+	var chatID sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT chat_id FROM teams WHERE id = $1`, teamID).Scan(&chatID)
+	if err == sql.ErrNoRows {
+		return nil, ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !chatID.Valid {
+		return nil, nil
+	}
+	return &chatID.String, nil
+}
+
+// SetTeamChat links a group chat to a team
+func (r *postgresRepository) SetTeamChat(ctx context.Context, teamID int, chatID string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE teams SET chat_id = $2 WHERE id = $1`, teamID, chatID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrTeamNotFound)
+}
@@ -0,0 +1,96 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+func TestCreateApplicationDuplicate(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO team_applications`)).
+		WithArgs(10, 5, "hi").
+		WillReturnError(&pq.Error{Code: database.UniqueViolation})
+
+	err := repo.CreateApplication(context.Background(), &ApplicationModel{TeamID: 10, UserID: 5, Message: "hi"})
+	assert.Equal(t, ErrApplicationExists, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetApplicationsByStatus(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE a.team_id = $1 AND a.status = $2 ORDER BY a.created_at DESC, a.id DESC`)).
+		WithArgs(10, ApplicationPending).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "user_id", "full_name", "message", "status", "created_at", "decided_at"}).
+			AddRow(1, 10, 5, "John", "hi", ApplicationPending, createdAt, nil))
+
+	apps, err := repo.GetApplications(context.Background(), 10, ApplicationPending)
+	assert.NoError(t, err)
+	if assert.Len(t, apps, 1) {
+		assert.Equal(t, "John", apps[0].FullName)
+		assert.Nil(t, apps[0].DecidedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptApplication(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE team_applications SET status = $2`)).
+		WithArgs(1, ApplicationAccepted, 7, ApplicationPending).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id", "user_id"}).AddRow(10, 5))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO team_members`)).
+		WithArgs(10, 5, RoleMember).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET open_slots = open_slots - 1`)).
+		WithArgs(10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.AcceptApplication(context.Background(), 1, 7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptApplicationAlreadyDecided(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE team_applications SET status = $2`)).
+		WithArgs(1, ApplicationAccepted, 7, ApplicationPending).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err := repo.AcceptApplication(context.Background(), 1, 7)
+	assert.Equal(t, ErrApplicationDecided, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTeamChatNotCreated(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT chat_id FROM teams WHERE id = $1`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow(nil))
+
+	chatID, err := repo.GetTeamChat(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Nil(t, chatID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,509 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+)
+
+// Member roles
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+)
+
+// Application statuses
+const (
+	ApplicationPending  = "pending"
+	ApplicationAccepted = "accepted"
+	ApplicationRejected = "rejected"
+)
+
+var (
+	ErrTeamNotFound        = errors.New("team not found")
+	ErrMemberNotFound      = errors.New("team member not found")
+	ErrAlreadyMember       = errors.New("user is already a team member")
+	ErrApplicationNotFound = errors.New("team application not found")
+	ErrApplicationExists   = errors.New("user already has a pending application")
+	ErrApplicationDecided  = errors.New("team application is already decided")
+)
+
+// TeamModel represents a team stored in the database
+type TeamModel struct {
+	ID            int
+	Name          string
+	CityID        int
+	Bio           string
+	AvatarMediaID *int
+	OpenSlots     int
+	MemberCount   int
+	CreatedAt     time.Time
+}
+
+// UpdateTeamModel holds the team fields to update; nil fields are left unchanged
+type UpdateTeamModel struct {
+	ID        int
+	Name      *string
+	CityID    *int
+	Bio       *string
+	OpenSlots *int
+}
+
+// MemberModel represents a team membership
+type MemberModel struct {
+	TeamID   int
+	UserID   int
+	FullName string
+	Role     string
+	JoinedAt time.Time
+}
+
+// ApplicationModel represents a request to join a team
+type ApplicationModel struct {
+	ID        int
+	TeamID    int
+	UserID    int
+	FullName  string
+	Message   string
+	Status    string
+	CreatedAt time.Time
+	DecidedAt *time.Time
+}
+
+// SearchParams defines the filters for team searches
+type SearchParams struct {
+	CurrentUserID int
+	Name          *string
+	CityID        *int
+	ImprovStyles  []string
+	HasOpenSlots  *bool
+	Page          int
+	PageSize      int
+}
+
+// Repository defines methods for team storage
+type Repository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CreateTeam(ctx context.Context, tx *sql.Tx, team *TeamModel, ownerID int) error
+	UpdateTeam(ctx context.Context, tx *sql.Tx, update *UpdateTeamModel) error
+	SetTeamAvatar(ctx context.Context, tx *sql.Tx, teamID int, mediaID *int) error
+	DeleteTeam(ctx context.Context, teamID int) error
+	GetTeam(ctx context.Context, teamID int) (*TeamModel, error)
+	SetTeamStyles(ctx context.Context, tx *sql.Tx, teamID int, styles []string) error
+	GetTeamStyles(ctx context.Context, teamID int) ([]string, error)
+
+	AddMember(ctx context.Context, teamID, userID int, role string) error
+	GetMember(ctx context.Context, teamID, userID int) (*MemberModel, error)
+	GetMembers(ctx context.Context, teamID int) ([]MemberModel, error)
+	UpdateMemberRole(ctx context.Context, teamID, userID int, role string) error
+	RemoveMember(ctx context.Context, teamID, userID int) error
+	CountOwners(ctx context.Context, teamID int) (int, error)
+
+	CreateApplication(ctx context.Context, app *ApplicationModel) error
+	GetApplication(ctx context.Context, applicationID int) (*ApplicationModel, error)
+	GetApplications(ctx context.Context, teamID int, status string) ([]ApplicationModel, error)
+	AcceptApplication(ctx context.Context, applicationID, decidedBy int) error
+	RejectApplication(ctx context.Context, applicationID, decidedBy int) error
+
+	GetTeamChat(ctx context.Context, teamID int) (*string, error)
+	SetTeamChat(ctx context.Context, teamID int, chatID string) error
+
+	CheckUserExists(ctx context.Context, userID int) (bool, error)
+	ValidateCity(ctx context.Context, cityID int) (bool, error)
+	ValidateImprovStyle(ctx context.Context, style string) (bool, error)
+
+	SearchTeams(ctx context.Context, params SearchParams) ([]*TeamModel, int, error)
+}
+
+type postgresRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewPostgresRepository creates a new team repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{
+		db:      db,
+		dialect: database.DialectFor(db),
+	}
+}
+
+// BeginTx starts a new transaction
+func (r *postgresRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+// CreateTeam inserts a new team and makes ownerID its owner
+func (r *postgresRepository) CreateTeam(ctx context.Context, tx *sql.Tx, team *TeamModel, ownerID int) error {
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO teams (name, city_id, bio, avatar_media_id, open_slots)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`,
+		team.Name, team.CityID, team.Bio, team.AvatarMediaID, team.OpenSlots,
+	).Scan(&team.ID, &team.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO team_members (team_id, user_id, role)
+        VALUES ($1, $2, $3)`,
+		team.ID, ownerID, RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	team.MemberCount = 1
+	return nil
+}
+
+// UpdateTeam updates the non-nil fields of a team
+func (r *postgresRepository) UpdateTeam(ctx context.Context, tx *sql.Tx, update *UpdateTeamModel) error {
+	sets := []string{}
+	args := []interface{}{update.ID}
+	argIndex := 2
+
+	if update.Name != nil {
+		sets = append(sets, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *update.Name)
+		argIndex++
+	}
+	if update.CityID != nil {
+		sets = append(sets, fmt.Sprintf("city_id = $%d", argIndex))
+		args = append(args, *update.CityID)
+		argIndex++
+	}
+	if update.Bio != nil {
+		sets = append(sets, fmt.Sprintf("bio = $%d", argIndex))
+		args = append(args, *update.Bio)
+		argIndex++
+	}
+	if update.OpenSlots != nil {
+		sets = append(sets, fmt.Sprintf("open_slots = $%d", argIndex))
+		args = append(args, *update.OpenSlots)
+	}
+
+	if len(sets) == 0 {
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE teams SET "+strings.Join(sets, ", ")+" WHERE id = $1", args...)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrTeamNotFound)
+}
+
+// SetTeamAvatar sets or clears (nil mediaID) the team avatar
+func (r *postgresRepository) SetTeamAvatar(ctx context.Context, tx *sql.Tx, teamID int, mediaID *int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE teams SET avatar_media_id = $2 WHERE id = $1`, teamID, mediaID)
+	return err
+}
+
+// DeleteTeam deletes a team together with its styles and memberships
+func (r *postgresRepository) DeleteTeam(ctx context.Context, teamID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, teamID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrTeamNotFound)
+}
+
+// GetTeam retrieves a team by ID
+func (r *postgresRepository) GetTeam(ctx context.Context, teamID int) (*TeamModel, error) {
+	team := &TeamModel{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT t.id, t.name, t.city_id, COALESCE(t.bio, ''), t.avatar_media_id, t.open_slots, t.created_at,
+               (SELECT COUNT(*) FROM team_members tm WHERE tm.team_id = t.id) AS member_count
+        FROM teams t
+        WHERE t.id = $1`, teamID,
+	).Scan(&team.ID, &team.Name, &team.CityID, &team.Bio, &team.AvatarMediaID, &team.OpenSlots, &team.CreatedAt, &team.MemberCount)
+	if err == sql.ErrNoRows {
+		return nil, ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// SetTeamStyles replaces the improv styles of a team
+func (r *postgresRepository) SetTeamStyles(ctx context.Context, tx *sql.Tx, teamID int, styles []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_styles WHERE team_id = $1`, teamID); err != nil {
+		return err
+	}
+
+	for _, style := range styles {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO team_styles (team_id, style) VALUES ($1, $2)`, teamID, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTeamStyles retrieves the improv styles of a team
+func (r *postgresRepository) GetTeamStyles(ctx context.Context, teamID int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT style FROM team_styles WHERE team_id = $1 ORDER BY style`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	styles := []string{}
+	for rows.Next() {
+		var style string
+		if err := rows.Scan(&style); err != nil {
+			return nil, err
+		}
+		styles = append(styles, style)
+	}
+	return styles, rows.Err()
+}
+
+// AddMember adds a user to a team
+func (r *postgresRepository) AddMember(ctx context.Context, teamID, userID int, role string) error {
+	result, err := r.db.ExecContext(ctx, `
+        INSERT INTO team_members (team_id, user_id, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (team_id, user_id) DO NOTHING`,
+		teamID, userID, role)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrAlreadyMember)
+}
+
+// GetMember retrieves a single team membership
+func (r *postgresRepository) GetMember(ctx context.Context, teamID, userID int) (*MemberModel, error) {
+	member := &MemberModel{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT tm.team_id, tm.user_id, COALESCE(p.full_name, ''), tm.role, tm.joined_at
+        FROM team_members tm
+        LEFT JOIN profiles p ON p.user_id = tm.user_id
+        WHERE tm.team_id = $1 AND tm.user_id = $2`, teamID, userID,
+	).Scan(&member.TeamID, &member.UserID, &member.FullName, &member.Role, &member.JoinedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// GetMembers retrieves all members of a team, owners first
+func (r *postgresRepository) GetMembers(ctx context.Context, teamID int) ([]MemberModel, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT tm.team_id, tm.user_id, COALESCE(p.full_name, ''), tm.role, tm.joined_at
+        FROM team_members tm
+        LEFT JOIN profiles p ON p.user_id = tm.user_id
+        WHERE tm.team_id = $1
+        ORDER BY tm.role = 'owner' DESC, tm.joined_at`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []MemberModel{}
+	for rows.Next() {
+		var member MemberModel
+		if err := rows.Scan(&member.TeamID, &member.UserID, &member.FullName, &member.Role, &member.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// UpdateMemberRole changes the role of a team member
+func (r *postgresRepository) UpdateMemberRole(ctx context.Context, teamID, userID int, role string) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE team_members SET role = $3
+        WHERE team_id = $1 AND user_id = $2`,
+		teamID, userID, role)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrMemberNotFound)
+}
+
+// RemoveMember removes a user from a team
+func (r *postgresRepository) RemoveMember(ctx context.Context, teamID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+        DELETE FROM team_members
+        WHERE team_id = $1 AND user_id = $2`,
+		teamID, userID)
+	if err != nil {
+		return err
+	}
+	return database.RequireRow(result, ErrMemberNotFound)
+}
+
+// CountOwners returns the number of owners of a team
+func (r *postgresRepository) CountOwners(ctx context.Context, teamID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM team_members
+        WHERE team_id = $1 AND role = $2`,
+		teamID, RoleOwner).Scan(&count)
+	return count, err
+}
+
+// CheckUserExists checks if a user exists
+func (r *postgresRepository) CheckUserExists(ctx context.Context, userID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", userID).Scan(&exists)
+	return exists, err
+}
+
+// ValidateCity checks if a city ID is valid
+func (r *postgresRepository) ValidateCity(ctx context.Context, cityID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM cities WHERE city_id = $1)", cityID).Scan(&exists)
+	return exists, err
+}
+
+// ValidateImprovStyle checks if an improv style is valid
+func (r *postgresRepository) ValidateImprovStyle(ctx context.Context, style string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM improv_style_catalog WHERE style_code = $1)", style).Scan(&exists)
+	return exists, err
+}
+
+// SearchTeams searches for teams and sorts them by the number of improv styles
+// shared with the current user, newest first within equal matches
+func (r *postgresRepository) SearchTeams(ctx context.Context, params SearchParams) ([]*TeamModel, int, error) {
+	// Users without improv styles are matched by the formats from their onboarding quiz
+	baseQuery := `
+        WITH current_user_styles AS (
+            SELECT style FROM improv_profile_styles WHERE user_id = $1
+            UNION
+            SELECT style FROM onboarding_formats
+            WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM improv_profile_styles WHERE user_id = $1)
+        ),
+        team_matches AS (
+            SELECT
+                t.id,
+                t.name,
+                t.city_id,
+                COALESCE(t.bio, '') AS bio,
+                t.avatar_media_id,
+                t.open_slots,
+                t.created_at,
+                (SELECT COUNT(*) FROM team_members tm WHERE tm.team_id = t.id) AS member_count,
+                (
+                    SELECT COUNT(*)
+                    FROM team_styles ts
+                    JOIN current_user_styles cus ON ts.style = cus.style
+                    WHERE ts.team_id = t.id
+                ) AS style_match_count
+            FROM teams t
+    `
+
+	countQuery := `
+        WITH current_user_styles AS (
+            SELECT style FROM improv_profile_styles WHERE user_id = $1
+            UNION
+            SELECT style FROM onboarding_formats
+            WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM improv_profile_styles WHERE user_id = $1)
+        ),
+        team_matches AS (
+            SELECT
+                t.id,
+                (
+                    SELECT COUNT(*)
+                    FROM team_styles ts
+                    JOIN current_user_styles cus ON ts.style = cus.style
+                    WHERE ts.team_id = t.id
+                ) AS style_match_count
+            FROM teams t
+    `
+
+	// Join once for each style to ensure ALL styles are present (AND logic)
+	joins := []string{}
+	for i := range params.ImprovStyles {
+		alias := fmt.Sprintf("ts%d", i)
+		joins = append(joins, fmt.Sprintf("JOIN team_styles %s ON t.id = %s.team_id", alias, alias))
+	}
+	for _, join := range joins {
+		baseQuery += " " + join
+		countQuery += " " + join
+	}
+
+	conditions := []string{}
+	args := []interface{}{params.CurrentUserID}
+	argIndex := 2
+
+	if params.Name != nil && *params.Name != "" {
+		conditions = append(conditions, fmt.Sprintf("t.name %s $%d", r.dialect.ILike(), argIndex))
+		args = append(args, "%"+*params.Name+"%")
+		argIndex++
+	}
+
+	if params.CityID != nil {
+		conditions = append(conditions, fmt.Sprintf("t.city_id = $%d", argIndex))
+		args = append(args, *params.CityID)
+		argIndex++
+	}
+
+	for i, style := range params.ImprovStyles {
+		conditions = append(conditions, fmt.Sprintf("ts%d.style = $%d", i, argIndex))
+		args = append(args, style)
+		argIndex++
+	}
+
+	if params.HasOpenSlots != nil {
+		if *params.HasOpenSlots {
+			conditions = append(conditions, "t.open_slots > 0")
+		} else {
+			conditions = append(conditions, "t.open_slots = 0")
+		}
+	}
+
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		baseQuery += whereClause
+		countQuery += whereClause
+	}
+
+	baseQuery += `) SELECT * FROM team_matches ORDER BY style_match_count DESC, created_at DESC`
+	countQuery += `) SELECT COUNT(*) FROM team_matches`
+
+	var totalCount int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	baseQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	teams := []*TeamModel{}
+	for rows.Next() {
+		team := &TeamModel{}
+		var styleMatchCount int
+		if err := rows.Scan(
+			&team.ID, &team.Name, &team.CityID, &team.Bio, &team.AvatarMediaID,
+			&team.OpenSlots, &team.CreatedAt, &team.MemberCount, &styleMatchCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return teams, totalCount, nil
+}
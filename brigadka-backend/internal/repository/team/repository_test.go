@@ -0,0 +1,128 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *postgresRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewPostgresRepository(db).(*postgresRepository)
+	return db, mock, repo
+}
+
+func TestCreateTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`
+        INSERT INTO teams (name, city_id, bio, avatar_media_id, open_slots)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`)).
+		WithArgs("Team", 1, "bio", nil, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, createdAt))
+	mock.ExpectExec(regexp.QuoteMeta(`
+        INSERT INTO team_members (team_id, user_id, role)
+        VALUES ($1, $2, $3)`)).
+		WithArgs(10, 5, RoleOwner).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := repo.BeginTx(context.Background())
+	assert.NoError(t, err)
+
+	team := &TeamModel{Name: "Team", CityID: 1, Bio: "bio", OpenSlots: 2}
+	err = repo.CreateTeam(context.Background(), tx, team, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	assert.Equal(t, 10, team.ID)
+	assert.Equal(t, createdAt, team.CreatedAt)
+	assert.Equal(t, 1, team.MemberCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTeamNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM teams t`)).
+		WithArgs(10).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetTeam(context.Background(), 10)
+	assert.ErrorIs(t, err, ErrTeamNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddMemberAlreadyMember(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO team_members`)).
+		WithArgs(10, 6, RoleMember).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.AddMember(context.Background(), 10, 6, RoleMember)
+	assert.ErrorIs(t, err, ErrAlreadyMember)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveMemberNotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM team_members`)).
+		WithArgs(10, 6).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.RemoveMember(context.Background(), 10, 6)
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchTeams(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	name := "impro"
+	cityID := 1
+	hasOpenSlots := true
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM team_matches`)).
+		WithArgs(5, "%impro%", 1, "shortform").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM team_matches ORDER BY style_match_count DESC, created_at DESC LIMIT $5 OFFSET $6`)).
+		WithArgs(5, "%impro%", 1, "shortform", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city_id", "bio", "avatar_media_id", "open_slots", "created_at", "member_count", "style_match_count"}).
+			AddRow(10, "Impro Team", 1, "bio", nil, 2, now, 3, 1))
+
+	teams, total, err := repo.SearchTeams(context.Background(), SearchParams{
+		CurrentUserID: 5,
+		Name:          &name,
+		CityID:        &cityID,
+		ImprovStyles:  []string{"shortform"},
+		HasOpenSlots:  &hasOpenSlots,
+		Page:          1,
+		PageSize:      20,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, teams, 1) {
+		assert.Equal(t, "Impro Team", teams[0].Name)
+		assert.Equal(t, 3, teams[0].MemberCount)
+		assert.Nil(t, teams[0].AvatarMediaID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -9,6 +9,7 @@ type User struct {
 	ID           int    `json:"id"`
 	Email        string `json:"email"`
 	PasswordHash string `json:"-"`
+	Role         string `json:"role"`
 }
 
 var (
@@ -34,7 +35,7 @@ func (r *PostgresUserRepository) BeginTx() (*sql.Tx, error) {
 // GetUserByEmail получает пользователя по email
 func (r *PostgresUserRepository) GetUserByEmail(email string) (*User, error) {
 	query := `
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE email = $1
     `
@@ -44,6 +45,7 @@ func (r *PostgresUserRepository) GetUserByEmail(email string) (*User, error) {
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Role,
 	)
 
 	if err != nil {
@@ -61,14 +63,14 @@ func (r *PostgresUserRepository) CreateUser(user *User) error {
 	query := `
         INSERT INTO users (email, password_hash)
         VALUES ($1, $2)
-        RETURNING id
+        RETURNING id, role
     `
 
 	err := r.db.QueryRow(
 		query,
 		user.Email,
 		user.PasswordHash,
-	).Scan(&user.ID)
+	).Scan(&user.ID, &user.Role)
 
 	if err != nil {
 		return err
@@ -80,7 +82,7 @@ func (r *PostgresUserRepository) CreateUser(user *User) error {
 // GetUserByID получает пользователя по ID
 func (r *PostgresUserRepository) GetUserByID(id int) (*User, error) {
 	query := `
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE id = $1
     `
@@ -90,6 +92,7 @@ func (r *PostgresUserRepository) GetUserByID(id int) (*User, error) {
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Role,
 	)
 
 	if err != nil {
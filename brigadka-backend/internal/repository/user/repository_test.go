@@ -22,13 +22,13 @@ func TestGetUserByEmail_Success(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE email = $1
     `)).
 		WithArgs("test@example.com").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash"}).
-			AddRow(1, "test@example.com", "hashed_password"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
+			AddRow(1, "test@example.com", "hashed_password", "user"))
 
 	user, err := repo.GetUserByEmail("test@example.com")
 	assert.NoError(t, err)
@@ -36,6 +36,7 @@ func TestGetUserByEmail_Success(t *testing.T) {
 	assert.Equal(t, 1, user.ID)
 	assert.Equal(t, "test@example.com", user.Email)
 	assert.Equal(t, "hashed_password", user.PasswordHash)
+	assert.Equal(t, "user", user.Role)
 }
 
 func TestGetUserByEmail_NotFound(t *testing.T) {
@@ -43,7 +44,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE email = $1
     `)).
@@ -62,10 +63,10 @@ func TestCreateUser_Success(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(`
         INSERT INTO users (email, password_hash)
         VALUES ($1, $2)
-        RETURNING id
+        RETURNING id, role
     `)).
 		WithArgs("newuser@example.com", "hashed_password").
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(1, "user"))
 
 	user := &User{
 		Email:        "newuser@example.com",
@@ -75,6 +76,7 @@ func TestCreateUser_Success(t *testing.T) {
 	err := repo.CreateUser(user)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, user.ID)
+	assert.Equal(t, "user", user.Role)
 }
 
 func TestCreateUser_Error(t *testing.T) {
@@ -84,7 +86,7 @@ func TestCreateUser_Error(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(`
         INSERT INTO users (email, password_hash)
         VALUES ($1, $2)
-        RETURNING id
+        RETURNING id, role
     `)).
 		WithArgs("newuser@example.com", "hashed_password").
 		WillReturnError(errors.New("database error"))
@@ -104,13 +106,13 @@ func TestGetUserByID_Success(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE id = $1
     `)).
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash"}).
-			AddRow(1, "test@example.com", "hashed_password"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
+			AddRow(1, "test@example.com", "hashed_password", "user"))
 
 	user, err := repo.GetUserByID(1)
 	assert.NoError(t, err)
@@ -118,6 +120,7 @@ func TestGetUserByID_Success(t *testing.T) {
 	assert.Equal(t, 1, user.ID)
 	assert.Equal(t, "test@example.com", user.Email)
 	assert.Equal(t, "hashed_password", user.PasswordHash)
+	assert.Equal(t, "user", user.Role)
 }
 
 func TestGetUserByID_NotFound(t *testing.T) {
@@ -125,7 +128,7 @@ func TestGetUserByID_NotFound(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-        SELECT id, email, password_hash
+        SELECT id, email, password_hash, role
         FROM users 
         WHERE id = $1
     `)).
@@ -0,0 +1,160 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	adminrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/admin"
+)
+
+type ReportedTarget = adminrepo.ReportedTarget
+type FlaggedMedia = adminrepo.FlaggedMedia
+type DailyCount = adminrepo.DailyCount
+
+const (
+	// dashboardListSize is how many entries each dashboard list shows
+	dashboardListSize = 10
+	// dashboardDays is how many days the message volume chart covers, today included
+	dashboardDays = 7
+)
+
+// Dashboard is the moderation overview: what needs review and how busy the community is
+type Dashboard struct {
+	Reports              DashboardReports  `json:"reports"`
+	Users                DashboardUsers    `json:"users"`
+	Media                DashboardMedia    `json:"media"`
+	Messages             DashboardMessages `json:"messages"`
+	ShadowbannedProfiles int               `json:"shadowbanned_profiles"`
+	GeneratedAt          time.Time         `json:"generated_at"`
+}
+
+// DashboardReports are the open reports, most reported content first
+type DashboardReports struct {
+	Open    int              `json:"open"`
+	Targets []ReportedTarget `json:"targets"`
+}
+
+// DashboardUsers are the recent sign-ups
+type DashboardUsers struct {
+	NewLastDay  int           `json:"new_last_day"`
+	NewLastWeek int           `json:"new_last_week"`
+	Recent      []UserSummary `json:"recent"`
+}
+
+// DashboardMedia is the media waiting for a moderator
+type DashboardMedia struct {
+	Pending          int            `json:"pending"`
+	RejectedLastWeek int            `json:"rejected_last_week"`
+	Flagged          []FlaggedMedia `json:"flagged"`
+}
+
+// DashboardMessages is the message volume
+type DashboardMessages struct {
+	LastDay  int          `json:"last_day"`
+	LastWeek int          `json:"last_week"`
+	Daily    []DailyCount `json:"daily"`
+}
+
+// GetDashboard collects the moderation overview
+func (s *AdminServiceImpl) GetDashboard(ctx context.Context) (*Dashboard, error) {
+	now := s.now()
+
+	counts, err := s.repo.GetDashboardCounts(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := s.repo.GetReportedTargets(ctx, dashboardListSize)
+	if err != nil {
+		return nil, err
+	}
+	users, _, err := s.repo.SearchUsers(ctx, UserFilter{}, now, dashboardListSize, 0)
+	if err != nil {
+		return nil, err
+	}
+	media, err := s.repo.GetFlaggedMedia(ctx, dashboardListSize)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := s.repo.GetDailyMessageCounts(ctx, now.AddDate(0, 0, 1-dashboardDays), dashboardDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dashboard{
+		Reports: DashboardReports{
+			Open:    counts.OpenReports,
+			Targets: targets,
+		},
+		Users: DashboardUsers{
+			NewLastDay:  counts.NewUsersDay,
+			NewLastWeek: counts.NewUsersWeek,
+			Recent:      users,
+		},
+		Media: DashboardMedia{
+			Pending:          counts.PendingMedia,
+			RejectedLastWeek: counts.RejectedMediaWeek,
+			Flagged:          media,
+		},
+		Messages: DashboardMessages{
+			LastDay:  counts.MessagesDay,
+			LastWeek: counts.MessagesWeek,
+			Daily:    daily,
+		},
+		ShadowbannedProfiles: counts.ShadowbannedProfiles,
+		GeneratedAt:          now,
+	}, nil
+}
+
+// ShadowbanProfile keeps the profile out of search, recommendations and tag
+// suggestions without telling its owner. Chats and teams are unaffected.
+func (s *AdminServiceImpl) ShadowbanProfile(ctx context.Context, adminID, userID int, reason string) error {
+	details, err := reasonDetails(reason)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileShadowban, details)
+	return mapProfileError(s.repo.SetProfileShadowbanned(ctx, userID, &now, entry))
+}
+
+// UnshadowbanProfile returns a shadowbanned profile to discovery
+func (s *AdminServiceImpl) UnshadowbanProfile(ctx context.Context, adminID, userID int) error {
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileUnshadowban, nil)
+	return mapProfileError(s.repo.SetProfileShadowbanned(ctx, userID, nil, entry))
+}
+
+// RemoveMessage hides a message from chat history and resolves its open reports
+func (s *AdminServiceImpl) RemoveMessage(ctx context.Context, adminID int, messageID, reason string) error {
+	if _, err := uuid.Parse(messageID); err != nil {
+		return ErrContentNotFound
+	}
+	return s.removeContent(ctx, adminID, adminrepo.TargetMessage, messageID, adminrepo.ActionMessageRemove, reason)
+}
+
+// RemoveMedia rejects media so it is no longer served and resolves its open reports
+func (s *AdminServiceImpl) RemoveMedia(ctx context.Context, adminID, mediaID int, reason string) error {
+	return s.removeContent(ctx, adminID, adminrepo.TargetMedia, strconv.Itoa(mediaID), adminrepo.ActionMediaRemove, reason)
+}
+
+func (s *AdminServiceImpl) removeContent(ctx context.Context, adminID int, targetType, targetID, action, reason string) error {
+	details, err := reasonDetails(reason)
+	if err != nil {
+		return err
+	}
+	entry := &AuditEntry{
+		AdminID:    &adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	}
+	err = s.repo.RemoveContent(ctx, targetType, targetID, adminID, s.now(), entry)
+	if errors.Is(err, adminrepo.ErrContentNotFound) {
+		return ErrContentNotFound
+	}
+	return err
+}
@@ -0,0 +1,362 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	adminrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/admin"
+	userrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/user"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+type UserFilter = adminrepo.UserFilter
+type UserSummary = adminrepo.UserSummary
+type ProfileFilter = adminrepo.ProfileFilter
+type ProfileSummary = adminrepo.ProfileSummary
+type AuditFilter = adminrepo.AuditFilter
+type AuditEntry = adminrepo.AuditEntry
+type SupportAccess = adminrepo.SupportAccess
+
+// MaxBanReasonLength limits the reason recorded with a ban
+const MaxBanReasonLength = 500
+
+// SupportAccessDuration is how long a user's consent to impersonation lasts
+const SupportAccessDuration = 72 * time.Hour
+
+// Возможные ошибки сервиса
+var (
+	ErrInvalidStatus    = errors.New("status must be active, suspended or hidden")
+	ErrProfileNotFound  = errors.New("profile not found")
+	ErrInvalidBanReason = errors.New("ban reason must be at most 500 characters")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrNoSupportAccess  = errors.New("user has not granted support access")
+	ErrImpersonateAdmin = errors.New("admins cannot be impersonated")
+	ErrContentNotFound  = errors.New("content not found")
+)
+
+// ProfileEditor applies profile changes with the same validation users get
+type ProfileEditor interface {
+	UpdateProfile(userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error)
+}
+
+// UserDirectory is a page of the admin user directory
+type UserDirectory struct {
+	Users      []UserSummary `json:"users"`
+	TotalCount int           `json:"total_count"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+}
+
+// UserRepository looks up the users being impersonated
+type UserRepository interface {
+	GetUserByID(id int) (*userrepo.User, error)
+}
+
+// TokenIssuer issues impersonation tokens
+type TokenIssuer interface {
+	IssueImpersonationToken(user *userrepo.User, adminID int) (string, time.Time, error)
+}
+
+// ImpersonationSession is a read-only token for viewing the app as a user
+type ImpersonationSession struct {
+	Token     string    `json:"token"`
+	UserID    int       `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SupportAccessStatus tells a user whether support may view the app as them
+type SupportAccessStatus struct {
+	Granted   bool       `json:"granted"`
+	GrantedAt *time.Time `json:"granted_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ProfileList is a page of the admin profile list
+type ProfileList struct {
+	Profiles   []ProfileSummary `json:"profiles"`
+	TotalCount int              `json:"total_count"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+}
+
+// AuditLog is a page of the admin audit log
+type AuditLog struct {
+	Entries    []AuditEntry `json:"entries"`
+	TotalCount int          `json:"total_count"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"`
+}
+
+// AdminServiceImpl implements support and moderation tools for admins
+type AdminServiceImpl struct {
+	repo     adminrepo.Repository
+	profiles ProfileEditor
+	users    UserRepository
+	tokens   TokenIssuer
+	now      func() time.Time
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(repo adminrepo.Repository, profiles ProfileEditor, users UserRepository, tokens TokenIssuer) *AdminServiceImpl {
+	return &AdminServiceImpl{
+		repo:     repo,
+		profiles: profiles,
+		users:    users,
+		tokens:   tokens,
+		now:      time.Now,
+	}
+}
+
+// SearchUsers returns a page of the user directory, newest accounts first
+func (s *AdminServiceImpl) SearchUsers(ctx context.Context, filter UserFilter, page, pageSize int) (*UserDirectory, error) {
+	switch filter.Status {
+	case "", adminrepo.UserActive, adminrepo.UserSuspended, adminrepo.UserHidden:
+	default:
+		return nil, ErrInvalidStatus
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	users, total, err := s.repo.SearchUsers(ctx, filter, s.now(), pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDirectory{
+		Users:      users,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// SearchProfiles returns a page of profiles, newest first
+func (s *AdminServiceImpl) SearchProfiles(ctx context.Context, filter ProfileFilter, page, pageSize int) (*ProfileList, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	profiles, total, err := s.repo.SearchProfiles(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ProfileList{
+		Profiles:   profiles,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// UpdateProfile edits a user's profile on their behalf and records the change in the audit log
+func (s *AdminServiceImpl) UpdateProfile(ctx context.Context, adminID, userID int, req profile.ProfileUpdateRequest) (*profile.Profile, error) {
+	updated, err := s.profiles.UpdateProfile(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The edit is committed by the profile service, so a failed audit write
+	// is logged rather than reported as a failed edit
+	details, _ := json.Marshal(req)
+	if err := s.repo.AddAuditEntry(ctx, newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileUpdate, details)); err != nil {
+		log.Printf("Failed to audit profile update of user %d by admin %d: %v", userID, adminID, err)
+	}
+	return updated, nil
+}
+
+// BanProfile hides the profile from the community. The account itself stays
+// usable; suspensions are the tool for locking it.
+func (s *AdminServiceImpl) BanProfile(ctx context.Context, adminID, userID int, reason string) error {
+	details, err := reasonDetails(reason)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileBan, details)
+	return mapProfileError(s.repo.SetProfileBanned(ctx, userID, &now, entry))
+}
+
+// UnbanProfile returns the profile to search, including profiles hidden after a report
+func (s *AdminServiceImpl) UnbanProfile(ctx context.Context, adminID, userID int) error {
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileUnban, nil)
+	return mapProfileError(s.repo.SetProfileBanned(ctx, userID, nil, entry))
+}
+
+// VerifyProfile marks the profile as verified
+func (s *AdminServiceImpl) VerifyProfile(ctx context.Context, adminID, userID int) error {
+	now := s.now()
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileVerify, nil)
+	return mapProfileError(s.repo.SetProfileVerified(ctx, userID, &now, entry))
+}
+
+// UnverifyProfile revokes the profile verification
+func (s *AdminServiceImpl) UnverifyProfile(ctx context.Context, adminID, userID int) error {
+	entry := newProfileAuditEntry(adminID, userID, adminrepo.ActionProfileUnverify, nil)
+	return mapProfileError(s.repo.SetProfileVerified(ctx, userID, nil, entry))
+}
+
+// GetAuditLog returns a page of admin actions, newest first
+func (s *AdminServiceImpl) GetAuditLog(ctx context.Context, filter AuditFilter, page, pageSize int) (*AuditLog, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	entries, total, err := s.repo.GetAuditLog(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{
+		Entries:    entries,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// GetSupportAccess returns whether the user currently allows support to view the app as them
+func (s *AdminServiceImpl) GetSupportAccess(ctx context.Context, userID int) (*SupportAccessStatus, error) {
+	access, err := s.repo.GetSupportAccess(ctx, userID, s.now())
+	if err != nil {
+		if errors.Is(err, adminrepo.ErrNoSupportAccess) {
+			return &SupportAccessStatus{}, nil
+		}
+		return nil, err
+	}
+	return &SupportAccessStatus{
+		Granted:   true,
+		GrantedAt: &access.GrantedAt,
+		ExpiresAt: &access.ExpiresAt,
+	}, nil
+}
+
+// GrantSupportAccess lets support view the app as the user for SupportAccessDuration.
+// Granting again extends the grant.
+func (s *AdminServiceImpl) GrantSupportAccess(ctx context.Context, userID int) (*SupportAccessStatus, error) {
+	now := s.now()
+	access := &SupportAccess{
+		UserID:    userID,
+		GrantedAt: now,
+		ExpiresAt: now.Add(SupportAccessDuration),
+	}
+	if err := s.repo.GrantSupportAccess(ctx, access); err != nil {
+		return nil, err
+	}
+	return &SupportAccessStatus{
+		Granted:   true,
+		GrantedAt: &access.GrantedAt,
+		ExpiresAt: &access.ExpiresAt,
+	}, nil
+}
+
+// RevokeSupportAccess withdraws the consent. Impersonation tokens already
+// issued stop working on their next request.
+func (s *AdminServiceImpl) RevokeSupportAccess(ctx context.Context, userID int) error {
+	return s.repo.RevokeSupportAccess(ctx, userID)
+}
+
+// StartImpersonation issues a read-only token acting as the user, who must have granted support access
+func (s *AdminServiceImpl) StartImpersonation(ctx context.Context, adminID, userID int) (*ImpersonationSession, error) {
+	user, err := s.users.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, userrepo.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	// Impersonating an admin would hand out admin routes
+	if user.Role == auth.RoleAdmin {
+		return nil, ErrImpersonateAdmin
+	}
+
+	if _, err := s.repo.GetSupportAccess(ctx, userID, s.now()); err != nil {
+		if errors.Is(err, adminrepo.ErrNoSupportAccess) {
+			return nil, ErrNoSupportAccess
+		}
+		return nil, err
+	}
+
+	token, expiresAt, err := s.tokens.IssueImpersonationToken(user, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, _ := json.Marshal(map[string]time.Time{"expires_at": expiresAt})
+	entry := newUserAuditEntry(adminID, userID, adminrepo.ActionImpersonationStart, details)
+	if err := s.repo.AddAuditEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationSession{
+		Token:     token,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// AuthorizeImpersonation reports whether the user still allows support access
+// and records the request in the audit log. A request that cannot be audited fails.
+func (s *AdminServiceImpl) AuthorizeImpersonation(ctx context.Context, adminID, userID int, method, path string) (bool, error) {
+	if _, err := s.repo.GetSupportAccess(ctx, userID, s.now()); err != nil {
+		if errors.Is(err, adminrepo.ErrNoSupportAccess) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	details, _ := json.Marshal(map[string]string{"method": method, "path": path})
+	entry := newUserAuditEntry(adminID, userID, adminrepo.ActionImpersonationRequest, details)
+	if err := s.repo.AddAuditEntry(ctx, entry); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func newUserAuditEntry(adminID, userID int, action string, details []byte) *AuditEntry {
+	return &AuditEntry{
+		AdminID:    &adminID,
+		Action:     action,
+		TargetType: adminrepo.TargetUser,
+		TargetID:   strconv.Itoa(userID),
+		Details:    details,
+	}
+}
+
+func newProfileAuditEntry(adminID, userID int, action string, details []byte) *AuditEntry {
+	return &AuditEntry{
+		AdminID:    &adminID,
+		Action:     action,
+		TargetType: adminrepo.TargetProfile,
+		TargetID:   strconv.Itoa(userID),
+		Details:    details,
+	}
+}
+
+// reasonDetails validates the reason given for a moderation action and turns it
+// into audit details; an empty reason records no details
+func reasonDetails(reason string) ([]byte, error) {
+	reason = strings.TrimSpace(reason)
+	if len([]rune(reason)) > MaxBanReasonLength {
+		return nil, ErrInvalidBanReason
+	}
+	if reason == "" {
+		return nil, nil
+	}
+	return json.Marshal(map[string]string{"reason": reason})
+}
+
+func mapProfileError(err error) error {
+	if errors.Is(err, adminrepo.ErrProfileNotFound) {
+		return ErrProfileNotFound
+	}
+	return err
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize
+}
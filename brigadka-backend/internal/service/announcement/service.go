@@ -0,0 +1,215 @@
+package announcement
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+	announcementrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/announcement"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+type Announcement = announcementrepo.Announcement
+
+const (
+	// MaxTitleLength limits the announcement title
+	MaxTitleLength = 200
+	// MaxBodyLength limits the announcement text
+	MaxBodyLength = 4000
+
+	// DefaultPageSize is the number of announcements returned when no limit is given
+	DefaultPageSize = 20
+	// MaxPageSize caps the number of announcements returned at once
+	MaxPageSize = 100
+
+	// pushBatchSize is the number of recipients loaded at a time while pushing an announcement
+	pushBatchSize = 500
+	// pushWorkers is the number of notifications sent concurrently
+	pushWorkers = 8
+	// maxPushBodyLength cuts long announcements in notifications
+	maxPushBodyLength = 200
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrAnnouncementNotFound = errors.New("announcement not found")
+	ErrInvalidTitle         = errors.New("announcement title must be 1-200 characters")
+	ErrInvalidBody          = errors.New("announcement text must be 1-4000 characters")
+)
+
+// Listener is told about every new announcement, for real-time delivery
+type Listener interface {
+	AnnouncementPosted(announcement Announcement)
+}
+
+// PushService notifies users about announcements
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// Page is a page of announcements, newest first
+type Page struct {
+	Announcements []Announcement `json:"announcements"`
+	NextBefore    *string        `json:"next_before"`  // Cursor for older announcements; nil when there are none
+	UnreadCount   int            `json:"unread_count"` // Of all announcements, not just this page
+}
+
+// AnnouncementServiceImpl publishes announcements to all users and tracks what they read
+type AnnouncementServiceImpl struct {
+	repo        announcementrepo.Repository
+	pushService PushService
+	listener    Listener // Optional
+}
+
+// NewAnnouncementService creates a new announcement service
+func NewAnnouncementService(repo announcementrepo.Repository, pushService PushService) *AnnouncementServiceImpl {
+	return &AnnouncementServiceImpl{
+		repo:        repo,
+		pushService: pushService,
+	}
+}
+
+// SetListener enables real-time delivery of announcements
+func (s *AnnouncementServiceImpl) SetListener(listener Listener) {
+	s.listener = listener
+}
+
+// PostAnnouncement publishes an announcement of an admin. Connected users get it right
+// away, users with a registered device get a push notification in the background.
+func (s *AnnouncementServiceImpl) PostAnnouncement(ctx context.Context, adminID int, title, body string) (*Announcement, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len([]rune(title)) > MaxTitleLength {
+		return nil, ErrInvalidTitle
+	}
+	body = strings.TrimSpace(body)
+	if body == "" || len([]rune(body)) > MaxBodyLength {
+		return nil, ErrInvalidBody
+	}
+
+	announcement := &Announcement{
+		ID:       idgen.New(),
+		AuthorID: &adminID,
+		Title:    title,
+		Body:     body,
+	}
+	if err := s.repo.CreateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	if s.listener != nil {
+		s.listener.AnnouncementPosted(*announcement)
+	}
+	go s.pushAnnouncement(*announcement)
+
+	return announcement, nil
+}
+
+// GetAnnouncements returns a page of announcements older than beforeID, marking the ones the user has read
+func (s *AnnouncementServiceImpl) GetAnnouncements(ctx context.Context, userID int, beforeID string, limit int) (*Page, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	// One more than requested tells whether older announcements follow
+	announcements, err := s.repo.GetAnnouncements(ctx, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	lastReadID, err := s.repo.GetLastReadID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Announcements: announcements, UnreadCount: unread}
+	if len(announcements) > limit {
+		page.Announcements = announcements[:limit]
+		next := page.Announcements[limit-1].ID
+		page.NextBefore = &next
+	}
+	for i := range page.Announcements {
+		// IDs are ULIDs, so older announcements have smaller IDs
+		page.Announcements[i].Read = page.Announcements[i].ID <= lastReadID
+	}
+	return page, nil
+}
+
+// MarkRead marks the announcement and all older ones read and returns the unread count
+func (s *AnnouncementServiceImpl) MarkRead(ctx context.Context, userID int, announcementID string) (int, error) {
+	if _, err := s.repo.MarkRead(ctx, userID, announcementID); err != nil {
+		if errors.Is(err, announcementrepo.ErrAnnouncementNotFound) {
+			return 0, ErrAnnouncementNotFound
+		}
+		return 0, err
+	}
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// pushAnnouncement notifies every user with a registered device, a batch at a time
+func (s *AnnouncementServiceImpl) pushAnnouncement(announcement Announcement) {
+	payload := push.NotificationPayload{
+		Title:    announcement.Title,
+		Body:     truncate(announcement.Body, maxPushBodyLength),
+		Sound:    "default",
+		Category: push.CategoryAnnouncement,
+	}
+
+	ctx := context.Background()
+	userIDs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < pushWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for userID := range userIDs {
+				s.sendPush(ctx, userID, payload)
+			}
+		}()
+	}
+
+	afterUserID := 0
+	for {
+		recipients, err := s.repo.GetPushRecipients(ctx, afterUserID, pushBatchSize)
+		if err != nil {
+			log.Printf("Failed to load recipients of announcement %s: %v", announcement.ID, err)
+			break
+		}
+		for _, userID := range recipients {
+			userIDs <- userID
+		}
+		if len(recipients) < pushBatchSize {
+			break
+		}
+		afterUserID = recipients[len(recipients)-1]
+	}
+	close(userIDs)
+	wg.Wait()
+}
+
+func (s *AnnouncementServiceImpl) sendPush(ctx context.Context, userID int, payload push.NotificationPayload) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+		log.Printf("Error sending announcement to user %d: %v", userID, err)
+	}
+}
+
+// truncate cuts s to at most limit runes, marking the cut with an ellipsis
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
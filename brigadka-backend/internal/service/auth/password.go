@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Password policy violation codes returned to clients
+const (
+	PasswordTooShort     = "too_short"
+	PasswordTooLong      = "too_long"
+	PasswordNoUppercase  = "missing_uppercase"
+	PasswordNoLowercase  = "missing_lowercase"
+	PasswordNoDigit      = "missing_digit"
+	PasswordNoSymbol     = "missing_symbol"
+	PasswordBreached     = "breached"
+	maxBcryptPasswordLen = 72
+)
+
+// PasswordPolicyError describes why a password was rejected
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password does not satisfy policy: " + strings.Join(e.Violations, ", ")
+}
+
+// BreachChecker checks whether a password appears in known data breaches
+type BreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// PasswordPolicy holds the password requirements applied on registration
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	BreachChecker    BreachChecker
+}
+
+// DefaultPasswordPolicy returns the policy used when nothing is configured
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+	}
+}
+
+// Validate checks the password against the policy and returns a *PasswordPolicyError listing all violations
+func (p PasswordPolicy) Validate(ctx context.Context, password string) error {
+	var violations []string
+
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, PasswordTooShort)
+	}
+	// bcrypt silently ignores everything after 72 bytes
+	if len(password) > maxBcryptPasswordLen {
+		violations = append(violations, PasswordTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, PasswordNoUppercase)
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, PasswordNoLowercase)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordNoDigit)
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordNoSymbol)
+	}
+
+	// Only query the breach API for passwords that pass the local checks
+	if len(violations) == 0 && p.BreachChecker != nil {
+		breached, err := p.BreachChecker.IsBreached(ctx, password)
+		if err != nil {
+			// Fail open: the external API being down must not block registrations
+			log.Printf("Breached password check failed: %v", err)
+		} else if breached {
+			violations = append(violations, PasswordBreached)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// PwnedPasswordsChecker queries the Have I Been Pwned range API using k-anonymity:
+// only the first 5 characters of the SHA-1 hash leave the server
+type PwnedPasswordsChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewPwnedPasswordsChecker creates a checker for the given range API base URL
+func NewPwnedPasswordsChecker(baseURL string) *PwnedPasswordsChecker {
+	if baseURL == "" {
+		baseURL = "https://api.pwnedpasswords.com/range/"
+	}
+	return &PwnedPasswordsChecker{
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/",
+		client:  &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// IsBreached reports whether the password hash suffix is present in the range response
+func (c *PwnedPasswordsChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the real number of matches from network observers
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status from breach API: %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		// Each line has the form SUFFIX:COUNT
+		line := strings.TrimSpace(scanner.Text())
+		candidate, count, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		// Padding entries have a zero count
+		return strings.TrimSpace(count) != "0", nil
+	}
+
+	return false, scanner.Err()
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -13,6 +14,31 @@ import (
 
 type User = userrepo.User
 
+// Token scopes
+const (
+	ScopeUser  = "user"
+	ScopeGuest = "guest"
+	// ScopeImpersonation is a read-only token support uses to view the app as the user
+	ScopeImpersonation = "impersonation"
+)
+
+// User roles
+const (
+	RoleUser      = "user"
+	RoleOrganizer = "organizer"
+	RoleAdmin     = "admin"
+)
+
+// TokenClaims holds the identity extracted from an access token
+type TokenClaims struct {
+	UserID int
+	Email  string
+	Scope  string
+	Role   string
+	// ImpersonatorID is the admin behind an impersonation token, 0 otherwise
+	ImpersonatorID int
+}
+
 type UserRepository interface {
 	GetUserByEmail(email string) (*User, error)
 	GetUserByID(id int) (*User, error)
@@ -20,10 +46,13 @@ type UserRepository interface {
 }
 
 type AuthService struct {
-	userRepository UserRepository
-	jwtSecret      []byte
-	tokenExpiry    time.Duration
-	refreshExpiry  time.Duration
+	userRepository      UserRepository
+	jwtSecret           []byte
+	tokenExpiry         time.Duration
+	refreshExpiry       time.Duration
+	guestExpiry         time.Duration
+	impersonationExpiry time.Duration
+	passwordPolicy      PasswordPolicy
 }
 
 type AuthResponse struct {
@@ -34,13 +63,21 @@ type AuthResponse struct {
 
 func NewAuthService(userRepo UserRepository, jwtSecret string) *AuthService {
 	return &AuthService{
-		userRepository: userRepo,
-		jwtSecret:      []byte(jwtSecret),
-		tokenExpiry:    time.Hour * 1,      // Token valid for 1 hour
-		refreshExpiry:  time.Hour * 24 * 7, // Refresh token valid for 7 days
+		userRepository:      userRepo,
+		jwtSecret:           []byte(jwtSecret),
+		tokenExpiry:         time.Hour * 1,      // Token valid for 1 hour
+		refreshExpiry:       time.Hour * 24 * 7, // Refresh token valid for 7 days
+		guestExpiry:         time.Hour * 24,     // Guest token valid for 1 day
+		impersonationExpiry: time.Minute * 15,   // Impersonation token valid for 15 minutes
+		passwordPolicy:      DefaultPasswordPolicy(),
 	}
 }
 
+// SetPasswordPolicy overrides the password policy applied on registration
+func (s *AuthService) SetPasswordPolicy(policy PasswordPolicy) {
+	s.passwordPolicy = policy
+}
+
 func (s *AuthService) Login(email, password string) (*AuthResponse, error) {
 	user, err := s.userRepository.GetUserByEmail(email)
 
@@ -92,6 +129,11 @@ func (s *AuthService) Register(email, password string) (*AuthResponse, error) {
 		return nil, errors.New("email already registered")
 	}
 
+	// Validate password strength
+	if err := s.passwordPolicy.Validate(context.Background(), password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -140,7 +182,7 @@ func (s *AuthService) RefreshToken(refreshToken string) (*AuthResponse, error) {
 		return s.jwtSecret, nil
 	})
 
-	if err != nil || !token.Valid {
+	if err != nil || !token.Valid || !expiresWithin(claims, s.refreshExpiry) {
 		return nil, errors.New("invalid refresh token")
 	}
 
@@ -188,19 +230,60 @@ func (s *AuthService) VerifyToken(tokenString string) error {
 		return s.jwtSecret, nil
 	})
 
-	if err != nil || !token.Valid {
+	if err != nil || !token.Valid || !expiresWithin(claims, s.maxAccessExpiry()) {
 		return errors.New("invalid token")
 	}
 
 	return nil
 }
 
+// IssueGuestToken creates a limited-scope access token for browsing without an account
+func (s *AuthService) IssueGuestToken() (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.guestExpiry)
+	claims := jwt.MapClaims{
+		"user_id": 0,
+		"email":   "",
+		"exp":     expiresAt.Unix(),
+		"type":    "access",
+		"scope":   ScopeGuest,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.jwtSecret)
+	if err != nil {
+		return "", time.Time{}, errors.New("failed to generate token")
+	}
+	return token, expiresAt, nil
+}
+
+// IssueImpersonationToken creates a short-lived token acting as user on behalf of adminID.
+// Permission checks are up to the caller.
+func (s *AuthService) IssueImpersonationToken(user *User, adminID int) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.impersonationExpiry)
+	claims := jwt.MapClaims{
+		"user_id":         user.ID,
+		"email":           user.Email,
+		"exp":             expiresAt.Unix(),
+		"type":            "access",
+		"scope":           ScopeImpersonation,
+		"role":            user.Role,
+		"impersonator_id": adminID,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.jwtSecret)
+	if err != nil {
+		return "", time.Time{}, errors.New("failed to generate token")
+	}
+	return token, expiresAt, nil
+}
+
 func (s *AuthService) generateToken(user *User) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
 		"email":   user.Email,
-		"exp":     time.Now().Add(s.tokenExpiry).UnixNano(),
+		"exp":     time.Now().Add(s.tokenExpiry).Unix(),
 		"type":    "access",
+		"scope":   ScopeUser,
+		"role":    user.Role,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -210,7 +293,7 @@ func (s *AuthService) generateToken(user *User) (string, error) {
 func (s *AuthService) generateRefreshToken(user *User) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
-		"exp":     time.Now().Add(s.refreshExpiry).UnixNano(),
+		"exp":     time.Now().Add(s.refreshExpiry).Unix(),
 		"type":    "refresh",
 	}
 
@@ -218,7 +301,47 @@ func (s *AuthService) generateRefreshToken(user *User) (string, error) {
 	return token.SignedString(s.jwtSecret)
 }
 
+// maxAccessExpiry is the longest lifetime of a token accepted as an access token
+func (s *AuthService) maxAccessExpiry() time.Duration {
+	longest := s.tokenExpiry
+	for _, expiry := range []time.Duration{s.guestExpiry, s.impersonationExpiry} {
+		if expiry > longest {
+			longest = expiry
+		}
+	}
+	return longest
+}
+
+// expiresWithin reports whether the token expires within its lifetime from now.
+// Tokens once issued with the expiry in nanoseconds would otherwise never expire.
+func expiresWithin(claims jwt.MapClaims, lifetime time.Duration) bool {
+	exp, ok := claims["exp"].(float64)
+	return ok && exp <= float64(time.Now().Add(lifetime).Unix())
+}
+
+// GetUserRole returns the current role of the user, or an empty string when the user
+// does not exist. Unlike the role in the access token, it reflects role changes right away.
+func (s *AuthService) GetUserRole(userID int) (string, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, userrepo.ErrUserNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return user.Role, nil
+}
+
 func (s *AuthService) GetUserInfoFromToken(tokenString string) (int, string, error) {
+	claims, err := s.ParseAccessToken(tokenString)
+	if err != nil {
+		return 0, "", err
+	}
+	return claims.UserID, claims.Email, nil
+}
+
+// ParseAccessToken validates an access token and returns its identity and scope
+func (s *AuthService) ParseAccessToken(tokenString string) (*TokenClaims, error) {
 	claims := jwt.MapClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -228,12 +351,45 @@ func (s *AuthService) GetUserInfoFromToken(tokenString string) (int, string, err
 		return s.jwtSecret, nil
 	})
 
-	if err != nil || !token.Valid {
-		return 0, "", errors.New("invalid token")
+	if err != nil || !token.Valid || !expiresWithin(claims, s.maxAccessExpiry()) {
+		return nil, errors.New("invalid token")
 	}
 
-	userID := int(claims["user_id"].(float64))
-	email := claims["email"].(string)
+	// Refresh tokens are signed with the same secret but must not authenticate requests
+	tokenType, ok := claims["type"].(string)
+	if !ok || tokenType != "access" {
+		return nil, errors.New("invalid token type")
+	}
 
-	return userID, email, nil
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	email, _ := claims["email"].(string)
+
+	// Tokens issued before scopes were introduced are full user tokens
+	scope, ok := claims["scope"].(string)
+	if !ok || scope == "" {
+		scope = ScopeUser
+	}
+
+	role, ok := claims["role"].(string)
+	if !ok || role == "" {
+		role = RoleUser
+	}
+
+	result := &TokenClaims{
+		UserID: int(userID),
+		Email:  email,
+		Scope:  scope,
+		Role:   role,
+	}
+	if scope == ScopeImpersonation {
+		impersonatorID, ok := claims["impersonator_id"].(float64)
+		if !ok || impersonatorID == 0 {
+			return nil, errors.New("invalid token")
+		}
+		result.ImpersonatorID = int(impersonatorID)
+	}
+	return result, nil
 }
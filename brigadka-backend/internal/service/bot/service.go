@@ -0,0 +1,318 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/netguard"
+	botrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/bot"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+)
+
+// APIKeyPrefix marks bot API keys so they are easy to tell apart from user tokens
+const APIKeyPrefix = "bk_"
+
+// Возможные ошибки сервиса
+var (
+	ErrBotNotFound       = errors.New("bot not found")
+	ErrInvalidName       = errors.New("invalid bot name")
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+	ErrInvalidAPIKey     = errors.New("invalid API key")
+	ErrChatNotFound      = errors.New("chat not found")
+	ErrNotGroupChat      = errors.New("bots can only write to group chats")
+	ErrEmptyMessage      = errors.New("message content is empty")
+)
+
+// Bot represents a bot account as seen by its owner
+type Bot struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	WebhookURL *string   `json:"webhook_url,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Credentials are returned once, when a bot is created
+type Credentials struct {
+	Bot
+	APIKey string `json:"api_key"`
+	// WebhookSecret signs webhook requests (X-Brigadka-Signature header)
+	WebhookSecret string `json:"webhook_secret"`
+}
+
+// MessagingService is the part of the messaging service used by bots
+type MessagingService interface {
+	GetUserChats(userID int) ([]messaging.Chat, error)
+	GetChat(chatID string, userID int) (*messaging.Chat, error)
+	AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error)
+}
+
+// BotServiceImpl manages bot accounts and their access to chats
+type BotServiceImpl struct {
+	repo             botrepo.Repository
+	messagingService MessagingService
+	messageListener  messaging.MessageListener
+	webhooks         *WebhookDispatcher
+}
+
+// NewBotService creates a new bot service
+func NewBotService(repo botrepo.Repository, messagingService MessagingService) *BotServiceImpl {
+	return &BotServiceImpl{
+		repo:             repo,
+		messagingService: messagingService,
+		webhooks:         NewWebhookDispatcher(),
+	}
+}
+
+// SetMessageListener enables real-time delivery of messages posted by bots
+func (s *BotServiceImpl) SetMessageListener(listener messaging.MessageListener) {
+	s.messageListener = listener
+}
+
+// CreateBot creates a bot owned by the user and returns its credentials
+func (s *BotServiceImpl) CreateBot(ctx context.Context, ownerID int, name string, webhookURL *string) (*Credentials, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+	webhookURL, err := normalizeWebhookURL(ctx, webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	apiKey = APIKeyPrefix + apiKey
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	model := &botrepo.BotModel{
+		OwnerID:       ownerID,
+		Name:          name,
+		APIKeyHash:    hashAPIKey(apiKey),
+		WebhookURL:    webhookURL,
+		WebhookSecret: secret,
+	}
+	if err := s.repo.CreateBot(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return &Credentials{
+		Bot:           convertBot(model),
+		APIKey:        apiKey,
+		WebhookSecret: secret,
+	}, nil
+}
+
+// GetBots returns bots created by the user
+func (s *BotServiceImpl) GetBots(ctx context.Context, ownerID int) ([]Bot, error) {
+	models, err := s.repo.GetBotsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	bots := make([]Bot, len(models))
+	for i := range models {
+		bots[i] = convertBot(&models[i])
+	}
+	return bots, nil
+}
+
+// UpdateWebhook sets the webhook URL of the user's bot; an empty URL disables webhooks
+func (s *BotServiceImpl) UpdateWebhook(ctx context.Context, ownerID, botID int, webhookURL *string) (*Bot, error) {
+	model, err := s.getOwnedBot(ctx, ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+	webhookURL, err = normalizeWebhookURL(ctx, webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateWebhook(ctx, botID, webhookURL); err != nil {
+		return nil, mapRepoError(err)
+	}
+	model.WebhookURL = webhookURL
+
+	bot := convertBot(model)
+	return &bot, nil
+}
+
+// DeleteBot deletes the user's bot and removes it from all chats
+func (s *BotServiceImpl) DeleteBot(ctx context.Context, ownerID, botID int) error {
+	if _, err := s.getOwnedBot(ctx, ownerID, botID); err != nil {
+		return err
+	}
+	return mapRepoError(s.repo.DeleteBot(ctx, botID))
+}
+
+// Authenticate returns the bot owning the API key
+func (s *BotServiceImpl) Authenticate(ctx context.Context, apiKey string) (*Bot, error) {
+	if !strings.HasPrefix(apiKey, APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	model, err := s.repo.GetBotByAPIKeyHash(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		if errors.Is(err, botrepo.ErrBotNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	bot := convertBot(model)
+	return &bot, nil
+}
+
+// GetChats returns the group chats the bot has been added to
+func (s *BotServiceImpl) GetChats(ctx context.Context, botID int) ([]messaging.Chat, error) {
+	chats, err := s.messagingService.GetUserChats(botID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []messaging.Chat{}
+	for _, chat := range chats {
+		if chat.IsGroup {
+			groups = append(groups, chat)
+		}
+	}
+	return groups, nil
+}
+
+// PostMessage sends a message from the bot to a group chat it participates in
+func (s *BotServiceImpl) PostMessage(ctx context.Context, botID int, chatID, messageID, content string) (*messaging.ChatMessage, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	chat, err := s.messagingService.GetChat(chatID, botID)
+	if err != nil {
+		if err.Error() == apierrors.ErrorUserNotInChat {
+			return nil, ErrChatNotFound
+		}
+		return nil, err
+	}
+	if !chat.IsGroup {
+		return nil, ErrNotGroupChat
+	}
+
+	sentAt, err := s.messagingService.AddMessage(messageID, chatID, botID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := messaging.ChatMessage{
+		MessageID: messageID,
+		ChatID:    chatID,
+		SenderID:  botID,
+		Content:   content,
+		SentAt:    sentAt,
+	}
+	if s.messageListener != nil {
+		s.messageListener.MessagePosted(msg)
+	}
+	return &msg, nil
+}
+
+// MessageAdded delivers a new chat message to the webhooks of bots in the chat
+func (s *BotServiceImpl) MessageAdded(msg messaging.ChatMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bots, err := s.repo.GetChatWebhooks(ctx, msg.ChatID)
+	if err != nil {
+		log.Printf("Failed to get webhooks for chat %s: %v", msg.ChatID, err)
+		return
+	}
+
+	for _, bot := range bots {
+		// Bots are not notified about their own messages
+		if bot.ID == msg.SenderID {
+			continue
+		}
+		s.webhooks.Deliver(*bot.WebhookURL, bot.WebhookSecret, WebhookEvent{
+			Type:      WebhookEventMessageCreated,
+			ChatID:    msg.ChatID,
+			MessageID: msg.MessageID,
+			SenderID:  msg.SenderID,
+			Content:   msg.Content,
+			SentAt:    msg.SentAt,
+		})
+	}
+}
+
+func (s *BotServiceImpl) getOwnedBot(ctx context.Context, ownerID, botID int) (*botrepo.BotModel, error) {
+	model, err := s.repo.GetBot(ctx, botID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	// Bots of other users are reported as missing
+	if model.OwnerID != ownerID {
+		return nil, ErrBotNotFound
+	}
+	return model, nil
+}
+
+// normalizeWebhookURL validates the URL; an empty URL means no webhook. Webhooks
+// carry chat messages, so only https URLs of public hosts are accepted.
+func normalizeWebhookURL(ctx context.Context, webhookURL *string) (*string, error) {
+	if webhookURL == nil {
+		return nil, nil
+	}
+	trimmed := strings.TrimSpace(*webhookURL)
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.User != nil {
+		return nil, ErrInvalidWebhookURL
+	}
+	if err := netguard.CheckHost(ctx, parsed.Hostname()); err != nil {
+		return nil, ErrInvalidWebhookURL
+	}
+	return &trimmed, nil
+}
+
+func convertBot(model *botrepo.BotModel) Bot {
+	return Bot{
+		ID:         model.ID,
+		Name:       model.Name,
+		WebhookURL: model.WebhookURL,
+		CreatedAt:  model.CreatedAt,
+	}
+}
+
+// hashAPIKey stores keys as SHA-256: they are random, so no salt is needed
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func mapRepoError(err error) error {
+	if errors.Is(err, botrepo.ErrBotNotFound) {
+		return ErrBotNotFound
+	}
+	return err
+}
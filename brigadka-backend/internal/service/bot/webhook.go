@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/netguard"
+)
+
+// Webhook event types
+const (
+	WebhookEventMessageCreated = "message.created"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body keyed with the webhook secret
+const SignatureHeader = "X-Brigadka-Signature"
+
+// WebhookEvent is the JSON body of a webhook request
+type WebhookEvent struct {
+	Type      string    `json:"type"`
+	ChatID    string    `json:"chat_id"`
+	MessageID string    `json:"message_id"`
+	SenderID  int       `json:"sender_id"`
+	Content   string    `json:"content"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// WebhookDispatcher sends webhook events to bot endpoints
+type WebhookDispatcher struct {
+	client *http.Client
+}
+
+// NewWebhookDispatcher creates a dispatcher with a short timeout so slow bots do not pile up requests.
+// Redirects are not followed and only public addresses are dialed, whatever the URL resolves to now.
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		client: netguard.NewClient(5*time.Second, 0),
+	}
+}
+
+// Deliver sends the event in the background; failures are logged and not retried
+func (d *WebhookDispatcher) Deliver(webhookURL, secret string, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal webhook event: %v", err)
+		return
+	}
+
+	go func() {
+		if err := d.send(context.Background(), webhookURL, secret, body); err != nil {
+			log.Printf("Failed to deliver webhook to %s: %v", webhookURL, err)
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, webhookURL, secret string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of the body; bots compare it with SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
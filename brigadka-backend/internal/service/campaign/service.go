@@ -0,0 +1,257 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+	campaignrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/campaign"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+type (
+	Campaign = campaignrepo.Campaign
+	Segment  = campaignrepo.Segment
+)
+
+const (
+	// MaxTitleLength limits the notification title
+	MaxTitleLength = 100
+	// MaxBodyLength limits the notification body
+	MaxBodyLength = 500
+	// MaxLeadTime is how far ahead a campaign can be scheduled
+	MaxLeadTime = 90 * 24 * time.Hour
+	// MaxInactiveDays limits the inactivity filter of a segment
+	MaxInactiveDays = 365
+
+	// claimBatchSize is the number of due campaigns processed per scheduler tick
+	claimBatchSize = 10
+	// audienceBatchSize is the number of recipients loaded at a time
+	audienceBatchSize = 500
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrCampaignNotFound = errors.New("campaign not found")
+	ErrNotCancellable   = errors.New("only a scheduled campaign can be cancelled")
+	ErrInvalidTitle     = errors.New("campaign title must be 1-100 characters")
+	ErrInvalidBody      = errors.New("campaign body must be 1-500 characters")
+	ErrInvalidTime      = errors.New("campaign time must be in the future and within 90 days")
+	ErrInvalidSegment   = errors.New("inactive_days must be between 1 and 365")
+)
+
+// Preview is the notification a campaign would send and the number of users it would reach now
+type Preview struct {
+	Notification push.NotificationPayload `json:"notification"`
+	Audience     int                      `json:"audience"`
+}
+
+// PushService delivers campaign notifications
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// CampaignServiceImpl schedules admin push campaigns and sends them when they are due
+type CampaignServiceImpl struct {
+	repo        campaignrepo.Repository
+	pushService PushService
+	now         func() time.Time
+	runObserver RunObserver // Optional
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(repo campaignrepo.Repository, pushService PushService) *CampaignServiceImpl {
+	return &CampaignServiceImpl{
+		repo:        repo,
+		pushService: pushService,
+		now:         time.Now,
+	}
+}
+
+// Preview validates a campaign and estimates its audience without scheduling it
+func (s *CampaignServiceImpl) Preview(ctx context.Context, title, body string, segment Segment) (*Preview, error) {
+	title, body, err := validateContent(title, body, segment)
+	if err != nil {
+		return nil, err
+	}
+	audience, err := s.repo.CountAudience(ctx, segment, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Notification: notification("", title, body),
+		Audience:     audience,
+	}, nil
+}
+
+// ScheduleCampaign schedules a campaign to be sent to the segment at scheduledAt
+func (s *CampaignServiceImpl) ScheduleCampaign(ctx context.Context, authorID int, title, body string, segment Segment, scheduledAt time.Time) (*Campaign, error) {
+	title, body, err := validateContent(title, body, segment)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if !scheduledAt.After(now) || scheduledAt.Sub(now) > MaxLeadTime {
+		return nil, ErrInvalidTime
+	}
+
+	campaign := &Campaign{
+		ID:          idgen.New(),
+		AuthorID:    &authorID,
+		Title:       title,
+		Body:        body,
+		Segment:     segment,
+		ScheduledAt: scheduledAt.UTC(),
+	}
+	if err := s.repo.CreateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// GetCampaigns returns up to limit campaigns, the latest scheduled first
+func (s *CampaignServiceImpl) GetCampaigns(ctx context.Context, limit int) ([]Campaign, error) {
+	return s.repo.GetCampaigns(ctx, limit)
+}
+
+// GetCampaign returns a campaign with its delivery stats
+func (s *CampaignServiceImpl) GetCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	campaign, err := s.repo.GetCampaign(ctx, campaignID)
+	if errors.Is(err, campaignrepo.ErrCampaignNotFound) {
+		return nil, ErrCampaignNotFound
+	}
+	return campaign, err
+}
+
+// CancelCampaign cancels a campaign that has not started sending
+func (s *CampaignServiceImpl) CancelCampaign(ctx context.Context, campaignID string) error {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if campaign.Status != campaignrepo.StatusScheduled {
+		return ErrNotCancellable
+	}
+
+	if err := s.repo.CancelCampaign(ctx, campaignID); err != nil {
+		if errors.Is(err, campaignrepo.ErrCampaignNotFound) {
+			// The worker claimed it in the meantime
+			return ErrNotCancellable
+		}
+		return err
+	}
+	return nil
+}
+
+// SetRunObserver reports the outcome of every run of the scheduler
+func (s *CampaignServiceImpl) SetRunObserver(observer RunObserver) {
+	s.runObserver = observer
+}
+
+// Run sends due campaigns every interval until the context is cancelled
+func (s *CampaignServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.ProcessDue(ctx)
+		if s.runObserver != nil {
+			s.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to process due push campaigns: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue sends all campaigns that are due. Campaigns are claimed before
+// they are sent, so a campaign interrupted midway is not sent twice.
+func (s *CampaignServiceImpl) ProcessDue(ctx context.Context) error {
+	for {
+		campaigns, err := s.repo.ClaimDueCampaigns(ctx, s.now(), claimBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, campaign := range campaigns {
+			if err := s.send(ctx, campaign); err != nil {
+				log.Printf("Failed to send push campaign %s: %v", campaign.ID, err)
+			}
+		}
+		if len(campaigns) < claimBatchSize {
+			return nil
+		}
+	}
+}
+
+// send queues the campaign notification for every user in the segment and records the counters
+func (s *CampaignServiceImpl) send(ctx context.Context, campaign Campaign) error {
+	payload := notification(campaign.ID, campaign.Title, campaign.Body)
+	// The segment is evaluated at the scheduled time, so a late run reaches the same users
+	at := campaign.ScheduledAt
+
+	recipients, queued, failed := 0, 0, 0
+	afterUserID := 0
+	for {
+		userIDs, err := s.repo.GetAudience(ctx, campaign.Segment, at, afterUserID, audienceBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			recipients++
+			if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+				log.Printf("Failed to queue push campaign %s for user %d: %v", campaign.ID, userID, err)
+				failed++
+				continue
+			}
+			queued++
+		}
+		if len(userIDs) < audienceBatchSize {
+			break
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+
+	return s.repo.FinishCampaign(ctx, campaign.ID, recipients, queued, failed, s.now())
+}
+
+func notification(campaignID, title, body string) push.NotificationPayload {
+	payload := push.NotificationPayload{
+		Title:    title,
+		Body:     body,
+		Sound:    "default",
+		Category: push.CategoryAnnouncement,
+	}
+	if campaignID != "" {
+		payload.CollapseID = "campaign:" + campaignID
+	}
+	return payload
+}
+
+func validateContent(title, body string, segment Segment) (string, string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len([]rune(title)) > MaxTitleLength {
+		return "", "", ErrInvalidTitle
+	}
+	body = strings.TrimSpace(body)
+	if body == "" || len([]rune(body)) > MaxBodyLength {
+		return "", "", ErrInvalidBody
+	}
+	if segment.InactiveDays != nil && (*segment.InactiveDays < 1 || *segment.InactiveDays > MaxInactiveDays) {
+		return "", "", ErrInvalidSegment
+	}
+	return title, body, nil
+}
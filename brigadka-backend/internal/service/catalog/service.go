@@ -0,0 +1,136 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	catalogrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/catalog"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/media"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/reminder"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/report"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/suspension"
+)
+
+type Reaction = catalogrepo.Reaction
+
+// ProfileCatalogs provides the catalogs used by profiles
+type ProfileCatalogs interface {
+	GetImprovStyles(lang string) ([]profile.TranslatedItem, error)
+	GetImprovGoals(lang string) ([]profile.TranslatedItem, error)
+	GetGenders(lang string) ([]profile.TranslatedItem, error)
+	GetCities(lang, country, query string) ([]profile.City, error)
+}
+
+// Limits are the input limits the app checks before sending a request
+type Limits struct {
+	MaxUploadBytes         int `json:"max_upload_bytes"`
+	MaxImageDimension      int `json:"max_image_dimension"`
+	MaxVideoSeconds        int `json:"max_video_seconds"`
+	MaxAudioIntroSeconds   int `json:"max_audio_intro_seconds"`
+	MaxReportCommentLength int `json:"max_report_comment_length"`
+	MaxReminderTextLength  int `json:"max_reminder_text_length"`
+	MaxAppealLength        int `json:"max_suspension_appeal_length"`
+	// Group chat size limits; 0 means unlimited
+	MaxGroupParticipants         int `json:"max_group_participants"`
+	MaxGroupParticipantsVerified int `json:"max_group_participants_verified"`
+}
+
+// Bundle holds every catalog the app needs to work offline
+type Bundle struct {
+	// Version is a hash of the content. The app refetches the bundle only when it changes.
+	Version      string                   `json:"version"`
+	ImprovStyles []profile.TranslatedItem `json:"improv_styles"`
+	ImprovGoals  []profile.TranslatedItem `json:"improv_goals"`
+	Genders      []profile.TranslatedItem `json:"genders"`
+	Cities       []profile.City           `json:"cities"`
+	Reactions    []Reaction               `json:"reactions"`
+	Limits       Limits                   `json:"limits"`
+}
+
+// CatalogServiceImpl assembles the catalog bundle
+type CatalogServiceImpl struct {
+	profiles    ProfileCatalogs
+	repo        catalogrepo.Repository
+	groupLimits messaging.GroupLimits
+	mediaLimits media.Limits
+}
+
+// NewCatalogService creates a new catalog service
+func NewCatalogService(profiles ProfileCatalogs, repo catalogrepo.Repository) *CatalogServiceImpl {
+	return &CatalogServiceImpl{
+		profiles: profiles,
+		repo:     repo,
+		groupLimits: messaging.GroupLimits{
+			MaxParticipants:         messaging.DefaultMaxGroupParticipants,
+			MaxParticipantsVerified: messaging.DefaultMaxGroupParticipantsVerified,
+		},
+		mediaLimits: media.DefaultLimits,
+	}
+}
+
+// SetGroupLimits reports configured group chat limits instead of the defaults
+func (s *CatalogServiceImpl) SetGroupLimits(limits messaging.GroupLimits) {
+	s.groupLimits = limits
+}
+
+// SetMediaLimits reports configured upload limits instead of the defaults
+func (s *CatalogServiceImpl) SetMediaLimits(limits media.Limits) {
+	s.mediaLimits = limits
+}
+
+// GetBundle returns all catalogs in the given language with their version
+func (s *CatalogServiceImpl) GetBundle(ctx context.Context, lang string) (*Bundle, error) {
+	var err error
+	bundle := &Bundle{Limits: s.currentLimits()}
+
+	if bundle.ImprovStyles, err = s.profiles.GetImprovStyles(lang); err != nil {
+		return nil, err
+	}
+	if bundle.ImprovGoals, err = s.profiles.GetImprovGoals(lang); err != nil {
+		return nil, err
+	}
+	if bundle.Genders, err = s.profiles.GetGenders(lang); err != nil {
+		return nil, err
+	}
+	if bundle.Cities, err = s.profiles.GetCities(lang, "", ""); err != nil {
+		return nil, err
+	}
+	if bundle.Reactions, err = s.repo.GetReactions(ctx); err != nil {
+		return nil, err
+	}
+
+	if bundle.Version, err = bundleVersion(bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func (s *CatalogServiceImpl) currentLimits() Limits {
+	return Limits{
+		MaxUploadBytes:               int(s.mediaLimits.MaxFileSize),
+		MaxImageDimension:            s.mediaLimits.MaxImageDimension,
+		MaxVideoSeconds:              int(s.mediaLimits.MaxVideoDuration.Seconds()),
+		MaxAudioIntroSeconds:         int(s.mediaLimits.MaxAudioDuration.Seconds()),
+		MaxReportCommentLength:       report.MaxCommentLength,
+		MaxReminderTextLength:        reminder.MaxTextLength,
+		MaxAppealLength:              suspension.MaxAppealLength,
+		MaxGroupParticipants:         s.groupLimits.MaxParticipants,
+		MaxGroupParticipantsVerified: s.groupLimits.MaxParticipantsVerified,
+	}
+}
+
+// bundleVersion hashes the bundle content, so any catalog change yields a new version
+func bundleVersion(bundle *Bundle) (string, error) {
+	content := *bundle
+	content.Version = ""
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,378 @@
+package class
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	classrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/class"
+)
+
+// Class levels
+const (
+	LevelBeginner     = classrepo.LevelBeginner
+	LevelIntermediate = classrepo.LevelIntermediate
+	LevelAdvanced     = classrepo.LevelAdvanced
+	LevelAll          = classrepo.LevelAll
+)
+
+// Listing limits
+const (
+	maxTitleLength = 100
+	maxCapacity    = 500
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrClassNotFound    = errors.New("class not found")
+	ErrNotTeacher       = errors.New("only the teacher of the class can do this")
+	ErrInvalidTitle     = errors.New("invalid class title")
+	ErrInvalidLevel     = errors.New("invalid class level")
+	ErrInvalidSchedule  = errors.New("class must end after it starts and not be over")
+	ErrInvalidCapacity  = errors.New("invalid class capacity")
+	ErrInvalidPrice     = errors.New("paid classes need a non-negative price and a currency code")
+	ErrInvalidCity      = errors.New("invalid city")
+	ErrAlreadyEnrolled  = errors.New("user is already enrolled")
+	ErrNotEnrolled      = errors.New("user is not enrolled")
+	ErrClassFull        = errors.New("class is full")
+	ErrClassOver        = errors.New("class is over")
+	ErrTeacherEnrolling = errors.New("teachers cannot enroll in their own class")
+)
+
+// Class represents a workshop or course listing
+type Class struct {
+	ID            int       `json:"id"`
+	TeacherID     int       `json:"teacher_id"`
+	TeacherName   string    `json:"teacher_name"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description,omitempty"`
+	CityID        *int      `json:"city_id,omitempty"` // Empty for online classes
+	Level         string    `json:"level"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	Schedule      string    `json:"schedule,omitempty"`
+	Capacity      int       `json:"capacity"`
+	EnrolledCount int       `json:"enrolled_count"`
+	SpotsLeft     int       `json:"spots_left"`
+	PriceCents    int       `json:"price_cents"`        // 0 for free classes
+	Currency      string    `json:"currency,omitempty"` // ISO 4217 code of a paid class
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Enrollment represents a student enrolled in a class
+type Enrollment struct {
+	UserID     int       `json:"user_id"`
+	FullName   string    `json:"full_name"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+// CreateRequest represents data needed to create a class
+type CreateRequest struct {
+	Title       string
+	Description string
+	CityID      *int
+	Level       string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Schedule    string
+	Capacity    int
+	PriceCents  int
+	Currency    string
+}
+
+// SearchFilter defines the filters for class searches
+type SearchFilter struct {
+	CityID    *int
+	Level     *string
+	TeacherID *int
+	Page      int
+	PageSize  int
+}
+
+// SearchResult represents the search results including pagination details
+type SearchResult struct {
+	Classes    []Class `json:"classes"`
+	TotalCount int     `json:"total_count"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"page_size"`
+}
+
+// ChatService manages the class group chat
+type ChatService interface {
+	CreateMembersChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error
+	AddParticipant(chatID string, userID int) error
+	RemoveParticipant(chatID string, userID int) error
+}
+
+// ClassServiceImpl implements class listings and enrollment
+type ClassServiceImpl struct {
+	classRepo   classrepo.Repository
+	chatService ChatService
+}
+
+// NewClassService creates a new class service
+func NewClassService(classRepo classrepo.Repository, chatService ChatService) *ClassServiceImpl {
+	return &ClassServiceImpl{
+		classRepo:   classRepo,
+		chatService: chatService,
+	}
+}
+
+// CreateClass lists a new class taught by teacherID
+func (s *ClassServiceImpl) CreateClass(ctx context.Context, teacherID int, req CreateRequest) (*Class, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" || len([]rune(req.Title)) > maxTitleLength {
+		return nil, ErrInvalidTitle
+	}
+	if !isValidLevel(req.Level) {
+		return nil, ErrInvalidLevel
+	}
+	if !req.EndsAt.After(req.StartsAt) || !req.EndsAt.After(time.Now()) {
+		return nil, ErrInvalidSchedule
+	}
+	if req.Capacity <= 0 || req.Capacity > maxCapacity {
+		return nil, ErrInvalidCapacity
+	}
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	if req.PriceCents < 0 || (req.PriceCents > 0 && len(req.Currency) != 3) {
+		return nil, ErrInvalidPrice
+	}
+	if req.PriceCents == 0 {
+		req.Currency = ""
+	}
+	if req.CityID != nil {
+		valid, err := s.classRepo.ValidateCity(ctx, *req.CityID)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, ErrInvalidCity
+		}
+	}
+
+	model := &classrepo.ClassModel{
+		TeacherID:   teacherID,
+		Title:       req.Title,
+		Description: strings.TrimSpace(req.Description),
+		CityID:      req.CityID,
+		Level:       req.Level,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Schedule:    strings.TrimSpace(req.Schedule),
+		Capacity:    req.Capacity,
+		PriceCents:  req.PriceCents,
+		Currency:    req.Currency,
+	}
+	if err := s.classRepo.CreateClass(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return s.GetClass(ctx, model.ID)
+}
+
+// GetClass returns a class by ID
+func (s *ClassServiceImpl) GetClass(ctx context.Context, classID int) (*Class, error) {
+	model, err := s.classRepo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	class := convertToClass(model)
+	return &class, nil
+}
+
+// DeleteClass cancels a class; only its teacher may do this. The group chat is kept.
+func (s *ClassServiceImpl) DeleteClass(ctx context.Context, userID, classID int) error {
+	if _, err := s.getTaughtClass(ctx, userID, classID); err != nil {
+		return err
+	}
+	return mapRepoError(s.classRepo.DeleteClass(ctx, classID))
+}
+
+// Search returns classes that are not over yet, soonest first
+func (s *ClassServiceImpl) Search(ctx context.Context, filter SearchFilter) (*SearchResult, error) {
+	if filter.Level != nil && !isValidLevel(*filter.Level) {
+		return nil, ErrInvalidLevel
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	models, total, err := s.classRepo.SearchClasses(ctx, classrepo.SearchParams{
+		EndsAfter: time.Now(),
+		CityID:    filter.CityID,
+		Level:     filter.Level,
+		TeacherID: filter.TeacherID,
+		Page:      filter.Page,
+		PageSize:  filter.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]Class, 0, len(models))
+	for _, m := range models {
+		classes = append(classes, convertToClass(m))
+	}
+	return &SearchResult{Classes: classes, TotalCount: total, Page: filter.Page, PageSize: filter.PageSize}, nil
+}
+
+// Enroll takes a seat in a class and adds the student to the class group chat.
+// Payment for paid classes is arranged with the teacher.
+func (s *ClassServiceImpl) Enroll(ctx context.Context, userID, classID int) (*Class, error) {
+	class, err := s.classRepo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	if class.TeacherID == userID {
+		return nil, ErrTeacherEnrolling
+	}
+	if !class.EndsAt.After(time.Now()) {
+		return nil, ErrClassOver
+	}
+
+	if err := s.classRepo.Enroll(ctx, classID, userID); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	s.joinClassChat(ctx, class, userID)
+
+	return s.GetClass(ctx, classID)
+}
+
+// Unenroll frees the student's seat and removes them from the class group chat
+func (s *ClassServiceImpl) Unenroll(ctx context.Context, userID, classID int) error {
+	if err := s.classRepo.Unenroll(ctx, classID, userID); err != nil {
+		return mapRepoError(err)
+	}
+
+	chatID, err := s.classRepo.GetClassChat(ctx, classID)
+	if err != nil {
+		log.Printf("Failed to get chat for class %d: %v", classID, err)
+		return nil
+	}
+	if chatID != nil {
+		if err := s.chatService.RemoveParticipant(*chatID, userID); err != nil {
+			log.Printf("Failed to remove user %d from chat of class %d: %v", userID, classID, err)
+		}
+	}
+	return nil
+}
+
+// GetEnrollments lists the students of a class; only its teacher may do this
+func (s *ClassServiceImpl) GetEnrollments(ctx context.Context, userID, classID int) ([]Enrollment, error) {
+	if _, err := s.getTaughtClass(ctx, userID, classID); err != nil {
+		return nil, err
+	}
+
+	models, err := s.classRepo.GetEnrollments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments := make([]Enrollment, 0, len(models))
+	for _, m := range models {
+		enrollments = append(enrollments, Enrollment{UserID: m.UserID, FullName: m.FullName, EnrolledAt: m.EnrolledAt})
+	}
+	return enrollments, nil
+}
+
+// getTaughtClass loads a class after checking that userID teaches it
+func (s *ClassServiceImpl) getTaughtClass(ctx context.Context, userID, classID int) (*classrepo.ClassModel, error) {
+	class, err := s.classRepo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	if class.TeacherID != userID {
+		return nil, ErrNotTeacher
+	}
+	return class, nil
+}
+
+// joinClassChat adds a new student to the class group chat, creating the chat
+// with the teacher and all students on the first enrollment
+func (s *ClassServiceImpl) joinClassChat(ctx context.Context, class *classrepo.ClassModel, userID int) {
+	chatID, err := s.classRepo.GetClassChat(ctx, class.ID)
+	if err != nil {
+		log.Printf("Failed to get chat for class %d: %v", class.ID, err)
+		return
+	}
+
+	if chatID != nil {
+		err := s.chatService.AddParticipant(*chatID, userID)
+		if err != nil && !database.IsUniqueViolation(err) {
+			log.Printf("Failed to add user %d to chat of class %d: %v", userID, class.ID, err)
+		}
+		return
+	}
+
+	enrollments, err := s.classRepo.GetEnrollments(ctx, class.ID)
+	if err != nil {
+		log.Printf("Failed to get enrollments of class %d: %v", class.ID, err)
+		return
+	}
+	participants := []int{class.TeacherID}
+	for _, e := range enrollments {
+		participants = append(participants, e.UserID)
+	}
+
+	newChatID := uuid.New().String()
+	if err := s.chatService.CreateMembersChat(ctx, newChatID, class.TeacherID, class.Title, participants); err != nil {
+		log.Printf("Failed to create chat for class %d: %v", class.ID, err)
+		return
+	}
+	if err := s.classRepo.SetClassChat(ctx, class.ID, newChatID); err != nil {
+		log.Printf("Failed to link chat %s to class %d: %v", newChatID, class.ID, err)
+	}
+}
+
+func isValidLevel(level string) bool {
+	switch level {
+	case LevelBeginner, LevelIntermediate, LevelAdvanced, LevelAll:
+		return true
+	}
+	return false
+}
+
+func convertToClass(m *classrepo.ClassModel) Class {
+	return Class{
+		ID:            m.ID,
+		TeacherID:     m.TeacherID,
+		TeacherName:   m.TeacherName,
+		Title:         m.Title,
+		Description:   m.Description,
+		CityID:        m.CityID,
+		Level:         m.Level,
+		StartsAt:      m.StartsAt,
+		EndsAt:        m.EndsAt,
+		Schedule:      m.Schedule,
+		Capacity:      m.Capacity,
+		EnrolledCount: m.EnrolledCount,
+		SpotsLeft:     max(m.Capacity-m.EnrolledCount, 0),
+		PriceCents:    m.PriceCents,
+		Currency:      m.Currency,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, classrepo.ErrClassNotFound):
+		return ErrClassNotFound
+	case errors.Is(err, classrepo.ErrAlreadyEnrolled):
+		return ErrAlreadyEnrolled
+	case errors.Is(err, classrepo.ErrNotEnrolled):
+		return ErrNotEnrolled
+	case errors.Is(err, classrepo.ErrClassFull):
+		return ErrClassFull
+	default:
+		return err
+	}
+}
@@ -0,0 +1,154 @@
+package consent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	consentrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/consent"
+)
+
+// Legal documents that require user consent
+const (
+	DocumentTermsOfService = "tos"
+	DocumentPrivacyPolicy  = "privacy"
+)
+
+var (
+	ErrOutdatedVersion = errors.New("accepted version does not match the current version")
+)
+
+// Versions holds the current versions of legal documents. Empty version means the document is not enforced.
+type Versions struct {
+	TermsOfService string `json:"tos_version"`
+	PrivacyPolicy  string `json:"privacy_version"`
+}
+
+func (v Versions) byDocument() map[string]string {
+	return map[string]string{
+		DocumentTermsOfService: v.TermsOfService,
+		DocumentPrivacyPolicy:  v.PrivacyPolicy,
+	}
+}
+
+// AcceptedDocument describes the latest accepted version of a document
+type AcceptedDocument struct {
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+// Status describes what the user accepted and what is still required
+type Status struct {
+	Current  Versions                    `json:"current"`
+	Accepted map[string]AcceptedDocument `json:"accepted"`
+	Missing  []string                    `json:"missing"`
+}
+
+// ConsentService defines consent tracking operations
+type ConsentService interface {
+	CurrentVersions() Versions
+	Accept(ctx context.Context, userID int, accepted Versions) error
+	MissingConsents(ctx context.Context, userID int) ([]string, error)
+	GetStatus(ctx context.Context, userID int) (*Status, error)
+}
+
+// ConsentServiceImpl implements ConsentService
+type ConsentServiceImpl struct {
+	repo    consentrepo.Repository
+	current Versions
+}
+
+// NewConsentService creates a consent service enforcing the given document versions
+func NewConsentService(repo consentrepo.Repository, current Versions) *ConsentServiceImpl {
+	return &ConsentServiceImpl{
+		repo:    repo,
+		current: current,
+	}
+}
+
+// CurrentVersions returns the versions users must accept
+func (s *ConsentServiceImpl) CurrentVersions() Versions {
+	return s.current
+}
+
+// Accept records acceptance of the current document versions.
+// Only current versions can be accepted so clients cannot consent to stale texts.
+func (s *ConsentServiceImpl) Accept(ctx context.Context, userID int, accepted Versions) error {
+	acceptedByDocument := accepted.byDocument()
+	for document, current := range s.current.byDocument() {
+		version := acceptedByDocument[document]
+		if version == "" {
+			continue
+		}
+		if version != current {
+			return ErrOutdatedVersion
+		}
+	}
+
+	for document, version := range acceptedByDocument {
+		if version == "" {
+			continue
+		}
+		if err := s.repo.SaveConsent(ctx, userID, document, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MissingConsents returns documents whose current version the user has not accepted.
+// All enforced documents are checked with a single query.
+func (s *ConsentServiceImpl) MissingConsents(ctx context.Context, userID int) ([]string, error) {
+	required := make(map[string]string)
+	for document, version := range s.current.byDocument() {
+		if version != "" {
+			required[document] = version
+		}
+	}
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	accepted, err := s.repo.GetAcceptedDocuments(ctx, userID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, document := range []string{DocumentTermsOfService, DocumentPrivacyPolicy} {
+		if _, ok := required[document]; ok && !accepted[document] {
+			missing = append(missing, document)
+		}
+	}
+	return missing, nil
+}
+
+// GetStatus returns accepted and missing consents for the user
+func (s *ConsentServiceImpl) GetStatus(ctx context.Context, userID int) (*Status, error) {
+	consents, err := s.repo.GetUserConsents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consents are ordered newest first, keep the first one per document
+	accepted := make(map[string]AcceptedDocument)
+	for _, c := range consents {
+		if _, ok := accepted[c.Document]; !ok {
+			accepted[c.Document] = AcceptedDocument{Version: c.Version, AcceptedAt: c.AcceptedAt}
+		}
+	}
+
+	missing := []string{}
+	for _, document := range []string{DocumentTermsOfService, DocumentPrivacyPolicy} {
+		version := s.current.byDocument()[document]
+		if version != "" && accepted[document].Version != version {
+			missing = append(missing, document)
+		}
+	}
+
+	return &Status{
+		Current:  s.current,
+		Accepted: accepted,
+		Missing:  missing,
+	}, nil
+}
@@ -0,0 +1,243 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+	exportrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/export"
+)
+
+type Export = exportrepo.Export
+
+var (
+	ErrExportNotFound = errors.New("export not found")
+	ErrExportNotReady = errors.New("export is not ready")
+	ErrUnknownKind    = errors.New("unknown export kind")
+)
+
+// Export worker defaults
+const (
+	DefaultWorkers     = 2
+	DefaultMaxAttempts = 3
+)
+
+// Generator produces the export file content for the user from the params the export
+// was submitted with, as JSON
+type Generator func(ctx context.Context, userID int, params json.RawMessage) ([]byte, error)
+
+// RunObserver is notified about the outcome of every run of the worker
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// ExportService runs exports in the background and serves their results
+type ExportService interface {
+	Submit(ctx context.Context, userID int, kind, fileName string, params interface{}) (*Export, error)
+	GetExport(ctx context.Context, userID int, exportID string) (*Export, error)
+	Download(ctx context.Context, userID int, exportID string) (*Export, error)
+}
+
+// ExportServiceImpl implements ExportService. Exports are stored in the database with
+// their params and generated by Run, at most a few at a time, so they survive restarts
+// and an export submitted on one replica may be generated on another.
+type ExportServiceImpl struct {
+	repo        exportrepo.Repository
+	generators  map[string]Generator
+	timeout     time.Duration
+	workers     int
+	maxAttempts int
+	now         func() time.Time
+	wake        chan struct{}
+	runObserver RunObserver // Optional
+}
+
+// NewExportService creates a new export service
+func NewExportService(repo exportrepo.Repository) *ExportServiceImpl {
+	return &ExportServiceImpl{
+		repo:        repo,
+		generators:  make(map[string]Generator),
+		timeout:     10 * time.Minute,
+		workers:     DefaultWorkers,
+		maxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// RegisterGenerator sets the generator of the exports of the kind
+func (s *ExportServiceImpl) RegisterGenerator(kind string, generate Generator) {
+	s.generators[kind] = generate
+}
+
+// SetRunObserver reports the outcome of every run of the worker
+func (s *ExportServiceImpl) SetRunObserver(observer RunObserver) {
+	s.runObserver = observer
+}
+
+// Submit stores a pending export; Run generates it with the generator of the kind
+// from params, which must marshal to JSON
+func (s *ExportServiceImpl) Submit(ctx context.Context, userID int, kind, fileName string, params interface{}) (*Export, error) {
+	if _, ok := s.generators[kind]; !ok {
+		return nil, ErrUnknownKind
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(fileName))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	export := &Export{
+		ID:          idgen.New(),
+		UserID:      userID,
+		Kind:        kind,
+		Status:      exportrepo.StatusPending,
+		FileName:    fileName,
+		ContentType: contentType,
+		Params:      data,
+	}
+	if err := s.repo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	// Wake the worker instead of waiting for the next poll
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return export, nil
+}
+
+// Run generates pending exports every interval, and as soon as one is submitted,
+// until the context is cancelled. Exports submitted on other replicas, and those left
+// pending by a worker that stopped, are picked up by the poll.
+func (s *ExportServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Process(ctx)
+		if s.runObserver != nil {
+			s.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to process exports: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// Process generates the pending exports, as many at a time as there are workers
+func (s *ExportServiceImpl) Process(ctx context.Context) error {
+	for {
+		now := s.now()
+		// The lease outlasts the generation timeout, so a running export is not claimed twice
+		exports, err := s.repo.ClaimExports(ctx, now, now.Add(s.timeout+time.Minute), s.workers)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, export := range exports {
+			wg.Add(1)
+			go func(export Export) {
+				defer wg.Done()
+				s.run(export)
+			}(export)
+		}
+		wg.Wait()
+
+		if len(exports) < s.workers {
+			return nil
+		}
+	}
+}
+
+// run generates a claimed export and stores the result. An export claimed more than
+// maxAttempts times keeps stopping its worker, e.g. by running out of memory, and fails.
+func (s *ExportServiceImpl) run(export Export) {
+	var data []byte
+	err := fmt.Errorf("export was interrupted %d times", export.Attempts-1)
+	if export.Attempts <= s.maxAttempts {
+		data, err = s.generate(export)
+	}
+	if err != nil {
+		log.Printf("Export %s failed: %v", export.ID, err)
+		if err := s.repo.FailExport(context.Background(), export.ID, err.Error()); err != nil {
+			log.Printf("Error marking export %s as failed: %v", export.ID, err)
+		}
+		return
+	}
+
+	if err := s.repo.CompleteExport(context.Background(), export.ID, data); err != nil {
+		log.Printf("Error saving export %s: %v", export.ID, err)
+	}
+}
+
+// generate runs the generator of the export; a panic fails the export instead of the server
+func (s *ExportServiceImpl) generate(export Export) (data []byte, err error) {
+	generate, ok := s.generators[export.Kind]
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Export %s panicked: %v\n%s", export.ID, r, debug.Stack())
+			data, err = nil, errors.New("export failed unexpectedly")
+		}
+	}()
+	return generate(ctx, export.UserID, export.Params)
+}
+
+// GetExport returns export status; exports of other users are reported as not found
+func (s *ExportServiceImpl) GetExport(ctx context.Context, userID int, exportID string) (*Export, error) {
+	export, err := s.repo.GetExport(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, exportrepo.ErrExportNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	if export.UserID != userID {
+		return nil, ErrExportNotFound
+	}
+	return export, nil
+}
+
+// Download returns a finished export together with its content
+func (s *ExportServiceImpl) Download(ctx context.Context, userID int, exportID string) (*Export, error) {
+	export, err := s.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != exportrepo.StatusDone {
+		return nil, ErrExportNotReady
+	}
+
+	export.Data, err = s.repo.GetExportData(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
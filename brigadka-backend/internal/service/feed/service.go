@@ -0,0 +1,147 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	feedrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/feed"
+)
+
+// Activity types
+const (
+	ActivityProfileUpdated = "profile_updated"
+	ActivityVideoAdded     = "video_added"
+	ActivityTeamUpdated    = "team_updated"
+	ActivityMemberJoined   = "member_joined"
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrTargetNotFound   = errors.New("follow target not found")
+	ErrNotFollowing     = errors.New("not following")
+	ErrCannotFollowSelf = errors.New("users cannot follow themselves")
+)
+
+// Activity represents an event shown in the feed of followers.
+// UserID and TeamID tell whose activity it is; at least one is set.
+type Activity struct {
+	ID        int64                  `json:"id"`
+	Type      string                 `json:"type"`
+	UserID    *int                   `json:"user_id,omitempty"`
+	UserName  *string                `json:"user_name,omitempty"`
+	TeamID    *int                   `json:"team_id,omitempty"`
+	TeamName  *string                `json:"team_name,omitempty"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// Feed represents a page of the activity feed
+type Feed struct {
+	Activities []Activity `json:"activities"`
+	// NextBeforeID is passed as before_id to get the next page; empty on the last page
+	NextBeforeID *int64 `json:"next_before_id,omitempty"`
+}
+
+// FeedServiceImpl implements follows and the activity feed
+type FeedServiceImpl struct {
+	repo feedrepo.Repository
+}
+
+// NewFeedService creates a new feed service
+func NewFeedService(repo feedrepo.Repository) *FeedServiceImpl {
+	return &FeedServiceImpl{
+		repo: repo,
+	}
+}
+
+// FollowUser subscribes followerID to the activity of userID
+func (s *FeedServiceImpl) FollowUser(ctx context.Context, followerID, userID int) error {
+	if followerID == userID {
+		return ErrCannotFollowSelf
+	}
+	return mapRepoError(s.repo.FollowUser(ctx, followerID, userID))
+}
+
+// UnfollowUser removes a subscription to a user
+func (s *FeedServiceImpl) UnfollowUser(ctx context.Context, followerID, userID int) error {
+	return mapRepoError(s.repo.UnfollowUser(ctx, followerID, userID))
+}
+
+// FollowTeam subscribes followerID to the activity of a team
+func (s *FeedServiceImpl) FollowTeam(ctx context.Context, followerID, teamID int) error {
+	return mapRepoError(s.repo.FollowTeam(ctx, followerID, teamID))
+}
+
+// UnfollowTeam removes a subscription to a team
+func (s *FeedServiceImpl) UnfollowTeam(ctx context.Context, followerID, teamID int) error {
+	return mapRepoError(s.repo.UnfollowTeam(ctx, followerID, teamID))
+}
+
+// Record appends an activity to the log; it shows up in the feeds of followers
+func (s *FeedServiceImpl) Record(ctx context.Context, activity Activity) error {
+	var payload []byte
+	if len(activity.Payload) > 0 {
+		var err error
+		if payload, err = json.Marshal(activity.Payload); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.AddActivity(ctx, &feedrepo.ActivityModel{
+		Type:    activity.Type,
+		UserID:  activity.UserID,
+		TeamID:  activity.TeamID,
+		Payload: payload,
+	})
+}
+
+// GetFeed returns recent activity of the users and teams followed by userID
+func (s *FeedServiceImpl) GetFeed(ctx context.Context, userID int, beforeID *int64, limit int) (*Feed, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	models, err := s.repo.GetFeed(ctx, userID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{Activities: make([]Activity, 0, len(models))}
+	for _, m := range models {
+		activity := Activity{
+			ID:        m.ID,
+			Type:      m.Type,
+			UserID:    m.UserID,
+			UserName:  m.UserName,
+			TeamID:    m.TeamID,
+			TeamName:  m.TeamName,
+			CreatedAt: m.CreatedAt,
+		}
+		if len(m.Payload) > 0 {
+			if err := json.Unmarshal(m.Payload, &activity.Payload); err != nil {
+				return nil, err
+			}
+		}
+		feed.Activities = append(feed.Activities, activity)
+	}
+
+	if len(models) == limit {
+		next := models[len(models)-1].ID
+		feed.NextBeforeID = &next
+	}
+	return feed, nil
+}
+
+// mapRepoError translates repository errors into service errors
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, feedrepo.ErrTargetNotFound):
+		return ErrTargetNotFound
+	case errors.Is(err, feedrepo.ErrNotFollowing):
+		return ErrNotFollowing
+	default:
+		return err
+	}
+}
@@ -0,0 +1,112 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	keysrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/keys"
+)
+
+type DeviceKeys = keysrepo.DeviceKeys
+
+type Prekey = keysrepo.Prekey
+
+type KeyBundle = keysrepo.KeyBundle
+
+const (
+	// MaxDeviceIDLength matches the device_id column
+	MaxDeviceIDLength = 64
+	// MaxKeyLength bounds every key and signature; real ones are well below it
+	MaxKeyLength = 1024
+	// MaxPrekeysPerUpload limits the one-time prekeys sent at once
+	MaxPrekeysPerUpload = 100
+)
+
+var (
+	ErrDeviceNotFound  = keysrepo.ErrDeviceNotFound
+	ErrInvalidDeviceID = errors.New("device ID must be 1 to 64 characters")
+	ErrInvalidKeys     = errors.New("keys must be non-empty and up to 1024 characters")
+	ErrTooManyPrekeys  = errors.New("up to 100 one-time prekeys can be uploaded at once")
+)
+
+// KeyService distributes the public keys of devices for end-to-end encrypted chats.
+// The server never sees private keys or message content.
+type KeyService interface {
+	// RegisterDevice saves the keys of one of the user's devices with an optional first batch of
+	// one-time prekeys, and returns how many one-time prekeys the device has
+	RegisterDevice(ctx context.Context, userID int, deviceKeys DeviceKeys, prekeys []Prekey) (int, error)
+	// UploadPrekeys adds one-time prekeys to a registered device and returns how many it has
+	UploadPrekeys(ctx context.Context, userID int, deviceID string, prekeys []Prekey) (int, error)
+	RemoveDevice(ctx context.Context, userID int, deviceID string) error
+	// GetKeyBundles returns the bundles of all devices of a user, each with a one-time prekey
+	// used up by the call
+	GetKeyBundles(ctx context.Context, userID int) ([]KeyBundle, error)
+}
+
+type KeyServiceImpl struct {
+	repo keysrepo.Repository
+}
+
+// NewKeyService creates a new key distribution service
+func NewKeyService(repo keysrepo.Repository) *KeyServiceImpl {
+	return &KeyServiceImpl{repo: repo}
+}
+
+func (s *KeyServiceImpl) RegisterDevice(ctx context.Context, userID int, deviceKeys DeviceKeys, prekeys []Prekey) (int, error) {
+	if err := validateDeviceID(deviceKeys.DeviceID); err != nil {
+		return 0, err
+	}
+	if !validKey(deviceKeys.IdentityKey) || !validKey(deviceKeys.SignedPrekey) || !validKey(deviceKeys.SignedPrekeySignature) {
+		return 0, ErrInvalidKeys
+	}
+	if err := validatePrekeys(prekeys); err != nil {
+		return 0, err
+	}
+
+	if err := s.repo.SaveDeviceKeys(ctx, userID, deviceKeys); err != nil {
+		return 0, err
+	}
+	return s.repo.AddPrekeys(ctx, userID, deviceKeys.DeviceID, prekeys)
+}
+
+func (s *KeyServiceImpl) UploadPrekeys(ctx context.Context, userID int, deviceID string, prekeys []Prekey) (int, error) {
+	if err := validateDeviceID(deviceID); err != nil {
+		return 0, err
+	}
+	if err := validatePrekeys(prekeys); err != nil {
+		return 0, err
+	}
+	return s.repo.AddPrekeys(ctx, userID, deviceID, prekeys)
+}
+
+func (s *KeyServiceImpl) RemoveDevice(ctx context.Context, userID int, deviceID string) error {
+	return s.repo.DeleteDevice(ctx, userID, deviceID)
+}
+
+func (s *KeyServiceImpl) GetKeyBundles(ctx context.Context, userID int) ([]KeyBundle, error) {
+	return s.repo.ClaimKeyBundles(ctx, userID)
+}
+
+func validateDeviceID(deviceID string) error {
+	if strings.TrimSpace(deviceID) == "" || len(deviceID) > MaxDeviceIDLength {
+		return ErrInvalidDeviceID
+	}
+	return nil
+}
+
+func validatePrekeys(prekeys []Prekey) error {
+	if len(prekeys) > MaxPrekeysPerUpload {
+		return ErrTooManyPrekeys
+	}
+	for _, prekey := range prekeys {
+		if !validKey(prekey.PublicKey) {
+			return ErrInvalidKeys
+		}
+	}
+	return nil
+}
+
+func validKey(key string) bool {
+	return key != "" && len(key) <= MaxKeyLength
+}
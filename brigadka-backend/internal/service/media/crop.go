@@ -0,0 +1,131 @@
+package media
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+var (
+	ErrNotAnImage  = errors.New("only images can be cropped")
+	ErrInvalidCrop = errors.New("crop rectangle must be non-empty and within the image")
+)
+
+// CropRect is a rectangle of an image in pixels of the original, from its top left corner
+type CropRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// CropMedia stores the rectangle of an image the user uploaded as new media, keeping
+// the original. The crop gets a thumbnail right away and its variants in the background.
+// Cropping the same rectangle again returns the earlier crop. A rectangle outside the
+// image or over the storage quota fails with *ValidationError.
+func (s *MediaServiceImpl) CropMedia(userID, mediaID int, rect CropRect) (*Media, error) {
+	original, err := s.mediaRepository.GetMediaByID(mediaID)
+	if err != nil {
+		if errors.Is(err, mediarepo.ErrMediaNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, err
+	}
+	if original.UserID != userID {
+		return nil, ErrNotMediaOwner
+	}
+	if original.Role != "image" {
+		return nil, ErrNotAnImage
+	}
+
+	file, err := s.storageProvider.DownloadFile(original.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media: %w", err)
+	}
+	defer file.Close()
+	src, err := decodeImage(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := image.Rect(rect.X, rect.Y, rect.X+rect.Width, rect.Y+rect.Height)
+	if rect.Width <= 0 || rect.Height <= 0 || !bounds.In(src.Rect) {
+		return nil, &ValidationError{Field: "crop", Code: CodeInvalidCrop, err: ErrInvalidCrop}
+	}
+	cropped := src.SubImage(bounds).(*image.RGBA)
+	content, err := encodeJPEG(cropped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode crop: %w", err)
+	}
+
+	hash := sha256.Sum256(content)
+	stored := mediarepo.StoredFile{ContentHash: hex.EncodeToString(hash[:])}
+	duplicate, err := s.mediaRepository.FindMediaByContentHash(userID, stored.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return &Media{
+			ID:               duplicate.ID,
+			URL:              duplicate.URL,
+			ThumbnailURL:     duplicate.ThumbnailURL,
+			ModerationStatus: duplicate.ModerationStatus,
+			Variants:         duplicate.VariantURLs(),
+		}, nil
+	}
+
+	// The image is in memory already, so the thumbnail is made here rather than by the worker
+	thumbnail, err := encodeJPEG(scaleImage(cropped, ThumbnailSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate thumbnail: %w", err)
+	}
+	stored.SizeBytes = int64(len(content) + len(thumbnail))
+	stored.QuotaBytes = s.limits.StorageQuota
+	if err := s.checkQuota(userID, stored.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	mediaURL, err := s.storageProvider.UploadFile(memoryFile{bytes.NewReader(content)}, "crop.jpg")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload crop: %w", err)
+	}
+	thumbnailURL, err := s.storageProvider.UploadFile(memoryFile{bytes.NewReader(thumbnail)}, "thumbnail.jpg")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload thumbnail: %w", err)
+	}
+
+	// A crop can show a part of the original the classifier scored differently, so it is classified again
+	moderation := mediarepo.Moderation{Status: mediarepo.ModerationApproved}
+	if s.moderator != nil {
+		moderation = s.moderator.classify(content, "image/jpeg")
+	}
+
+	var newID int
+	switch {
+	case s.thumbnails != nil:
+		newID, err = s.mediaRepository.CreateMediaWithThumbnailJob(userID, "image", mediaURL, thumbnailURL, stored, moderation)
+	case s.moderator != nil:
+		newID, err = s.mediaRepository.CreateModeratedMedia(userID, "image", mediaURL, thumbnailURL, stored, moderation)
+	default:
+		newID, err = s.mediaRepository.CreateMedia(userID, "image", mediaURL, thumbnailURL, stored)
+	}
+	if err != nil {
+		return nil, s.saveFailed(err, mediaURL, thumbnailURL)
+	}
+	if s.thumbnails != nil {
+		s.thumbnails.wakeUp()
+	}
+
+	return &Media{
+		ID:               newID,
+		URL:              mediaURL,
+		ThumbnailURL:     thumbnailURL,
+		ModerationStatus: moderation.Status,
+		Variants:         mediarepo.Media{Role: "image", URL: mediaURL}.VariantURLs(),
+	}, nil
+}
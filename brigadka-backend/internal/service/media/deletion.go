@@ -0,0 +1,85 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+var (
+	ErrNotMediaOwner = errors.New("media belongs to another user")
+	ErrMediaInUse    = errors.New("media is in use")
+)
+
+// InUseError is returned when deleting media that is still used. Usages are the
+// mediarepo.Usage* values, e.g. "avatar" or "message_attachment".
+type InUseError struct {
+	Usages []string
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("media is in use: %s", strings.Join(e.Usages, ", "))
+}
+
+func (e *InUseError) Unwrap() error {
+	return ErrMediaInUse
+}
+
+// DeleteMedia deletes media the user uploaded and no longer uses, together with
+// its stored file, thumbnail and image variants. Used media fails with *InUseError.
+func (s *MediaServiceImpl) DeleteMedia(userID, mediaID int) error {
+	media, err := s.mediaRepository.GetMediaByID(mediaID)
+	if err != nil {
+		if errors.Is(err, mediarepo.ErrMediaNotFound) {
+			return ErrMediaNotFound
+		}
+		return err
+	}
+	if media.UserID != userID {
+		return ErrNotMediaOwner
+	}
+
+	// The usage check is part of the delete, so media attached in the meantime is kept
+	err = s.mediaRepository.DeleteMedia(userID, mediaID)
+	switch {
+	case errors.Is(err, mediarepo.ErrMediaInUse):
+		usages, err := s.mediaRepository.GetMediaUsages(mediaID)
+		if err != nil {
+			return err
+		}
+		return &InUseError{Usages: usages}
+	case errors.Is(err, mediarepo.ErrMediaNotFound):
+		return ErrMediaNotFound
+	case err != nil:
+		return err
+	}
+
+	// The files are unreachable once the row is gone; a failed delete only leaves an orphaned object
+	for _, url := range storedFiles(media) {
+		if err := s.storageProvider.DeleteFileByURL(url); err != nil {
+			log.Printf("Failed to delete file %s of media %d: %v", url, mediaID, err)
+		}
+	}
+	return nil
+}
+
+// storedFiles returns the distinct URLs of the files stored for the media. Variants
+// of small images point to the original, which is deleted once.
+func storedFiles(media *mediarepo.Media) []string {
+	urls := []string{media.URL}
+	seen := map[string]bool{media.URL: true}
+	candidates := []string{media.ThumbnailURL}
+	for _, url := range media.Variants {
+		candidates = append(candidates, url)
+	}
+	for _, url := range candidates {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,61 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// FFmpeg grabs video frames with the ffmpeg tool
+type FFmpeg struct {
+	path string
+}
+
+// NewFFmpeg finds the ffmpeg binary by name or path
+func NewFFmpeg(path string) (*FFmpeg, error) {
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FFmpeg{path: resolved}, nil
+}
+
+// Frame picks a representative frame among the first ones with the thumbnail filter,
+// which skips black and blurred frames, and scales it to fit size pixels
+func (f *FFmpeg) Frame(ctx context.Context, video io.Reader, size int) ([]byte, error) {
+	// The video is written to a temporary file, since MP4s are not always readable from a pipe
+	temp, err := os.CreateTemp("", "video-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(temp.Name())
+	defer temp.Close()
+	if _, err := io.Copy(temp, video); err != nil {
+		return nil, err
+	}
+
+	scale := fmt.Sprintf("thumbnail,scale=w=%[1]d:h=%[1]d:force_original_aspect_ratio=decrease", size)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, f.path,
+		"-v", "error",
+		"-i", temp.Name(),
+		"-vf", scale,
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "mjpeg",
+		"pipe:1",
+	)
+	cmd.Stderr = &stderr
+	frame, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if len(frame) == 0 {
+		return nil, fmt.Errorf("ffmpeg returned no frame")
+	}
+	return frame, nil
+}
@@ -0,0 +1,80 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FFProbe measures durations with the ffprobe tool of FFmpeg, which reads
+// any container FFmpeg supports and not only the MP4 movie header
+type FFProbe struct {
+	path string
+}
+
+// NewFFProbe finds the ffprobe binary by name or path
+func NewFFProbe(path string) (*FFProbe, error) {
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FFProbe{path: resolved}, nil
+}
+
+// Duration returns the duration of the container. Files ffprobe cannot read,
+// such as MP4s without decodable streams, fall back to the MP4 movie header.
+func (p *FFProbe) Duration(ctx context.Context, file io.ReadSeeker) (time.Duration, error) {
+	duration, err := p.probe(ctx, file)
+	if err != nil {
+		if headerDuration, headerErr := mp4Duration(file); headerErr == nil {
+			return headerDuration, nil
+		}
+		return 0, err
+	}
+	return duration, nil
+}
+
+// probe runs ffprobe on the file. Uploads kept in memory are written to a
+// temporary file first, since ffprobe needs to seek.
+func (p *FFProbe) probe(ctx context.Context, file io.ReadSeeker) (time.Duration, error) {
+	name := ""
+	if f, ok := file.(*os.File); ok {
+		name = f.Name()
+	} else {
+		temp, err := os.CreateTemp("", "upload-*")
+		if err != nil {
+			return 0, err
+		}
+		defer os.Remove(temp.Name())
+		defer temp.Close()
+
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return 0, err
+		}
+		if _, err := io.Copy(temp, file); err != nil {
+			return 0, err
+		}
+		name = temp.Name()
+	}
+
+	output, err := exec.CommandContext(ctx, p.path,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		name,
+	).Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected ffprobe output %q", strings.TrimSpace(string(output)))
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
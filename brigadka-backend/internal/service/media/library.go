@@ -0,0 +1,108 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+// QuotaWarningRatio is the share of the storage quota from which clients should warn the user
+const QuotaWarningRatio = 0.9
+
+// Quota is the storage taken by the uploads of a user
+type Quota struct {
+	UsedBytes int64 `json:"used_bytes"`
+	// LimitBytes is 0 when uploads are not limited
+	LimitBytes int64 `json:"limit_bytes"`
+	// Warning is set once QuotaWarningRatio of the quota is used
+	Warning bool `json:"warning"`
+}
+
+// MediaLibrary is a page of the media uploaded by a user, newest first
+type MediaLibrary struct {
+	Items      []mediarepo.LibraryItem `json:"items"`
+	TotalCount int                     `json:"total_count"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	Quota      Quota                   `json:"quota"`
+}
+
+// GetMediaLibrary returns the uploads of the user with where each one is used
+func (s *MediaServiceImpl) GetMediaLibrary(userID, page, pageSize int) (*MediaLibrary, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	items, total, err := s.mediaRepository.GetUserMedia(userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Variants = items[i].VariantURLs()
+	}
+
+	quota, err := s.GetQuota(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaLibrary{Items: items, TotalCount: total, Page: page, PageSize: pageSize, Quota: *quota}, nil
+}
+
+// GetQuota returns how much of the storage quota the user has used
+func (s *MediaServiceImpl) GetQuota(userID int) (*Quota, error) {
+	used, err := s.mediaRepository.GetStorageUsed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &Quota{UsedBytes: used, LimitBytes: s.limits.StorageQuota}
+	if quota.LimitBytes > 0 {
+		quota.Warning = float64(used) >= QuotaWarningRatio*float64(quota.LimitBytes)
+	}
+	return quota, nil
+}
+
+// checkQuota rejects an upload of size bytes that does not fit into the user's quota.
+// It runs before the files are stored to spare uploading them; the repository checks
+// the quota again when it saves the media, together with concurrent uploads of the user.
+func (s *MediaServiceImpl) checkQuota(userID int, size int64) error {
+	if s.limits.StorageQuota <= 0 {
+		return nil
+	}
+
+	used, err := s.mediaRepository.GetStorageUsed(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get storage used: %w", err)
+	}
+	if used+size > s.limits.StorageQuota {
+		return s.quotaExceeded()
+	}
+	return nil
+}
+
+func (s *MediaServiceImpl) quotaExceeded() error {
+	return &ValidationError{Field: "file", Code: CodeQuotaExceeded, Limit: s.limits.StorageQuota, err: ErrQuotaExceeded}
+}
+
+// saveFailed turns the error of saving an upload into the one returned to the user.
+// The stored files are deleted when the quota ran out, as another upload of the user
+// took the space after checkQuota; other errors leave them for a retry to deduplicate.
+func (s *MediaServiceImpl) saveFailed(err error, fileURLs ...string) error {
+	if !errors.Is(err, mediarepo.ErrQuotaExceeded) {
+		return err
+	}
+	for _, url := range fileURLs {
+		if url == "" {
+			continue
+		}
+		if err := s.storageProvider.DeleteFileByURL(url); err != nil {
+			log.Printf("Failed to delete file %s of an upload over quota: %v", url, err)
+		}
+	}
+	return s.quotaExceeded()
+}
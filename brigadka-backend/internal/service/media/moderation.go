@@ -0,0 +1,210 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+// DefaultNSFWThreshold is the score above which uploads are held for review
+const DefaultNSFWThreshold = 0.8
+
+// classifyTimeout bounds the time an upload waits for the classifier
+const classifyTimeout = 10 * time.Second
+
+// maxClassifiedImageSize limits the image sent to the classifier
+const maxClassifiedImageSize = 10 * 1024 * 1024
+
+// NSFWClassifier scores images from 0 (safe) to 1 (explicit)
+type NSFWClassifier interface {
+	// Name identifies the provider in the moderation queue and metrics
+	Name() string
+	Classify(ctx context.Context, image []byte, contentType string) (float64, error)
+}
+
+// ProviderMetrics are counters of a classification provider since startup
+type ProviderMetrics struct {
+	Provider     string  `json:"provider"`
+	Threshold    float64 `json:"threshold"`
+	Classified   int64   `json:"classified"`
+	Flagged      int64   `json:"flagged"`
+	Errors       int64   `json:"errors"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// ModerationQueue is a page of media waiting for manual review
+type ModerationQueue struct {
+	Items      []mediarepo.PendingMedia `json:"items"`
+	TotalCount int                      `json:"total_count"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+}
+
+type providerCounters struct {
+	classified   int64
+	flagged      int64
+	errors       int64
+	totalLatency time.Duration
+}
+
+// moderator runs uploads through the classifier and keeps per-provider metrics
+type moderator struct {
+	classifier NSFWClassifier
+	threshold  float64
+
+	mu      sync.Mutex
+	metrics map[string]*providerCounters
+}
+
+// SetNSFWClassifier enables automated moderation: images and video thumbnails
+// scoring above the threshold are held for manual review
+func (s *MediaServiceImpl) SetNSFWClassifier(classifier NSFWClassifier, threshold float64) {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultNSFWThreshold
+	}
+	s.moderator = &moderator{
+		classifier: classifier,
+		threshold:  threshold,
+		metrics:    map[string]*providerCounters{},
+	}
+}
+
+// moderate classifies the picture of an upload: the image itself or the video thumbnail.
+// Uploads that cannot be classified are held for review.
+func (m *moderator) moderate(mediaType string, fileHeader, thumbnailHeader UploadedFile) mediarepo.Moderation {
+	provider := m.classifier.Name()
+
+	picture := fileHeader
+	if mediaType == "video" {
+		picture = thumbnailHeader
+	}
+
+	image, err := readUpload(picture)
+	if err != nil {
+		log.Printf("Failed to read %s for moderation: %v", picture.GetFilename(), err)
+		m.record(provider, false, true, 0)
+		return mediarepo.Moderation{Status: mediarepo.ModerationPending, Provider: provider}
+	}
+	return m.classify(image, picture.GetHeader().Get("Content-Type"))
+}
+
+// classify scores a picture; pictures that cannot be classified are held for review
+func (m *moderator) classify(image []byte, contentType string) mediarepo.Moderation {
+	provider := m.classifier.Name()
+
+	ctx, cancel := context.WithTimeout(context.Background(), classifyTimeout)
+	defer cancel()
+
+	started := time.Now()
+	score, err := m.classifier.Classify(ctx, image, contentType)
+	latency := time.Since(started)
+	if err != nil {
+		log.Printf("NSFW classification by %s failed: %v", provider, err)
+		m.record(provider, false, true, latency)
+		return mediarepo.Moderation{Status: mediarepo.ModerationPending, Provider: provider}
+	}
+
+	flagged := score >= m.threshold
+	m.record(provider, flagged, false, latency)
+
+	status := mediarepo.ModerationApproved
+	if flagged {
+		status = mediarepo.ModerationPending
+	}
+	return mediarepo.Moderation{Status: status, Score: &score, Provider: provider}
+}
+
+func (m *moderator) record(provider string, flagged, failed bool, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters, ok := m.metrics[provider]
+	if !ok {
+		counters = &providerCounters{}
+		m.metrics[provider] = counters
+	}
+	switch {
+	case failed:
+		counters.errors++
+	case flagged:
+		counters.classified++
+		counters.flagged++
+	default:
+		counters.classified++
+	}
+	counters.totalLatency += latency
+}
+
+func (m *moderator) snapshot() []ProviderMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]ProviderMetrics, 0, len(m.metrics))
+	for provider, counters := range m.metrics {
+		metrics := ProviderMetrics{
+			Provider:   provider,
+			Threshold:  m.threshold,
+			Classified: counters.classified,
+			Flagged:    counters.flagged,
+			Errors:     counters.errors,
+		}
+		if calls := counters.classified + counters.errors; calls > 0 {
+			metrics.AvgLatencyMs = float64(counters.totalLatency.Milliseconds()) / float64(calls)
+		}
+		result = append(result, metrics)
+	}
+	return result
+}
+
+// GetModerationQueue returns media held for manual review
+func (s *MediaServiceImpl) GetModerationQueue(page, pageSize int) (*ModerationQueue, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	items, total, err := s.mediaRepository.GetPendingMedia(pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ModerationQueue{Items: items, TotalCount: total, Page: page, PageSize: pageSize}, nil
+}
+
+// ReviewMedia approves or rejects a media item from the moderation queue
+func (s *MediaServiceImpl) ReviewMedia(moderatorID, mediaID int, approve bool) error {
+	status := mediarepo.ModerationRejected
+	if approve {
+		status = mediarepo.ModerationApproved
+	}
+
+	err := s.mediaRepository.ReviewMedia(mediaID, moderatorID, status)
+	if errors.Is(err, mediarepo.ErrMediaNotFound) {
+		return ErrMediaNotFound
+	}
+	return err
+}
+
+// GetModerationMetrics returns classifier counters; empty when moderation is disabled
+func (s *MediaServiceImpl) GetModerationMetrics() []ProviderMetrics {
+	if s.moderator == nil {
+		return []ProviderMetrics{}
+	}
+	return s.moderator.snapshot()
+}
+
+func readUpload(upload UploadedFile) ([]byte, error) {
+	file, err := upload.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, maxClassifiedImageSize))
+}
@@ -0,0 +1,110 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+var errNoDuration = errors.New("mp4 movie header not found")
+
+// mp4Duration reads the duration of an MP4/M4A file from its movie header
+// (moov/mvhd) box. The reader is left at an unspecified position.
+func mp4Duration(r io.ReadSeeker) (time.Duration, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	offset := int64(0)
+	for offset < end {
+		size, boxType, headerLen, err := readBoxHeader(r, end-offset)
+		if err != nil {
+			return 0, err
+		}
+		switch boxType {
+		case "moov":
+			// Descend into the container, its children follow the header
+			end = offset + size
+			offset += headerLen
+			continue
+		case "mvhd":
+			return readMovieHeader(r)
+		}
+		offset += size
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return 0, err
+		}
+	}
+	return 0, errNoDuration
+}
+
+// readBoxHeader reads an MP4 box header and returns the full box size,
+// its type and the header length
+func readBoxHeader(r io.Reader, remaining int64) (int64, string, int64, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, "", 0, err
+	}
+	size := int64(binary.BigEndian.Uint32(header[:4]))
+	boxType := string(header[4:])
+	headerLen := int64(8)
+
+	switch size {
+	case 0:
+		// The box extends to the end of its container
+		size = remaining
+	case 1:
+		var large [8]byte
+		if _, err := io.ReadFull(r, large[:]); err != nil {
+			return 0, "", 0, err
+		}
+		size = int64(binary.BigEndian.Uint64(large[:]))
+		headerLen = 16
+	}
+	if size < headerLen || size > remaining {
+		return 0, "", 0, errors.New("invalid mp4 box size")
+	}
+	return size, boxType, headerLen, nil
+}
+
+// readMovieHeader parses the body of an mvhd box
+func readMovieHeader(r io.Reader) (time.Duration, error) {
+	var versionAndFlags [4]byte
+	if _, err := io.ReadFull(r, versionAndFlags[:]); err != nil {
+		return 0, err
+	}
+
+	var timescale, duration uint64
+	if versionAndFlags[0] == 1 {
+		// creation_time(8), modification_time(8), timescale(4), duration(8)
+		var body [28]byte
+		if _, err := io.ReadFull(r, body[:]); err != nil {
+			return 0, err
+		}
+		timescale = uint64(binary.BigEndian.Uint32(body[16:20]))
+		duration = binary.BigEndian.Uint64(body[20:28])
+	} else {
+		// creation_time(4), modification_time(4), timescale(4), duration(4)
+		var body [16]byte
+		if _, err := io.ReadFull(r, body[:]); err != nil {
+			return 0, err
+		}
+		timescale = uint64(binary.BigEndian.Uint32(body[8:12]))
+		duration = uint64(binary.BigEndian.Uint32(body[12:16]))
+	}
+	if timescale == 0 {
+		return 0, errors.New("invalid mp4 timescale")
+	}
+	seconds := duration / timescale
+	if seconds > uint64(math.MaxInt64/int64(time.Second)) {
+		return time.Duration(math.MaxInt64), nil
+	}
+	fraction := duration % timescale * uint64(time.Second) / timescale
+	return time.Duration(seconds)*time.Second + time.Duration(fraction), nil
+}
@@ -0,0 +1,70 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPClassifier sends images to an HTTP scoring endpoint.
+// The endpoint receives the raw image and answers with {"nsfw_score": 0.93}.
+type HTTPClassifier struct {
+	name     string
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewHTTPClassifier creates a classifier for the provider endpoint; the API key is sent as a bearer token
+func NewHTTPClassifier(name, endpoint, apiKey string) *HTTPClassifier {
+	return &HTTPClassifier{
+		name:     name,
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (c *HTTPClassifier) Name() string {
+	return c.name
+}
+
+// Classify returns the NSFW score of the image
+func (c *HTTPClassifier) Classify(ctx context.Context, image []byte, contentType string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(image))
+	if err != nil {
+		return 0, err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("classifier responded with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		NSFWScore *float64 `json:"nsfw_score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("invalid classifier response: %w", err)
+	}
+	if result.NSFWScore == nil || *result.NSFWScore < 0 || *result.NSFWScore > 1 {
+		return 0, fmt.Errorf("invalid classifier score")
+	}
+	return *result.NSFWScore, nil
+}
@@ -1,12 +1,19 @@
 package media
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
 	"net/textproto"
-	"path/filepath"
-	"strings"
+	"time"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
 )
 
 // Определение ошибок
@@ -14,56 +21,82 @@ var (
 	ErrMediaNotFound   = errors.New("media not found")
 	ErrInvalidFileType = errors.New("invalid file type")
 	ErrFileTooBig      = errors.New("file too big")
+	ErrAudioTooLong    = errors.New("audio too long")
 )
 
 type Media struct {
 	ID           int    `json:"id"`
 	URL          string `json:"url"`
 	ThumbnailURL string `json:"thumbnail_url"`
+	// ModerationStatus is "pending" while the upload waits for manual review
+	ModerationStatus string `json:"moderation_status"`
+	// ThumbnailPending is set while the server generates the thumbnail; ThumbnailURL is empty until then
+	ThumbnailPending bool `json:"thumbnail_pending"`
+	// Variants are the URLs of the sizes of an image by variant name. A new upload
+	// only has the full variant; the smaller ones are generated in the background.
+	Variants map[string]string `json:"variants,omitempty"`
 }
 
-// Константы для ограничений
-const (
-	MaxFileSize = 50 * 1024 * 1024 // 10 MB
-)
-
 // Repository defines the interface for media database operations
 type MediaRepository interface {
-	CreateMedia(userID int, mediaType, mediaURL, thumbnailURL string) (int, error)
+	CreateMedia(userID int, mediaType, mediaURL, thumbnailURL string, stored mediarepo.StoredFile) (int, error)
+	CreateModeratedMedia(userID int, mediaType, mediaURL, thumbnailURL string, stored mediarepo.StoredFile, moderation mediarepo.Moderation) (int, error)
+	FindMediaByContentHash(userID int, contentHash string) (*mediarepo.DuplicateMedia, error)
+	GetStorageUsed(userID int) (int64, error)
+	GetUserMedia(userID, limit, offset int) ([]mediarepo.LibraryItem, int, error)
+	GetMediaByID(mediaID int) (*mediarepo.Media, error)
+	GetMediaUsages(mediaID int) ([]string, error)
 	DeleteMedia(userID, mediaID int) error
+	GetPendingMedia(limit, offset int) ([]mediarepo.PendingMedia, int, error)
+	ReviewMedia(mediaID, moderatorID int, status string) error
+
+	CreateMediaWithThumbnailJob(userID int, mediaType, mediaURL, thumbnailURL string, stored mediarepo.StoredFile, moderation mediarepo.Moderation) (int, error)
+	ClaimThumbnailJobs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]mediarepo.ThumbnailJob, error)
+	CompleteThumbnailJob(ctx context.Context, mediaID int, thumbnailURL string, variants map[string]string, moderation *mediarepo.Moderation) error
+	RetryThumbnailJob(ctx context.Context, mediaID int, nextAttemptAt time.Time, lastError string) error
+	FailThumbnailJob(ctx context.Context, mediaID int, failedAt time.Time, lastError string) error
 }
 
 // StorageProvider определяет интерфейс для загрузки и получения файлов
 type StorageProvider interface {
 	UploadFile(file multipart.File, fileName string) (string, error)
+	DownloadFile(fileURL string) (io.ReadCloser, error)
 	DeleteFile(fileName string) error
+	DeleteFileByURL(fileURL string) error
 	GetFileURL(fileName string) string
 }
 
+// ActivityRecorder writes media events to the activity feed
+type ActivityRecorder interface {
+	Record(ctx context.Context, activity feed.Activity) error
+}
+
 // MediaServiceImpl представляет реализацию сервиса медиа
 type MediaServiceImpl struct {
-	mediaRepository MediaRepository
-	storageProvider StorageProvider
-	allowedTypes    map[string]bool // Разрешенные расширения
+	mediaRepository  MediaRepository
+	storageProvider  StorageProvider
+	limits           Limits
+	prober           DurationProber
+	activityRecorder ActivityRecorder
+	moderator        *moderator
+	thumbnails       *ThumbnailWorker // Optional
 }
 
 // NewMediaService создает новый экземпляр MediaServiceImpl
 func NewMediaService(mediaRepo MediaRepository, storageProvider StorageProvider) *MediaServiceImpl {
-	// Разрешенные типы файлов
-	allowedTypes := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".mp4":  true,
-	}
-
 	return &MediaServiceImpl{
 		mediaRepository: mediaRepo,
 		storageProvider: storageProvider,
-		allowedTypes:    allowedTypes,
+		limits:          DefaultLimits,
+		prober:          mp4Prober{},
 	}
 }
 
+// SetActivityRecorder enables publishing new videos to followers
+func (s *MediaServiceImpl) SetActivityRecorder(recorder ActivityRecorder) {
+	s.activityRecorder = recorder
+}
+
 type FileHeaderWrapper struct {
 	*multipart.FileHeader
 }
@@ -91,18 +124,12 @@ type UploadedFile interface {
 	GetHeader() textproto.MIMEHeader
 }
 
-// UploadMedia uploads a new media file and its thumbnail
+// UploadMedia validates and uploads a new media file and its thumbnail. Without a
+// thumbnail, images and videos get one generated in the background; audio has none.
+// Images also get their sized variants generated in the background.
+// A file the user has uploaded before returns the earlier media instead of a new copy.
+// Rejected uploads, including those over the storage quota, fail with *ValidationError.
 func (s *MediaServiceImpl) UploadMedia(userID int, fileHeader, thumbnailHeader UploadedFile) (*Media, error) {
-	// Проверяем размер основного файла
-	if fileHeader.GetSize() > MaxFileSize {
-		return nil, ErrFileTooBig
-	}
-
-	// Проверяем размер thumbnail файла, если он предоставлен
-	if thumbnailHeader.GetSize() > MaxFileSize {
-		return nil, ErrFileTooBig
-	}
-
 	// Открываем основной файл
 	file, err := fileHeader.Open()
 	if err != nil {
@@ -110,21 +137,57 @@ func (s *MediaServiceImpl) UploadMedia(userID int, fileHeader, thumbnailHeader U
 	}
 	defer file.Close()
 
-	ext := strings.ToLower(filepath.Ext(fileHeader.GetFilename()))
-	if _, allowed := s.allowedTypes[ext]; !allowed {
-		return nil, ErrInvalidFileType
+	// Проверяем файлы до загрузки, чтобы не оставлять в хранилище отклоненные файлы
+	mediaType, err := s.validateUpload("file", fileHeader, file, "image", "video", "audio")
+	if err != nil {
+		return nil, err
 	}
 
-	// Определяем тип медиа по расширению
-	var mediaType string
-	switch ext {
-	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
-		mediaType = "image"
-	case ".mp4", ".webm":
-		mediaType = "video"
-	default:
-		return nil, ErrInvalidFileType
+	// Повторная загрузка того же файла возвращает уже сохраненное медиа; новый thumbnail не нужен
+	contentHash, err := hashContent(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash file: %w", err)
 	}
+	duplicate, err := s.mediaRepository.FindMediaByContentHash(userID, contentHash)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return &Media{
+			ID:               duplicate.ID,
+			URL:              duplicate.URL,
+			ThumbnailURL:     duplicate.ThumbnailURL,
+			ModerationStatus: duplicate.ModerationStatus,
+			ThumbnailPending: duplicate.ThumbnailURL == "" && duplicate.Role != "audio",
+			Variants:         duplicate.VariantURLs(),
+		}, nil
+	}
+
+	var thumbFile multipart.File
+	if thumbnailHeader != nil {
+		thumbFile, err = thumbnailHeader.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open thumbnail file: %w", err)
+		}
+		defer thumbFile.Close()
+
+		if _, err := s.validateUpload("thumbnail", thumbnailHeader, thumbFile, "image"); err != nil {
+			return nil, err
+		}
+	} else if mediaType != "audio" && !s.thumbnails.canGenerate(mediaType) {
+		return nil, &ValidationError{Field: "thumbnail", Code: CodeThumbnailRequired, err: ErrThumbnailRequired}
+	}
+
+	stored := mediarepo.StoredFile{ContentHash: contentHash, SizeBytes: fileHeader.GetSize(), QuotaBytes: s.limits.StorageQuota}
+	if thumbnailHeader != nil {
+		stored.SizeBytes += thumbnailHeader.GetSize()
+	}
+	if err := s.checkQuota(userID, stored.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	generateThumbnail := thumbnailHeader == nil && mediaType != "audio"
+	queueJob := generateThumbnail || (mediaType == "image" && s.thumbnails != nil)
 
 	// Загружаем основной файл в хранилище
 	mediaURL, err := s.storageProvider.UploadFile(file, fileHeader.GetFilename())
@@ -133,32 +196,85 @@ func (s *MediaServiceImpl) UploadMedia(userID int, fileHeader, thumbnailHeader U
 	}
 
 	var thumbnailURL string
-	// Загружаем thumbnail, если он предоставлен
-	thumbFile, err := thumbnailHeader.Open()
-	if err != nil {
-		return nil, fmt.Errorf("failed to open thumbnail file: %w", err)
+	if thumbFile != nil {
+		thumbnailURL, err = s.storageProvider.UploadFile(thumbFile, thumbnailHeader.GetFilename())
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload thumbnail: %w", err)
+		}
 	}
-	defer thumbFile.Close()
 
-	thumbExt := strings.ToLower(filepath.Ext(thumbnailHeader.GetFilename()))
-	if _, allowed := s.allowedTypes[thumbExt]; !allowed {
-		return nil, ErrInvalidFileType
+	// Сохраняем информацию о медиа в БД; при включенной модерации — вместе с ее результатом
+	moderated := s.moderator != nil && mediaType != "audio"
+	moderation := mediarepo.Moderation{Status: mediarepo.ModerationApproved}
+	if moderated {
+		if mediaType == "video" && generateThumbnail {
+			// The generated thumbnail is classified by the worker; until then the video is held
+			moderation = mediarepo.Moderation{Status: mediarepo.ModerationPending, Provider: s.moderator.classifier.Name()}
+		} else {
+			moderation = s.moderator.moderate(mediaType, fileHeader, thumbnailHeader)
+		}
 	}
 
-	thumbnailURL, err = s.storageProvider.UploadFile(thumbFile, thumbnailHeader.GetFilename())
+	var mediaID int
+	switch {
+	case queueJob:
+		mediaID, err = s.mediaRepository.CreateMediaWithThumbnailJob(userID, mediaType, mediaURL, thumbnailURL, stored, moderation)
+	case moderated:
+		mediaID, err = s.mediaRepository.CreateModeratedMedia(userID, mediaType, mediaURL, thumbnailURL, stored, moderation)
+	default:
+		mediaID, err = s.mediaRepository.CreateMedia(userID, mediaType, mediaURL, thumbnailURL, stored)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload thumbnail: %w", err)
+		return nil, s.saveFailed(err, mediaURL, thumbnailURL)
 	}
 
-	// Сохраняем информацию о медиа в БД
-	mediaID, err := s.mediaRepository.CreateMedia(userID, mediaType, mediaURL, thumbnailURL)
-	if err != nil {
-		return nil, err
+	if queueJob {
+		s.thumbnails.wakeUp()
+	} else if mediaType == "video" && moderation.Status == mediarepo.ModerationApproved {
+		// Held media becomes visible to followers only after review
+		s.recordVideo(userID, mediaID, mediaURL, thumbnailURL)
 	}
 
 	return &Media{
-		ID:           mediaID,
-		URL:          mediaURL,
-		ThumbnailURL: thumbnailURL,
+		ID:               mediaID,
+		URL:              mediaURL,
+		ThumbnailURL:     thumbnailURL,
+		ModerationStatus: moderation.Status,
+		ThumbnailPending: generateThumbnail,
+		Variants:         mediarepo.Media{Role: mediaType, URL: mediaURL}.VariantURLs(),
 	}, nil
 }
+
+// hashContent returns the hex SHA-256 of the file and rewinds it for the upload
+func hashContent(file io.ReadSeeker) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// recordVideo publishes a new video to the followers of its owner
+func (s *MediaServiceImpl) recordVideo(userID, mediaID int, mediaURL, thumbnailURL string) {
+	if s.activityRecorder == nil {
+		return
+	}
+	err := s.activityRecorder.Record(context.Background(), feed.Activity{
+		Type:   feed.ActivityVideoAdded,
+		UserID: &userID,
+		Payload: map[string]interface{}{
+			"media_id":      mediaID,
+			"url":           mediaURL,
+			"thumbnail_url": thumbnailURL,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to record video activity: %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"log"
+	"time"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+// Thumbnail worker defaults
+const (
+	// ThumbnailSize is the longest side of a generated thumbnail in pixels
+	ThumbnailSize               = 480
+	DefaultThumbnailMaxAttempts = 5
+)
+
+const (
+	thumbnailBatchSize = 10
+	// thumbnailLease is how long a claimed job waits before another worker retries it
+	thumbnailLease = 5 * time.Minute
+	// thumbnailTimeout bounds the generation of a single thumbnail, well within the lease
+	thumbnailTimeout     = 2 * time.Minute
+	thumbnailRetryDelay  = 30 * time.Second
+	thumbnailJPEGQuality = 80
+)
+
+// FrameGrabber extracts a representative frame of a video as a JPEG of at most size pixels
+type FrameGrabber interface {
+	Frame(ctx context.Context, video io.Reader, size int) ([]byte, error)
+}
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// ThumbnailWorker generates the thumbnails of uploads that came without one:
+// images are scaled down and videos get a frame grabbed by the FrameGrabber.
+// It also makes the sized variants of every uploaded image.
+// Jobs are stored in the database and retried until they run out of attempts.
+type ThumbnailWorker struct {
+	service     *MediaServiceImpl
+	grabber     FrameGrabber // Optional; without it videos need an uploaded thumbnail
+	maxAttempts int
+	now         func() time.Time
+	wake        chan struct{}
+	runObserver RunObserver // Optional
+}
+
+// EnableThumbnailGeneration makes the thumbnail of image uploads, and of video uploads
+// when grabber is set, optional. The returned worker must be run to generate them.
+func (s *MediaServiceImpl) EnableThumbnailGeneration(grabber FrameGrabber, maxAttempts int) *ThumbnailWorker {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultThumbnailMaxAttempts
+	}
+	s.thumbnails = &ThumbnailWorker{
+		service:     s,
+		grabber:     grabber,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+	}
+	return s.thumbnails
+}
+
+// SetRunObserver reports the outcome of every run of the worker
+func (w *ThumbnailWorker) SetRunObserver(observer RunObserver) {
+	w.runObserver = observer
+}
+
+// canGenerate reports whether thumbnails of the media type are generated; false for a nil worker
+func (w *ThumbnailWorker) canGenerate(mediaType string) bool {
+	if w == nil {
+		return false
+	}
+	return mediaType == "image" || (mediaType == "video" && w.grabber != nil)
+}
+
+// wakeUp starts a run without waiting for the next poll
+func (w *ThumbnailWorker) wakeUp() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run generates due thumbnails every interval, and as soon as one is queued,
+// until the context is cancelled. Jobs queued on other replicas are picked up by the poll.
+func (w *ThumbnailWorker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Process(ctx)
+		if w.runObserver != nil {
+			w.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to process thumbnail jobs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// Process generates the thumbnails that are due, a batch at a time
+func (w *ThumbnailWorker) Process(ctx context.Context) error {
+	repo := w.service.mediaRepository
+	for {
+		now := w.now()
+		jobs, err := repo.ClaimThumbnailJobs(ctx, now, now.Add(thumbnailLease), thumbnailBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			err := w.generate(ctx, job)
+			if err == nil {
+				continue
+			}
+			if job.Attempts >= w.maxAttempts {
+				log.Printf("Thumbnail of media %d failed after %d attempts: %v", job.ID, job.Attempts, err)
+				if err := repo.FailThumbnailJob(ctx, job.ID, w.now(), err.Error()); err != nil {
+					log.Printf("Failed to stop thumbnail job of media %d: %v", job.ID, err)
+				}
+				continue
+			}
+			retryAt := w.now().Add(thumbnailRetryDelay << (job.Attempts - 1))
+			if err := repo.RetryThumbnailJob(ctx, job.ID, retryAt, err.Error()); err != nil {
+				log.Printf("Failed to schedule retry of thumbnail job of media %d: %v", job.ID, err)
+			}
+		}
+
+		if len(jobs) < thumbnailBatchSize {
+			return nil
+		}
+	}
+}
+
+// generate makes the missing thumbnail and the image variants of an upload, stores
+// them and, for videos held until their thumbnail could be classified, records the
+// moderation result
+func (w *ThumbnailWorker) generate(ctx context.Context, job mediarepo.ThumbnailJob) error {
+	ctx, cancel := context.WithTimeout(ctx, thumbnailTimeout)
+	defer cancel()
+
+	original, err := w.service.storageProvider.DownloadFile(job.URL)
+	if err != nil {
+		return err
+	}
+	defer original.Close()
+
+	var thumbnail []byte
+	var variants map[string]string
+	switch job.Role {
+	case "image":
+		src, err := decodeImage(original)
+		if err != nil {
+			return fmt.Errorf("failed to decode image: %w", err)
+		}
+		if variants, err = w.uploadVariants(job.URL, src); err != nil {
+			return err
+		}
+		if job.ThumbnailURL == "" {
+			if thumbnail, err = encodeJPEG(scaleImage(src, ThumbnailSize)); err != nil {
+				return fmt.Errorf("failed to generate thumbnail: %w", err)
+			}
+		}
+	case "video":
+		if w.grabber == nil {
+			return fmt.Errorf("no frame grabber for video thumbnails")
+		}
+		if thumbnail, err = w.grabber.Frame(ctx, original, ThumbnailSize); err != nil {
+			return fmt.Errorf("failed to generate thumbnail: %w", err)
+		}
+	default:
+		return fmt.Errorf("no thumbnails for %s media", job.Role)
+	}
+
+	thumbnailURL := job.ThumbnailURL
+	if thumbnail != nil {
+		thumbnailURL, err = w.service.storageProvider.UploadFile(memoryFile{bytes.NewReader(thumbnail)}, "thumbnail.jpg")
+		if err != nil {
+			return fmt.Errorf("failed to upload thumbnail: %w", err)
+		}
+	}
+
+	var moderation *mediarepo.Moderation
+	if job.Role == "video" && w.service.moderator != nil {
+		result := w.service.moderator.classify(thumbnail, "image/jpeg")
+		moderation = &result
+	}
+	if err := w.service.mediaRepository.CompleteThumbnailJob(ctx, job.ID, thumbnailURL, variants, moderation); err != nil {
+		return err
+	}
+
+	if job.Role == "video" && (moderation == nil || moderation.Status == mediarepo.ModerationApproved) {
+		w.service.recordVideo(job.UserID, job.ID, job.URL, thumbnailURL)
+	}
+	return nil
+}
+
+// uploadVariants stores the sized variants of an image. Sizes the image already
+// fits in point to the original instead of a copy.
+func (w *ThumbnailWorker) uploadVariants(originalURL string, src *image.RGBA) (map[string]string, error) {
+	variants := make(map[string]string, len(mediarepo.VariantSizes))
+	for name, size := range mediarepo.VariantSizes {
+		if src.Rect.Dx() <= size && src.Rect.Dy() <= size {
+			variants[name] = originalURL
+			continue
+		}
+		variant, err := encodeJPEG(scaleImage(src, size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s variant: %w", name, err)
+		}
+		url, err := w.service.storageProvider.UploadFile(memoryFile{bytes.NewReader(variant)}, "variant-"+name+".jpg")
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload %s variant: %w", name, err)
+		}
+		variants[name] = url
+	}
+	return variants, nil
+}
+
+// decodeImage decodes an image into RGBA pixels, which are scaled once per size
+func decodeImage(r io.Reader) (*image.RGBA, error) {
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	return rgba, nil
+}
+
+// scaleImage scales the image down to fit size pixels, averaging the source
+// pixels under each pixel of the result. Images that fit are returned as is.
+func scaleImage(rgba *image.RGBA, size int) *image.RGBA {
+	width, height := rgba.Rect.Dx(), rgba.Rect.Dy()
+	if width <= size && height <= size {
+		return rgba
+	}
+	dstWidth, dstHeight := size, max(1, height*size/width)
+	if height > width {
+		dstWidth, dstHeight = max(1, width*size/height), size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// encodeJPEG encodes a generated thumbnail or variant
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// memoryFile lets a generated file be uploaded through StorageProvider
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
@@ -0,0 +1,245 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Register decoders for image.DecodeConfig
+	_ "image/png"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Upload limits used unless configured with SetLimits
+const (
+	MaxFileSize       = 50 * 1024 * 1024 // 50 MB
+	MaxImageDimension = 8192             // Pixels, for the width and the height
+	MaxVideoDuration  = 3 * time.Minute
+	MaxAudioDuration  = 60 * time.Second   // Голосовое представление профиля
+	StorageQuota      = 1024 * 1024 * 1024 // 1 GB of uploads per user
+)
+
+// probeTimeout bounds the time spent measuring the duration of an upload
+const probeTimeout = 30 * time.Second
+
+var (
+	ErrImageTooLarge = errors.New("image too large")
+	ErrVideoTooLong  = errors.New("video too long")
+	ErrInvalidMedia  = errors.New("file content cannot be read")
+	// ErrThumbnailRequired is returned for uploads without a thumbnail the server cannot generate
+	ErrThumbnailRequired = errors.New("thumbnail required")
+	ErrQuotaExceeded     = errors.New("storage quota exceeded")
+)
+
+// Limits are checked for every upload; a zero limit is not checked
+type Limits struct {
+	MaxFileSize       int64 // Bytes, for the file and the thumbnail
+	MaxImageDimension int   // Pixels, for images and thumbnails
+	MaxVideoDuration  time.Duration
+	MaxAudioDuration  time.Duration
+	StorageQuota      int64 // Bytes of all uploads of a user, files and thumbnails
+}
+
+// DefaultLimits are the limits of a new media service
+var DefaultLimits = Limits{
+	MaxFileSize:       MaxFileSize,
+	MaxImageDimension: MaxImageDimension,
+	MaxVideoDuration:  MaxVideoDuration,
+	MaxAudioDuration:  MaxAudioDuration,
+	StorageQuota:      StorageQuota,
+}
+
+// Validation error codes returned to clients
+const (
+	CodeUnsupportedType   = "unsupported_media_type"
+	CodeFileTooLarge      = "file_too_large"
+	CodeImageTooLarge     = "image_too_large"
+	CodeVideoTooLong      = "video_too_long"
+	CodeAudioTooLong      = "audio_too_long"
+	CodeInvalidMedia      = "invalid_media"
+	CodeThumbnailRequired = "thumbnail_required"
+	CodeQuotaExceeded     = "storage_quota_exceeded"
+	CodeInvalidCrop       = "invalid_crop"
+)
+
+// ValidationError describes an upload rejected by validation. It wraps one of
+// ErrInvalidFileType, ErrFileTooBig, ErrImageTooLarge, ErrVideoTooLong,
+// ErrAudioTooLong, ErrInvalidMedia, ErrThumbnailRequired, ErrQuotaExceeded and ErrInvalidCrop.
+type ValidationError struct {
+	Field string // "file" or "thumbnail"; "crop" for CodeInvalidCrop
+	Code  string
+	// Limit that was exceeded: bytes, pixels or seconds depending on the code
+	Limit int64
+	// Allowed content types, for CodeUnsupportedType
+	Allowed []string
+	err     error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// mediaTypeByExtension maps accepted file extensions to media types
+var mediaTypeByExtension = map[string]string{
+	".jpg":  "image",
+	".jpeg": "image",
+	".png":  "image",
+	".mp4":  "video",
+	".m4a":  "audio",
+}
+
+// allowedContentTypes lists the sniffed content types accepted for each media type
+var allowedContentTypes = map[string]map[string]bool{
+	"image": {"image/jpeg": true, "image/png": true},
+	"video": {"video/mp4": true},
+	"audio": {"audio/mp4": true},
+}
+
+// DurationProber measures the duration of a video or audio file
+type DurationProber interface {
+	Duration(ctx context.Context, file io.ReadSeeker) (time.Duration, error)
+}
+
+// mp4Prober reads the duration from the MP4 movie header without external tools
+type mp4Prober struct{}
+
+func (mp4Prober) Duration(ctx context.Context, file io.ReadSeeker) (time.Duration, error) {
+	return mp4Duration(file)
+}
+
+// SetLimits replaces the default upload limits
+func (s *MediaServiceImpl) SetLimits(limits Limits) {
+	s.limits = limits
+}
+
+// Limits returns the upload limits in effect
+func (s *MediaServiceImpl) Limits() Limits {
+	return s.limits
+}
+
+// SetDurationProber replaces the built-in MP4 header reader, e.g. with ffprobe
+func (s *MediaServiceImpl) SetDurationProber(prober DurationProber) {
+	s.prober = prober
+}
+
+// validateUpload checks the size, the sniffed content type and the dimensions or duration
+// of an upload and returns its media type. The file is rewound for uploading.
+func (s *MediaServiceImpl) validateUpload(field string, upload UploadedFile, file io.ReadSeeker, mediaTypes ...string) (string, error) {
+	if s.limits.MaxFileSize > 0 && upload.GetSize() > s.limits.MaxFileSize {
+		return "", &ValidationError{Field: field, Code: CodeFileTooLarge, Limit: s.limits.MaxFileSize, err: ErrFileTooBig}
+	}
+
+	mediaType := mediaTypeByExtension[strings.ToLower(filepath.Ext(upload.GetFilename()))]
+	if !contains(mediaTypes, mediaType) {
+		return "", unsupportedType(field, mediaTypes)
+	}
+
+	contentType, err := sniffContentType(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", field, err)
+	}
+	if !allowedContentTypes[mediaType][contentType] {
+		return "", unsupportedType(field, []string{mediaType})
+	}
+
+	switch mediaType {
+	case "image":
+		err = s.checkDimensions(field, file)
+	case "video":
+		err = s.checkDuration(field, file, s.limits.MaxVideoDuration, CodeVideoTooLong, ErrVideoTooLong)
+	case "audio":
+		err = s.checkDuration(field, file, s.limits.MaxAudioDuration, CodeAudioTooLong, ErrAudioTooLong)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind %s: %w", field, err)
+	}
+	return mediaType, nil
+}
+
+func (s *MediaServiceImpl) checkDimensions(field string, file io.ReadSeeker) error {
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return &ValidationError{Field: field, Code: CodeInvalidMedia, err: ErrInvalidMedia}
+	}
+	limit := s.limits.MaxImageDimension
+	if limit > 0 && (config.Width > limit || config.Height > limit) {
+		return &ValidationError{Field: field, Code: CodeImageTooLarge, Limit: int64(limit), err: ErrImageTooLarge}
+	}
+	return nil
+}
+
+func (s *MediaServiceImpl) checkDuration(field string, file io.ReadSeeker, limit time.Duration, code string, tooLong error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	duration, err := s.prober.Duration(ctx, file)
+	if err != nil {
+		return &ValidationError{Field: field, Code: CodeInvalidMedia, err: ErrInvalidMedia}
+	}
+	if limit > 0 && duration > limit {
+		return &ValidationError{Field: field, Code: code, Limit: int64(limit.Seconds()), err: tooLong}
+	}
+	return nil
+}
+
+// sniffContentType detects the content type from the first bytes of the file.
+// MP4 containers are told apart by their major brand, since M4A audio is an MP4 file too.
+func sniffContentType(file io.ReadSeeker) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	header = header[:n]
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	if len(header) >= 12 && string(header[4:8]) == "ftyp" {
+		switch string(header[8:12]) {
+		case "M4A ", "M4B ":
+			return "audio/mp4", nil
+		case "qt  ":
+			return "video/quicktime", nil
+		}
+		return "video/mp4", nil
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(header), ";")
+	return contentType, nil
+}
+
+func unsupportedType(field string, mediaTypes []string) *ValidationError {
+	allowed := []string{}
+	for _, mediaType := range mediaTypes {
+		for contentType := range allowedContentTypes[mediaType] {
+			allowed = append(allowed, contentType)
+		}
+	}
+	sort.Strings(allowed)
+	return &ValidationError{Field: field, Code: CodeUnsupportedType, Allowed: allowed, err: ErrInvalidFileType}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+)
+
+// MaxAttachments limits the number of photos and videos in a message
+const MaxAttachments = 10
+
+// attachmentTypes are the media types that can be attached to messages
+var attachmentTypes = map[string]bool{
+	"image": true,
+	"video": true,
+}
+
+// MediaRepository looks up the media attached to messages
+type MediaRepository interface {
+	GetMediaByIDs(mediaIDs []int) ([]mediarepo.Media, error)
+}
+
+// URLSigner makes media URLs accessible for a limited time
+type URLSigner interface {
+	SignURL(url string) string
+}
+
+// SetURLSigner enables signed attachment URLs, for storage that is not public
+func (s *ServiceImpl) SetURLSigner(signer URLSigner) {
+	s.urlSigner = signer
+}
+
+// validateAttachments checks that the media exist, are photos or videos and were uploaded by the sender
+func (s *ServiceImpl) validateAttachments(senderID int, mediaIDs []int) error {
+	if len(mediaIDs) > MaxAttachments {
+		return errors.New(apierrors.ErrorInvalidAttachment)
+	}
+
+	seen := make(map[int]bool, len(mediaIDs))
+	for _, id := range mediaIDs {
+		if seen[id] {
+			return errors.New(apierrors.ErrorInvalidAttachment)
+		}
+		seen[id] = true
+	}
+
+	media, err := s.mediaRepo.GetMediaByIDs(mediaIDs)
+	if err != nil {
+		return err
+	}
+	if len(media) != len(mediaIDs) {
+		return errors.New(apierrors.ErrorInvalidAttachment)
+	}
+	for _, m := range media {
+		if m.UserID != senderID || !attachmentTypes[m.Role] {
+			return errors.New(apierrors.ErrorInvalidAttachment)
+		}
+	}
+	return nil
+}
+
+// signAttachments replaces the attachment URLs with signed ones when signing is enabled
+func (s *ServiceImpl) signAttachments(attachments []Attachment) []Attachment {
+	if s.urlSigner == nil {
+		return attachments
+	}
+	for i := range attachments {
+		attachments[i].URL = s.urlSigner.SignURL(attachments[i].URL)
+		attachments[i].ThumbnailURL = s.urlSigner.SignURL(attachments[i].ThumbnailURL)
+	}
+	return attachments
+}
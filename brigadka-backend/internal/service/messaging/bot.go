@@ -0,0 +1,121 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/google/uuid"
+)
+
+type ChatMessage = messaging.ChatMessage
+
+// DefaultBotLanguage is used when a conversation is started without a language
+const DefaultBotLanguage = "ru"
+
+// Bot is an automated participant of direct chats
+type Bot interface {
+	// UserID is the system user the bot writes as
+	UserID() int
+	// Name is shown as the chat name of bot conversations
+	Name() string
+	// Greeting returns the messages that open a conversation
+	Greeting(lang string) []string
+	// Reply returns answers to a user message, nil when the bot stays silent
+	Reply(content string, lang string) []string
+}
+
+// MessageListener delivers messages created by the server to chat participants
+type MessageListener interface {
+	MessagePosted(msg ChatMessage)
+}
+
+// MessageObserver is notified about every message sent by chat participants
+type MessageObserver interface {
+	MessageAdded(msg ChatMessage)
+}
+
+// SetBot enables bot conversations
+func (s *ServiceImpl) SetBot(bot Bot) {
+	s.bot = bot
+}
+
+// SetMessageListener enables real-time delivery of bot and system messages
+func (s *ServiceImpl) SetMessageListener(listener MessageListener) {
+	s.messageListener = listener
+}
+
+// SetMessageObserver enables notifications about new messages, e.g. bot webhooks
+func (s *ServiceImpl) SetMessageObserver(observer MessageObserver) {
+	s.messageObserver = observer
+}
+
+// StartBotConversation opens a direct chat between the user and the bot and sends the greeting
+func (s *ServiceImpl) StartBotConversation(ctx context.Context, userID int, lang string) error {
+	if s.bot == nil {
+		return nil
+	}
+	if lang == "" {
+		lang = DefaultBotLanguage
+	}
+
+	chatID, err := s.messagingRepo.GetOrCreateDirectChat(ctx, s.bot.UserID(), userID, false)
+	if err != nil {
+		return err
+	}
+
+	// The language is kept so that later replies match the greeting
+	if err := s.messagingRepo.SaveBotConversation(ctx, chatID, userID, lang); err != nil {
+		return err
+	}
+
+	s.postBotMessages(chatID, s.bot.Greeting(lang))
+	return nil
+}
+
+// handleBotMessage lets the bot answer a message sent to one of its conversations
+func (s *ServiceImpl) handleBotMessage(chatID string, senderID int, content string) {
+	if senderID == s.bot.UserID() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lang, err := s.messagingRepo.GetBotConversationLang(ctx, chatID)
+	if err != nil {
+		log.Printf("Failed to get bot conversation for chat %s: %v", chatID, err)
+		return
+	}
+	if lang == "" {
+		// Not a bot conversation
+		return
+	}
+
+	s.postBotMessages(chatID, s.bot.Reply(content, lang))
+}
+
+// postBotMessages stores messages from the bot and hands them to the listener
+func (s *ServiceImpl) postBotMessages(chatID string, contents []string) {
+	for _, content := range contents {
+		messageID := uuid.New().String()
+		sentAt, seq, err := s.messagingRepo.AddMessage(messageID, chatID, s.bot.UserID(), content)
+		if err != nil {
+			log.Printf("Failed to post bot message to chat %s: %v", chatID, err)
+			return
+		}
+
+		if s.messageListener != nil {
+			s.messageListener.MessagePosted(ChatMessage{
+				MessageID: messageID,
+				ChatID:    chatID,
+				SenderID:  s.bot.UserID(),
+				Content:   content,
+				SentAt:    sentAt,
+				Seq:       seq,
+				Kind:      messaging.MessageKindUser,
+			})
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// DefaultDedupeWindow is how long sent messages are remembered to recognize resends
+const DefaultDedupeWindow = 5 * time.Minute
+
+// DuplicateMessageError is returned when the sender already sent a message with the ID,
+// e.g. a client resending after a reconnect. Message is the stored one, to acknowledge again.
+type DuplicateMessageError struct {
+	Message *messaging.ChatMessage
+}
+
+func (e *DuplicateMessageError) Error() string {
+	return "message " + e.Message.MessageID + " was already sent"
+}
+
+// sentKey identifies a message of a sender
+type sentKey struct {
+	senderID  int
+	messageID string
+}
+
+type sentMessage struct {
+	msg    messaging.ChatMessage
+	sentAt time.Time
+}
+
+// sentMessageCache remembers recently stored messages, so that resends are answered
+// without hitting the database. It is local to the instance; resends reaching another
+// replica are recognized by the unique message ID in the database.
+type sentMessageCache struct {
+	mu        sync.Mutex
+	window    time.Duration
+	messages  map[sentKey]sentMessage
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newSentMessageCache(window time.Duration) *sentMessageCache {
+	return &sentMessageCache{
+		window:   window,
+		messages: make(map[sentKey]sentMessage),
+		now:      time.Now,
+	}
+}
+
+// get returns a copy of a message the sender stored within the window
+func (c *sentMessageCache) get(senderID int, messageID string) *messaging.ChatMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sent, ok := c.messages[sentKey{senderID: senderID, messageID: messageID}]
+	if !ok || c.now().Sub(sent.sentAt) >= c.window {
+		return nil
+	}
+	msg := sent.msg
+	return &msg
+}
+
+// add remembers a stored message
+func (c *sentMessageCache) add(msg *messaging.ChatMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.sweep(now)
+	c.messages[sentKey{senderID: msg.SenderID, messageID: msg.MessageID}] = sentMessage{msg: *msg, sentAt: now}
+}
+
+// sweep forgets the messages older than the window, once per window
+func (c *sentMessageCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.window {
+		return
+	}
+	c.lastSweep = now
+	for key, sent := range c.messages {
+		if now.Sub(sent.sentAt) >= c.window {
+			delete(c.messages, key)
+		}
+	}
+}
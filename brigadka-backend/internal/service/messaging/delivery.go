@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// StoreDeliveryReceipt records that a device of the user received a direct chat up to
+// the message. It reports whether the delivery position moved, so that the sender is
+// told only once; receipts in group chats are ignored.
+func (s *ServiceImpl) StoreDeliveryReceipt(ctx context.Context, userID int, chatID string, messageID string) (bool, error) {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return false, err
+	}
+	if !inChat {
+		return false, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	return s.messagingRepo.StoreDeliveryReceipt(ctx, userID, chatID, messageID)
+}
+
+// setMessageStatuses sets the delivery status of the user's own messages in a direct chat
+func (s *ServiceImpl) setMessageStatuses(ctx context.Context, chatID string, userID int, messages []messaging.ChatMessage) error {
+	state, err := s.messagingRepo.GetDeliveryState(ctx, chatID, userID)
+	if err != nil || state == nil {
+		return err
+	}
+	for i := range messages {
+		if messages[i].SenderID == userID && messages[i].Kind != messaging.MessageKindSystem {
+			messages[i].Status = state.Status(messages[i].Seq)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// MaxCiphertextLength caps the size of an end-to-end encrypted message
+const MaxCiphertextLength = 65536
+
+// AddEncryptedMessage adds an end-to-end encrypted message to a direct chat.
+// The server stores and forwards the ciphertext without reading it, so there is
+// no link preview and bots are not notified. Fails like AddMessageWithAttachments.
+func (s *ServiceImpl) AddEncryptedMessage(ctx context.Context, messageID string, chatID string, senderID int, ciphertext string) (*messaging.ChatMessage, error) {
+	if stored := s.sentMessages.get(senderID, messageID); stored != nil {
+		return nil, &DuplicateMessageError{Message: stored}
+	}
+
+	inChat, err := s.IsUserInChat(senderID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	if ciphertext == "" || len(ciphertext) > MaxCiphertextLength {
+		return nil, errors.New(apierrors.ErrorInvalidCiphertext)
+	}
+
+	// Group chats have no shared keys, their members could not read the message
+	size, err := s.messagingRepo.GetChatSize(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if size.IsGroup {
+		return nil, errors.New(apierrors.ErrorEncryptedGroupChat)
+	}
+
+	if err := s.messageLimiter.allow(senderID, chatID); err != nil {
+		return nil, err
+	}
+
+	msg := &messaging.ChatMessage{
+		MessageID:  messageID,
+		ChatID:     chatID,
+		SenderID:   senderID,
+		Ciphertext: ciphertext,
+		Kind:       messaging.MessageKindUser,
+	}
+	msg.SentAt, msg.Seq, err = s.messagingRepo.AddEncryptedMessage(ctx, messageID, chatID, senderID, ciphertext)
+	if err != nil {
+		return nil, s.duplicateOf(senderID, messageID, err)
+	}
+
+	s.sentMessages.add(msg)
+	return msg, nil
+}
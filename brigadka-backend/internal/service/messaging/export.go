@@ -0,0 +1,101 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// exportPageSize is the number of messages loaded at a time while exporting a chat
+const exportPageSize = 500
+
+// ExportedMessage is a chat message in an export, with the sender's name at the time of the export
+type ExportedMessage struct {
+	ChatMessage
+	SenderName string `json:"sender_name"` // Empty when the sender's account was deleted
+}
+
+// ChatExportWriter receives an exported chat: the chat first, then its messages
+// oldest first, a page at a time
+type ChatExportWriter interface {
+	WriteChat(chat *Chat) error
+	WriteMessages(messages []ExportedMessage) error
+}
+
+// ExportChat writes the chat and all its messages to w for a participant.
+// Messages are loaded page by page, so long chats are not held in memory.
+func (s *ServiceImpl) ExportChat(ctx context.Context, userID int, chatID string, w ChatExportWriter) error {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !inChat {
+		return errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	chat, err := s.GetChat(chatID, userID)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteChat(chat); err != nil {
+		return err
+	}
+
+	names := make(map[int]string)
+	var afterSeq int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		messages, err := s.messagingRepo.GetChatMessagesAfter(ctx, chatID, afterSeq, exportPageSize)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		exported := make([]ExportedMessage, len(messages))
+		for i, msg := range messages {
+			msg.Attachments = s.signAttachments(msg.Attachments)
+			name, err := s.senderName(names, msg.SenderID)
+			if err != nil {
+				return err
+			}
+			exported[i] = ExportedMessage{ChatMessage: msg, SenderName: name}
+		}
+		if err := w.WriteMessages(exported); err != nil {
+			return err
+		}
+
+		if len(messages) < exportPageSize {
+			return nil
+		}
+		afterSeq = messages[len(messages)-1].Seq
+	}
+}
+
+// senderName returns the name of a message sender, looking each sender up once per export
+func (s *ServiceImpl) senderName(names map[int]string, senderID int) (string, error) {
+	if name, ok := names[senderID]; ok {
+		return name, nil
+	}
+
+	var name string
+	if s.bot != nil && senderID == s.bot.UserID() {
+		name = s.bot.Name()
+	} else {
+		sender, err := s.profileRepo.GetProfile(senderID)
+		if err != nil && !errors.Is(err, profile.ErrProfileNotExists) {
+			return "", err
+		}
+		if sender != nil {
+			name = sender.FullName
+		}
+	}
+	names[senderID] = name
+	return name, nil
+}
@@ -0,0 +1,75 @@
+package messaging
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// Default group chat size limits
+const (
+	DefaultMaxGroupParticipants         = 50
+	DefaultMaxGroupParticipantsVerified = 200
+)
+
+// GroupLimits caps the number of participants in group chats.
+// Chats created by verified organizers get the larger limit. Zero disables a limit.
+type GroupLimits struct {
+	MaxParticipants         int
+	MaxParticipantsVerified int
+}
+
+// ParticipantLimitError is returned when adding participants would exceed the group chat limit
+type ParticipantLimitError struct {
+	Limit        int // Maximum number of participants in the chat
+	Participants int // Number of participants the chat would have
+}
+
+func (e *ParticipantLimitError) Error() string {
+	return fmt.Sprintf("group chat participant limit reached: %d of %d", e.Participants, e.Limit)
+}
+
+// SetGroupLimits replaces the default group chat size limits
+func (s *ServiceImpl) SetGroupLimits(limits GroupLimits) {
+	s.groupLimits = limits
+}
+
+// GroupLimits returns the group chat size limits in effect
+func (s *ServiceImpl) GroupLimits() GroupLimits {
+	return s.groupLimits
+}
+
+func (s *ServiceImpl) groupLimit(creatorVerified bool) int {
+	if creatorVerified {
+		return s.groupLimits.MaxParticipantsVerified
+	}
+	return s.groupLimits.MaxParticipants
+}
+
+// checkGroupSize fails with *ParticipantLimitError when the group would grow past its limit
+func (s *ServiceImpl) checkGroupSize(participants int, creatorVerified bool) error {
+	limit := s.groupLimit(creatorVerified)
+	if limit > 0 && participants > limit {
+		return &ParticipantLimitError{Limit: limit, Participants: participants}
+	}
+	return nil
+}
+
+// checkNewGroupSize checks the size of a group about to be created by creatorID
+func (s *ServiceImpl) checkNewGroupSize(creatorID int, participants []int) error {
+	members := map[int]struct{}{creatorID: {}}
+	for _, id := range participants {
+		members[id] = struct{}{}
+	}
+
+	// The creator's profile only matters when the group does not fit the regular limit
+	if s.checkGroupSize(len(members), false) == nil {
+		return nil
+	}
+	creator, err := s.profileRepo.GetProfile(creatorID)
+	if err != nil && !errors.Is(err, profile.ErrProfileNotExists) {
+		return err
+	}
+	return s.checkGroupSize(len(members), creator != nil && creator.IsVerified)
+}
@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// Limits matching the chats columns
+const (
+	maxChatNameLength        = 255
+	maxChatDescriptionLength = 1000
+)
+
+// UpdateChat changes the name, description and avatar of a group chat on behalf of an admin
+// and returns the updated chat. An empty description or a zero avatar ID removes them.
+func (s *ServiceImpl) UpdateChat(ctx context.Context, adminID int, chatID string, update ChatUpdate) (*messaging.Chat, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxChatNameLength {
+			return nil, errors.New(apierrors.ErrorInvalidChatName)
+		}
+		update.Name = &name
+	}
+	if update.Description != nil {
+		description := strings.TrimSpace(*update.Description)
+		if utf8.RuneCountInString(description) > maxChatDescriptionLength {
+			return nil, errors.New(apierrors.ErrorInvalidChatDescription)
+		}
+		update.Description = &description
+	}
+
+	if err := s.requireGroupRole(ctx, adminID, chatID, true); err != nil {
+		return nil, err
+	}
+	if update.AvatarMediaID != nil && *update.AvatarMediaID != 0 {
+		if err := s.validateChatAvatar(adminID, *update.AvatarMediaID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.messagingRepo.UpdateChat(ctx, chatID, update); err != nil {
+		return nil, err
+	}
+	if update.Name != nil {
+		s.postSystemMessage(ctx, chatID, adminID, messaging.SystemEventChatRenamed, nil)
+	}
+	if update.Description != nil || update.AvatarMediaID != nil {
+		s.postSystemMessage(ctx, chatID, adminID, messaging.SystemEventChatUpdated, nil)
+	}
+	return s.GetChat(chatID, adminID)
+}
+
+// validateChatAvatar checks that the avatar is an image uploaded by the admin
+func (s *ServiceImpl) validateChatAvatar(adminID int, mediaID int) error {
+	media, err := s.mediaRepo.GetMediaByIDs([]int{mediaID})
+	if err != nil {
+		return err
+	}
+	if len(media) != 1 || media[0].UserID != adminID || media[0].Role != "image" {
+		return errors.New(apierrors.ErrorInvalidChatAvatar)
+	}
+	return nil
+}
+
+// signChatAvatar replaces the avatar URLs with signed ones when signing is enabled
+func (s *ServiceImpl) signChatAvatar(avatar *messaging.ChatAvatar) *messaging.ChatAvatar {
+	if avatar == nil || s.urlSigner == nil {
+		return avatar
+	}
+	signed := *avatar
+	signed.URL = s.urlSigner.SignURL(avatar.URL)
+	signed.ThumbnailURL = s.urlSigner.SignURL(avatar.ThumbnailURL)
+	return &signed
+}
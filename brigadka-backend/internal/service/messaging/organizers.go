@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+)
+
+// isOrganizer reports whether the user is an organizer. The role is read from the
+// database rather than taken from the access token, which outlives role changes.
+func (s *ServiceImpl) isOrganizer(ctx context.Context, userID int) (bool, error) {
+	role, err := s.messagingRepo.GetUserRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return role == auth.RoleOrganizer, nil
+}
+
+// checkOrganizerContact fails when some of the users brought into a chat by an organizer
+// have not allowed contact from organizers. The organizer themself is skipped.
+func (s *ServiceImpl) checkOrganizerContact(ctx context.Context, organizerID int, userIDs []int) error {
+	others := make([]int, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != organizerID {
+			others = append(others, id)
+		}
+	}
+	closed, err := s.messagingRepo.GetUsersClosedToOrganizers(ctx, others)
+	if err != nil {
+		return err
+	}
+	if len(closed) > 0 {
+		return errors.New(apierrors.ErrorOrganizerContactNotAllowed)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/linkpreview"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+type LinkPreview = messaging.LinkPreview
+
+const (
+	// previewQueueSize is how many messages can wait for their link preview;
+	// previews of messages sent while the queue is full are skipped
+	previewQueueSize = 256
+	// previewFetchTimeout bounds the work on one preview, including storing it
+	previewFetchTimeout = 15 * time.Second
+)
+
+// PreviewFetcher loads the preview of a link; nil means the page has nothing to show
+type PreviewFetcher interface {
+	Fetch(ctx context.Context, url string) (*linkpreview.Preview, error)
+}
+
+// PreviewListener is told when the link preview of a message is stored
+type PreviewListener interface {
+	PreviewReady(chatID string, messageID string, preview LinkPreview)
+}
+
+// previewJob is a message waiting for the preview of its first link
+type previewJob struct {
+	chatID    string
+	messageID string
+	url       string
+}
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+// firstLink returns the first http(s) link in a message, without trailing punctuation
+func firstLink(content string) string {
+	link := linkPattern.FindString(content)
+	return strings.TrimRight(link, ".,;:!?)]}»")
+}
+
+// EnableLinkPreviews starts workers that fetch the first link of new messages and
+// store its preview. The listener is told about every stored preview.
+func (s *ServiceImpl) EnableLinkPreviews(ctx context.Context, fetcher PreviewFetcher, listener PreviewListener, workers int) {
+	s.previewQueue = make(chan previewJob, previewQueueSize)
+	for i := 0; i < workers; i++ {
+		go s.runPreviewWorker(ctx, fetcher, listener)
+	}
+}
+
+// queueLinkPreview schedules the preview of the first link in a stored message
+func (s *ServiceImpl) queueLinkPreview(msg *ChatMessage) {
+	if s.previewQueue == nil {
+		return
+	}
+	link := firstLink(msg.Content)
+	if link == "" {
+		return
+	}
+
+	select {
+	case s.previewQueue <- previewJob{chatID: msg.ChatID, messageID: msg.MessageID, url: link}:
+	default:
+		log.Printf("Link preview queue is full, skipping preview of message %s", msg.MessageID)
+	}
+}
+
+func (s *ServiceImpl) runPreviewWorker(ctx context.Context, fetcher PreviewFetcher, listener PreviewListener) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.previewQueue:
+			if err := s.buildPreview(ctx, fetcher, listener, job); err != nil {
+				log.Printf("Failed to build link preview of message %s: %v", job.messageID, err)
+			}
+		}
+	}
+}
+
+// buildPreview fetches the link of a message, stores the preview and notifies the listener
+func (s *ServiceImpl) buildPreview(ctx context.Context, fetcher PreviewFetcher, listener PreviewListener, job previewJob) error {
+	ctx, cancel := context.WithTimeout(ctx, previewFetchTimeout)
+	defer cancel()
+
+	fetched, err := fetcher.Fetch(ctx, job.url)
+	if err != nil || fetched == nil {
+		return err
+	}
+
+	preview := LinkPreview{
+		URL:         fetched.URL,
+		Title:       fetched.Title,
+		Description: fetched.Description,
+		ImageURL:    fetched.ImageURL,
+		SiteName:    fetched.SiteName,
+	}
+	if err := s.messagingRepo.SaveMessagePreview(ctx, job.messageID, preview); err != nil {
+		return err
+	}
+	if listener != nil {
+		listener.PreviewReady(job.chatID, job.messageID, preview)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default limit of messages a user can send to one chat
+const (
+	DefaultMessageRateLimit  = 20
+	DefaultMessageRateWindow = 10 * time.Second
+)
+
+// MessageRateLimit caps the number of messages a user can send to one chat within a window.
+// Zero Messages disables the limit.
+type MessageRateLimit struct {
+	Messages int
+	Window   time.Duration
+}
+
+// RateLimitError is returned when the sender exceeded the message rate limit of the chat
+type RateLimitError struct {
+	RetryAfter time.Duration // Time until the next message is accepted
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("message rate limit reached, retry after %s", e.RetryAfter)
+}
+
+// rateKey identifies the messages of a sender in a chat
+type rateKey struct {
+	userID int
+	chatID string
+}
+
+// messageLimiter keeps the send times within the window per sender and chat.
+// The counts are local to the instance, so with several replicas a sender
+// gets the limit on each replica it reaches.
+type messageLimiter struct {
+	mu        sync.Mutex
+	limit     MessageRateLimit
+	sent      map[rateKey][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newMessageLimiter(limit MessageRateLimit) *messageLimiter {
+	return &messageLimiter{
+		limit: limit,
+		sent:  make(map[rateKey][]time.Time),
+		now:   time.Now,
+	}
+}
+
+// allow records a message of userID in chatID, or fails with *RateLimitError
+// when the sender already sent the maximum number of messages within the window
+func (l *messageLimiter) allow(userID int, chatID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit.Messages <= 0 {
+		return nil
+	}
+
+	now := l.now()
+	since := now.Add(-l.limit.Window)
+	l.sweep(now, since)
+
+	key := rateKey{userID: userID, chatID: chatID}
+	sent := dropBefore(l.sent[key], since)
+	if len(sent) >= l.limit.Messages {
+		l.sent[key] = sent
+		return &RateLimitError{RetryAfter: sent[0].Add(l.limit.Window).Sub(now)}
+	}
+	l.sent[key] = append(sent, now)
+	return nil
+}
+
+// sweep forgets senders that sent nothing within the window, once per window
+func (l *messageLimiter) sweep(now, since time.Time) {
+	if now.Sub(l.lastSweep) < l.limit.Window {
+		return
+	}
+	l.lastSweep = now
+	for key, sent := range l.sent {
+		if len(dropBefore(sent, since)) == 0 {
+			delete(l.sent, key)
+		}
+	}
+}
+
+// dropBefore removes the send times before since; times are in ascending order
+func dropBefore(sent []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(sent) && !sent[i].After(since) {
+		i++
+	}
+	return sent[i:]
+}
+
+// SetMessageRateLimit replaces the default message rate limit
+func (s *ServiceImpl) SetMessageRateLimit(limit MessageRateLimit) {
+	s.messageLimiter = newMessageLimiter(limit)
+}
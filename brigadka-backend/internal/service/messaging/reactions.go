@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+type ReactionSummary = messaging.ReactionSummary
+
+type Reaction = messaging.Reaction
+
+// GetMessageReactions returns everyone who reacted to a message. Messages of chats the
+// user is not in are reported as not found.
+func (s *ServiceImpl) GetMessageReactions(ctx context.Context, userID int, messageID string) ([]Reaction, error) {
+	chatID, err := s.GetChatIDForMessage(messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(apierrors.ErrorMessageNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorMessageNotFound)
+	}
+
+	return s.messagingRepo.GetMessageReactions(ctx, messageID)
+}
+
+// setMessageReactions attaches the aggregated reactions to the messages of a history page
+func (s *ServiceImpl) setMessageReactions(ctx context.Context, userID int, messages []messaging.ChatMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.MessageID
+	}
+
+	summaries, err := s.messagingRepo.GetReactionSummaries(ctx, ids, userID)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		messages[i].Reactions = summaries[messages[i].MessageID]
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+type DirectMessageSettings = messaging.DirectMessageSettings
+
+// GetChatRequests returns the chats from strangers the user has not accepted yet
+func (s *ServiceImpl) GetChatRequests(userID int) ([]messaging.Chat, error) {
+	rawChats, err := s.messagingRepo.GetChatRequests(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.nameChats(rawChats, userID), nil
+}
+
+// AcceptChatRequest moves a chat request to the user's chats; from then on it sends push notifications
+func (s *ServiceImpl) AcceptChatRequest(ctx context.Context, userID int, chatID string) error {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+	return s.messagingRepo.AcceptChatRequest(ctx, chatID, userID)
+}
+
+// GetDirectMessageSettings returns who can start direct chats with the user
+func (s *ServiceImpl) GetDirectMessageSettings(ctx context.Context, userID int) (*messaging.DirectMessageSettings, error) {
+	return s.messagingRepo.GetDirectMessageSettings(ctx, userID)
+}
+
+// UpdateDirectMessageSettings changes who can start direct chats with the user.
+// Existing chats are not affected.
+func (s *ServiceImpl) UpdateDirectMessageSettings(ctx context.Context, userID int, settings messaging.DirectMessageSettings) error {
+	if settings.Policy != messaging.DirectMessagePolicyEveryone && settings.Policy != messaging.DirectMessagePolicyFiltered {
+		return errors.New(apierrors.ErrorInvalidDirectMessagePolicy)
+	}
+	return s.messagingRepo.SaveDirectMessageSettings(ctx, userID, settings)
+}
+
+// requestRecipients returns the users a chat started by senderID is a request for,
+// those whose direct message settings do not allow the sender
+func (s *ServiceImpl) requestRecipients(ctx context.Context, senderID int, userIDs []int) ([]int, error) {
+	var requests []int
+	for _, userID := range userIDs {
+		if userID == senderID {
+			continue
+		}
+		allowed, err := s.messagingRepo.IsDirectMessageAllowed(ctx, userID, senderID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			requests = append(requests, userID)
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// noProfiles answers as if no user had filled in a profile
+type noProfiles struct{}
+
+func (noProfiles) GetProfile(userID int) (*profile.ProfileModel, error) {
+	return nil, profile.ErrProfileNotExists
+}
+
+// newRequestsRepository returns a repository where user 3 filters strangers out
+// and everyone else accepts chats from everyone
+func newRequestsRepository() *MessagingRepositoryMock {
+	return &MessagingRepositoryMock{
+		GetUserRoleFunc: func(ctx context.Context, userID int) (string, error) {
+			return "", nil
+		},
+		IsDirectMessageAllowedFunc: func(ctx context.Context, recipientID int, senderID int) (bool, error) {
+			return recipientID != 3, nil
+		},
+		GetChatSizeFunc: func(chatID string) (*messaging.ChatSize, error) {
+			return &messaging.ChatSize{IsGroup: true, Participants: 2}, nil
+		},
+		CreateChatFunc: func(ctx context.Context, chatID string, creatorID int, chatName string, participants []int, requests []int) error {
+			return nil
+		},
+		AddParticipantFunc: func(chatID string, userID int, asRequest bool) error {
+			return nil
+		},
+		AddSystemMessageFunc: func(ctx context.Context, msg messaging.ChatMessage) (time.Time, int64, error) {
+			return time.Now(), 1, nil
+		},
+	}
+}
+
+func TestCreateChatIsRequestForFilteredParticipants(t *testing.T) {
+	repo := newRequestsRepository()
+	s := NewService(repo, noProfiles{}, nil)
+
+	assert.NoError(t, s.CreateChat(context.Background(), "c1", 1, "Jam", []int{1, 2, 3}))
+
+	if calls := repo.CreateChatCalls(); assert.Len(t, calls, 1) {
+		assert.Equal(t, []int{3}, calls[0].Requests)
+	}
+	for _, call := range repo.IsDirectMessageAllowedCalls() {
+		assert.Equal(t, 1, call.SenderID)
+		assert.NotEqual(t, 1, call.RecipientID, "the creator is not asked")
+	}
+}
+
+func TestCreateMembersChatIgnoresDirectMessageSettings(t *testing.T) {
+	repo := newRequestsRepository()
+	s := NewService(repo, noProfiles{}, nil)
+
+	assert.NoError(t, s.CreateMembersChat(context.Background(), "c1", 1, "Team", []int{1, 2, 3}))
+
+	assert.Empty(t, repo.IsDirectMessageAllowedCalls())
+	if calls := repo.CreateChatCalls(); assert.Len(t, calls, 1) {
+		assert.Empty(t, calls[0].Requests)
+	}
+}
+
+func TestAddMemberIsRequestForFilteredUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int
+		wantRequest bool
+	}{
+		{"allows strangers", 2, false},
+		{"filters strangers", 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRequestsRepository()
+			s := NewService(repo, noProfiles{}, nil)
+
+			assert.NoError(t, s.AddMember(context.Background(), 1, "c1", tt.userID))
+
+			if calls := repo.AddParticipantCalls(); assert.Len(t, calls, 1) {
+				assert.Equal(t, tt.userID, calls[0].UserID)
+				assert.Equal(t, tt.wantRequest, calls[0].AsRequest)
+			}
+		})
+	}
+}
+
+func TestAddParticipantJoiningIsNeverRequest(t *testing.T) {
+	repo := newRequestsRepository()
+	s := NewService(repo, noProfiles{}, nil)
+
+	assert.NoError(t, s.AddParticipant("c1", 3))
+
+	assert.Empty(t, repo.IsDirectMessageAllowedCalls())
+	if calls := repo.AddParticipantCalls(); assert.Len(t, calls, 1) {
+		assert.False(t, calls[0].AsRequest)
+	}
+}
@@ -0,0 +1,65 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// MaxResumeMessages caps the messages replayed per chat when a client resumes.
+// Clients load the rest with the messages endpoint.
+const MaxResumeMessages = 200
+
+// ChatReplay holds what a client missed in a chat since the last message it saw
+type ChatReplay struct {
+	ChatID       string
+	Messages     []ChatMessage // Oldest first
+	Reactions    []messaging.ReactionEvent
+	ReadReceipts []messaging.ReadReceipt // Of the other participants
+	LastSeq      int64                   // Seq of the newest replayed message, or the one the client saw
+	Truncated    bool                    // More messages follow the replayed ones
+}
+
+// GetChatReplay returns the messages sent in a chat after afterSeq, with the reactions
+// and read receipts of the other participants since that message was sent
+func (s *ServiceImpl) GetChatReplay(ctx context.Context, userID int, chatID string, afterSeq int64) (*ChatReplay, error) {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	page, err := s.messagingRepo.GetChatMessagePage(chatID, messaging.MessageCursor{AfterSeq: afterSeq}, MaxResumeMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &ChatReplay{
+		ChatID:    chatID,
+		Messages:  make([]ChatMessage, 0, len(page.Messages)),
+		LastSeq:   afterSeq,
+		Truncated: len(page.Messages) == MaxResumeMessages,
+	}
+	// Pages are newest first
+	for i := len(page.Messages) - 1; i >= 0; i-- {
+		msg := page.Messages[i]
+		msg.Attachments = s.signAttachments(msg.Attachments)
+		replay.Messages = append(replay.Messages, msg)
+		replay.LastSeq = msg.Seq
+	}
+	if err := s.setMessageStatuses(ctx, chatID, userID, replay.Messages); err != nil {
+		return nil, err
+	}
+
+	if replay.Reactions, err = s.messagingRepo.GetReactionsSince(ctx, chatID, afterSeq); err != nil {
+		return nil, err
+	}
+	if replay.ReadReceipts, err = s.messagingRepo.GetReadReceiptsSince(ctx, chatID, afterSeq, userID); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
@@ -0,0 +1,85 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// LeaveChat removes the user from a group chat. When the last admin leaves,
+// the longest-standing participant becomes admin.
+func (s *ServiceImpl) LeaveChat(ctx context.Context, userID int, chatID string) error {
+	if err := s.requireGroupRole(ctx, userID, chatID, false); err != nil {
+		return err
+	}
+	if err := s.messagingRepo.RemoveParticipant(chatID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(ctx, chatID, userID, messaging.SystemEventParticipantLeft, nil)
+	return nil
+}
+
+// RemoveMember removes a participant from a group chat on behalf of an admin.
+// Participants removing themselves leave the chat.
+func (s *ServiceImpl) RemoveMember(ctx context.Context, adminID int, chatID string, userID int) error {
+	if adminID == userID {
+		return s.LeaveChat(ctx, userID, chatID)
+	}
+	if err := s.requireGroupRole(ctx, adminID, chatID, true); err != nil {
+		return err
+	}
+	if err := s.requireTargetParticipant(ctx, chatID, userID); err != nil {
+		return err
+	}
+	if err := s.messagingRepo.RemoveParticipant(chatID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(ctx, chatID, adminID, messaging.SystemEventParticipantRemoved, &userID)
+	return nil
+}
+
+// PromoteToAdmin makes a participant of a group chat an admin
+func (s *ServiceImpl) PromoteToAdmin(ctx context.Context, adminID int, chatID string, userID int) error {
+	if err := s.requireGroupRole(ctx, adminID, chatID, true); err != nil {
+		return err
+	}
+	err := s.messagingRepo.SetParticipantRole(ctx, chatID, userID, messaging.RoleAdmin)
+	if err != nil {
+		if err.Error() == apierrors.ErrorUserNotInChat {
+			return errors.New(apierrors.ErrorParticipantNotFound)
+		}
+		return err
+	}
+	s.postSystemMessage(ctx, chatID, adminID, messaging.SystemEventAdminPromoted, &userID)
+	return nil
+}
+
+// requireGroupRole checks that the user participates in a group chat, as an admin when admin is set
+func (s *ServiceImpl) requireGroupRole(ctx context.Context, userID int, chatID string, admin bool) error {
+	role, err := s.messagingRepo.GetParticipantRole(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	size, err := s.messagingRepo.GetChatSize(chatID)
+	if err != nil {
+		return err
+	}
+	if !size.IsGroup {
+		return errors.New(apierrors.ErrorNotGroupChat)
+	}
+	if admin && role != messaging.RoleAdmin {
+		return errors.New(apierrors.ErrorNotChatAdmin)
+	}
+	return nil
+}
+
+// requireTargetParticipant checks that the user an admin acts on participates in the chat
+func (s *ServiceImpl) requireTargetParticipant(ctx context.Context, chatID string, userID int) error {
+	_, err := s.messagingRepo.GetParticipantRole(ctx, chatID, userID)
+	if err != nil && err.Error() == apierrors.ErrorUserNotInChat {
+		return errors.New(apierrors.ErrorParticipantNotFound)
+	}
+	return err
+}
@@ -4,61 +4,126 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
 	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
 )
 
 type Chat = messaging.Chat
 
+type ReadState = messaging.ReadState
+
+type Attachment = messaging.Attachment
+
+type MessageCursor = messaging.MessageCursor
+
+type MessagePage = messaging.MessagePage
+
+type ChatUpdate = messaging.ChatUpdate
+
+type ChatAvatar = messaging.ChatAvatar
+
 // Service interface defines the messaging service operations
 type Service interface {
 	GetUserChats(userID int) ([]messaging.Chat, error)
 	GetChat(chatID string, userID int) (*messaging.Chat, error)
 	CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error
+	CreateMembersChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error
 	AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error)
+	AddMessageWithAttachments(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messaging.ChatMessage, error)
+	AddEncryptedMessage(ctx context.Context, messageID string, chatID string, senderID int, ciphertext string) (*messaging.ChatMessage, error)
+	GetChatReplay(ctx context.Context, userID int, chatID string, afterSeq int64) (*ChatReplay, error)
 	GetChatParticipants(chatID string) ([]int, error)
 	IsUserInChat(userID int, chatID string) (bool, error)
 	AddParticipant(chatID string, userID int) error
+	AddMember(ctx context.Context, actorID int, chatID string, userID int) error
 	RemoveParticipant(chatID string, userID int) error
 	AddReaction(reactionID string, messageID string, userID int, reactionCode string) error
 	RemoveReaction(messageID string, userID int, reactionCode string) error
 	GetChatIDForMessage(messageID string) (string, error)
+	GetMessageReactions(ctx context.Context, userID int, messageID string) ([]messaging.Reaction, error)
 	GetChatMessages(chatID string, userID int, limit, offset int) ([]messaging.ChatMessage, error)
+	GetChatMessagePage(chatID string, userID int, cursor messaging.MessageCursor, limit int) (*messaging.MessagePage, error)
 	StoreTypingIndicator(userID int, chatID string) error
-	StoreReadReceipt(userID int, chatID string, messageID string) error
+	StoreReadReceipt(userID int, chatID string, messageID string) (*messaging.ReadState, error)
+	MarkAllRead(ctx context.Context, userID int) ([]messaging.ReadState, error)
+	MarkChatRead(ctx context.Context, userID int, chatID string) ([]messaging.ReadState, error)
+	StoreDeliveryReceipt(ctx context.Context, userID int, chatID string, messageID string) (bool, error)
 	GetUserChatRooms(userID int) (map[string]struct{}, error)
 	GetChatParticipantsForBroadcast(chatID string) ([]int, error)
 	GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int) (string, error)
+	GetChatRequests(userID int) ([]messaging.Chat, error)
+	AcceptChatRequest(ctx context.Context, userID int, chatID string) error
+	GetDirectMessageSettings(ctx context.Context, userID int) (*messaging.DirectMessageSettings, error)
+	UpdateDirectMessageSettings(ctx context.Context, userID int, settings messaging.DirectMessageSettings) error
+	MuteChat(ctx context.Context, userID int, chatID string, until *time.Time) error
+	UnmuteChat(ctx context.Context, userID int, chatID string) error
+	ArchiveChat(ctx context.Context, userID int, chatID string) error
+	UnarchiveChat(ctx context.Context, userID int, chatID string) error
+	GetMutedParticipants(ctx context.Context, chatID string) (map[int]struct{}, error)
+	GetUnreadCounts(ctx context.Context, chatID string, userIDs []int) (map[int]messaging.UnreadCounts, error)
+	LeaveChat(ctx context.Context, userID int, chatID string) error
+	RemoveMember(ctx context.Context, adminID int, chatID string, userID int) error
+	PromoteToAdmin(ctx context.Context, adminID int, chatID string, userID int) error
+	UpdateChat(ctx context.Context, adminID int, chatID string, update ChatUpdate) (*messaging.Chat, error)
+	ExportChat(ctx context.Context, userID int, chatID string, w ChatExportWriter) error
 }
 
+//go:generate moq -out mocks_test.go ../../repository/messaging MessagingRepository
+
 type ProfileRepository interface {
 	GetProfile(userID int) (*profile.ProfileModel, error)
 }
 
 // ServiceImpl implements the messaging service
 type ServiceImpl struct {
-	messagingRepo messaging.MessagingRepository
-	profileRepo   ProfileRepository
+	messagingRepo   messaging.MessagingRepository
+	profileRepo     ProfileRepository
+	mediaRepo       MediaRepository
+	urlSigner       URLSigner       // Optional signing of attachment URLs
+	bot             Bot             // Optional bot answering in its direct chats
+	messageListener MessageListener // Optional delivery of bot messages
+	messageObserver MessageObserver // Optional notifications about new messages
+	groupLimits     GroupLimits
+	messageLimiter  *messageLimiter
+	sentMessages    *sentMessageCache
+	previewQueue    chan previewJob // Messages waiting for link previews, nil when previews are disabled
 }
 
 // NewService creates a new messaging service
-func NewService(messagingRepo messaging.MessagingRepository, profileRepo ProfileRepository) *ServiceImpl {
+func NewService(messagingRepo messaging.MessagingRepository, profileRepo ProfileRepository, mediaRepo MediaRepository) *ServiceImpl {
 	return &ServiceImpl{
 		messagingRepo: messagingRepo,
 		profileRepo:   profileRepo,
+		mediaRepo:     mediaRepo,
+		groupLimits: GroupLimits{
+			MaxParticipants:         DefaultMaxGroupParticipants,
+			MaxParticipantsVerified: DefaultMaxGroupParticipantsVerified,
+		},
+		messageLimiter: newMessageLimiter(MessageRateLimit{
+			Messages: DefaultMessageRateLimit,
+			Window:   DefaultMessageRateWindow,
+		}),
+		sentMessages: newSentMessageCache(DefaultDedupeWindow),
 	}
 }
 
-// GetUserChats retrieves all chats for a user
+// GetUserChats retrieves the chats of a user, except for the requests not accepted yet
 func (s *ServiceImpl) GetUserChats(userID int) ([]messaging.Chat, error) {
 	rawChats, err := s.messagingRepo.GetUserChats(userID)
 	if err != nil {
 		return nil, err
 	}
+	return s.nameChats(rawChats, userID), nil
+}
 
+// nameChats sets the names of a user's chats, skipping the ones whose name cannot be loaded
+func (s *ServiceImpl) nameChats(rawChats []messaging.Chat, userID int) []messaging.Chat {
 	var chats []messaging.Chat
 
 	// TODO: use batch query for profile retrieval
@@ -71,15 +136,24 @@ func (s *ServiceImpl) GetUserChats(userID int) ([]messaging.Chat, error) {
 		chats = append(chats, *chat)
 	}
 
-	return chats, nil
+	return chats
 }
 
 func (s *ServiceImpl) setChatName(chat *messaging.Chat, userID int) (*messaging.Chat, error) {
-	if chat == nil || chat.IsGroup {
+	if chat == nil {
+		return chat, nil
+	}
+	if chat.IsGroup {
+		chat.Avatar = s.signChatAvatar(chat.Avatar)
 		return chat, nil
 	}
 
 	for _, participant := range chat.Participants {
+		if s.bot != nil && participant == s.bot.UserID() {
+			name := s.bot.Name()
+			chat.ChatName = &name
+			continue
+		}
 		if participant != userID {
 			profile, err := s.profileRepo.GetProfile(participant)
 			if err != nil {
@@ -102,24 +176,158 @@ func (s *ServiceImpl) GetChat(chatID string, userID int) (*messaging.Chat, error
 	return s.setChatName(chat, userID)
 }
 
-// CreateChat creates a new chat with the specified participants
+// CreateChat creates a new chat with the specified participants. The chat is a request
+// for the participants whose direct message settings do not allow the creator.
+// Fails with *ParticipantLimitError when there are more participants than the group limit,
+// and when the creator is an organizer and a participant has not allowed organizer contact.
 func (s *ServiceImpl) CreateChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error {
-	return s.messagingRepo.CreateChat(ctx, chatID, creatorID, chatName, participants)
+	organizer, err := s.isOrganizer(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if organizer {
+		if err := s.checkOrganizerContact(ctx, creatorID, participants); err != nil {
+			return err
+		}
+	}
+	requests, err := s.requestRecipients(ctx, creatorID, participants)
+	if err != nil {
+		return err
+	}
+	return s.createGroup(ctx, chatID, creatorID, chatName, participants, requests)
 }
 
-// AddMessage adds a new message to a chat
+// CreateMembersChat creates a group chat for users who joined something together,
+// such as a team or a class. Unlike CreateChat it skips the organizer contact check
+// and direct message settings: the members chose to join, the creator did not pick them.
+func (s *ServiceImpl) CreateMembersChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error {
+	return s.createGroup(ctx, chatID, creatorID, chatName, participants, nil)
+}
+
+func (s *ServiceImpl) createGroup(ctx context.Context, chatID string, creatorID int, chatName string, participants []int, requests []int) error {
+	if err := s.checkNewGroupSize(creatorID, participants); err != nil {
+		return err
+	}
+	if err := s.messagingRepo.CreateChat(ctx, chatID, creatorID, chatName, participants, requests); err != nil {
+		return err
+	}
+	s.postSystemMessage(ctx, chatID, creatorID, messaging.SystemEventChatCreated, nil)
+	return nil
+}
+
+// AddMessage adds a new message to a chat. It posts reminders and bot messages
+// and is not rate limited.
 func (s *ServiceImpl) AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error) {
+	msg, err := s.addMessage(messageID, chatID, senderID, content, nil, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return msg.SentAt, nil
+}
+
+// AddMessageWithAttachments adds a new message with photos and videos uploaded by the sender.
+// The returned message carries the attachments that can be shown, with signed URLs.
+// Fails with *RateLimitError when the sender exceeded the message rate limit of the chat,
+// and with *DuplicateMessageError when the sender already sent a message with the ID.
+func (s *ServiceImpl) AddMessageWithAttachments(messageID string, chatID string, senderID int, content string, mediaIDs []int) (*messaging.ChatMessage, error) {
+	return s.addMessage(messageID, chatID, senderID, content, mediaIDs, true)
+}
+
+// addMessage stores a message; messages sent by users are rate limited,
+// unlike the ones the service posts on their behalf, such as reminders
+func (s *ServiceImpl) addMessage(messageID string, chatID string, senderID int, content string, mediaIDs []int, rateLimited bool) (*messaging.ChatMessage, error) {
+	// Resends are answered before the limits, they do not add a message
+	if stored := s.sentMessages.get(senderID, messageID); stored != nil {
+		return nil, &DuplicateMessageError{Message: stored}
+	}
+
 	// Check if user can send messages to this chat
 	inChat, err := s.IsUserInChat(senderID, chatID)
 	if err != nil {
-		return time.Time{}, err
+		return nil, err
 	}
 
 	if !inChat {
-		return time.Time{}, errors.New(apierrors.ErrorUserNotInChat)
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	if strings.TrimSpace(content) == "" && len(mediaIDs) == 0 {
+		return nil, errors.New(apierrors.ErrorEmptyMessage)
+	}
+
+	if rateLimited {
+		if err := s.messageLimiter.allow(senderID, chatID); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &messaging.ChatMessage{
+		MessageID: messageID,
+		ChatID:    chatID,
+		SenderID:  senderID,
+		Content:   content,
+		Kind:      messaging.MessageKindUser,
+	}
+	if len(mediaIDs) == 0 {
+		msg.SentAt, msg.Seq, err = s.messagingRepo.AddMessage(messageID, chatID, senderID, content)
+		if err != nil {
+			return nil, s.duplicateOf(senderID, messageID, err)
+		}
+	} else {
+		if err := s.validateAttachments(senderID, mediaIDs); err != nil {
+			return nil, err
+		}
+		msg.SentAt, msg.Seq, err = s.messagingRepo.AddMessageWithAttachments(messageID, chatID, senderID, content, mediaIDs)
+		if err != nil {
+			return nil, s.duplicateOf(senderID, messageID, err)
+		}
+
+		// Media waiting for moderation are stored but shown only once approved
+		attachments, err := s.messagingRepo.GetMessageAttachments([]string{messageID})
+		if err != nil {
+			log.Printf("Error loading attachments of message %s: %v", messageID, err)
+		}
+		msg.Attachments = s.signAttachments(attachments[messageID])
+	}
+
+	s.sentMessages.add(msg)
+	s.queueLinkPreview(msg)
+
+	// The bot answers after the message has been delivered
+	if s.bot != nil {
+		go s.handleBotMessage(chatID, senderID, content)
+	}
+
+	if s.messageObserver != nil {
+		go s.messageObserver.MessageAdded(*msg)
 	}
 
-	return s.messagingRepo.AddMessage(messageID, chatID, senderID, content)
+	return msg, nil
+}
+
+// duplicateOf turns the unique violation of a resent message ID into *DuplicateMessageError,
+// for resends the cache no longer remembers or that reached another replica.
+// IDs taken by other senders keep the unique violation.
+func (s *ServiceImpl) duplicateOf(senderID int, messageID string, err error) error {
+	if !database.IsUniqueViolation(err) {
+		return err
+	}
+	stored, getErr := s.messagingRepo.GetMessage(context.Background(), messageID)
+	if getErr != nil {
+		if getErr.Error() != apierrors.ErrorMessageNotFound {
+			log.Printf("Error fetching duplicate message %s: %v", messageID, getErr)
+		}
+		return err
+	}
+	if stored.SenderID != senderID {
+		return err
+	}
+	attachments, getErr := s.messagingRepo.GetMessageAttachments([]string{messageID})
+	if getErr != nil {
+		log.Printf("Error loading attachments of message %s: %v", messageID, getErr)
+	}
+	stored.Attachments = s.signAttachments(attachments[messageID])
+	return &DuplicateMessageError{Message: stored}
 }
 
 // GetChatParticipants retrieves all participants in a chat
@@ -132,14 +340,68 @@ func (s *ServiceImpl) IsUserInChat(userID int, chatID string) (bool, error) {
 	return s.messagingRepo.IsUserInChat(userID, chatID)
 }
 
-// AddParticipant adds a user to a chat
+// AddParticipant adds a user who joins a chat, e.g. by joining its team.
+// Fails with *ParticipantLimitError when the group chat is full.
 func (s *ServiceImpl) AddParticipant(chatID string, userID int) error {
-	return s.messagingRepo.AddParticipant(chatID, userID)
+	if err := s.addParticipant(context.Background(), userID, chatID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(context.Background(), chatID, userID, messaging.SystemEventParticipantJoined, nil)
+	return nil
+}
+
+// AddMember adds a user to a chat on behalf of a participant.
+// Fails with *ParticipantLimitError when the group chat is full.
+func (s *ServiceImpl) AddMember(ctx context.Context, actorID int, chatID string, userID int) error {
+	if err := s.addParticipant(ctx, actorID, chatID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(ctx, chatID, actorID, messaging.SystemEventParticipantAdded, &userID)
+	return nil
 }
 
-// RemoveParticipant removes a user from a chat
+func (s *ServiceImpl) addParticipant(ctx context.Context, actorID int, chatID string, userID int) error {
+	size, err := s.messagingRepo.GetChatSize(chatID)
+	if err != nil {
+		return err
+	}
+	// Users joining on their own need no consent; users added by an organizer
+	// or into a chat an organizer started do, and the chat is a request for users
+	// whose direct message settings do not allow the one who added them
+	asRequest := false
+	if actorID != userID {
+		organizer := size.CreatorRole == auth.RoleOrganizer
+		if !organizer {
+			if organizer, err = s.isOrganizer(ctx, actorID); err != nil {
+				return err
+			}
+		}
+		if organizer {
+			if err := s.checkOrganizerContact(ctx, actorID, []int{userID}); err != nil {
+				return err
+			}
+		}
+		allowed, err := s.messagingRepo.IsDirectMessageAllowed(ctx, userID, actorID)
+		if err != nil {
+			return err
+		}
+		asRequest = !allowed
+	}
+	if size.IsGroup {
+		if err := s.checkGroupSize(size.Participants+1, size.CreatorVerified); err != nil {
+			return err
+		}
+	}
+	return s.messagingRepo.AddParticipant(chatID, userID, asRequest)
+}
+
+// RemoveParticipant removes a user who leaves a chat, e.g. by leaving its team
 func (s *ServiceImpl) RemoveParticipant(chatID string, userID int) error {
-	return s.messagingRepo.RemoveParticipant(chatID, userID)
+	if err := s.messagingRepo.RemoveParticipant(chatID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(context.Background(), chatID, userID, messaging.SystemEventParticipantLeft, nil)
+	return nil
 }
 
 // AddReaction adds a reaction to a message
@@ -174,6 +436,8 @@ func (s *ServiceImpl) GetChatIDForMessage(messageID string) (string, error) {
 }
 
 // GetChatMessages retrieves messages for a chat with pagination
+//
+// Deprecated: kept for clients paging by offset, use GetChatMessagePage.
 func (s *ServiceImpl) GetChatMessages(chatID string, userID int, limit, offset int) ([]messaging.ChatMessage, error) {
 	// Check if user is in chat
 	inChat, err := s.IsUserInChat(userID, chatID)
@@ -185,7 +449,47 @@ func (s *ServiceImpl) GetChatMessages(chatID string, userID int, limit, offset i
 		return nil, errors.New(apierrors.ErrorUserNotInChat)
 	}
 
-	return s.messagingRepo.GetChatMessages(chatID, userID, limit, offset)
+	messages, err := s.messagingRepo.GetChatMessages(chatID, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		messages[i].Attachments = s.signAttachments(messages[i].Attachments)
+	}
+	if err := s.setMessageStatuses(context.Background(), chatID, userID, messages); err != nil {
+		return nil, err
+	}
+	if err := s.setMessageReactions(context.Background(), userID, messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetChatMessagePage retrieves a page of chat messages around the cursor
+func (s *ServiceImpl) GetChatMessagePage(chatID string, userID int, cursor messaging.MessageCursor, limit int) (*messaging.MessagePage, error) {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	page, err := s.messagingRepo.GetChatMessagePage(chatID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range page.Messages {
+		page.Messages[i].Attachments = s.signAttachments(page.Messages[i].Attachments)
+	}
+	if err := s.setMessageStatuses(context.Background(), chatID, userID, page.Messages); err != nil {
+		return nil, err
+	}
+	if err := s.setMessageReactions(context.Background(), userID, page.Messages); err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
 // StoreTypingIndicator records that a user is typing in a chat
@@ -193,11 +497,40 @@ func (s *ServiceImpl) StoreTypingIndicator(userID int, chatID string) error {
 	return s.messagingRepo.StoreTypingIndicator(userID, chatID)
 }
 
-// StoreReadReceipt records that a user has read messages up to a certain point
-func (s *ServiceImpl) StoreReadReceipt(userID int, chatID string, messageID string) error {
+// StoreReadReceipt records that a user has read a chat up to the message.
+// It returns the new read state, or nil when the user had already read further.
+func (s *ServiceImpl) StoreReadReceipt(userID int, chatID string, messageID string) (*messaging.ReadState, error) {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
 	return s.messagingRepo.StoreReadReceipt(userID, chatID, messageID)
 }
 
+// MarkAllRead marks every chat of the user as read up to its latest message
+func (s *ServiceImpl) MarkAllRead(ctx context.Context, userID int) ([]messaging.ReadState, error) {
+	return s.messagingRepo.MarkChatsRead(ctx, userID, "")
+}
+
+// MarkChatRead marks a single chat as read up to its latest message
+func (s *ServiceImpl) MarkChatRead(ctx context.Context, userID int, chatID string) ([]messaging.ReadState, error) {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inChat {
+		return nil, errors.New(apierrors.ErrorUserNotInChat)
+	}
+
+	return s.messagingRepo.MarkChatsRead(ctx, userID, chatID)
+}
+
 // GetUserChatRooms retrieves all chat IDs a user is part of
 func (s *ServiceImpl) GetUserChatRooms(userID int) (map[string]struct{}, error) {
 	return s.messagingRepo.GetUserChatRooms(userID)
@@ -208,12 +541,38 @@ func (s *ServiceImpl) GetChatParticipantsForBroadcast(chatID string) ([]int, err
 	return s.messagingRepo.GetChatParticipantsForBroadcast(chatID)
 }
 
-// GetOrCreateDirectChat finds or creates a direct chat between two users
+// GetOrCreateDirectChat finds or creates a direct chat between two users. A new chat
+// from someone the direct message settings of userID2 do not allow is a request for userID2.
 func (s *ServiceImpl) GetOrCreateDirectChat(ctx context.Context, userID1 int, userID2 int) (string, error) {
 	// Business logic moved from handler to service
 	if userID1 == userID2 {
 		return "", errors.New(apierrors.ErrorCannotCreateChatWithSelf)
 	}
 
-	return s.messagingRepo.GetOrCreateDirectChat(ctx, userID1, userID2)
+	// Organizers can only reach users who allowed organizer contact
+	organizer, err := s.isOrganizer(ctx, userID1)
+	if err != nil {
+		return "", err
+	}
+	if organizer {
+		if err := s.checkOrganizerContact(ctx, userID1, []int{userID2}); err != nil {
+			return "", err
+		}
+	}
+
+	allowed, err := s.messagingRepo.IsDirectMessageAllowed(ctx, userID2, userID1)
+	if err != nil {
+		return "", err
+	}
+	return s.messagingRepo.GetOrCreateDirectChat(ctx, userID1, userID2, !allowed)
+}
+
+// GetOrCreatePairedChat finds or creates a direct chat between two users the app paired
+// at their own request, such as practice partners. It is never a request, whatever the
+// direct message settings of either user.
+func (s *ServiceImpl) GetOrCreatePairedChat(ctx context.Context, userID1 int, userID2 int) (string, error) {
+	if userID1 == userID2 {
+		return "", errors.New(apierrors.ErrorCannotCreateChatWithSelf)
+	}
+	return s.messagingRepo.GetOrCreateDirectChat(ctx, userID1, userID2, false)
 }
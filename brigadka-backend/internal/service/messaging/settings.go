@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "github.com/bulatminnakhmetov/brigadka-backend/internal/errors"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// MuteChat turns off push notifications of a chat for the user until the given time,
+// or indefinitely when until is nil. Muting again replaces the previous end.
+func (s *ServiceImpl) MuteChat(ctx context.Context, userID int, chatID string, until *time.Time) error {
+	if until != nil && !until.After(time.Now()) {
+		return errors.New(apierrors.ErrorInvalidMuteUntil)
+	}
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+	return s.messagingRepo.MuteChat(ctx, chatID, userID, until)
+}
+
+// UnmuteChat turns push notifications of a chat back on for the user
+func (s *ServiceImpl) UnmuteChat(ctx context.Context, userID int, chatID string) error {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+	return s.messagingRepo.UnmuteChat(ctx, chatID, userID)
+}
+
+// ArchiveChat moves a chat to the user's archive
+func (s *ServiceImpl) ArchiveChat(ctx context.Context, userID int, chatID string) error {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+	return s.messagingRepo.ArchiveChat(ctx, chatID, userID)
+}
+
+// UnarchiveChat moves a chat out of the user's archive
+func (s *ServiceImpl) UnarchiveChat(ctx context.Context, userID int, chatID string) error {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+	return s.messagingRepo.UnarchiveChat(ctx, chatID, userID)
+}
+
+// GetMutedParticipants returns the participants of a chat who should not get push notifications from it
+func (s *ServiceImpl) GetMutedParticipants(ctx context.Context, chatID string) (map[int]struct{}, error) {
+	return s.messagingRepo.GetMutedParticipants(ctx, chatID, time.Now())
+}
+
+// GetUnreadCounts counts the unread messages of the users in the chat and in all their chats,
+// for the badges of push notifications
+func (s *ServiceImpl) GetUnreadCounts(ctx context.Context, chatID string, userIDs []int) (map[int]messaging.UnreadCounts, error) {
+	return s.messagingRepo.GetUnreadCounts(ctx, chatID, userIDs)
+}
+
+func (s *ServiceImpl) requireParticipant(userID int, chatID string) error {
+	inChat, err := s.IsUserInChat(userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !inChat {
+		return errors.New(apierrors.ErrorUserNotInChat)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/messaging"
+)
+
+// postSystemMessage records a change of a chat in its history and hands the message to
+// the listener for delivery. The change is already made, so failures are only logged.
+func (s *ServiceImpl) postSystemMessage(ctx context.Context, chatID string, actorID int, event string, targetUserID *int) {
+	names := make(map[int]string)
+	actor := s.displayName(names, actorID)
+	target := ""
+	if targetUserID != nil {
+		target = s.displayName(names, *targetUserID)
+	}
+
+	msg := ChatMessage{
+		MessageID:    uuid.New().String(),
+		ChatID:       chatID,
+		SenderID:     actorID,
+		Kind:         messaging.MessageKindSystem,
+		Event:        event,
+		TargetUserID: targetUserID,
+	}
+	switch event {
+	case messaging.SystemEventChatCreated:
+		msg.Content = fmt.Sprintf("%s created the chat", actor)
+	case messaging.SystemEventChatRenamed:
+		msg.Content = fmt.Sprintf("%s renamed the chat", actor)
+	case messaging.SystemEventChatUpdated:
+		msg.Content = fmt.Sprintf("%s updated the chat info", actor)
+	case messaging.SystemEventParticipantAdded:
+		msg.Content = fmt.Sprintf("%s added %s", actor, target)
+	case messaging.SystemEventParticipantJoined:
+		msg.Content = fmt.Sprintf("%s joined the chat", actor)
+	case messaging.SystemEventParticipantRemoved:
+		msg.Content = fmt.Sprintf("%s removed %s", actor, target)
+	case messaging.SystemEventParticipantLeft:
+		msg.Content = fmt.Sprintf("%s left the chat", actor)
+	case messaging.SystemEventAdminPromoted:
+		msg.Content = fmt.Sprintf("%s made %s an admin", actor, target)
+	}
+
+	var err error
+	msg.SentAt, msg.Seq, err = s.messagingRepo.AddSystemMessage(ctx, msg)
+	if err != nil {
+		log.Printf("Failed to post %s system message to chat %s: %v", event, chatID, err)
+		return
+	}
+
+	if s.messageListener != nil {
+		s.messageListener.MessagePosted(msg)
+	}
+}
+
+// displayName returns the name of a user for a system message
+func (s *ServiceImpl) displayName(names map[int]string, userID int) string {
+	name, err := s.senderName(names, userID)
+	if err != nil {
+		log.Printf("Error loading the name of user %d: %v", userID, err)
+	}
+	if name == "" {
+		return fmt.Sprintf("User %d", userID)
+	}
+	return name
+}
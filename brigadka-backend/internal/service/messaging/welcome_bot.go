@@ -0,0 +1,89 @@
+package messaging
+
+import (
+	"strings"
+)
+
+// welcomeBotText holds the localized texts of the welcome bot
+type welcomeBotText struct {
+	greeting []string
+	faq      map[string]string
+	unknown  string
+}
+
+var welcomeBotTexts = map[string]welcomeBotText{
+	"ru": {
+		greeting: []string{
+			"Привет! Я Бригадка, помогу освоиться в приложении.",
+			"Заполни профиль: добавь фото, видео с выступлений и любимые стили импровизации — так тебя быстрее найдут партнеры и команды.",
+			"Напиши /help, чтобы увидеть, на какие вопросы я умею отвечать.",
+		},
+		faq: map[string]string{
+			"/help":    "Команды:\n/profile — как заполнить профиль\n/teams — как найти команду\n/feed — подписки и лента\n/help — этот список",
+			"/profile": "Открой свой профиль и нажми «Редактировать». Добавь аватар, видео и короткое голосовое представление, укажи город и стили импровизации.",
+			"/teams":   "В разделе «Команды» можно искать команды по городу и стилям. Отправь заявку на вступление — владелец команды рассмотрит ее и добавит тебя в чат команды.",
+			"/feed":    "Подписывайся на людей и команды, чтобы видеть их новые видео и изменения в ленте.",
+		},
+		unknown: "Я пока понимаю только команды. Напиши /help, чтобы увидеть список.",
+	},
+	"en": {
+		greeting: []string{
+			"Hi! I'm Brigadka, I'll help you get started.",
+			"Fill in your profile: add a photo, performance videos and your favourite improv styles so partners and teams can find you faster.",
+			"Send /help to see the questions I can answer.",
+		},
+		faq: map[string]string{
+			"/help":    "Commands:\n/profile — how to fill in your profile\n/teams — how to find a team\n/feed — follows and the feed\n/help — this list",
+			"/profile": "Open your profile and tap \"Edit\". Add an avatar, videos and a short audio introduction, set your city and improv styles.",
+			"/teams":   "Search teams by city and style in the \"Teams\" tab. Apply to join and the team owner will review your application and add you to the team chat.",
+			"/feed":    "Follow people and teams to see their new videos and updates in your feed.",
+		},
+		unknown: "I only understand commands for now. Send /help to see the list.",
+	},
+}
+
+// WelcomeBot greets new users and answers fixed FAQ commands
+type WelcomeBot struct {
+	userID int
+}
+
+// NewWelcomeBot creates the welcome bot writing as the given system user
+func NewWelcomeBot(userID int) *WelcomeBot {
+	return &WelcomeBot{userID: userID}
+}
+
+// UserID returns the system user of the bot
+func (b *WelcomeBot) UserID() int {
+	return b.userID
+}
+
+// Name returns the display name of the bot
+func (b *WelcomeBot) Name() string {
+	return "Brigadka"
+}
+
+// Greeting returns the welcome sequence in the given language
+func (b *WelcomeBot) Greeting(lang string) []string {
+	return textsFor(lang).greeting
+}
+
+// Reply answers FAQ commands, anything else gets a hint
+func (b *WelcomeBot) Reply(content string, lang string) []string {
+	texts := textsFor(lang)
+	command := strings.ToLower(strings.TrimSpace(content))
+	if command == "/start" {
+		command = "/help"
+	}
+	if answer, ok := texts.faq[command]; ok {
+		return []string{answer}
+	}
+	return []string{texts.unknown}
+}
+
+// textsFor falls back to the default language for unsupported ones
+func textsFor(lang string) welcomeBotText {
+	if texts, ok := welcomeBotTexts[lang]; ok {
+		return texts
+	}
+	return welcomeBotTexts[DefaultBotLanguage]
+}
@@ -0,0 +1,215 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	onboardingrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/onboarding"
+)
+
+// Preferred schedules
+const (
+	ScheduleWeekdays = "weekdays"
+	ScheduleWeekends = "weekends"
+	ScheduleEvenings = "evenings"
+	ScheduleFlexible = "flexible"
+)
+
+// Experience levels
+const (
+	ExperienceNone         = "none"
+	ExperienceBeginner     = "beginner"
+	ExperienceIntermediate = "intermediate"
+	ExperienceAdvanced     = "advanced"
+)
+
+// Onboarding steps in the order the app walks the user through them
+const (
+	StepProfileCreated  = "profile_created"
+	StepAvatarUploaded  = "avatar_uploaded"
+	StepQuizAnswered    = "quiz_answered"
+	StepFirstSearchDone = "first_search_done"
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrAnswersNotFound    = errors.New("onboarding answers not found")
+	ErrInvalidImprovStyle = errors.New("invalid improv style")
+	ErrInvalidSchedule    = errors.New("invalid schedule")
+	ErrInvalidExperience  = errors.New("invalid experience")
+)
+
+// Answers represents the onboarding quiz answers. They are private to the
+// user and only used to recommend profiles and teams.
+type Answers struct {
+	PreferredFormats []string  `json:"preferred_formats"`
+	Schedule         *string   `json:"schedule,omitempty"`
+	Experience       *string   `json:"experience,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Step is an onboarding step and whether the user has completed it
+type Step struct {
+	Code string `json:"code"`
+	Done bool   `json:"done"`
+}
+
+// Status is the onboarding progress of a user
+type Status struct {
+	Steps     []Step   `json:"steps"`
+	Remaining []string `json:"remaining"` // Codes of steps not done yet, in order
+	Completed bool     `json:"completed"`
+}
+
+// ProfileState reports the profile state that onboarding steps depend on
+type ProfileState interface {
+	CheckProfileExists(userID int) (bool, error)
+	HasAvatar(userID int) (bool, error)
+}
+
+// OnboardingServiceImpl implements the onboarding quiz and progress tracking
+type OnboardingServiceImpl struct {
+	repo     onboardingrepo.Repository
+	profiles ProfileState
+	now      func() time.Time
+}
+
+// NewOnboardingService creates a new onboarding service
+func NewOnboardingService(repo onboardingrepo.Repository, profiles ProfileState) *OnboardingServiceImpl {
+	return &OnboardingServiceImpl{
+		repo:     repo,
+		profiles: profiles,
+		now:      time.Now,
+	}
+}
+
+// GetAnswers retrieves the quiz answers of a user
+func (s *OnboardingServiceImpl) GetAnswers(ctx context.Context, userID int) (*Answers, error) {
+	model, err := s.repo.GetAnswers(ctx, userID)
+	if errors.Is(err, onboardingrepo.ErrAnswersNotFound) {
+		return nil, ErrAnswersNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertToAnswers(model), nil
+}
+
+// SaveAnswers validates and stores the quiz answers of a user, replacing previous answers
+func (s *OnboardingServiceImpl) SaveAnswers(ctx context.Context, userID int, answers Answers) (*Answers, error) {
+	if answers.Schedule != nil && !isValidSchedule(*answers.Schedule) {
+		return nil, ErrInvalidSchedule
+	}
+	if answers.Experience != nil && !isValidExperience(*answers.Experience) {
+		return nil, ErrInvalidExperience
+	}
+
+	formats := []string{}
+	seen := map[string]bool{}
+	for _, style := range answers.PreferredFormats {
+		if seen[style] {
+			continue
+		}
+		valid, err := s.repo.ValidateImprovStyle(ctx, style)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, ErrInvalidImprovStyle
+		}
+		seen[style] = true
+		formats = append(formats, style)
+	}
+
+	model := &onboardingrepo.AnswersModel{
+		UserID:           userID,
+		PreferredFormats: formats,
+		Schedule:         answers.Schedule,
+		Experience:       answers.Experience,
+	}
+	if err := s.repo.SaveAnswers(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return convertToAnswers(model), nil
+}
+
+// GetStatus collects which onboarding steps the user has completed
+func (s *OnboardingServiceImpl) GetStatus(ctx context.Context, userID int) (*Status, error) {
+	hasProfile, err := s.profiles.CheckProfileExists(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The avatar is part of the profile
+	hasAvatar := false
+	if hasProfile {
+		if hasAvatar, err = s.profiles.HasAvatar(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	answered := true
+	if _, err := s.repo.GetAnswers(ctx, userID); err != nil {
+		if !errors.Is(err, onboardingrepo.ErrAnswersNotFound) {
+			return nil, err
+		}
+		answered = false
+	}
+
+	firstSearchAt, err := s.repo.GetFirstSearchAt(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Steps: []Step{
+			{Code: StepProfileCreated, Done: hasProfile},
+			{Code: StepAvatarUploaded, Done: hasAvatar},
+			{Code: StepQuizAnswered, Done: answered},
+			{Code: StepFirstSearchDone, Done: firstSearchAt != nil},
+		},
+		Remaining: []string{},
+	}
+	for _, step := range status.Steps {
+		if !step.Done {
+			status.Remaining = append(status.Remaining, step.Code)
+		}
+	}
+	status.Completed = len(status.Remaining) == 0
+	return status, nil
+}
+
+// RecordSearch marks the first search step as done. Guests are not tracked.
+func (s *OnboardingServiceImpl) RecordSearch(ctx context.Context, userID int) error {
+	if userID == 0 {
+		return nil
+	}
+	return s.repo.RecordFirstSearch(ctx, userID, s.now())
+}
+
+func convertToAnswers(model *onboardingrepo.AnswersModel) *Answers {
+	return &Answers{
+		PreferredFormats: model.PreferredFormats,
+		Schedule:         model.Schedule,
+		Experience:       model.Experience,
+		UpdatedAt:        model.UpdatedAt,
+	}
+}
+
+func isValidSchedule(schedule string) bool {
+	switch schedule {
+	case ScheduleWeekdays, ScheduleWeekends, ScheduleEvenings, ScheduleFlexible:
+		return true
+	}
+	return false
+}
+
+func isValidExperience(experience string) bool {
+	switch experience {
+	case ExperienceNone, ExperienceBeginner, ExperienceIntermediate, ExperienceAdvanced:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+package partner
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	partnerrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/partner"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// Practice levels
+const (
+	LevelBeginner     = partnerrepo.LevelBeginner
+	LevelIntermediate = partnerrepo.LevelIntermediate
+	LevelAdvanced     = partnerrepo.LevelAdvanced
+)
+
+const (
+	// MinOverlap is how long the windows of two users must overlap for them to be paired
+	MinOverlap = time.Hour
+	// MaxLeadTime is how far ahead a window can end; the queue is for finding a partner tonight
+	MaxLeadTime = 24 * time.Hour
+
+	// matchPushType marks the pushes sent to both users of a new match
+	matchPushType = "partner_match"
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrNotWaiting     = errors.New("user is not waiting for a partner")
+	ErrAlreadyWaiting = errors.New("user is already waiting for a partner")
+	ErrInvalidCity    = errors.New("invalid city")
+	ErrInvalidLevel   = errors.New("invalid level")
+	ErrInvalidWindow  = errors.New("time window must last at least an hour and end within a day")
+)
+
+// levelRanks orders the levels; users at most one level apart are paired
+var levelRanks = map[string]int{
+	LevelBeginner:     0,
+	LevelIntermediate: 1,
+	LevelAdvanced:     2,
+}
+
+// QueueEntry is the user's place in the partner queue
+type QueueEntry struct {
+	ID             int       `json:"id"`
+	CityID         int       `json:"city_id"`
+	Level          string    `json:"level"`
+	AvailableFrom  time.Time `json:"available_from"`
+	AvailableUntil time.Time `json:"available_until"`
+	Status         string    `json:"status"` // waiting or matched
+	CreatedAt      time.Time `json:"created_at"`
+	Match          *Match    `json:"match,omitempty"`
+}
+
+// Match is the partner the user was paired with
+type Match struct {
+	PartnerID   int       `json:"partner_id"`
+	PartnerName string    `json:"partner_name"`
+	ChatID      *string   `json:"chat_id,omitempty"`
+	MatchedAt   time.Time `json:"matched_at"`
+}
+
+// JoinRequest represents data needed to join the partner queue
+type JoinRequest struct {
+	CityID         int
+	Level          string
+	AvailableFrom  time.Time
+	AvailableUntil time.Time
+}
+
+// ChatService creates the direct chat of a new pair
+type ChatService interface {
+	GetOrCreatePairedChat(ctx context.Context, userID1 int, userID2 int) (string, error)
+}
+
+// PushService notifies both users of a new pair
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// RunObserver is told the outcome of every matcher run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// PartnerServiceImpl keeps the queue of users looking for a practice partner and pairs them
+type PartnerServiceImpl struct {
+	repo        partnerrepo.Repository
+	chatService ChatService
+	pushService PushService
+	now         func() time.Time
+	runObserver RunObserver // Optional
+}
+
+// NewPartnerService creates a new partner queue service
+func NewPartnerService(repo partnerrepo.Repository, chatService ChatService, pushService PushService) *PartnerServiceImpl {
+	return &PartnerServiceImpl{
+		repo:        repo,
+		chatService: chatService,
+		pushService: pushService,
+		now:         time.Now,
+	}
+}
+
+// SetRunObserver reports the outcome of every run of the matcher
+func (s *PartnerServiceImpl) SetRunObserver(observer RunObserver) {
+	s.runObserver = observer
+}
+
+// Join puts the user in the queue for a partner in a city within a time window
+func (s *PartnerServiceImpl) Join(ctx context.Context, userID int, req JoinRequest) (*QueueEntry, error) {
+	if _, ok := levelRanks[req.Level]; !ok {
+		return nil, ErrInvalidLevel
+	}
+	now := s.now()
+	from := req.AvailableFrom
+	if from.Before(now) {
+		from = now
+	}
+	if req.AvailableUntil.Sub(from) < MinOverlap || req.AvailableUntil.Sub(now) > MaxLeadTime {
+		return nil, ErrInvalidWindow
+	}
+
+	valid, err := s.repo.ValidateCity(ctx, req.CityID)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidCity
+	}
+
+	request := &partnerrepo.Request{
+		UserID:         userID,
+		CityID:         req.CityID,
+		Level:          req.Level,
+		AvailableFrom:  from.UTC(),
+		AvailableUntil: req.AvailableUntil.UTC(),
+	}
+	if err := s.repo.CreateRequest(ctx, request, now); err != nil {
+		if errors.Is(err, partnerrepo.ErrAlreadyWaiting) {
+			return nil, ErrAlreadyWaiting
+		}
+		return nil, err
+	}
+	return convertToQueueEntry(request), nil
+}
+
+// GetQueueEntry returns the user's current request, with the partner once matched
+func (s *PartnerServiceImpl) GetQueueEntry(ctx context.Context, userID int) (*QueueEntry, error) {
+	request, err := s.repo.GetCurrentRequest(ctx, userID, s.now())
+	if err != nil {
+		if errors.Is(err, partnerrepo.ErrRequestNotFound) {
+			return nil, ErrNotWaiting
+		}
+		return nil, err
+	}
+	return convertToQueueEntry(request), nil
+}
+
+// Leave takes the user out of the queue
+func (s *PartnerServiceImpl) Leave(ctx context.Context, userID int) error {
+	if err := s.repo.CancelRequest(ctx, userID); err != nil {
+		if errors.Is(err, partnerrepo.ErrRequestNotFound) {
+			return ErrNotWaiting
+		}
+		return err
+	}
+	return nil
+}
+
+// Run pairs waiting users every interval until the context is cancelled
+func (s *PartnerServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.MatchWaiting(ctx)
+		if s.runObserver != nil {
+			s.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to match practice partners: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MatchWaiting expires requests whose window is over and pairs the rest. Users who
+// waited longest are paired first, each with the compatible user sharing the most
+// improv styles.
+func (s *PartnerServiceImpl) MatchWaiting(ctx context.Context) error {
+	now := s.now()
+	if _, err := s.repo.ExpireRequests(ctx, now); err != nil {
+		return err
+	}
+	requests, err := s.repo.GetWaitingRequests(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	paired := make(map[int]bool)
+	for i, request := range requests {
+		if paired[request.ID] {
+			continue
+		}
+
+		best, bestScore := -1, -1
+		for j := i + 1; j < len(requests); j++ {
+			candidate := requests[j]
+			if paired[candidate.ID] || !compatible(request, candidate) {
+				continue
+			}
+			if score := sharedStyles(request, candidate); score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		partner := requests[best]
+		matchID, err := s.repo.CreateMatch(ctx, request.ID, partner.ID)
+		if errors.Is(err, partnerrepo.ErrRequestTaken) {
+			// One of them left the queue meanwhile; the other waits for the next run
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		paired[request.ID] = true
+		paired[partner.ID] = true
+
+		s.connect(ctx, matchID, request.UserID, partner.UserID)
+	}
+	return nil
+}
+
+// connect creates the direct chat of a new pair and notifies both users
+func (s *PartnerServiceImpl) connect(ctx context.Context, matchID, userID1, userID2 int) {
+	data := map[string]string{
+		"type":     matchPushType,
+		"match_id": strconv.Itoa(matchID),
+	}
+	chatID, err := s.chatService.GetOrCreatePairedChat(ctx, userID1, userID2)
+	if err != nil {
+		log.Printf("Failed to create chat for partner match %d: %v", matchID, err)
+	} else {
+		if err := s.repo.SetMatchChat(ctx, matchID, chatID); err != nil {
+			log.Printf("Failed to link chat %s to partner match %d: %v", chatID, matchID, err)
+		}
+		data["chat_id"] = chatID
+	}
+
+	payload := push.NotificationPayload{
+		Title:    "Партнер найден",
+		Body:     "Мы нашли вам партнера для практики сегодня. Договоритесь о встрече в чате!",
+		Sound:    "default",
+		Category: push.CategoryNewMatch,
+		Data:     data,
+	}
+	s.sendPush(userID1, payload)
+	s.sendPush(userID2, payload)
+}
+
+func (s *PartnerServiceImpl) sendPush(userID int, payload push.NotificationPayload) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+			log.Printf("Error sending push notification to user %d: %v", userID, err)
+		}
+	}()
+}
+
+// compatible reports whether two requests can be paired: the same city, levels at most
+// one apart, windows overlapping by MinOverlap and a shared improv style unless either
+// user has none in the profile
+func compatible(a, b partnerrepo.Request) bool {
+	if a.CityID != b.CityID || a.UserID == b.UserID {
+		return false
+	}
+	if gap := levelRanks[a.Level] - levelRanks[b.Level]; gap > 1 || gap < -1 {
+		return false
+	}
+
+	from, until := a.AvailableFrom, a.AvailableUntil
+	if b.AvailableFrom.After(from) {
+		from = b.AvailableFrom
+	}
+	if b.AvailableUntil.Before(until) {
+		until = b.AvailableUntil
+	}
+	if until.Sub(from) < MinOverlap {
+		return false
+	}
+
+	return len(a.ImprovStyles) == 0 || len(b.ImprovStyles) == 0 || sharedStyles(a, b) > 0
+}
+
+func sharedStyles(a, b partnerrepo.Request) int {
+	count := 0
+	for _, style := range a.ImprovStyles {
+		for _, other := range b.ImprovStyles {
+			if style == other {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func convertToQueueEntry(r *partnerrepo.Request) *QueueEntry {
+	entry := &QueueEntry{
+		ID:             r.ID,
+		CityID:         r.CityID,
+		Level:          r.Level,
+		AvailableFrom:  r.AvailableFrom,
+		AvailableUntil: r.AvailableUntil,
+		Status:         r.Status,
+		CreatedAt:      r.CreatedAt,
+	}
+	if r.Match != nil {
+		entry.Match = &Match{
+			PartnerID:   r.Match.PartnerID,
+			PartnerName: r.Match.PartnerName,
+			ChatID:      r.Match.ChatID,
+			MatchedAt:   r.Match.MatchedAt,
+		}
+	}
+	return entry
+}
@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// maxAvailabilitySlots limits the number of weekly slots per profile
+const maxAvailabilitySlots = 50
+
+// weekdays maps ISO weekdays (index + 1) to their codes
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// AvailabilitySlot is a weekly time slot, e.g. {"day": "tue", "start": "19:00", "end": "22:00"}
+type AvailabilitySlot struct {
+	Day   string `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// parseAvailabilitySlot validates a slot and converts it to the repository representation
+func parseAvailabilitySlot(slot AvailabilitySlot) (profilerepo.AvailabilitySlot, error) {
+	day := 0
+	for i, code := range weekdays {
+		if code == slot.Day {
+			day = i + 1
+		}
+	}
+	if day == 0 {
+		return profilerepo.AvailabilitySlot{}, ErrInvalidAvailability
+	}
+
+	start, ok := parseClock(slot.Start)
+	if !ok || start == 24*60 {
+		return profilerepo.AvailabilitySlot{}, ErrInvalidAvailability
+	}
+	end, ok := parseClock(slot.End)
+	if !ok || end <= start {
+		return profilerepo.AvailabilitySlot{}, ErrInvalidAvailability
+	}
+
+	return profilerepo.AvailabilitySlot{Day: day, StartMinute: start, EndMinute: end}, nil
+}
+
+// parseAvailability validates a weekly schedule; slots on the same day must not overlap
+func parseAvailability(slots []AvailabilitySlot) ([]profilerepo.AvailabilitySlot, error) {
+	if len(slots) > maxAvailabilitySlots {
+		return nil, ErrInvalidAvailability
+	}
+
+	parsed := make([]profilerepo.AvailabilitySlot, 0, len(slots))
+	for _, slot := range slots {
+		p, err := parseAvailabilitySlot(slot)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		if parsed[i].Day != parsed[j].Day {
+			return parsed[i].Day < parsed[j].Day
+		}
+		return parsed[i].StartMinute < parsed[j].StartMinute
+	})
+	for i := 1; i < len(parsed); i++ {
+		if parsed[i].Day == parsed[i-1].Day && parsed[i].StartMinute < parsed[i-1].EndMinute {
+			return nil, ErrInvalidAvailability
+		}
+	}
+	return parsed, nil
+}
+
+// convertAvailability formats repository slots for the response
+func convertAvailability(slots []profilerepo.AvailabilitySlot) []AvailabilitySlot {
+	if len(slots) == 0 {
+		return nil
+	}
+	converted := make([]AvailabilitySlot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.Day < 1 || slot.Day > len(weekdays) {
+			continue
+		}
+		converted = append(converted, AvailabilitySlot{
+			Day:   weekdays[slot.Day-1],
+			Start: formatClock(slot.StartMinute),
+			End:   formatClock(slot.EndMinute),
+		})
+	}
+	return converted
+}
+
+// parseClock parses "HH:MM" into minutes from midnight; "24:00" is allowed as the end of the day
+func parseClock(value string) (int, bool) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, false
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if value[i] < '0' || value[i] > '9' {
+			return 0, false
+		}
+	}
+	hours := int(value[0]-'0')*10 + int(value[1]-'0')
+	minutes := int(value[3]-'0')*10 + int(value[4]-'0')
+	if minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
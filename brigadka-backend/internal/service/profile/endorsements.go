@@ -0,0 +1,62 @@
+package profile
+
+import (
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// Endorsement is the number of users who endorsed a profile for an improv style
+type Endorsement struct {
+	Style string `json:"style"`
+	Count int    `json:"count"`
+}
+
+// Endorse endorses another user's profile for an improv style. Only users who
+// share a team with the profile owner can endorse it, which keeps strangers from
+// inflating the counts.
+func (s *ProfileServiceImpl) Endorse(userID int, profileUserID int, style string) error {
+	if userID == profileUserID {
+		return ErrCannotEndorseSelf
+	}
+
+	valid, err := s.profileRepo.ValidateImprovStyle(style)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidImprovStyle
+	}
+
+	exists, err := s.profileRepo.CheckProfileExists(profileUserID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProfileNotFound
+	}
+
+	teamID, err := s.profileRepo.GetSharedTeam(userID, profileUserID)
+	if err != nil {
+		return err
+	}
+	if teamID == 0 {
+		return ErrNoSharedTeam
+	}
+
+	return s.profileRepo.AddEndorsement(userID, profileUserID, style, teamID)
+}
+
+// RemoveEndorsement withdraws the user's endorsement of a profile for a style
+func (s *ProfileServiceImpl) RemoveEndorsement(userID int, profileUserID int, style string) error {
+	return s.profileRepo.RemoveEndorsement(userID, profileUserID, style)
+}
+
+func convertEndorsements(counts []profilerepo.EndorsementCount) []Endorsement {
+	if len(counts) == 0 {
+		return nil
+	}
+	endorsements := make([]Endorsement, len(counts))
+	for i, count := range counts {
+		endorsements[i] = Endorsement{Style: count.Style, Count: count.Count}
+	}
+	return endorsements
+}
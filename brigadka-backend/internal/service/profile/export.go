@@ -0,0 +1,120 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+)
+
+// SearchExportKind is the export kind of search results, generated by GenerateSearchExport
+const SearchExportKind = "profile_search"
+
+// SearchExportParams are the params of a search results export
+type SearchExportParams struct {
+	Filter SearchFilter `json:"filter"`
+	Lang   string       `json:"lang"`
+}
+
+// maxExportRows limits the number of profiles in a single search export
+const maxExportRows = 5000
+
+// exportPageSize is the page size used to walk search results during export
+const exportPageSize = 100
+
+// GenerateSearchExport renders the search results export of the user from its params
+func (s *ProfileServiceImpl) GenerateSearchExport(ctx context.Context, userID int, params json.RawMessage) ([]byte, error) {
+	var p SearchExportParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	return s.ExportSearchCSV(ctx, userID, p.Filter, p.Lang)
+}
+
+// ExportSearchCSV runs the search across all result pages and renders the profiles as CSV.
+// Profiles that did not allow organizer contact are left out.
+func (s *ProfileServiceImpl) ExportSearchCSV(ctx context.Context, userID int, filter SearchFilter, lang string) ([]byte, error) {
+	cities, err := s.GetCities(lang, "", "")
+	if err != nil {
+		return nil, err
+	}
+	cityNames := make(map[int]string, len(cities))
+	for _, city := range cities {
+		cityNames[city.ID] = city.Name
+	}
+
+	styles, err := s.GetImprovStyles(lang)
+	if err != nil {
+		return nil, err
+	}
+	styleLabels := make(map[string]string, len(styles))
+	for _, style := range styles {
+		styleLabels[style.Code] = style.Label
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"full_name", "city", "improv_styles"}); err != nil {
+		return nil, err
+	}
+
+	filter.PageSize = exportPageSize
+	// Pages must not overlap, and the export has no use for facet counts
+	filter.Shuffle = false
+	filter.IncludeFacets = false
+	rows := 0
+	for page := 1; rows < maxExportRows; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		filter.Page = page
+		result, err := s.Search(userID, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range result.Profiles {
+			if rows >= maxExportRows {
+				break
+			}
+			// Only profiles that opted in to organizer contact are exported
+			if !p.AllowOrganizerContact {
+				continue
+			}
+
+			labels := make([]string, 0, len(p.ImprovStyles))
+			for _, code := range p.ImprovStyles {
+				if label, ok := styleLabels[code]; ok {
+					labels = append(labels, label)
+				} else {
+					labels = append(labels, code)
+				}
+			}
+
+			if err := w.Write([]string{csvSafe(p.FullName), cityNames[p.CityID], strings.Join(labels, "; ")}); err != nil {
+				return nil, err
+			}
+			rows++
+		}
+
+		if page*exportPageSize >= result.TotalCount {
+			break
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvSafe prevents spreadsheet applications from interpreting user input as a formula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+package profile
+
+// AddFavorite adds another user's profile to favorites
+func (s *ProfileServiceImpl) AddFavorite(userID int, profileUserID int) error {
+	if userID == profileUserID {
+		return ErrCannotFavoriteSelf
+	}
+
+	exists, err := s.profileRepo.CheckProfileExists(profileUserID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProfileNotFound
+	}
+
+	return s.profileRepo.AddFavorite(userID, profileUserID)
+}
+
+// RemoveFavorite removes a profile from favorites
+func (s *ProfileServiceImpl) RemoveFavorite(userID int, profileUserID int) error {
+	return s.profileRepo.RemoveFavorite(userID, profileUserID)
+}
+
+// GetFavorites returns a page of favorite profiles
+func (s *ProfileServiceImpl) GetFavorites(userID int, page int, pageSize int) (*SearchResult, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	profiles, totalCount, err := s.profileRepo.GetFavorites(userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Profiles:   make([]Profile, 0, len(profiles)),
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	result.Profiles = append(result.Profiles, s.expandProfiles(profiles)...)
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package profile
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Link kinds stored in profile_links
+const (
+	LinkInstagram = "instagram"
+	LinkTelegram  = "telegram"
+	LinkYouTube   = "youtube"
+	LinkWebsite   = "website"
+)
+
+// maxLinkLength limits the length of a stored link
+const maxLinkLength = 2048
+
+var (
+	instagramHandle = regexp.MustCompile(`^[A-Za-z0-9._]{1,30}$`)
+	telegramHandle  = regexp.MustCompile(`^[A-Za-z0-9_]{5,32}$`)
+	youtubeHandle   = regexp.MustCompile(`^[A-Za-z0-9._-]{3,30}$`)
+)
+
+// Links represents social links of a profile
+type Links struct {
+	Instagram string `json:"instagram,omitempty"`
+	Telegram  string `json:"telegram,omitempty"`
+	YouTube   string `json:"youtube,omitempty"`
+	Website   string `json:"website,omitempty"`
+}
+
+// normalizeLinks validates links and converts them to canonical URLs keyed by kind.
+// Empty links are dropped.
+func normalizeLinks(links Links) (map[string]string, error) {
+	normalizers := []struct {
+		kind      string
+		raw       string
+		normalize func(string) (string, error)
+	}{
+		{LinkInstagram, links.Instagram, normalizeInstagram},
+		{LinkTelegram, links.Telegram, normalizeTelegram},
+		{LinkYouTube, links.YouTube, normalizeYouTube},
+		{LinkWebsite, links.Website, normalizeWebsite},
+	}
+
+	result := map[string]string{}
+	for _, n := range normalizers {
+		raw := strings.TrimSpace(n.raw)
+		if raw == "" {
+			continue
+		}
+		if len(raw) > maxLinkLength {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLink, n.kind)
+		}
+		normalized, err := n.normalize(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLink, n.kind)
+		}
+		result[n.kind] = normalized
+	}
+	return result, nil
+}
+
+// convertLinks builds Links from stored links; nil when the profile has none
+func convertLinks(links map[string]string) *Links {
+	if len(links) == 0 {
+		return nil
+	}
+	return &Links{
+		Instagram: links[LinkInstagram],
+		Telegram:  links[LinkTelegram],
+		YouTube:   links[LinkYouTube],
+		Website:   links[LinkWebsite],
+	}
+}
+
+// normalizeInstagram accepts "@handle", "handle" or a profile URL
+func normalizeInstagram(raw string) (string, error) {
+	handle, err := extractHandle(raw, []string{"instagram.com"})
+	if err != nil || !instagramHandle.MatchString(handle) {
+		return "", ErrInvalidLink
+	}
+	return "https://instagram.com/" + handle, nil
+}
+
+// normalizeTelegram accepts "@username", "username" or a t.me link
+func normalizeTelegram(raw string) (string, error) {
+	handle, err := extractHandle(raw, []string{"t.me", "telegram.me"})
+	if err != nil || !telegramHandle.MatchString(handle) {
+		return "", ErrInvalidLink
+	}
+	return "https://t.me/" + handle, nil
+}
+
+// normalizeYouTube accepts "@handle" or a youtube.com / youtu.be link
+func normalizeYouTube(raw string) (string, error) {
+	if strings.HasPrefix(raw, "@") {
+		if !youtubeHandle.MatchString(raw[1:]) {
+			return "", ErrInvalidLink
+		}
+		return "https://youtube.com/" + raw, nil
+	}
+
+	u, err := parseLinkURL(raw)
+	if err != nil {
+		return "", err
+	}
+	host := trimHostPrefix(u.Hostname())
+	if (host != "youtube.com" && host != "youtu.be") || strings.Trim(u.Path, "/") == "" {
+		return "", ErrInvalidLink
+	}
+	u.Scheme = "https"
+	u.Host = host
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// normalizeWebsite accepts any http(s) URL, defaulting to https
+func normalizeWebsite(raw string) (string, error) {
+	u, err := parseLinkURL(raw)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(u.Hostname(), ".") {
+		return "", ErrInvalidLink
+	}
+	u.Host = strings.ToLower(u.Host)
+	return u.String(), nil
+}
+
+// extractHandle returns the handle from "@handle", a bare handle or a URL on one of the hosts
+func extractHandle(raw string, hosts []string) (string, error) {
+	if strings.HasPrefix(raw, "@") {
+		return raw[1:], nil
+	}
+	if !strings.Contains(raw, "/") {
+		return raw, nil
+	}
+
+	u, err := parseLinkURL(raw)
+	if err != nil {
+		return "", err
+	}
+	host := trimHostPrefix(u.Hostname())
+	for _, h := range hosts {
+		if host == h {
+			// Only the first path segment is the handle
+			segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+			return segments[0], nil
+		}
+	}
+	return "", ErrInvalidLink
+}
+
+// parseLinkURL parses an http(s) URL, adding the https scheme when it is missing
+func parseLinkURL(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return nil, ErrInvalidLink
+	}
+	return u, nil
+}
+
+func trimHostPrefix(host string) string {
+	host = strings.ToLower(host)
+	host = strings.TrimPrefix(host, "www.")
+	return strings.TrimPrefix(host, "m.")
+}
@@ -0,0 +1,140 @@
+package profile
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// Recommendation limits
+const (
+	// DefaultRecommendationLimit is the number of recommendations returned when the client does not ask for a limit
+	DefaultRecommendationLimit = 20
+	// MaxRecommendationLimit caps the number of recommendations per request
+	MaxRecommendationLimit = 50
+	// recommendationPoolSize is how many candidates are preselected in SQL and ranked in the service
+	recommendationPoolSize = 200
+)
+
+// Reasons a profile is recommended, shown by clients next to the profile
+const (
+	ReasonSharedStyles   = "shared_styles"
+	ReasonSameCity       = "same_city"
+	ReasonSharedGoals    = "shared_goals"
+	ReasonRecentlyActive = "recently_active"
+)
+
+// Ranking weights; a candidate matching on everything scores 1
+const (
+	styleWeight   = 0.4
+	cityWeight    = 0.25
+	goalWeight    = 0.2
+	recencyWeight = 0.15
+
+	// maxCountedStyles is the number of shared styles that earns the full style score
+	maxCountedStyles = 3
+	// recencyHalfLife is how long it takes the recency score to halve
+	recencyHalfLife = 14 * 24 * time.Hour
+	// recentlyActiveWithin marks candidates active this recently with a reason
+	recentlyActiveWithin = 7 * 24 * time.Hour
+)
+
+// Recommendation is a recommended profile with its score and the reasons behind it
+type Recommendation struct {
+	Profile Profile  `json:"profile"`
+	Score   float64  `json:"score"` // From 0 to 1
+	Reasons []string `json:"reasons"`
+}
+
+// GetRecommendations returns profiles the user may want to play with, best matches first.
+// Candidates are ranked by shared improv styles, the same city, shared goals and recent activity.
+func (s *ProfileServiceImpl) GetRecommendations(userID int, limit int) ([]Recommendation, error) {
+	if limit <= 0 {
+		limit = DefaultRecommendationLimit
+	}
+	if limit > MaxRecommendationLimit {
+		limit = MaxRecommendationLimit
+	}
+
+	now := time.Now()
+	candidates, err := s.profileRepo.GetRecommendationCandidates(userID, now, recommendationPoolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := rankCandidates(candidates, now)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	profiles := make([]*profilerepo.ProfileModel, len(ranked))
+	for i, r := range ranked {
+		profiles[i] = r.candidate.Profile
+	}
+
+	recommendations := make([]Recommendation, 0, len(ranked))
+	for i, expanded := range s.expandProfiles(profiles) {
+		recommendations = append(recommendations, Recommendation{
+			Profile: expanded,
+			Score:   ranked[i].score,
+			Reasons: ranked[i].reasons,
+		})
+	}
+
+	return recommendations, nil
+}
+
+type rankedCandidate struct {
+	candidate profilerepo.RecommendationCandidate
+	score     float64
+	reasons   []string
+}
+
+// rankCandidates scores the candidates and sorts them by score, then by recent activity
+func rankCandidates(candidates []profilerepo.RecommendationCandidate, now time.Time) []rankedCandidate {
+	ranked := make([]rankedCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		score, reasons := scoreCandidate(candidate, now)
+		ranked = append(ranked, rankedCandidate{candidate: candidate, score: score, reasons: reasons})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].candidate.LastActiveAt.After(ranked[j].candidate.LastActiveAt)
+	})
+	return ranked
+}
+
+// scoreCandidate combines the ranking signals into a score from 0 to 1
+func scoreCandidate(candidate profilerepo.RecommendationCandidate, now time.Time) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	if candidate.SharedStyles > 0 {
+		score += styleWeight * float64(min(candidate.SharedStyles, maxCountedStyles)) / maxCountedStyles
+		reasons = append(reasons, ReasonSharedStyles)
+	}
+	if candidate.SameCity {
+		score += cityWeight
+		reasons = append(reasons, ReasonSameCity)
+	}
+	if candidate.SharedGoals > 0 {
+		score += goalWeight
+		reasons = append(reasons, ReasonSharedGoals)
+	}
+
+	inactive := now.Sub(candidate.LastActiveAt)
+	if inactive < 0 {
+		inactive = 0
+	}
+	score += recencyWeight * math.Pow(0.5, float64(inactive)/float64(recencyHalfLife))
+	if inactive <= recentlyActiveWithin {
+		reasons = append(reasons, ReasonRecentlyActive)
+	}
+
+	return score, reasons
+}
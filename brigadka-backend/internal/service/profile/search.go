@@ -1,33 +1,91 @@
 package profile
 
 import (
+	"context"
 	"log"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
 	"time"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
 )
 
 // SearchFilter defines the filters for profile searches
 type SearchFilter struct {
-	FullName       *string    `json:"full_name,omitempty"`
-	LookingForTeam *bool      `json:"looking_for_team,omitempty"`
-	Goals          []string   `json:"goals,omitempty"`
-	ImprovStyles   []string   `json:"improv_styles,omitempty"`
-	AgeMin         *int       `json:"age_min,omitempty"`
-	AgeMax         *int       `json:"age_max,omitempty"`
-	Genders        []string   `json:"genders,omitempty"`
-	CityID         *int       `json:"city_id,omitempty"`
-	HasAvatar      *bool      `json:"has_avatar,omitempty"`
-	HasVideo       *bool      `json:"has_video,omitempty"`
-	CreatedAfter   *time.Time `json:"created_after,omitempty"`
-	Page           int        `json:"page"`
-	PageSize       int        `json:"page_size"`
+	// Query is a full-text search over the name and bio, tolerant to typos.
+	// Results are ordered by relevance first.
+	Query          string             `json:"query,omitempty"`
+	FullName       *string            `json:"full_name,omitempty"` // Deprecated: searched as Query when Query is empty
+	LookingForTeam *bool              `json:"looking_for_team,omitempty"`
+	Goals          []string           `json:"goals,omitempty"`
+	ImprovStyles   []string           `json:"improv_styles,omitempty"`
+	AgeMin         *int               `json:"age_min,omitempty"`
+	AgeMax         *int               `json:"age_max,omitempty"`
+	Genders        []string           `json:"genders,omitempty"`
+	CityID         *int               `json:"city_id,omitempty"`
+	HasAvatar      *bool              `json:"has_avatar,omitempty"`
+	HasVideo       *bool              `json:"has_video,omitempty"`
+	CreatedAfter   *time.Time         `json:"created_after,omitempty"`
+	AvailableOn    []AvailabilitySlot `json:"available_on,omitempty"`
+	VerifiedOnly   bool               `json:"verified_only,omitempty"`
+	Tags           []string           `json:"tags,omitempty"` // Profiles must have all of the tags
+	Near           *NearFilter        `json:"near,omitempty"`
+	// ExcludeContacted hides users the searcher already has a direct chat with
+	ExcludeContacted bool `json:"exclude_contacted,omitempty"`
+	// Shuffle orders results randomly instead of by rank, for browsing profiles.
+	// The order is stable for the seed; without one a new seed is generated and returned.
+	Shuffle bool   `json:"shuffle,omitempty"`
+	Seed    *int64 `json:"seed,omitempty"`
+	// IncludeFacets adds the number of matches per city, improv style and goal to the result
+	IncludeFacets bool `json:"include_facets,omitempty"`
+	Page          int  `json:"page"`
+	PageSize      int  `json:"page_size"`
+}
+
+// maxSearchQueryLength limits the text query of a search
+const maxSearchQueryLength = 200
+
+// MaxSearchRadiusKm limits the radius of searches near a point
+const MaxSearchRadiusKm = 1000
+
+// NearFilter limits a search to profiles whose city is within the radius of a point.
+// Results are ordered by distance.
+type NearFilter struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	RadiusKm float64 `json:"radius_km"`
 }
 
 // SearchResult represents the search results including pagination details
 type SearchResult struct {
-	Profiles   []Profile `json:"profiles"`
-	TotalCount int       `json:"total_count"`
-	Page       int       `json:"page"`
-	PageSize   int       `json:"page_size"`
+	Profiles   []Profile     `json:"profiles"`
+	TotalCount int           `json:"total_count"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	Seed       *int64        `json:"seed,omitempty"` // Set for shuffled searches, pass it back for the next pages
+	Facets     *SearchFacets `json:"facets,omitempty"`
+}
+
+// Facet is the number of matching profiles with a catalog value
+type Facet struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
+
+// CityFacet is the number of matching profiles in a city
+type CityFacet struct {
+	CityID int `json:"city_id"`
+	Count  int `json:"count"`
+}
+
+// SearchFacets counts the matches of a search per filter value, most common first,
+// so clients can show counts on filter chips. Counts are over all matches, not the page.
+type SearchFacets struct {
+	Cities       []CityFacet `json:"cities"`
+	ImprovStyles []Facet     `json:"improv_styles"`
+	Goals        []Facet     `json:"goals"`
 }
 
 // Search searches for profiles with the given filters and sorts results by improv style matches
@@ -51,44 +109,132 @@ func (s *ProfileServiceImpl) Search(userID int, filter SearchFilter) (*SearchRes
 		birthDateMin = &date
 	}
 
-	// Call repository to search profiles with style matches
-	profiles, totalCount, err := s.profileRepo.SearchProfiles(
-		userID,
-		filter.FullName,
-		filter.LookingForTeam,
-		filter.Goals,
-		filter.ImprovStyles,
-		birthDateMin,
-		birthDateMax,
-		filter.Genders,
-		filter.CityID,
-		filter.HasAvatar,
-		filter.HasVideo,
-		filter.CreatedAfter,
-		filter.Page,
-		filter.PageSize,
-	)
+	// Profiles must be free for the whole of at least one of the requested slots
+	availableOn := make([]profilerepo.AvailabilitySlot, 0, len(filter.AvailableOn))
+	for _, slot := range filter.AvailableOn {
+		parsed, err := parseAvailabilitySlot(slot)
+		if err != nil {
+			return nil, err
+		}
+		availableOn = append(availableOn, parsed)
+	}
+
+	// Tags are matched in normalized form, as they are stored
+	tags := make([]string, 0, len(filter.Tags))
+	for _, raw := range filter.Tags {
+		tag, err := normalizeTag(raw)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	var near *profilerepo.Near
+	if filter.Near != nil {
+		if !validNear(*filter.Near) {
+			return nil, ErrInvalidLocation
+		}
+		near = &profilerepo.Near{
+			Latitude:  filter.Near.Lat,
+			Longitude: filter.Near.Lon,
+			RadiusKm:  filter.Near.RadiusKm,
+		}
+	}
+
+	query := strings.TrimSpace(filter.Query)
+	if query == "" && filter.FullName != nil {
+		query = strings.TrimSpace(*filter.FullName)
+	}
+	if len([]rune(query)) > maxSearchQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	// Seeds stay within 32 bits so JavaScript clients can pass them back unchanged
+	var shuffleSeed *int64
+	if filter.Shuffle {
+		seed := rand.Int63n(math.MaxInt32)
+		if filter.Seed != nil {
+			seed = *filter.Seed
+		}
+		shuffleSeed = &seed
+	}
 
+	profiles, totalCount, facets, err := s.searchProvider.SearchProfiles(context.Background(), SearchQuery{
+		UserID:           userID,
+		Text:             query,
+		LookingForTeam:   filter.LookingForTeam,
+		Goals:            filter.Goals,
+		ImprovStyles:     filter.ImprovStyles,
+		BirthDateMin:     birthDateMin,
+		BirthDateMax:     birthDateMax,
+		Genders:          filter.Genders,
+		CityID:           filter.CityID,
+		HasAvatar:        filter.HasAvatar,
+		HasVideo:         filter.HasVideo,
+		CreatedAfter:     filter.CreatedAfter,
+		AvailableOn:      availableOn,
+		VerifiedOnly:     filter.VerifiedOnly,
+		Tags:             tags,
+		Near:             near,
+		ExcludeContacted: filter.ExcludeContacted,
+		ShuffleSeed:      shuffleSeed,
+		WithFacets:       filter.IncludeFacets,
+		Page:             filter.Page,
+		PageSize:         filter.PageSize,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if s.searchRecorder != nil {
+		if err := s.searchRecorder.RecordSearch(context.Background(), userID); err != nil {
+			log.Printf("Failed to record search: %v", err)
+		}
+	}
+
 	// Convert repository profiles to service profiles
 	result := &SearchResult{
 		Profiles:   make([]Profile, 0, len(profiles)),
 		TotalCount: totalCount,
 		Page:       filter.Page,
 		PageSize:   filter.PageSize,
+		Seed:       shuffleSeed,
+		Facets:     convertFacets(facets),
+	}
+
+	result.Profiles = append(result.Profiles, s.expandProfiles(profiles)...)
+
+	return result, nil
+}
+
+func validNear(near NearFilter) bool {
+	return near.Lat >= -90 && near.Lat <= 90 &&
+		near.Lon >= -180 && near.Lon <= 180 &&
+		near.RadiusKm > 0 && near.RadiusKm <= MaxSearchRadiusKm
+}
+
+func convertFacets(facets *profilerepo.SearchFacets) *SearchFacets {
+	if facets == nil {
+		return nil
 	}
 
-	for _, p := range profiles {
-		expanded, err := s.ExpandProfile(p)
+	result := &SearchFacets{
+		Cities:       make([]CityFacet, 0, len(facets.Cities)),
+		ImprovStyles: make([]Facet, 0, len(facets.ImprovStyles)),
+		Goals:        make([]Facet, 0, len(facets.Goals)),
+	}
+	for _, f := range facets.Cities {
+		cityID, err := strconv.Atoi(f.Value)
 		if err != nil {
-			log.Printf("Error expanding profile %d: %v", p.UserID, err)
 			continue
 		}
-		result.Profiles = append(result.Profiles, *expanded)
+		result.Cities = append(result.Cities, CityFacet{CityID: cityID, Count: f.Count})
 	}
-
-	return result, nil
+	for _, f := range facets.ImprovStyles {
+		result.ImprovStyles = append(result.ImprovStyles, Facet{Code: f.Value, Count: f.Count})
+	}
+	for _, f := range facets.Goals {
+		result.Goals = append(result.Goals, Facet{Code: f.Value, Count: f.Count})
+	}
+	return result
 }
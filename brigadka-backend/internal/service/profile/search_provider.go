@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"context"
+	"time"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+// SearchQuery is a validated profile search, as passed to a search provider
+type SearchQuery struct {
+	UserID           int // The searcher, excluded from the results
+	Text             string
+	LookingForTeam   *bool
+	Goals            []string // Any of the goals
+	ImprovStyles     []string // All of the styles
+	BirthDateMin     *time.Time
+	BirthDateMax     *time.Time
+	Genders          []string
+	CityID           *int
+	HasAvatar        *bool
+	HasVideo         *bool
+	CreatedAfter     *time.Time
+	AvailableOn      []profilerepo.AvailabilitySlot
+	VerifiedOnly     bool
+	Tags             []string // All of the tags, normalized
+	Near             *profilerepo.Near
+	ExcludeContacted bool
+	ShuffleSeed      *int64
+	WithFacets       bool
+	Page             int
+	PageSize         int
+}
+
+// SearchProvider finds the profiles matching a search. It returns a page of
+// profiles with their media, styles and goals loaded, the total number of
+// matches and, when asked for, the facets.
+type SearchProvider interface {
+	SearchProfiles(ctx context.Context, query SearchQuery) ([]*profilerepo.ProfileModel, int, *profilerepo.SearchFacets, error)
+}
+
+// PostgresSearchProvider searches profiles in the database. It is the default provider.
+type PostgresSearchProvider struct {
+	repo ProfileRepository
+}
+
+// NewPostgresSearchProvider creates a provider searching the profile repository
+func NewPostgresSearchProvider(repo ProfileRepository) *PostgresSearchProvider {
+	return &PostgresSearchProvider{repo: repo}
+}
+
+// SearchProfiles searches profiles with SQL, ranking by relevance, distance and style matches
+func (p *PostgresSearchProvider) SearchProfiles(ctx context.Context, q SearchQuery) ([]*profilerepo.ProfileModel, int, *profilerepo.SearchFacets, error) {
+	return p.repo.SearchProfiles(
+		q.UserID,
+		q.Text,
+		q.LookingForTeam,
+		q.Goals,
+		q.ImprovStyles,
+		q.BirthDateMin,
+		q.BirthDateMax,
+		q.Genders,
+		q.CityID,
+		q.HasAvatar,
+		q.HasVideo,
+		q.CreatedAfter,
+		q.AvailableOn,
+		q.VerifiedOnly,
+		q.Tags,
+		q.Near,
+		q.ExcludeContacted,
+		q.ShuffleSeed,
+		q.WithFacets,
+		q.Page,
+		q.PageSize,
+	)
+}
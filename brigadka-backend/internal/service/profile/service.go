@@ -1,14 +1,17 @@
 package profile
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
 	"github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
 	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
 )
 
 // Возможные ошибки сервиса
@@ -20,69 +23,111 @@ var (
 	ErrInvalidImprovGoal    = errors.New("invalid improv goal")
 	ErrInvalidGender        = errors.New("invalid gender")
 	ErrInvalidCity          = errors.New("invalid city")
+	ErrCannotFavoriteSelf   = errors.New("cannot add own profile to favorites")
+	ErrCannotEndorseSelf    = errors.New("cannot endorse own profile")
+	ErrNoSharedTeam         = errors.New("only teammates can endorse a profile")
+	ErrInvalidAudioIntro    = errors.New("invalid audio introduction")
+	ErrInvalidLink          = errors.New("invalid link")
+	ErrInvalidAvailability  = errors.New("invalid availability")
+	ErrInvalidTag           = errors.New("invalid tag")
+	ErrInvalidLocation      = errors.New("invalid search location")
+	ErrInvalidSearchQuery   = errors.New("search query must be at most 200 characters")
 )
 
+// ConsentOrganizerContact is the audit name of the organizer contact setting
+const ConsentOrganizerContact = "organizer_contact"
+
 // TranslatedItem represents a catalog item with translations
 type TranslatedItem struct {
 	Code  string `json:"code"`
 	Label string `json:"label"`
 }
 
+// citySearchLimit caps city suggestions while the user is typing
+const citySearchLimit = 20
+
 // City represents a city
 type City struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	CountryCode string `json:"country_code"`
+	Region      string `json:"region,omitempty"`
+	Timezone    string `json:"timezone"`
 }
 
 type Media struct {
 	ID           int    `json:"id"`
 	URL          string `json:"url"`
 	ThumbnailURL string `json:"thumbnail_url"`
+	// Variants maps image sizes ("128", "512", "full") to URLs
+	Variants map[string]string `json:"variants,omitempty"`
 }
 
 // Profile represents profile data for response
 type Profile struct {
-	UserID         int       `json:"user_id"`
-	FullName       string    `json:"full_name"`
-	Birthday       time.Time `json:"birthday,omitempty"`
-	Gender         string    `json:"gender,omitempty"`
-	CityID         int       `json:"city_id,omitempty"`
-	Bio            string    `json:"bio,omitempty"`
-	Goal           string    `json:"goal,omitempty"`
-	LookingForTeam bool      `json:"looking_for_team"`
-	ImprovStyles   []string  `json:"improv_styles,omitempty"`
-	CreatedAt      time.Time `json:"created_at"`
-	Avatar         *Media    `json:"avatar,omitempty"`
-	Videos         []Media   `json:"videos,omitempty"`
+	UserID                int                `json:"user_id"`
+	FullName              string             `json:"full_name"`
+	Birthday              time.Time          `json:"birthday,omitempty"`
+	Gender                string             `json:"gender,omitempty"`
+	CityID                int                `json:"city_id,omitempty"`
+	Bio                   string             `json:"bio,omitempty"`
+	Goal                  string             `json:"goal,omitempty"` // Primary goal, the first of Goals
+	Goals                 []string           `json:"goals,omitempty"`
+	LookingForTeam        bool               `json:"looking_for_team"`
+	AllowOrganizerContact bool               `json:"allow_organizer_contact"`
+	IsFavorite            bool               `json:"is_favorite"`
+	IsVerified            bool               `json:"is_verified"`
+	ImprovStyles          []string           `json:"improv_styles,omitempty"`
+	Tags                  []string           `json:"tags,omitempty"`
+	CreatedAt             time.Time          `json:"created_at"`
+	DistanceKm            *float64           `json:"distance_km,omitempty"` // Set in searches near a point
+	Avatar                *Media             `json:"avatar,omitempty"`
+	AudioIntro            *Media             `json:"audio_intro,omitempty"`
+	Videos                []Media            `json:"videos,omitempty"`
+	Links                 *Links             `json:"links,omitempty"`
+	Availability          []AvailabilitySlot `json:"availability,omitempty"`
+	Endorsements          []Endorsement      `json:"endorsements,omitempty"`
 }
 
 // ProfileCreateRequest represents data needed to create a profile
 type ProfileCreateRequest struct {
-	UserID         int       `json:"user_id" validate:"required"`
-	FullName       string    `json:"full_name" validate:"required"`
-	Birthday       time.Time `json:"birthday"`
-	Gender         string    `json:"gender"`
-	CityID         int       `json:"city_id"`
-	Bio            string    `json:"bio"`
-	Goal           string    `json:"goal"`
-	ImprovStyles   []string  `json:"improv_styles"`
-	LookingForTeam bool      `json:"looking_for_team"`
-	Avatar         *int      `json:"avatar,omitempty"`
-	Videos         []int     `json:"videos,omitempty"`
+	UserID                int                `json:"user_id" validate:"required"`
+	FullName              string             `json:"full_name" validate:"required"`
+	Birthday              time.Time          `json:"birthday"`
+	Gender                string             `json:"gender"`
+	CityID                int                `json:"city_id"`
+	Bio                   string             `json:"bio"`
+	Goal                  string             `json:"goal"`
+	Goals                 []string           `json:"goals,omitempty"` // Merged with Goal, which becomes the primary goal
+	ImprovStyles          []string           `json:"improv_styles"`
+	Tags                  []string           `json:"tags,omitempty"`
+	LookingForTeam        bool               `json:"looking_for_team"`
+	AllowOrganizerContact bool               `json:"allow_organizer_contact"`
+	Avatar                *int               `json:"avatar,omitempty"`
+	AudioIntro            *int               `json:"audio_intro,omitempty"`
+	Videos                []int              `json:"videos,omitempty"`
+	Links                 *Links             `json:"links,omitempty"`
+	Availability          []AvailabilitySlot `json:"availability,omitempty"`
 }
 
 // ProfileUpdateRequest represents data needed to update a profile
 type ProfileUpdateRequest struct {
-	FullName       *string    `json:"full_name,omitempty"`
-	Birthday       *time.Time `json:"birthday,omitempty"`
-	Gender         *string    `json:"gender,omitempty"`
-	CityID         *int       `json:"city_id,omitempty"`
-	Bio            *string    `json:"bio,omitempty"`
-	Goal           *string    `json:"goal,omitempty"`
-	ImprovStyles   []string   `json:"improv_styles,omitempty"`
-	LookingForTeam *bool      `json:"looking_for_team,omitempty"`
-	Avatar         *int       `json:"avatar,omitempty"`
-	Videos         []int      `json:"videos,omitempty"`
+	FullName              *string            `json:"full_name,omitempty"`
+	Birthday              *time.Time         `json:"birthday,omitempty"`
+	Gender                *string            `json:"gender,omitempty"`
+	CityID                *int               `json:"city_id,omitempty"`
+	Bio                   *string            `json:"bio,omitempty"`
+	Goal                  *string            `json:"goal,omitempty"`
+	Goals                 []string           `json:"goals,omitempty"` // Replaces all goals; Goal alone replaces them with one
+	ImprovStyles          []string           `json:"improv_styles,omitempty"`
+	Tags                  []string           `json:"tags,omitempty"` // Replaces all tags; an empty list clears them
+	LookingForTeam        *bool              `json:"looking_for_team,omitempty"`
+	AllowOrganizerContact *bool              `json:"allow_organizer_contact,omitempty"`
+	Avatar                *int               `json:"avatar,omitempty"`
+	AudioIntro            *int               `json:"audio_intro,omitempty"`
+	Videos                []int              `json:"videos,omitempty"`
+	Links                 *Links             `json:"links,omitempty"`
+	Availability          []AvailabilitySlot `json:"availability,omitempty"`
 }
 
 type MediaRepository interface {
@@ -96,6 +141,9 @@ type ProfileRepository interface {
 	CheckProfileExists(userID int) (bool, error)
 	CreateProfile(tx *sql.Tx, profile *profile.ProfileModel) (time.Time, error)
 	AddImprovStyles(tx *sql.Tx, userID int, styles []string) error
+	AddImprovGoals(tx *sql.Tx, userID int, goals []string) error
+	GetImprovGoals(userID int) ([]string, error)
+	ClearImprovGoals(tx *sql.Tx, userID int) error
 	GetProfile(userID int) (*profile.ProfileModel, error)
 	GetProfileByUserID(userID int) (*profile.ProfileModel, error)
 
@@ -103,12 +151,34 @@ type ProfileRepository interface {
 	SetProfileAvatar(tx *sql.Tx, userID int, mediaID int) error
 	RemoveAvatar(tx *sql.Tx, userID int) error
 
+	GetProfileAudioIntro(userID int) (*int, error)
+	SetProfileAudioIntro(tx *sql.Tx, userID int, mediaID int) error
+
 	GetProfileVideos(userID int) ([]int, error)
 	SetProfileVideos(tx *sql.Tx, userID int, videos []int) error
 
+	GetProfileLinks(userID int) (map[string]string, error)
+	SetProfileLinks(tx *sql.Tx, userID int, links map[string]string) error
+	GetProfileTags(userID int) ([]string, error)
+	SetProfileTags(tx *sql.Tx, userID int, tags []string) error
+	SuggestTags(prefix string, limit int) ([]profile.TagSuggestion, error)
+
+	GetProfileAvailability(userID int) ([]profilerepo.AvailabilitySlot, error)
+	SetProfileAvailability(tx *sql.Tx, userID int, slots []profilerepo.AvailabilitySlot) error
+
+	GetSharedTeam(userID int, otherUserID int) (int, error)
+	AddEndorsement(endorserID int, profileUserID int, style string, teamID int) error
+	RemoveEndorsement(endorserID int, profileUserID int, style string) error
+	GetEndorsementCounts(userID int) ([]profilerepo.EndorsementCount, error)
+
 	ValidateMediaRole(role string) (bool, error)
 	GetImprovStyles(userID int) ([]string, error)
 	UpdateProfile(tx *sql.Tx, profile *profile.UpdateProfileModel) error
+	AddConsentAudit(tx *sql.Tx, userID int, setting string, allowed bool) error
+	AddFavorite(userID int, profileUserID int) error
+	RemoveFavorite(userID int, profileUserID int) error
+	GetFavorites(userID int, page int, pageSize int) ([]*profilerepo.ProfileModel, int, error)
+	GetRecommendationCandidates(userID int, now time.Time, limit int) ([]profilerepo.RecommendationCandidate, error)
 	ClearImprovStyles(tx *sql.Tx, userID int) error
 	ClearProfileMedia(tx *sql.Tx, userID int, role string) error
 	ValidateImprovGoal(goal string) (bool, error)
@@ -118,13 +188,10 @@ type ProfileRepository interface {
 	GetImprovStylesCatalog(lang string) ([]profile.TranslatedItem, error)
 	GetImprovGoalsCatalog(lang string) ([]profile.TranslatedItem, error)
 	GetGendersCatalog(lang string) ([]profile.TranslatedItem, error)
-	GetCities() ([]struct {
-		ID   int
-		Name string
-	}, error)
+	GetCities(filter profilerepo.CityFilter) ([]profilerepo.City, error)
 	SearchProfiles(
 		currentUserID int,
-		fullName *string,
+		query string,
 		lookingForTeam *bool,
 		goals []string,
 		improvStyles []string,
@@ -135,25 +202,61 @@ type ProfileRepository interface {
 		hasAvatar *bool,
 		hasVideo *bool,
 		createdAfter *time.Time,
+		availableOn []profilerepo.AvailabilitySlot,
+		verifiedOnly bool,
+		tags []string,
+		near *profilerepo.Near,
+		excludeContacted bool,
+		shuffleSeed *int64,
+		withFacets bool,
 		page int,
 		pageSize int,
-	) ([]*profilerepo.ProfileModel, int, error)
+	) ([]*profilerepo.ProfileModel, int, *profilerepo.SearchFacets, error)
+}
+
+// ActivityRecorder writes profile events to the activity feed
+type ActivityRecorder interface {
+	Record(ctx context.Context, activity feed.Activity) error
+}
+
+// SearchRecorder is notified after a user searches profiles
+type SearchRecorder interface {
+	RecordSearch(ctx context.Context, userID int) error
 }
 
 // ProfileServiceImpl реализует интерфейс ProfileService
 type ProfileServiceImpl struct {
-	profileRepo ProfileRepository
-	mediaRepo   MediaRepository
+	profileRepo      ProfileRepository
+	mediaRepo        MediaRepository
+	activityRecorder ActivityRecorder
+	searchRecorder   SearchRecorder
+	searchProvider   SearchProvider
 }
 
 // NewProfileService создает новый экземпляр сервиса профилей
 func NewProfileService(profileRepo ProfileRepository, mediaRepo MediaRepository) *ProfileServiceImpl {
 	return &ProfileServiceImpl{
-		profileRepo: profileRepo,
-		mediaRepo:   mediaRepo,
+		profileRepo:    profileRepo,
+		mediaRepo:      mediaRepo,
+		searchProvider: NewPostgresSearchProvider(profileRepo),
 	}
 }
 
+// SetActivityRecorder enables publishing profile updates to followers
+func (s *ProfileServiceImpl) SetActivityRecorder(recorder ActivityRecorder) {
+	s.activityRecorder = recorder
+}
+
+// SetSearchRecorder enables tracking of searches for onboarding progress
+func (s *ProfileServiceImpl) SetSearchRecorder(recorder SearchRecorder) {
+	s.searchRecorder = recorder
+}
+
+// SetSearchProvider replaces the database search, e.g. with an external search index
+func (s *ProfileServiceImpl) SetSearchProvider(provider SearchProvider) {
+	s.searchProvider = provider
+}
+
 func convertMedia(media *mediarepo.Media) *Media {
 	if media == nil {
 		return nil
@@ -162,6 +265,7 @@ func convertMedia(media *mediarepo.Media) *Media {
 		ID:           media.ID,
 		URL:          media.URL,
 		ThumbnailURL: media.ThumbnailURL,
+		Variants:     media.VariantURLs(),
 	}
 }
 
@@ -174,20 +278,30 @@ func convertMediaList(mediaList []mediarepo.Media) []Media {
 }
 
 // convertToProfile преобразует данные из репозитория в структуру для ответа
-func convertToProfile(profile *profilerepo.ProfileModel, styles []string, avatar *mediarepo.Media, videos []mediarepo.Media) *Profile {
+func convertToProfile(profile *profilerepo.ProfileModel, goals, styles, tags []string, links map[string]string, availability []profilerepo.AvailabilitySlot, endorsements []profilerepo.EndorsementCount, avatar, audioIntro *mediarepo.Media, videos []mediarepo.Media) *Profile {
 	return &Profile{
-		UserID:         profile.UserID,
-		FullName:       profile.FullName,
-		Birthday:       profile.Birthday,
-		Gender:         profile.Gender,
-		CityID:         profile.CityID,
-		Bio:            profile.Bio,
-		Goal:           profile.Goal,
-		LookingForTeam: profile.LookingForTeam,
-		ImprovStyles:   styles,
-		CreatedAt:      profile.CreatedAt,
-		Avatar:         convertMedia(avatar),
-		Videos:         convertMediaList(videos),
+		UserID:                profile.UserID,
+		FullName:              profile.FullName,
+		Birthday:              profile.Birthday,
+		Gender:                profile.Gender,
+		CityID:                profile.CityID,
+		Bio:                   profile.Bio,
+		Goal:                  profile.Goal,
+		Goals:                 primaryGoalFirst(profile.Goal, goals),
+		LookingForTeam:        profile.LookingForTeam,
+		AllowOrganizerContact: profile.AllowOrganizerContact,
+		IsFavorite:            profile.IsFavorite,
+		IsVerified:            profile.IsVerified,
+		ImprovStyles:          styles,
+		Tags:                  tags,
+		CreatedAt:             profile.CreatedAt,
+		DistanceKm:            profile.DistanceKm,
+		Avatar:                convertMedia(avatar),
+		AudioIntro:            convertMedia(audioIntro),
+		Videos:                convertMediaList(videos),
+		Links:                 convertLinks(links),
+		Availability:          convertAvailability(availability),
+		Endorsements:          convertEndorsements(endorsements),
 	}
 }
 
@@ -228,13 +342,10 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 		return nil, ErrInvalidCity
 	}
 
-	valid, err = s.profileRepo.ValidateImprovGoal(req.Goal)
-	if err != nil {
+	goals := mergeGoals(req.Goal, req.Goals)
+	if err = s.validateGoals(goals); err != nil {
 		return nil, err
 	}
-	if !valid {
-		return nil, ErrInvalidImprovGoal
-	}
 
 	// Validate styles
 	for _, style := range req.ImprovStyles {
@@ -247,6 +358,30 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 		}
 	}
 
+	if req.AudioIntro != nil {
+		if err = s.validateAudioIntro(req.UserID, *req.AudioIntro); err != nil {
+			return nil, err
+		}
+	}
+
+	var links map[string]string
+	if req.Links != nil {
+		links, err = normalizeLinks(*req.Links)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := parseAvailability(req.Availability)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx, err := s.profileRepo.BeginTx()
 	if err != nil {
@@ -261,14 +396,15 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 
 	// Create profile
 	profileModel := &profilerepo.ProfileModel{
-		UserID:         req.UserID,
-		FullName:       req.FullName,
-		Birthday:       req.Birthday,
-		Gender:         req.Gender,
-		CityID:         req.CityID,
-		Bio:            req.Bio,
-		Goal:           req.Goal,
-		LookingForTeam: req.LookingForTeam,
+		UserID:                req.UserID,
+		FullName:              req.FullName,
+		Birthday:              req.Birthday,
+		Gender:                req.Gender,
+		CityID:                req.CityID,
+		Bio:                   req.Bio,
+		Goal:                  goals[0],
+		LookingForTeam:        req.LookingForTeam,
+		AllowOrganizerContact: req.AllowOrganizerContact,
 	}
 
 	_, err = s.profileRepo.CreateProfile(tx, profileModel)
@@ -276,6 +412,19 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 		return nil, err
 	}
 
+	err = s.profileRepo.AddImprovGoals(tx, req.UserID, goals)
+	if err != nil {
+		return nil, err
+	}
+
+	// Opt-in to organizer contact is off by default, record explicit consent
+	if req.AllowOrganizerContact {
+		err = s.profileRepo.AddConsentAudit(tx, req.UserID, ConsentOrganizerContact, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Add improv styles if provided
 	if len(req.ImprovStyles) > 0 {
 		err = s.profileRepo.AddImprovStyles(tx, req.UserID, req.ImprovStyles)
@@ -291,6 +440,13 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 		}
 	}
 
+	if req.AudioIntro != nil {
+		err = s.profileRepo.SetProfileAudioIntro(tx, req.UserID, *req.AudioIntro)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Videos != nil {
 		err := s.profileRepo.SetProfileVideos(tx, req.UserID, req.Videos)
 		if err != nil {
@@ -298,6 +454,27 @@ func (s *ProfileServiceImpl) CreateProfile(req ProfileCreateRequest) (*Profile,
 		}
 	}
 
+	if len(links) > 0 {
+		err = s.profileRepo.SetProfileLinks(tx, req.UserID, links)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags) > 0 {
+		err = s.profileRepo.SetProfileTags(tx, req.UserID, tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(availability) > 0 {
+		err = s.profileRepo.SetProfileAvailability(tx, req.UserID, availability)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -310,31 +487,180 @@ func (s *ProfileServiceImpl) ExpandProfile(profile *profile.ProfileModel) (*Prof
 	if profile == nil {
 		return nil, nil
 	}
+	return s.expandProfile(profile, s.loadMedia([]*profilerepo.ProfileModel{profile})), nil
+}
+
+// expandProfiles expands a page of profiles, loading the media of all of them at once.
+// Lists show avatars small, so their URL points to the large variant when there is one;
+// the original stays available in the variants.
+func (s *ProfileServiceImpl) expandProfiles(profiles []*profilerepo.ProfileModel) []Profile {
+	media := s.loadMedia(profiles)
+	expanded := make([]Profile, 0, len(profiles))
+	for _, p := range profiles {
+		profile := s.expandProfile(p, media)
+		if profile.Avatar != nil {
+			if url, ok := profile.Avatar.Variants[mediarepo.VariantLarge]; ok {
+				profile.Avatar.URL = url
+			}
+		}
+		expanded = append(expanded, *profile)
+	}
+	return expanded
+}
+
+// loadMedia fetches the avatars, audio introductions and videos of the profiles by media ID
+func (s *ProfileServiceImpl) loadMedia(profiles []*profilerepo.ProfileModel) map[int]mediarepo.Media {
+	ids := []int{}
+	for _, p := range profiles {
+		if p.Avatar != nil {
+			ids = append(ids, *p.Avatar)
+		}
+		if p.AudioIntro != nil {
+			ids = append(ids, *p.AudioIntro)
+		}
+		ids = append(ids, p.Videos...)
+	}
 
-	// Get improv styles
-	styles, err := s.profileRepo.GetImprovStyles(profile.UserID)
+	media := make(map[int]mediarepo.Media, len(ids))
+	if len(ids) == 0 {
+		return media
+	}
+	found, err := s.mediaRepo.GetMediaByIDs(ids)
 	if err != nil {
-		log.Printf("failed to get improv styles: %v", err)
+		log.Printf("failed to get profile media: %v", err)
+	}
+	for _, m := range found {
+		media[m.ID] = m
+	}
+	return media
+}
+
+func (s *ProfileServiceImpl) expandProfile(profile *profile.ProfileModel, media map[int]mediarepo.Media) *Profile {
+	// Get improv styles and goals, unless they were loaded with the profile
+	styles := profile.ImprovStyles
+	if styles == nil {
+		var err error
+		styles, err = s.profileRepo.GetImprovStyles(profile.UserID)
+		if err != nil {
+			log.Printf("failed to get improv styles: %v", err)
+		}
 	}
 
-	// Get avatar
-	var avatar *mediarepo.Media
+	goals := profile.Goals
+	if goals == nil {
+		var err error
+		goals, err = s.profileRepo.GetImprovGoals(profile.UserID)
+		if err != nil {
+			log.Printf("failed to get improv goals: %v", err)
+		}
+	}
+
+	// Get tags
+	tags, err := s.profileRepo.GetProfileTags(profile.UserID)
+	if err != nil {
+		log.Printf("failed to get profile tags: %v", err)
+	}
+
+	// Get links
+	links, err := s.profileRepo.GetProfileLinks(profile.UserID)
+	if err != nil {
+		log.Printf("failed to get profile links: %v", err)
+	}
+
+	// Get availability
+	availability, err := s.profileRepo.GetProfileAvailability(profile.UserID)
+	if err != nil {
+		log.Printf("failed to get profile availability: %v", err)
+	}
+
+	// Get endorsement counts
+	endorsements, err := s.profileRepo.GetEndorsementCounts(profile.UserID)
+	if err != nil {
+		log.Printf("failed to get profile endorsements: %v", err)
+	}
+
+	// Pick the avatar, audio introduction and videos from the loaded media
+	var avatar, audioIntro *mediarepo.Media
 	if profile.Avatar != nil {
-		media, err := s.mediaRepo.GetMediaByID(*profile.Avatar)
-		if media != nil {
-			avatar = media
+		if m, ok := media[*profile.Avatar]; ok {
+			avatar = &m
+		}
+	}
+	if profile.AudioIntro != nil {
+		if m, ok := media[*profile.AudioIntro]; ok {
+			audioIntro = &m
 		}
+	}
+	var videos []mediarepo.Media
+	for _, id := range profile.Videos {
+		if m, ok := media[id]; ok {
+			videos = append(videos, m)
+		}
+	}
+
+	return convertToProfile(profile, goals, styles, tags, links, availability, endorsements, avatar, audioIntro, videos)
+}
+
+// validateGoals checks that there is at least one goal and all goals are in the catalog
+func (s *ProfileServiceImpl) validateGoals(goals []string) error {
+	if len(goals) == 0 {
+		return ErrInvalidImprovGoal
+	}
+	for _, goal := range goals {
+		valid, err := s.profileRepo.ValidateImprovGoal(goal)
 		if err != nil {
-			log.Printf("failed to get avatar media: %v", err)
+			return err
+		}
+		if !valid {
+			return ErrInvalidImprovGoal
+		}
+	}
+	return nil
+}
+
+// mergeGoals combines the legacy single goal with the goals list. The single goal,
+// when set, becomes the primary goal; otherwise the first of the list is.
+func mergeGoals(goal string, goals []string) []string {
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, g := range append([]string{goal}, goals...) {
+		if g == "" || seen[g] {
+			continue
+		}
+		seen[g] = true
+		merged = append(merged, g)
+	}
+	return merged
+}
+
+// primaryGoalFirst orders goals so the primary goal leads, as mergeGoals does on input
+func primaryGoalFirst(primary string, goals []string) []string {
+	if primary == "" {
+		return goals
+	}
+	ordered := []string{primary}
+	for _, g := range goals {
+		if g != primary {
+			ordered = append(ordered, g)
 		}
 	}
+	return ordered
+}
 
-	// Get videos
-	videos, err := s.mediaRepo.GetMediaByIDs(profile.Videos)
+// validateAudioIntro checks that the media is an audio clip uploaded by the user
+func (s *ProfileServiceImpl) validateAudioIntro(userID int, mediaID int) error {
+	media, err := s.mediaRepo.GetMediaByID(mediaID)
 	if err != nil {
-		log.Printf("failed to get videos media: %v", err)
+		if errors.Is(err, mediarepo.ErrMediaNotFound) {
+			return ErrInvalidAudioIntro
+		}
+		return err
 	}
-	return convertToProfile(profile, styles, avatar, videos), nil
+	// Role holds the media type (image, video or audio)
+	if media.Role != "audio" || media.UserID != userID {
+		return ErrInvalidAudioIntro
+	}
+	return nil
 }
 
 // GetProfileByUserID retrieves a profile by user ID
@@ -392,13 +718,16 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 		}
 	}
 
-	if req.Goal != nil {
-		valid, err := s.profileRepo.ValidateImprovGoal(*req.Goal)
-		if err != nil {
-			return nil, err
+	// Goals are replaced as a whole when either field is set
+	var goals []string
+	if req.Goal != nil || req.Goals != nil {
+		primary := ""
+		if req.Goal != nil {
+			primary = *req.Goal
 		}
-		if !valid {
-			return nil, ErrInvalidImprovGoal
+		goals = mergeGoals(primary, req.Goals)
+		if err := s.validateGoals(goals); err != nil {
+			return nil, err
 		}
 	}
 
@@ -413,6 +742,30 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 		}
 	}
 
+	if req.AudioIntro != nil {
+		if err := s.validateAudioIntro(userID, *req.AudioIntro); err != nil {
+			return nil, err
+		}
+	}
+
+	var links map[string]string
+	if req.Links != nil {
+		links, err = normalizeLinks(*req.Links)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := parseAvailability(req.Availability)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx, err := s.profileRepo.BeginTx()
 	if err != nil {
@@ -427,14 +780,18 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 
 	// Update profile
 	updateProfileModel := &profilerepo.UpdateProfileModel{
-		UserID:         profile.UserID,
-		FullName:       req.FullName,
-		Birthday:       req.Birthday,
-		Gender:         req.Gender,
-		CityID:         req.CityID,
-		Bio:            req.Bio,
-		Goal:           req.Goal,
-		LookingForTeam: req.LookingForTeam,
+		UserID:                profile.UserID,
+		FullName:              req.FullName,
+		Birthday:              req.Birthday,
+		Gender:                req.Gender,
+		CityID:                req.CityID,
+		Bio:                   req.Bio,
+		LookingForTeam:        req.LookingForTeam,
+		AllowOrganizerContact: req.AllowOrganizerContact,
+	}
+
+	if goals != nil {
+		updateProfileModel.Goal = &goals[0]
 	}
 
 	err = s.profileRepo.UpdateProfile(tx, updateProfileModel)
@@ -442,6 +799,25 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 		return nil, err
 	}
 
+	if goals != nil {
+		err = s.profileRepo.ClearImprovGoals(tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		err = s.profileRepo.AddImprovGoals(tx, userID, goals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Audit changes of the organizer contact consent
+	if req.AllowOrganizerContact != nil && *req.AllowOrganizerContact != profile.AllowOrganizerContact {
+		err = s.profileRepo.AddConsentAudit(tx, userID, ConsentOrganizerContact, *req.AllowOrganizerContact)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Clear and re-add styles
 	err = s.profileRepo.ClearImprovStyles(tx, userID)
 	if err != nil {
@@ -462,6 +838,13 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 		}
 	}
 
+	if req.AudioIntro != nil {
+		err = s.profileRepo.SetProfileAudioIntro(tx, userID, *req.AudioIntro)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Videos != nil {
 		err := s.profileRepo.SetProfileVideos(tx, userID, req.Videos)
 		if err != nil {
@@ -469,14 +852,50 @@ func (s *ProfileServiceImpl) UpdateProfile(userID int, req ProfileUpdateRequest)
 		}
 	}
 
+	// Links are replaced as a whole; empty links remove them
+	if req.Links != nil {
+		err = s.profileRepo.SetProfileLinks(tx, userID, links)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Tags are replaced as a whole; an empty list clears them
+	if req.Tags != nil {
+		err = s.profileRepo.SetProfileTags(tx, userID, tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Availability is replaced as a whole; an empty list clears it
+	if req.Availability != nil {
+		err = s.profileRepo.SetProfileAvailability(tx, userID, availability)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	s.recordActivity(feed.Activity{Type: feed.ActivityProfileUpdated, UserID: &userID})
+
 	return s.GetProfile(userID)
 }
 
+// recordActivity publishes an event to the feed; failures do not affect the profile
+func (s *ProfileServiceImpl) recordActivity(activity feed.Activity) {
+	if s.activityRecorder == nil {
+		return
+	}
+	if err := s.activityRecorder.Record(context.Background(), activity); err != nil {
+		log.Printf("Failed to record %s activity: %v", activity.Type, err)
+	}
+}
+
 // GetImprovStyles returns improv styles catalog with translations
 func (s *ProfileServiceImpl) GetImprovStyles(lang string) ([]TranslatedItem, error) {
 	repoItems, err := s.profileRepo.GetImprovStylesCatalog(lang)
@@ -528,9 +947,19 @@ func (s *ProfileServiceImpl) GetGenders(lang string) ([]TranslatedItem, error) {
 	return items, nil
 }
 
-// GetCities returns available cities
-func (s *ProfileServiceImpl) GetCities() ([]City, error) {
-	repoCities, err := s.profileRepo.GetCities()
+// GetCities returns cities in the given language, optionally limited to a country
+// and to names matching the query. Query results are capped for search-as-you-type.
+func (s *ProfileServiceImpl) GetCities(lang, country, query string) ([]City, error) {
+	filter := profilerepo.CityFilter{
+		Lang:        lang,
+		CountryCode: strings.ToUpper(strings.TrimSpace(country)),
+		Query:       strings.TrimSpace(query),
+	}
+	if filter.Query != "" {
+		filter.Limit = citySearchLimit
+	}
+
+	repoCities, err := s.profileRepo.GetCities(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -538,8 +967,13 @@ func (s *ProfileServiceImpl) GetCities() ([]City, error) {
 	cities := make([]City, len(repoCities))
 	for i, city := range repoCities {
 		cities[i] = City{
-			ID:   city.ID,
-			Name: city.Name,
+			ID:          city.ID,
+			Name:        city.Name,
+			CountryCode: city.CountryCode,
+			Timezone:    city.Timezone,
+		}
+		if city.Region != nil {
+			cities[i].Region = *city.Region
 		}
 	}
 	return cities, nil
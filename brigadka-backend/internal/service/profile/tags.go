@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+)
+
+type TagSuggestion = profilerepo.TagSuggestion
+
+// MaxProfileTags limits the number of tags on a profile
+const MaxProfileTags = 10
+
+// maxTagLength limits the length of a tag in characters
+const maxTagLength = 50
+
+// tagSuggestionLimit is the number of tags returned by autocomplete
+const tagSuggestionLimit = 10
+
+// normalizeTags normalizes the tags and drops duplicates, keeping the order
+func normalizeTags(tags []string) ([]string, error) {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, raw := range tags {
+		tag, err := normalizeTag(raw)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) > MaxProfileTags {
+		return nil, fmt.Errorf("%w: at most %d tags", ErrInvalidTag, MaxProfileTags)
+	}
+	return result, nil
+}
+
+// normalizeTag lowercases the tag, drops a leading "#" and collapses whitespace,
+// so "#МузИмпров" and "музимпров" are the same tag. Only letters, digits,
+// spaces, "-" and "_" are allowed.
+func normalizeTag(raw string) (string, error) {
+	tag := strings.TrimPrefix(strings.TrimSpace(raw), "#")
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+	if tag == "" || len([]rune(tag)) > maxTagLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, raw)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTag, raw)
+		}
+	}
+	return tag, nil
+}
+
+// SuggestTags returns tags starting with the query, most used first.
+// An empty query returns the most used tags.
+func (s *ProfileServiceImpl) SuggestTags(query string) ([]TagSuggestion, error) {
+	prefix := strings.TrimPrefix(strings.TrimSpace(query), "#")
+	prefix = strings.ToLower(strings.Join(strings.Fields(prefix), " "))
+	if len([]rune(prefix)) > maxTagLength {
+		return []TagSuggestion{}, nil
+	}
+	return s.profileRepo.SuggestTags(prefix, tagSuggestionLimit)
+}
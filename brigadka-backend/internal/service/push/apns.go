@@ -0,0 +1,161 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sideshow/apns2"
+	apns2payload "github.com/sideshow/apns2/payload"
+	"github.com/sideshow/apns2/token"
+)
+
+// APNSConfig holds the credentials of the app in Apple Push Notification Service
+type APNSConfig struct {
+	KeyID       string
+	TeamID      string
+	PrivateKey  []byte
+	BundleID    string
+	Development bool // Use the sandbox server
+}
+
+// Complete reports whether every credential needed to send is set
+func (c APNSConfig) Complete() bool {
+	return len(c.PrivateKey) > 0 && c.KeyID != "" && c.TeamID != "" && c.BundleID != ""
+}
+
+type apnsProvider struct {
+	config APNSConfig
+
+	mu     sync.Mutex
+	client *apns2.Client // Created on the first send
+}
+
+// NewAPNSProvider creates a provider sending notifications through Apple Push Notification Service.
+// The auth key is loaded on the first send.
+func NewAPNSProvider(config APNSConfig) Provider {
+	return &apnsProvider{config: config}
+}
+
+// apnsClient returns the APNS client, creating it from the auth key if needed
+func (p *apnsProvider) apnsClient() (*apns2.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	// Verify required APNS configuration
+	if !p.config.Complete() {
+		return nil, errors.New("incomplete APNS configuration")
+	}
+
+	// Create a new token based authentication for APNS
+	authKey, err := token.AuthKeyFromBytes(p.config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load APNS auth key: %w", err)
+	}
+
+	// Create a token client
+	authToken := &token.Token{
+		AuthKey: authKey,
+		KeyID:   p.config.KeyID,
+		TeamID:  p.config.TeamID,
+	}
+
+	// Determine if we should use development or production APNS server
+	var client *apns2.Client
+	if p.config.Development {
+		client = apns2.NewTokenClient(authToken).Development()
+	} else {
+		client = apns2.NewTokenClient(authToken).Production()
+	}
+
+	// Set timeout on the client
+	client.HTTPClient.Timeout = 15 * time.Second
+
+	p.client = client
+	return client, nil
+}
+
+// Send sends notifications to Apple Push Notification Service
+func (p *apnsProvider) Send(ctx context.Context, tokens []string, payload NotificationPayload) []TokenResult {
+	client, err := p.apnsClient()
+	if err != nil {
+		log.Printf("Warning: APNS is unavailable: %v", err)
+		return failAll(tokens, PlatformIOS, err)
+	}
+
+	// Build APNS notification payload
+	apnsPayload := apns2payload.NewPayload()
+	pushType, priority := apns2.PushTypeAlert, apns2.PriorityHigh
+	if payload.Silent {
+		// Background notifications may carry nothing but content-available in aps
+		apnsPayload.ContentAvailable()
+		pushType, priority = apns2.PushTypeBackground, apns2.PriorityLow
+	} else {
+		apnsPayload.
+			AlertTitle(payload.Title).
+			AlertBody(payload.Body).
+			Sound(defaultIfEmpty(payload.Sound, "default"))
+
+		// Set badge if provided
+		if payload.Badge > 0 {
+			apnsPayload.Badge(payload.Badge)
+		}
+
+		// If an image URL is provided, add it as a media attachment
+		if payload.ImageURL != "" {
+			apnsPayload.MutableContent()
+			apnsPayload.Custom("image_url", payload.ImageURL)
+		}
+	}
+	for key, value := range payload.Data {
+		apnsPayload.Custom(key, value)
+	}
+
+	// Process all tokens
+	results := make([]TokenResult, 0, len(tokens))
+	for _, token := range tokens {
+		// Create notification
+		notification := &apns2.Notification{
+			DeviceToken: token,
+			Topic:       p.config.BundleID,
+			Payload:     apnsPayload,
+			Priority:    priority,
+			PushType:    pushType,
+			CollapseID:  payload.CollapseID,
+		}
+
+		// Send notification
+		result := TokenResult{Token: token, Platform: PlatformIOS}
+		resp, err := client.Push(notification)
+		switch {
+		case err != nil:
+			result.Error = fmt.Sprintf("failed to send APNS notification: %v", err)
+		case resp.StatusCode == http.StatusOK:
+			result.Success = true
+		default:
+			result.Error = fmt.Sprintf("%d %s", resp.StatusCode, resp.Reason)
+			// 410 Gone: the app was uninstalled, the token is no longer valid
+			result.Removed = resp.StatusCode == http.StatusGone || isDeadAPNSToken(resp.Reason)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// isDeadAPNSToken reports whether APNS rejected the token itself rather than the notification
+func isDeadAPNSToken(reason string) bool {
+	switch reason {
+	case apns2.ReasonBadDeviceToken, apns2.ReasonDeviceTokenNotForTopic, apns2.ReasonUnregistered:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package push
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
+)
+
+// DefaultTokenMaxAgeDays is how long a token lives without its app registering it again.
+// Apps register their token on every start, so older tokens belong to devices that
+// uninstalled the app or were not used for months.
+const DefaultTokenMaxAgeDays = 90
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// TokenCleaner periodically removes the tokens not registered again for too long
+type TokenCleaner struct {
+	repository  pushrepo.Repository
+	maxAge      time.Duration
+	now         func() time.Time
+	runObserver RunObserver // Optional
+}
+
+// NewTokenCleaner creates a cleaner removing tokens older than maxAge
+func NewTokenCleaner(repo pushrepo.Repository, maxAge time.Duration) *TokenCleaner {
+	return &TokenCleaner{
+		repository: repo,
+		maxAge:     maxAge,
+		now:        time.Now,
+	}
+}
+
+// SetRunObserver reports the outcome of every run of the cleaner
+func (c *TokenCleaner) SetRunObserver(observer RunObserver) {
+	c.runObserver = observer
+}
+
+// Run removes stale tokens every interval until the context is cancelled
+func (c *TokenCleaner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.Cleanup(ctx)
+		if c.runObserver != nil {
+			c.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to clean up stale push tokens: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup removes the tokens not registered again within maxAge
+func (c *TokenCleaner) Cleanup(ctx context.Context) error {
+	deleted, err := c.repository.DeleteTokensNotSeenSince(ctx, c.now().Add(-c.maxAge))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Removed %d stale push tokens", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package push
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDigestWindows are the aggregation windows of the categories coalesced by default.
+// Notifications of other categories are sent right away.
+var DefaultDigestWindows = map[string]time.Duration{
+	CategoryNewMessage:      15 * time.Second,
+	CategoryTeamApplication: time.Minute,
+}
+
+// digestNouns name what a digest of the category counts
+var digestNouns = map[string]string{
+	CategoryNewMessage:      "messages",
+	CategoryNewMatch:        "matches",
+	CategoryTeamApplication: "team notifications",
+	CategoryAnnouncement:    "announcements",
+}
+
+// digestPayload combines the notifications of one category queued for a user during
+// the aggregation window, oldest first, into one, e.g. "5 new messages in 2 chats"
+func digestPayload(category string, payloads []NotificationPayload) NotificationPayload {
+	latest := payloads[len(payloads)-1]
+	if len(payloads) == 1 {
+		return latest
+	}
+
+	noun, ok := digestNouns[category]
+	if !ok {
+		noun = "notifications"
+	}
+
+	// Notifications of one thread, e.g. a chat, share the collapse ID
+	threads := make(map[string]bool)
+	sameTitle := true
+	badge := 0
+	for _, payload := range payloads {
+		threads[payload.CollapseID] = true
+		if payload.Title != latest.Title {
+			sameTitle = false
+		}
+		if payload.Badge > badge {
+			badge = payload.Badge
+		}
+	}
+
+	digest := NotificationPayload{
+		Title:      latest.Title,
+		Body:       fmt.Sprintf("%d new %s", len(payloads), noun),
+		Sound:      latest.Sound,
+		Badge:      badge,
+		Category:   category,
+		CollapseID: latest.CollapseID,
+	}
+	if !sameTitle {
+		digest.Title = "New " + noun
+	}
+	if len(threads) > 1 {
+		digest.CollapseID = "digest:" + category
+		if category == CategoryNewMessage {
+			digest.Body = fmt.Sprintf("%d new messages in %d chats", len(payloads), len(threads))
+		}
+	}
+	return digest
+}
@@ -0,0 +1,125 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"google.golang.org/api/option"
+)
+
+type fcmProvider struct {
+	credentialsFile string
+
+	mu     sync.Mutex
+	client *messaging.Client // Created on the first send
+}
+
+// NewFCMProvider creates a provider sending notifications through Firebase Cloud Messaging
+// with the service account in credentialsFile. Firebase is initialized on the first send,
+// and again on the next one if that failed, so the server starts without reaching it.
+func NewFCMProvider(credentialsFile string) Provider {
+	return &fcmProvider{credentialsFile: credentialsFile}
+}
+
+// messagingClient returns the Firebase messaging client, initializing Firebase if needed
+func (p *fcmProvider) messagingClient(ctx context.Context) (*messaging.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(p.credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app: %w", err)
+	}
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firebase messaging client: %w", err)
+	}
+
+	p.client = client
+	return client, nil
+}
+
+// Send sends notifications to Firebase Cloud Messaging
+func (p *fcmProvider) Send(ctx context.Context, tokens []string, payload NotificationPayload) []TokenResult {
+	client, err := p.messagingClient(ctx)
+	if err != nil {
+		log.Printf("Warning: FCM is unavailable: %v", err)
+		return failAll(tokens, PlatformAndroid, err)
+	}
+
+	results := make([]TokenResult, 0, len(tokens))
+
+	// Send messages individually to each token
+	for _, token := range tokens {
+		// Create message for a single token
+		message := &messaging.Message{
+			Token: token,
+			Data:  payload.Data,
+		}
+		if payload.Silent {
+			// A data message without a notification is handed to the app instead of shown
+			message.Android = &messaging.AndroidConfig{Priority: "high"}
+		} else {
+			message.Notification, message.Android = fcmNotification(payload)
+		}
+
+		// Send individual message
+		result := TokenResult{Token: token, Platform: PlatformAndroid}
+		if _, err := client.Send(ctx, message); err != nil {
+			log.Printf("Failed to send FCM message to token %s: %v", token, err)
+			result.Error = err.Error()
+
+			// NotRegistered or a malformed token: the app was uninstalled or the token expired,
+			// and the token would fail on every send
+			result.Removed = messaging.IsUnregistered(err) || messaging.IsInvalidArgument(err)
+		} else {
+			result.Success = true
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// fcmNotification builds the notification shown on the device and its android options
+func fcmNotification(payload NotificationPayload) (*messaging.Notification, *messaging.AndroidConfig) {
+	// Create notification
+	notification := &messaging.Notification{
+		Title: payload.Title,
+		Body:  payload.Body,
+	}
+
+	// Create android config with icon from the image URL
+	androidConfig := &messaging.AndroidConfig{
+		Notification: &messaging.AndroidNotification{
+			Sound: defaultIfEmpty(payload.Sound, "default"),
+		},
+	}
+
+	// The tag replaces a shown notification, the collapse key a pending one
+	if payload.CollapseID != "" {
+		androidConfig.CollapseKey = payload.CollapseID
+		androidConfig.Notification.Tag = payload.CollapseID
+	}
+
+	// Launchers that show a counter take it from the notification
+	if payload.Badge > 0 {
+		badge := payload.Badge
+		androidConfig.Notification.NotificationCount = &badge
+	}
+
+	// Set icon for Android if image URL is provided
+	if payload.ImageURL != "" {
+		androidConfig.Notification.Icon = payload.ImageURL
+	}
+
+	return notification, androidConfig
+}
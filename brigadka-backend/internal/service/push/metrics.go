@@ -0,0 +1,153 @@
+package push
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyBucketsMs are the upper bounds of the provider latency histogram
+var latencyBucketsMs = []int64{50, 100, 250, 500, 1000, 2500, 5000}
+
+// uncategorized labels the metrics of notifications without a category, e.g. reminders
+const uncategorized = "uncategorized"
+
+// DeliveryMetrics are counters of the notifications of one category sent to one platform since startup
+type DeliveryMetrics struct {
+	Platform string `json:"platform"`
+	Category string `json:"category"`
+	Sent     int64  `json:"sent"`    // Tokens the provider accepted the notification for
+	Failed   int64  `json:"failed"`  // Tokens the provider did not accept it for, including removed ones
+	Removed  int64  `json:"removed"` // Tokens the provider reported dead
+	Disabled int64  `json:"disabled"`
+	// One observation per call to the provider, which sends to all the devices of a user
+	AvgLatencyMs float64         `json:"avg_latency_ms"`
+	Latency      []LatencyBucket `json:"latency"`
+	LastError    string          `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time      `json:"last_error_at,omitempty"`
+}
+
+// LatencyBucket counts provider calls that took up to LeMs milliseconds and longer than
+// the previous bucket. The last bucket, without LeMs, counts the slower ones.
+type LatencyBucket struct {
+	LeMs  int64 `json:"le_ms,omitempty"`
+	Count int64 `json:"count"`
+}
+
+type metricsKey struct {
+	platform string
+	category string
+}
+
+type deliveryCounters struct {
+	sent, failed, removed, disabled int64
+	calls                           int64
+	totalLatency                    time.Duration
+	buckets                         []int64 // One more than latencyBucketsMs
+	lastError                       string
+	lastErrorAt                     time.Time
+}
+
+// deliveryMetrics keeps the counters of every platform and category
+type deliveryMetrics struct {
+	mu       sync.Mutex
+	counters map[metricsKey]*deliveryCounters
+}
+
+func newDeliveryMetrics() *deliveryMetrics {
+	return &deliveryMetrics{counters: map[metricsKey]*deliveryCounters{}}
+}
+
+func (m *deliveryMetrics) get(platform, category string) *deliveryCounters {
+	if category == "" {
+		category = uncategorized
+	}
+	key := metricsKey{platform, category}
+	counters, ok := m.counters[key]
+	if !ok {
+		counters = &deliveryCounters{buckets: make([]int64, len(latencyBucketsMs)+1)}
+		m.counters[key] = counters
+	}
+	return counters
+}
+
+// record counts the results of a call to the provider of the platform
+func (m *deliveryMetrics) record(platform, category string, results []TokenResult, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := m.get(platform, category)
+	for _, result := range results {
+		switch {
+		case result.Success:
+			counters.sent++
+		case result.Disabled:
+			counters.disabled++
+		default:
+			counters.failed++
+			if result.Removed {
+				counters.removed++
+			}
+			counters.lastError = result.Error
+			counters.lastErrorAt = time.Now()
+		}
+	}
+	if len(results) > 0 && results[0].Disabled {
+		// No provider was called
+		return
+	}
+
+	counters.calls++
+	counters.totalLatency += latency
+	bucket := len(latencyBucketsMs)
+	for i, le := range latencyBucketsMs {
+		if latency <= time.Duration(le)*time.Millisecond {
+			bucket = i
+			break
+		}
+	}
+	counters.buckets[bucket]++
+}
+
+// snapshot returns the metrics ordered by platform and category
+func (m *deliveryMetrics) snapshot() []DeliveryMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]DeliveryMetrics, 0, len(m.counters))
+	for key, counters := range m.counters {
+		metrics := DeliveryMetrics{
+			Platform:  key.platform,
+			Category:  key.category,
+			Sent:      counters.sent,
+			Failed:    counters.failed,
+			Removed:   counters.removed,
+			Disabled:  counters.disabled,
+			Latency:   make([]LatencyBucket, 0, len(counters.buckets)),
+			LastError: counters.lastError,
+		}
+		if counters.calls > 0 {
+			metrics.AvgLatencyMs = float64(counters.totalLatency.Milliseconds()) / float64(counters.calls)
+		}
+		for i, count := range counters.buckets {
+			bucket := LatencyBucket{Count: count}
+			if i < len(latencyBucketsMs) {
+				bucket.LeMs = latencyBucketsMs[i]
+			}
+			metrics.Latency = append(metrics.Latency, bucket)
+		}
+		if !counters.lastErrorAt.IsZero() {
+			at := counters.lastErrorAt
+			metrics.LastErrorAt = &at
+		}
+		result = append(result, metrics)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Platform != result[j].Platform {
+			return result[i].Platform < result[j].Platform
+		}
+		return result[i].Category < result[j].Category
+	})
+	return result
+}
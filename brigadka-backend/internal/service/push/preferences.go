@@ -0,0 +1,173 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
+)
+
+// Notification categories users can turn off. Notifications without a category,
+// such as reminders the user scheduled and account notices, are always sent.
+const (
+	CategoryNewMessage      = "new_message"
+	CategoryNewMatch        = "new_match"
+	CategoryTeamApplication = "team_application"
+	CategoryAnnouncement    = "announcement"
+)
+
+var (
+	ErrInvalidQuietHours = errors.New("quiet hours must be two different times formatted as HH:MM")
+	ErrInvalidTimezone   = errors.New("unknown time zone")
+)
+
+// NotificationPreferences are the notification categories a user receives and their quiet hours
+type NotificationPreferences struct {
+	NewMessage      bool        `json:"new_message"`
+	NewMatch        bool        `json:"new_match"`
+	TeamApplication bool        `json:"team_application"`
+	Announcements   bool        `json:"announcements"`
+	QuietHours      *QuietHours `json:"quiet_hours"` // Null when the user has no quiet hours
+	// IANA time zone of the quiet hours; the time zone of the user's city when empty
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// QuietHours is a daily period without categorized notifications. It may cross
+// midnight, e.g. from 22:00 to 08:00.
+type QuietHours struct {
+	Start string `json:"start"` // HH:MM
+	End   string `json:"end"`   // HH:MM
+}
+
+const quietHoursLayout = "15:04"
+
+// GetPreferences retrieves the notification preferences of a user
+func (s *pushService) GetPreferences(ctx context.Context, userID int) (*NotificationPreferences, error) {
+	stored, err := s.repository.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := &NotificationPreferences{
+		NewMessage:      stored.NewMessage,
+		NewMatch:        stored.NewMatch,
+		TeamApplication: stored.TeamApplication,
+		Announcements:   stored.Announcements,
+		Timezone:        stored.Timezone,
+	}
+	if stored.QuietHoursStart != nil && stored.QuietHoursEnd != nil {
+		prefs.QuietHours = &QuietHours{
+			Start: formatMinutes(*stored.QuietHoursStart),
+			End:   formatMinutes(*stored.QuietHoursEnd),
+		}
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences replaces the notification preferences of a user
+func (s *pushService) UpdatePreferences(ctx context.Context, userID int, prefs NotificationPreferences) error {
+	stored := pushrepo.NotificationPreferences{
+		NewMessage:      prefs.NewMessage,
+		NewMatch:        prefs.NewMatch,
+		TeamApplication: prefs.TeamApplication,
+		Announcements:   prefs.Announcements,
+		Timezone:        prefs.Timezone,
+	}
+
+	if prefs.Timezone != "" {
+		if _, err := time.LoadLocation(prefs.Timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+
+	if prefs.QuietHours != nil {
+		start, err := parseMinutes(prefs.QuietHours.Start)
+		if err != nil {
+			return ErrInvalidQuietHours
+		}
+		end, err := parseMinutes(prefs.QuietHours.End)
+		if err != nil || start == end {
+			return ErrInvalidQuietHours
+		}
+		stored.QuietHoursStart, stored.QuietHoursEnd = &start, &end
+	}
+
+	return s.repository.SavePreferences(ctx, userID, stored)
+}
+
+// allowsNotification checks the preferences of the recipient. When they cannot be
+// loaded, the notification is sent: an unwanted notification beats a lost one.
+func (s *pushService) allowsNotification(ctx context.Context, userID int, category string) bool {
+	if category == "" {
+		return true
+	}
+
+	prefs, err := s.repository.GetPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get notification preferences of user %d: %v", userID, err)
+		return true
+	}
+
+	if !categoryEnabled(prefs, category) {
+		return false
+	}
+	return !inQuietHours(prefs, time.Now())
+}
+
+func categoryEnabled(prefs *pushrepo.NotificationPreferences, category string) bool {
+	switch category {
+	case CategoryNewMessage:
+		return prefs.NewMessage
+	case CategoryNewMatch:
+		return prefs.NewMatch
+	case CategoryTeamApplication:
+		return prefs.TeamApplication
+	case CategoryAnnouncement:
+		return prefs.Announcements
+	default:
+		return true
+	}
+}
+
+// inQuietHours reports whether now falls into the quiet hours of the user, in the
+// user's time zone, else the time zone of their city, else UTC
+func inQuietHours(prefs *pushrepo.NotificationPreferences, now time.Time) bool {
+	if prefs.QuietHoursStart == nil || prefs.QuietHoursEnd == nil {
+		return false
+	}
+
+	location := time.UTC
+	for _, name := range []string{prefs.Timezone, prefs.CityTimezone} {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			location = loc
+			break
+		}
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	start, end := *prefs.QuietHoursStart, *prefs.QuietHoursEnd
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// The quiet hours cross midnight
+	return minute >= start || minute < end
+}
+
+func parseMinutes(value string) (int, error) {
+	t, err := time.Parse(quietHoursLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,44 @@
+package push
+
+import (
+	"context"
+	"log"
+)
+
+// Platforms of push tokens
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+)
+
+// Provider sends notifications to devices of one platform and reports the outcome
+// for each token. Tokens the provider knows are no longer registered come back with
+// Removed set; the service deletes them.
+type Provider interface {
+	Send(ctx context.Context, tokens []string, payload NotificationPayload) []TokenResult
+}
+
+type logProvider struct {
+	platform string
+}
+
+// NewLogProvider creates a provider that only logs notifications and reports them
+// delivered, for local development and integration tests without FCM or APNS credentials
+func NewLogProvider(platform string) Provider {
+	return &logProvider{platform: platform}
+}
+
+// Send logs the notification for every token
+func (p *logProvider) Send(ctx context.Context, tokens []string, payload NotificationPayload) []TokenResult {
+	results := make([]TokenResult, 0, len(tokens))
+	for _, token := range tokens {
+		if payload.Silent {
+			log.Printf("Silent push to %s token %s: %v", p.platform, token, payload.Data)
+		} else {
+			log.Printf("Push to %s token %s: %q %q (category %q, collapse ID %q, badge %d)",
+				p.platform, token, payload.Title, payload.Body, payload.Category, payload.CollapseID, payload.Badge)
+		}
+		results = append(results, TokenResult{Token: token, Platform: p.platform, Success: true})
+	}
+	return results
+}
@@ -0,0 +1,276 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
+)
+
+// Queue defaults
+const (
+	DefaultQueueBatchSize   = 50
+	DefaultQueueMaxAttempts = 5
+)
+
+const (
+	// queueLease is how long a claimed delivery waits before another worker retries it
+	queueLease = 2 * time.Minute
+	// deliveryTimeout bounds a single attempt, well within the lease
+	deliveryTimeout = 30 * time.Second
+	retryBaseDelay  = 10 * time.Second
+	retryMaxDelay   = 30 * time.Minute
+)
+
+// Sender delivers a notification to the devices of a user right away
+type Sender interface {
+	SendNotification(ctx context.Context, userID int, payload NotificationPayload) error
+}
+
+// QueueStats are counters of the delivery queue since startup
+type QueueStats struct {
+	Enqueued     int64 `json:"enqueued"`
+	Sent         int64 `json:"sent"`
+	Coalesced    int64 `json:"coalesced"` // Sent as part of a digest of several notifications
+	Skipped      int64 `json:"skipped"`   // Users without registered devices
+	Retried      int64 `json:"retried"`
+	DeadLettered int64 `json:"dead_lettered"` // Ran out of attempts
+}
+
+// Queue sends push notifications in the background. Notifications are stored in
+// the database, so they survive restarts, and failed attempts are retried with
+// exponential backoff until they run out of attempts and stay as dead letters.
+type Queue struct {
+	repository  pushrepo.Repository
+	sender      Sender
+	batchSize   int
+	maxAttempts int
+	now         func() time.Time
+	wake        chan struct{}
+	runObserver RunObserver // Optional
+	// Categories whose notifications are held for the window and sent as one digest
+	digestWindows map[string]time.Duration
+
+	enqueued     atomic.Int64
+	sent         atomic.Int64
+	coalesced    atomic.Int64
+	skipped      atomic.Int64
+	retried      atomic.Int64
+	deadLettered atomic.Int64
+}
+
+// NewQueue creates a queue delivering notifications through sender
+func NewQueue(repo pushrepo.Repository, sender Sender, maxAttempts int) *Queue {
+	return &Queue{
+		repository:    repo,
+		sender:        sender,
+		batchSize:     DefaultQueueBatchSize,
+		maxAttempts:   maxAttempts,
+		now:           time.Now,
+		wake:          make(chan struct{}, 1),
+		digestWindows: make(map[string]time.Duration),
+	}
+}
+
+// SetDigestWindow holds the notifications of the category for the window after the
+// first one is queued and sends those queued meanwhile for a user as one digest,
+// e.g. "5 new messages in 2 chats". A zero window sends every notification right away.
+func (q *Queue) SetDigestWindow(category string, window time.Duration) {
+	if window <= 0 {
+		delete(q.digestWindows, category)
+		return
+	}
+	q.digestWindows[category] = window
+}
+
+// SetRunObserver reports the outcome of every run of the queue worker
+func (q *Queue) SetRunObserver(observer RunObserver) {
+	q.runObserver = observer
+}
+
+// SendNotification queues a notification for the user; it is sent by Run
+func (q *Queue) SendNotification(ctx context.Context, userID int, payload NotificationPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	window := q.digestWindows[payload.Category]
+	if err := q.repository.EnqueueDelivery(ctx, userID, payload.Category, string(data), q.now().Add(window)); err != nil {
+		return err
+	}
+	q.enqueued.Add(1)
+
+	// Wake the worker instead of waiting for the next poll; a digest waits for the poll after its window
+	if window == 0 {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Stats returns the counters of the queue
+func (q *Queue) Stats() QueueStats {
+	return QueueStats{
+		Enqueued:     q.enqueued.Load(),
+		Sent:         q.sent.Load(),
+		Coalesced:    q.coalesced.Load(),
+		Skipped:      q.skipped.Load(),
+		Retried:      q.retried.Load(),
+		DeadLettered: q.deadLettered.Load(),
+	}
+}
+
+// Run sends the due notifications every interval, and as soon as one is queued,
+// until the context is cancelled. Deliveries queued on other replicas are picked up by the poll.
+func (q *Queue) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := q.Process(ctx)
+		if q.runObserver != nil {
+			q.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to process push queue: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// Process sends the notifications that are due, a batch at a time, the batch in parallel
+func (q *Queue) Process(ctx context.Context) error {
+	for {
+		now := q.now()
+		deliveries, err := q.repository.ClaimDeliveries(ctx, now, now.Add(queueLease), q.batchSize)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, group := range q.groupDeliveries(deliveries) {
+			wg.Add(1)
+			go func(group []pushrepo.Delivery) {
+				defer wg.Done()
+				q.deliver(ctx, group)
+			}(group)
+		}
+		wg.Wait()
+
+		if len(deliveries) < q.batchSize {
+			return nil
+		}
+	}
+}
+
+// groupDeliveries puts the claimed deliveries of a user in a category with a digest
+// window together, in the order they were queued; any other delivery is sent on its own
+func (q *Queue) groupDeliveries(deliveries []pushrepo.Delivery) [][]pushrepo.Delivery {
+	type digestKey struct {
+		userID   int
+		category string
+	}
+
+	var groups [][]pushrepo.Delivery
+	digests := make(map[digestKey]int)
+	for _, delivery := range deliveries {
+		if _, ok := q.digestWindows[delivery.Category]; !ok {
+			groups = append(groups, []pushrepo.Delivery{delivery})
+			continue
+		}
+		key := digestKey{delivery.UserID, delivery.Category}
+		if i, ok := digests[key]; ok {
+			groups[i] = append(groups[i], delivery)
+			continue
+		}
+		digests[key] = len(groups)
+		groups = append(groups, []pushrepo.Delivery{delivery})
+	}
+	return groups
+}
+
+// deliver makes an attempt to send claimed deliveries of a user, several as one digest,
+// and records the outcome for each
+func (q *Queue) deliver(ctx context.Context, group []pushrepo.Delivery) {
+	var deliveries []pushrepo.Delivery
+	var payloads []NotificationPayload
+	for _, delivery := range group {
+		var payload NotificationPayload
+		if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+			q.fail(ctx, delivery, err)
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+		payloads = append(payloads, payload)
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	delivery := deliveries[0]
+
+	sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	err := q.sender.SendNotification(sendCtx, delivery.UserID, digestPayload(delivery.Category, payloads))
+	cancel()
+
+	// Deliveries of a digest are retried at the same time, so they are sent together again
+	retryAt := q.now().Add(retryDelay(delivery.Attempts))
+	for _, delivery := range deliveries {
+		switch {
+		case err == nil:
+			q.sent.Add(1)
+			if len(deliveries) > 1 {
+				q.coalesced.Add(1)
+			}
+		case errors.Is(err, ErrNoTokens), errors.Is(err, ErrPushDisabled):
+			// Nothing to retry until the user registers a device or the platform is enabled
+			q.skipped.Add(1)
+		case delivery.Attempts >= q.maxAttempts:
+			q.fail(ctx, delivery, err)
+			continue
+		default:
+			q.retried.Add(1)
+			if err := q.repository.RetryDelivery(ctx, delivery.ID, retryAt, err.Error()); err != nil {
+				log.Printf("Failed to schedule retry of push delivery %s: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		if err := q.repository.CompleteDelivery(ctx, delivery.ID); err != nil {
+			log.Printf("Failed to complete push delivery %s: %v", delivery.ID, err)
+		}
+	}
+}
+
+// fail moves a delivery to the dead letters
+func (q *Queue) fail(ctx context.Context, delivery pushrepo.Delivery, cause error) {
+	q.deadLettered.Add(1)
+	log.Printf("Push delivery %s to user %d failed after %d attempts: %v", delivery.ID, delivery.UserID, delivery.Attempts, cause)
+	if err := q.repository.FailDelivery(ctx, delivery.ID, q.now(), cause.Error()); err != nil {
+		log.Printf("Failed to record failed push delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// retryDelay doubles the wait after every failed attempt, up to retryMaxDelay
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
@@ -5,20 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
-	"github.com/sideshow/apns2"
-	apns2payload "github.com/sideshow/apns2/payload"
-	"github.com/sideshow/apns2/token"
-
-	"firebase.google.com/go/v4/messaging"
 	pushrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/push"
 )
 
 var (
 	ErrTokenNotFound = errors.New("token not found")
+	ErrNoTokens      = errors.New("no tokens found for user")
+	// ErrPushDisabled means no provider is configured for the platforms of the user's devices
+	ErrPushDisabled = errors.New("push notifications are disabled for the user's devices")
 )
 
 // NotificationPayload represents a push notification payload
@@ -28,6 +25,34 @@ type NotificationPayload struct {
 	Badge    int    `json:"badge,omitempty"`
 	Sound    string `json:"sound,omitempty"`
 	ImageURL string `json:"imageUrl,omitempty"`
+	// One of the Category constants; the recipient's preferences decide whether it is sent
+	Category string `json:"category,omitempty"`
+	// Notifications with the same collapse ID replace each other on the device instead of stacking
+	CollapseID string `json:"collapseId,omitempty"`
+	// A silent notification is not shown; it wakes the app to act on Data in the background
+	Silent bool `json:"silent,omitempty"`
+	// Key-value pairs passed to the app with the notification
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// TokenResult is the answer of the push provider for one device
+type TokenResult struct {
+	Token    string `json:"token"`
+	Platform string `json:"platform"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`    // Provider response when the device did not get the notification
+	Removed  bool   `json:"removed,omitempty"`  // The provider reported the token dead, so it was deleted
+	Disabled bool   `json:"disabled,omitempty"` // No provider is configured for the platform
+}
+
+// RegisteredToken is a device the user registered for push notifications
+type RegisteredToken struct {
+	Token         string     `json:"token"`
+	Platform      string     `json:"platform"`
+	DeviceID      string     `json:"device_id,omitempty"`
+	LastSeenAt    time.Time  `json:"last_seen_at"`              // When the app last registered the token
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"` // When a notification was last delivered to the device
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // PushService defines the operations for push notifications
@@ -36,38 +61,26 @@ type PushService interface {
 	DeleteToken(ctx context.Context, userID int, token string) error
 	SendNotification(ctx context.Context, userID int, payload NotificationPayload) error
 	SendNotificationToTokens(ctx context.Context, userID int, tokens []string, payload NotificationPayload) error
+	SendTestNotification(ctx context.Context, userID int) ([]TokenResult, error)
+	GetTokens(ctx context.Context, userID int) ([]RegisteredToken, error)
+	GetPreferences(ctx context.Context, userID int) (*NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID int, prefs NotificationPreferences) error
+	GetMetrics() []DeliveryMetrics
 }
 
 type pushService struct {
-	repository      pushrepo.Repository
-	firebaseClient  *messaging.Client
-	apnsKeyID       string
-	apnsTeamID      string
-	apnsPrivateKey  []byte
-	apnsBundleID    string
-	apnsDevelopment bool
+	repository pushrepo.Repository
+	providers  map[string]Provider // By platform
+	metrics    *deliveryMetrics
 }
 
-// Config holds the configuration for the push service
-type Config struct {
-	FCMServerKey    string
-	APNSKeyID       string
-	APNSTeamID      string
-	APNSPrivateKey  []byte
-	APNSBundleID    string
-	APNSDevelopment bool
-}
-
-// NewPushService creates a new push notification service
-func NewPushService(repo pushrepo.Repository, config Config, firebaseClient *messaging.Client) PushService {
+// NewPushService creates a new push notification service sending notifications
+// through the provider of each platform, e.g. FCM for android and APNS for ios
+func NewPushService(repo pushrepo.Repository, providers map[string]Provider) PushService {
 	return &pushService{
-		repository:      repo,
-		firebaseClient:  firebaseClient,
-		apnsKeyID:       config.APNSKeyID,
-		apnsTeamID:      config.APNSTeamID,
-		apnsPrivateKey:  config.APNSPrivateKey,
-		apnsBundleID:    config.APNSBundleID,
-		apnsDevelopment: config.APNSDevelopment,
+		repository: repo,
+		providers:  providers,
+		metrics:    newDeliveryMetrics(),
 	}
 }
 
@@ -103,53 +116,69 @@ func (s *pushService) DeleteToken(ctx context.Context, userID int, token string)
 	return s.repository.DeleteToken(ctx, userID, token)
 }
 
-// SendNotification sends a push notification to a specific user
+// SendNotification sends a push notification to a specific user. Notifications of a
+// category the user turned off or sent during their quiet hours are dropped silently.
+// Fails when none of the user's devices accepted the notification.
 func (s *pushService) SendNotification(ctx context.Context, userID int, payload NotificationPayload) error {
+	if !s.allowsNotification(ctx, userID, payload.Category) {
+		return nil
+	}
+
 	tokens, err := s.repository.GetUserTokens(ctx, userID)
 	if err != nil {
 		return err
 	}
 
 	if len(tokens) == 0 {
-		return errors.New("no tokens found for user")
+		return ErrNoTokens
 	}
 
-	// Group tokens by platform
-	androidTokens := make([]string, 0)
-	iosTokens := make([]string, 0)
+	return resultsError(s.sendToTokens(ctx, userID, tokens, payload))
+}
 
-	for _, token := range tokens {
-		if strings.ToLower(token.Platform) == "android" {
-			androidTokens = append(androidTokens, token.Token)
-		} else if strings.ToLower(token.Platform) == "ios" {
-			iosTokens = append(iosTokens, token.Token)
-		}
+// SendTestNotification sends a test notification to every device of the user,
+// regardless of their preferences, and returns the answer of the provider for each
+func (s *pushService) SendTestNotification(ctx context.Context, userID int) ([]TokenResult, error) {
+	tokens, err := s.repository.GetUserTokens(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	var sendErrors []error
-
-	// Send to Android devices
-	if len(androidTokens) > 0 {
-		err := s.sendToFCM(ctx, userID, androidTokens, payload)
-		if err != nil {
-			sendErrors = append(sendErrors, fmt.Errorf("FCM error: %w", err))
-		}
+	if len(tokens) == 0 {
+		return nil, ErrNoTokens
 	}
 
-	// Send to iOS devices
-	if len(iosTokens) > 0 {
-		err := s.sendToAPNS(ctx, userID, iosTokens, payload)
-		if err != nil {
-			sendErrors = append(sendErrors, fmt.Errorf("APNS error: %w", err))
-		}
+	return s.sendToTokens(ctx, userID, tokens, NotificationPayload{
+		Title: "Test notification",
+		Body:  "Notifications work on this device",
+		Sound: "default",
+	}), nil
+}
+
+// GetTokens lists the devices registered by the user
+func (s *pushService) GetTokens(ctx context.Context, userID int) ([]RegisteredToken, error) {
+	tokens, err := s.repository.GetUserTokens(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(sendErrors) > 0 {
-		// Return first error or combine them
-		return sendErrors[0]
+	registered := make([]RegisteredToken, 0, len(tokens))
+	for _, token := range tokens {
+		registered = append(registered, RegisteredToken{
+			Token:         token.Token,
+			Platform:      token.Platform,
+			DeviceID:      token.DeviceID,
+			LastSeenAt:    token.LastSeenAt,
+			LastSuccessAt: token.LastSuccessAt,
+			CreatedAt:     token.CreatedAt,
+		})
 	}
+	return registered, nil
+}
 
-	return nil
+// GetMetrics returns the delivery counters per platform and category since startup
+func (s *pushService) GetMetrics() []DeliveryMetrics {
+	return s.metrics.snapshot()
 }
 
 // SendNotificationToTokens sends a notification to specific tokens
@@ -159,180 +188,129 @@ func (s *pushService) SendNotificationToTokens(ctx context.Context, userID int,
 	}
 
 	// TODO: handler apns
-	return s.sendToFCM(ctx, userID, tokens, payload)
+	return resultsError(s.send(ctx, userID, PlatformAndroid, tokens, payload))
 }
 
-// sendToFCM sends notifications to Firebase Cloud Messaging
-func (s *pushService) sendToFCM(ctx context.Context, userID int, tokens []string, payload NotificationPayload) error {
-	if s.firebaseClient == nil {
-		return errors.New("firebase messaging client not initialized")
-	}
-
-	if len(tokens) == 0 {
-		return errors.New("no tokens provided")
-	}
-
-	// Track success and failures
-	var failedTokens []string
-	var invalidTokens []string
-	successCount := 0
+// sendToTokens sends the notification to every token through the provider of its platform
+// and records the tokens that accepted it
+func (s *pushService) sendToTokens(ctx context.Context, userID int, tokens []pushrepo.PushToken, payload NotificationPayload) []TokenResult {
+	// Group tokens by platform
+	androidTokens := make([]string, 0)
+	iosTokens := make([]string, 0)
 
-	// Send messages individually to each token
 	for _, token := range tokens {
-		// Create notification
-		notification := &messaging.Notification{
-			Title: payload.Title,
-			Body:  payload.Body,
-		}
-
-		// Create android config with icon from the image URL
-		androidConfig := &messaging.AndroidConfig{
-			Notification: &messaging.AndroidNotification{
-				Sound: defaultIfEmpty(payload.Sound, "default"),
-			},
-		}
-
-		// Set icon for Android if image URL is provided
-		if payload.ImageURL != "" {
-			androidConfig.Notification.Icon = payload.ImageURL
-		}
-
-		// Create message for a single token
-		message := &messaging.Message{
-			Token:        token,
-			Notification: notification,
-			Android:      androidConfig,
-		}
-
-		// Send individual message
-		_, err := s.firebaseClient.Send(ctx, message)
-		if err != nil {
-			failedTokens = append(failedTokens, token)
-
-			log.Printf("Failed to send FCM message to user %d, token %s: %v", userID, token, err)
-
-			// Check if error is due to an invalid token
-			if messaging.IsUnregistered(err) || messaging.IsInvalidArgument(err) {
-				invalidTokens = append(invalidTokens, token)
-			}
-		} else {
-			successCount++
+		if strings.ToLower(token.Platform) == PlatformAndroid {
+			androidTokens = append(androidTokens, token.Token)
+		} else if strings.ToLower(token.Platform) == PlatformIOS {
+			iosTokens = append(iosTokens, token.Token)
 		}
 	}
 
-	// Clean up invalid tokens
-	for _, token := range invalidTokens {
-		_ = s.repository.DeleteToken(ctx, userID, token) // Best effort cleanup
-	}
-
-	// Return error if all messages failed to send
-	if successCount == 0 && len(failedTokens) > 0 {
-		return fmt.Errorf("all FCM messages failed to send")
-	}
-
-	return nil
-}
+	var results []TokenResult
 
-// sendToAPNS sends notifications to Apple Push Notification Service
-func (s *pushService) sendToAPNS(ctx context.Context, userID int, tokens []string, payload NotificationPayload) error {
-	// Verify required APNS configuration
-	if len(s.apnsPrivateKey) == 0 || s.apnsKeyID == "" || s.apnsTeamID == "" || s.apnsBundleID == "" {
-		return errors.New("incomplete APNS configuration")
+	// Send to Android devices
+	if len(androidTokens) > 0 {
+		results = append(results, s.send(ctx, userID, PlatformAndroid, androidTokens, payload)...)
 	}
 
-	// Create a new token based authentication for APNS
-	authKey, err := token.AuthKeyFromBytes(s.apnsPrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to load APNS auth key: %w", err)
+	// Send to iOS devices
+	if len(iosTokens) > 0 {
+		results = append(results, s.send(ctx, userID, PlatformIOS, iosTokens, payload)...)
 	}
 
-	// Create a token client
-	authToken := &token.Token{
-		AuthKey: authKey,
-		KeyID:   s.apnsKeyID,
-		TeamID:  s.apnsTeamID,
+	var succeeded []string
+	for _, result := range results {
+		if result.Success {
+			succeeded = append(succeeded, result.Token)
+		}
 	}
-
-	// Determine if we should use development or production APNS server
-	var client *apns2.Client
-	if s.apnsDevelopment {
-		client = apns2.NewTokenClient(authToken).Development()
-	} else {
-		client = apns2.NewTokenClient(authToken).Production()
+	if err := s.repository.MarkTokensSucceeded(ctx, succeeded); err != nil {
+		log.Printf("Failed to record successful push to user %d: %v", userID, err)
 	}
 
-	// Set timeout on the client
-	client.HTTPClient.Timeout = 15 * time.Second
-
-	// Build APNS notification payload
-	apnsPayload := apns2payload.NewPayload().
-		AlertTitle(payload.Title).
-		AlertBody(payload.Body).
-		Sound(defaultIfEmpty(payload.Sound, "default"))
+	return results
+}
 
-	// Set badge if provided
-	if payload.Badge > 0 {
-		apnsPayload.Badge(payload.Badge)
+// send sends the notification through the provider of the platform and deletes
+// the tokens the provider reported dead
+func (s *pushService) send(ctx context.Context, userID int, platform string, tokens []string, payload NotificationPayload) []TokenResult {
+	provider, ok := s.providers[platform]
+	if !ok {
+		results := failAll(tokens, platform, fmt.Errorf("push notifications to %s are disabled", platform))
+		for i := range results {
+			results[i].Disabled = true
+		}
+		s.metrics.record(platform, payload.Category, results, 0)
+		return results
 	}
 
-	// If an image URL is provided, add it as a media attachment
-	if payload.ImageURL != "" {
-		apnsPayload.MutableContent()
-		apnsPayload.Custom("image_url", payload.ImageURL)
+	started := time.Now()
+	results := provider.Send(ctx, tokens, payload)
+	s.metrics.record(platform, payload.Category, results, time.Since(started))
+	for _, result := range results {
+		if result.Removed {
+			s.removeStaleToken(ctx, userID, result.Token, providerName(platform)+" "+result.Error)
+		}
 	}
+	return results
+}
 
-	// Process all tokens
-	var errs []error
-	for _, token := range tokens {
-		// Create notification
-		notification := &apns2.Notification{
-			DeviceToken: token,
-			Topic:       s.apnsBundleID,
-			Payload:     apnsPayload,
-			Priority:    apns2.PriorityHigh,
-			PushType:    apns2.PushTypeAlert,
+// resultsError fails when no device accepted the notification, unless every failed
+// token was dead and removed. Devices that got it are not sent it again on a retry.
+// Fails with ErrPushDisabled when the only devices left are of disabled platforms.
+func resultsError(results []TokenResult) error {
+	var failure *TokenResult
+	disabled := false
+	for i, result := range results {
+		if result.Success {
+			return nil
 		}
-
-		// Send notification
-		resp, err := client.Push(notification)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to send APNS notification: %w", err))
+		if result.Disabled {
+			disabled = true
 			continue
 		}
-
-		// Handle APNS response
-		if resp.StatusCode != http.StatusOK {
-			// Handle specific status codes
-			switch resp.Reason {
-			case apns2.ReasonBadDeviceToken, apns2.ReasonDeviceTokenNotForTopic, apns2.ReasonUnregistered:
-				// Token is invalid, remove it from database
-				_ = s.repository.DeleteToken(ctx, userID, token)
-				errs = append(errs, fmt.Errorf("invalid token removed: %s - %s", token, resp.Reason))
-			default:
-				errs = append(errs, fmt.Errorf("APNS error: %s", resp.Reason))
-			}
+		if !result.Removed && failure == nil {
+			failure = &results[i]
 		}
 	}
+	if failure != nil {
+		return fmt.Errorf("%s error: %s", providerName(failure.Platform), failure.Error)
+	}
+	if disabled {
+		return ErrPushDisabled
+	}
+	return nil
+}
 
-	// Return concatenated errors if any
-	if len(errs) > 0 {
-		var combinedErr strings.Builder
-		for i, err := range errs {
-			if i > 0 {
-				combinedErr.WriteString("; ")
-			}
-			combinedErr.WriteString(err.Error())
-		}
-		return errors.New(combinedErr.String())
+// failAll reports the same failure for every token, when the provider cannot be reached at all
+func failAll(tokens []string, platform string, err error) []TokenResult {
+	results := make([]TokenResult, len(tokens))
+	for i, token := range tokens {
+		results[i] = TokenResult{Token: token, Platform: platform, Error: err.Error()}
+	}
+	return results
+}
+
+func providerName(platform string) string {
+	if platform == PlatformIOS {
+		return "APNS"
 	}
+	return "FCM"
+}
 
-	return nil
+// removeStaleToken deletes a token the provider reported as dead, so later sends skip it
+func (s *pushService) removeStaleToken(ctx context.Context, userID int, token string, reason string) {
+	if err := s.repository.DeleteToken(ctx, userID, token); err != nil {
+		log.Printf("Failed to remove stale push token of user %d: %v", userID, err)
+		return
+	}
+	log.Printf("Removed stale push token of user %d: %s", userID, reason)
 }
 
 // Helper functions
 func isValidPlatform(platform string) bool {
 	platform = strings.ToLower(platform)
-	return platform == "ios" || platform == "android"
+	return platform == PlatformIOS || platform == PlatformAndroid
 }
 
 func defaultIfEmpty(val, defaultVal string) string {
@@ -0,0 +1,134 @@
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+
+	referralrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/referral"
+)
+
+const (
+	// CodeLength is the length of a referral code
+	CodeLength = 8
+
+	// codeAlphabet is Crockford base32, which leaves out letters easily mistaken for digits
+	codeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// codeAttempts bounds the retries when a generated code is already taken
+	codeAttempts = 5
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrInvalidCode     = errors.New("invalid referral code")
+	ErrSelfReferral    = errors.New("users cannot refer themselves")
+	ErrAlreadyReferred = errors.New("user is already referred")
+)
+
+// Stats is the user's referral code with the users who signed up with it
+type Stats struct {
+	Code     string `json:"code"`
+	SignedUp int    `json:"signed_up"`
+	// Activated counts referred users who created a profile and sent their first message
+	Activated int `json:"activated"`
+}
+
+// ReferralServiceImpl hands out referral codes and attributes signups to them
+type ReferralServiceImpl struct {
+	repo referralrepo.Repository
+}
+
+// NewReferralService creates a new referral service
+func NewReferralService(repo referralrepo.Repository) *ReferralServiceImpl {
+	return &ReferralServiceImpl{
+		repo: repo,
+	}
+}
+
+// GetStats returns the user's referral code, creating it on first use, with the counts
+// of referred signups and activated users
+func (s *ReferralServiceImpl) GetStats(ctx context.Context, userID int) (*Stats, error) {
+	code, err := s.getOrCreateCode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.GetStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &Stats{
+		Code:      code,
+		SignedUp:  stats.SignedUp,
+		Activated: stats.Activated,
+	}, nil
+}
+
+// Attribute records that a newly registered user signed up with a referral code
+func (s *ReferralServiceImpl) Attribute(ctx context.Context, userID int, code string) error {
+	code = normalizeCode(code)
+	if len(code) != CodeLength {
+		return ErrInvalidCode
+	}
+
+	referrerID, err := s.repo.GetReferrer(ctx, code)
+	if err != nil {
+		if errors.Is(err, referralrepo.ErrCodeNotFound) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+	if referrerID == userID {
+		return ErrSelfReferral
+	}
+
+	if err := s.repo.CreateReferral(ctx, userID, referrerID, code); err != nil {
+		if errors.Is(err, referralrepo.ErrAlreadyReferred) {
+			return ErrAlreadyReferred
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ReferralServiceImpl) getOrCreateCode(ctx context.Context, userID int) (string, error) {
+	code, err := s.repo.GetCode(ctx, userID)
+	if err == nil {
+		return code, nil
+	}
+	if !errors.Is(err, referralrepo.ErrCodeNotFound) {
+		return "", err
+	}
+
+	for i := 0; i < codeAttempts; i++ {
+		candidate, err := generateCode()
+		if err != nil {
+			return "", err
+		}
+		code, err = s.repo.CreateCode(ctx, userID, candidate)
+		if errors.Is(err, referralrepo.ErrCodeTaken) {
+			continue
+		}
+		return code, err
+	}
+	return "", errors.New("failed to generate a unique referral code")
+}
+
+func generateCode() (string, error) {
+	b := make([]byte, CodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// The alphabet has 32 characters, so the low 5 bits pick one without bias
+	for i := range b {
+		b[i] = codeAlphabet[b[i]&31]
+	}
+	return string(b), nil
+}
+
+// normalizeCode accepts codes typed in lower case or with the letters Crockford
+// base32 reads as digits
+func normalizeCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("O", "0", "I", "1", "L", "1").Replace(code)
+}
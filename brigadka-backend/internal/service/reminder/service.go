@@ -0,0 +1,247 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+	reminderrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/reminder"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/messaging"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+type Reminder = reminderrepo.Reminder
+
+const (
+	// MaxTextLength limits the reminder text
+	MaxTextLength = 500
+	// MaxLeadTime is how far ahead a reminder can be scheduled
+	MaxLeadTime = 365 * 24 * time.Hour
+
+	// claimBatchSize is the number of due reminders processed per scheduler tick
+	claimBatchSize = 100
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrChatNotFound     = errors.New("chat not found")
+	ErrNotAuthor        = errors.New("only the author can cancel a reminder")
+	ErrInvalidText      = errors.New("reminder text must be 1-500 characters")
+	ErrInvalidTime      = errors.New("reminder time must be in the future and within a year")
+)
+
+// MessagingService is the part of the messaging service used by reminders
+type MessagingService interface {
+	IsUserInChat(userID int, chatID string) (bool, error)
+	GetChatParticipants(chatID string) ([]int, error)
+	AddMessage(messageID string, chatID string, senderID int, content string) (time.Time, error)
+}
+
+// PushService notifies chat participants about reminders
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// ReminderServiceImpl schedules reminders and posts them to chats when they are due
+type ReminderServiceImpl struct {
+	repo             reminderrepo.Repository
+	messagingService MessagingService
+	pushService      PushService
+	messageListener  messaging.MessageListener
+	now              func() time.Time
+	runObserver      RunObserver // Optional
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(repo reminderrepo.Repository, messagingService MessagingService, pushService PushService) *ReminderServiceImpl {
+	return &ReminderServiceImpl{
+		repo:             repo,
+		messagingService: messagingService,
+		pushService:      pushService,
+		now:              time.Now,
+	}
+}
+
+// SetMessageListener enables real-time delivery of reminder messages
+func (s *ReminderServiceImpl) SetMessageListener(listener messaging.MessageListener) {
+	s.messageListener = listener
+}
+
+// CreateReminder schedules a reminder in a chat the user participates in
+func (s *ReminderServiceImpl) CreateReminder(ctx context.Context, userID int, chatID, text string, remindAt time.Time) (*Reminder, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len([]rune(text)) > MaxTextLength {
+		return nil, ErrInvalidText
+	}
+	now := s.now()
+	if !remindAt.After(now) || remindAt.Sub(now) > MaxLeadTime {
+		return nil, ErrInvalidTime
+	}
+
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return nil, err
+	}
+
+	reminder := &Reminder{
+		ID:        idgen.New(),
+		ChatID:    chatID,
+		CreatedBy: userID,
+		Text:      text,
+		RemindAt:  remindAt.UTC(),
+	}
+	if err := s.repo.CreateReminder(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// GetReminders returns the pending reminders of a chat
+func (s *ReminderServiceImpl) GetReminders(ctx context.Context, userID int, chatID string) ([]Reminder, error) {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPendingReminders(ctx, chatID)
+}
+
+// CancelReminder cancels a pending reminder created by the user
+func (s *ReminderServiceImpl) CancelReminder(ctx context.Context, userID int, chatID, reminderID string) error {
+	if err := s.requireParticipant(userID, chatID); err != nil {
+		return err
+	}
+
+	reminder, err := s.repo.GetReminder(ctx, reminderID)
+	if err != nil {
+		if errors.Is(err, reminderrepo.ErrReminderNotFound) {
+			return ErrReminderNotFound
+		}
+		return err
+	}
+	if reminder.ChatID != chatID || reminder.Status != reminderrepo.StatusPending {
+		return ErrReminderNotFound
+	}
+	if reminder.CreatedBy != userID {
+		return ErrNotAuthor
+	}
+
+	if err := s.repo.CancelReminder(ctx, reminderID); err != nil {
+		if errors.Is(err, reminderrepo.ErrReminderNotFound) {
+			return ErrReminderNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SetRunObserver reports the outcome of every run of the scheduler
+func (s *ReminderServiceImpl) SetRunObserver(observer RunObserver) {
+	s.runObserver = observer
+}
+
+// Run posts due reminders every interval until the context is cancelled
+func (s *ReminderServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.ProcessDue(ctx)
+		if s.runObserver != nil {
+			s.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to process due reminders: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue posts all reminders that are due. Reminders are claimed before
+// they are posted, so a failure to post is logged and not retried.
+func (s *ReminderServiceImpl) ProcessDue(ctx context.Context) error {
+	for {
+		reminders, err := s.repo.ClaimDueReminders(ctx, s.now(), claimBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, reminder := range reminders {
+			s.deliver(reminder)
+		}
+		if len(reminders) < claimBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliver posts the reminder to the chat on behalf of its author and notifies the participants
+func (s *ReminderServiceImpl) deliver(reminder Reminder) {
+	content := "⏰ " + reminder.Text
+	messageID := uuid.New().String()
+
+	sentAt, err := s.messagingService.AddMessage(messageID, reminder.ChatID, reminder.CreatedBy, content)
+	if err != nil {
+		// The author may have left the chat since scheduling the reminder
+		log.Printf("Failed to post reminder %s to chat %s: %v", reminder.ID, reminder.ChatID, err)
+		return
+	}
+
+	if s.messageListener != nil {
+		s.messageListener.MessagePosted(messaging.ChatMessage{
+			MessageID: messageID,
+			ChatID:    reminder.ChatID,
+			SenderID:  reminder.CreatedBy,
+			Content:   content,
+			SentAt:    sentAt,
+		})
+	}
+
+	participants, err := s.messagingService.GetChatParticipants(reminder.ChatID)
+	if err != nil {
+		log.Printf("Failed to get participants of chat %s: %v", reminder.ChatID, err)
+		return
+	}
+	payload := push.NotificationPayload{
+		Title: "Напоминание",
+		Body:  reminder.Text,
+		Sound: "default",
+	}
+	for _, userID := range participants {
+		s.sendPush(userID, payload)
+	}
+}
+
+func (s *ReminderServiceImpl) sendPush(userID int, payload push.NotificationPayload) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+			log.Printf("Error sending push notification to user %d: %v", userID, err)
+		}
+	}()
+}
+
+func (s *ReminderServiceImpl) requireParticipant(userID int, chatID string) error {
+	inChat, err := s.messagingService.IsUserInChat(userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !inChat {
+		return ErrChatNotFound
+	}
+	return nil
+}
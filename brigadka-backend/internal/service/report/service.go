@@ -0,0 +1,223 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	reportrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/report"
+)
+
+type Report = reportrepo.Report
+type Reason = reportrepo.Reason
+
+// Resolution actions
+const (
+	// ActionHide hides the reported content: the profile drops out of search,
+	// the message out of chat history, and the media from profiles
+	ActionHide = "hide"
+	// ActionDismiss closes the reports without touching the content
+	ActionDismiss = "dismiss"
+)
+
+// MaxCommentLength limits the reporter's comment
+const MaxCommentLength = 1000
+
+// Возможные ошибки сервиса
+var (
+	ErrInvalidTarget   = errors.New("invalid report target")
+	ErrTargetNotFound  = errors.New("reported content not found")
+	ErrInvalidReason   = errors.New("unknown report reason")
+	ErrInvalidComment  = errors.New("report comment must be at most 1000 characters")
+	ErrAlreadyReported = errors.New("content already reported")
+	ErrReportNotFound  = errors.New("report not found")
+	ErrReportClosed    = errors.New("report already resolved")
+	ErrInvalidAction   = errors.New("resolution action must be hide or dismiss")
+	ErrInvalidStatus   = errors.New("invalid report status")
+)
+
+// CreateRequest describes a report submitted by a user
+type CreateRequest struct {
+	TargetType string
+	TargetID   string
+	Reason     string
+	Comment    string
+}
+
+// ReportQueue is a page of reports for moderators
+type ReportQueue struct {
+	Items      []Report `json:"items"`
+	TotalCount int      `json:"total_count"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"page_size"`
+}
+
+// Resolution describes what a moderator did about reported content
+type Resolution struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Action     string `json:"action"`
+	// ResolvedReports counts every open report on the target closed by the resolution
+	ResolvedReports int `json:"resolved_reports"`
+}
+
+// ReportServiceImpl handles user reports and their moderation
+type ReportServiceImpl struct {
+	repo reportrepo.Repository
+	now  func() time.Time
+}
+
+// NewReportService creates a new report service
+func NewReportService(repo reportrepo.Repository) *ReportServiceImpl {
+	return &ReportServiceImpl{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// GetReasons returns the report reason catalog
+func (s *ReportServiceImpl) GetReasons(ctx context.Context, lang string) ([]Reason, error) {
+	return s.repo.GetReasons(ctx, lang)
+}
+
+// CreateReport files a report about a profile, message or media
+func (s *ReportServiceImpl) CreateReport(ctx context.Context, reporterID int, req CreateRequest) (*Report, error) {
+	targetID, err := normalizeTargetID(req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if req.TargetType == reportrepo.TargetProfile && targetID == strconv.Itoa(reporterID) {
+		return nil, ErrInvalidTarget
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if len([]rune(comment)) > MaxCommentLength {
+		return nil, ErrInvalidComment
+	}
+
+	ok, err := s.repo.ReasonExists(ctx, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidReason
+	}
+
+	exists, err := s.repo.TargetExists(ctx, req.TargetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTargetNotFound
+	}
+
+	report := &Report{
+		ReporterID: reporterID,
+		TargetType: req.TargetType,
+		TargetID:   targetID,
+		Reason:     req.Reason,
+		Comment:    comment,
+	}
+	if err := s.repo.CreateReport(ctx, report); err != nil {
+		if errors.Is(err, reportrepo.ErrAlreadyReported) {
+			return nil, ErrAlreadyReported
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetReports returns a page of reports in the given status, oldest first.
+// An empty status lists open reports.
+func (s *ReportServiceImpl) GetReports(ctx context.Context, status string, page, pageSize int) (*ReportQueue, error) {
+	switch status {
+	case "":
+		status = reportrepo.StatusOpen
+	case reportrepo.StatusOpen, reportrepo.StatusResolved, reportrepo.StatusDismissed:
+	default:
+		return nil, ErrInvalidStatus
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	reports, total, err := s.repo.GetReports(ctx, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportQueue{
+		Items:      reports,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// ResolveReport applies the action to the reported content and closes every
+// open report on the same content, so duplicates do not stay in the queue
+func (s *ReportServiceImpl) ResolveReport(ctx context.Context, moderatorID, reportID int, action string) (*Resolution, error) {
+	var status string
+	switch action {
+	case ActionHide:
+		status = reportrepo.StatusResolved
+	case ActionDismiss:
+		status = reportrepo.StatusDismissed
+	default:
+		return nil, ErrInvalidAction
+	}
+
+	report, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, reportrepo.ErrReportNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	if report.Status != reportrepo.StatusOpen {
+		return nil, ErrReportClosed
+	}
+
+	resolved, err := s.repo.ResolveTarget(ctx, report.TargetType, report.TargetID, moderatorID, status, action == ActionHide, s.now())
+	if err != nil {
+		// Another moderator resolved the target in the meantime
+		if errors.Is(err, reportrepo.ErrNoOpenReports) {
+			return nil, ErrReportClosed
+		}
+		return nil, err
+	}
+
+	return &Resolution{
+		TargetType:      report.TargetType,
+		TargetID:        report.TargetID,
+		Action:          action,
+		ResolvedReports: resolved,
+	}, nil
+}
+
+// normalizeTargetID validates the ID format for the target type, so lookups never fail on malformed input
+func normalizeTargetID(targetType, targetID string) (string, error) {
+	targetID = strings.TrimSpace(targetID)
+	switch targetType {
+	case reportrepo.TargetProfile, reportrepo.TargetMedia:
+		id, err := strconv.Atoi(targetID)
+		if err != nil || id <= 0 {
+			return "", ErrInvalidTarget
+		}
+		return strconv.Itoa(id), nil
+	case reportrepo.TargetMessage:
+		id, err := uuid.Parse(targetID)
+		if err != nil {
+			return "", ErrInvalidTarget
+		}
+		return id.String(), nil
+	default:
+		return "", ErrInvalidTarget
+	}
+}
@@ -0,0 +1,214 @@
+package searchindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	searchindexrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/searchindex"
+)
+
+// indexMapping describes the profile documents. Bios are analyzed with Russian
+// stemming and names without it, as in the database search.
+const indexMapping = `{
+  "mappings": {
+    "properties": {
+      "user_id":          {"type": "integer"},
+      "full_name":        {"type": "text", "analyzer": "standard"},
+      "bio":              {"type": "text", "analyzer": "russian"},
+      "birthday":         {"type": "date"},
+      "gender":           {"type": "keyword"},
+      "city_id":          {"type": "integer"},
+      "location":         {"type": "geo_point"},
+      "looking_for_team": {"type": "boolean"},
+      "verified":         {"type": "boolean"},
+      "has_avatar":       {"type": "boolean"},
+      "has_video":        {"type": "boolean"},
+      "improv_styles":    {"type": "keyword"},
+      "goals":            {"type": "keyword"},
+      "tags":             {"type": "keyword"},
+      "created_at":       {"type": "date"}
+    }
+  }
+}`
+
+// Client is a minimal OpenSearch client for the profile index.
+// It speaks the REST API directly, so Elasticsearch works as well.
+type Client struct {
+	baseURL  string
+	index    string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewClient creates a client for the index at the cluster URL; credentials are optional
+func NewClient(baseURL, index, username, password string) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		index:    index,
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// IndexExists reports whether the profile index has been created
+func (c *Client) IndexExists(ctx context.Context) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, "/"+c.index, "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("opensearch responded with status %d", resp.StatusCode)
+	}
+}
+
+// CreateIndex creates the profile index with its mapping
+func (c *Client) CreateIndex(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodPut, "/"+c.index, "application/json", strings.NewReader(indexMapping))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// Bulk indexes the documents and deletes the profiles with the given IDs in one request
+func (c *Client) Bulk(ctx context.Context, documents []searchindexrepo.Document, deleted []int) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range documents {
+		if err := encoder.Encode(bulkAction("index", c.index, doc.UserID)); err != nil {
+			return err
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+	for _, userID := range deleted {
+		if err := encoder.Encode(bulkAction("delete", c.index, userID)); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	// The request succeeds even when some of the actions fail
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("invalid bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, outcome := range item {
+			// Deleting a profile that was never indexed is not an error
+			if action == "delete" && outcome.Status == http.StatusNotFound {
+				continue
+			}
+			if outcome.Status >= 300 {
+				return fmt.Errorf("bulk %s failed with status %d: %s", action, outcome.Status, outcome.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// searchResponse is the part of a search response used by the provider
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source struct {
+				UserID int `json:"user_id"`
+			} `json:"_source"`
+			Sort []json.RawMessage `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]struct {
+		Buckets []struct {
+			Key      json.RawMessage `json:"key"`
+			DocCount int             `json:"doc_count"`
+		} `json:"buckets"`
+	} `json:"aggregations"`
+}
+
+// search runs a search request against the profile index
+func (c *Client) search(ctx context.Context, request map[string]interface{}) (*searchResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/"+c.index+"/_search", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var result searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid search response: %w", err)
+	}
+	return &result, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	return c.client.Do(req)
+}
+
+func bulkAction(action, index string, userID int) map[string]interface{} {
+	return map[string]interface{}{
+		action: map[string]interface{}{"_index": index, "_id": fmt.Sprint(userID)},
+	}
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("opensearch responded with status %d: %s", resp.StatusCode, message)
+}
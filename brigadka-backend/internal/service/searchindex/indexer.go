@@ -0,0 +1,124 @@
+package searchindex
+
+import (
+	"context"
+	"log"
+	"time"
+
+	searchindexrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/searchindex"
+)
+
+// DefaultBatchSize is the number of profiles synced per bulk request
+const DefaultBatchSize = 200
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// Indexer keeps the search index in sync with the database. Profile changes are
+// queued in an outbox by database triggers; the indexer reads the queue in batches,
+// indexes the current documents and deletes hidden and deleted profiles.
+type Indexer struct {
+	repo        searchindexrepo.Repository
+	client      *Client
+	batchSize   int
+	runObserver RunObserver // Optional
+}
+
+// NewIndexer creates an indexer syncing batchSize profiles per request
+func NewIndexer(repo searchindexrepo.Repository, client *Client, batchSize int) *Indexer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Indexer{
+		repo:      repo,
+		client:    client,
+		batchSize: batchSize,
+	}
+}
+
+// SetRunObserver enables reporting of sync runs, e.g. to the health endpoint
+func (i *Indexer) SetRunObserver(observer RunObserver) {
+	i.runObserver = observer
+}
+
+// EnsureIndex creates the index when it does not exist and queues every profile to fill it
+func (i *Indexer) EnsureIndex(ctx context.Context) error {
+	exists, err := i.client.IndexExists(ctx)
+	if err != nil || exists {
+		return err
+	}
+	if err := i.client.CreateIndex(ctx); err != nil {
+		return err
+	}
+	log.Printf("Created search index, queueing all profiles")
+	return i.repo.EnqueueAll(ctx)
+}
+
+// Run syncs queued profiles until the context is cancelled
+func (i *Indexer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := i.Sync(ctx)
+		if i.runObserver != nil {
+			i.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to sync search index: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes all queued profiles. A batch that fails stays queued for the next run.
+func (i *Indexer) Sync(ctx context.Context) error {
+	for {
+		pending, err := i.repo.GetPending(ctx, i.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		userIDs := make([]int, len(pending))
+		for j, p := range pending {
+			userIDs[j] = p.UserID
+		}
+		documents, err := i.repo.GetDocuments(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		// Queued profiles without a document were deleted or hidden
+		indexed := make(map[int]bool, len(documents))
+		for _, doc := range documents {
+			indexed[doc.UserID] = true
+		}
+		deleted := []int{}
+		for _, userID := range userIDs {
+			if !indexed[userID] {
+				deleted = append(deleted, userID)
+			}
+		}
+
+		if err := i.client.Bulk(ctx, documents, deleted); err != nil {
+			return err
+		}
+		if err := i.repo.Ack(ctx, pending); err != nil {
+			return err
+		}
+
+		if len(pending) < i.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package searchindex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	profilerepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/profile"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/profile"
+)
+
+// facetSize is the number of values counted per facet
+const facetSize = 100
+
+// ProfileRepository loads the profiles found in the index
+type ProfileRepository interface {
+	GetProfilesByIDs(currentUserID int, userIDs []int) ([]*profilerepo.ProfileModel, error)
+	GetImprovStyles(userID int) ([]string, error)
+}
+
+// OpenSearchProvider searches profiles in the OpenSearch index and loads the page
+// from the database. Searches by availability or excluding contacted users need data
+// that is not indexed and go to the fallback, as do searches when the index fails.
+type OpenSearchProvider struct {
+	client   *Client
+	profiles ProfileRepository
+	fallback profile.SearchProvider
+}
+
+// NewOpenSearchProvider creates a provider searching the index behind the client
+func NewOpenSearchProvider(client *Client, profiles ProfileRepository, fallback profile.SearchProvider) *OpenSearchProvider {
+	return &OpenSearchProvider{
+		client:   client,
+		profiles: profiles,
+		fallback: fallback,
+	}
+}
+
+// SearchProfiles searches the index, ranking like the database search:
+// relevance for text queries, then distance, then improv style matches and the newest profiles
+func (p *OpenSearchProvider) SearchProfiles(ctx context.Context, q profile.SearchQuery) ([]*profilerepo.ProfileModel, int, *profilerepo.SearchFacets, error) {
+	if len(q.AvailableOn) > 0 || q.ExcludeContacted {
+		return p.fallback.SearchProfiles(ctx, q)
+	}
+
+	profiles, total, facets, err := p.search(ctx, q)
+	if err != nil {
+		log.Printf("Search index failed, searching the database: %v", err)
+		return p.fallback.SearchProfiles(ctx, q)
+	}
+	return profiles, total, facets, nil
+}
+
+func (p *OpenSearchProvider) search(ctx context.Context, q profile.SearchQuery) ([]*profilerepo.ProfileModel, int, *profilerepo.SearchFacets, error) {
+	// Profiles sharing the searcher's improv styles rank higher
+	styles, err := p.profiles.GetImprovStyles(q.UserID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	request, distanceSort := buildSearchRequest(q, styles)
+	resp, err := p.client.search(ctx, request)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	userIDs := make([]int, len(resp.Hits.Hits))
+	distances := make(map[int]float64, len(resp.Hits.Hits))
+	for i, hit := range resp.Hits.Hits {
+		userIDs[i] = hit.Source.UserID
+		if distanceSort >= 0 && distanceSort < len(hit.Sort) {
+			var distance float64
+			if err := json.Unmarshal(hit.Sort[distanceSort], &distance); err == nil {
+				distances[hit.Source.UserID] = distance
+			}
+		}
+	}
+
+	profiles, err := p.profiles.GetProfilesByIDs(q.UserID, userIDs)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	for _, found := range profiles {
+		if distance, ok := distances[found.UserID]; ok {
+			found.DistanceKm = &distance
+		}
+	}
+
+	var facets *profilerepo.SearchFacets
+	if q.WithFacets {
+		facets = &profilerepo.SearchFacets{
+			Cities:       facetCounts(resp, "city_id"),
+			ImprovStyles: facetCounts(resp, "improv_styles"),
+			Goals:        facetCounts(resp, "goals"),
+		}
+	}
+	return profiles, resp.Hits.Total.Value, facets, nil
+}
+
+// buildSearchRequest translates the query to the OpenSearch query DSL. It also returns
+// the position of the distance in the sort values of the hits, or -1 without one.
+func buildSearchRequest(q profile.SearchQuery, searcherStyles []string) (map[string]interface{}, int) {
+	must := []interface{}{}
+	filter := []interface{}{}
+	should := []interface{}{}
+
+	if q.Text != "" {
+		must = append(must, map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     q.Text,
+				"fields":    []string{"full_name^2", "bio"},
+				"fuzziness": "AUTO",
+			},
+		})
+	}
+	if q.LookingForTeam != nil {
+		filter = append(filter, term("looking_for_team", *q.LookingForTeam))
+	}
+	if len(q.Goals) > 0 {
+		filter = append(filter, map[string]interface{}{"terms": map[string]interface{}{"goals": q.Goals}})
+	}
+	for _, style := range q.ImprovStyles {
+		filter = append(filter, term("improv_styles", style))
+	}
+	if q.BirthDateMin != nil || q.BirthDateMax != nil {
+		filter = append(filter, dateRange("birthday", q.BirthDateMin, q.BirthDateMax))
+	}
+	if len(q.Genders) > 0 {
+		filter = append(filter, map[string]interface{}{"terms": map[string]interface{}{"gender": q.Genders}})
+	}
+	if q.CityID != nil {
+		filter = append(filter, term("city_id", *q.CityID))
+	}
+	if q.HasAvatar != nil {
+		filter = append(filter, term("has_avatar", *q.HasAvatar))
+	}
+	if q.HasVideo != nil {
+		filter = append(filter, term("has_video", *q.HasVideo))
+	}
+	if q.CreatedAfter != nil {
+		filter = append(filter, dateRange("created_at", q.CreatedAfter, nil))
+	}
+	if q.VerifiedOnly {
+		filter = append(filter, term("verified", true))
+	}
+	for _, tag := range q.Tags {
+		filter = append(filter, term("tags", tag))
+	}
+	location := map[string]interface{}{}
+	if q.Near != nil {
+		location = map[string]interface{}{"lat": q.Near.Latitude, "lon": q.Near.Longitude}
+		filter = append(filter, map[string]interface{}{
+			"geo_distance": map[string]interface{}{
+				"distance": fmt.Sprintf("%gkm", q.Near.RadiusKm),
+				"location": location,
+			},
+		})
+	}
+	if len(searcherStyles) > 0 {
+		should = append(should, map[string]interface{}{
+			"terms": map[string]interface{}{"improv_styles": searcherStyles},
+		})
+	}
+
+	var query interface{} = map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":     must,
+			"filter":   filter,
+			"should":   should,
+			"must_not": []interface{}{term("user_id", q.UserID)},
+			// Style matches only rank, they do not filter
+			"minimum_should_match": 0,
+		},
+	}
+
+	distanceSort := -1
+	sort := []interface{}{}
+	if q.ShuffleSeed != nil {
+		// The order is random but stable for the seed, so pages do not overlap
+		query = map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":        query,
+				"random_score": map[string]interface{}{"seed": *q.ShuffleSeed, "field": "user_id"},
+				"boost_mode":   "replace",
+			},
+		}
+		sort = append(sort, "_score")
+	} else {
+		geoSort := map[string]interface{}{
+			"_geo_distance": map[string]interface{}{"location": location, "order": "asc", "unit": "km"},
+		}
+		if q.Text != "" {
+			sort = append(sort, "_score")
+		}
+		if q.Near != nil {
+			distanceSort = len(sort)
+			sort = append(sort, geoSort)
+		}
+		if q.Text == "" {
+			sort = append(sort, "_score")
+		}
+		sort = append(sort, map[string]interface{}{"created_at": "desc"})
+	}
+	sort = append(sort, map[string]interface{}{"user_id": "asc"})
+
+	request := map[string]interface{}{
+		"query":            query,
+		"sort":             sort,
+		"from":             (q.Page - 1) * q.PageSize,
+		"size":             q.PageSize,
+		"track_total_hits": true,
+		"_source":          []string{"user_id"},
+	}
+	if q.WithFacets {
+		request["aggs"] = map[string]interface{}{
+			"city_id":       termsAggregation("city_id"),
+			"improv_styles": termsAggregation("improv_styles"),
+			"goals":         termsAggregation("goals"),
+		}
+	}
+	return request, distanceSort
+}
+
+// facetCounts converts the buckets of an aggregation, which come most common first
+func facetCounts(resp *searchResponse, name string) []profilerepo.FacetCount {
+	buckets := resp.Aggregations[name].Buckets
+	counts := make([]profilerepo.FacetCount, 0, len(buckets))
+	for _, bucket := range buckets {
+		// Keys of keyword fields are strings and keys of numeric fields are numbers
+		value := strings.Trim(string(bucket.Key), `"`)
+		counts = append(counts, profilerepo.FacetCount{Value: value, Count: bucket.DocCount})
+	}
+	return counts
+}
+
+func term(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"term": map[string]interface{}{field: value}}
+}
+
+func dateRange(field string, from, to *time.Time) map[string]interface{} {
+	bounds := map[string]interface{}{}
+	if from != nil {
+		bounds["gte"] = from.Format(time.RFC3339)
+	}
+	if to != nil {
+		bounds["lte"] = to.Format(time.RFC3339)
+	}
+	return map[string]interface{}{"range": map[string]interface{}{field: bounds}}
+}
+
+func termsAggregation(field string) map[string]interface{} {
+	return map[string]interface{}{"terms": map[string]interface{}{"field": field, "size": facetSize}}
+}
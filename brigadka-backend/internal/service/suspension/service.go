@@ -0,0 +1,321 @@
+package suspension
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	suspensionrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/suspension"
+	userrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/user"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/auth"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+type Suspension = suspensionrepo.Suspension
+type Appeal = suspensionrepo.Appeal
+
+const (
+	// MaxReasonLength limits the suspension reason shown to the user
+	MaxReasonLength = 500
+	// MaxAppealLength limits the appeal message
+	MaxAppealLength = 2000
+
+	// liftBatchSize is the number of expired suspensions lifted per scheduler tick
+	liftBatchSize = 100
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrCannotSuspendAdmin = errors.New("admins cannot be suspended")
+	ErrAlreadySuspended   = errors.New("user is already suspended")
+	ErrNotSuspended       = errors.New("user is not suspended")
+	ErrInvalidReason      = errors.New("suspension reason must be 1-500 characters")
+	ErrInvalidDuration    = errors.New("suspension duration must not be negative")
+	ErrInvalidMessage     = errors.New("appeal message must be 1-2000 characters")
+	ErrAppealExists       = errors.New("suspension already appealed")
+	ErrAppealNotFound     = errors.New("appeal not found")
+)
+
+// Status describes the current suspension of a user and its appeal
+type Status struct {
+	Suspended  bool        `json:"suspended"`
+	Suspension *Suspension `json:"suspension,omitempty"`
+	Appeal     *Appeal     `json:"appeal,omitempty"`
+}
+
+// AppealQueue is a page of appeals waiting for review
+type AppealQueue struct {
+	Items      []Appeal `json:"items"`
+	TotalCount int      `json:"total_count"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"page_size"`
+}
+
+// UserRepository looks up the users being suspended
+type UserRepository interface {
+	GetUserByID(id int) (*userrepo.User, error)
+}
+
+// PushService notifies users when their suspension is lifted
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// RunObserver is told the outcome of every scheduled run, for health reporting
+type RunObserver interface {
+	ObserveRun(err error)
+}
+
+// SuspensionServiceImpl manages suspensions and appeals
+type SuspensionServiceImpl struct {
+	repo        suspensionrepo.Repository
+	userRepo    UserRepository
+	pushService PushService
+	now         func() time.Time
+	runObserver RunObserver // Optional
+}
+
+// NewSuspensionService creates a new suspension service
+func NewSuspensionService(repo suspensionrepo.Repository, userRepo UserRepository, pushService PushService) *SuspensionServiceImpl {
+	return &SuspensionServiceImpl{
+		repo:        repo,
+		userRepo:    userRepo,
+		pushService: pushService,
+		now:         time.Now,
+	}
+}
+
+// SuspendUser blocks the user from the API. A zero duration suspends until lifted by an admin.
+func (s *SuspensionServiceImpl) SuspendUser(ctx context.Context, adminID, userID int, reason string, duration time.Duration) (*Suspension, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > MaxReasonLength {
+		return nil, ErrInvalidReason
+	}
+	if duration < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, userrepo.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	// Admins cannot be suspended so moderation can never lock itself out
+	if user.Role == auth.RoleAdmin {
+		return nil, ErrCannotSuspendAdmin
+	}
+
+	suspension := &Suspension{
+		UserID:      userID,
+		Reason:      reason,
+		SuspendedBy: &adminID,
+	}
+	if duration > 0 {
+		endsAt := s.now().Add(duration).UTC()
+		suspension.EndsAt = &endsAt
+	}
+	if err := s.repo.CreateSuspension(ctx, suspension); err != nil {
+		if errors.Is(err, suspensionrepo.ErrAlreadySuspended) {
+			return nil, ErrAlreadySuspended
+		}
+		return nil, err
+	}
+	return suspension, nil
+}
+
+// LiftSuspension lifts the active suspension of the user ahead of time
+func (s *SuspensionServiceImpl) LiftSuspension(ctx context.Context, adminID, userID int) error {
+	suspension, err := s.GetActiveSuspension(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if suspension == nil {
+		return ErrNotSuspended
+	}
+	return s.lift(ctx, suspension, &adminID)
+}
+
+// GetActiveSuspension returns the suspension in effect for the user, or nil
+func (s *SuspensionServiceImpl) GetActiveSuspension(ctx context.Context, userID int) (*Suspension, error) {
+	suspension, err := s.repo.GetActiveSuspension(ctx, userID, s.now())
+	if errors.Is(err, suspensionrepo.ErrSuspensionNotFound) {
+		return nil, nil
+	}
+	return suspension, err
+}
+
+// GetStatus returns the active suspension of the user and its appeal, if any
+func (s *SuspensionServiceImpl) GetStatus(ctx context.Context, userID int) (*Status, error) {
+	suspension, err := s.GetActiveSuspension(ctx, userID)
+	if err != nil || suspension == nil {
+		return &Status{}, err
+	}
+
+	status := &Status{Suspended: true, Suspension: suspension}
+	appeal, err := s.repo.GetAppealBySuspension(ctx, suspension.ID)
+	if err != nil && !errors.Is(err, suspensionrepo.ErrAppealNotFound) {
+		return nil, err
+	}
+	status.Appeal = appeal
+	return status, nil
+}
+
+// SubmitAppeal sends the user's active suspension to the moderation queue.
+// Each suspension can be appealed once.
+func (s *SuspensionServiceImpl) SubmitAppeal(ctx context.Context, userID int, message string) (*Appeal, error) {
+	message = strings.TrimSpace(message)
+	if message == "" || len([]rune(message)) > MaxAppealLength {
+		return nil, ErrInvalidMessage
+	}
+
+	suspension, err := s.GetActiveSuspension(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if suspension == nil {
+		return nil, ErrNotSuspended
+	}
+
+	appeal := &Appeal{
+		SuspensionID: suspension.ID,
+		UserID:       userID,
+		Message:      message,
+	}
+	if err := s.repo.CreateAppeal(ctx, appeal); err != nil {
+		if errors.Is(err, suspensionrepo.ErrAppealExists) {
+			return nil, ErrAppealExists
+		}
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// GetAppeals returns a page of pending appeals, oldest first
+func (s *SuspensionServiceImpl) GetAppeals(ctx context.Context, page, pageSize int) (*AppealQueue, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	appeals, total, err := s.repo.GetAppeals(ctx, suspensionrepo.AppealPending, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &AppealQueue{
+		Items:      appeals,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// ReviewAppeal accepts or rejects a pending appeal. Accepting lifts the suspension.
+func (s *SuspensionServiceImpl) ReviewAppeal(ctx context.Context, adminID, appealID int, accept bool) error {
+	appeal, err := s.repo.GetAppeal(ctx, appealID)
+	if err != nil {
+		if errors.Is(err, suspensionrepo.ErrAppealNotFound) {
+			return ErrAppealNotFound
+		}
+		return err
+	}
+
+	status := suspensionrepo.AppealRejected
+	if accept {
+		status = suspensionrepo.AppealAccepted
+	}
+	if err := s.repo.ReviewAppeal(ctx, appealID, adminID, status, s.now()); err != nil {
+		if errors.Is(err, suspensionrepo.ErrAppealNotFound) {
+			return ErrAppealNotFound
+		}
+		return err
+	}
+	if !accept {
+		return nil
+	}
+
+	// The suspension may have expired or been lifted while the appeal waited
+	err = s.lift(ctx, &Suspension{ID: appeal.SuspensionID, UserID: appeal.UserID}, &adminID)
+	if errors.Is(err, ErrNotSuspended) {
+		return nil
+	}
+	return err
+}
+
+// SetRunObserver reports the outcome of every run of the poller
+func (s *SuspensionServiceImpl) SetRunObserver(observer RunObserver) {
+	s.runObserver = observer
+}
+
+// Run lifts expired suspensions every interval until the context is cancelled
+func (s *SuspensionServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.LiftExpired(ctx)
+		if s.runObserver != nil {
+			s.runObserver.ObserveRun(err)
+		}
+		if err != nil {
+			log.Printf("Failed to lift expired suspensions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LiftExpired lifts all suspensions that have ended and notifies the users.
+// Expired suspensions stop being enforced on their own; lifting closes them
+// so the user can be suspended again and gets a notification.
+func (s *SuspensionServiceImpl) LiftExpired(ctx context.Context) error {
+	for {
+		suspensions, err := s.repo.LiftExpiredSuspensions(ctx, s.now(), liftBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, suspension := range suspensions {
+			s.notifyLifted(suspension.UserID)
+		}
+		if len(suspensions) < liftBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *SuspensionServiceImpl) lift(ctx context.Context, suspension *Suspension, adminID *int) error {
+	if err := s.repo.LiftSuspension(ctx, suspension.ID, adminID, s.now()); err != nil {
+		if errors.Is(err, suspensionrepo.ErrSuspensionNotFound) {
+			return ErrNotSuspended
+		}
+		return err
+	}
+	s.notifyLifted(suspension.UserID)
+	return nil
+}
+
+func (s *SuspensionServiceImpl) notifyLifted(userID int) {
+	payload := push.NotificationPayload{
+		Title: "Доступ восстановлен",
+		Body:  "Блокировка аккаунта снята",
+		Sound: "default",
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+			log.Printf("Error sending push notification to user %d: %v", userID, err)
+		}
+	}()
+}
@@ -0,0 +1,289 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/database"
+	teamrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/team"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// Application statuses
+const (
+	ApplicationPending  = teamrepo.ApplicationPending
+	ApplicationAccepted = teamrepo.ApplicationAccepted
+	ApplicationRejected = teamrepo.ApplicationRejected
+)
+
+// Application represents a request to join a team
+type Application struct {
+	ID        int        `json:"id"`
+	TeamID    int        `json:"team_id"`
+	UserID    int        `json:"user_id"`
+	FullName  string     `json:"full_name"`
+	Message   string     `json:"message,omitempty"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+}
+
+// Apply creates an application from userID to join a team and notifies the team owners
+func (s *TeamServiceImpl) Apply(ctx context.Context, userID, teamID int, message string) (*Application, error) {
+	team, err := s.teamRepo.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	_, err = s.teamRepo.GetMember(ctx, teamID, userID)
+	if err == nil {
+		return nil, ErrAlreadyMember
+	}
+	if !errors.Is(err, teamrepo.ErrMemberNotFound) {
+		return nil, err
+	}
+
+	if team.OpenSlots == 0 {
+		return nil, ErrNoOpenSlots
+	}
+
+	model := &teamrepo.ApplicationModel{
+		TeamID:  teamID,
+		UserID:  userID,
+		Message: strings.TrimSpace(message),
+	}
+	if err := s.teamRepo.CreateApplication(ctx, model); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	app, err := s.teamRepo.GetApplication(ctx, model.ID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	s.notifyOwners(ctx, team, app)
+
+	result := convertToApplication(*app)
+	return &result, nil
+}
+
+// GetApplications lists the applications of a team; only owners may do this.
+// An empty status returns applications in any status.
+func (s *TeamServiceImpl) GetApplications(ctx context.Context, userID, teamID int, status string) ([]Application, error) {
+	if status != "" && !isValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
+	models, err := s.teamRepo.GetApplications(ctx, teamID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]Application, 0, len(models))
+	for _, m := range models {
+		apps = append(apps, convertToApplication(m))
+	}
+	return apps, nil
+}
+
+// AcceptApplication adds the applicant to the team and its group chat; only owners may do this
+func (s *TeamServiceImpl) AcceptApplication(ctx context.Context, userID, teamID, applicationID int) (*Application, error) {
+	team, app, err := s.getOwnedApplication(ctx, userID, teamID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.AcceptApplication(ctx, applicationID, userID); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	s.joinTeamChat(ctx, teamID, app.UserID)
+	s.recordActivity(ctx, feed.Activity{Type: feed.ActivityMemberJoined, UserID: &app.UserID, TeamID: &teamID})
+	s.notifyApplicant(team, app.UserID, "Your application was accepted")
+
+	return s.getApplication(ctx, applicationID)
+}
+
+// RejectApplication declines an application; only owners may do this
+func (s *TeamServiceImpl) RejectApplication(ctx context.Context, userID, teamID, applicationID int) (*Application, error) {
+	team, app, err := s.getOwnedApplication(ctx, userID, teamID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.RejectApplication(ctx, applicationID, userID); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	s.notifyApplicant(team, app.UserID, "Your application was declined")
+
+	return s.getApplication(ctx, applicationID)
+}
+
+// getOwnedApplication loads an application of teamID after checking that userID owns the team
+func (s *TeamServiceImpl) getOwnedApplication(ctx context.Context, userID, teamID, applicationID int) (*teamrepo.TeamModel, *teamrepo.ApplicationModel, error) {
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	team, err := s.teamRepo.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, nil, mapRepoError(err)
+	}
+
+	app, err := s.teamRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, nil, mapRepoError(err)
+	}
+	// Applications are addressed through their team
+	if app.TeamID != teamID {
+		return nil, nil, ErrApplicationNotFound
+	}
+	if app.Status != ApplicationPending {
+		return nil, nil, ErrApplicationDecided
+	}
+
+	return team, app, nil
+}
+
+func (s *TeamServiceImpl) getApplication(ctx context.Context, applicationID int) (*Application, error) {
+	app, err := s.teamRepo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	result := convertToApplication(*app)
+	return &result, nil
+}
+
+// joinTeamChat adds a new member to the team group chat, creating the chat
+// with all current members on first use. Chat failures do not undo the membership.
+func (s *TeamServiceImpl) joinTeamChat(ctx context.Context, teamID, userID int) {
+	chatID, err := s.teamRepo.GetTeamChat(ctx, teamID)
+	if err != nil {
+		log.Printf("Failed to get chat for team %d: %v", teamID, err)
+		return
+	}
+
+	if chatID != nil {
+		err := s.chatService.AddParticipant(*chatID, userID)
+		if err != nil && !database.IsUniqueViolation(err) {
+			log.Printf("Failed to add user %d to chat of team %d: %v", userID, teamID, err)
+		}
+		return
+	}
+
+	team, err := s.teamRepo.GetTeam(ctx, teamID)
+	if err != nil {
+		log.Printf("Failed to get team %d: %v", teamID, err)
+		return
+	}
+	members, err := s.teamRepo.GetMembers(ctx, teamID)
+	if err != nil || len(members) == 0 {
+		log.Printf("Failed to get members of team %d: %v", teamID, err)
+		return
+	}
+
+	participants := make([]int, 0, len(members))
+	for _, m := range members {
+		participants = append(participants, m.UserID)
+	}
+
+	// Members are ordered owners first, so the chat is created on behalf of an owner
+	newChatID := uuid.New().String()
+	if err := s.chatService.CreateMembersChat(ctx, newChatID, participants[0], team.Name, participants); err != nil {
+		log.Printf("Failed to create chat for team %d: %v", teamID, err)
+		return
+	}
+	if err := s.teamRepo.SetTeamChat(ctx, teamID, newChatID); err != nil {
+		log.Printf("Failed to link chat %s to team %d: %v", newChatID, teamID, err)
+	}
+}
+
+// leaveTeamChat removes a former member from the team group chat
+func (s *TeamServiceImpl) leaveTeamChat(ctx context.Context, teamID, userID int) {
+	chatID, err := s.teamRepo.GetTeamChat(ctx, teamID)
+	if err != nil {
+		log.Printf("Failed to get chat for team %d: %v", teamID, err)
+		return
+	}
+	if chatID == nil {
+		return
+	}
+	if err := s.chatService.RemoveParticipant(*chatID, userID); err != nil {
+		log.Printf("Failed to remove user %d from chat of team %d: %v", userID, teamID, err)
+	}
+}
+
+// notifyOwners sends a push notification about a new application to every team owner
+func (s *TeamServiceImpl) notifyOwners(ctx context.Context, team *teamrepo.TeamModel, app *teamrepo.ApplicationModel) {
+	members, err := s.teamRepo.GetMembers(ctx, team.ID)
+	if err != nil {
+		log.Printf("Failed to get owners of team %d for push notification: %v", team.ID, err)
+		return
+	}
+
+	body := "Someone wants to join your team"
+	if app.FullName != "" {
+		body = fmt.Sprintf("%s wants to join your team", app.FullName)
+	}
+	payload := push.NotificationPayload{
+		Title:    team.Name,
+		Body:     body,
+		Sound:    "default",
+		Category: push.CategoryTeamApplication,
+	}
+
+	for _, m := range members {
+		if m.Role == RoleOwner {
+			s.sendPush(m.UserID, payload)
+		}
+	}
+}
+
+// notifyApplicant sends a push notification about the decision on an application
+func (s *TeamServiceImpl) notifyApplicant(team *teamrepo.TeamModel, userID int, body string) {
+	s.sendPush(userID, push.NotificationPayload{
+		Title:    team.Name,
+		Body:     body,
+		Sound:    "default",
+		Category: push.CategoryTeamApplication,
+	})
+}
+
+func (s *TeamServiceImpl) sendPush(userID int, payload push.NotificationPayload) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.pushService.SendNotification(ctx, userID, payload); err != nil {
+			log.Printf("Error sending push notification to user %d: %v", userID, err)
+		}
+	}()
+}
+
+func convertToApplication(m teamrepo.ApplicationModel) Application {
+	return Application{
+		ID:        m.ID,
+		TeamID:    m.TeamID,
+		UserID:    m.UserID,
+		FullName:  m.FullName,
+		Message:   m.Message,
+		Status:    m.Status,
+		CreatedAt: m.CreatedAt,
+		DecidedAt: m.DecidedAt,
+	}
+}
+
+func isValidStatus(status string) bool {
+	return status == ApplicationPending || status == ApplicationAccepted || status == ApplicationRejected
+}
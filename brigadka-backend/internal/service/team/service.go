@@ -0,0 +1,597 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	mediarepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/media"
+	teamrepo "github.com/bulatminnakhmetov/brigadka-backend/internal/repository/team"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/feed"
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/service/push"
+)
+
+// Member roles
+const (
+	RoleOwner  = teamrepo.RoleOwner
+	RoleMember = teamrepo.RoleMember
+)
+
+// Возможные ошибки сервиса
+var (
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrNotTeamOwner       = errors.New("only team owners can do this")
+	ErrMemberNotFound     = errors.New("team member not found")
+	ErrAlreadyMember      = errors.New("user is already a team member")
+	ErrLastOwner          = errors.New("team must have at least one owner")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidName        = errors.New("invalid team name")
+	ErrInvalidRole        = errors.New("invalid team role")
+	ErrInvalidCity        = errors.New("invalid city")
+	ErrInvalidImprovStyle = errors.New("invalid improv style")
+	ErrInvalidOpenSlots   = errors.New("open slots must not be negative")
+	ErrInvalidAvatar      = errors.New("invalid avatar")
+
+	ErrApplicationNotFound = errors.New("team application not found")
+	ErrApplicationExists   = errors.New("user already has a pending application")
+	ErrApplicationDecided  = errors.New("team application is already decided")
+	ErrInvalidStatus       = errors.New("invalid application status")
+	ErrNoOpenSlots         = errors.New("team has no open slots")
+)
+
+// Media represents a team avatar
+type Media struct {
+	ID           int    `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// Team represents team data for response
+type Team struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	CityID       int       `json:"city_id"`
+	Bio          string    `json:"bio,omitempty"`
+	ImprovStyles []string  `json:"improv_styles,omitempty"`
+	OpenSlots    int       `json:"open_slots"`
+	MemberCount  int       `json:"member_count"`
+	Avatar       *Media    `json:"avatar,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Member represents a team member
+type Member struct {
+	UserID   int       `json:"user_id"`
+	FullName string    `json:"full_name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// CreateRequest represents data needed to create a team
+type CreateRequest struct {
+	Name         string
+	CityID       int
+	Bio          string
+	ImprovStyles []string
+	OpenSlots    int
+	Avatar       *int
+}
+
+// UpdateRequest represents data needed to update a team; nil fields are left unchanged
+type UpdateRequest struct {
+	Name         *string
+	CityID       *int
+	Bio          *string
+	ImprovStyles []string
+	OpenSlots    *int
+	Avatar       *int
+}
+
+// SearchFilter defines the filters for team searches
+type SearchFilter struct {
+	Name         *string
+	CityID       *int
+	ImprovStyles []string
+	HasOpenSlots *bool
+	Page         int
+	PageSize     int
+}
+
+// SearchResult represents the search results including pagination details
+type SearchResult struct {
+	Teams      []Team `json:"teams"`
+	TotalCount int    `json:"total_count"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+}
+
+type MediaRepository interface {
+	GetMediaByID(mediaID int) (*mediarepo.Media, error)
+}
+
+// ChatService manages the team group chat
+type ChatService interface {
+	CreateMembersChat(ctx context.Context, chatID string, creatorID int, chatName string, participants []int) error
+	AddParticipant(chatID string, userID int) error
+	RemoveParticipant(chatID string, userID int) error
+}
+
+// PushService notifies users about their applications
+type PushService interface {
+	SendNotification(ctx context.Context, userID int, payload push.NotificationPayload) error
+}
+
+// ActivityRecorder writes team events to the activity feed
+type ActivityRecorder interface {
+	Record(ctx context.Context, activity feed.Activity) error
+}
+
+// TeamServiceImpl implements team management
+type TeamServiceImpl struct {
+	teamRepo         teamrepo.Repository
+	mediaRepo        MediaRepository
+	chatService      ChatService
+	pushService      PushService
+	activityRecorder ActivityRecorder
+}
+
+// NewTeamService creates a new team service
+func NewTeamService(teamRepo teamrepo.Repository, mediaRepo MediaRepository, chatService ChatService, pushService PushService) *TeamServiceImpl {
+	return &TeamServiceImpl{
+		teamRepo:    teamRepo,
+		mediaRepo:   mediaRepo,
+		chatService: chatService,
+		pushService: pushService,
+	}
+}
+
+// SetActivityRecorder enables publishing team updates and new members to followers
+func (s *TeamServiceImpl) SetActivityRecorder(recorder ActivityRecorder) {
+	s.activityRecorder = recorder
+}
+
+// CreateTeam creates a team owned by userID
+func (s *TeamServiceImpl) CreateTeam(ctx context.Context, userID int, req CreateRequest) (*Team, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, ErrInvalidName
+	}
+	if req.OpenSlots < 0 {
+		return nil, ErrInvalidOpenSlots
+	}
+	if err := s.validateCity(ctx, req.CityID); err != nil {
+		return nil, err
+	}
+	if err := s.validateStyles(ctx, req.ImprovStyles); err != nil {
+		return nil, err
+	}
+	if req.Avatar != nil {
+		if err := s.validateAvatar(userID, *req.Avatar); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.teamRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	model := &teamrepo.TeamModel{
+		Name:          req.Name,
+		CityID:        req.CityID,
+		Bio:           req.Bio,
+		AvatarMediaID: req.Avatar,
+		OpenSlots:     req.OpenSlots,
+	}
+	if err = s.teamRepo.CreateTeam(ctx, tx, model, userID); err != nil {
+		return nil, err
+	}
+
+	if len(req.ImprovStyles) > 0 {
+		if err = s.teamRepo.SetTeamStyles(ctx, tx, model.ID, req.ImprovStyles); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, model.ID)
+}
+
+// GetTeam retrieves a team by ID
+func (s *TeamServiceImpl) GetTeam(ctx context.Context, teamID int) (*Team, error) {
+	model, err := s.teamRepo.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	styles, err := s.teamRepo.GetTeamStyles(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToTeam(model, styles), nil
+}
+
+// UpdateTeam updates a team; only owners may do this
+func (s *TeamServiceImpl) UpdateTeam(ctx context.Context, userID, teamID int, req UpdateRequest) (*Team, error) {
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrInvalidName
+		}
+		req.Name = &name
+	}
+	if req.OpenSlots != nil && *req.OpenSlots < 0 {
+		return nil, ErrInvalidOpenSlots
+	}
+	if req.CityID != nil {
+		if err := s.validateCity(ctx, *req.CityID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateStyles(ctx, req.ImprovStyles); err != nil {
+		return nil, err
+	}
+	if req.Avatar != nil {
+		if err := s.validateAvatar(userID, *req.Avatar); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.teamRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = s.teamRepo.UpdateTeam(ctx, tx, &teamrepo.UpdateTeamModel{
+		ID:        teamID,
+		Name:      req.Name,
+		CityID:    req.CityID,
+		Bio:       req.Bio,
+		OpenSlots: req.OpenSlots,
+	})
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	if req.ImprovStyles != nil {
+		if err = s.teamRepo.SetTeamStyles(ctx, tx, teamID, req.ImprovStyles); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Avatar != nil {
+		if err = s.teamRepo.SetTeamAvatar(ctx, tx, teamID, req.Avatar); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.recordActivity(ctx, feed.Activity{Type: feed.ActivityTeamUpdated, TeamID: &teamID})
+
+	return s.GetTeam(ctx, teamID)
+}
+
+// DeleteTeam deletes a team; only owners may do this
+func (s *TeamServiceImpl) DeleteTeam(ctx context.Context, userID, teamID int) error {
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return err
+	}
+	return mapRepoError(s.teamRepo.DeleteTeam(ctx, teamID))
+}
+
+// GetMembers lists the members of a team
+func (s *TeamServiceImpl) GetMembers(ctx context.Context, teamID int) ([]Member, error) {
+	if _, err := s.teamRepo.GetTeam(ctx, teamID); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	models, err := s.teamRepo.GetMembers(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]Member, 0, len(models))
+	for _, m := range models {
+		members = append(members, convertToMember(m))
+	}
+	return members, nil
+}
+
+// AddMember adds a user to a team; only owners may do this
+func (s *TeamServiceImpl) AddMember(ctx context.Context, userID, teamID, memberID int, role string) (*Member, error) {
+	if role == "" {
+		role = RoleMember
+	}
+	if !isValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.teamRepo.CheckUserExists(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.teamRepo.AddMember(ctx, teamID, memberID, role); err != nil {
+		return nil, mapRepoError(err)
+	}
+	s.joinTeamChat(ctx, teamID, memberID)
+	s.recordActivity(ctx, feed.Activity{Type: feed.ActivityMemberJoined, UserID: &memberID, TeamID: &teamID})
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, memberID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	result := convertToMember(*member)
+	return &result, nil
+}
+
+// UpdateMemberRole changes the role of a team member; only owners may do this
+func (s *TeamServiceImpl) UpdateMemberRole(ctx context.Context, userID, teamID, memberID int, role string) (*Member, error) {
+	if !isValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, memberID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	if member.Role == RoleOwner && role != RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, teamID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.teamRepo.UpdateMemberRole(ctx, teamID, memberID, role); err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	member.Role = role
+	result := convertToMember(*member)
+	return &result, nil
+}
+
+// RemoveMember removes a user from a team. Owners may remove anyone,
+// members may only remove themselves. The last owner cannot leave.
+func (s *TeamServiceImpl) RemoveMember(ctx context.Context, userID, teamID, memberID int) error {
+	if userID != memberID {
+		if err := s.requireOwner(ctx, teamID, userID); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, memberID)
+	if err != nil {
+		if errors.Is(err, teamrepo.ErrMemberNotFound) {
+			// Distinguish a missing team from a missing membership
+			if _, teamErr := s.teamRepo.GetTeam(ctx, teamID); teamErr != nil {
+				return mapRepoError(teamErr)
+			}
+		}
+		return mapRepoError(err)
+	}
+
+	if member.Role == RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, teamID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.teamRepo.RemoveMember(ctx, teamID, memberID); err != nil {
+		return mapRepoError(err)
+	}
+	s.leaveTeamChat(ctx, teamID, memberID)
+	return nil
+}
+
+// Search searches for teams with the given filters and sorts results by improv style matches
+func (s *TeamServiceImpl) Search(ctx context.Context, userID int, filter SearchFilter) (*SearchResult, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	models, totalCount, err := s.teamRepo.SearchTeams(ctx, teamrepo.SearchParams{
+		CurrentUserID: userID,
+		Name:          filter.Name,
+		CityID:        filter.CityID,
+		ImprovStyles:  filter.ImprovStyles,
+		HasOpenSlots:  filter.HasOpenSlots,
+		Page:          filter.Page,
+		PageSize:      filter.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Teams:      make([]Team, 0, len(models)),
+		TotalCount: totalCount,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+	}
+
+	for _, model := range models {
+		styles, err := s.teamRepo.GetTeamStyles(ctx, model.ID)
+		if err != nil {
+			log.Printf("Failed to get styles for team %d: %v", model.ID, err)
+		}
+		result.Teams = append(result.Teams, *s.convertToTeam(model, styles))
+	}
+
+	return result, nil
+}
+
+// recordActivity publishes an event to the feed; failures do not affect the team
+func (s *TeamServiceImpl) recordActivity(ctx context.Context, activity feed.Activity) {
+	if s.activityRecorder == nil {
+		return
+	}
+	if err := s.activityRecorder.Record(ctx, activity); err != nil {
+		log.Printf("Failed to record %s activity: %v", activity.Type, err)
+	}
+}
+
+// requireOwner returns ErrNotTeamOwner unless userID owns the team
+func (s *TeamServiceImpl) requireOwner(ctx context.Context, teamID, userID int) error {
+	member, err := s.teamRepo.GetMember(ctx, teamID, userID)
+	if errors.Is(err, teamrepo.ErrMemberNotFound) {
+		if _, err := s.teamRepo.GetTeam(ctx, teamID); err != nil {
+			return mapRepoError(err)
+		}
+		return ErrNotTeamOwner
+	}
+	if err != nil {
+		return err
+	}
+	if member.Role != RoleOwner {
+		return ErrNotTeamOwner
+	}
+	return nil
+}
+
+// ensureAnotherOwner fails if the team has a single owner
+func (s *TeamServiceImpl) ensureAnotherOwner(ctx context.Context, teamID int) error {
+	owners, err := s.teamRepo.CountOwners(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func (s *TeamServiceImpl) validateCity(ctx context.Context, cityID int) error {
+	valid, err := s.teamRepo.ValidateCity(ctx, cityID)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidCity
+	}
+	return nil
+}
+
+func (s *TeamServiceImpl) validateStyles(ctx context.Context, styles []string) error {
+	for _, style := range styles {
+		valid, err := s.teamRepo.ValidateImprovStyle(ctx, style)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return ErrInvalidImprovStyle
+		}
+	}
+	return nil
+}
+
+// validateAvatar checks that the media exists and was uploaded by userID
+func (s *TeamServiceImpl) validateAvatar(userID, mediaID int) error {
+	media, err := s.mediaRepo.GetMediaByID(mediaID)
+	if errors.Is(err, mediarepo.ErrMediaNotFound) {
+		return ErrInvalidAvatar
+	}
+	if err != nil {
+		return err
+	}
+	if media == nil || media.UserID != userID {
+		return ErrInvalidAvatar
+	}
+	return nil
+}
+
+func (s *TeamServiceImpl) convertToTeam(model *teamrepo.TeamModel, styles []string) *Team {
+	team := &Team{
+		ID:           model.ID,
+		Name:         model.Name,
+		CityID:       model.CityID,
+		Bio:          model.Bio,
+		ImprovStyles: styles,
+		OpenSlots:    model.OpenSlots,
+		MemberCount:  model.MemberCount,
+		CreatedAt:    model.CreatedAt,
+	}
+
+	if model.AvatarMediaID != nil {
+		media, err := s.mediaRepo.GetMediaByID(*model.AvatarMediaID)
+		if err != nil {
+			log.Printf("Failed to get avatar for team %d: %v", model.ID, err)
+		} else if media != nil {
+			team.Avatar = &Media{
+				ID:           media.ID,
+				URL:          media.URL,
+				ThumbnailURL: media.ThumbnailURL,
+			}
+		}
+	}
+
+	return team
+}
+
+func convertToMember(m teamrepo.MemberModel) Member {
+	return Member{
+		UserID:   m.UserID,
+		FullName: m.FullName,
+		Role:     m.Role,
+		JoinedAt: m.JoinedAt,
+	}
+}
+
+func isValidRole(role string) bool {
+	return role == RoleOwner || role == RoleMember
+}
+
+// mapRepoError translates repository errors into service errors
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, teamrepo.ErrTeamNotFound):
+		return ErrTeamNotFound
+	case errors.Is(err, teamrepo.ErrMemberNotFound):
+		return ErrMemberNotFound
+	case errors.Is(err, teamrepo.ErrAlreadyMember):
+		return ErrAlreadyMember
+	case errors.Is(err, teamrepo.ErrApplicationNotFound):
+		return ErrApplicationNotFound
+	case errors.Is(err, teamrepo.ErrApplicationExists):
+		return ErrApplicationExists
+	case errors.Is(err, teamrepo.ErrApplicationDecided):
+		return ErrApplicationDecided
+	default:
+		return err
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"io"
 	"mime/multipart"
 	"path/filepath"
+	"strings"
 
-	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
 )
 
 // MinioClient определяет интерфейс для работы с S3-совместимым хранилищем
@@ -17,6 +19,7 @@ type MinioClient interface {
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
 	PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (info minio.UploadInfo, err error)
 	RemoveObject(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error
+	GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (*minio.Object, error)
 }
 
 // S3StorageProvider представляет провайдер хранилища для S3-совместимых сервисов (включая Backblaze B2)
@@ -78,9 +81,9 @@ func NewS3StorageProvider(accessKeyID, secretAccessKey, endpoint, bucketName, cd
 func (s *S3StorageProvider) UploadFile(file multipart.File, fileName string) (string, error) {
 	ctx := context.Background()
 
-	// Генерируем уникальное имя файла, используя UUID
+	// Генерируем уникальное имя файла; ULID упорядочивает объекты по времени загрузки
 	extension := filepath.Ext(fileName)
-	uniqueFileName := fmt.Sprintf("%s/%s%s", s.uploadPath, uuid.New().String(), extension)
+	uniqueFileName := fmt.Sprintf("%s/%s%s", s.uploadPath, idgen.New(), extension)
 
 	// Определяем тип контента
 	contentType := ""
@@ -119,6 +122,38 @@ func (s *S3StorageProvider) DeleteFile(fileName string) error {
 	return nil
 }
 
+// DeleteFileByURL удаляет файл, загруженный через UploadFile, по его URL
+func (s *S3StorageProvider) DeleteFileByURL(fileURL string) error {
+	objectName, err := s.objectName(fileURL)
+	if err != nil {
+		return err
+	}
+	return s.DeleteFile(objectName)
+}
+
+// DownloadFile открывает файл, загруженный через UploadFile, по его URL
+func (s *S3StorageProvider) DownloadFile(fileURL string) (io.ReadCloser, error) {
+	objectName, err := s.objectName(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(context.Background(), s.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return object, nil
+}
+
+// objectName восстанавливает имя объекта в бакете по URL, который вернул GetFileURL
+func (s *S3StorageProvider) objectName(fileURL string) (string, error) {
+	prefix := s.GetFileURL("")
+	if !strings.HasPrefix(fileURL, prefix) || len(fileURL) == len(prefix) {
+		return "", fmt.Errorf("file %s is not stored in bucket %s", fileURL, s.bucketName)
+	}
+	return strings.TrimPrefix(fileURL, prefix), nil
+}
+
 // GetFileURL возвращает URL для доступа к файлу через Cloudflare CDN
 func (s *S3StorageProvider) GetFileURL(fileName string) string {
 	// Если указан CDN домен, используем его
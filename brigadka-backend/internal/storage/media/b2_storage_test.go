@@ -3,6 +3,7 @@ package media
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/url"
@@ -35,6 +36,14 @@ func (m *MockMinioClient) RemoveObject(ctx context.Context, bucketName string, o
 	return args.Error(0)
 }
 
+func (m *MockMinioClient) GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (*minio.Object, error) {
+	args := m.Called(ctx, bucketName, objectName, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*minio.Object), args.Error(1)
+}
+
 func (m *MockMinioClient) PresignedGetObject(ctx context.Context, bucketName string, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error) {
 	args := m.Called(ctx, bucketName, objectName, expires, reqParams)
 	if args.Get(0) == nil {
@@ -264,3 +273,63 @@ func TestGetFileURL(t *testing.T) {
 		assert.Equal(t, "https://s3.example.com/test-bucket/media/test-file.jpg", url)
 	})
 }
+
+// TestDownloadFile проверяет, что файл ищется в бакете по имени объекта из URL
+func TestDownloadFile(t *testing.T) {
+	t.Run("object name from CDN URL", func(t *testing.T) {
+		mockClient := new(MockMinioClient)
+		provider := &S3StorageProvider{
+			client:     mockClient,
+			bucketName: "test-bucket",
+			cdnDomain:  "cdn.example.com",
+			uploadPath: "media",
+		}
+
+		mockClient.On("GetObject", mock.Anything, "test-bucket", "media/01HZX.jpg", minio.GetObjectOptions{}).
+			Return(nil, errors.New("not found"))
+
+		_, err := provider.DownloadFile("https://cdn.example.com/media/01HZX.jpg")
+
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("foreign URL", func(t *testing.T) {
+		mockClient := new(MockMinioClient)
+		provider := &S3StorageProvider{
+			client:     mockClient,
+			bucketName: "test-bucket",
+			cdnDomain:  "cdn.example.com",
+			uploadPath: "media",
+		}
+
+		_, err := provider.DownloadFile("https://other.example.com/media/01HZX.jpg")
+
+		assert.Error(t, err)
+		mockClient.AssertNotCalled(t, "GetObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteFileByURL(t *testing.T) {
+	mockClient := new(MockMinioClient)
+	provider := &S3StorageProvider{
+		client:     mockClient,
+		bucketName: "test-bucket",
+		cdnDomain:  "cdn.example.com",
+		uploadPath: "media",
+	}
+
+	mockClient.On("RemoveObject", mock.Anything, "test-bucket", "media/01HZX.jpg", mock.Anything).Return(nil)
+
+	assert.NoError(t, provider.DeleteFileByURL("https://cdn.example.com/media/01HZX.jpg"))
+	assert.Error(t, provider.DeleteFileByURL("https://other.example.com/media/01HZX.jpg"))
+	mockClient.AssertNumberOfCalls(t, "RemoveObject", 1)
+}
+
+func TestGCSFileURL(t *testing.T) {
+	provider := &S3StorageProvider{
+		bucketName: "test-bucket",
+		endpoint:   gcsEndpoint,
+	}
+	assert.Equal(t, "https://storage.googleapis.com/test-bucket/media/a.jpg", provider.GetFileURL("media/a.jpg"))
+}
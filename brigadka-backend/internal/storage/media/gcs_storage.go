@@ -0,0 +1,11 @@
+package media
+
+// gcsEndpoint — S3-совместимый XML API Google Cloud Storage
+const gcsEndpoint = "storage.googleapis.com"
+
+// NewGCSStorageProvider создает хранилище в бакете Google Cloud Storage. GCS работает через
+// S3-совместимый API с HMAC-ключом сервисного аккаунта, поэтому используется S3StorageProvider.
+// Без CDN файлы доступны по адресу https://storage.googleapis.com/<бакет>/<файл>.
+func NewGCSStorageProvider(hmacAccessID, hmacSecret, bucketName, cdnDomain, uploadPath string) (*S3StorageProvider, error) {
+	return NewS3StorageProvider(hmacAccessID, hmacSecret, gcsEndpoint, bucketName, cdnDomain, uploadPath, "")
+}
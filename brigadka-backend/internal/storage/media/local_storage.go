@@ -0,0 +1,134 @@
+package media
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bulatminnakhmetov/brigadka-backend/internal/idgen"
+)
+
+// LocalStorageProvider хранит файлы в каталоге на диске — для локальной разработки и тестов
+// без облачного хранилища. Файлы раздает Handler, смонтированный по адресу baseURL.
+type LocalStorageProvider struct {
+	dir        string // Корневой каталог хранилища
+	baseURL    string // URL, по которому раздается каталог, без завершающего "/"
+	uploadPath string // Подкаталог для загрузок
+}
+
+// NewLocalStorageProvider создает хранилище в каталоге dir, создавая его при необходимости
+func NewLocalStorageProvider(dir, baseURL, uploadPath string) (*LocalStorageProvider, error) {
+	if err := os.MkdirAll(filepath.Join(dir, uploadPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStorageProvider{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		uploadPath: uploadPath,
+	}, nil
+}
+
+// UploadFile сохраняет файл под уникальным именем
+func (s *LocalStorageProvider) UploadFile(file multipart.File, fileName string) (string, error) {
+	objectName := path.Join(s.uploadPath, idgen.New()+filepath.Ext(fileName))
+	filePath, err := s.filePath(objectName)
+	if err != nil {
+		return "", err
+	}
+
+	dst, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	if _, err := io.Copy(dst, file); err != nil {
+		dst.Close()
+		os.Remove(filePath)
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return s.GetFileURL(objectName), nil
+}
+
+// DeleteFile удаляет файл из хранилища
+func (s *LocalStorageProvider) DeleteFile(fileName string) error {
+	filePath, err := s.filePath(fileName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// DeleteFileByURL удаляет файл, загруженный через UploadFile, по его URL
+func (s *LocalStorageProvider) DeleteFileByURL(fileURL string) error {
+	objectName, err := s.objectName(fileURL)
+	if err != nil {
+		return err
+	}
+	return s.DeleteFile(objectName)
+}
+
+// DownloadFile открывает файл, загруженный через UploadFile, по его URL
+func (s *LocalStorageProvider) DownloadFile(fileURL string) (io.ReadCloser, error) {
+	objectName, err := s.objectName(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	filePath, err := s.filePath(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return file, nil
+}
+
+// GetFileURL возвращает URL файла: baseURL и имя файла
+func (s *LocalStorageProvider) GetFileURL(fileName string) string {
+	return s.baseURL + "/" + fileName
+}
+
+// Handler раздает файлы хранилища; его нужно смонтировать по пути из baseURL, отрезав этот путь.
+// Списки файлов каталогов не отдаются.
+func (s *LocalStorageProvider) Handler() http.Handler {
+	files := http.FileServer(http.Dir(s.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// objectName восстанавливает имя файла по URL, который вернул GetFileURL
+func (s *LocalStorageProvider) objectName(fileURL string) (string, error) {
+	prefix := s.GetFileURL("")
+	if !strings.HasPrefix(fileURL, prefix) || len(fileURL) == len(prefix) {
+		return "", fmt.Errorf("file %s is not stored in %s", fileURL, s.dir)
+	}
+	return strings.TrimPrefix(fileURL, prefix), nil
+}
+
+// filePath возвращает путь к файлу на диске, не выпуская имена вроде "../x" за пределы каталога
+func (s *LocalStorageProvider) filePath(objectName string) (string, error) {
+	if !fs.ValidPath(objectName) || objectName == "." {
+		return "", fmt.Errorf("invalid file name %q", objectName)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(objectName)), nil
+}
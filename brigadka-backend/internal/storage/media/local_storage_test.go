@@ -0,0 +1,71 @@
+package media
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorageProvider {
+	provider, err := NewLocalStorageProvider(t.TempDir(), "http://localhost:8080/files/", "media")
+	require.NoError(t, err)
+	return provider
+}
+
+func TestLocalStorageUploadDownloadDelete(t *testing.T) {
+	provider := newTestLocalStorage(t)
+
+	fileURL, err := provider.UploadFile(&mockMultipartFile{
+		Reader: bytes.NewReader([]byte("test file content")),
+		Closer: io.NopCloser(nil),
+	}, "photo.jpg")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fileURL, "http://localhost:8080/files/media/"))
+	assert.True(t, strings.HasSuffix(fileURL, ".jpg"))
+
+	file, err := provider.DownloadFile(fileURL)
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	file.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "test file content", string(content))
+
+	require.NoError(t, provider.DeleteFileByURL(fileURL))
+	_, err = provider.DownloadFile(fileURL)
+	assert.Error(t, err)
+}
+
+func TestLocalStorageRejectsForeignPaths(t *testing.T) {
+	provider := newTestLocalStorage(t)
+
+	_, err := provider.DownloadFile("https://other.example.com/media/a.jpg")
+	assert.Error(t, err)
+	_, err = provider.DownloadFile("http://localhost:8080/files/../secret.txt")
+	assert.Error(t, err)
+	assert.Error(t, provider.DeleteFile("/etc/passwd"))
+}
+
+func TestLocalStorageHandler(t *testing.T) {
+	provider := newTestLocalStorage(t)
+	require.NoError(t, os.WriteFile(filepath.Join(provider.dir, "media", "a.txt"), []byte("hello"), 0o644))
+
+	handler := http.StripPrefix("/files", provider.Handler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/media/a.txt", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello", rec.Body.String())
+
+	// Directory listings are not served
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/media/", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}